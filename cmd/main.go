@@ -2,10 +2,15 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 
 	"github.com/go-chi/chi/v5"
@@ -14,108 +19,736 @@ import (
 	auth "github.com/iden3/go-iden3-auth/v2"
 	"github.com/iden3/go-iden3-auth/v2/loaders"
 	"github.com/iden3/go-iden3-auth/v2/pubsignals"
-	"github.com/iden3/go-iden3-auth/v2/state"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/patrickmn/go-cache"
+	"github.com/piprate/json-gold/ld"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 
 	"github.com/0xPolygonID/verifier-backend/internal/api"
+	"github.com/0xPolygonID/verifier-backend/internal/audit"
+	"github.com/0xPolygonID/verifier-backend/internal/circuitkeys"
 	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/configcheck"
+	"github.com/0xPolygonID/verifier-backend/internal/confirmations"
 	"github.com/0xPolygonID/verifier-backend/internal/errors"
+	"github.com/0xPolygonID/verifier-backend/internal/events"
+	"github.com/0xPolygonID/verifier-backend/internal/grpcapi"
+	"github.com/0xPolygonID/verifier-backend/internal/hooks"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/keystore"
+	"github.com/0xPolygonID/verifier-backend/internal/kvcache"
 	"github.com/0xPolygonID/verifier-backend/internal/loader"
+	"github.com/0xPolygonID/verifier-backend/internal/logging"
+	"github.com/0xPolygonID/verifier-backend/internal/nullifier"
+	"github.com/0xPolygonID/verifier-backend/internal/objectstore"
+	"github.com/0xPolygonID/verifier-backend/internal/oidc"
+	"github.com/0xPolygonID/verifier-backend/internal/policy"
+	"github.com/0xPolygonID/verifier-backend/internal/resolver"
+	"github.com/0xPolygonID/verifier-backend/internal/sessions"
+	"github.com/0xPolygonID/verifier-backend/internal/shadow"
+	"github.com/0xPolygonID/verifier-backend/internal/shortener"
+	"github.com/0xPolygonID/verifier-backend/internal/signing"
+	"github.com/0xPolygonID/verifier-backend/internal/stateresolver"
+	"github.com/0xPolygonID/verifier-backend/internal/statesnapshot"
+	"github.com/0xPolygonID/verifier-backend/internal/stats"
+	"github.com/0xPolygonID/verifier-backend/internal/testmode"
+	"github.com/0xPolygonID/verifier-backend/internal/timing"
+	"github.com/0xPolygonID/verifier-backend/internal/users"
+	"github.com/0xPolygonID/verifier-backend/internal/webhook"
 )
 
 func main() {
 	ctx := context.Background()
+	validateConfig := flag.Bool("validate-config", false, "check the configuration, print a report and exit with a non-zero code if a check failed")
+	flag.Parse()
+	if *validateConfig {
+		os.Exit(validate(ctx))
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		log.WithField("error", err).Error("cannot load config")
 		return
 	}
 
+	if cfg.LogFormat == config.LogFormatJSON {
+		log.SetFormatter(&log.JSONFormatter{})
+	}
+
+	trustedProxies, err := config.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.WithField("error", err).Error("invalid trusted proxies")
+		return
+	}
+
 	mux := chi.NewRouter()
 
 	mux.Use(
 		chiMiddleware.RequestID,
+		api.ClientAddr,
+		api.ForwardedURL(trustedProxies),
+		logging.Middleware(log.StandardLogger()),
 		chiMiddleware.Recoverer,
-		cors.Handler(cors.Options{AllowedOrigins: []string{"*"}}),
-		chiMiddleware.NoCache,
+		cors.Handler(cors.Options{AllowedOrigins: cfg.CORS.AllowedOrigins}),
+		api.NoCache,
+		chiMiddleware.GetHead,
+		i18n.Middleware,
 	)
 
-	keysLoader := &loaders.FSKeyLoader{Dir: cfg.KeyDIR}
-	w3cLoader := loader.NewW3CDocumentLoader(nil, cfg.IPFSURL)
-	resolvers, senderDIDs, err := parseResolverSettings(ctx, cfg.ResolverSettings)
+	keysLocation := cfg.VerificationKeys.Location
+	if keysLocation == "" {
+		keysLocation = cfg.KeyDIR
+	}
+	keysSource, err := circuitkeys.NewSource(keysLocation, cfg.VerificationKeys.S3Region, cfg.VerificationKeys.S3Endpoint)
+	if err != nil {
+		log.WithField("error", err).Error("invalid verification keys location")
+		return
+	}
+	keysLoader := circuitkeys.NewLoader(keysSource, cfg.VerificationKeys.CacheDir, cfg.VerificationKeys.Checksums)
+	schemaAllowlist, err := policy.NewSchemaAllowlist(cfg.SchemaAllowlist)
+	if err != nil {
+		log.WithField("error", err).Error("cannot create schema allowlist")
+		return
+	}
+	loaderOpts := []loader.Option{
+		loader.WithSchemaAllowlist(schemaAllowlist),
+		loader.WithGuard(loader.Guard{
+			Schemes:      cfg.DocumentFetch.Schemes,
+			AllowPrivate: cfg.DocumentFetch.AllowPrivateNetworks,
+			MaxRedirects: cfg.DocumentFetch.MaxRedirects,
+			Timeout:      cfg.DocumentFetch.Timeout.AsDuration(),
+		}),
+	}
+	if len(cfg.DocumentFetch.Hosts) > 0 {
+		loaderOpts = append(loaderOpts, loader.WithHosts(documentHosts(cfg.DocumentFetch.Hosts)))
+	}
+	if cfg.DocumentCache.Location != "" {
+		store, err := objectstore.New(cfg.DocumentCache.Location, cfg.DocumentCache.S3Region, cfg.DocumentCache.S3Endpoint)
+		if err != nil {
+			log.WithField("error", err).Error("invalid document cache location")
+			return
+		}
+		log.WithField("location", store.Location()).Info("caching json-ld documents")
+		loaderOpts = append(loaderOpts, loader.WithStore(store, cfg.DocumentCache.TTL.AsDuration()))
+	}
+	loaderOpts = append(loaderOpts, loader.WithMemoryCache(cfg.DocumentCache.MemorySize, cfg.DocumentCache.MemoryTTLDurations(),
+		cfg.DocumentCache.NegativeTTL.AsDuration()))
+	ipfsGateways := cfg.IPFSGateways
+	if len(ipfsGateways) == 0 {
+		ipfsGateways = []string{cfg.IPFSURL}
+	}
+	w3cLoader := loader.NewW3CDocumentLoader(loader.NewIPFS(cfg.IPFSNodeURL, ipfsGateways), loaderOpts...)
+	resolverOpts := stateresolver.Options{
+		CacheTTL:         cfg.StateResolver.CacheTTL.AsDuration(),
+		ReplacedCacheTTL: cfg.StateResolver.ReplacedCacheTTL.AsDuration(),
+		Retries:          cfg.StateResolver.Retries,
+		Backoff:          cfg.StateResolver.RetryBackoff.AsDuration(),
+		Timeout:          cfg.StateResolver.Timeout.AsDuration(),
+		BreakerThreshold: cfg.StateResolver.BreakerThreshold,
+		BreakerCooldown:  cfg.StateResolver.BreakerCooldown.AsDuration(),
+	}
+	resolvers, rpcs, senderDIDs, err := parseResolverSettings(ctx, cfg.ResolverSettings, resolverOpts, cfg.StateSnapshot)
 	if err != nil {
 		log.WithField("error", err).Error("cannot parse resolver settings")
 		return
 	}
 
-	verifier, err := auth.NewVerifier(keysLoader, resolvers, auth.WithDocumentLoader(w3cLoader))
+	if cfg.SenderDID.CheckState {
+		if err := checkSenderDIDStates(ctx, cfg.ResolverSettings, rpcs); err != nil {
+			log.WithField("error", err).Error("invalid sender did")
+			return
+		}
+	}
+
+	if cfg.DIDResolver.URL != "" {
+		didResolver := resolver.New(cfg.DIDResolver.URL, cfg.DIDResolver.CacheTTL.AsDuration())
+		if err := validateSenderDIDs(ctx, didResolver, senderDIDs, cfg.SenderDID); err != nil {
+			log.WithField("error", err).Error("invalid sender did")
+			return
+		}
+	}
+
+	verifier, err := auth.NewVerifier(keysLoader, timing.WrapResolvers(resolvers), auth.WithDocumentLoader(w3cLoader))
 	if err != nil {
 		log.WithFields(log.Fields{"err": err}).Error("failed to create verifier")
 		return
 	}
 
-	apiServer := api.New(*cfg, verifier, senderDIDs)
-	api.HandlerFromMux(api.NewStrictHandlerWithOptions(apiServer, nil,
-		api.StrictHTTPServerOptions{RequestErrorHandlerFunc: errors.RequestErrorHandlerFunc}), mux)
+	issuerPolicy, err := policy.NewIssuerPolicy(cfg.IssuerPolicy)
+	if err != nil {
+		log.WithField("error", err).Error("cannot create issuer policy")
+		return
+	}
+
+	keys, err := signing.NewKeyRing(*cfg)
+	if err != nil {
+		log.WithField("error", err).Error("cannot load signing keys")
+		return
+	}
+
+	verifierKeys, err := keystore.New(ctx, *cfg)
+	if err != nil {
+		log.WithField("error", err).Error("cannot load verifier keys")
+		return
+	}
+
+	if cfg.JWT.Enabled {
+		if _, err := keys.Key(""); err != nil {
+			log.WithField("error", err).Error("a signing key is required to issue tokens")
+			return
+		}
+	}
+
+	opts := []api.Option{api.WithIssuerPolicy(issuerPolicy), api.WithSchemaAllowlist(schemaAllowlist), api.WithKeyRing(keys), api.WithKeystore(verifierKeys), api.WithLogger(log.StandardLogger()), api.WithCircuitKeys(keysLoader), api.WithDocumentPinner(w3cLoader), api.WithQueryBuilder(w3cLoader)}
+	if cfg.QueryLint {
+		opts = append(opts, api.WithQueryLinter(w3cLoader))
+	}
+	if cfg.QueryTemplatesPath != "" {
+		templates, err := api.OpenQueryTemplateFile(cfg.QueryTemplatesPath)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err, "path": cfg.QueryTemplatesPath}).Error("failed to open query templates")
+			return
+		}
+		opts = append(opts, api.WithQueryTemplates(templates))
+	}
+	if cfg.Shadow.KeyDIR != "" {
+		shadowVerifier, err := newShadowVerifier(ctx, cfg.Shadow, resolvers, resolverOpts, w3cLoader)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("failed to create shadow verifier")
+			return
+		}
+		log.WithField("keydir", cfg.Shadow.KeyDIR).Info("shadow verification enabled")
+		opts = append(opts, api.WithShadowVerifier(shadowVerifier))
+	}
+
+	if cfg.Nullifiers.Enabled {
+		var storage nullifier.Storage = nullifier.NewMemoryStorage()
+		if cfg.Nullifiers.RegistryPath != "" {
+			fileStorage, err := nullifier.OpenFileStorage(cfg.Nullifiers.RegistryPath)
+			if err != nil {
+				log.WithFields(log.Fields{"err": err, "path": cfg.Nullifiers.RegistryPath}).Error("failed to open nullifier registry")
+				return
+			}
+			defer fileStorage.Close()
+			storage = fileStorage
+		}
+		registry, err := nullifier.NewRegistry(ctx, storage)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err}).Error("failed to create nullifier registry")
+			return
+		}
+		go registry.Run(ctx, cfg.Nullifiers.CheckpointInterval.AsDuration())
+		opts = append(opts, api.WithNullifierRegistry(registry))
+	}
+
+	if cfg.UserRegistry.Enabled {
+		var registry users.Registry = users.NewMemoryRegistry()
+		if cfg.UserRegistry.Path != "" {
+			fileRegistry, err := users.OpenFileRegistry(cfg.UserRegistry.Path)
+			if err != nil {
+				log.WithFields(log.Fields{"err": err, "path": cfg.UserRegistry.Path}).Error("failed to open user registry")
+				return
+			}
+			defer fileRegistry.Close()
+			registry = fileRegistry
+		}
+		opts = append(opts, api.WithUserRegistry(registry))
+	}
+
+	if cfg.AuditLog.Enabled {
+		var auditLog audit.Log = audit.NewMemoryLog(cfg.AuditLog.MemorySize)
+		if cfg.AuditLog.Path != "" {
+			fileLog, err := audit.OpenFileLog(cfg.AuditLog.Path)
+			if err != nil {
+				log.WithFields(log.Fields{"err": err, "path": cfg.AuditLog.Path}).Error("failed to open audit log")
+				return
+			}
+			defer fileLog.Close()
+			auditLog = fileLog
+		}
+		opts = append(opts, api.WithAuditLog(auditLog))
+	}
+
+	if _, err := nullifier.LookupDerivation(cfg.Nullifiers.Derivation); err != nil {
+		log.WithField("err", err).Error("invalid nullifier session derivation")
+		return
+	}
+
+	if cfg.Nullifiers.StorePath != "" {
+		store, err := nullifier.OpenFileStore(cfg.Nullifiers.StorePath)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err, "path": cfg.Nullifiers.StorePath}).Error("failed to open nullifier store")
+			return
+		}
+		defer store.Close()
+		opts = append(opts, api.WithNullifierStore(store))
+	}
+
+	var kv kvcache.Cache
+	switch cfg.QRStore.Driver {
+	case config.QRStoreDriverRedis:
+		log.WithField("addr", cfg.QRStore.RedisAddr).Info("storing qr codes in redis")
+		kv = kvcache.NewRedis(cfg.QRStore.RedisAddr, cfg.QRStore.RedisPassword, cfg.QRStore.RedisDB)
+	case config.QRStoreDriverMemcached:
+		log.WithField("addrs", cfg.QRStore.MemcachedAddrs).Info("storing qr codes in memcached")
+		kv = kvcache.NewMemcached(cfg.QRStore.MemcachedAddrs)
+	}
+	if kv != nil {
+		if len(cfg.QRStore.EncryptionKeys) > 0 {
+			dataKeys, err := cfg.QRStore.DataKeys()
+			if err != nil {
+				log.WithField("error", err).Error("invalid qr store encryption keys")
+				return
+			}
+			if kv, err = kvcache.NewEncrypted(kv, dataKeys); err != nil {
+				log.WithField("error", err).Error("cannot encrypt the qr store")
+				return
+			}
+			log.Info("encrypting the values of the qr store")
+		}
+		opts = append(opts, api.WithQRCache(kv), api.WithStatusCache(kv), api.WithAPIKeyCache(kv))
+	}
+
+	if cfg.QRLink.ShortenerURL != "" {
+		opts = append(opts, api.WithURLShortener(shortener.New(cfg.QRLink.ShortenerURL)))
+	}
+
+	var statsStore objectstore.Store
+	if cfg.Stats.Location != "" {
+		if statsStore, err = objectstore.New(cfg.Stats.Location, cfg.Stats.S3Region, cfg.Stats.S3Endpoint); err != nil {
+			log.WithField("error", err).Error("invalid stats location")
+			return
+		}
+		log.WithField("location", statsStore.Location()).Info("persisting verification stats")
+	}
+	statsTracker := stats.NewTracker(statsStore)
+	statsCtx, stopStats := context.WithCancel(ctx)
+	statsDone := make(chan struct{})
+	go func() {
+		statsTracker.Run(statsCtx, cfg.Stats.FlushInterval.AsDuration())
+		close(statsDone)
+	}()
+	opts = append(opts, api.WithStats(statsTracker))
+
+	var ledger sessions.Ledger = sessions.NewMemoryLedger()
+	if cfg.SessionLedger.Path != "" {
+		fileLedger, err := sessions.OpenFileLedger(cfg.SessionLedger.Path)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err, "path": cfg.SessionLedger.Path}).Error("failed to open session ledger")
+			return
+		}
+		defer fileLedger.Close()
+		ledger = fileLedger
+	}
+	reaperCtx, stopReaper := context.WithCancel(ctx)
+	defer stopReaper()
+	go sessions.RunReaper(reaperCtx, ledger, cfg.SessionLedger.Retention.AsDuration(), cfg.SessionLedger.ReapInterval.AsDuration(), log.StandardLogger())
+	opts = append(opts, api.WithSessionLedger(ledger))
+
+	if cfg.Events.Driver != "" {
+		publisher, err := events.New(cfg.Events)
+		if err != nil {
+			log.WithField("error", err).Error("invalid events broker")
+			return
+		}
+		bus := events.NewBus(publisher, cfg.Events.QueueSize)
+		defer bus.Close()
+		log.WithFields(log.Fields{"driver": cfg.Events.Driver, "topic": cfg.Events.Topic}).Info("publishing session events")
+		opts = append(opts, api.WithEvents(bus))
+	}
+
+	if cfg.SessionWebhook.DeadLetterPath != "" {
+		deadLetters, err := webhook.OpenFileDeadLetters(cfg.SessionWebhook.DeadLetterPath, cfg.SessionWebhook.DeadLetterSize)
+		if err != nil {
+			log.WithFields(log.Fields{"err": err, "path": cfg.SessionWebhook.DeadLetterPath}).Error("failed to open webhook dead letters")
+			return
+		}
+		defer deadLetters.Close()
+		opts = append(opts, api.WithWebhookDeadLetters(deadLetters))
+	}
+
+	verificationHooks, err := hooks.Lookup(cfg.VerificationHooks.Names)
+	if err != nil {
+		log.WithField("error", err).Error("invalid verification hooks")
+		return
+	}
+	if cfg.VerificationHooks.URL != "" {
+		verificationHooks = append(verificationHooks, hooks.NewHTTP(cfg.VerificationHooks.URL, cfg.VerificationHooks.Secret,
+			cfg.VerificationHooks.Timeout.AsDuration()))
+	}
+	if len(verificationHooks) > 0 {
+		log.WithFields(log.Fields{"hooks": cfg.VerificationHooks.Names, "url": cfg.VerificationHooks.URL}).Info("running verification hooks")
+		opts = append(opts, api.WithVerificationHooks(verificationHooks...))
+	}
+
+	opts = append(opts, api.WithNetworkRPCs(rpcs))
+	if cfg.Reverification.Enabled {
+		if cfg.Reverification.After.AsDuration() >= cfg.CacheExpiration.AsDuration() {
+			log.WithFields(log.Fields{"after": cfg.Reverification.After.AsDuration(), "cacheExpiration": cfg.CacheExpiration.AsDuration()}).
+				Warn("verifications are removed from the cache before their re-verification")
+		}
+		opts = append(opts, api.WithStateResolvers(resolvers))
+	}
+
+	var apiVerifier api.Verifier = verifier
+	if cfg.TestMode.Enabled {
+		mock, err := testmode.NewVerifier(cfg.TestMode.Token, cfg.TestMode.UserDID, cfg.TestMode.IssuerDID)
+		if err != nil {
+			log.WithField("error", err).Error("cannot create the test mode verifier")
+			return
+		}
+		log.WithFields(log.Fields{"userDID": cfg.TestMode.UserDID, "issuerDID": cfg.TestMode.IssuerDID}).
+			Warn("test mode enabled, callbacks with the canned token are accepted without proofs")
+		apiVerifier = mock
+	}
+
+	apiServer := api.New(*cfg, apiVerifier, senderDIDs, opts...)
+	// the webhook deliveries waiting for a retry are moved to the dead letters before they are closed
+	defer apiServer.Close()
+	if cfg.DocumentCache.Prewarm {
+		go apiServer.Prewarm(ctx)
+	}
+	if cfg.Reverification.Enabled && !cfg.ReadOnly {
+		go apiServer.RunReverification(ctx)
+	}
+	if cfg.VerificationAsync && cfg.VerificationConcurrency > 0 {
+		go apiServer.RunVerificationWorkers(ctx)
+	}
+	if cfg.ReadOnly {
+		log.Info("serving as a read-only replica")
+		mux.Use(api.ReadOnly)
+	}
+	if len(cfg.ManagementAuth.HMACSecrets) > 0 || cfg.ManagementAuth.ClientCAPath != "" {
+		// the signatures are shared by the replicas through the qr store, when it is not kept in memory
+		var seen kvcache.Cache = cache.New(cfg.ManagementAuth.ReplayWindow.AsDuration(), cfg.ManagementAuth.ReplayWindow.AsDuration())
+		if kv != nil {
+			seen = kv
+		}
+		mux.Use(api.ManagementAuth(cfg.ManagementAuth, cfg.Limits.MaxBodySize, seen))
+	}
+	mux.Use(apiServer.Roles)
+	api.HandlerWithOptions(api.NewStrictHandlerWithOptions(apiServer, nil,
+		api.StrictHTTPServerOptions{RequestErrorHandlerFunc: errors.RequestErrorHandlerFunc}), api.ChiServerOptions{
+		BaseRouter:  mux,
+		Middlewares: []api.MiddlewareFunc{api.BodyLimits(cfg.Limits.MaxBodySize)},
+	})
 	api.RegisterStatic(mux)
+	apiServer.RegisterRoutes(mux)
+
+	if len(cfg.OIDC.Clients) > 0 {
+		provider, err := oidc.New(*cfg, apiServer, keys)
+		if err != nil {
+			log.WithField("error", err).Error("a signing key is required by the oidc provider")
+			return
+		}
+		provider.Register(mux)
+	}
 
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%s", cfg.ApiPort),
 		Handler: mux,
 	}
+	if cfg.ManagementAuth.TLSCertPath != "" || cfg.ManagementAuth.ClientCAPath != "" {
+		if server.TLSConfig, err = api.ManagementTLSConfig(cfg.ManagementAuth); err != nil {
+			log.WithField("error", err).Error("invalid management client certificate authorities")
+			return
+		}
+	}
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
 		log.WithField("port", cfg.ApiPort).Info("server started")
-		if err := server.ListenAndServe(); err != nil {
+		var err error
+		if cfg.ManagementAuth.TLSCertPath != "" {
+			err = server.ListenAndServeTLS(cfg.ManagementAuth.TLSCertPath, cfg.ManagementAuth.TLSKeyPath)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil {
 			log.WithField("error", err).Error("starting http server")
 		}
 	}()
 
+	if cfg.GRPCPort != "" {
+		var grpcOpts []grpc.ServerOption
+		if cfg.ReadOnly {
+			grpcOpts = append(grpcOpts, grpc.ChainUnaryInterceptor(grpcapi.ReadOnly))
+		}
+		grpcServer := grpcapi.NewServer(apiServer, grpcOpts...)
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPCPort))
+		if err != nil {
+			log.WithField("error", err).Error("cannot listen on the grpc port")
+			return
+		}
+		defer grpcServer.GracefulStop()
+		go func() {
+			log.WithField("port", cfg.GRPCPort).Info("grpc server started")
+			if err := grpcServer.Serve(lis); err != nil {
+				log.WithField("error", err).Error("starting grpc server")
+			}
+		}()
+	}
+
 	<-quit
 	log.Info("Shutting down")
+	stopStats()
+	<-statsDone
 }
 
-// parseResolverSettings parses the resolver settings from the config file
-func parseResolverSettings(ctx context.Context, rs config.ResolverSettings) (map[string]pubsignals.StateResolver, map[string]string, error) {
+// parseResolverSettings parses the resolver settings from the config file. With the state snapshots enabled, the states
+// of each network are answered from its snapshot, synced until ctx is done. The clients of the RPC urls of the networks
+// are returned by blockchain:network, for the other reads of the networks.
+func parseResolverSettings(ctx context.Context, rs config.ResolverSettings, opts stateresolver.Options,
+	snapshots config.StateSnapshot,
+) (map[string]pubsignals.StateResolver, map[string]*stateresolver.Resolver, map[string]string, error) {
 	var (
 		resolvers     = make(map[string]pubsignals.StateResolver)
+		rpcs          = make(map[string]*stateresolver.Resolver)
 		verifiersDIDs = make(map[string]string)
 	)
 
 	for chainName, chainSettings := range rs {
 		for networkName, networkSettings := range chainSettings {
 			prefix := fmt.Sprintf("%s:%s", chainName, networkName)
-			resolver := state.NewETHResolver(networkSettings.NetworkURL, networkSettings.ContractAddress)
+			urls := append([]string{networkSettings.NetworkURL}, networkSettings.NetworkURLs...)
+			networkOpts := opts
+			networkOpts.Selection = networkSettings.RPCSelection
+			rpc := stateresolver.New(urls, networkSettings.ContractAddress, networkOpts)
+			rpcs[prefix] = rpc
+			var resolver pubsignals.StateResolver = rpc
+			if snapshots.Enabled {
+				// the snapshot syncs the latest states from the contract, not from the cache of the resolver
+				snapshot, err := openStateSnapshot(prefix, rpc.Uncached(), snapshots)
+				if err != nil {
+					log.WithFields(log.Fields{"network": prefix, "err": err}).Error("cannot open state snapshot")
+					return nil, nil, nil, err
+				}
+				go snapshot.Run(ctx, snapshots.Interval.AsDuration())
+				resolver = snapshot
+			}
+			if networkSettings.Confirmations > 0 {
+				resolver = confirmations.NewResolver(prefix, resolver,
+					confirmations.NewETHChain(rpc, networkSettings.ContractAddress),
+					networkSettings.Confirmations, networkSettings.ConfirmationMode == config.ConfirmationModeProvisional)
+			}
 			resolvers[prefix] = resolver
 
-			//if err := registerCustomDIDMethod(ctx, chainName, networkName, networkSettings); err != nil {
-			//	log.Error(ctx, "cannot register custom DID method", "err", err)
-			//	return nil, nil, err
-			//}
+			if err := registerDIDMethod(chainName, networkName, networkSettings); err != nil {
+				log.WithFields(log.Fields{"network": prefix, "err": err}).Error("cannot register DID method")
+				return nil, nil, nil, fmt.Errorf("%s: %w", prefix, err)
+			}
 
 			verifiersDIDs[networkSettings.ChainID] = networkSettings.DID
 		}
 	}
 
-	return resolvers, verifiersDIDs, nil
+	return resolvers, rpcs, verifiersDIDs, nil
+}
+
+// validateSenderDIDs checks that the sender DIDs of the resolver settings and the default sender DID can be resolved
+func validateSenderDIDs(ctx context.Context, didResolver *resolver.Resolver, senderDIDs map[string]string, cfg config.SenderDID) error {
+	dids := make([]string, 0, len(senderDIDs)+1)
+	for _, did := range senderDIDs {
+		dids = append(dids, did)
+	}
+	if cfg.Fallback == config.SenderDIDFallbackDefault {
+		dids = append(dids, cfg.DefaultDID)
+	}
+
+	for _, did := range dids {
+		if did == "" {
+			continue
+		}
+		if _, err := didResolver.Resolve(ctx, did); err != nil {
+			return err
+		}
+		log.WithField("did", did).Info("sender did resolved")
+	}
+	return nil
+}
+
+// openStateSnapshot opens the state snapshot of the network, persisted in a file of the snapshots directory if any
+func documentHosts(hosts []config.DocumentHost) []loader.Host {
+	loaderHosts := make([]loader.Host, 0, len(hosts))
+	for _, h := range hosts {
+		header := make(http.Header, len(h.Headers))
+		for name, value := range h.Headers {
+			header.Set(name, value)
+		}
+		loaderHosts = append(loaderHosts, loader.Host{
+			Name:     h.Host,
+			Header:   header,
+			Username: h.Username,
+			Password: h.Password,
+			Timeout:  h.Timeout,
+		})
+	}
+	return loaderHosts
+}
+
+func openStateSnapshot(network string, source pubsignals.StateResolver, cfg config.StateSnapshot) (*statesnapshot.Snapshot, error) {
+	opts := statesnapshot.Options{MaxAge: cfg.MaxAge.AsDuration(), Retention: cfg.Retention.AsDuration()}
+	if cfg.Dir != "" {
+		opts.Path = filepath.Join(cfg.Dir, strings.ReplaceAll(network, ":", "_")+".json")
+	}
+	return statesnapshot.Open(network, source, opts)
+}
+
+// newShadowVerifier creates the shadow verifier. It uses the resolvers of the main verifier when no shadow resolver settings are provided.
+func newShadowVerifier(ctx context.Context, cfg config.Shadow, resolvers map[string]pubsignals.StateResolver,
+	resolverOpts stateresolver.Options, documentLoader ld.DocumentLoader,
+) (*shadow.Verifier, error) {
+	if len(cfg.ResolverSettings) > 0 {
+		var err error
+		resolvers, _, _, err = parseResolverSettings(ctx, cfg.ResolverSettings, resolverOpts, config.StateSnapshot{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	verifier, err := auth.NewVerifier(&loaders.FSKeyLoader{Dir: cfg.KeyDIR}, resolvers, auth.WithDocumentLoader(documentLoader))
+	if err != nil {
+		return nil, err
+	}
+	return shadow.NewVerifier(verifier, cfg.MaxConcurrent), nil
 }
 
-//func registerCustomDIDMethod(ctx context.Context, blockchain string, network string, resolverAttrs config.ResolverSettingsAttrs) error {
-//	chainID, err := strconv.Atoi(resolverAttrs.ChainID)
-//	if err != nil {
-//		return fmt.Errorf("cannot convert chainID to int: %w", err)
-//	}
-//	params := core.DIDMethodNetworkParams{
-//		Method:      core.DIDMethod(resolverAttrs.Method),
-//		Blockchain:  core.Blockchain(blockchain),
-//		Network:     core.NetworkID(network),
-//		NetworkFlag: resolverAttrs.NetworkFlag,
-//	}
-//	if err := core.RegisterDIDMethodNetwork(params, core.WithChainID(chainID)); err != nil {
-//		log.Error(ctx, "cannot register custom DID method", "err", err, "customDID", chainID)
-//		return err
-//	}
-//	return nil
-//}
+// registerDIDMethod registers the DID method, network and flag of the resolver settings of a network,
+// and checks that the verifier DID belongs to them
+func registerDIDMethod(blockchain string, network string, resolverAttrs config.ResolverSettingsAttrs) error {
+	chainID, err := strconv.Atoi(resolverAttrs.ChainID)
+	if err != nil {
+		return fmt.Errorf("cannot convert chainID to int: %w", err)
+	}
+	params := core.DIDMethodNetworkParams{
+		Method:      core.DIDMethod(resolverAttrs.Method),
+		Blockchain:  core.Blockchain(blockchain),
+		Network:     core.NetworkID(network),
+		NetworkFlag: resolverAttrs.NetworkFlag,
+	}
+	opts := []core.RegistrationOptions{core.WithChainID(chainID)}
+	if resolverAttrs.MethodByte != nil {
+		opts = append(opts, core.WithDIDMethodByte(*resolverAttrs.MethodByte))
+	}
+	if err := core.RegisterDIDMethodNetwork(params, opts...); err != nil {
+		return err
+	}
+
+	if resolverAttrs.DID == "" {
+		return nil
+	}
+	_, err = networkDID(blockchain, network, resolverAttrs)
+	return err
+}
+
+// networkDID parses the verifier DID of the resolver settings of a network, and checks that it uses the method and
+// belongs to the blockchain and the network it is declared on. The method of the network must be registered.
+func networkDID(blockchain string, network string, resolverAttrs config.ResolverSettingsAttrs) (core.ID, error) {
+	did, err := w3c.ParseDID(resolverAttrs.DID)
+	if err != nil {
+		return core.ID{}, fmt.Errorf("invalid did %s: %w", resolverAttrs.DID, err)
+	}
+	if did.Method != resolverAttrs.Method {
+		return core.ID{}, fmt.Errorf("did %s uses the %s method, not the %s method of the network", resolverAttrs.DID,
+			did.Method, resolverAttrs.Method)
+	}
+	if len(did.IDStrings) != 3 || did.IDStrings[0] != blockchain || did.IDStrings[1] != network {
+		return core.ID{}, fmt.Errorf("did %s is not a did of the %s:%s network it is declared on", resolverAttrs.DID,
+			blockchain, network)
+	}
+	id, err := core.IDFromDID(*did)
+	if err != nil {
+		return core.ID{}, fmt.Errorf("did %s does not match the network settings: %w", resolverAttrs.DID, err)
+	}
+	return id, nil
+}
+
+// checkSenderDIDStates checks that the verifier DIDs of the resolver settings published a state on the state
+// contract of their network, so the wallets can resolve them
+func checkSenderDIDStates(ctx context.Context, rs config.ResolverSettings, rpcs map[string]*stateresolver.Resolver) error {
+	for chainName, chainSettings := range rs {
+		for networkName, networkSettings := range chainSettings {
+			if networkSettings.DID == "" {
+				continue
+			}
+			prefix := fmt.Sprintf("%s:%s", chainName, networkName)
+			if err := checkSenderDIDState(ctx, chainName, networkName, networkSettings, rpcs[prefix]); err != nil {
+				return fmt.Errorf("%s: %w", prefix, err)
+			}
+			log.WithFields(log.Fields{"network": prefix, "did": networkSettings.DID}).Info("sender did state found")
+		}
+	}
+	return nil
+}
+
+// checkSenderDIDState checks that the verifier DID of a network published a state on the state contract of the network
+func checkSenderDIDState(ctx context.Context, blockchain string, network string, resolverAttrs config.ResolverSettingsAttrs,
+	rpc *stateresolver.Resolver,
+) error {
+	id, err := networkDID(blockchain, network, resolverAttrs)
+	if err != nil {
+		return err
+	}
+	if _, err := rpc.LatestState(ctx, id.BigInt()); err != nil {
+		return fmt.Errorf("did %s has no state on the state contract %s: %w", resolverAttrs.DID, resolverAttrs.ContractAddress, err)
+	}
+	return nil
+}
+
+// validate checks the configuration of the environment and the resolver settings, the verification keys of the
+// required circuits, the RPC urls and the DIDs of the networks, writes the report to the standard output and returns
+// the exit code of the process
+func validate(ctx context.Context) int {
+	var report configcheck.Report
+	cfg, err := config.Load()
+	report.Add("configuration", err)
+	if err == nil {
+		validateDeployment(ctx, &report, cfg)
+	}
+	if err := report.Write(os.Stdout); err != nil {
+		return 2
+	}
+	if report.Failed() {
+		return 1
+	}
+	return 0
+}
+
+func validateDeployment(ctx context.Context, report *configcheck.Report, cfg *config.Config) {
+	keysLocation := cfg.VerificationKeys.Location
+	if keysLocation == "" {
+		keysLocation = cfg.KeyDIR
+	}
+	keysSource, err := circuitkeys.NewSource(keysLocation, cfg.VerificationKeys.S3Region, cfg.VerificationKeys.S3Endpoint)
+	report.Add("verification keys location "+keysLocation, err)
+	if err == nil {
+		keysLoader := circuitkeys.NewLoader(keysSource, "", cfg.VerificationKeys.Checksums)
+		configcheck.CheckKeys(report, keysLoader, configcheck.RequiredCircuits)
+	}
+
+	configcheck.CheckRPCs(ctx, report, cfg.ResolverSettings, stateresolver.DialETH, cfg.StateResolver.Timeout.AsDuration())
+
+	senderDIDs := make(map[string]string)
+	for _, network := range configcheck.Networks(cfg.ResolverSettings) {
+		attrs := cfg.ResolverSettings[network.Blockchain][network.Network]
+		err := registerDIDMethod(network.Blockchain, network.Network, attrs)
+		report.Add("did method and sender did of "+network.String(), err)
+		if err != nil || attrs.DID == "" {
+			continue
+		}
+		senderDIDs[attrs.ChainID] = attrs.DID
+		if cfg.SenderDID.CheckState {
+			rpc := stateresolver.New(append([]string{attrs.NetworkURL}, attrs.NetworkURLs...), attrs.ContractAddress,
+				stateresolver.Options{Timeout: cfg.StateResolver.Timeout.AsDuration(), Selection: attrs.RPCSelection})
+			report.Add("sender did state of "+network.String(),
+				checkSenderDIDState(ctx, network.Blockchain, network.Network, attrs, rpc))
+		}
+	}
+	if cfg.DIDResolver.URL != "" {
+		didResolver := resolver.New(cfg.DIDResolver.URL, cfg.DIDResolver.CacheTTL.AsDuration())
+		report.Add("sender did resolution", validateSenderDIDs(ctx, didResolver, senderDIDs, cfg.SenderDID))
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/policy"
+)
+
+// WithSchemaAllowlist sets the schema allowlist of the contexts of the queries, shared with the document loader
+func WithSchemaAllowlist(a *policy.SchemaAllowlist) Option {
+	return func(s *Server) {
+		s.schemaAllowlist = a
+	}
+}
+
+// GetSchemaAllowlist - get the schema allowlist
+func (s *Server) GetSchemaAllowlist(ctx context.Context, request GetSchemaAllowlistRequestObject) (GetSchemaAllowlistResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return GetSchemaAllowlist401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	return GetSchemaAllowlist200JSONResponse(s.getSchemaAllowlist()), nil
+}
+
+// SetSchemaAllowlist - replace the schema allowlist
+func (s *Server) SetSchemaAllowlist(ctx context.Context, request SetSchemaAllowlistRequestObject) (SetSchemaAllowlistResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleAdmin) {
+		return SetSchemaAllowlist401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+
+	if err := s.schemaAllowlist.Set(request.Body.Enabled, request.Body.Entries); err != nil {
+		return SetSchemaAllowlist400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeSchemaAllowlistInvalid, err.Error())}}, nil
+	}
+	s.log(ctx).WithFields(log.Fields{
+		"enabled": request.Body.Enabled,
+		"entries": request.Body.Entries,
+	}).Info("schema allowlist updated")
+
+	return SetSchemaAllowlist200JSONResponse(s.getSchemaAllowlist()), nil
+}
+
+func (s *Server) getSchemaAllowlist() SchemaAllowlist {
+	return SchemaAllowlist{
+		Enabled: s.schemaAllowlist.Enabled(),
+		Entries: s.schemaAllowlist.Entries(),
+	}
+}
+
+// checkSchemaAllowlist rejects the scopes whose query uses a context that is not in the schema allowlist
+func (s *Server) checkSchemaAllowlist(scopes []ScopeRequest) error {
+	for _, scope := range scopes {
+		schemaContext, _ := scope.Query["context"].(string)
+		if schemaContext == "" {
+			continue
+		}
+		if err := s.schemaAllowlist.Check(schemaContext); err != nil {
+			return i18n.Wrap(err, i18n.CodeSchemaContextNotAllowed, schemaContext, scope.Id)
+		}
+	}
+	return nil
+}
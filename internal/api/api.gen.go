@@ -9,26 +9,199 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
+	verrors "github.com/0xPolygonID/verifier-backend/internal/errors"
+	messages "github.com/0xPolygonID/verifier-backend/internal/messages"
+	models "github.com/0xPolygonID/verifier-backend/internal/models"
 	"github.com/go-chi/chi/v5"
 	uuid "github.com/google/uuid"
+	merkletree "github.com/iden3/go-merkletree-sql/v2"
+	verifiable "github.com/iden3/go-schema-processor/v2/verifiable"
 	"github.com/oapi-codegen/runtime"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+	jose "gopkg.in/go-jose/go-jose.v2"
+)
+
+// Defines values for SchemaStatusStatus.
+const (
+	SchemaFailed SchemaStatusStatus = "failed"
+	SchemaPinned SchemaStatusStatus = "pinned"
+)
+
+// Defines values for ScopeProofProofType.
+const (
+	BJJSignature2021           ScopeProofProofType = "BJJSignature2021"
+	Iden3SparseMerkleTreeProof ScopeProofProofType = "Iden3SparseMerkleTreeProof"
+)
+
+// Defines values for ScopeStatusStatus.
+const (
+	Failed     ScopeStatusStatus = "failed"
+	Mismatched ScopeStatusStatus = "mismatched"
+	Missing    ScopeStatusStatus = "missing"
+	Satisfied  ScopeStatusStatus = "satisfied"
+)
+
+// Defines values for SearchSessionsParamsStatus.
+const (
+	SessionStatusAbandoned SearchSessionsParamsStatus = "abandoned"
+	SessionStatusConsumed  SearchSessionsParamsStatus = "consumed"
+	SessionStatusError     SearchSessionsParamsStatus = "error"
+	SessionStatusExpired   SearchSessionsParamsStatus = "expired"
+	SessionStatusPending   SearchSessionsParamsStatus = "pending"
+	SessionStatusRevoked   SearchSessionsParamsStatus = "revoked"
+	SessionStatusStale     SearchSessionsParamsStatus = "stale"
+	SessionStatusSuccess   SearchSessionsParamsStatus = "success"
+)
+
+// Defines values for SignInLinkParamsLinkType.
+const (
+	Iden3comm SignInLinkParamsLinkType = "iden3comm"
+	Universal SignInLinkParamsLinkType = "universal"
 )
 
 // Body defines model for Body.
-type Body struct {
-	CallbackUrl *string `json:"callbackUrl,omitempty"`
-	Reason      string  `json:"reason"`
-	Scope       []Scope `json:"scope"`
+type Body = messages.Body
+
+// BuildQueryRequest defines model for BuildQueryRequest.
+type BuildQueryRequest struct {
+	// AllowedIssuers Issuers accepted for the credential, all of them by default
+	AllowedIssuers *[]string `json:"allowedIssuers,omitempty"`
+
+	// ChainID Chain of the sign-in requests. When it is set, the response also contains the sign-in body of the scope.
+	ChainID *string `json:"chainID,omitempty"`
+
+	// CircuitId Circuit of the scope, credentialAtomicQuerySigV2 by default, or credentialAtomicQueryV3-beta.1 when only it supports the operator
+	CircuitId *string `json:"circuitId,omitempty"`
+
+	// Context URL of the JSON-LD context of the credential schema
+	Context string `json:"context"`
+
+	// DetectMerklization Reads the `iden3_serialization` of the type in its context to tell merklized from non-merklized credentials,
+	// and warns about the fields the non-merklized credentials do not store in a slot of their claims.
+	DetectMerklization *bool `json:"detectMerklization,omitempty"`
+
+	// Field Field of the credentialSubject to query. Without it, the query only proves the ownership of a credential of the type.
+	Field *string `json:"field,omitempty"`
 
-	// TransactionData Only required when using on-chain verification
-	TransactionData *TransactionDataResponse `json:"transaction_data,omitempty"`
+	// Operator Operator of the field, with or without the $ prefix. Without it, the field is selectively disclosed.
+	Operator *string `json:"operator,omitempty"`
+
+	// ScopeID Id of the scope, 1 by default
+	ScopeID *uint32 `json:"scopeID,omitempty"`
+
+	// SkipClaimRevocationCheck Sets `skipClaimRevocationCheck` in the query, so the wallets do not prove that the credential is not revoked
+	SkipClaimRevocationCheck *bool `json:"skipClaimRevocationCheck,omitempty"`
+
+	// Type Type of the credential
+	Type string `json:"type"`
+
+	// Value Value of the operator, an array for $in, $nin, $between and $nonbetween, and a boolean for $exists
+	Value *interface{} `json:"value,omitempty"`
+}
+
+// BuildQueryResponse defines model for BuildQueryResponse.
+type BuildQueryResponse struct {
+	// Merklized Whether the credentials of the type are merklized, set with `detectMerklization`
+	Merklized *bool          `json:"merklized,omitempty"`
+	Scope     ScopeRequest   `json:"scope"`
+	SignIn    *SignInRequest `json:"signIn,omitempty"`
+
+	// Slots Fields stored in the slots of the claims of a non-merklized type, the only fields its queries can use
+	Slots *[]string `json:"slots,omitempty"`
+
+	// Warnings Why the wallets cannot prove the query, e.g. a field of a non-merklized type that is not in a slot
+	Warnings *[]string `json:"warnings,omitempty"`
 }
 
-// CallbackResponse defines model for CallbackResponse.
+// CallbackResponse The response of a successful callback carries the `redirectUri` of the sign-in, when it is set.
 type CallbackResponse = map[string]interface{}
 
+// CampaignNullifiers defines model for CampaignNullifiers.
+type CampaignNullifiers struct {
+	Campaign           string   `json:"campaign"`
+	NullifierSessionID string   `json:"nullifierSessionID"`
+	Nullifiers         []string `json:"nullifiers"`
+}
+
+// CircuitKey defines model for CircuitKey.
+type CircuitKey struct {
+	// Checksum hex encoded sha256 of the key
+	Checksum  string    `json:"checksum"`
+	CircuitId string    `json:"circuitId"`
+	LoadedAt  time.Time `json:"loadedAt"`
+}
+
+// CircuitKeys defines model for CircuitKeys.
+type CircuitKeys struct {
+	Keys     []CircuitKey `json:"keys"`
+	Location string       `json:"location"`
+}
+
+// CredentialOfferResponse defines model for CredentialOfferResponse.
+type CredentialOfferResponse struct {
+	// CredentialID ID of the credential in the issuer node
+	CredentialID string `json:"credentialID"`
+
+	// Offer Name of the credential offer
+	Offer string `json:"offer"`
+
+	// UniversalLink Universal link of the offer, to be shown as a QR code
+	UniversalLink string `json:"universalLink"`
+}
+
+// CredentialStatus defines model for CredentialStatus.
+type CredentialStatus = verifiable.CredentialStatus
+
+// CredentialTypeStats defines model for CredentialTypeStats.
+type CredentialTypeStats struct {
+	Type          string `json:"type"`
+	Verifications int    `json:"verifications"`
+}
+
+// DIDDocument defines model for DIDDocument.
+type DIDDocument struct {
+	Context []string `json:"@context"`
+
+	// AlsoKnownAs DIDs the verifier sends the requests from on the networks
+	AlsoKnownAs        *[]string                `json:"alsoKnownAs,omitempty"`
+	AssertionMethod    *[]string                `json:"assertionMethod,omitempty"`
+	Id                 string                   `json:"id"`
+	Service            []DIDService             `json:"service"`
+	VerificationMethod *[]DIDVerificationMethod `json:"verificationMethod,omitempty"`
+}
+
+// DIDService defines model for DIDService.
+type DIDService struct {
+	Id              string `json:"id"`
+	ServiceEndpoint string `json:"serviceEndpoint"`
+	Type            string `json:"type"`
+}
+
+// DIDVerificationMethod defines model for DIDVerificationMethod.
+type DIDVerificationMethod struct {
+	Controller   string          `json:"controller"`
+	Id           string          `json:"id"`
+	PublicKeyJwk jose.JSONWebKey `json:"publicKeyJwk"`
+	Type         string          `json:"type"`
+}
+
+// DailyStats defines model for DailyStats.
+type DailyStats struct {
+	AverageLatencyMs *float64 `json:"averageLatencyMs,omitempty"`
+	Date             string   `json:"date"`
+	Failures         int      `json:"failures"`
+	SuccessRate      *float64 `json:"successRate,omitempty"`
+	Verifications    int      `json:"verifications"`
+}
+
+// ErrorCodeStats defines model for ErrorCodeStats.
+type ErrorCodeStats struct {
+	Code     string `json:"code"`
+	Failures int    `json:"failures"`
+}
+
 // GenericErrorMessage defines model for GenericErrorMessage.
 type GenericErrorMessage struct {
 	Message string `json:"message"`
@@ -37,9 +210,35 @@ type GenericErrorMessage struct {
 // Health defines model for Health.
 type Health = map[string]interface{}
 
+// IssuerPolicy defines model for IssuerPolicy.
+type IssuerPolicy struct {
+	Issuers map[string][]string `json:"issuers"`
+
+	// Mode `reject`: requests with wildcard allowedIssuers are rejected for the credential types with trusted issuers.
+	// `rewrite`: wildcard allowedIssuers are replaced with the trusted issuers.
+	Mode string `json:"mode"`
+}
+
+// IssuerPolicyRequest defines model for IssuerPolicyRequest.
+type IssuerPolicyRequest struct {
+	Issuers []string `json:"issuers"`
+}
+
+// JWKS defines model for JWKS.
+type JWKS = jose.JSONWebKeySet
+
 // JWZMetadata defines model for JWZMetadata.
 type JWZMetadata struct {
-	Nullifiers              *[]JWZProofs            `json:"nullifiers"`
+	// EthAddress Ethereum address the identity of the user was created from, derived from the genesis state of its DID, only
+	// for the identities created from an Ethereum address. No signature of the address is checked.
+	EthAddress *string      `json:"ethAddress,omitempty"`
+	Nullifiers *[]JWZProofs `json:"nullifiers"`
+
+	// Proofs How the credential of every verified scope was proven
+	Proofs *[]ScopeProof `json:"proofs,omitempty"`
+
+	// Scopes Reconciliation of every requested scope with the response
+	Scopes                  *[]ScopeStatus          `json:"scopes,omitempty"`
 	UserDID                 string                  `json:"userDID"`
 	VerifiablePresentations VerifiablePresentations `json:"verifiablePresentations"`
 }
@@ -51,94 +250,622 @@ type JWZProofs struct {
 	ScopeID            uint32 `json:"scopeID"`
 }
 
+// LocalizedReason defines model for LocalizedReason.
+type LocalizedReason struct {
+	// Message Message of the authorization request in the locale.
+	Message *string `json:"message,omitempty"`
+
+	// Reason Reason of the authorization request in the locale.
+	Reason string `json:"reason"`
+}
+
+// Network defines model for Network.
+type Network struct {
+	Blockchain string `json:"blockchain"`
+	ChainID    string `json:"chainID"`
+
+	// Confirmations Blocks required on top of the state transitions the verifications rely on
+	Confirmations uint64 `json:"confirmations"`
+	Method        string `json:"method"`
+
+	// Name Blockchain and network of the DIDs of the network
+	Name          string `json:"name"`
+	Network       string `json:"network"`
+	StateContract string `json:"stateContract"`
+
+	// VerifierContract Verifier contract of the on-chain sign-ins that do not set their contractAddress
+	VerifierContract *string `json:"verifierContract,omitempty"`
+
+	// VerifierDID DID the requests of the network are sent from
+	VerifierDID *string `json:"verifierDID,omitempty"`
+
+	// VerifierMethodID Method of the verifier contract of the on-chain sign-ins that do not set their methodID
+	VerifierMethodID *string `json:"verifierMethodID,omitempty"`
+}
+
+// NullifierCheckpoint defines model for NullifierCheckpoint.
+type NullifierCheckpoint struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Id        int       `json:"id"`
+
+	// Root Root of the nullifiers merkle tree
+	Root string `json:"root"`
+
+	// Size Number of nullifiers in the tree
+	Size int `json:"size"`
+}
+
+// NullifierProof defines model for NullifierProof.
+type NullifierProof struct {
+	Checkpoint NullifierCheckpoint `json:"checkpoint"`
+
+	// Exists true if the nullifier was seen before the checkpoint
+	Exists    bool   `json:"exists"`
+	Nullifier string `json:"nullifier"`
+
+	// Proof Merkle tree proof of the nullifier for the root of the checkpoint
+	Proof merkletree.Proof `json:"proof"`
+
+	// Value Leaf value of the nullifier when it exists, the unix time it was first seen.
+	// The proof is verified with this value, or 0 for a proof of non-inclusion.
+	Value *string `json:"value,omitempty"`
+}
+
+// NullifierSession The nullifier session derived for a campaign, and the inputs of its derivation
+type NullifierSession struct {
+	Campaign string `json:"campaign"`
+
+	// CredentialType Credential type given to the derivation, the `campaign` derivation ignores it
+	CredentialType *string `json:"credentialType,omitempty"`
+
+	// Derivation Name of the derivation, `campaign`, `credential-type` or a custom one
+	Derivation string `json:"derivation"`
+
+	// Namespace Namespace of the deployment, not set when the deployment has none
+	Namespace          *string `json:"namespace,omitempty"`
+	NullifierSessionID string  `json:"nullifierSessionID"`
+
+	// Tenant Tenant of the API key, not set for the keys without tenant
+	Tenant *string `json:"tenant,omitempty"`
+}
+
+// OnChainProofsRequest defines model for OnChainProofsRequest.
+type OnChainProofsRequest struct {
+	// Address Address that submitted the proofs to the verifier contract
+	Address string `json:"address"`
+}
+
+// PrewarmSchemasRequest defines model for PrewarmSchemasRequest.
+type PrewarmSchemasRequest struct {
+	// Urls Additional JSON-LD documents to pin
+	Urls *[]string `json:"urls,omitempty"`
+}
+
 // QRCode defines model for QRCode.
-type QRCode struct {
-	Body Body    `json:"body"`
-	From string  `json:"from"`
-	Id   string  `json:"id"`
-	Thid string  `json:"thid"`
-	To   *string `json:"to,omitempty"`
-	Typ  string  `json:"typ"`
-	Type string  `json:"type"`
+type QRCode = messages.QRCode
+
+// QRStoreFetchRequest defines model for QRStoreFetchRequest.
+type QRStoreFetchRequest struct {
+	// Nonce Random value of the wallet, different on every fetch of a session
+	Nonce string `json:"nonce"`
+
+	// WalletDID DID of the wallet the session is bound to
+	WalletDID string `json:"walletDID"`
 }
 
 // Query defines model for Query.
 type Query = map[string]interface{}
 
-// Scope defines model for Scope.
-type Scope struct {
+// QueryTemplate defines model for QueryTemplate.
+type QueryTemplate struct {
+	CircuitId string       `json:"circuitId"`
+	Name      string       `json:"name"`
+	Params    *ScopeParams `json:"params,omitempty"`
+	Query     Query        `json:"query"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+// QueryTemplateRequest defines model for QueryTemplateRequest.
+type QueryTemplateRequest struct {
 	CircuitId string       `json:"circuitId"`
-	Id        uint32       `json:"id"`
 	Params    *ScopeParams `json:"params,omitempty"`
 	Query     Query        `json:"query"`
 }
 
-// ScopeParams defines model for ScopeParams.
+// ReasonTemplate defines model for ReasonTemplate.
+type ReasonTemplate struct {
+	// DefaultLocale Locale used when the template has no translation for the locale of the sign-in.
+	DefaultLocale string `json:"defaultLocale"`
+
+	// Locales Reasons and messages of the template by locale, e.g. `en` or `pt-BR`. They are templates of the variables
+	// of the sign-in, e.g. `{{.company}}`.
+	Locales   map[string]LocalizedReason `json:"locales"`
+	Name      string                     `json:"name"`
+	UpdatedAt time.Time                  `json:"updatedAt"`
+}
+
+// ReasonTemplateRef Reason template of the request, rendered in the locale of the user. The reason and the message of the
+// request take precedence over the ones of the template, and the on-chain sessions only take the reason.
+type ReasonTemplateRef struct {
+	// Locale Locale of the user e.g. `pt-BR`. The translation of the locale is used, then the one of its language,
+	// then the one of the default locale of the template.
+	Locale *string `json:"locale,omitempty"`
+
+	// Name Name of the reason template.
+	Name string `json:"name"`
+
+	// Variables Values of the variables of the template.
+	Variables *map[string]string `json:"variables,omitempty"`
+}
+
+// ReasonTemplateRequest defines model for ReasonTemplateRequest.
+type ReasonTemplateRequest struct {
+	// DefaultLocale Locale used when the template has no translation for the locale of the sign-in.
+	DefaultLocale string `json:"defaultLocale"`
+
+	// Locales Reasons and messages of the template by locale, e.g. `en` or `pt-BR`. They are templates of the variables
+	// of the sign-in, e.g. `{{.company}}`.
+	Locales map[string]LocalizedReason `json:"locales"`
+}
+
+// RevocationStatusRequest defines model for RevocationStatusRequest.
+type RevocationStatusRequest struct {
+	// Credential W3C credential. When present, the issuer and the credential status are taken from it.
+	Credential       *verifiable.W3CCredential `json:"credential,omitempty"`
+	CredentialStatus *CredentialStatus         `json:"credentialStatus,omitempty"`
+	IssuerDID        *string                   `json:"issuerDID,omitempty"`
+	RevocationNonce  *uint64                   `json:"revocationNonce,omitempty"`
+}
+
+// RevocationStatusResponse defines model for RevocationStatusResponse.
+type RevocationStatusResponse struct {
+	// Issuer Issuer tree state used to build the proof
+	Issuer verifiable.TreeState `json:"issuer"`
+
+	// Mtp Merkle tree proof of the revocation nonce in the issuer revocation tree
+	Mtp     merkletree.Proof `json:"mtp"`
+	Revoked bool             `json:"revoked"`
+}
+
+// SLIWindow defines model for SLIWindow.
+type SLIWindow struct {
+	Failures      int      `json:"failures"`
+	P95LatencyMs  float64  `json:"p95LatencyMs"`
+	RpcCalls      int      `json:"rpcCalls"`
+	RpcErrorRatio *float64 `json:"rpcErrorRatio,omitempty"`
+	RpcErrors     int      `json:"rpcErrors"`
+	SuccessRate   *float64 `json:"successRate,omitempty"`
+	Verifications int      `json:"verifications"`
+	Window        string   `json:"window"`
+}
+
+// SandboxKey defines model for SandboxKey.
+type SandboxKey struct {
+	ApiKey    string    `json:"apiKey"`
+	ChainIDs  []string  `json:"chainIDs"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// SandboxKeyPending defines model for SandboxKeyPending.
+type SandboxKeyPending struct {
+	Message string `json:"message"`
+}
+
+// SandboxKeyRequest defines model for SandboxKeyRequest.
+type SandboxKeyRequest struct {
+	Email string `json:"email"`
+}
+
+// SandboxKeyVerifyRequest defines model for SandboxKeyVerifyRequest.
+type SandboxKeyVerifyRequest struct {
+	Code  string `json:"code"`
+	Email string `json:"email"`
+}
+
+// SchemaAllowlist defines model for SchemaAllowlist.
+type SchemaAllowlist struct {
+	// Enabled Only the contexts and schemas of the entries are accepted in the queries and loaded when enabled
+	Enabled bool `json:"enabled"`
+
+	// Entries Allowed urls, url prefixes ending with a slash, and ipfs CIDs
+	Entries []string `json:"entries"`
+}
+
+// SchemaStatus defines model for SchemaStatus.
+type SchemaStatus struct {
+	CheckedAt time.Time `json:"checkedAt"`
+
+	// DurationMs Time to fetch the document in milliseconds
+	DurationMs int64 `json:"durationMs"`
+
+	// Error Cause of the failure, set when the status is failed
+	Error  *string            `json:"error,omitempty"`
+	Status SchemaStatusStatus `json:"status"`
+
+	// Templates Query templates that reference the document
+	Templates *[]string `json:"templates,omitempty"`
+	Url       string    `json:"url"`
+}
+
+// SchemaStatusStatus defines model for SchemaStatus.Status.
+type SchemaStatusStatus string
+
+// Scope defines model for Scope.
+type Scope = messages.Scope
+
+// ScopeParams Params of the scope, rejected when its circuit does not accept them. The credentialAtomicQueryV3 circuits accept
+// `nullifierSessionID` and `linkNonce` as decimal integers, `verifierID` as a DID and `groupID` as a positive integer, the
+// credentialAtomicQuerySigV2 and credentialAtomicQueryMTPV2 circuits `nullifierSessionID` only.
 type ScopeParams = map[string]interface{}
 
-// ScopeRequest defines model for ScopeRequest.
+// ScopeProof defines model for ScopeProof.
+type ScopeProof struct {
+	IssuerDID string `json:"issuerDID"`
+
+	// IssuerState State of the issuer the proof relies on, as a hex string
+	IssuerState string `json:"issuerState"`
+
+	// IssuerStateGenesis The issuer state is the genesis state of the issuer, which was never published on-chain
+	IssuerStateGenesis bool `json:"issuerStateGenesis"`
+
+	// Merklized The credential is merklized
+	Merklized bool `json:"merklized"`
+
+	// ProofType BJJSignature2021: the credential is signed by the issuer and does not need to be published in the issuer state.
+	// Iden3SparseMerkleTreeProof: the credential is included in the published issuer state.
+	ProofType ScopeProofProofType `json:"proofType"`
+	ScopeID   uint32              `json:"scopeID"`
+}
+
+// ScopeProofProofType BJJSignature2021: the credential is signed by the issuer and does not need to be published in the issuer state.
+// Iden3SparseMerkleTreeProof: the credential is included in the published issuer state.
+type ScopeProofProofType string
+
+// ScopeRequest `circuitId` and `query` are required unless a query template is used.
+// When `template` is set, the inline `query` fields override the fields of the template query.
 type ScopeRequest struct {
-	CircuitId string       `json:"circuitId"`
+	CircuitId string       `json:"circuitId,omitempty"`
 	Id        uint32       `json:"id"`
 	Params    *ScopeParams `json:"params,omitempty"`
-	Query     Query        `json:"query"`
+	Query     Query        `json:"query,omitempty"`
 
-	// TransactionData Only required when using on-chain verification
+	// Template Name of the query template to use
+	Template *string `json:"template,omitempty"`
+
+	// TransactionData Only required when using on-chain verification. The contractAddress, methodID and network default to the
+	// verifier contract preset of the network of chainID in the resolver settings.
 	TransactionData *TransactionData `json:"transactionData,omitempty"`
 }
 
+// ScopeStatus defines model for ScopeStatus.
+type ScopeStatus struct {
+	Reason  *string `json:"reason,omitempty"`
+	ScopeID uint32  `json:"scopeID"`
+
+	// Status satisfied: the scope was answered once with the requested circuit, an allowed issuer and the requested credential and fields.
+	// missing: the scope was not answered, only accepted for optional scopes.
+	// mismatched: the answer does not match the request, or the scope was not requested.
+	// failed: the proof of the scope is not valid, only accepted when the request does not require all the scopes.
+	Status ScopeStatusStatus `json:"status"`
+}
+
+// ScopeStatusStatus satisfied: the scope was answered once with the requested circuit, an allowed issuer and the requested credential and fields.
+// missing: the scope was not answered, only accepted for optional scopes.
+// mismatched: the answer does not match the request, or the scope was not requested.
+// failed: the proof of the scope is not valid, only accepted when the request does not require all the scopes.
+type ScopeStatusStatus string
+
+// SessionEvent An event of the timeline of a session
+type SessionEvent struct {
+	// Message Error of the failed verifications
+	Message *string `json:"message,omitempty"`
+
+	// Time Time of the event
+	Time time.Time `json:"time"`
+
+	// Type created, qr-fetched, callback-received, verification-started, verified or failed
+	Type string `json:"type"`
+}
+
+// SessionMetadata Key/value pairs of the integrator echoed back in the status and the webhook events of the session, e.g. to correlate them
+// with its own records. Up to 16 keys of up to 64 characters, with values of up to 256 characters.
+type SessionMetadata map[string]string
+
+// ShadowVerificationDisagreement defines model for ShadowVerificationDisagreement.
+type ShadowVerificationDisagreement struct {
+	// PrimaryError error of the primary verifier, empty if the verification succeeded
+	PrimaryError *string `json:"primaryError,omitempty"`
+	SessionID    string  `json:"sessionID"`
+
+	// ShadowError error of the shadow verifier, empty if the verification succeeded
+	ShadowError *string   `json:"shadowError,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// ShadowVerificationReport defines model for ShadowVerificationReport.
+type ShadowVerificationReport struct {
+	Agreements    int `json:"agreements"`
+	Disagreements int `json:"disagreements"`
+
+	// Dropped callbacks not compared because all the shadow workers were busy
+	Dropped             int                              `json:"dropped"`
+	RecentDisagreements []ShadowVerificationDisagreement `json:"recentDisagreements"`
+	Total               int                              `json:"total"`
+}
+
+// SignInAuthRequest defines model for SignInAuthRequest.
+type SignInAuthRequest struct {
+	ChainID string  `json:"chainID"`
+	Reason  *string `json:"reason,omitempty"`
+
+	// RedirectUri URL returned to the wallet in the response of a successful callback, so it can send the user back to the app
+	// of the verifier, e.g. a universal link or a deep link.
+	RedirectUri *string `json:"redirectUri,omitempty"`
+
+	// RequireEthAddress Rejects the callbacks of users whose identity was not created from an Ethereum address.
+	RequireEthAddress *bool     `json:"requireEthAddress,omitempty"`
+	Tags              *[]string `json:"tags,omitempty"`
+	To                *string   `json:"to,omitempty"`
+}
+
+// SignInBatchRequest defines model for SignInBatchRequest.
+type SignInBatchRequest struct {
+	Requests []SignInRequest `json:"requests"`
+}
+
+// SignInBatchResponse defines model for SignInBatchResponse.
+type SignInBatchResponse struct {
+	Results []SignInBatchResult `json:"results"`
+}
+
+// SignInBatchResult Result of a sign-in request of the batch. Either `sessionID`, `qrCode` and `expiresAt` or `error` are set.
+type SignInBatchResult struct {
+	Error     *string    `json:"error,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	QrCode    *string    `json:"qrCode,omitempty"`
+	SessionID *UUID      `json:"sessionID,omitempty"`
+}
+
+// SignInFlowRequest defines model for SignInFlowRequest.
+type SignInFlowRequest struct {
+	// ChainID Chain of the request, required when the flow does not set one and ignored otherwise.
+	ChainID *string `json:"chainID,omitempty"`
+
+	// Metadata Key/value pairs of the integrator echoed back in the status and the webhook events of the session, e.g. to correlate them
+	// with its own records. Up to 16 keys of up to 64 characters, with values of up to 256 characters.
+	Metadata *SessionMetadata `json:"metadata,omitempty"`
+	Tags     *[]string        `json:"tags,omitempty"`
+	To       *string          `json:"to,omitempty"`
+}
+
 // SignInRequest defines model for SignInRequest.
 type SignInRequest struct {
 	// ChainID Only required when using off-chain verification
 	// `80002`: `amoy`
 	// `80001`: `mumbai`
 	// `137` : `mainnet`
-	ChainID *string        `json:"chainID,omitempty"`
-	Reason  *string        `json:"reason,omitempty"`
-	Scope   []ScopeRequest `json:"scope"`
-	To      *string        `json:"to,omitempty"`
-
-	// TransactionData Only required when using on-chain verification
+	ChainID *string `json:"chainID,omitempty"`
+
+	// EnforceUniqueNullifier Rejects the callbacks with a nullifier that was already used in the same nullifier session,
+	// so every user can only prove once per nullifier session e.g: sybil-resistant airdrops or voting.
+	// All the scopes must use the `credentialAtomicQueryV3-beta.1` circuit, or `credentialAtomicQueryV3OnChain-beta.1`
+	// on the on-chain sign-ins, with a `nullifierSessionID` param.
+	EnforceUniqueNullifier *bool `json:"enforceUniqueNullifier,omitempty"`
+
+	// Message Message of the authorization request shown by the wallets. Off-chain sessions only.
+	Message *string `json:"message,omitempty"`
+
+	// Metadata Key/value pairs of the integrator echoed back in the status and the webhook events of the session, e.g. to correlate them
+	// with its own records. Up to 16 keys of up to 64 characters, with values of up to 256 characters.
+	Metadata *SessionMetadata `json:"metadata,omitempty"`
+
+	// PublicURL Base URL of the callback and the QR code link of the request, instead of the public URL of the verifier.
+	// It must be one of the allowed public URLs when the verifier restricts them.
+	PublicURL *string `json:"publicURL,omitempty"`
+	Reason    *string `json:"reason,omitempty"`
+
+	// ReasonTemplate Reason template of the request, rendered in the locale of the user. The reason and the message of the
+	// request take precedence over the ones of the template, and the on-chain sessions only take the reason.
+	ReasonTemplate *ReasonTemplateRef `json:"reasonTemplate,omitempty"`
+
+	// RedirectUri URL returned to the wallet in the response of a successful callback, so it can send the user back to the app
+	// of the verifier, e.g. a universal link or a deep link. Off-chain sessions only.
+	RedirectUri *string `json:"redirectUri,omitempty"`
+
+	// RequireEthAddress Rejects the callbacks of users whose identity was not created from an Ethereum address, so the session returns
+	// the genesis-derived address in `jwzMetadata.ethAddress`. The address is derived from the DID, not proved by a
+	// signature. Off-chain sessions only.
+	RequireEthAddress *bool `json:"requireEthAddress,omitempty"`
+
+	// RequiredScopes Number of scopes that must be verified, all of them by default. When fewer scopes are required,
+	// the callbacks are accepted if at least that many scopes are verified, and the status reports the outcome of every scope.
+	// Only supported by off-chain verifications of at most 5 scopes without a groupId.
+	RequiredScopes *int           `json:"requiredScopes,omitempty"`
+	Scope          []ScopeRequest `json:"scope"`
+
+	// Tags Tags of the session, used to search the sessions and group their stats e.g: one tag per campaign.
+	// Tags can only contain letters, digits and the characters `_ . : -`, with up to 64 characters.
+	Tags *[]string `json:"tags,omitempty"`
+	To   *string   `json:"to,omitempty"`
+
+	// TransactionData Only required when using on-chain verification. The contractAddress, methodID and network default to the
+	// verifier contract preset of the network of chainID in the resolver settings.
 	TransactionData *TransactionData `json:"transactionData,omitempty"`
+
+	// TrustProfile Name of a trust profile of the verifier configuration. The scopes must comply with the schemas and operators
+	// of the profile, the allowed issuers and the revocation check are taken from the profile when they are not set,
+	// and the callbacks are rejected when the proofs do not comply with the profile.
+	TrustProfile *string `json:"trustProfile,omitempty"`
+}
+
+// SignInUniqueRequest defines model for SignInUniqueRequest.
+type SignInUniqueRequest struct {
+	// Campaign Name of the campaign, with up to 64 letters, digits, '-' or '_'.
+	Campaign string         `json:"campaign"`
+	ChainID  string         `json:"chainID"`
+	Reason   *string        `json:"reason,omitempty"`
+	Scope    []ScopeRequest `json:"scope"`
+	Tags     *[]string      `json:"tags,omitempty"`
+	To       *string        `json:"to,omitempty"`
 }
 
 // SingInResponse defines model for SingInResponse.
 type SingInResponse struct {
-	QrCode    string `json:"qrCode"`
-	SessionID UUID   `json:"sessionID"`
+	// ExpiresAt When the session stops waiting for the callback of the wallet, the QR code must be generated again after it
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Links Links that open the request in the wallets, for the devices that cannot scan the QR code
+	Links     *WalletLinks `json:"links,omitempty"`
+	QrCode    string       `json:"qrCode"`
+	SessionID UUID         `json:"sessionID"`
+}
+
+// StageTimings defines model for StageTimings.
+type StageTimings struct {
+	AverageMs float64 `json:"averageMs"`
+	MaxMs     float64 `json:"maxMs"`
+	Stage     string  `json:"stage"`
+	TotalMs   float64 `json:"totalMs"`
 }
 
 // StatusResponse defines model for StatusResponse.
 type StatusResponse struct {
+	// ErrorCode machine-readable cause of the error, set when the status is error
+	ErrorCode *verrors.Code `json:"errorCode,omitempty"`
+
+	// ExpiresAt When the session stops waiting for the callback of the wallet, set for pending and expired sessions.
+	// Expired sessions that were removed from the cache are reported as expired for the session ledger retention.
+	ExpiresAt   *time.Time   `json:"expiresAt,omitempty"`
 	Jwz         *string      `json:"jwz"`
 	JwzMetadata *JWZMetadata `json:"jwzMetadata,omitempty"`
 
 	// Message error message
 	Message *string `json:"message"`
 
-	// Status pending, success, error
+	// Metadata Key/value pairs of the integrator echoed back in the status and the webhook events of the session, e.g. to correlate them
+	// with its own records. Up to 16 keys of up to 64 characters, with values of up to 256 characters.
+	Metadata *SessionMetadata `json:"metadata,omitempty"`
+
+	// Provisional The verification relies on a recent state transition that does not have the block confirmations required by its network yet.
+	// The status changes to error if the state is reverted by a chain reorganization before it is confirmed.
+	Provisional *bool `json:"provisional,omitempty"`
+
+	// Status pending, success, error, consumed, expired, abandoned, stale or revoked.
+	// Sessions without a callback expire after the session ttl: abandoned when the QR code was fetched by a wallet, expired otherwise.
+	// Successful verifications become stale or revoked when their re-verification finds that a state of their proofs was replaced.
 	Status string `json:"status"`
+
+	// Timings Timed steps of the last verification of the session, only returned with `debug=true`.
+	Timings *[]TimingSpan `json:"timings,omitempty"`
+
+	// Token JWT signed by the verifier for the user of the session, when token issuance is enabled.
+	// Its public key is published in /.well-known/jwks.json, or /tenants/{tenantID}/.well-known/jwks.json for tenant sessions.
+	Token *string `json:"token,omitempty"`
+}
+
+// TagStats defines model for TagStats.
+type TagStats struct {
+	Abandoned int    `json:"abandoned"`
+	Created   int    `json:"created"`
+	Expired   int    `json:"expired"`
+	Failed    int    `json:"failed"`
+	Scanned   int    `json:"scanned"`
+	Tag       string `json:"tag"`
+	Verified  int    `json:"verified"`
+}
+
+// TaggedSession defines model for TaggedSession.
+type TaggedSession struct {
+	CreatedAt time.Time `json:"createdAt"`
+	SessionID uuid.UUID `json:"sessionID"`
+	Status    string    `json:"status"`
+	Tags      []string  `json:"tags"`
+}
+
+// TimingSpan defines model for TimingSpan.
+type TimingSpan struct {
+	DurationMs float64 `json:"durationMs"`
+
+	// Name unpack, pub_signals_parse, state_resolution, revocation_check, proof_verification, post_processing, or parse
+	// for the whole parse stage.
+	Name string `json:"name"`
+
+	// ScopeID Scope of the span, set for the pub signals parse, the state resolutions and the revocation checks of a scope.
+	ScopeID *uint32 `json:"scopeID,omitempty"`
+
+	// StartMs Time since the start of the verification.
+	StartMs float64 `json:"startMs"`
 }
 
-// TransactionData Only required when using on-chain verification
+// TransactionData Only required when using on-chain verification. The contractAddress, methodID and network default to the
+// verifier contract preset of the network of chainID in the resolver settings.
 type TransactionData struct {
 	ChainID         int    `json:"chainID"`
-	ContractAddress string `json:"contractAddress"`
-	MethodID        string `json:"methodID"`
-	Network         string `json:"network"`
+	ContractAddress string `json:"contractAddress,omitempty"`
+	MethodID        string `json:"methodID,omitempty"`
+	Network         string `json:"network,omitempty"`
 }
 
 // TransactionDataResponse Only required when using on-chain verification
-type TransactionDataResponse struct {
-	ChainId         int    `json:"chain_id"`
-	ContractAddress string `json:"contract_address"`
-	MethodId        string `json:"method_id"`
-	Network         string `json:"network"`
-}
+type TransactionDataResponse = messages.TransactionData
 
 // UUID defines model for UUID.
 type UUID = uuid.UUID
 
+// UnpackRequest defines model for UnpackRequest.
+type UnpackRequest struct {
+	// Message JWZ token or plain JSON iden3comm message
+	Message string `json:"message"`
+}
+
+// UnpackResponse defines model for UnpackResponse.
+type UnpackResponse struct {
+	// Circuits Circuits of the proofs of the message, the auth circuit of the token first
+	Circuits []UnpackedCircuit `json:"circuits"`
+
+	// Header Header of the JWZ token, not set for the plain messages
+	Header *map[string]interface{} `json:"header,omitempty"`
+
+	// MediaType Media type of the message
+	MediaType string `json:"mediaType"`
+
+	// Payload Payload of the message
+	Payload models.JWZPayload `json:"payload"`
+}
+
+// UnpackedCircuit defines model for UnpackedCircuit.
+type UnpackedCircuit struct {
+	CircuitId string `json:"circuitId"`
+
+	// Error Why the public signals could not be interpreted
+	Error *string `json:"error,omitempty"`
+
+	// PubSignals Public signals by name
+	PubSignals *map[string]interface{} `json:"pubSignals,omitempty"`
+
+	// ScopeID Id of the scope of the proof, not set for the auth circuit
+	ScopeID *int `json:"scopeID,omitempty"`
+}
+
+// UserNullifier defines model for UserNullifier.
+type UserNullifier struct {
+	Nullifier          string `json:"nullifier"`
+	NullifierSessionID string `json:"nullifierSessionID"`
+}
+
+// UserRecord The verifications of a user, keyed by its DID
+type UserRecord struct {
+	// CredentialTypes Types of the credentials the user verified
+	CredentialTypes []string  `json:"credentialTypes"`
+	Did             string    `json:"did"`
+	FirstVerifiedAt time.Time `json:"firstVerifiedAt"`
+	LastVerifiedAt  time.Time `json:"lastVerifiedAt"`
+
+	// Nullifiers Nullifiers the user proved, with their nullifier session
+	Nullifiers    []UserNullifier `json:"nullifiers"`
+	Verifications int             `json:"verifications"`
+}
+
 // VerifiablePresentation defines model for VerifiablePresentation.
 type VerifiablePresentation struct {
 	CredentialSubject map[string]interface{} `json:"credentialSubject"`
@@ -150,659 +877,7916 @@ type VerifiablePresentation struct {
 // VerifiablePresentations defines model for VerifiablePresentations.
 type VerifiablePresentations = []VerifiablePresentation
 
+// VerificationStats defines model for VerificationStats.
+type VerificationStats struct {
+	AverageLatencyMs *float64 `json:"averageLatencyMs,omitempty"`
+
+	// CredentialTypes The most requested credential types, most requested first
+	CredentialTypes []CredentialTypeStats `json:"credentialTypes"`
+	Days            []DailyStats          `json:"days"`
+
+	// ErrorCodes Failures by error code, most frequent first
+	ErrorCodes    []ErrorCodeStats `json:"errorCodes"`
+	Failures      int              `json:"failures"`
+	From          string           `json:"from"`
+	SuccessRate   *float64         `json:"successRate,omitempty"`
+	To            string           `json:"to"`
+	Verifications int              `json:"verifications"`
+}
+
+// VerificationTimings defines model for VerificationTimings.
+type VerificationTimings struct {
+	Stages        []StageTimings `json:"stages"`
+	Verifications int            `json:"verifications"`
+}
+
+// VerifierKey defines model for VerifierKey.
+type VerifierKey struct {
+	// KeyId kid of a JWT signing key, hex encoded compressed public key of a BJJ key, address of an ETH key
+	KeyId string `json:"keyId"`
+
+	// Retired the key no longer signs, it is still published so the signatures it made can be verified
+	Retired bool `json:"retired"`
+
+	// Type JWT, BJJ or ETH
+	Type string `json:"type"`
+}
+
+// VerifierKeys defines model for VerifierKeys.
+type VerifierKeys struct {
+	Keys []VerifierKey `json:"keys"`
+}
+
+// WalletLinks Links that open the request in the wallets, for the devices that cannot scan the QR code
+type WalletLinks struct {
+	// DeepLink Link of the scheme of the wallet apps
+	DeepLink string `json:"deepLink"`
+
+	// Interstitial Page that redirects the mobile devices to the wallet, and shows the QR code to the other devices
+	Interstitial string `json:"interstitial"`
+
+	// UniversalLinks Universal links of the wallets, that open their app when it is installed and their web wallet otherwise
+	UniversalLinks []string `json:"universalLinks"`
+}
+
+// WebhookDelivery The delivery of an event to a webhook
+type WebhookDelivery struct {
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Event Body of the requests sent to the webhooks
+	Event WebhookDeliveryEvent `json:"event"`
+	Id    string               `json:"id"`
+
+	// LastError Error of the last failed attempt
+	LastError *string `json:"lastError,omitempty"`
+
+	// Status pending, delivered or dead
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Webhook Flow of the webhook, not set for the session webhook
+	Webhook *string `json:"webhook,omitempty"`
+}
+
+// WebhookDeliveryEvent Body of the requests sent to the webhooks
+type WebhookDeliveryEvent struct {
+	// Metadata Metadata of the sign-in of the session
+	Metadata  *map[string]string `json:"metadata,omitempty"`
+	SessionID string             `json:"sessionID"`
+	Status    string             `json:"status"`
+	Time      time.Time          `json:"time"`
+	Type      string             `json:"type"`
+}
+
+// ApiKey defines model for apiKey.
+type ApiKey = string
+
+// Campaign defines model for campaign.
+type Campaign = string
+
+// CredentialType defines model for credentialType.
+type CredentialType = string
+
+// DeliveryID defines model for deliveryID.
+type DeliveryID = string
+
+// Did defines model for did.
+type Did = string
+
+// FlowName defines model for flowName.
+type FlowName = string
+
 // Id defines model for id.
-type Id = uuid.UUID
+type Id = string
+
+// Nullifier defines model for nullifier.
+type Nullifier = string
+
+// PathSessionID defines model for pathSessionID.
+type PathSessionID = uuid.UUID
 
 // SessionID defines model for sessionID.
 type SessionID = uuid.UUID
 
+// Tag defines model for tag.
+type Tag = string
+
+// TemplateName defines model for templateName.
+type TemplateName = string
+
+// TenantID defines model for tenantID.
+type TenantID = string
+
 // N400 defines model for 400.
 type N400 = GenericErrorMessage
 
+// N401 defines model for 401.
+type N401 = GenericErrorMessage
+
+// N403 defines model for 403.
+type N403 = GenericErrorMessage
+
 // N404 defines model for 404.
 type N404 = GenericErrorMessage
 
+// N409 defines model for 409.
+type N409 = GenericErrorMessage
+
+// N410 defines model for 410.
+type N410 = GenericErrorMessage
+
+// N429 defines model for 429.
+type N429 = GenericErrorMessage
+
 // N500 defines model for 500.
 type N500 = GenericErrorMessage
 
-// CallbackTextBody defines parameters for Callback.
-type CallbackTextBody = string
+// ListCircuitKeysParams defines parameters for ListCircuitKeys.
+type ListCircuitKeysParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-// CallbackParams defines parameters for Callback.
-type CallbackParams struct {
-	// SessionID ID e.g: 89d298fa-15a6-4a1d-ab13-d1069467eedd
-	SessionID SessionID `form:"sessionID" json:"sessionID"`
+// ReloadCircuitKeysParams defines parameters for ReloadCircuitKeys.
+type ReloadCircuitKeysParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-// GetQRCodeFromStoreParams defines parameters for GetQRCodeFromStore.
-type GetQRCodeFromStoreParams struct {
-	// Id ID e.g: 89d298fa-15a6-4a1d-ab13-d1069467eedd
-	Id Id `form:"id" json:"id"`
+// GetIssuerPolicyParams defines parameters for GetIssuerPolicy.
+type GetIssuerPolicyParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-// StatusParams defines parameters for Status.
-type StatusParams struct {
-	// SessionID ID e.g: 89d298fa-15a6-4a1d-ab13-d1069467eedd
-	SessionID SessionID `form:"sessionID" json:"sessionID"`
+// DeleteIssuerPolicyParams defines parameters for DeleteIssuerPolicy.
+type DeleteIssuerPolicyParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-// CallbackTextRequestBody defines body for Callback for text/plain ContentType.
-type CallbackTextRequestBody = CallbackTextBody
+// SetIssuerPolicyParams defines parameters for SetIssuerPolicy.
+type SetIssuerPolicyParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-// SignInJSONRequestBody defines body for SignIn for application/json ContentType.
-type SignInJSONRequestBody = SignInRequest
+// ListVerifierKeysParams defines parameters for ListVerifierKeys.
+type ListVerifierKeysParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Get the documentation
-	// (GET /)
-	GetDocumentation(w http.ResponseWriter, r *http.Request)
-	// Callback
-	// (POST /callback)
-	Callback(w http.ResponseWriter, r *http.Request, params CallbackParams)
-	// Health Check
-	// (GET /health)
-	Health(w http.ResponseWriter, r *http.Request)
-	// Get QRCode from store
-	// (GET /qr-store)
-	GetQRCodeFromStore(w http.ResponseWriter, r *http.Request, params GetQRCodeFromStoreParams)
-	// Sign in
-	// (POST /sign-in)
-	SignIn(w http.ResponseWriter, r *http.Request)
-	// Get Status
-	// (GET /status)
-	Status(w http.ResponseWriter, r *http.Request, params StatusParams)
+// RotateVerifierKeysParams defines parameters for RotateVerifierKeys.
+type RotateVerifierKeysParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+// ListQueryTemplatesParams defines parameters for ListQueryTemplates.
+type ListQueryTemplatesParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-type Unimplemented struct{}
+// DeleteQueryTemplateParams defines parameters for DeleteQueryTemplate.
+type DeleteQueryTemplateParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-// Get the documentation
-// (GET /)
-func (_ Unimplemented) GetDocumentation(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+// GetQueryTemplateParams defines parameters for GetQueryTemplate.
+type GetQueryTemplateParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-// Callback
-// (POST /callback)
-func (_ Unimplemented) Callback(w http.ResponseWriter, r *http.Request, params CallbackParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+// SetQueryTemplateParams defines parameters for SetQueryTemplate.
+type SetQueryTemplateParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-// Health Check
-// (GET /health)
-func (_ Unimplemented) Health(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+// ListReasonTemplatesParams defines parameters for ListReasonTemplates.
+type ListReasonTemplatesParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-// Get QRCode from store
-// (GET /qr-store)
-func (_ Unimplemented) GetQRCodeFromStore(w http.ResponseWriter, r *http.Request, params GetQRCodeFromStoreParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+// DeleteReasonTemplateParams defines parameters for DeleteReasonTemplate.
+type DeleteReasonTemplateParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-// Sign in
-// (POST /sign-in)
-func (_ Unimplemented) SignIn(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+// SetReasonTemplateParams defines parameters for SetReasonTemplate.
+type SetReasonTemplateParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-// Get Status
-// (GET /status)
-func (_ Unimplemented) Status(w http.ResponseWriter, r *http.Request, params StatusParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+// GetSchemaAllowlistParams defines parameters for GetSchemaAllowlist.
+type GetSchemaAllowlistParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// SetSchemaAllowlistParams defines parameters for SetSchemaAllowlist.
+type SetSchemaAllowlistParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// ListSchemasParams defines parameters for ListSchemas.
+type ListSchemasParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-// GetDocumentation operation middleware
-func (siw *ServerInterfaceWrapper) GetDocumentation(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// PrewarmSchemasParams defines parameters for PrewarmSchemas.
+type PrewarmSchemasParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetDocumentation(w, r)
-	}))
+// SearchSessionsParams defines parameters for SearchSessions.
+type SearchSessionsParams struct {
+	// Tag Tag e.g: campaign:spring-airdrop
+	Tag Tag `form:"tag" json:"tag"`
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// Status Only return the sessions with this status
+	Status *SearchSessionsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-// Callback operation middleware
-func (siw *ServerInterfaceWrapper) Callback(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	var err error
+// SearchSessionsParamsStatus defines parameters for SearchSessions.
+type SearchSessionsParamsStatus string
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params CallbackParams
+// GetShadowVerificationReportParams defines parameters for GetShadowVerificationReport.
+type GetShadowVerificationReportParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	// ------------- Required query parameter "sessionID" -------------
+// GetSLIParams defines parameters for GetSLI.
+type GetSLIParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	if paramValue := r.URL.Query().Get("sessionID"); paramValue != "" {
+// GetVerificationStatsParams defines parameters for GetVerificationStats.
+type GetVerificationStatsParams struct {
+	// From First day of the range, YYYY-MM-DD
+	From *string `form:"from,omitempty" json:"from,omitempty"`
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "sessionID"})
-		return
-	}
+	// To Last day of the range, YYYY-MM-DD, today by default
+	To *string `form:"to,omitempty" json:"to,omitempty"`
 
-	err = runtime.BindQueryParameter("form", true, true, "sessionID", r.URL.Query(), &params.SessionID)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
-		return
-	}
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Callback(w, r, params)
-	}))
+// GetTagStatsParams defines parameters for GetTagStats.
+type GetTagStatsParams struct {
+	// Tag Only return the stats of this tag
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+// GetVerificationTimingsParams defines parameters for GetVerificationTimings.
+type GetVerificationTimingsParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-// Health operation middleware
-func (siw *ServerInterfaceWrapper) Health(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// ListWebhookDeadLettersParams defines parameters for ListWebhookDeadLetters.
+type ListWebhookDeadLettersParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Health(w, r)
-	}))
+// ReplayWebhookDeadLetterParams defines parameters for ReplayWebhookDeadLetter.
+type ReplayWebhookDeadLetterParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// CallbackTextBody defines parameters for Callback.
+type CallbackTextBody = string
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+// CallbackParams defines parameters for Callback.
+type CallbackParams struct {
+	// SessionID ID e.g: 89d298fa-15a6-4a1d-ab13-d1069467eedd
+	SessionID SessionID `form:"sessionID" json:"sessionID"`
 }
 
-// GetQRCodeFromStore operation middleware
-func (siw *ServerInterfaceWrapper) GetQRCodeFromStore(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// GetCampaignNullifierSessionParams defines parameters for GetCampaignNullifierSession.
+type GetCampaignNullifierSessionParams struct {
+	// CredentialType Credential type of the scope, used by the `credential-type` derivation
+	CredentialType *string `form:"credentialType,omitempty" json:"credentialType,omitempty"`
 
-	var err error
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetQRCodeFromStoreParams
+// GetCampaignNullifiersParams defines parameters for GetCampaignNullifiers.
+type GetCampaignNullifiersParams struct {
+	// CredentialType Credential type of the scope, used by the `credential-type` derivation
+	CredentialType *string `form:"credentialType,omitempty" json:"credentialType,omitempty"`
+	Nullifier      *string `form:"nullifier,omitempty" json:"nullifier,omitempty"`
 
-	// ------------- Required query parameter "id" -------------
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	if paramValue := r.URL.Query().Get("id"); paramValue != "" {
+// GetNullifierProofParams defines parameters for GetNullifierProof.
+type GetNullifierProofParams struct {
+	// Checkpoint Checkpoint id. The latest checkpoint is used when it is not set.
+	Checkpoint *int `form:"checkpoint,omitempty" json:"checkpoint,omitempty"`
+}
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "id"})
-		return
-	}
+// GetQRCodeFromStoreParams defines parameters for GetQRCodeFromStore.
+type GetQRCodeFromStoreParams struct {
+	// Id Signed QR code token e.g: 3q2-7wEjRWeJq83vASNFZ4mr
+	Id Id `form:"id" json:"id"`
 
-	err = runtime.BindQueryParameter("form", true, true, "id", r.URL.Query(), &params.Id)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
-	}
+	// IfNoneMatch ETag of a cached response
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetQRCodeFromStore(w, r, params)
-	}))
+// FetchQRCodeFromStoreParams defines parameters for FetchQRCodeFromStore.
+type FetchQRCodeFromStoreParams struct {
+	// Id Signed QR code token e.g: 3q2-7wEjRWeJq83vASNFZ4mr
+	Id Id `form:"id" json:"id"`
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// CreateCredentialOfferParams defines parameters for CreateCredentialOffer.
+type CreateCredentialOfferParams struct {
+	// Offer Name of the credential offer, optional when a single offer is configured
+	Offer *string `form:"offer,omitempty" json:"offer,omitempty"`
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-// SignIn operation middleware
-func (siw *ServerInterfaceWrapper) SignIn(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// GetSessionEventsParams defines parameters for GetSessionEvents.
+type GetSessionEventsParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SignIn(w, r)
-	}))
+// FinalizeSessionParams defines parameters for FinalizeSession.
+type FinalizeSessionParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// GetSessionResultParams defines parameters for GetSessionResult.
+type GetSessionResultParams struct {
+	// Consume Delete the result once it is returned
+	Consume *bool `form:"consume,omitempty" json:"consume,omitempty"`
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-// Status operation middleware
-func (siw *ServerInterfaceWrapper) Status(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// GetSessionWebhooksParams defines parameters for GetSessionWebhooks.
+type GetSessionWebhooksParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	var err error
+// SignInParams defines parameters for SignIn.
+type SignInParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params StatusParams
+// SignInAuthParams defines parameters for SignInAuth.
+type SignInAuthParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	// ------------- Required query parameter "sessionID" -------------
+// SignInBatchParams defines parameters for SignInBatch.
+type SignInBatchParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	if paramValue := r.URL.Query().Get("sessionID"); paramValue != "" {
+// SignInFlowParams defines parameters for SignInFlow.
+type SignInFlowParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
+}
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "sessionID"})
-		return
-	}
+// SignInLinkParams defines parameters for SignInLink.
+type SignInLinkParams struct {
+	// TemplateId Name of the query template e.g: kyc-age-over-18
+	TemplateId string `form:"templateId" json:"templateId"`
 
-	err = runtime.BindQueryParameter("form", true, true, "sessionID", r.URL.Query(), &params.SessionID)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
-		return
-	}
+	// ChainId Chain ID e.g: 80002
+	ChainId string `form:"chainId" json:"chainId"`
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Status(w, r, params)
-	}))
+	// LinkType Type of the redirect link
+	LinkType *SignInLinkParamsLinkType `form:"linkType,omitempty" json:"linkType,omitempty"`
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// Expires Expiration of a signed link, in unix seconds
+	Expires *int64 `form:"expires,omitempty" json:"expires,omitempty"`
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
+	// Signature HMAC-SHA256 of the link with an API key, hex encoded. Accepted instead of the X-API-Key header.
+	Signature *string `form:"signature,omitempty" json:"signature,omitempty"`
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+// SignInLinkParamsLinkType defines parameters for SignInLink.
+type SignInLinkParamsLinkType string
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
+// SignInUniqueParams defines parameters for SignInUnique.
+type SignInUniqueParams struct {
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+// StatusParams defines parameters for Status.
+type StatusParams struct {
+	// SessionID ID e.g: 89d298fa-15a6-4a1d-ab13-d1069467eedd
+	SessionID SessionID `form:"sessionID" json:"sessionID"`
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	// Debug Include the timings of the verification of the session. Requires the API key that created the session, a key of its tenant or an admin key.
+	Debug *bool `form:"debug,omitempty" json:"debug,omitempty"`
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-type RequiredParamError struct {
-	ParamName string
-}
+// ListUsersParams defines parameters for ListUsers.
+type ListUsersParams struct {
+	// CredentialType Only the users that verified the credential type
+	CredentialType *string `form:"credentialType,omitempty" json:"credentialType,omitempty"`
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	// TenantID ID of the tenant whose users are read
+	TenantID *string `form:"tenantID,omitempty" json:"tenantID,omitempty"`
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+// GetUserParams defines parameters for GetUser.
+type GetUserParams struct {
+	// TenantID ID of the tenant whose users are read
+	TenantID *string `form:"tenantID,omitempty" json:"tenantID,omitempty"`
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
+	// XAPIKey API key. Required when the deployment restricts the access to its chains.
+	XAPIKey *ApiKey `json:"X-API-Key,omitempty"`
 }
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+// SetIssuerPolicyJSONRequestBody defines body for SetIssuerPolicy for application/json ContentType.
+type SetIssuerPolicyJSONRequestBody = IssuerPolicyRequest
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+// SetQueryTemplateJSONRequestBody defines body for SetQueryTemplate for application/json ContentType.
+type SetQueryTemplateJSONRequestBody = QueryTemplateRequest
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+// SetReasonTemplateJSONRequestBody defines body for SetReasonTemplate for application/json ContentType.
+type SetReasonTemplateJSONRequestBody = ReasonTemplateRequest
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+// SetSchemaAllowlistJSONRequestBody defines body for SetSchemaAllowlist for application/json ContentType.
+type SetSchemaAllowlistJSONRequestBody = SchemaAllowlist
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+// PrewarmSchemasJSONRequestBody defines body for PrewarmSchemas for application/json ContentType.
+type PrewarmSchemasJSONRequestBody = PrewarmSchemasRequest
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+// CallbackTextRequestBody defines body for Callback for text/plain ContentType.
+type CallbackTextRequestBody = CallbackTextBody
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+// CredentialRevocationStatusJSONRequestBody defines body for CredentialRevocationStatus for application/json ContentType.
+type CredentialRevocationStatusJSONRequestBody = RevocationStatusRequest
+
+// FetchQRCodeFromStoreJSONRequestBody defines body for FetchQRCodeFromStore for application/json ContentType.
+type FetchQRCodeFromStoreJSONRequestBody = QRStoreFetchRequest
+
+// CreateSandboxKeyJSONRequestBody defines body for CreateSandboxKey for application/json ContentType.
+type CreateSandboxKeyJSONRequestBody = SandboxKeyRequest
+
+// VerifySandboxKeyJSONRequestBody defines body for VerifySandboxKey for application/json ContentType.
+type VerifySandboxKeyJSONRequestBody = SandboxKeyVerifyRequest
+
+// CheckOnChainProofsJSONRequestBody defines body for CheckOnChainProofs for application/json ContentType.
+type CheckOnChainProofsJSONRequestBody = OnChainProofsRequest
+
+// SignInJSONRequestBody defines body for SignIn for application/json ContentType.
+type SignInJSONRequestBody = SignInRequest
+
+// SignInAuthJSONRequestBody defines body for SignInAuth for application/json ContentType.
+type SignInAuthJSONRequestBody = SignInAuthRequest
+
+// SignInBatchJSONRequestBody defines body for SignInBatch for application/json ContentType.
+type SignInBatchJSONRequestBody = SignInBatchRequest
+
+// SignInFlowJSONRequestBody defines body for SignInFlow for application/json ContentType.
+type SignInFlowJSONRequestBody = SignInFlowRequest
+
+// SignInUniqueJSONRequestBody defines body for SignInUnique for application/json ContentType.
+type SignInUniqueJSONRequestBody = SignInUniqueRequest
+
+// BuildQueryJSONRequestBody defines body for BuildQuery for application/json ContentType.
+type BuildQueryJSONRequestBody = BuildQueryRequest
+
+// UnpackMessageJSONRequestBody defines body for UnpackMessage for application/json ContentType.
+type UnpackMessageJSONRequestBody = UnpackRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Get the documentation
+	// (GET /)
+	GetDocumentation(w http.ResponseWriter, r *http.Request)
+	// Get the DID document of the verifier
+	// (GET /.well-known/did.json)
+	GetDIDDocument(w http.ResponseWriter, r *http.Request)
+	// Get the verifier public keys
+	// (GET /.well-known/jwks.json)
+	GetJWKS(w http.ResponseWriter, r *http.Request)
+	// List the loaded verification keys
+	// (GET /admin/circuits)
+	ListCircuitKeys(w http.ResponseWriter, r *http.Request, params ListCircuitKeysParams)
+	// Reload the verification keys
+	// (POST /admin/circuits/reload)
+	ReloadCircuitKeys(w http.ResponseWriter, r *http.Request, params ReloadCircuitKeysParams)
+	// Get the issuer policy
+	// (GET /admin/issuer-policy)
+	GetIssuerPolicy(w http.ResponseWriter, r *http.Request, params GetIssuerPolicyParams)
+	// Remove the trusted issuers of a credential type
+	// (DELETE /admin/issuer-policy/{credentialType})
+	DeleteIssuerPolicy(w http.ResponseWriter, r *http.Request, credentialType CredentialType, params DeleteIssuerPolicyParams)
+	// Set the trusted issuers of a credential type
+	// (PUT /admin/issuer-policy/{credentialType})
+	SetIssuerPolicy(w http.ResponseWriter, r *http.Request, credentialType CredentialType, params SetIssuerPolicyParams)
+	// List the keys of the verifier
+	// (GET /admin/keys)
+	ListVerifierKeys(w http.ResponseWriter, r *http.Request, params ListVerifierKeysParams)
+	// Rotate the keys of the verifier
+	// (POST /admin/keys/rotate)
+	RotateVerifierKeys(w http.ResponseWriter, r *http.Request, params RotateVerifierKeysParams)
+	// List the query templates
+	// (GET /admin/query-templates)
+	ListQueryTemplates(w http.ResponseWriter, r *http.Request, params ListQueryTemplatesParams)
+	// Delete a query template
+	// (DELETE /admin/query-templates/{templateName})
+	DeleteQueryTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params DeleteQueryTemplateParams)
+	// Get a query template
+	// (GET /admin/query-templates/{templateName})
+	GetQueryTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params GetQueryTemplateParams)
+	// Create or replace a query template
+	// (PUT /admin/query-templates/{templateName})
+	SetQueryTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params SetQueryTemplateParams)
+	// List the reason templates
+	// (GET /admin/reason-templates)
+	ListReasonTemplates(w http.ResponseWriter, r *http.Request, params ListReasonTemplatesParams)
+	// Delete a reason template
+	// (DELETE /admin/reason-templates/{templateName})
+	DeleteReasonTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params DeleteReasonTemplateParams)
+	// Create or replace a reason template
+	// (PUT /admin/reason-templates/{templateName})
+	SetReasonTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params SetReasonTemplateParams)
+	// Get the schema allowlist
+	// (GET /admin/schema-allowlist)
+	GetSchemaAllowlist(w http.ResponseWriter, r *http.Request, params GetSchemaAllowlistParams)
+	// Replace the schema allowlist
+	// (PUT /admin/schema-allowlist)
+	SetSchemaAllowlist(w http.ResponseWriter, r *http.Request, params SetSchemaAllowlistParams)
+	// List the pinned JSON-LD documents
+	// (GET /admin/schemas)
+	ListSchemas(w http.ResponseWriter, r *http.Request, params ListSchemasParams)
+	// Prewarm the JSON-LD documents
+	// (POST /admin/schemas/prewarm)
+	PrewarmSchemas(w http.ResponseWriter, r *http.Request, params PrewarmSchemasParams)
+	// Search the sessions by tag
+	// (GET /admin/sessions)
+	SearchSessions(w http.ResponseWriter, r *http.Request, params SearchSessionsParams)
+	// Get the shadow verification report
+	// (GET /admin/shadow-verification)
+	GetShadowVerificationReport(w http.ResponseWriter, r *http.Request, params GetShadowVerificationReportParams)
+	// Get the service level indicators
+	// (GET /admin/sli)
+	GetSLI(w http.ResponseWriter, r *http.Request, params GetSLIParams)
+	// Get the verification statistics
+	// (GET /admin/stats)
+	GetVerificationStats(w http.ResponseWriter, r *http.Request, params GetVerificationStatsParams)
+	// Get the funnel stats of the tags
+	// (GET /admin/tags/stats)
+	GetTagStats(w http.ResponseWriter, r *http.Request, params GetTagStatsParams)
+	// Get the verification timings
+	// (GET /admin/verification-timings)
+	GetVerificationTimings(w http.ResponseWriter, r *http.Request, params GetVerificationTimingsParams)
+	// List the failed webhook deliveries
+	// (GET /admin/webhooks/dead-letters)
+	ListWebhookDeadLetters(w http.ResponseWriter, r *http.Request, params ListWebhookDeadLettersParams)
+	// Replay a failed webhook delivery
+	// (POST /admin/webhooks/dead-letters/{deliveryID}/replay)
+	ReplayWebhookDeadLetter(w http.ResponseWriter, r *http.Request, deliveryID DeliveryID, params ReplayWebhookDeadLetterParams)
+	// Callback
+	// (POST /callback)
+	Callback(w http.ResponseWriter, r *http.Request, params CallbackParams)
+	// Get the nullifier session of a campaign
+	// (GET /campaigns/{campaign}/nullifier-session)
+	GetCampaignNullifierSession(w http.ResponseWriter, r *http.Request, campaign Campaign, params GetCampaignNullifierSessionParams)
+	// Get the nullifiers of a campaign
+	// (GET /campaigns/{campaign}/nullifiers)
+	GetCampaignNullifiers(w http.ResponseWriter, r *http.Request, campaign Campaign, params GetCampaignNullifiersParams)
+	// Get the supported networks
+	// (GET /config/networks)
+	GetNetworks(w http.ResponseWriter, r *http.Request)
+	// Check credential revocation status
+	// (POST /credentials/revocation-status)
+	CredentialRevocationStatus(w http.ResponseWriter, r *http.Request)
+	// Health Check
+	// (GET /health)
+	Health(w http.ResponseWriter, r *http.Request)
+	// Get the nullifier registry checkpoints
+	// (GET /nullifiers/checkpoints)
+	GetNullifierCheckpoints(w http.ResponseWriter, r *http.Request)
+	// Get a proof of inclusion or non-inclusion of a nullifier
+	// (GET /nullifiers/{nullifier}/proof)
+	GetNullifierProof(w http.ResponseWriter, r *http.Request, nullifier Nullifier, params GetNullifierProofParams)
+	// Get QRCode from store
+	// (GET /qr-store)
+	GetQRCodeFromStore(w http.ResponseWriter, r *http.Request, params GetQRCodeFromStoreParams)
+	// Fetch QRCode from store for a wallet
+	// (POST /qr-store)
+	FetchQRCodeFromStore(w http.ResponseWriter, r *http.Request, params FetchQRCodeFromStoreParams)
+	// Create a sandbox API key
+	// (POST /sandbox/keys)
+	CreateSandboxKey(w http.ResponseWriter, r *http.Request)
+	// Verify email and create a sandbox API key
+	// (POST /sandbox/keys/verify)
+	VerifySandboxKey(w http.ResponseWriter, r *http.Request)
+	// Create a credential offer for the user of a session
+	// (POST /sessions/{sessionID}/credential-offer)
+	CreateCredentialOffer(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params CreateCredentialOfferParams)
+	// Get the events of a session
+	// (GET /sessions/{sessionID}/events)
+	GetSessionEvents(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params GetSessionEventsParams)
+	// Finalize a session
+	// (POST /sessions/{sessionID}/finalize)
+	FinalizeSession(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params FinalizeSessionParams)
+	// Check the proofs of an on-chain session
+	// (POST /sessions/{sessionID}/onchain-proofs)
+	CheckOnChainProofs(w http.ResponseWriter, r *http.Request, sessionID PathSessionID)
+	// Get the result of a session
+	// (GET /sessions/{sessionID}/result)
+	GetSessionResult(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params GetSessionResultParams)
+	// Get the webhook deliveries of a session
+	// (GET /sessions/{sessionID}/webhooks)
+	GetSessionWebhooks(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params GetSessionWebhooksParams)
+	// Sign in
+	// (POST /sign-in)
+	SignIn(w http.ResponseWriter, r *http.Request, params SignInParams)
+	// Sign in proving only the ownership of an identity
+	// (POST /sign-in/auth)
+	SignInAuth(w http.ResponseWriter, r *http.Request, params SignInAuthParams)
+	// Sign in batch
+	// (POST /sign-in/batch)
+	SignInBatch(w http.ResponseWriter, r *http.Request, params SignInBatchParams)
+	// Sign in with a verification flow
+	// (POST /sign-in/flow/{name})
+	SignInFlow(w http.ResponseWriter, r *http.Request, name FlowName, params SignInFlowParams)
+	// Sign in link
+	// (GET /sign-in/link)
+	SignInLink(w http.ResponseWriter, r *http.Request, params SignInLinkParams)
+	// Sign in once per campaign
+	// (POST /sign-in/unique)
+	SignInUnique(w http.ResponseWriter, r *http.Request, params SignInUniqueParams)
+	// Get Status
+	// (GET /status)
+	Status(w http.ResponseWriter, r *http.Request, params StatusParams)
+	// Get the public keys of a tenant
+	// (GET /tenants/{tenantID}/.well-known/jwks.json)
+	GetTenantJWKS(w http.ResponseWriter, r *http.Request, tenantID TenantID)
+	// Build a scope query
+	// (POST /tools/build-query)
+	BuildQuery(w http.ResponseWriter, r *http.Request)
+	// Decode an iden3comm message
+	// (POST /tools/unpack)
+	UnpackMessage(w http.ResponseWriter, r *http.Request)
+	// List the verified users
+	// (GET /users)
+	ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams)
+	// Get a verified user
+	// (GET /users/{did})
+	GetUser(w http.ResponseWriter, r *http.Request, did Did, params GetUserParams)
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// Get the documentation
+// (GET /)
+func (_ Unimplemented) GetDocumentation(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+// Get the DID document of the verifier
+// (GET /.well-known/did.json)
+func (_ Unimplemented) GetDIDDocument(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// Get the verifier public keys
+// (GET /.well-known/jwks.json)
+func (_ Unimplemented) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+// List the loaded verification keys
+// (GET /admin/circuits)
+func (_ Unimplemented) ListCircuitKeys(w http.ResponseWriter, r *http.Request, params ListCircuitKeysParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/", wrapper.GetDocumentation)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/callback", wrapper.Callback)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/health", wrapper.Health)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/qr-store", wrapper.GetQRCodeFromStore)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/sign-in", wrapper.SignIn)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/status", wrapper.Status)
-	})
+// Reload the verification keys
+// (POST /admin/circuits/reload)
+func (_ Unimplemented) ReloadCircuitKeys(w http.ResponseWriter, r *http.Request, params ReloadCircuitKeysParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	return r
+// Get the issuer policy
+// (GET /admin/issuer-policy)
+func (_ Unimplemented) GetIssuerPolicy(w http.ResponseWriter, r *http.Request, params GetIssuerPolicyParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type N400JSONResponse GenericErrorMessage
+// Remove the trusted issuers of a credential type
+// (DELETE /admin/issuer-policy/{credentialType})
+func (_ Unimplemented) DeleteIssuerPolicy(w http.ResponseWriter, r *http.Request, credentialType CredentialType, params DeleteIssuerPolicyParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-type N404JSONResponse GenericErrorMessage
+// Set the trusted issuers of a credential type
+// (PUT /admin/issuer-policy/{credentialType})
+func (_ Unimplemented) SetIssuerPolicy(w http.ResponseWriter, r *http.Request, credentialType CredentialType, params SetIssuerPolicyParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-type N500JSONResponse GenericErrorMessage
+// List the keys of the verifier
+// (GET /admin/keys)
+func (_ Unimplemented) ListVerifierKeys(w http.ResponseWriter, r *http.Request, params ListVerifierKeysParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-type GetDocumentationRequestObject struct {
+// Rotate the keys of the verifier
+// (POST /admin/keys/rotate)
+func (_ Unimplemented) RotateVerifierKeys(w http.ResponseWriter, r *http.Request, params RotateVerifierKeysParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type GetDocumentationResponseObject interface {
-	VisitGetDocumentationResponse(w http.ResponseWriter) error
+// List the query templates
+// (GET /admin/query-templates)
+func (_ Unimplemented) ListQueryTemplates(w http.ResponseWriter, r *http.Request, params ListQueryTemplatesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type GetDocumentation200Response struct {
+// Delete a query template
+// (DELETE /admin/query-templates/{templateName})
+func (_ Unimplemented) DeleteQueryTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params DeleteQueryTemplateParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-func (response GetDocumentation200Response) VisitGetDocumentationResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
+// Get a query template
+// (GET /admin/query-templates/{templateName})
+func (_ Unimplemented) GetQueryTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params GetQueryTemplateParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type CallbackRequestObject struct {
-	Params CallbackParams
-	Body   *CallbackTextRequestBody
+// Create or replace a query template
+// (PUT /admin/query-templates/{templateName})
+func (_ Unimplemented) SetQueryTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params SetQueryTemplateParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type CallbackResponseObject interface {
-	VisitCallbackResponse(w http.ResponseWriter) error
+// List the reason templates
+// (GET /admin/reason-templates)
+func (_ Unimplemented) ListReasonTemplates(w http.ResponseWriter, r *http.Request, params ListReasonTemplatesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type Callback200JSONResponse CallbackResponse
+// Delete a reason template
+// (DELETE /admin/reason-templates/{templateName})
+func (_ Unimplemented) DeleteReasonTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params DeleteReasonTemplateParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-func (response Callback200JSONResponse) VisitCallbackResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// Create or replace a reason template
+// (PUT /admin/reason-templates/{templateName})
+func (_ Unimplemented) SetReasonTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params SetReasonTemplateParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	return json.NewEncoder(w).Encode(response)
+// Get the schema allowlist
+// (GET /admin/schema-allowlist)
+func (_ Unimplemented) GetSchemaAllowlist(w http.ResponseWriter, r *http.Request, params GetSchemaAllowlistParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type Callback404JSONResponse struct{ N404JSONResponse }
+// Replace the schema allowlist
+// (PUT /admin/schema-allowlist)
+func (_ Unimplemented) SetSchemaAllowlist(w http.ResponseWriter, r *http.Request, params SetSchemaAllowlistParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-func (response Callback404JSONResponse) VisitCallbackResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+// List the pinned JSON-LD documents
+// (GET /admin/schemas)
+func (_ Unimplemented) ListSchemas(w http.ResponseWriter, r *http.Request, params ListSchemasParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	return json.NewEncoder(w).Encode(response)
+// Prewarm the JSON-LD documents
+// (POST /admin/schemas/prewarm)
+func (_ Unimplemented) PrewarmSchemas(w http.ResponseWriter, r *http.Request, params PrewarmSchemasParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type Callback500JSONResponse struct{ N500JSONResponse }
+// Search the sessions by tag
+// (GET /admin/sessions)
+func (_ Unimplemented) SearchSessions(w http.ResponseWriter, r *http.Request, params SearchSessionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-func (response Callback500JSONResponse) VisitCallbackResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// Get the shadow verification report
+// (GET /admin/shadow-verification)
+func (_ Unimplemented) GetShadowVerificationReport(w http.ResponseWriter, r *http.Request, params GetShadowVerificationReportParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	return json.NewEncoder(w).Encode(response)
+// Get the service level indicators
+// (GET /admin/sli)
+func (_ Unimplemented) GetSLI(w http.ResponseWriter, r *http.Request, params GetSLIParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type HealthRequestObject struct {
+// Get the verification statistics
+// (GET /admin/stats)
+func (_ Unimplemented) GetVerificationStats(w http.ResponseWriter, r *http.Request, params GetVerificationStatsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type HealthResponseObject interface {
-	VisitHealthResponse(w http.ResponseWriter) error
+// Get the funnel stats of the tags
+// (GET /admin/tags/stats)
+func (_ Unimplemented) GetTagStats(w http.ResponseWriter, r *http.Request, params GetTagStatsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type Health200JSONResponse Health
+// Get the verification timings
+// (GET /admin/verification-timings)
+func (_ Unimplemented) GetVerificationTimings(w http.ResponseWriter, r *http.Request, params GetVerificationTimingsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// List the failed webhook deliveries
+// (GET /admin/webhooks/dead-letters)
+func (_ Unimplemented) ListWebhookDeadLetters(w http.ResponseWriter, r *http.Request, params ListWebhookDeadLettersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	return json.NewEncoder(w).Encode(response)
+// Replay a failed webhook delivery
+// (POST /admin/webhooks/dead-letters/{deliveryID}/replay)
+func (_ Unimplemented) ReplayWebhookDeadLetter(w http.ResponseWriter, r *http.Request, deliveryID DeliveryID, params ReplayWebhookDeadLetterParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type Health500JSONResponse struct{ N500JSONResponse }
+// Callback
+// (POST /callback)
+func (_ Unimplemented) Callback(w http.ResponseWriter, r *http.Request, params CallbackParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-func (response Health500JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// Get the nullifier session of a campaign
+// (GET /campaigns/{campaign}/nullifier-session)
+func (_ Unimplemented) GetCampaignNullifierSession(w http.ResponseWriter, r *http.Request, campaign Campaign, params GetCampaignNullifierSessionParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	return json.NewEncoder(w).Encode(response)
+// Get the nullifiers of a campaign
+// (GET /campaigns/{campaign}/nullifiers)
+func (_ Unimplemented) GetCampaignNullifiers(w http.ResponseWriter, r *http.Request, campaign Campaign, params GetCampaignNullifiersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type GetQRCodeFromStoreRequestObject struct {
-	Params GetQRCodeFromStoreParams
+// Get the supported networks
+// (GET /config/networks)
+func (_ Unimplemented) GetNetworks(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type GetQRCodeFromStoreResponseObject interface {
-	VisitGetQRCodeFromStoreResponse(w http.ResponseWriter) error
+// Check credential revocation status
+// (POST /credentials/revocation-status)
+func (_ Unimplemented) CredentialRevocationStatus(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Health Check
+// (GET /health)
+func (_ Unimplemented) Health(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type GetQRCodeFromStore200JSONResponse QRCode
+// Get the nullifier registry checkpoints
+// (GET /nullifiers/checkpoints)
+func (_ Unimplemented) GetNullifierCheckpoints(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-func (response GetQRCodeFromStore200JSONResponse) VisitGetQRCodeFromStoreResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// Get a proof of inclusion or non-inclusion of a nullifier
+// (GET /nullifiers/{nullifier}/proof)
+func (_ Unimplemented) GetNullifierProof(w http.ResponseWriter, r *http.Request, nullifier Nullifier, params GetNullifierProofParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	return json.NewEncoder(w).Encode(response)
+// Get QRCode from store
+// (GET /qr-store)
+func (_ Unimplemented) GetQRCodeFromStore(w http.ResponseWriter, r *http.Request, params GetQRCodeFromStoreParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type GetQRCodeFromStore404JSONResponse struct{ N404JSONResponse }
+// Fetch QRCode from store for a wallet
+// (POST /qr-store)
+func (_ Unimplemented) FetchQRCodeFromStore(w http.ResponseWriter, r *http.Request, params FetchQRCodeFromStoreParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-func (response GetQRCodeFromStore404JSONResponse) VisitGetQRCodeFromStoreResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+// Create a sandbox API key
+// (POST /sandbox/keys)
+func (_ Unimplemented) CreateSandboxKey(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	return json.NewEncoder(w).Encode(response)
+// Verify email and create a sandbox API key
+// (POST /sandbox/keys/verify)
+func (_ Unimplemented) VerifySandboxKey(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type GetQRCodeFromStore500JSONResponse struct{ N500JSONResponse }
+// Create a credential offer for the user of a session
+// (POST /sessions/{sessionID}/credential-offer)
+func (_ Unimplemented) CreateCredentialOffer(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params CreateCredentialOfferParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-func (response GetQRCodeFromStore500JSONResponse) VisitGetQRCodeFromStoreResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// Get the events of a session
+// (GET /sessions/{sessionID}/events)
+func (_ Unimplemented) GetSessionEvents(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params GetSessionEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	return json.NewEncoder(w).Encode(response)
+// Finalize a session
+// (POST /sessions/{sessionID}/finalize)
+func (_ Unimplemented) FinalizeSession(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params FinalizeSessionParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type SignInRequestObject struct {
-	Body *SignInJSONRequestBody
+// Check the proofs of an on-chain session
+// (POST /sessions/{sessionID}/onchain-proofs)
+func (_ Unimplemented) CheckOnChainProofs(w http.ResponseWriter, r *http.Request, sessionID PathSessionID) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type SignInResponseObject interface {
-	VisitSignInResponse(w http.ResponseWriter) error
+// Get the result of a session
+// (GET /sessions/{sessionID}/result)
+func (_ Unimplemented) GetSessionResult(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params GetSessionResultParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the webhook deliveries of a session
+// (GET /sessions/{sessionID}/webhooks)
+func (_ Unimplemented) GetSessionWebhooks(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params GetSessionWebhooksParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Sign in
+// (POST /sign-in)
+func (_ Unimplemented) SignIn(w http.ResponseWriter, r *http.Request, params SignInParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type SignIn200JSONResponse SingInResponse
+// Sign in proving only the ownership of an identity
+// (POST /sign-in/auth)
+func (_ Unimplemented) SignInAuth(w http.ResponseWriter, r *http.Request, params SignInAuthParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Sign in batch
+// (POST /sign-in/batch)
+func (_ Unimplemented) SignInBatch(w http.ResponseWriter, r *http.Request, params SignInBatchParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Sign in with a verification flow
+// (POST /sign-in/flow/{name})
+func (_ Unimplemented) SignInFlow(w http.ResponseWriter, r *http.Request, name FlowName, params SignInFlowParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Sign in link
+// (GET /sign-in/link)
+func (_ Unimplemented) SignInLink(w http.ResponseWriter, r *http.Request, params SignInLinkParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Sign in once per campaign
+// (POST /sign-in/unique)
+func (_ Unimplemented) SignInUnique(w http.ResponseWriter, r *http.Request, params SignInUniqueParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get Status
+// (GET /status)
+func (_ Unimplemented) Status(w http.ResponseWriter, r *http.Request, params StatusParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the public keys of a tenant
+// (GET /tenants/{tenantID}/.well-known/jwks.json)
+func (_ Unimplemented) GetTenantJWKS(w http.ResponseWriter, r *http.Request, tenantID TenantID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Build a scope query
+// (POST /tools/build-query)
+func (_ Unimplemented) BuildQuery(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Decode an iden3comm message
+// (POST /tools/unpack)
+func (_ Unimplemented) UnpackMessage(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the verified users
+// (GET /users)
+func (_ Unimplemented) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a verified user
+// (GET /users/{did})
+func (_ Unimplemented) GetUser(w http.ResponseWriter, r *http.Request, did Did, params GetUserParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetDocumentation operation middleware
+func (siw *ServerInterfaceWrapper) GetDocumentation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDocumentation(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetDIDDocument operation middleware
+func (siw *ServerInterfaceWrapper) GetDIDDocument(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDIDDocument(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetJWKS operation middleware
+func (siw *ServerInterfaceWrapper) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetJWKS(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListCircuitKeys operation middleware
+func (siw *ServerInterfaceWrapper) ListCircuitKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCircuitKeysParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCircuitKeys(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReloadCircuitKeys operation middleware
+func (siw *ServerInterfaceWrapper) ReloadCircuitKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReloadCircuitKeysParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReloadCircuitKeys(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetIssuerPolicy operation middleware
+func (siw *ServerInterfaceWrapper) GetIssuerPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetIssuerPolicyParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetIssuerPolicy(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteIssuerPolicy operation middleware
+func (siw *ServerInterfaceWrapper) DeleteIssuerPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "credentialType" -------------
+	var credentialType CredentialType
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "credentialType", runtime.ParamLocationPath, chi.URLParam(r, "credentialType"), &credentialType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "credentialType", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteIssuerPolicyParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteIssuerPolicy(w, r, credentialType, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetIssuerPolicy operation middleware
+func (siw *ServerInterfaceWrapper) SetIssuerPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "credentialType" -------------
+	var credentialType CredentialType
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "credentialType", runtime.ParamLocationPath, chi.URLParam(r, "credentialType"), &credentialType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "credentialType", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetIssuerPolicyParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetIssuerPolicy(w, r, credentialType, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListVerifierKeys operation middleware
+func (siw *ServerInterfaceWrapper) ListVerifierKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListVerifierKeysParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListVerifierKeys(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RotateVerifierKeys operation middleware
+func (siw *ServerInterfaceWrapper) RotateVerifierKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RotateVerifierKeysParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RotateVerifierKeys(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListQueryTemplates operation middleware
+func (siw *ServerInterfaceWrapper) ListQueryTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListQueryTemplatesParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListQueryTemplates(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteQueryTemplate operation middleware
+func (siw *ServerInterfaceWrapper) DeleteQueryTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "templateName" -------------
+	var templateName TemplateName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "templateName", runtime.ParamLocationPath, chi.URLParam(r, "templateName"), &templateName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "templateName", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteQueryTemplateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteQueryTemplate(w, r, templateName, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetQueryTemplate operation middleware
+func (siw *ServerInterfaceWrapper) GetQueryTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "templateName" -------------
+	var templateName TemplateName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "templateName", runtime.ParamLocationPath, chi.URLParam(r, "templateName"), &templateName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "templateName", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetQueryTemplateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetQueryTemplate(w, r, templateName, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetQueryTemplate operation middleware
+func (siw *ServerInterfaceWrapper) SetQueryTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "templateName" -------------
+	var templateName TemplateName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "templateName", runtime.ParamLocationPath, chi.URLParam(r, "templateName"), &templateName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "templateName", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetQueryTemplateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetQueryTemplate(w, r, templateName, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListReasonTemplates operation middleware
+func (siw *ServerInterfaceWrapper) ListReasonTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListReasonTemplatesParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListReasonTemplates(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteReasonTemplate operation middleware
+func (siw *ServerInterfaceWrapper) DeleteReasonTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "templateName" -------------
+	var templateName TemplateName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "templateName", runtime.ParamLocationPath, chi.URLParam(r, "templateName"), &templateName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "templateName", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteReasonTemplateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteReasonTemplate(w, r, templateName, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetReasonTemplate operation middleware
+func (siw *ServerInterfaceWrapper) SetReasonTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "templateName" -------------
+	var templateName TemplateName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "templateName", runtime.ParamLocationPath, chi.URLParam(r, "templateName"), &templateName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "templateName", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetReasonTemplateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetReasonTemplate(w, r, templateName, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetSchemaAllowlist operation middleware
+func (siw *ServerInterfaceWrapper) GetSchemaAllowlist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSchemaAllowlistParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSchemaAllowlist(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetSchemaAllowlist operation middleware
+func (siw *ServerInterfaceWrapper) SetSchemaAllowlist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetSchemaAllowlistParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetSchemaAllowlist(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListSchemas operation middleware
+func (siw *ServerInterfaceWrapper) ListSchemas(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListSchemasParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSchemas(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PrewarmSchemas operation middleware
+func (siw *ServerInterfaceWrapper) PrewarmSchemas(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PrewarmSchemasParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PrewarmSchemas(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SearchSessions operation middleware
+func (siw *ServerInterfaceWrapper) SearchSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchSessionsParams
+
+	// ------------- Required query parameter "tag" -------------
+
+	if paramValue := r.URL.Query().Get("tag"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "tag"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchSessions(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetShadowVerificationReport operation middleware
+func (siw *ServerInterfaceWrapper) GetShadowVerificationReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetShadowVerificationReportParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetShadowVerificationReport(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetSLI operation middleware
+func (siw *ServerInterfaceWrapper) GetSLI(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSLIParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSLI(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetVerificationStats operation middleware
+func (siw *ServerInterfaceWrapper) GetVerificationStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetVerificationStatsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetVerificationStats(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTagStats operation middleware
+func (siw *ServerInterfaceWrapper) GetTagStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTagStatsParams
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTagStats(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetVerificationTimings operation middleware
+func (siw *ServerInterfaceWrapper) GetVerificationTimings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetVerificationTimingsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetVerificationTimings(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListWebhookDeadLetters operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeadLetters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeadLettersParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookDeadLetters(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplayWebhookDeadLetter operation middleware
+func (siw *ServerInterfaceWrapper) ReplayWebhookDeadLetter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "deliveryID" -------------
+	var deliveryID DeliveryID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "deliveryID", runtime.ParamLocationPath, chi.URLParam(r, "deliveryID"), &deliveryID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deliveryID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReplayWebhookDeadLetterParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplayWebhookDeadLetter(w, r, deliveryID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// Callback operation middleware
+func (siw *ServerInterfaceWrapper) Callback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CallbackParams
+
+	// ------------- Required query parameter "sessionID" -------------
+
+	if paramValue := r.URL.Query().Get("sessionID"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "sessionID"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "sessionID", r.URL.Query(), &params.SessionID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Callback(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetCampaignNullifierSession operation middleware
+func (siw *ServerInterfaceWrapper) GetCampaignNullifierSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "campaign" -------------
+	var campaign Campaign
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "campaign", runtime.ParamLocationPath, chi.URLParam(r, "campaign"), &campaign)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "campaign", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCampaignNullifierSessionParams
+
+	// ------------- Optional query parameter "credentialType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "credentialType", r.URL.Query(), &params.CredentialType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "credentialType", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCampaignNullifierSession(w, r, campaign, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetCampaignNullifiers operation middleware
+func (siw *ServerInterfaceWrapper) GetCampaignNullifiers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "campaign" -------------
+	var campaign Campaign
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "campaign", runtime.ParamLocationPath, chi.URLParam(r, "campaign"), &campaign)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "campaign", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCampaignNullifiersParams
+
+	// ------------- Optional query parameter "credentialType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "credentialType", r.URL.Query(), &params.CredentialType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "credentialType", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "nullifier" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "nullifier", r.URL.Query(), &params.Nullifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nullifier", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCampaignNullifiers(w, r, campaign, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetNetworks operation middleware
+func (siw *ServerInterfaceWrapper) GetNetworks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetNetworks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CredentialRevocationStatus operation middleware
+func (siw *ServerInterfaceWrapper) CredentialRevocationStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CredentialRevocationStatus(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// Health operation middleware
+func (siw *ServerInterfaceWrapper) Health(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Health(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetNullifierCheckpoints operation middleware
+func (siw *ServerInterfaceWrapper) GetNullifierCheckpoints(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetNullifierCheckpoints(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetNullifierProof operation middleware
+func (siw *ServerInterfaceWrapper) GetNullifierProof(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "nullifier" -------------
+	var nullifier Nullifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "nullifier", runtime.ParamLocationPath, chi.URLParam(r, "nullifier"), &nullifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nullifier", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNullifierProofParams
+
+	// ------------- Optional query parameter "checkpoint" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "checkpoint", r.URL.Query(), &params.Checkpoint)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "checkpoint", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetNullifierProof(w, r, nullifier, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetQRCodeFromStore operation middleware
+func (siw *ServerInterfaceWrapper) GetQRCodeFromStore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetQRCodeFromStoreParams
+
+	// ------------- Required query parameter "id" -------------
+
+	if paramValue := r.URL.Query().Get("id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "id", r.URL.Query(), &params.Id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, valueList[0], &IfNoneMatch)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetQRCodeFromStore(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// FetchQRCodeFromStore operation middleware
+func (siw *ServerInterfaceWrapper) FetchQRCodeFromStore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params FetchQRCodeFromStoreParams
+
+	// ------------- Required query parameter "id" -------------
+
+	if paramValue := r.URL.Query().Get("id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "id", r.URL.Query(), &params.Id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FetchQRCodeFromStore(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateSandboxKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateSandboxKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSandboxKey(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// VerifySandboxKey operation middleware
+func (siw *ServerInterfaceWrapper) VerifySandboxKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VerifySandboxKey(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateCredentialOffer operation middleware
+func (siw *ServerInterfaceWrapper) CreateCredentialOffer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "sessionID" -------------
+	var sessionID PathSessionID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "sessionID", runtime.ParamLocationPath, chi.URLParam(r, "sessionID"), &sessionID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateCredentialOfferParams
+
+	// ------------- Optional query parameter "offer" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offer", r.URL.Query(), &params.Offer)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offer", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCredentialOffer(w, r, sessionID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetSessionEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetSessionEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "sessionID" -------------
+	var sessionID PathSessionID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "sessionID", runtime.ParamLocationPath, chi.URLParam(r, "sessionID"), &sessionID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSessionEventsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSessionEvents(w, r, sessionID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// FinalizeSession operation middleware
+func (siw *ServerInterfaceWrapper) FinalizeSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "sessionID" -------------
+	var sessionID PathSessionID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "sessionID", runtime.ParamLocationPath, chi.URLParam(r, "sessionID"), &sessionID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params FinalizeSessionParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FinalizeSession(w, r, sessionID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CheckOnChainProofs operation middleware
+func (siw *ServerInterfaceWrapper) CheckOnChainProofs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "sessionID" -------------
+	var sessionID PathSessionID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "sessionID", runtime.ParamLocationPath, chi.URLParam(r, "sessionID"), &sessionID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CheckOnChainProofs(w, r, sessionID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetSessionResult operation middleware
+func (siw *ServerInterfaceWrapper) GetSessionResult(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "sessionID" -------------
+	var sessionID PathSessionID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "sessionID", runtime.ParamLocationPath, chi.URLParam(r, "sessionID"), &sessionID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSessionResultParams
+
+	// ------------- Optional query parameter "consume" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "consume", r.URL.Query(), &params.Consume)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "consume", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSessionResult(w, r, sessionID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetSessionWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) GetSessionWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "sessionID" -------------
+	var sessionID PathSessionID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "sessionID", runtime.ParamLocationPath, chi.URLParam(r, "sessionID"), &sessionID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSessionWebhooksParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSessionWebhooks(w, r, sessionID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SignIn operation middleware
+func (siw *ServerInterfaceWrapper) SignIn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SignInParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SignIn(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SignInAuth operation middleware
+func (siw *ServerInterfaceWrapper) SignInAuth(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SignInAuthParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SignInAuth(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SignInBatch operation middleware
+func (siw *ServerInterfaceWrapper) SignInBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SignInBatchParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SignInBatch(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SignInFlow operation middleware
+func (siw *ServerInterfaceWrapper) SignInFlow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name FlowName
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SignInFlowParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SignInFlow(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SignInLink operation middleware
+func (siw *ServerInterfaceWrapper) SignInLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SignInLinkParams
+
+	// ------------- Required query parameter "templateId" -------------
+
+	if paramValue := r.URL.Query().Get("templateId"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "templateId"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "templateId", r.URL.Query(), &params.TemplateId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "templateId", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "chainId" -------------
+
+	if paramValue := r.URL.Query().Get("chainId"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "chainId"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "chainId", r.URL.Query(), &params.ChainId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "chainId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "linkType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "linkType", r.URL.Query(), &params.LinkType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "linkType", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "expires" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "expires", r.URL.Query(), &params.Expires)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expires", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "signature" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "signature", r.URL.Query(), &params.Signature)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "signature", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SignInLink(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SignInUnique operation middleware
+func (siw *ServerInterfaceWrapper) SignInUnique(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SignInUniqueParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SignInUnique(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// Status operation middleware
+func (siw *ServerInterfaceWrapper) Status(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StatusParams
+
+	// ------------- Required query parameter "sessionID" -------------
+
+	if paramValue := r.URL.Query().Get("sessionID"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "sessionID"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "sessionID", r.URL.Query(), &params.SessionID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "debug" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "debug", r.URL.Query(), &params.Debug)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "debug", Err: err})
+		return
+	}
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Status(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTenantJWKS operation middleware
+func (siw *ServerInterfaceWrapper) GetTenantJWKS(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "tenantID" -------------
+	var tenantID TenantID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "tenantID", runtime.ParamLocationPath, chi.URLParam(r, "tenantID"), &tenantID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tenantID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTenantJWKS(w, r, tenantID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// BuildQuery operation middleware
+func (siw *ServerInterfaceWrapper) BuildQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BuildQuery(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UnpackMessage operation middleware
+func (siw *ServerInterfaceWrapper) UnpackMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnpackMessage(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListUsers operation middleware
+func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUsersParams
+
+	// ------------- Optional query parameter "credentialType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "credentialType", r.URL.Query(), &params.CredentialType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "credentialType", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tenantID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tenantID", r.URL.Query(), &params.TenantID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tenantID", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetUser operation middleware
+func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "did" -------------
+	var did Did
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "did", runtime.ParamLocationPath, chi.URLParam(r, "did"), &did)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "did", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserParams
+
+	// ------------- Optional query parameter "tenantID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tenantID", r.URL.Query(), &params.TenantID)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tenantID", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "X-API-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-API-Key")]; found {
+		var XAPIKey ApiKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-API-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "X-API-Key", runtime.ParamLocationHeader, valueList[0], &XAPIKey)
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-API-Key", Err: err})
+			return
+		}
+
+		params.XAPIKey = &XAPIKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUser(w, r, did, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/", wrapper.GetDocumentation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/.well-known/did.json", wrapper.GetDIDDocument)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/.well-known/jwks.json", wrapper.GetJWKS)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/circuits", wrapper.ListCircuitKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/circuits/reload", wrapper.ReloadCircuitKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/issuer-policy", wrapper.GetIssuerPolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/issuer-policy/{credentialType}", wrapper.DeleteIssuerPolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/issuer-policy/{credentialType}", wrapper.SetIssuerPolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/keys", wrapper.ListVerifierKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/keys/rotate", wrapper.RotateVerifierKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/query-templates", wrapper.ListQueryTemplates)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/query-templates/{templateName}", wrapper.DeleteQueryTemplate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/query-templates/{templateName}", wrapper.GetQueryTemplate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/query-templates/{templateName}", wrapper.SetQueryTemplate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/reason-templates", wrapper.ListReasonTemplates)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/reason-templates/{templateName}", wrapper.DeleteReasonTemplate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/reason-templates/{templateName}", wrapper.SetReasonTemplate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/schema-allowlist", wrapper.GetSchemaAllowlist)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/schema-allowlist", wrapper.SetSchemaAllowlist)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/schemas", wrapper.ListSchemas)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/schemas/prewarm", wrapper.PrewarmSchemas)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/sessions", wrapper.SearchSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/shadow-verification", wrapper.GetShadowVerificationReport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/sli", wrapper.GetSLI)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/stats", wrapper.GetVerificationStats)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/tags/stats", wrapper.GetTagStats)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/verification-timings", wrapper.GetVerificationTimings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/webhooks/dead-letters", wrapper.ListWebhookDeadLetters)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/webhooks/dead-letters/{deliveryID}/replay", wrapper.ReplayWebhookDeadLetter)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/callback", wrapper.Callback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/campaigns/{campaign}/nullifier-session", wrapper.GetCampaignNullifierSession)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/campaigns/{campaign}/nullifiers", wrapper.GetCampaignNullifiers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/config/networks", wrapper.GetNetworks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/credentials/revocation-status", wrapper.CredentialRevocationStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/health", wrapper.Health)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/nullifiers/checkpoints", wrapper.GetNullifierCheckpoints)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/nullifiers/{nullifier}/proof", wrapper.GetNullifierProof)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/qr-store", wrapper.GetQRCodeFromStore)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/qr-store", wrapper.FetchQRCodeFromStore)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/sandbox/keys", wrapper.CreateSandboxKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/sandbox/keys/verify", wrapper.VerifySandboxKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/sessions/{sessionID}/credential-offer", wrapper.CreateCredentialOffer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sessions/{sessionID}/events", wrapper.GetSessionEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/sessions/{sessionID}/finalize", wrapper.FinalizeSession)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/sessions/{sessionID}/onchain-proofs", wrapper.CheckOnChainProofs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sessions/{sessionID}/result", wrapper.GetSessionResult)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sessions/{sessionID}/webhooks", wrapper.GetSessionWebhooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/sign-in", wrapper.SignIn)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/sign-in/auth", wrapper.SignInAuth)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/sign-in/batch", wrapper.SignInBatch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/sign-in/flow/{name}", wrapper.SignInFlow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sign-in/link", wrapper.SignInLink)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/sign-in/unique", wrapper.SignInUnique)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/status", wrapper.Status)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tenants/{tenantID}/.well-known/jwks.json", wrapper.GetTenantJWKS)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tools/build-query", wrapper.BuildQuery)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tools/unpack", wrapper.UnpackMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users", wrapper.ListUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{did}", wrapper.GetUser)
+	})
+
+	return r
+}
+
+type N400JSONResponse GenericErrorMessage
+
+type N401JSONResponse GenericErrorMessage
+
+type N403JSONResponse GenericErrorMessage
+
+type N404JSONResponse GenericErrorMessage
+
+type N409JSONResponse GenericErrorMessage
+
+type N410JSONResponse GenericErrorMessage
+
+type N429JSONResponse GenericErrorMessage
+
+type N500JSONResponse GenericErrorMessage
+
+type GetDocumentationRequestObject struct {
+}
+
+type GetDocumentationResponseObject interface {
+	VisitGetDocumentationResponse(w http.ResponseWriter) error
+}
+
+type GetDocumentation200Response struct {
+}
+
+func (response GetDocumentation200Response) VisitGetDocumentationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type GetDIDDocumentRequestObject struct {
+}
+
+type GetJWKSRequestObject struct {
+}
+
+type GetDIDDocumentResponseObject interface {
+	VisitGetDIDDocumentResponse(w http.ResponseWriter) error
+}
+
+type GetJWKSResponseObject interface {
+	VisitGetJWKSResponse(w http.ResponseWriter) error
+}
+
+type GetDIDDocument200JSONResponse DIDDocument
+
+func (response GetDIDDocument200JSONResponse) VisitGetDIDDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJWKS200JSONResponse JWKS
+
+func (response GetJWKS200JSONResponse) VisitGetJWKSResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDIDDocument404JSONResponse struct{ N404JSONResponse }
+
+func (response GetDIDDocument404JSONResponse) VisitGetDIDDocumentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetJWKS404JSONResponse struct{ N404JSONResponse }
+
+func (response GetJWKS404JSONResponse) VisitGetJWKSResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCircuitKeysRequestObject struct {
+	Params ListCircuitKeysParams
+}
+
+type ListCircuitKeysResponseObject interface {
+	VisitListCircuitKeysResponse(w http.ResponseWriter) error
+}
+
+type ListCircuitKeys200JSONResponse CircuitKeys
+
+func (response ListCircuitKeys200JSONResponse) VisitListCircuitKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCircuitKeys401JSONResponse struct{ N401JSONResponse }
+
+func (response ListCircuitKeys401JSONResponse) VisitListCircuitKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReloadCircuitKeysRequestObject struct {
+	Params ReloadCircuitKeysParams
+}
+
+type ReloadCircuitKeysResponseObject interface {
+	VisitReloadCircuitKeysResponse(w http.ResponseWriter) error
+}
+
+type ReloadCircuitKeys200JSONResponse CircuitKeys
+
+func (response ReloadCircuitKeys200JSONResponse) VisitReloadCircuitKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReloadCircuitKeys401JSONResponse struct{ N401JSONResponse }
+
+func (response ReloadCircuitKeys401JSONResponse) VisitReloadCircuitKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReloadCircuitKeys500JSONResponse struct{ N500JSONResponse }
+
+func (response ReloadCircuitKeys500JSONResponse) VisitReloadCircuitKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIssuerPolicyRequestObject struct {
+	Params GetIssuerPolicyParams
+}
+
+type GetIssuerPolicyResponseObject interface {
+	VisitGetIssuerPolicyResponse(w http.ResponseWriter) error
+}
+
+type GetIssuerPolicy200JSONResponse IssuerPolicy
+
+func (response GetIssuerPolicy200JSONResponse) VisitGetIssuerPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIssuerPolicy401JSONResponse struct{ N401JSONResponse }
+
+func (response GetIssuerPolicy401JSONResponse) VisitGetIssuerPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteIssuerPolicyRequestObject struct {
+	CredentialType CredentialType `json:"credentialType"`
+	Params         DeleteIssuerPolicyParams
+}
+
+type DeleteIssuerPolicyResponseObject interface {
+	VisitDeleteIssuerPolicyResponse(w http.ResponseWriter) error
+}
+
+type DeleteIssuerPolicy200JSONResponse IssuerPolicy
+
+func (response DeleteIssuerPolicy200JSONResponse) VisitDeleteIssuerPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteIssuerPolicy401JSONResponse struct{ N401JSONResponse }
+
+func (response DeleteIssuerPolicy401JSONResponse) VisitDeleteIssuerPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetIssuerPolicyRequestObject struct {
+	CredentialType CredentialType `json:"credentialType"`
+	Params         SetIssuerPolicyParams
+	Body           *SetIssuerPolicyJSONRequestBody
+}
+
+type SetIssuerPolicyResponseObject interface {
+	VisitSetIssuerPolicyResponse(w http.ResponseWriter) error
+}
+
+type SetIssuerPolicy200JSONResponse IssuerPolicy
+
+func (response SetIssuerPolicy200JSONResponse) VisitSetIssuerPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetIssuerPolicy400JSONResponse struct{ N400JSONResponse }
+
+func (response SetIssuerPolicy400JSONResponse) VisitSetIssuerPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetIssuerPolicy401JSONResponse struct{ N401JSONResponse }
+
+func (response SetIssuerPolicy401JSONResponse) VisitSetIssuerPolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListVerifierKeysRequestObject struct {
+	Params ListVerifierKeysParams
+}
+
+type ListVerifierKeysResponseObject interface {
+	VisitListVerifierKeysResponse(w http.ResponseWriter) error
+}
+
+type ListVerifierKeys200JSONResponse VerifierKeys
+
+func (response ListVerifierKeys200JSONResponse) VisitListVerifierKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListVerifierKeys401JSONResponse struct{ N401JSONResponse }
+
+func (response ListVerifierKeys401JSONResponse) VisitListVerifierKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateVerifierKeysRequestObject struct {
+	Params RotateVerifierKeysParams
+}
+
+type RotateVerifierKeysResponseObject interface {
+	VisitRotateVerifierKeysResponse(w http.ResponseWriter) error
+}
+
+type RotateVerifierKeys200JSONResponse VerifierKeys
+
+func (response RotateVerifierKeys200JSONResponse) VisitRotateVerifierKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateVerifierKeys401JSONResponse struct{ N401JSONResponse }
+
+func (response RotateVerifierKeys401JSONResponse) VisitRotateVerifierKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateVerifierKeys500JSONResponse struct{ N500JSONResponse }
+
+func (response RotateVerifierKeys500JSONResponse) VisitRotateVerifierKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListQueryTemplatesRequestObject struct {
+	Params ListQueryTemplatesParams
+}
+
+type ListQueryTemplatesResponseObject interface {
+	VisitListQueryTemplatesResponse(w http.ResponseWriter) error
+}
+
+type ListQueryTemplates200JSONResponse []QueryTemplate
+
+func (response ListQueryTemplates200JSONResponse) VisitListQueryTemplatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListQueryTemplates401JSONResponse struct{ N401JSONResponse }
+
+func (response ListQueryTemplates401JSONResponse) VisitListQueryTemplatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteQueryTemplateRequestObject struct {
+	TemplateName TemplateName `json:"templateName"`
+	Params       DeleteQueryTemplateParams
+}
+
+type DeleteQueryTemplateResponseObject interface {
+	VisitDeleteQueryTemplateResponse(w http.ResponseWriter) error
+}
+
+type DeleteQueryTemplate200JSONResponse QueryTemplate
+
+func (response DeleteQueryTemplate200JSONResponse) VisitDeleteQueryTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteQueryTemplate401JSONResponse struct{ N401JSONResponse }
+
+func (response DeleteQueryTemplate401JSONResponse) VisitDeleteQueryTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteQueryTemplate404JSONResponse struct{ N404JSONResponse }
+
+func (response DeleteQueryTemplate404JSONResponse) VisitDeleteQueryTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteQueryTemplate500JSONResponse struct{ N500JSONResponse }
+
+func (response DeleteQueryTemplate500JSONResponse) VisitDeleteQueryTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQueryTemplateRequestObject struct {
+	TemplateName TemplateName `json:"templateName"`
+	Params       GetQueryTemplateParams
+}
+
+type GetQueryTemplateResponseObject interface {
+	VisitGetQueryTemplateResponse(w http.ResponseWriter) error
+}
+
+type GetQueryTemplate200JSONResponse QueryTemplate
+
+func (response GetQueryTemplate200JSONResponse) VisitGetQueryTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQueryTemplate401JSONResponse struct{ N401JSONResponse }
+
+func (response GetQueryTemplate401JSONResponse) VisitGetQueryTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQueryTemplate404JSONResponse struct{ N404JSONResponse }
+
+func (response GetQueryTemplate404JSONResponse) VisitGetQueryTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetQueryTemplateRequestObject struct {
+	TemplateName TemplateName `json:"templateName"`
+	Params       SetQueryTemplateParams
+	Body         *SetQueryTemplateJSONRequestBody
+}
+
+type SetQueryTemplateResponseObject interface {
+	VisitSetQueryTemplateResponse(w http.ResponseWriter) error
+}
+
+type SetQueryTemplate200JSONResponse QueryTemplate
+
+func (response SetQueryTemplate200JSONResponse) VisitSetQueryTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetQueryTemplate400JSONResponse struct{ N400JSONResponse }
+
+func (response SetQueryTemplate400JSONResponse) VisitSetQueryTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetQueryTemplate401JSONResponse struct{ N401JSONResponse }
+
+func (response SetQueryTemplate401JSONResponse) VisitSetQueryTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetQueryTemplate500JSONResponse struct{ N500JSONResponse }
+
+func (response SetQueryTemplate500JSONResponse) VisitSetQueryTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListReasonTemplatesRequestObject struct {
+	Params ListReasonTemplatesParams
+}
+
+type ListReasonTemplatesResponseObject interface {
+	VisitListReasonTemplatesResponse(w http.ResponseWriter) error
+}
+
+type ListReasonTemplates200JSONResponse []ReasonTemplate
+
+func (response ListReasonTemplates200JSONResponse) VisitListReasonTemplatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListReasonTemplates401JSONResponse struct{ N401JSONResponse }
+
+func (response ListReasonTemplates401JSONResponse) VisitListReasonTemplatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteReasonTemplateRequestObject struct {
+	TemplateName TemplateName `json:"templateName"`
+	Params       DeleteReasonTemplateParams
+}
+
+type DeleteReasonTemplateResponseObject interface {
+	VisitDeleteReasonTemplateResponse(w http.ResponseWriter) error
+}
+
+type DeleteReasonTemplate200JSONResponse ReasonTemplate
+
+func (response DeleteReasonTemplate200JSONResponse) VisitDeleteReasonTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteReasonTemplate401JSONResponse struct{ N401JSONResponse }
+
+func (response DeleteReasonTemplate401JSONResponse) VisitDeleteReasonTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteReasonTemplate404JSONResponse struct{ N404JSONResponse }
+
+func (response DeleteReasonTemplate404JSONResponse) VisitDeleteReasonTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteReasonTemplate500JSONResponse struct{ N500JSONResponse }
+
+func (response DeleteReasonTemplate500JSONResponse) VisitDeleteReasonTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetReasonTemplateRequestObject struct {
+	TemplateName TemplateName `json:"templateName"`
+	Params       SetReasonTemplateParams
+	Body         *SetReasonTemplateJSONRequestBody
+}
+
+type SetReasonTemplateResponseObject interface {
+	VisitSetReasonTemplateResponse(w http.ResponseWriter) error
+}
+
+type SetReasonTemplate200JSONResponse ReasonTemplate
+
+func (response SetReasonTemplate200JSONResponse) VisitSetReasonTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetReasonTemplate400JSONResponse struct{ N400JSONResponse }
+
+func (response SetReasonTemplate400JSONResponse) VisitSetReasonTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetReasonTemplate401JSONResponse struct{ N401JSONResponse }
+
+func (response SetReasonTemplate401JSONResponse) VisitSetReasonTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetReasonTemplate500JSONResponse struct{ N500JSONResponse }
+
+func (response SetReasonTemplate500JSONResponse) VisitSetReasonTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaAllowlistRequestObject struct {
+	Params GetSchemaAllowlistParams
+}
+
+type GetSchemaAllowlistResponseObject interface {
+	VisitGetSchemaAllowlistResponse(w http.ResponseWriter) error
+}
+
+type GetSchemaAllowlist200JSONResponse SchemaAllowlist
+
+func (response GetSchemaAllowlist200JSONResponse) VisitGetSchemaAllowlistResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSchemaAllowlist401JSONResponse struct{ N401JSONResponse }
+
+func (response GetSchemaAllowlist401JSONResponse) VisitGetSchemaAllowlistResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetSchemaAllowlistRequestObject struct {
+	Params SetSchemaAllowlistParams
+	Body   *SetSchemaAllowlistJSONRequestBody
+}
+
+type SetSchemaAllowlistResponseObject interface {
+	VisitSetSchemaAllowlistResponse(w http.ResponseWriter) error
+}
+
+type SetSchemaAllowlist200JSONResponse SchemaAllowlist
+
+func (response SetSchemaAllowlist200JSONResponse) VisitSetSchemaAllowlistResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetSchemaAllowlist400JSONResponse struct{ N400JSONResponse }
+
+func (response SetSchemaAllowlist400JSONResponse) VisitSetSchemaAllowlistResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetSchemaAllowlist401JSONResponse struct{ N401JSONResponse }
+
+func (response SetSchemaAllowlist401JSONResponse) VisitSetSchemaAllowlistResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListSchemasRequestObject struct {
+	Params ListSchemasParams
+}
+
+type ListSchemasResponseObject interface {
+	VisitListSchemasResponse(w http.ResponseWriter) error
+}
+
+type ListSchemas200JSONResponse []SchemaStatus
+
+func (response ListSchemas200JSONResponse) VisitListSchemasResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListSchemas401JSONResponse struct{ N401JSONResponse }
+
+func (response ListSchemas401JSONResponse) VisitListSchemasResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PrewarmSchemasRequestObject struct {
+	Params PrewarmSchemasParams
+	Body   *PrewarmSchemasJSONRequestBody
+}
+
+type PrewarmSchemasResponseObject interface {
+	VisitPrewarmSchemasResponse(w http.ResponseWriter) error
+}
+
+type PrewarmSchemas200JSONResponse []SchemaStatus
+
+func (response PrewarmSchemas200JSONResponse) VisitPrewarmSchemasResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PrewarmSchemas401JSONResponse struct{ N401JSONResponse }
+
+func (response PrewarmSchemas401JSONResponse) VisitPrewarmSchemasResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PrewarmSchemas500JSONResponse struct{ N500JSONResponse }
+
+func (response PrewarmSchemas500JSONResponse) VisitPrewarmSchemasResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchSessionsRequestObject struct {
+	Params SearchSessionsParams
+}
+
+type SearchSessionsResponseObject interface {
+	VisitSearchSessionsResponse(w http.ResponseWriter) error
+}
+
+type SearchSessions200JSONResponse []TaggedSession
+
+func (response SearchSessions200JSONResponse) VisitSearchSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchSessions401JSONResponse struct{ N401JSONResponse }
+
+func (response SearchSessions401JSONResponse) VisitSearchSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetShadowVerificationReportRequestObject struct {
+	Params GetShadowVerificationReportParams
+}
+
+type GetShadowVerificationReportResponseObject interface {
+	VisitGetShadowVerificationReportResponse(w http.ResponseWriter) error
+}
+
+type GetShadowVerificationReport200JSONResponse ShadowVerificationReport
+
+func (response GetShadowVerificationReport200JSONResponse) VisitGetShadowVerificationReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetShadowVerificationReport401JSONResponse struct{ N401JSONResponse }
+
+func (response GetShadowVerificationReport401JSONResponse) VisitGetShadowVerificationReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetShadowVerificationReport404JSONResponse struct{ N404JSONResponse }
+
+func (response GetShadowVerificationReport404JSONResponse) VisitGetShadowVerificationReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSLIRequestObject struct {
+	Params GetSLIParams
+}
+
+type GetSLIResponseObject interface {
+	VisitGetSLIResponse(w http.ResponseWriter) error
+}
+
+type GetSLI200JSONResponse []SLIWindow
+
+func (response GetSLI200JSONResponse) VisitGetSLIResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSLI401JSONResponse struct{ N401JSONResponse }
+
+func (response GetSLI401JSONResponse) VisitGetSLIResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVerificationStatsRequestObject struct {
+	Params GetVerificationStatsParams
+}
+
+type GetVerificationStatsResponseObject interface {
+	VisitGetVerificationStatsResponse(w http.ResponseWriter) error
+}
+
+type GetVerificationStats200JSONResponse VerificationStats
+
+func (response GetVerificationStats200JSONResponse) VisitGetVerificationStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVerificationStats400JSONResponse struct{ N400JSONResponse }
+
+func (response GetVerificationStats400JSONResponse) VisitGetVerificationStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVerificationStats401JSONResponse struct{ N401JSONResponse }
+
+func (response GetVerificationStats401JSONResponse) VisitGetVerificationStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVerificationStats500JSONResponse struct{ N500JSONResponse }
+
+func (response GetVerificationStats500JSONResponse) VisitGetVerificationStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTagStatsRequestObject struct {
+	Params GetTagStatsParams
+}
+
+type GetTagStatsResponseObject interface {
+	VisitGetTagStatsResponse(w http.ResponseWriter) error
+}
+
+type GetTagStats200JSONResponse []TagStats
+
+func (response GetTagStats200JSONResponse) VisitGetTagStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTagStats401JSONResponse struct{ N401JSONResponse }
+
+func (response GetTagStats401JSONResponse) VisitGetTagStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVerificationTimingsRequestObject struct {
+	Params GetVerificationTimingsParams
+}
+
+type GetVerificationTimingsResponseObject interface {
+	VisitGetVerificationTimingsResponse(w http.ResponseWriter) error
+}
+
+type GetVerificationTimings200JSONResponse VerificationTimings
+
+func (response GetVerificationTimings200JSONResponse) VisitGetVerificationTimingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetVerificationTimings401JSONResponse struct{ N401JSONResponse }
+
+func (response GetVerificationTimings401JSONResponse) VisitGetVerificationTimingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeadLettersRequestObject struct {
+	Params ListWebhookDeadLettersParams
+}
+
+type ListWebhookDeadLettersResponseObject interface {
+	VisitListWebhookDeadLettersResponse(w http.ResponseWriter) error
+}
+
+type ListWebhookDeadLetters200JSONResponse []WebhookDelivery
+
+func (response ListWebhookDeadLetters200JSONResponse) VisitListWebhookDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeadLetters401JSONResponse struct{ N401JSONResponse }
+
+func (response ListWebhookDeadLetters401JSONResponse) VisitListWebhookDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookDeadLetters500JSONResponse struct{ N500JSONResponse }
+
+func (response ListWebhookDeadLetters500JSONResponse) VisitListWebhookDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplayWebhookDeadLetterRequestObject struct {
+	DeliveryID DeliveryID `json:"deliveryID"`
+	Params     ReplayWebhookDeadLetterParams
+}
+
+type ReplayWebhookDeadLetterResponseObject interface {
+	VisitReplayWebhookDeadLetterResponse(w http.ResponseWriter) error
+}
+
+type ReplayWebhookDeadLetter202JSONResponse WebhookDelivery
+
+func (response ReplayWebhookDeadLetter202JSONResponse) VisitReplayWebhookDeadLetterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplayWebhookDeadLetter401JSONResponse struct{ N401JSONResponse }
+
+func (response ReplayWebhookDeadLetter401JSONResponse) VisitReplayWebhookDeadLetterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplayWebhookDeadLetter404JSONResponse struct{ N404JSONResponse }
+
+func (response ReplayWebhookDeadLetter404JSONResponse) VisitReplayWebhookDeadLetterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplayWebhookDeadLetter409JSONResponse struct{ N409JSONResponse }
+
+func (response ReplayWebhookDeadLetter409JSONResponse) VisitReplayWebhookDeadLetterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplayWebhookDeadLetter500JSONResponse struct{ N500JSONResponse }
+
+func (response ReplayWebhookDeadLetter500JSONResponse) VisitReplayWebhookDeadLetterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CallbackRequestObject struct {
+	Params CallbackParams
+	Body   *CallbackTextRequestBody
+}
+
+type CallbackResponseObject interface {
+	VisitCallbackResponse(w http.ResponseWriter) error
+}
+
+type Callback200JSONResponse CallbackResponse
+
+func (response Callback200JSONResponse) VisitCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Callback202JSONResponse CallbackResponse
+
+func (response Callback202JSONResponse) VisitCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Callback400JSONResponse struct{ N400JSONResponse }
+
+func (response Callback400JSONResponse) VisitCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Callback404JSONResponse struct{ N404JSONResponse }
+
+func (response Callback404JSONResponse) VisitCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Callback409JSONResponse struct{ N409JSONResponse }
+
+func (response Callback409JSONResponse) VisitCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Callback429JSONResponse struct{ N429JSONResponse }
+
+func (response Callback429JSONResponse) VisitCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Callback500JSONResponse struct{ N500JSONResponse }
+
+func (response Callback500JSONResponse) VisitCallbackResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCampaignNullifierSessionRequestObject struct {
+	Campaign Campaign `json:"campaign"`
+	Params   GetCampaignNullifierSessionParams
+}
+
+type GetCampaignNullifierSessionResponseObject interface {
+	VisitGetCampaignNullifierSessionResponse(w http.ResponseWriter) error
+}
+
+type GetCampaignNullifierSession200JSONResponse NullifierSession
+
+func (response GetCampaignNullifierSession200JSONResponse) VisitGetCampaignNullifierSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCampaignNullifierSession400JSONResponse struct{ N400JSONResponse }
+
+func (response GetCampaignNullifierSession400JSONResponse) VisitGetCampaignNullifierSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCampaignNullifierSession401JSONResponse struct{ N401JSONResponse }
+
+func (response GetCampaignNullifierSession401JSONResponse) VisitGetCampaignNullifierSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCampaignNullifiersRequestObject struct {
+	Campaign Campaign `json:"campaign"`
+	Params   GetCampaignNullifiersParams
+}
+
+type GetCampaignNullifiersResponseObject interface {
+	VisitGetCampaignNullifiersResponse(w http.ResponseWriter) error
+}
+
+type GetCampaignNullifiers200JSONResponse CampaignNullifiers
+
+func (response GetCampaignNullifiers200JSONResponse) VisitGetCampaignNullifiersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCampaignNullifiers400JSONResponse struct{ N400JSONResponse }
+
+func (response GetCampaignNullifiers400JSONResponse) VisitGetCampaignNullifiersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCampaignNullifiers401JSONResponse struct{ N401JSONResponse }
+
+func (response GetCampaignNullifiers401JSONResponse) VisitGetCampaignNullifiersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCampaignNullifiers500JSONResponse struct{ N500JSONResponse }
+
+func (response GetCampaignNullifiers500JSONResponse) VisitGetCampaignNullifiersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNetworksRequestObject struct {
+}
+
+type GetNetworksResponseObject interface {
+	VisitGetNetworksResponse(w http.ResponseWriter) error
+}
+
+type GetNetworks200JSONResponse []Network
+
+func (response GetNetworks200JSONResponse) VisitGetNetworksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNetworks500JSONResponse struct{ N500JSONResponse }
+
+func (response GetNetworks500JSONResponse) VisitGetNetworksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CredentialRevocationStatusRequestObject struct {
+	Body *CredentialRevocationStatusJSONRequestBody
+}
+
+type CredentialRevocationStatusResponseObject interface {
+	VisitCredentialRevocationStatusResponse(w http.ResponseWriter) error
+}
+
+type CredentialRevocationStatus200JSONResponse RevocationStatusResponse
+
+func (response CredentialRevocationStatus200JSONResponse) VisitCredentialRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CredentialRevocationStatus400JSONResponse struct{ N400JSONResponse }
+
+func (response CredentialRevocationStatus400JSONResponse) VisitCredentialRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CredentialRevocationStatus500JSONResponse struct{ N500JSONResponse }
+
+func (response CredentialRevocationStatus500JSONResponse) VisitCredentialRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HealthRequestObject struct {
+}
+
+type HealthResponseObject interface {
+	VisitHealthResponse(w http.ResponseWriter) error
+}
+
+type Health200JSONResponse Health
+
+func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Health500JSONResponse struct{ N500JSONResponse }
+
+func (response Health500JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNullifierCheckpointsRequestObject struct {
+}
+
+type GetNullifierCheckpointsResponseObject interface {
+	VisitGetNullifierCheckpointsResponse(w http.ResponseWriter) error
+}
+
+type GetNullifierCheckpoints200JSONResponse []NullifierCheckpoint
+
+func (response GetNullifierCheckpoints200JSONResponse) VisitGetNullifierCheckpointsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNullifierCheckpoints404JSONResponse struct{ N404JSONResponse }
+
+func (response GetNullifierCheckpoints404JSONResponse) VisitGetNullifierCheckpointsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNullifierCheckpoints500JSONResponse struct{ N500JSONResponse }
+
+func (response GetNullifierCheckpoints500JSONResponse) VisitGetNullifierCheckpointsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNullifierProofRequestObject struct {
+	Nullifier Nullifier `json:"nullifier"`
+	Params    GetNullifierProofParams
+}
+
+type GetNullifierProofResponseObject interface {
+	VisitGetNullifierProofResponse(w http.ResponseWriter) error
+}
+
+type GetNullifierProof200JSONResponse NullifierProof
+
+func (response GetNullifierProof200JSONResponse) VisitGetNullifierProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNullifierProof400JSONResponse struct{ N400JSONResponse }
+
+func (response GetNullifierProof400JSONResponse) VisitGetNullifierProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNullifierProof404JSONResponse struct{ N404JSONResponse }
+
+func (response GetNullifierProof404JSONResponse) VisitGetNullifierProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNullifierProof500JSONResponse struct{ N500JSONResponse }
+
+func (response GetNullifierProof500JSONResponse) VisitGetNullifierProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQRCodeFromStoreRequestObject struct {
+	Params GetQRCodeFromStoreParams
+}
+
+type GetQRCodeFromStoreResponseObject interface {
+	VisitGetQRCodeFromStoreResponse(w http.ResponseWriter) error
+}
+
+type GetQRCodeFromStore200ResponseHeaders struct {
+	CacheControl string
+	ETag         string
+}
+
+type GetQRCodeFromStore200JSONResponse struct {
+	Body    QRCode
+	Headers GetQRCodeFromStore200ResponseHeaders
+}
+
+func (response GetQRCodeFromStore200JSONResponse) VisitGetQRCodeFromStoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetQRCodeFromStore304ResponseHeaders struct {
+	CacheControl string
+	ETag         string
+}
+
+type GetQRCodeFromStore304Response struct {
+	Headers GetQRCodeFromStore304ResponseHeaders
+}
+
+func (response GetQRCodeFromStore304Response) VisitGetQRCodeFromStoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetQRCodeFromStore404JSONResponse struct{ N404JSONResponse }
+
+func (response GetQRCodeFromStore404JSONResponse) VisitGetQRCodeFromStoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetQRCodeFromStore500JSONResponse struct{ N500JSONResponse }
+
+func (response GetQRCodeFromStore500JSONResponse) VisitGetQRCodeFromStoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FetchQRCodeFromStoreRequestObject struct {
+	Params FetchQRCodeFromStoreParams
+	Body   *FetchQRCodeFromStoreJSONRequestBody
+}
+
+type FetchQRCodeFromStoreResponseObject interface {
+	VisitFetchQRCodeFromStoreResponse(w http.ResponseWriter) error
+}
+
+type FetchQRCodeFromStore200JSONResponse QRCode
+
+func (response FetchQRCodeFromStore200JSONResponse) VisitFetchQRCodeFromStoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FetchQRCodeFromStore400JSONResponse struct{ N400JSONResponse }
+
+func (response FetchQRCodeFromStore400JSONResponse) VisitFetchQRCodeFromStoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FetchQRCodeFromStore404JSONResponse struct{ N404JSONResponse }
+
+func (response FetchQRCodeFromStore404JSONResponse) VisitFetchQRCodeFromStoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FetchQRCodeFromStore409JSONResponse struct{ N409JSONResponse }
+
+func (response FetchQRCodeFromStore409JSONResponse) VisitFetchQRCodeFromStoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FetchQRCodeFromStore500JSONResponse struct{ N500JSONResponse }
+
+func (response FetchQRCodeFromStore500JSONResponse) VisitFetchQRCodeFromStoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSandboxKeyRequestObject struct {
+	Body *CreateSandboxKeyJSONRequestBody
+}
+
+type CreateSandboxKeyResponseObject interface {
+	VisitCreateSandboxKeyResponse(w http.ResponseWriter) error
+}
+
+type CreateSandboxKey201JSONResponse SandboxKey
+
+func (response CreateSandboxKey201JSONResponse) VisitCreateSandboxKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSandboxKey202JSONResponse SandboxKeyPending
+
+func (response CreateSandboxKey202JSONResponse) VisitCreateSandboxKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSandboxKey400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateSandboxKey400JSONResponse) VisitCreateSandboxKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSandboxKey404JSONResponse struct{ N404JSONResponse }
+
+func (response CreateSandboxKey404JSONResponse) VisitCreateSandboxKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSandboxKey429JSONResponse struct{ N429JSONResponse }
+
+func (response CreateSandboxKey429JSONResponse) VisitCreateSandboxKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSandboxKey500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateSandboxKey500JSONResponse) VisitCreateSandboxKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifySandboxKeyRequestObject struct {
+	Body *VerifySandboxKeyJSONRequestBody
+}
+
+type VerifySandboxKeyResponseObject interface {
+	VisitVerifySandboxKeyResponse(w http.ResponseWriter) error
+}
+
+type VerifySandboxKey201JSONResponse SandboxKey
+
+func (response VerifySandboxKey201JSONResponse) VisitVerifySandboxKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifySandboxKey400JSONResponse struct{ N400JSONResponse }
+
+func (response VerifySandboxKey400JSONResponse) VisitVerifySandboxKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifySandboxKey404JSONResponse struct{ N404JSONResponse }
+
+func (response VerifySandboxKey404JSONResponse) VisitVerifySandboxKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifySandboxKey429JSONResponse struct{ N429JSONResponse }
+
+func (response VerifySandboxKey429JSONResponse) VisitVerifySandboxKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifySandboxKey500JSONResponse struct{ N500JSONResponse }
+
+func (response VerifySandboxKey500JSONResponse) VisitVerifySandboxKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredentialOfferRequestObject struct {
+	SessionID PathSessionID `json:"sessionID"`
+	Params    CreateCredentialOfferParams
+}
+
+type GetSessionEventsRequestObject struct {
+	SessionID PathSessionID `json:"sessionID"`
+	Params    GetSessionEventsParams
+}
+
+type GetSessionEventsResponseObject interface {
+	VisitGetSessionEventsResponse(w http.ResponseWriter) error
+}
+
+type GetSessionEvents200JSONResponse []SessionEvent
+
+func (response GetSessionEvents200JSONResponse) VisitGetSessionEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionEvents403JSONResponse struct{ N403JSONResponse }
+
+func (response GetSessionEvents403JSONResponse) VisitGetSessionEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionEvents404JSONResponse struct{ N404JSONResponse }
+
+func (response GetSessionEvents404JSONResponse) VisitGetSessionEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FinalizeSessionRequestObject struct {
+	SessionID PathSessionID `json:"sessionID"`
+	Params    FinalizeSessionParams
+}
+
+type CreateCredentialOfferResponseObject interface {
+	VisitCreateCredentialOfferResponse(w http.ResponseWriter) error
+}
+
+type FinalizeSessionResponseObject interface {
+	VisitFinalizeSessionResponse(w http.ResponseWriter) error
+}
+
+type CreateCredentialOffer200JSONResponse CredentialOfferResponse
+
+func (response CreateCredentialOffer200JSONResponse) VisitCreateCredentialOfferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FinalizeSession200JSONResponse StatusResponse
+
+func (response FinalizeSession200JSONResponse) VisitFinalizeSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredentialOffer401JSONResponse struct{ N401JSONResponse }
+
+func (response CreateCredentialOffer401JSONResponse) VisitCreateCredentialOfferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FinalizeSession401JSONResponse struct{ N401JSONResponse }
+
+func (response FinalizeSession401JSONResponse) VisitFinalizeSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredentialOffer403JSONResponse struct{ N403JSONResponse }
+
+func (response CreateCredentialOffer403JSONResponse) VisitCreateCredentialOfferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FinalizeSession403JSONResponse struct{ N403JSONResponse }
+
+func (response FinalizeSession403JSONResponse) VisitFinalizeSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredentialOffer404JSONResponse struct{ N404JSONResponse }
+
+func (response CreateCredentialOffer404JSONResponse) VisitCreateCredentialOfferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FinalizeSession404JSONResponse struct{ N404JSONResponse }
+
+func (response FinalizeSession404JSONResponse) VisitFinalizeSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredentialOffer409JSONResponse struct{ N409JSONResponse }
+
+func (response CreateCredentialOffer409JSONResponse) VisitCreateCredentialOfferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FinalizeSession409JSONResponse struct{ N409JSONResponse }
+
+func (response FinalizeSession409JSONResponse) VisitFinalizeSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredentialOffer410JSONResponse struct{ N410JSONResponse }
+
+func (response CreateCredentialOffer410JSONResponse) VisitCreateCredentialOfferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCredentialOffer500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateCredentialOffer500JSONResponse) VisitCreateCredentialOfferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FinalizeSession410JSONResponse struct{ N410JSONResponse }
+
+func (response FinalizeSession410JSONResponse) VisitFinalizeSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckOnChainProofsRequestObject struct {
+	SessionID PathSessionID `json:"sessionID"`
+	Body      *CheckOnChainProofsJSONRequestBody
+}
+
+type CheckOnChainProofsResponseObject interface {
+	VisitCheckOnChainProofsResponse(w http.ResponseWriter) error
+}
+
+type CheckOnChainProofs200JSONResponse StatusResponse
+
+func (response CheckOnChainProofs200JSONResponse) VisitCheckOnChainProofsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckOnChainProofs400JSONResponse struct{ N400JSONResponse }
+
+func (response CheckOnChainProofs400JSONResponse) VisitCheckOnChainProofsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckOnChainProofs404JSONResponse struct{ N404JSONResponse }
+
+func (response CheckOnChainProofs404JSONResponse) VisitCheckOnChainProofsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckOnChainProofs410JSONResponse struct{ N410JSONResponse }
+
+func (response CheckOnChainProofs410JSONResponse) VisitCheckOnChainProofsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckOnChainProofs500JSONResponse struct{ N500JSONResponse }
+
+func (response CheckOnChainProofs500JSONResponse) VisitCheckOnChainProofsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionResultRequestObject struct {
+	SessionID PathSessionID `json:"sessionID"`
+	Params    GetSessionResultParams
+}
+
+type GetSessionResultResponseObject interface {
+	VisitGetSessionResultResponse(w http.ResponseWriter) error
+}
+
+type GetSessionResult200JSONResponse StatusResponse
+
+func (response GetSessionResult200JSONResponse) VisitGetSessionResultResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionResult401JSONResponse struct{ N401JSONResponse }
+
+func (response GetSessionResult401JSONResponse) VisitGetSessionResultResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionResult403JSONResponse struct{ N403JSONResponse }
+
+func (response GetSessionResult403JSONResponse) VisitGetSessionResultResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionResult404JSONResponse struct{ N404JSONResponse }
+
+func (response GetSessionResult404JSONResponse) VisitGetSessionResultResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionResult409JSONResponse struct{ N409JSONResponse }
+
+func (response GetSessionResult409JSONResponse) VisitGetSessionResultResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionResult410JSONResponse struct{ N410JSONResponse }
+
+func (response GetSessionResult410JSONResponse) VisitGetSessionResultResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionResult500JSONResponse struct{ N500JSONResponse }
+
+func (response GetSessionResult500JSONResponse) VisitGetSessionResultResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionWebhooksRequestObject struct {
+	SessionID PathSessionID `json:"sessionID"`
+	Params    GetSessionWebhooksParams
+}
+
+type GetSessionWebhooksResponseObject interface {
+	VisitGetSessionWebhooksResponse(w http.ResponseWriter) error
+}
+
+type GetSessionWebhooks200JSONResponse []WebhookDelivery
+
+func (response GetSessionWebhooks200JSONResponse) VisitGetSessionWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionWebhooks403JSONResponse struct{ N403JSONResponse }
+
+func (response GetSessionWebhooks403JSONResponse) VisitGetSessionWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionWebhooks404JSONResponse struct{ N404JSONResponse }
+
+func (response GetSessionWebhooks404JSONResponse) VisitGetSessionWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInRequestObject struct {
+	Params SignInParams
+	Body   *SignInJSONRequestBody
+}
+
+type SignInResponseObject interface {
+	VisitSignInResponse(w http.ResponseWriter) error
+}
+
+type SignIn200JSONResponse SingInResponse
+
+func (response SignIn200JSONResponse) VisitSignInResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignIn400JSONResponse struct{ N400JSONResponse }
+
+func (response SignIn400JSONResponse) VisitSignInResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignIn401JSONResponse struct{ N401JSONResponse }
+
+func (response SignIn401JSONResponse) VisitSignInResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignIn403JSONResponse struct{ N403JSONResponse }
+
+func (response SignIn403JSONResponse) VisitSignInResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignIn500JSONResponse struct{ N500JSONResponse }
+
+func (response SignIn500JSONResponse) VisitSignInResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInAuthRequestObject struct {
+	Params SignInAuthParams
+	Body   *SignInAuthJSONRequestBody
+}
+
+type SignInAuthResponseObject interface {
+	VisitSignInAuthResponse(w http.ResponseWriter) error
+}
+
+type SignInAuth200JSONResponse SingInResponse
+
+func (response SignInAuth200JSONResponse) VisitSignInAuthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInAuth400JSONResponse struct{ N400JSONResponse }
+
+func (response SignInAuth400JSONResponse) VisitSignInAuthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInAuth401JSONResponse struct{ N401JSONResponse }
+
+func (response SignInAuth401JSONResponse) VisitSignInAuthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInAuth403JSONResponse struct{ N403JSONResponse }
+
+func (response SignInAuth403JSONResponse) VisitSignInAuthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInAuth500JSONResponse struct{ N500JSONResponse }
+
+func (response SignInAuth500JSONResponse) VisitSignInAuthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInBatchRequestObject struct {
+	Params SignInBatchParams
+	Body   *SignInBatchJSONRequestBody
+}
+
+type SignInBatchResponseObject interface {
+	VisitSignInBatchResponse(w http.ResponseWriter) error
+}
+
+type SignInBatch200JSONResponse SignInBatchResponse
+
+func (response SignInBatch200JSONResponse) VisitSignInBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInBatch400JSONResponse struct{ N400JSONResponse }
+
+func (response SignInBatch400JSONResponse) VisitSignInBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInFlowRequestObject struct {
+	Name   FlowName `json:"name"`
+	Params SignInFlowParams
+	Body   *SignInFlowJSONRequestBody
+}
+
+type SignInFlowResponseObject interface {
+	VisitSignInFlowResponse(w http.ResponseWriter) error
+}
+
+type SignInFlow200JSONResponse SingInResponse
+
+func (response SignInFlow200JSONResponse) VisitSignInFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInFlow400JSONResponse struct{ N400JSONResponse }
+
+func (response SignInFlow400JSONResponse) VisitSignInFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInFlow401JSONResponse struct{ N401JSONResponse }
+
+func (response SignInFlow401JSONResponse) VisitSignInFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInFlow403JSONResponse struct{ N403JSONResponse }
+
+func (response SignInFlow403JSONResponse) VisitSignInFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInFlow404JSONResponse struct{ N404JSONResponse }
+
+func (response SignInFlow404JSONResponse) VisitSignInFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInFlow500JSONResponse struct{ N500JSONResponse }
+
+func (response SignInFlow500JSONResponse) VisitSignInFlowResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInLinkRequestObject struct {
+	Params SignInLinkParams
+}
+
+type SignInLinkResponseObject interface {
+	VisitSignInLinkResponse(w http.ResponseWriter) error
+}
+
+type SignInLink302ResponseHeaders struct {
+	Location string
+}
+
+type SignInLink302Response struct {
+	Headers SignInLink302ResponseHeaders
+}
+
+func (response SignInLink302Response) VisitSignInLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(302)
+	return nil
+}
+
+type SignInLink400JSONResponse struct{ N400JSONResponse }
+
+func (response SignInLink400JSONResponse) VisitSignInLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInLink401JSONResponse struct{ N401JSONResponse }
+
+func (response SignInLink401JSONResponse) VisitSignInLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInLink403JSONResponse struct{ N403JSONResponse }
+
+func (response SignInLink403JSONResponse) VisitSignInLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInLink429JSONResponse struct{ N429JSONResponse }
+
+func (response SignInLink429JSONResponse) VisitSignInLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInLink500JSONResponse struct{ N500JSONResponse }
+
+func (response SignInLink500JSONResponse) VisitSignInLinkResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInUniqueRequestObject struct {
+	Params SignInUniqueParams
+	Body   *SignInUniqueJSONRequestBody
+}
+
+type SignInUniqueResponseObject interface {
+	VisitSignInUniqueResponse(w http.ResponseWriter) error
+}
+
+type SignInUnique200JSONResponse SingInResponse
+
+func (response SignInUnique200JSONResponse) VisitSignInUniqueResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInUnique400JSONResponse struct{ N400JSONResponse }
+
+func (response SignInUnique400JSONResponse) VisitSignInUniqueResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInUnique401JSONResponse struct{ N401JSONResponse }
+
+func (response SignInUnique401JSONResponse) VisitSignInUniqueResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInUnique403JSONResponse struct{ N403JSONResponse }
+
+func (response SignInUnique403JSONResponse) VisitSignInUniqueResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SignInUnique500JSONResponse struct{ N500JSONResponse }
+
+func (response SignInUnique500JSONResponse) VisitSignInUniqueResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StatusRequestObject struct {
+	Params StatusParams
+}
+
+type StatusResponseObject interface {
+	VisitStatusResponse(w http.ResponseWriter) error
+}
+
+type Status200JSONResponse StatusResponse
+
+func (response Status200JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Status401JSONResponse struct{ N401JSONResponse }
+
+func (response Status401JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Status404JSONResponse struct{ N404JSONResponse }
+
+func (response Status404JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Status500JSONResponse struct{ N500JSONResponse }
+
+func (response Status500JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTenantJWKSRequestObject struct {
+	TenantID TenantID `json:"tenantID"`
+}
+
+type GetTenantJWKSResponseObject interface {
+	VisitGetTenantJWKSResponse(w http.ResponseWriter) error
+}
+
+type GetTenantJWKS200JSONResponse JWKS
+
+func (response GetTenantJWKS200JSONResponse) VisitGetTenantJWKSResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTenantJWKS404JSONResponse struct{ N404JSONResponse }
+
+func (response GetTenantJWKS404JSONResponse) VisitGetTenantJWKSResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BuildQueryRequestObject struct {
+	Body *BuildQueryJSONRequestBody
+}
+
+type UnpackMessageRequestObject struct {
+	Body *UnpackMessageJSONRequestBody
+}
+
+type BuildQueryResponseObject interface {
+	VisitBuildQueryResponse(w http.ResponseWriter) error
+}
+
+type UnpackMessageResponseObject interface {
+	VisitUnpackMessageResponse(w http.ResponseWriter) error
+}
+
+type BuildQuery200JSONResponse BuildQueryResponse
+
+func (response BuildQuery200JSONResponse) VisitBuildQueryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnpackMessage200JSONResponse UnpackResponse
+
+func (response UnpackMessage200JSONResponse) VisitUnpackMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BuildQuery400JSONResponse struct{ N400JSONResponse }
+
+func (response BuildQuery400JSONResponse) VisitBuildQueryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnpackMessage400JSONResponse struct{ N400JSONResponse }
+
+func (response UnpackMessage400JSONResponse) VisitUnpackMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BuildQuery500JSONResponse struct{ N500JSONResponse }
+
+func (response BuildQuery500JSONResponse) VisitBuildQueryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnpackMessage500JSONResponse struct{ N500JSONResponse }
+
+func (response UnpackMessage500JSONResponse) VisitUnpackMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsersRequestObject struct {
+	Params ListUsersParams
+}
+
+type ListUsersResponseObject interface {
+	VisitListUsersResponse(w http.ResponseWriter) error
+}
+
+type ListUsers200JSONResponse []UserRecord
+
+func (response ListUsers200JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsers401JSONResponse struct{ N401JSONResponse }
+
+func (response ListUsers401JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsers404JSONResponse struct{ N404JSONResponse }
+
+func (response ListUsers404JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsers500JSONResponse struct{ N500JSONResponse }
+
+func (response ListUsers500JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserRequestObject struct {
+	Did    Did `json:"did"`
+	Params GetUserParams
+}
+
+type GetUserResponseObject interface {
+	VisitGetUserResponse(w http.ResponseWriter) error
+}
+
+type GetUser200JSONResponse UserRecord
+
+func (response GetUser200JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser401JSONResponse struct{ N401JSONResponse }
+
+func (response GetUser401JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser404JSONResponse struct{ N404JSONResponse }
+
+func (response GetUser404JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser500JSONResponse struct{ N500JSONResponse }
+
+func (response GetUser500JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Get the documentation
+	// (GET /)
+	GetDocumentation(ctx context.Context, request GetDocumentationRequestObject) (GetDocumentationResponseObject, error)
+	// Get the DID document of the verifier
+	// (GET /.well-known/did.json)
+	GetDIDDocument(ctx context.Context, request GetDIDDocumentRequestObject) (GetDIDDocumentResponseObject, error)
+	// Get the verifier public keys
+	// (GET /.well-known/jwks.json)
+	GetJWKS(ctx context.Context, request GetJWKSRequestObject) (GetJWKSResponseObject, error)
+	// List the loaded verification keys
+	// (GET /admin/circuits)
+	ListCircuitKeys(ctx context.Context, request ListCircuitKeysRequestObject) (ListCircuitKeysResponseObject, error)
+	// Reload the verification keys
+	// (POST /admin/circuits/reload)
+	ReloadCircuitKeys(ctx context.Context, request ReloadCircuitKeysRequestObject) (ReloadCircuitKeysResponseObject, error)
+	// Get the issuer policy
+	// (GET /admin/issuer-policy)
+	GetIssuerPolicy(ctx context.Context, request GetIssuerPolicyRequestObject) (GetIssuerPolicyResponseObject, error)
+	// Remove the trusted issuers of a credential type
+	// (DELETE /admin/issuer-policy/{credentialType})
+	DeleteIssuerPolicy(ctx context.Context, request DeleteIssuerPolicyRequestObject) (DeleteIssuerPolicyResponseObject, error)
+	// Set the trusted issuers of a credential type
+	// (PUT /admin/issuer-policy/{credentialType})
+	SetIssuerPolicy(ctx context.Context, request SetIssuerPolicyRequestObject) (SetIssuerPolicyResponseObject, error)
+	// List the keys of the verifier
+	// (GET /admin/keys)
+	ListVerifierKeys(ctx context.Context, request ListVerifierKeysRequestObject) (ListVerifierKeysResponseObject, error)
+	// Rotate the keys of the verifier
+	// (POST /admin/keys/rotate)
+	RotateVerifierKeys(ctx context.Context, request RotateVerifierKeysRequestObject) (RotateVerifierKeysResponseObject, error)
+	// List the query templates
+	// (GET /admin/query-templates)
+	ListQueryTemplates(ctx context.Context, request ListQueryTemplatesRequestObject) (ListQueryTemplatesResponseObject, error)
+	// Delete a query template
+	// (DELETE /admin/query-templates/{templateName})
+	DeleteQueryTemplate(ctx context.Context, request DeleteQueryTemplateRequestObject) (DeleteQueryTemplateResponseObject, error)
+	// Get a query template
+	// (GET /admin/query-templates/{templateName})
+	GetQueryTemplate(ctx context.Context, request GetQueryTemplateRequestObject) (GetQueryTemplateResponseObject, error)
+	// Create or replace a query template
+	// (PUT /admin/query-templates/{templateName})
+	SetQueryTemplate(ctx context.Context, request SetQueryTemplateRequestObject) (SetQueryTemplateResponseObject, error)
+	// List the reason templates
+	// (GET /admin/reason-templates)
+	ListReasonTemplates(ctx context.Context, request ListReasonTemplatesRequestObject) (ListReasonTemplatesResponseObject, error)
+	// Delete a reason template
+	// (DELETE /admin/reason-templates/{templateName})
+	DeleteReasonTemplate(ctx context.Context, request DeleteReasonTemplateRequestObject) (DeleteReasonTemplateResponseObject, error)
+	// Create or replace a reason template
+	// (PUT /admin/reason-templates/{templateName})
+	SetReasonTemplate(ctx context.Context, request SetReasonTemplateRequestObject) (SetReasonTemplateResponseObject, error)
+	// Get the schema allowlist
+	// (GET /admin/schema-allowlist)
+	GetSchemaAllowlist(ctx context.Context, request GetSchemaAllowlistRequestObject) (GetSchemaAllowlistResponseObject, error)
+	// Replace the schema allowlist
+	// (PUT /admin/schema-allowlist)
+	SetSchemaAllowlist(ctx context.Context, request SetSchemaAllowlistRequestObject) (SetSchemaAllowlistResponseObject, error)
+	// List the pinned JSON-LD documents
+	// (GET /admin/schemas)
+	ListSchemas(ctx context.Context, request ListSchemasRequestObject) (ListSchemasResponseObject, error)
+	// Prewarm the JSON-LD documents
+	// (POST /admin/schemas/prewarm)
+	PrewarmSchemas(ctx context.Context, request PrewarmSchemasRequestObject) (PrewarmSchemasResponseObject, error)
+	// Search the sessions by tag
+	// (GET /admin/sessions)
+	SearchSessions(ctx context.Context, request SearchSessionsRequestObject) (SearchSessionsResponseObject, error)
+	// Get the shadow verification report
+	// (GET /admin/shadow-verification)
+	GetShadowVerificationReport(ctx context.Context, request GetShadowVerificationReportRequestObject) (GetShadowVerificationReportResponseObject, error)
+	// Get the service level indicators
+	// (GET /admin/sli)
+	GetSLI(ctx context.Context, request GetSLIRequestObject) (GetSLIResponseObject, error)
+	// Get the verification statistics
+	// (GET /admin/stats)
+	GetVerificationStats(ctx context.Context, request GetVerificationStatsRequestObject) (GetVerificationStatsResponseObject, error)
+	// Get the funnel stats of the tags
+	// (GET /admin/tags/stats)
+	GetTagStats(ctx context.Context, request GetTagStatsRequestObject) (GetTagStatsResponseObject, error)
+	// Get the verification timings
+	// (GET /admin/verification-timings)
+	GetVerificationTimings(ctx context.Context, request GetVerificationTimingsRequestObject) (GetVerificationTimingsResponseObject, error)
+	// List the failed webhook deliveries
+	// (GET /admin/webhooks/dead-letters)
+	ListWebhookDeadLetters(ctx context.Context, request ListWebhookDeadLettersRequestObject) (ListWebhookDeadLettersResponseObject, error)
+	// Replay a failed webhook delivery
+	// (POST /admin/webhooks/dead-letters/{deliveryID}/replay)
+	ReplayWebhookDeadLetter(ctx context.Context, request ReplayWebhookDeadLetterRequestObject) (ReplayWebhookDeadLetterResponseObject, error)
+	// Callback
+	// (POST /callback)
+	Callback(ctx context.Context, request CallbackRequestObject) (CallbackResponseObject, error)
+	// Get the nullifier session of a campaign
+	// (GET /campaigns/{campaign}/nullifier-session)
+	GetCampaignNullifierSession(ctx context.Context, request GetCampaignNullifierSessionRequestObject) (GetCampaignNullifierSessionResponseObject, error)
+	// Get the nullifiers of a campaign
+	// (GET /campaigns/{campaign}/nullifiers)
+	GetCampaignNullifiers(ctx context.Context, request GetCampaignNullifiersRequestObject) (GetCampaignNullifiersResponseObject, error)
+	// Get the supported networks
+	// (GET /config/networks)
+	GetNetworks(ctx context.Context, request GetNetworksRequestObject) (GetNetworksResponseObject, error)
+	// Check credential revocation status
+	// (POST /credentials/revocation-status)
+	CredentialRevocationStatus(ctx context.Context, request CredentialRevocationStatusRequestObject) (CredentialRevocationStatusResponseObject, error)
+	// Health Check
+	// (GET /health)
+	Health(ctx context.Context, request HealthRequestObject) (HealthResponseObject, error)
+	// Get the nullifier registry checkpoints
+	// (GET /nullifiers/checkpoints)
+	GetNullifierCheckpoints(ctx context.Context, request GetNullifierCheckpointsRequestObject) (GetNullifierCheckpointsResponseObject, error)
+	// Get a proof of inclusion or non-inclusion of a nullifier
+	// (GET /nullifiers/{nullifier}/proof)
+	GetNullifierProof(ctx context.Context, request GetNullifierProofRequestObject) (GetNullifierProofResponseObject, error)
+	// Get QRCode from store
+	// (GET /qr-store)
+	GetQRCodeFromStore(ctx context.Context, request GetQRCodeFromStoreRequestObject) (GetQRCodeFromStoreResponseObject, error)
+	// Fetch QRCode from store for a wallet
+	// (POST /qr-store)
+	FetchQRCodeFromStore(ctx context.Context, request FetchQRCodeFromStoreRequestObject) (FetchQRCodeFromStoreResponseObject, error)
+	// Create a sandbox API key
+	// (POST /sandbox/keys)
+	CreateSandboxKey(ctx context.Context, request CreateSandboxKeyRequestObject) (CreateSandboxKeyResponseObject, error)
+	// Verify email and create a sandbox API key
+	// (POST /sandbox/keys/verify)
+	VerifySandboxKey(ctx context.Context, request VerifySandboxKeyRequestObject) (VerifySandboxKeyResponseObject, error)
+	// Create a credential offer for the user of a session
+	// (POST /sessions/{sessionID}/credential-offer)
+	CreateCredentialOffer(ctx context.Context, request CreateCredentialOfferRequestObject) (CreateCredentialOfferResponseObject, error)
+	// Get the events of a session
+	// (GET /sessions/{sessionID}/events)
+	GetSessionEvents(ctx context.Context, request GetSessionEventsRequestObject) (GetSessionEventsResponseObject, error)
+	// Finalize a session
+	// (POST /sessions/{sessionID}/finalize)
+	FinalizeSession(ctx context.Context, request FinalizeSessionRequestObject) (FinalizeSessionResponseObject, error)
+	// Check the proofs of an on-chain session
+	// (POST /sessions/{sessionID}/onchain-proofs)
+	CheckOnChainProofs(ctx context.Context, request CheckOnChainProofsRequestObject) (CheckOnChainProofsResponseObject, error)
+	// Get the result of a session
+	// (GET /sessions/{sessionID}/result)
+	GetSessionResult(ctx context.Context, request GetSessionResultRequestObject) (GetSessionResultResponseObject, error)
+	// Get the webhook deliveries of a session
+	// (GET /sessions/{sessionID}/webhooks)
+	GetSessionWebhooks(ctx context.Context, request GetSessionWebhooksRequestObject) (GetSessionWebhooksResponseObject, error)
+	// Sign in
+	// (POST /sign-in)
+	SignIn(ctx context.Context, request SignInRequestObject) (SignInResponseObject, error)
+	// Sign in proving only the ownership of an identity
+	// (POST /sign-in/auth)
+	SignInAuth(ctx context.Context, request SignInAuthRequestObject) (SignInAuthResponseObject, error)
+	// Sign in batch
+	// (POST /sign-in/batch)
+	SignInBatch(ctx context.Context, request SignInBatchRequestObject) (SignInBatchResponseObject, error)
+	// Sign in with a verification flow
+	// (POST /sign-in/flow/{name})
+	SignInFlow(ctx context.Context, request SignInFlowRequestObject) (SignInFlowResponseObject, error)
+	// Sign in link
+	// (GET /sign-in/link)
+	SignInLink(ctx context.Context, request SignInLinkRequestObject) (SignInLinkResponseObject, error)
+	// Sign in once per campaign
+	// (POST /sign-in/unique)
+	SignInUnique(ctx context.Context, request SignInUniqueRequestObject) (SignInUniqueResponseObject, error)
+	// Get Status
+	// (GET /status)
+	Status(ctx context.Context, request StatusRequestObject) (StatusResponseObject, error)
+	// Get the public keys of a tenant
+	// (GET /tenants/{tenantID}/.well-known/jwks.json)
+	GetTenantJWKS(ctx context.Context, request GetTenantJWKSRequestObject) (GetTenantJWKSResponseObject, error)
+	// Build a scope query
+	// (POST /tools/build-query)
+	BuildQuery(ctx context.Context, request BuildQueryRequestObject) (BuildQueryResponseObject, error)
+	// Decode an iden3comm message
+	// (POST /tools/unpack)
+	UnpackMessage(ctx context.Context, request UnpackMessageRequestObject) (UnpackMessageResponseObject, error)
+	// List the verified users
+	// (GET /users)
+	ListUsers(ctx context.Context, request ListUsersRequestObject) (ListUsersResponseObject, error)
+	// Get a verified user
+	// (GET /users/{did})
+	GetUser(ctx context.Context, request GetUserRequestObject) (GetUserResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHttpHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHttpMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetDocumentation operation middleware
+func (sh *strictHandler) GetDocumentation(w http.ResponseWriter, r *http.Request) {
+	var request GetDocumentationRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDocumentation(ctx, request.(GetDocumentationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDocumentation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDocumentationResponseObject); ok {
+		if err := validResponse.VisitGetDocumentationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetDIDDocument operation middleware
+func (sh *strictHandler) GetDIDDocument(w http.ResponseWriter, r *http.Request) {
+	var request GetDIDDocumentRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDIDDocument(ctx, request.(GetDIDDocumentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDIDDocument")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDIDDocumentResponseObject); ok {
+		if err := validResponse.VisitGetDIDDocumentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetJWKS operation middleware
+func (sh *strictHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	var request GetJWKSRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetJWKS(ctx, request.(GetJWKSRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetJWKS")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetJWKSResponseObject); ok {
+		if err := validResponse.VisitGetJWKSResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListCircuitKeys operation middleware
+func (sh *strictHandler) ListCircuitKeys(w http.ResponseWriter, r *http.Request, params ListCircuitKeysParams) {
+	var request ListCircuitKeysRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListCircuitKeys(ctx, request.(ListCircuitKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListCircuitKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListCircuitKeysResponseObject); ok {
+		if err := validResponse.VisitListCircuitKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReloadCircuitKeys operation middleware
+func (sh *strictHandler) ReloadCircuitKeys(w http.ResponseWriter, r *http.Request, params ReloadCircuitKeysParams) {
+	var request ReloadCircuitKeysRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReloadCircuitKeys(ctx, request.(ReloadCircuitKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReloadCircuitKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReloadCircuitKeysResponseObject); ok {
+		if err := validResponse.VisitReloadCircuitKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetIssuerPolicy operation middleware
+func (sh *strictHandler) GetIssuerPolicy(w http.ResponseWriter, r *http.Request, params GetIssuerPolicyParams) {
+	var request GetIssuerPolicyRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetIssuerPolicy(ctx, request.(GetIssuerPolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetIssuerPolicy")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetIssuerPolicyResponseObject); ok {
+		if err := validResponse.VisitGetIssuerPolicyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteIssuerPolicy operation middleware
+func (sh *strictHandler) DeleteIssuerPolicy(w http.ResponseWriter, r *http.Request, credentialType CredentialType, params DeleteIssuerPolicyParams) {
+	var request DeleteIssuerPolicyRequestObject
+
+	request.CredentialType = credentialType
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteIssuerPolicy(ctx, request.(DeleteIssuerPolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteIssuerPolicy")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteIssuerPolicyResponseObject); ok {
+		if err := validResponse.VisitDeleteIssuerPolicyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetIssuerPolicy operation middleware
+func (sh *strictHandler) SetIssuerPolicy(w http.ResponseWriter, r *http.Request, credentialType CredentialType, params SetIssuerPolicyParams) {
+	var request SetIssuerPolicyRequestObject
+
+	request.CredentialType = credentialType
+	request.Params = params
+
+	var body SetIssuerPolicyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetIssuerPolicy(ctx, request.(SetIssuerPolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetIssuerPolicy")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetIssuerPolicyResponseObject); ok {
+		if err := validResponse.VisitSetIssuerPolicyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListVerifierKeys operation middleware
+func (sh *strictHandler) ListVerifierKeys(w http.ResponseWriter, r *http.Request, params ListVerifierKeysParams) {
+	var request ListVerifierKeysRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListVerifierKeys(ctx, request.(ListVerifierKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListVerifierKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListVerifierKeysResponseObject); ok {
+		if err := validResponse.VisitListVerifierKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RotateVerifierKeys operation middleware
+func (sh *strictHandler) RotateVerifierKeys(w http.ResponseWriter, r *http.Request, params RotateVerifierKeysParams) {
+	var request RotateVerifierKeysRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RotateVerifierKeys(ctx, request.(RotateVerifierKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RotateVerifierKeys")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RotateVerifierKeysResponseObject); ok {
+		if err := validResponse.VisitRotateVerifierKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListQueryTemplates operation middleware
+func (sh *strictHandler) ListQueryTemplates(w http.ResponseWriter, r *http.Request, params ListQueryTemplatesParams) {
+	var request ListQueryTemplatesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListQueryTemplates(ctx, request.(ListQueryTemplatesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListQueryTemplates")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListQueryTemplatesResponseObject); ok {
+		if err := validResponse.VisitListQueryTemplatesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteQueryTemplate operation middleware
+func (sh *strictHandler) DeleteQueryTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params DeleteQueryTemplateParams) {
+	var request DeleteQueryTemplateRequestObject
+
+	request.TemplateName = templateName
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteQueryTemplate(ctx, request.(DeleteQueryTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteQueryTemplate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteQueryTemplateResponseObject); ok {
+		if err := validResponse.VisitDeleteQueryTemplateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetQueryTemplate operation middleware
+func (sh *strictHandler) GetQueryTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params GetQueryTemplateParams) {
+	var request GetQueryTemplateRequestObject
+
+	request.TemplateName = templateName
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetQueryTemplate(ctx, request.(GetQueryTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetQueryTemplate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetQueryTemplateResponseObject); ok {
+		if err := validResponse.VisitGetQueryTemplateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetQueryTemplate operation middleware
+func (sh *strictHandler) SetQueryTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params SetQueryTemplateParams) {
+	var request SetQueryTemplateRequestObject
+
+	request.TemplateName = templateName
+	request.Params = params
+
+	var body SetQueryTemplateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetQueryTemplate(ctx, request.(SetQueryTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetQueryTemplate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetQueryTemplateResponseObject); ok {
+		if err := validResponse.VisitSetQueryTemplateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListReasonTemplates operation middleware
+func (sh *strictHandler) ListReasonTemplates(w http.ResponseWriter, r *http.Request, params ListReasonTemplatesParams) {
+	var request ListReasonTemplatesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListReasonTemplates(ctx, request.(ListReasonTemplatesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListReasonTemplates")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListReasonTemplatesResponseObject); ok {
+		if err := validResponse.VisitListReasonTemplatesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteReasonTemplate operation middleware
+func (sh *strictHandler) DeleteReasonTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params DeleteReasonTemplateParams) {
+	var request DeleteReasonTemplateRequestObject
+
+	request.TemplateName = templateName
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteReasonTemplate(ctx, request.(DeleteReasonTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteReasonTemplate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteReasonTemplateResponseObject); ok {
+		if err := validResponse.VisitDeleteReasonTemplateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetReasonTemplate operation middleware
+func (sh *strictHandler) SetReasonTemplate(w http.ResponseWriter, r *http.Request, templateName TemplateName, params SetReasonTemplateParams) {
+	var request SetReasonTemplateRequestObject
+
+	request.TemplateName = templateName
+	request.Params = params
+
+	var body SetReasonTemplateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetReasonTemplate(ctx, request.(SetReasonTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetReasonTemplate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetReasonTemplateResponseObject); ok {
+		if err := validResponse.VisitSetReasonTemplateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSchemaAllowlist operation middleware
+func (sh *strictHandler) GetSchemaAllowlist(w http.ResponseWriter, r *http.Request, params GetSchemaAllowlistParams) {
+	var request GetSchemaAllowlistRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSchemaAllowlist(ctx, request.(GetSchemaAllowlistRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSchemaAllowlist")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSchemaAllowlistResponseObject); ok {
+		if err := validResponse.VisitGetSchemaAllowlistResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetSchemaAllowlist operation middleware
+func (sh *strictHandler) SetSchemaAllowlist(w http.ResponseWriter, r *http.Request, params SetSchemaAllowlistParams) {
+	var request SetSchemaAllowlistRequestObject
+
+	request.Params = params
+
+	var body SetSchemaAllowlistJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetSchemaAllowlist(ctx, request.(SetSchemaAllowlistRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetSchemaAllowlist")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetSchemaAllowlistResponseObject); ok {
+		if err := validResponse.VisitSetSchemaAllowlistResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListSchemas operation middleware
+func (sh *strictHandler) ListSchemas(w http.ResponseWriter, r *http.Request, params ListSchemasParams) {
+	var request ListSchemasRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListSchemas(ctx, request.(ListSchemasRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListSchemas")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListSchemasResponseObject); ok {
+		if err := validResponse.VisitListSchemasResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PrewarmSchemas operation middleware
+func (sh *strictHandler) PrewarmSchemas(w http.ResponseWriter, r *http.Request, params PrewarmSchemasParams) {
+	var request PrewarmSchemasRequestObject
+
+	request.Params = params
+
+	var body PrewarmSchemasJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PrewarmSchemas(ctx, request.(PrewarmSchemasRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PrewarmSchemas")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PrewarmSchemasResponseObject); ok {
+		if err := validResponse.VisitPrewarmSchemasResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SearchSessions operation middleware
+func (sh *strictHandler) SearchSessions(w http.ResponseWriter, r *http.Request, params SearchSessionsParams) {
+	var request SearchSessionsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchSessions(ctx, request.(SearchSessionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchSessions")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SearchSessionsResponseObject); ok {
+		if err := validResponse.VisitSearchSessionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetShadowVerificationReport operation middleware
+func (sh *strictHandler) GetShadowVerificationReport(w http.ResponseWriter, r *http.Request, params GetShadowVerificationReportParams) {
+	var request GetShadowVerificationReportRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetShadowVerificationReport(ctx, request.(GetShadowVerificationReportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetShadowVerificationReport")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetShadowVerificationReportResponseObject); ok {
+		if err := validResponse.VisitGetShadowVerificationReportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSLI operation middleware
+func (sh *strictHandler) GetSLI(w http.ResponseWriter, r *http.Request, params GetSLIParams) {
+	var request GetSLIRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSLI(ctx, request.(GetSLIRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSLI")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSLIResponseObject); ok {
+		if err := validResponse.VisitGetSLIResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetVerificationStats operation middleware
+func (sh *strictHandler) GetVerificationStats(w http.ResponseWriter, r *http.Request, params GetVerificationStatsParams) {
+	var request GetVerificationStatsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVerificationStats(ctx, request.(GetVerificationStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVerificationStats")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetVerificationStatsResponseObject); ok {
+		if err := validResponse.VisitGetVerificationStatsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetTagStats operation middleware
+func (sh *strictHandler) GetTagStats(w http.ResponseWriter, r *http.Request, params GetTagStatsParams) {
+	var request GetTagStatsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTagStats(ctx, request.(GetTagStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTagStats")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTagStatsResponseObject); ok {
+		if err := validResponse.VisitGetTagStatsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetVerificationTimings operation middleware
+func (sh *strictHandler) GetVerificationTimings(w http.ResponseWriter, r *http.Request, params GetVerificationTimingsParams) {
+	var request GetVerificationTimingsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVerificationTimings(ctx, request.(GetVerificationTimingsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVerificationTimings")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetVerificationTimingsResponseObject); ok {
+		if err := validResponse.VisitGetVerificationTimingsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListWebhookDeadLetters operation middleware
+func (sh *strictHandler) ListWebhookDeadLetters(w http.ResponseWriter, r *http.Request, params ListWebhookDeadLettersParams) {
+	var request ListWebhookDeadLettersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListWebhookDeadLetters(ctx, request.(ListWebhookDeadLettersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListWebhookDeadLetters")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListWebhookDeadLettersResponseObject); ok {
+		if err := validResponse.VisitListWebhookDeadLettersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReplayWebhookDeadLetter operation middleware
+func (sh *strictHandler) ReplayWebhookDeadLetter(w http.ResponseWriter, r *http.Request, deliveryID DeliveryID, params ReplayWebhookDeadLetterParams) {
+	var request ReplayWebhookDeadLetterRequestObject
+
+	request.DeliveryID = deliveryID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplayWebhookDeadLetter(ctx, request.(ReplayWebhookDeadLetterRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplayWebhookDeadLetter")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplayWebhookDeadLetterResponseObject); ok {
+		if err := validResponse.VisitReplayWebhookDeadLetterResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Callback operation middleware
+func (sh *strictHandler) Callback(w http.ResponseWriter, r *http.Request, params CallbackParams) {
+	var request CallbackRequestObject
+
+	request.Params = params
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+	body := CallbackTextRequestBody(data)
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Callback(ctx, request.(CallbackRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Callback")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CallbackResponseObject); ok {
+		if err := validResponse.VisitCallbackResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCampaignNullifierSession operation middleware
+func (sh *strictHandler) GetCampaignNullifierSession(w http.ResponseWriter, r *http.Request, campaign Campaign, params GetCampaignNullifierSessionParams) {
+	var request GetCampaignNullifierSessionRequestObject
+
+	request.Campaign = campaign
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCampaignNullifierSession(ctx, request.(GetCampaignNullifierSessionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCampaignNullifierSession")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCampaignNullifierSessionResponseObject); ok {
+		if err := validResponse.VisitGetCampaignNullifierSessionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCampaignNullifiers operation middleware
+func (sh *strictHandler) GetCampaignNullifiers(w http.ResponseWriter, r *http.Request, campaign Campaign, params GetCampaignNullifiersParams) {
+	var request GetCampaignNullifiersRequestObject
+
+	request.Campaign = campaign
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCampaignNullifiers(ctx, request.(GetCampaignNullifiersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCampaignNullifiers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCampaignNullifiersResponseObject); ok {
+		if err := validResponse.VisitGetCampaignNullifiersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetNetworks operation middleware
+func (sh *strictHandler) GetNetworks(w http.ResponseWriter, r *http.Request) {
+	var request GetNetworksRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetNetworks(ctx, request.(GetNetworksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetNetworks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetNetworksResponseObject); ok {
+		if err := validResponse.VisitGetNetworksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CredentialRevocationStatus operation middleware
+func (sh *strictHandler) CredentialRevocationStatus(w http.ResponseWriter, r *http.Request) {
+	var request CredentialRevocationStatusRequestObject
+
+	var body CredentialRevocationStatusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CredentialRevocationStatus(ctx, request.(CredentialRevocationStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CredentialRevocationStatus")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CredentialRevocationStatusResponseObject); ok {
+		if err := validResponse.VisitCredentialRevocationStatusResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Health operation middleware
+func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
+	var request HealthRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Health(ctx, request.(HealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Health")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(HealthResponseObject); ok {
+		if err := validResponse.VisitHealthResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetNullifierCheckpoints operation middleware
+func (sh *strictHandler) GetNullifierCheckpoints(w http.ResponseWriter, r *http.Request) {
+	var request GetNullifierCheckpointsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetNullifierCheckpoints(ctx, request.(GetNullifierCheckpointsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetNullifierCheckpoints")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetNullifierCheckpointsResponseObject); ok {
+		if err := validResponse.VisitGetNullifierCheckpointsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetNullifierProof operation middleware
+func (sh *strictHandler) GetNullifierProof(w http.ResponseWriter, r *http.Request, nullifier Nullifier, params GetNullifierProofParams) {
+	var request GetNullifierProofRequestObject
+
+	request.Nullifier = nullifier
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetNullifierProof(ctx, request.(GetNullifierProofRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetNullifierProof")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetNullifierProofResponseObject); ok {
+		if err := validResponse.VisitGetNullifierProofResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetQRCodeFromStore operation middleware
+func (sh *strictHandler) GetQRCodeFromStore(w http.ResponseWriter, r *http.Request, params GetQRCodeFromStoreParams) {
+	var request GetQRCodeFromStoreRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetQRCodeFromStore(ctx, request.(GetQRCodeFromStoreRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetQRCodeFromStore")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetQRCodeFromStoreResponseObject); ok {
+		if err := validResponse.VisitGetQRCodeFromStoreResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// FetchQRCodeFromStore operation middleware
+func (sh *strictHandler) FetchQRCodeFromStore(w http.ResponseWriter, r *http.Request, params FetchQRCodeFromStoreParams) {
+	var request FetchQRCodeFromStoreRequestObject
+
+	request.Params = params
+
+	var body FetchQRCodeFromStoreJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.FetchQRCodeFromStore(ctx, request.(FetchQRCodeFromStoreRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "FetchQRCodeFromStore")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(FetchQRCodeFromStoreResponseObject); ok {
+		if err := validResponse.VisitFetchQRCodeFromStoreResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateSandboxKey operation middleware
+func (sh *strictHandler) CreateSandboxKey(w http.ResponseWriter, r *http.Request) {
+	var request CreateSandboxKeyRequestObject
+
+	var body CreateSandboxKeyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateSandboxKey(ctx, request.(CreateSandboxKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateSandboxKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateSandboxKeyResponseObject); ok {
+		if err := validResponse.VisitCreateSandboxKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// VerifySandboxKey operation middleware
+func (sh *strictHandler) VerifySandboxKey(w http.ResponseWriter, r *http.Request) {
+	var request VerifySandboxKeyRequestObject
+
+	var body VerifySandboxKeyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.VerifySandboxKey(ctx, request.(VerifySandboxKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "VerifySandboxKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(VerifySandboxKeyResponseObject); ok {
+		if err := validResponse.VisitVerifySandboxKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateCredentialOffer operation middleware
+func (sh *strictHandler) CreateCredentialOffer(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params CreateCredentialOfferParams) {
+	var request CreateCredentialOfferRequestObject
+
+	request.SessionID = sessionID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateCredentialOffer(ctx, request.(CreateCredentialOfferRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateCredentialOffer")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateCredentialOfferResponseObject); ok {
+		if err := validResponse.VisitCreateCredentialOfferResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSessionEvents operation middleware
+func (sh *strictHandler) GetSessionEvents(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params GetSessionEventsParams) {
+	var request GetSessionEventsRequestObject
+
+	request.SessionID = sessionID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSessionEvents(ctx, request.(GetSessionEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSessionEvents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSessionEventsResponseObject); ok {
+		if err := validResponse.VisitGetSessionEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// FinalizeSession operation middleware
+func (sh *strictHandler) FinalizeSession(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params FinalizeSessionParams) {
+	var request FinalizeSessionRequestObject
+
+	request.SessionID = sessionID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.FinalizeSession(ctx, request.(FinalizeSessionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "FinalizeSession")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(FinalizeSessionResponseObject); ok {
+		if err := validResponse.VisitFinalizeSessionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CheckOnChainProofs operation middleware
+func (sh *strictHandler) CheckOnChainProofs(w http.ResponseWriter, r *http.Request, sessionID PathSessionID) {
+	var request CheckOnChainProofsRequestObject
+
+	request.SessionID = sessionID
+
+	var body CheckOnChainProofsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CheckOnChainProofs(ctx, request.(CheckOnChainProofsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CheckOnChainProofs")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CheckOnChainProofsResponseObject); ok {
+		if err := validResponse.VisitCheckOnChainProofsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSessionResult operation middleware
+func (sh *strictHandler) GetSessionResult(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params GetSessionResultParams) {
+	var request GetSessionResultRequestObject
+
+	request.SessionID = sessionID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSessionResult(ctx, request.(GetSessionResultRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSessionResult")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSessionResultResponseObject); ok {
+		if err := validResponse.VisitGetSessionResultResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSessionWebhooks operation middleware
+func (sh *strictHandler) GetSessionWebhooks(w http.ResponseWriter, r *http.Request, sessionID PathSessionID, params GetSessionWebhooksParams) {
+	var request GetSessionWebhooksRequestObject
+
+	request.SessionID = sessionID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSessionWebhooks(ctx, request.(GetSessionWebhooksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSessionWebhooks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSessionWebhooksResponseObject); ok {
+		if err := validResponse.VisitGetSessionWebhooksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SignIn operation middleware
+func (sh *strictHandler) SignIn(w http.ResponseWriter, r *http.Request, params SignInParams) {
+	var request SignInRequestObject
+
+	request.Params = params
+
+	var body SignInJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SignIn(ctx, request.(SignInRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SignIn")
+	}
 
-func (response SignIn200JSONResponse) VisitSignInResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SignInResponseObject); ok {
+		if err := validResponse.VisitSignInResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type SignIn400JSONResponse struct{ N400JSONResponse }
+// SignInAuth operation middleware
+func (sh *strictHandler) SignInAuth(w http.ResponseWriter, r *http.Request, params SignInAuthParams) {
+	var request SignInAuthRequestObject
 
-func (response SignIn400JSONResponse) VisitSignInResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body SignInAuthJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type SignIn500JSONResponse struct{ N500JSONResponse }
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SignInAuth(ctx, request.(SignInAuthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SignInAuth")
+	}
 
-func (response SignIn500JSONResponse) VisitSignInResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SignInAuthResponseObject); ok {
+		if err := validResponse.VisitSignInAuthResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StatusRequestObject struct {
-	Params StatusParams
-}
+// SignInBatch operation middleware
+func (sh *strictHandler) SignInBatch(w http.ResponseWriter, r *http.Request, params SignInBatchParams) {
+	var request SignInBatchRequestObject
 
-type StatusResponseObject interface {
-	VisitStatusResponse(w http.ResponseWriter) error
-}
+	request.Params = params
 
-type Status200JSONResponse StatusResponse
+	var body SignInBatchJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func (response Status200JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SignInBatch(ctx, request.(SignInBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SignInBatch")
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SignInBatchResponseObject); ok {
+		if err := validResponse.VisitSignInBatchResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type Status404JSONResponse struct{ N404JSONResponse }
+// SignInFlow operation middleware
+func (sh *strictHandler) SignInFlow(w http.ResponseWriter, r *http.Request, name FlowName, params SignInFlowParams) {
+	var request SignInFlowRequestObject
 
-func (response Status404JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.Name = name
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body SignInFlowJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type Status500JSONResponse struct{ N500JSONResponse }
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SignInFlow(ctx, request.(SignInFlowRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SignInFlow")
+	}
 
-func (response Status500JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SignInFlowResponseObject); ok {
+		if err := validResponse.VisitSignInFlowResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Get the documentation
-	// (GET /)
-	GetDocumentation(ctx context.Context, request GetDocumentationRequestObject) (GetDocumentationResponseObject, error)
-	// Callback
-	// (POST /callback)
-	Callback(ctx context.Context, request CallbackRequestObject) (CallbackResponseObject, error)
-	// Health Check
-	// (GET /health)
-	Health(ctx context.Context, request HealthRequestObject) (HealthResponseObject, error)
-	// Get QRCode from store
-	// (GET /qr-store)
-	GetQRCodeFromStore(ctx context.Context, request GetQRCodeFromStoreRequestObject) (GetQRCodeFromStoreResponseObject, error)
-	// Sign in
-	// (POST /sign-in)
-	SignIn(ctx context.Context, request SignInRequestObject) (SignInResponseObject, error)
-	// Get Status
-	// (GET /status)
-	Status(ctx context.Context, request StatusRequestObject) (StatusResponseObject, error)
-}
+// SignInLink operation middleware
+func (sh *strictHandler) SignInLink(w http.ResponseWriter, r *http.Request, params SignInLinkParams) {
+	var request SignInLinkRequestObject
 
-type StrictHandlerFunc = strictnethttp.StrictHttpHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHttpMiddlewareFunc
+	request.Params = params
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SignInLink(ctx, request.(SignInLinkRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SignInLink")
+	}
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SignInLinkResponseObject); ok {
+		if err := validResponse.VisitSignInLinkResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
+// SignInUnique operation middleware
+func (sh *strictHandler) SignInUnique(w http.ResponseWriter, r *http.Request, params SignInUniqueParams) {
+	var request SignInUniqueRequestObject
 
-// GetDocumentation operation middleware
-func (sh *strictHandler) GetDocumentation(w http.ResponseWriter, r *http.Request) {
-	var request GetDocumentationRequestObject
+	request.Params = params
+
+	var body SignInUniqueJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetDocumentation(ctx, request.(GetDocumentationRequestObject))
+		return sh.ssi.SignInUnique(ctx, request.(SignInUniqueRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetDocumentation")
+		handler = middleware(handler, "SignInUnique")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetDocumentationResponseObject); ok {
-		if err := validResponse.VisitGetDocumentationResponse(w); err != nil {
+	} else if validResponse, ok := response.(SignInUniqueResponseObject); ok {
+		if err := validResponse.VisitSignInUniqueResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -810,33 +8794,25 @@ func (sh *strictHandler) GetDocumentation(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// Callback operation middleware
-func (sh *strictHandler) Callback(w http.ResponseWriter, r *http.Request, params CallbackParams) {
-	var request CallbackRequestObject
+// Status operation middleware
+func (sh *strictHandler) Status(w http.ResponseWriter, r *http.Request, params StatusParams) {
+	var request StatusRequestObject
 
 	request.Params = params
 
-	data, err := io.ReadAll(r.Body)
-	if err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't read body: %w", err))
-		return
-	}
-	body := CallbackTextRequestBody(data)
-	request.Body = &body
-
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Callback(ctx, request.(CallbackRequestObject))
+		return sh.ssi.Status(ctx, request.(StatusRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Callback")
+		handler = middleware(handler, "Status")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CallbackResponseObject); ok {
-		if err := validResponse.VisitCallbackResponse(w); err != nil {
+	} else if validResponse, ok := response.(StatusResponseObject); ok {
+		if err := validResponse.VisitStatusResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -844,23 +8820,25 @@ func (sh *strictHandler) Callback(w http.ResponseWriter, r *http.Request, params
 	}
 }
 
-// Health operation middleware
-func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
-	var request HealthRequestObject
+// GetTenantJWKS operation middleware
+func (sh *strictHandler) GetTenantJWKS(w http.ResponseWriter, r *http.Request, tenantID TenantID) {
+	var request GetTenantJWKSRequestObject
+
+	request.TenantID = tenantID
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Health(ctx, request.(HealthRequestObject))
+		return sh.ssi.GetTenantJWKS(ctx, request.(GetTenantJWKSRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Health")
+		handler = middleware(handler, "GetTenantJWKS")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(HealthResponseObject); ok {
-		if err := validResponse.VisitHealthResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetTenantJWKSResponseObject); ok {
+		if err := validResponse.VisitGetTenantJWKSResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -868,25 +8846,30 @@ func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetQRCodeFromStore operation middleware
-func (sh *strictHandler) GetQRCodeFromStore(w http.ResponseWriter, r *http.Request, params GetQRCodeFromStoreParams) {
-	var request GetQRCodeFromStoreRequestObject
+// BuildQuery operation middleware
+func (sh *strictHandler) BuildQuery(w http.ResponseWriter, r *http.Request) {
+	var request BuildQueryRequestObject
 
-	request.Params = params
+	var body BuildQueryJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetQRCodeFromStore(ctx, request.(GetQRCodeFromStoreRequestObject))
+		return sh.ssi.BuildQuery(ctx, request.(BuildQueryRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetQRCodeFromStore")
+		handler = middleware(handler, "BuildQuery")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetQRCodeFromStoreResponseObject); ok {
-		if err := validResponse.VisitGetQRCodeFromStoreResponse(w); err != nil {
+	} else if validResponse, ok := response.(BuildQueryResponseObject); ok {
+		if err := validResponse.VisitBuildQueryResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -894,11 +8877,11 @@ func (sh *strictHandler) GetQRCodeFromStore(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// SignIn operation middleware
-func (sh *strictHandler) SignIn(w http.ResponseWriter, r *http.Request) {
-	var request SignInRequestObject
+// UnpackMessage operation middleware
+func (sh *strictHandler) UnpackMessage(w http.ResponseWriter, r *http.Request) {
+	var request UnpackMessageRequestObject
 
-	var body SignInJSONRequestBody
+	var body UnpackMessageJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -906,18 +8889,18 @@ func (sh *strictHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.SignIn(ctx, request.(SignInRequestObject))
+		return sh.ssi.UnpackMessage(ctx, request.(UnpackMessageRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "SignIn")
+		handler = middleware(handler, "UnpackMessage")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(SignInResponseObject); ok {
-		if err := validResponse.VisitSignInResponse(w); err != nil {
+	} else if validResponse, ok := response.(UnpackMessageResponseObject); ok {
+		if err := validResponse.VisitUnpackMessageResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -925,25 +8908,52 @@ func (sh *strictHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Status operation middleware
-func (sh *strictHandler) Status(w http.ResponseWriter, r *http.Request, params StatusParams) {
-	var request StatusRequestObject
+// ListUsers operation middleware
+func (sh *strictHandler) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
+	var request ListUsersRequestObject
 
 	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Status(ctx, request.(StatusRequestObject))
+		return sh.ssi.ListUsers(ctx, request.(ListUsersRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Status")
+		handler = middleware(handler, "ListUsers")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(StatusResponseObject); ok {
-		if err := validResponse.VisitStatusResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListUsersResponseObject); ok {
+		if err := validResponse.VisitListUsersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUser operation middleware
+func (sh *strictHandler) GetUser(w http.ResponseWriter, r *http.Request, did Did, params GetUserParams) {
+	var request GetUserRequestObject
+
+	request.Did = did
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUser(ctx, request.(GetUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetUserResponseObject); ok {
+		if err := validResponse.VisitGetUserResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/mail"
+	"time"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+const (
+	sandboxKeyPrefix           = "sbx_"
+	sandboxKeyBytes            = 16
+	sandboxVerificationTTL     = 15 * time.Minute
+	sandboxVerificationDigits  = 6
+	sandboxVerificationMaxCode = 1000000
+)
+
+var (
+	errAPIKeyRequired          = i18n.New(i18n.CodeAPIKeyRequired)
+	errAPIKeyInvalid           = i18n.New(i18n.CodeAPIKeyInvalid)
+	errVerificationCodeInvalid = i18n.New(i18n.CodeSandboxVerificationInvalid)
+	errVerificationCodeLocked  = i18n.New(i18n.CodeSandboxVerificationLocked)
+)
+
+// SandboxAPIKey is a time-limited API key bound to a set of chains
+type SandboxAPIKey struct {
+	Key       string
+	Email     string
+	ChainIDs  []string
+	ExpiresAt time.Time
+}
+
+// AllowsChain returns true if the key can be used to create requests for the given chain.
+func (k SandboxAPIKey) AllowsChain(chainID string) bool {
+	for _, id := range k.ChainIDs {
+		if id == chainID {
+			return true
+		}
+	}
+	return false
+}
+
+type sandboxVerification struct {
+	Code      string
+	Attempts  int
+	Used      bool
+	ExpiresAt time.Time
+}
+
+// APIKeyStore is a storage of sandbox API keys, their email verification codes and the rate limits of their creation.
+// Values are stored as JSON, so the cache can be shared by the replicas.
+type APIKeyStore struct {
+	cache       qrCache
+	maxAttempts int
+	rates       *rateLimiter
+}
+
+// NewAPIKeyStore creates a new APIKeyStore. A verification code is locked after maxAttempts invalid codes,
+// and at most rateLimit keys or codes can be requested by an email or an address per rateWindow.
+func NewAPIKeyStore(c qrCache, maxAttempts, rateLimit int, rateWindow time.Duration) *APIKeyStore {
+	return &APIKeyStore{cache: c, maxAttempts: maxAttempts, rates: newRateLimiter(c, "sandbox-rate-", rateLimit, rateWindow)}
+}
+
+// Mint creates a new sandbox key for the given email that expires after ttl.
+func (s *APIKeyStore) Mint(email string, chainIDs []string, ttl time.Duration) (SandboxAPIKey, error) {
+	b := make([]byte, sandboxKeyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return SandboxAPIKey{}, err
+	}
+
+	key := SandboxAPIKey{
+		Key:       sandboxKeyPrefix + hex.EncodeToString(b),
+		Email:     email,
+		ChainIDs:  chainIDs,
+		ExpiresAt: time.Now().Add(ttl).UTC(),
+	}
+	if err := s.set(s.key()+key.Key, key, ttl); err != nil {
+		return SandboxAPIKey{}, err
+	}
+	return key, nil
+}
+
+// Get returns a sandbox key from the cache. Expired keys are not returned.
+func (s *APIKeyStore) Get(key string) (*SandboxAPIKey, error) {
+	var sk SandboxAPIKey
+	if !s.get(s.key()+key, &sk) || time.Now().After(sk.ExpiresAt) {
+		return nil, errAPIKeyInvalid
+	}
+	return &sk, nil
+}
+
+// NewVerificationCode creates and stores a verification code for the given email.
+func (s *APIKeyStore) NewVerificationCode(email string) (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(sandboxVerificationMaxCode))
+	if err != nil {
+		return "", err
+	}
+	code := fmt.Sprintf("%0*d", sandboxVerificationDigits, n.Int64())
+	v := sandboxVerification{Code: code, ExpiresAt: time.Now().Add(sandboxVerificationTTL)}
+	if err := s.set(s.verificationKey(email), v, sandboxVerificationTTL); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// CheckVerificationCode validates the code sent to the given email. A code can only be used once, and it is locked
+// after too many invalid attempts, so a new code must be requested.
+func (s *APIKeyStore) CheckVerificationCode(email, code string) error {
+	var v sandboxVerification
+	if !s.get(s.verificationKey(email), &v) || v.Used {
+		return errVerificationCodeInvalid
+	}
+	ttl := time.Until(v.ExpiresAt)
+	if ttl <= 0 {
+		return errVerificationCodeInvalid
+	}
+	if v.Attempts >= s.maxAttempts {
+		return errVerificationCodeLocked
+	}
+
+	if subtle.ConstantTimeCompare([]byte(v.Code), []byte(code)) != 1 {
+		v.Attempts++
+		if err := s.set(s.verificationKey(email), v, ttl); err != nil {
+			return err
+		}
+		if v.Attempts >= s.maxAttempts {
+			return errVerificationCodeLocked
+		}
+		return errVerificationCodeInvalid
+	}
+
+	v.Used = true
+	return s.set(s.verificationKey(email), v, ttl)
+}
+
+// Allow counts a request of the sandbox keys by subject, an email or an address, and returns false once the subject
+// made more than the rate limit of requests in the current window.
+func (s *APIKeyStore) Allow(subject string) bool {
+	return s.rates.Allow(subject)
+}
+
+func (s *APIKeyStore) get(id string, v any) bool {
+	data, ok := s.cache.Get(id)
+	if !ok {
+		return false
+	}
+	b, ok := data.([]byte)
+	if !ok {
+		return false
+	}
+	return json.Unmarshal(b, v) == nil
+}
+
+func (s *APIKeyStore) set(id string, v any, ttl time.Duration) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	s.cache.Set(id, b, ttl)
+	return nil
+}
+
+func (s *APIKeyStore) key() string {
+	return "sandbox-key-"
+}
+
+func (s *APIKeyStore) verificationKey(email string) string {
+	return "sandbox-verification-" + email
+}
+
+func parseEmail(email string) (string, error) {
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return "", fmt.Errorf("field email is not valid: %w", err)
+	}
+	return addr.Address, nil
+}
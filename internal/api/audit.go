@@ -0,0 +1,226 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/iden3comm/v2/protocol"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/audit"
+	verrors "github.com/0xPolygonID/verifier-backend/internal/errors"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+)
+
+const (
+	auditLogDisabled = "audit log is not enabled"
+
+	// defaultAuditPageSize is the number of records of an export without limit
+	defaultAuditPageSize = 1000
+	// maxAuditPageSize is the largest number of records of an export
+	maxAuditPageSize = 10000
+	// auditFlushInterval is the number of records written between the flushes of an export
+	auditFlushInterval = 100
+	// auditNextCursorHeader is the trailer of the exports with more records than their page, the cursor of the next page
+	auditNextCursorHeader = "X-Next-Cursor"
+)
+
+// auditCSVHeader is the first row of the csv exports, the lists are joined with ';'
+var auditCSVHeader = []string{"seq", "time", "type", "sessionID", "tenant", "userDID", "circuitIDs", "credentialTypes", "errorCode", "error"}
+
+// errAuditPageFull stops the scan of the audit log once the page of the export is full
+var errAuditPageFull = errors.New("audit page full")
+
+// WithAuditLog records the verifications in the audit log l
+func WithAuditLog(l audit.Log) Option {
+	return func(s *Server) {
+		s.audit = l
+	}
+}
+
+// ExportAudit streams the records of the audit log to admins, as json lines or csv with format=csv, appended at or
+// after the from and before the to RFC 3339 times when they are set. A page holds at most limit records numbered after
+// the after cursor, the seq of the last record of the previous page, which is also sent in the X-Next-Cursor trailer
+// when more records remain. The records are read from the log as the client receives them, so a slow client does not
+// make the server buffer the export.
+func (s *Server) ExportAudit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if s.audit == nil {
+		writeError(w, http.StatusNotFound, auditLogDisabled)
+		return
+	}
+	if apiKey := r.Header.Get("X-API-Key"); !s.hasRole(ctx, &apiKey, roleAdmin) {
+		writeError(w, http.StatusUnauthorized, i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired))
+		return
+	}
+
+	query := r.URL.Query()
+	var filter audit.Filter
+	for _, param := range []struct {
+		name string
+		t    *time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		if v := query.Get(param.name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid "+param.name+" time, expected RFC 3339")
+				return
+			}
+			*param.t = t
+		}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.To.After(filter.From) {
+		writeError(w, http.StatusBadRequest, "the range must end after it starts")
+		return
+	}
+	if v := query.Get("after"); v != "" {
+		after, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid after cursor")
+			return
+		}
+		filter.After = after
+	}
+	limit := defaultAuditPageSize
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxAuditPageSize {
+			writeError(w, http.StatusBadRequest, "the limit must be between 1 and "+strconv.Itoa(maxAuditPageSize))
+			return
+		}
+		limit = n
+	}
+	format := query.Get("format")
+	switch format {
+	case "", "jsonl":
+		format = "jsonl"
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	default:
+		writeError(w, http.StatusBadRequest, "invalid format, expected jsonl or csv")
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="audit.`+format+`"`)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Trailer", auditNextCursorHeader)
+	w.WriteHeader(http.StatusOK)
+
+	buf := bufio.NewWriter(w)
+	csvWriter := csv.NewWriter(buf)
+	flush := func() error {
+		if format == "csv" {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return err
+			}
+		}
+		if err := buf.Flush(); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		return nil
+	}
+	if format == "csv" {
+		_ = csvWriter.Write(auditCSVHeader)
+	}
+
+	written, last := 0, uint64(0)
+	err := s.audit.Scan(ctx, filter, func(record audit.Record) error {
+		if written == limit {
+			return errAuditPageFull
+		}
+		var err error
+		if format == "csv" {
+			err = csvWriter.Write(auditCSVRow(record))
+		} else {
+			var b []byte
+			if b, err = json.Marshal(record); err == nil {
+				_, err = buf.Write(append(b, '\n'))
+			}
+		}
+		if err != nil {
+			return err
+		}
+		written, last = written+1, record.Seq
+		if written%auditFlushInterval == 0 {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errAuditPageFull) {
+		// the status was sent, the client sees a truncated export without the next cursor
+		s.log(ctx).WithFields(log.Fields{"err": err, "records": written}).Error("failed to export audit log")
+		return
+	}
+	if err := flush(); err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err, "records": written}).Error("failed to export audit log")
+		return
+	}
+	if errors.Is(err, errAuditPageFull) {
+		w.Header().Set(auditNextCursorHeader, strconv.FormatUint(last, 10))
+	}
+}
+
+func auditCSVRow(record audit.Record) []string {
+	return []string{
+		strconv.FormatUint(record.Seq, 10),
+		record.Time.Format(time.RFC3339Nano),
+		record.Type,
+		record.SessionID,
+		record.Tenant,
+		record.UserDID,
+		strings.Join(record.CircuitIDs, ";"),
+		strings.Join(record.CredentialTypes, ";"),
+		record.ErrorCode,
+		record.Error,
+	}
+}
+
+// recordAudit appends the result of the verification of the session, read from the session cache, to the audit log
+func (s *Server) recordAudit(ctx context.Context, sessionID uuid.UUID, scopes []protocol.ZeroKnowledgeProofRequest, verified bool) {
+	if s.audit == nil {
+		return
+	}
+	record := audit.Record{
+		Time:            time.Now().UTC(),
+		Type:            audit.TypeVerificationSucceeded,
+		SessionID:       sessionID.String(),
+		Tenant:          s.getSessionTenant(sessionID),
+		CircuitIDs:      make([]string, 0, len(scopes)),
+		CredentialTypes: make([]string, 0, len(scopes)),
+	}
+	for _, scope := range scopes {
+		record.CircuitIDs = append(record.CircuitIDs, scope.CircuitID)
+		if credentialType, _ := scope.Query["type"].(string); credentialType != "" {
+			record.CredentialTypes = append(record.CredentialTypes, credentialType)
+		}
+	}
+	item, _ := s.cache.Get(sessionID.String())
+	if verified {
+		if verification, ok := item.(models.VerificationResponse); ok {
+			record.UserDID = verification.UserDID
+		}
+	} else {
+		record.Type = audit.TypeVerificationFailed
+		record.ErrorCode = string(verrors.CodeVerificationFailed)
+		if err, ok := item.(error); ok {
+			record.ErrorCode, record.Error = string(verrors.Classify(err)), err.Error()
+		}
+	}
+	if _, err := s.audit.Append(ctx, record); err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err, "sessionID": sessionID}).Error("failed to record audit")
+	}
+}
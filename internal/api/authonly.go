@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/logging"
+	"github.com/0xPolygonID/verifier-backend/internal/messages"
+)
+
+// SignInAuth - create a session where the user only proves the ownership of an identity, without scopes
+func (s *Server) SignInAuth(ctx context.Context, request SignInAuthRequestObject) (SignInAuthResponseObject, error) {
+	sessionID := uuid.New()
+	ctx = logging.WithEntry(ctx, s.log(ctx).WithField(logging.FieldSessionID, sessionID))
+
+	signIn := SignInRequestObject{
+		Params: SignInParams{XAPIKey: request.Params.XAPIKey},
+		Body: &SignInRequest{
+			ChainID: &request.Body.ChainID,
+			Reason:  request.Body.Reason,
+			To:      request.Body.To,
+			Scope:   []ScopeRequest{},
+			Tags:    request.Body.Tags,
+		},
+	}
+	if resp, ok := s.authorizeSignIn(ctx, signIn); !ok {
+		return signInAuthResponse(resp), nil
+	}
+	s.setSessionTenant(sessionID, s.tenantID(request.Params.XAPIKey))
+	s.setSessionOwner(sessionID, request.Params.XAPIKey)
+	s.setSessionPriority(sessionID, s.priority(ctx, request.Params.XAPIKey))
+
+	if err := validateTags(request.Body.Tags); err != nil {
+		s.log(ctx).Error(err)
+		return SignInAuth400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+	}
+	if request.Body.RedirectUri != nil {
+		if err := checkRedirectURI(*request.Body.RedirectUri); err != nil {
+			s.log(ctx).Error(err)
+			return SignInAuth400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+		}
+	}
+	if request.Body.ChainID == "" {
+		return SignInAuth400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeFieldEmpty, "chainID")}}, nil
+	}
+	senderDID, err := s.requestSenderDID(request.Body.ChainID)
+	if err != nil {
+		s.log(ctx).Error(err)
+		return SignInAuth400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+	}
+
+	authReq := messages.AuthRequest(messages.Request{
+		ID:          uuid.NewString(),
+		Reason:      s.reason(request.Body.Reason),
+		From:        senderDID,
+		To:          common.FromPointer(request.Body.To),
+		CallbackURL: getUri(s.publicURL(ctx, nil), sessionID),
+	})
+	s.setEthAddressRequired(sessionID, request.Body.RequireEthAddress)
+	s.setRedirectURI(sessionID, request.Body.RedirectUri)
+	resp := s.startOffChainSession(ctx, sessionID, authReq, signIn.Body, s.cfg.SessionTTL.AsDuration())
+	if _, ok := resp.(SignIn200JSONResponse); ok {
+		s.log(ctx).WithFields(log.Fields{"chainID": request.Body.ChainID}).Info("sign-in without scopes")
+	}
+	return signInAuthResponse(resp), nil
+}
+
+func signInAuthResponse(resp SignInResponseObject) SignInAuthResponseObject {
+	switch r := resp.(type) {
+	case SignIn200JSONResponse:
+		return SignInAuth200JSONResponse(r)
+	case SignIn400JSONResponse:
+		return SignInAuth400JSONResponse(r)
+	case SignIn401JSONResponse:
+		return SignInAuth401JSONResponse(r)
+	case SignIn403JSONResponse:
+		return SignInAuth403JSONResponse(r)
+	case SignIn500JSONResponse:
+		return SignInAuth500JSONResponse(r)
+	default:
+		return SignInAuth500JSONResponse{N500JSONResponse{Message: "unexpected sign-in response"}}
+	}
+}
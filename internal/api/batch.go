@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+// SignInBatch - create a session and a QR code for every sign-in request of the batch
+func (s *Server) SignInBatch(ctx context.Context, request SignInBatchRequestObject) (SignInBatchResponseObject, error) {
+	if len(request.Body.Requests) == 0 {
+		return SignInBatch400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeFieldEmpty, "requests")}}, nil
+	}
+	if len(request.Body.Requests) > s.cfg.SignInBatchMaxSize {
+		return SignInBatch400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeTooManyBatchRequests, s.cfg.SignInBatchMaxSize)}}, nil
+	}
+
+	ctx = withBatch(ctx)
+	results := make([]SignInBatchResult, 0, len(request.Body.Requests))
+	var failed int
+	for i := range request.Body.Requests {
+		resp, err := s.SignIn(ctx, SignInRequestObject{
+			Params: SignInParams{XAPIKey: request.Params.XAPIKey},
+			Body:   &request.Body.Requests[i],
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result := toSignInBatchResult(resp)
+		if result.Error != nil {
+			failed++
+		}
+		results = append(results, result)
+	}
+	s.log(ctx).WithFields(log.Fields{"requests": len(results), "failed": failed}).Info("sign-in batch processed")
+
+	return SignInBatch200JSONResponse{Results: results}, nil
+}
+
+func toSignInBatchResult(resp SignInResponseObject) SignInBatchResult {
+	switch r := resp.(type) {
+	case SignIn200JSONResponse:
+		return SignInBatchResult{SessionID: common.ToPointer(r.SessionID), QrCode: common.ToPointer(r.QrCode), ExpiresAt: common.ToPointer(r.ExpiresAt)}
+	case SignIn400JSONResponse:
+		return SignInBatchResult{Error: common.ToPointer(r.Message)}
+	case SignIn401JSONResponse:
+		return SignInBatchResult{Error: common.ToPointer(r.Message)}
+	case SignIn403JSONResponse:
+		return SignInBatchResult{Error: common.ToPointer(r.Message)}
+	case SignIn500JSONResponse:
+		return SignInBatchResult{Error: common.ToPointer(r.Message)}
+	default:
+		return SignInBatchResult{Error: common.ToPointer("unexpected sign-in response")}
+	}
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/iden3/go-circuits/v2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/nullifier"
+)
+
+const invalidCampaignName = "campaign name must have between 1 and 64 letters, digits, '-' or '_'"
+
+// SignInUnique - create a session where every user can only be verified once per campaign
+func (s *Server) SignInUnique(ctx context.Context, request SignInUniqueRequestObject) (SignInUniqueResponseObject, error) {
+	if !templateNameRegex.MatchString(request.Body.Campaign) {
+		return SignInUnique400JSONResponse{N400JSONResponse{Message: invalidCampaignName}}, nil
+	}
+
+	scopes := make([]ScopeRequest, 0, len(request.Body.Scope))
+	for _, scope := range request.Body.Scope {
+		if scope.CircuitId == "" && scope.Template == nil {
+			scope.CircuitId = string(circuits.AtomicQueryV3CircuitID)
+		}
+		params := ScopeParams{}
+		if scope.Params != nil {
+			for k, v := range *scope.Params {
+				params[k] = v
+			}
+		}
+		session := s.campaignNullifierSession(request.Params.XAPIKey, request.Body.Campaign, s.scopeCredentialType(scope))
+		params["nullifierSessionID"] = session.NullifierSessionID
+		scope.Params = &params
+		scopes = append(scopes, scope)
+	}
+
+	resp, err := s.SignIn(ctx, SignInRequestObject{
+		Params: SignInParams{XAPIKey: request.Params.XAPIKey},
+		Body: &SignInRequest{
+			ChainID:                &request.Body.ChainID,
+			Reason:                 request.Body.Reason,
+			To:                     request.Body.To,
+			Scope:                  scopes,
+			Tags:                   request.Body.Tags,
+			EnforceUniqueNullifier: common.ToPointer(true),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch r := resp.(type) {
+	case SignIn200JSONResponse:
+		return SignInUnique200JSONResponse(r), nil
+	case SignIn400JSONResponse:
+		return SignInUnique400JSONResponse(r), nil
+	case SignIn401JSONResponse:
+		return SignInUnique401JSONResponse(r), nil
+	case SignIn403JSONResponse:
+		return SignInUnique403JSONResponse(r), nil
+	case SignIn500JSONResponse:
+		return SignInUnique500JSONResponse(r), nil
+	default:
+		return SignInUnique500JSONResponse{N500JSONResponse{Message: "unexpected sign-in response"}}, nil
+	}
+}
+
+// GetCampaignNullifiers - get the nullifiers used in a campaign
+func (s *Server) GetCampaignNullifiers(ctx context.Context, request GetCampaignNullifiersRequestObject) (GetCampaignNullifiersResponseObject, error) {
+	if !s.canSignIn(request.Params.XAPIKey) {
+		return GetCampaignNullifiers401JSONResponse{N401JSONResponse{Message: i18n.Localize(ctx, errAPIKeyInvalid)}}, nil
+	}
+	if !templateNameRegex.MatchString(request.Campaign) {
+		return GetCampaignNullifiers400JSONResponse{N400JSONResponse{Message: invalidCampaignName}}, nil
+	}
+
+	nullifierSessionID := s.campaignNullifierSession(request.Params.XAPIKey, request.Campaign,
+		common.FromPointer(request.Params.CredentialType)).NullifierSessionID
+	nullifiers, err := s.nullifierStore.List(ctx, nullifierSessionID)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err, "campaign": request.Campaign}).Error("failed to list campaign nullifiers")
+		return GetCampaignNullifiers500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	if request.Params.Nullifier != nil {
+		filtered := make([]string, 0, 1)
+		if contains(nullifiers, *request.Params.Nullifier) {
+			filtered = append(filtered, *request.Params.Nullifier)
+		}
+		nullifiers = filtered
+	}
+
+	return GetCampaignNullifiers200JSONResponse{
+		Campaign:           request.Campaign,
+		NullifierSessionID: nullifierSessionID,
+		Nullifiers:         nullifiers,
+	}, nil
+}
+
+// GetCampaignNullifierSession - get the nullifier session of a campaign
+func (s *Server) GetCampaignNullifierSession(ctx context.Context, request GetCampaignNullifierSessionRequestObject) (GetCampaignNullifierSessionResponseObject, error) {
+	if !s.canSignIn(request.Params.XAPIKey) {
+		return GetCampaignNullifierSession401JSONResponse{N401JSONResponse{Message: i18n.Localize(ctx, errAPIKeyInvalid)}}, nil
+	}
+	if !templateNameRegex.MatchString(request.Campaign) {
+		return GetCampaignNullifierSession400JSONResponse{N400JSONResponse{Message: invalidCampaignName}}, nil
+	}
+	return GetCampaignNullifierSession200JSONResponse(s.campaignNullifierSession(request.Params.XAPIKey, request.Campaign,
+		common.FromPointer(request.Params.CredentialType))), nil
+}
+
+// campaignDerivation is the derivation of the nullifier sessions of the campaigns, and its name
+type campaignDerivation struct {
+	name string
+	nullifier.Derivation
+}
+
+// newCampaignDerivation returns the derivation registered with name, the campaign derivation when there is none.
+// The name is checked on startup.
+func newCampaignDerivation(name string) campaignDerivation {
+	if derivation, err := nullifier.LookupDerivation(name); err == nil {
+		return campaignDerivation{name: name, Derivation: derivation}
+	}
+	derivation, _ := nullifier.LookupDerivation(nullifier.DerivationCampaign)
+	return campaignDerivation{name: nullifier.DerivationCampaign, Derivation: derivation}
+}
+
+// campaignNullifierSession derives the nullifier session of the scopes of the credential type in a campaign of the
+// tenant of the api key, so campaigns with the same name of different tenants do not share their nullifiers
+func (s *Server) campaignNullifierSession(apiKey *string, campaign, credentialType string) NullifierSession {
+	input := nullifier.SessionInput{
+		Namespace:      s.cfg.Nullifiers.Namespace,
+		Tenant:         s.tenantID(apiKey),
+		Campaign:       campaign,
+		CredentialType: credentialType,
+	}
+	session := NullifierSession{
+		Campaign:           campaign,
+		Derivation:         s.campaignSessions.name,
+		NullifierSessionID: s.campaignSessions.Derive(input).String(),
+	}
+	if input.Namespace != "" {
+		session.Namespace = &input.Namespace
+	}
+	if input.Tenant != "" {
+		session.Tenant = &input.Tenant
+	}
+	if credentialType != "" {
+		session.CredentialType = &credentialType
+	}
+	return session
+}
+
+// scopeCredentialType returns the credential type of the query of the scope, or of its query template
+func (s *Server) scopeCredentialType(scope ScopeRequest) string {
+	if credentialType, _ := scope.Query["type"].(string); credentialType != "" || scope.Template == nil {
+		return credentialType
+	}
+	if template, ok := s.queryTemplates.Get(*scope.Template); ok {
+		credentialType, _ := template.Query["type"].(string)
+		return credentialType
+	}
+	return ""
+}
+
+// canSignIn checks that the api key can create sessions, regardless of the chains it is restricted to
+func (s *Server) canSignIn(apiKey *string) bool {
+	if apiKey == nil || *apiKey == "" {
+		return len(s.cfg.APIKeys) == 0 && len(s.cfg.Tenants) == 0
+	}
+	for _, key := range s.cfg.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(*apiKey)) == 1 {
+			return true
+		}
+	}
+	if s.tenantID(apiKey) != "" {
+		return true
+	}
+	_, err := s.apiKeys.Get(*apiKey)
+	return err == nil
+}
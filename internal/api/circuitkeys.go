@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/circuitkeys"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+// WithCircuitKeys sets the loader of the verification keys used by the verifier, so they can be listed and reloaded
+func WithCircuitKeys(l *circuitkeys.Loader) Option {
+	return func(s *Server) {
+		s.circuitKeys = l
+	}
+}
+
+// ListCircuitKeys - list the loaded verification keys
+func (s *Server) ListCircuitKeys(ctx context.Context, request ListCircuitKeysRequestObject) (ListCircuitKeysResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return ListCircuitKeys401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	return ListCircuitKeys200JSONResponse(s.listCircuitKeys()), nil
+}
+
+// ReloadCircuitKeys - fetch the verification keys again from their location
+func (s *Server) ReloadCircuitKeys(ctx context.Context, request ReloadCircuitKeysRequestObject) (ReloadCircuitKeysResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleAdmin) {
+		return ReloadCircuitKeys401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	if err := s.circuitKeys.Reload(ctx); err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to reload verification keys")
+		return ReloadCircuitKeys500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	s.log(ctx).WithFields(log.Fields{"location": s.circuitKeys.Location()}).Info("verification keys reloaded")
+	return ReloadCircuitKeys200JSONResponse(s.listCircuitKeys()), nil
+}
+
+func (s *Server) listCircuitKeys() CircuitKeys {
+	loaded := s.circuitKeys.Keys()
+	keys := make([]CircuitKey, 0, len(loaded))
+	for _, key := range loaded {
+		keys = append(keys, CircuitKey{CircuitId: string(key.CircuitID), Checksum: key.Checksum, LoadedAt: key.LoadedAt})
+	}
+	return CircuitKeys{Location: s.circuitKeys.Location(), Keys: keys}
+}
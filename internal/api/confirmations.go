@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/confirmations"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+)
+
+const defaultConfirmationPollInterval = 15 * time.Second
+
+// watchConfirmations waits until the states of a provisional verification have the required confirmations
+// to make it final, or fails it if one of them is reverted. It gives up when the session expires.
+func (s *Server) watchConfirmations(sessionID uuid.UUID, jwz string, states []confirmations.State) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.CacheExpiration.AsDuration())
+	defer cancel()
+	interval := s.cfg.ConfirmationPollInterval.AsDuration()
+	if interval <= 0 {
+		interval = defaultConfirmationPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for len(states) > 0 {
+		select {
+		case <-ctx.Done():
+			s.logger.WithFields(log.Fields{"sessionID": sessionID}).Warn("verification still provisional when the session expired")
+			return
+		case <-ticker.C:
+		}
+
+		unconfirmed := states[:0]
+		for _, st := range states {
+			confirmed, err := st.Confirmed(ctx)
+			if errors.Is(err, confirmations.ErrStateReverted) {
+				s.logger.WithFields(log.Fields{"sessionID": sessionID, "network": st.Network, "state": st.State}).
+					Error("state of a provisional verification was reverted")
+				s.finishProvisional(sessionID, jwz, i18n.New(i18n.CodeStateReverted, st.State, st.Network))
+				return
+			}
+			if err != nil {
+				s.logger.WithFields(log.Fields{"sessionID": sessionID, "network": st.Network, "err": err}).
+					Warn("failed to check the confirmations of a state")
+			}
+			if !confirmed {
+				unconfirmed = append(unconfirmed, st)
+			}
+		}
+		states = unconfirmed
+	}
+	s.finishProvisional(sessionID, jwz, nil)
+}
+
+// finishProvisional makes the provisional verification of the session final, or replaces it with err.
+// Results that were consumed or replaced in the meantime are left untouched.
+func (s *Server) finishProvisional(sessionID uuid.UUID, jwz string, err error) {
+	s.resultsMu.Lock()
+	defer s.resultsMu.Unlock()
+	defer s.publishStatus(sessionID)
+
+	item, ok := s.cache.Get(sessionID.String())
+	if !ok {
+		return
+	}
+	verification, ok := item.(models.VerificationResponse)
+	if !ok || !verification.Provisional || verification.Jwz != jwz {
+		return
+	}
+	if err != nil {
+		s.cache.Set(sessionID.String(), err, cache.DefaultExpiration)
+		return
+	}
+	verification.Provisional = false
+	s.cache.Set(sessionID.String(), verification, cache.DefaultExpiration)
+	s.logger.WithFields(log.Fields{"sessionID": sessionID}).Info("provisional verification confirmed")
+}
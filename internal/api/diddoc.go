@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"sort"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+const (
+	didContext           = "https://www.w3.org/ns/did/v1"
+	jwsContext           = "https://w3id.org/security/suites/jws-2020/v1"
+	jsonWebKeyType       = "JsonWebKey2020"
+	iden3CommServiceType = "Iden3CommServiceV1"
+	pushServiceType      = "push-notification"
+)
+
+// GetDIDDocument - get the DID document of the verifier
+func (s *Server) GetDIDDocument(_ context.Context, _ GetDIDDocumentRequestObject) (GetDIDDocumentResponseObject, error) {
+	if !s.cfg.DIDDocument.Enabled {
+		return GetDIDDocument404JSONResponse{N404JSONResponse{Message: "did document is not enabled"}}, nil
+	}
+	did, err := s.cfg.DIDDocument.WebDID(s.cfg.Host)
+	if err != nil {
+		return GetDIDDocument404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+	}
+
+	doc := DIDDocument{
+		Context: []string{didContext},
+		Id:      did,
+		Service: []DIDService{{
+			Id:              did + "#iden3-communication",
+			Type:            iden3CommServiceType,
+			ServiceEndpoint: s.cfg.WalletURL() + config.CallbackURL,
+		}},
+	}
+	if s.cfg.DIDDocument.PushURL != "" {
+		doc.Service = append(doc.Service, DIDService{
+			Id:              did + "#push",
+			Type:            pushServiceType,
+			ServiceEndpoint: s.cfg.DIDDocument.PushURL,
+		})
+	}
+	if senders := s.networkSenderDIDs(); len(senders) > 0 {
+		doc.AlsoKnownAs = &senders
+	}
+	// the tokens are signed with the keys of the verifier, so they are its assertion methods
+	if jwks, err := s.keys.JWKS(""); err == nil && len(jwks.Keys) > 0 {
+		methods := make([]DIDVerificationMethod, 0, len(jwks.Keys))
+		assertions := make([]string, 0, len(jwks.Keys))
+		for _, key := range jwks.Keys {
+			id := did + "#" + key.KeyID
+			methods = append(methods, DIDVerificationMethod{Id: id, Type: jsonWebKeyType, Controller: did, PublicKeyJwk: key})
+			assertions = append(assertions, id)
+		}
+		doc.Context = append(doc.Context, jwsContext)
+		doc.VerificationMethod = &methods
+		doc.AssertionMethod = &assertions
+	}
+	return GetDIDDocument200JSONResponse(doc), nil
+}
+
+// networkSenderDIDs returns the DIDs the verifier sends the requests from on the networks of the resolver settings
+func (s *Server) networkSenderDIDs() []string {
+	seen := make(map[string]bool)
+	dids := make([]string, 0)
+	for _, networks := range s.cfg.ResolverSettings {
+		for _, settings := range networks {
+			did, err := s.getSenderDID(settings.ChainID)
+			if err != nil || did == "" || seen[did] {
+				continue
+			}
+			seen[did] = true
+			dids = append(dids, did)
+		}
+	}
+	sort.Strings(dids)
+	return dids
+}
+
+// requestSenderDID returns the DID the off-chain requests are sent from, the did:web identity of the verifier when it
+// is the sender, or the DID of the network of the chain
+func (s *Server) requestSenderDID(chainID string) (string, error) {
+	if s.cfg.DIDDocument.Sender {
+		return s.cfg.DIDDocument.WebDID(s.cfg.Host)
+	}
+	return s.getSenderDID(chainID)
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-jwz/v2"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+const (
+	sessionWalletKeyPrefix = "session-wallet-"
+	// maxWalletDeliveries is the number of deliveries kept per session, the oldest are dropped first
+	maxWalletDeliveries = 16
+)
+
+// walletDelivery is a request of an off-chain session delivered to a wallet with a POST of its request_uri
+type walletDelivery struct {
+	WalletDID string
+	ThreadID  string
+	Nonce     string
+}
+
+// walletDeliveries are the last deliveries of a session. The DIDs of the POSTs are not proven, so they do not bind the
+// session: it is bound to a wallet once the verified response of that wallet answers the request delivered to it.
+type walletDeliveries []walletDelivery
+
+// FetchQRCodeFromStore - delivers the request of a QR code to a wallet, personalized for it
+func (s *Server) FetchQRCodeFromStore(ctx context.Context, request FetchQRCodeFromStoreRequestObject) (FetchQRCodeFromStoreResponseObject, error) {
+	if request.Body == nil || request.Body.Nonce == "" {
+		return FetchQRCodeFromStore400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeFieldEmpty, "nonce")}}, nil
+	}
+	if _, err := w3c.ParseDID(request.Body.WalletDID); err != nil {
+		return FetchQRCodeFromStore400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeWalletDIDInvalid, request.Body.WalletDID)}}, nil
+	}
+
+	qrCode, err := s.qrStore.Get(request.Params.Id)
+	if errors.Is(err, errQRCodeNotFound) {
+		return FetchQRCodeFromStore404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeQRCodeNotFound)}}, nil
+	}
+	if err != nil {
+		return FetchQRCodeFromStore500JSONResponse{N500JSONResponse{Message: fmt.Sprintf("Error getting QRCode: %s", err.Error())}}, nil
+	}
+	if qrCode.Body.CallbackUrl == nil {
+		return FetchQRCodeFromStore400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeDirectDeliveryOnChain)}}, nil
+	}
+	sessionID, err := callbackSessionID(*qrCode.Body.CallbackUrl)
+	if err != nil {
+		return FetchQRCodeFromStore500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	threadID := uuid.NewString()
+	if err := s.deliverToWallet(sessionID, request.Body.WalletDID, request.Body.Nonce, threadID); err != nil {
+		s.log(ctx).WithFields(log.Fields{"sessionID": sessionID, "walletDID": request.Body.WalletDID, "err": err}).Warn("request delivery refused")
+		if i18n.HasCode(err, i18n.CodeSessionNotFound) {
+			return FetchQRCodeFromStore404JSONResponse{N404JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+		}
+		return FetchQRCodeFromStore409JSONResponse{N409JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+	}
+	s.tags.scan(request.Params.Id)
+	s.scanSession(request.Params.Id)
+	s.log(ctx).WithFields(log.Fields{"sessionID": sessionID, "walletDID": request.Body.WalletDID}).Info("request delivered to wallet")
+
+	qrCode.To = &request.Body.WalletDID
+	qrCode.Thid = threadID
+	return FetchQRCodeFromStore200JSONResponse(*qrCode), nil
+}
+
+// callbackSessionID returns the session of the callback url of a QR code
+func callbackSessionID(callbackURL string) (uuid.UUID, error) {
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid callback url of the qr code: %w", err)
+	}
+	return uuid.Parse(u.Query().Get("sessionID"))
+}
+
+// deliverToWallet records the delivery of a pending session to walletDID with the thread id of the request. Every
+// delivery must use a new nonce.
+func (s *Server) deliverToWallet(sessionID uuid.UUID, walletDID, nonce, threadID string) error {
+	s.resultsMu.Lock()
+	defer s.resultsMu.Unlock()
+
+	item, ok := s.cache.Get(sessionID.String())
+	if !ok {
+		return i18n.New(i18n.CodeSessionNotFound)
+	}
+	authRequest, pending := item.(protocol.AuthorizationRequestMessage)
+	if !pending {
+		return i18n.New(i18n.CodeSessionNotPending, sessionID)
+	}
+	if authRequest.To != "" && authRequest.To != walletDID {
+		return i18n.New(i18n.CodeSessionBoundToWallet, sessionID)
+	}
+
+	delivered, _ := s.walletDeliveries(sessionID)
+	for _, delivery := range delivered {
+		if delivery.Nonce == nonce {
+			return i18n.New(i18n.CodeWalletNonceReused, sessionID)
+		}
+	}
+	// the deliveries are replaced rather than updated, so the callbacks read them without holding the lock
+	delivered = append(slices.Clone(delivered), walletDelivery{WalletDID: walletDID, ThreadID: threadID, Nonce: nonce})
+	if len(delivered) > maxWalletDeliveries {
+		delivered = delivered[len(delivered)-maxWalletDeliveries:]
+	}
+	s.cache.Set(sessionWalletKeyPrefix+sessionID.String(), delivered, cache.DefaultExpiration)
+	return nil
+}
+
+func (s *Server) walletDeliveries(sessionID uuid.UUID) (walletDeliveries, bool) {
+	item, ok := s.cache.Get(sessionWalletKeyPrefix + sessionID.String())
+	if !ok {
+		return nil, false
+	}
+	return item.(walletDeliveries), true
+}
+
+// walletRequest returns the request of a session as it was delivered to the wallet answering with token: the delivery
+// of the thread of the token, or the last delivery when the token cannot be read before its verification
+func (s *Server) walletRequest(sessionID uuid.UUID, request protocol.AuthorizationRequestMessage, token string) protocol.AuthorizationRequestMessage {
+	delivered, ok := s.walletDeliveries(sessionID)
+	if !ok {
+		return request
+	}
+	delivery := delivered[len(delivered)-1]
+	if threadID := responseThreadID(token); threadID != "" {
+		for _, d := range delivered {
+			if d.ThreadID == threadID {
+				delivery = d
+			}
+		}
+	}
+	request.To = delivery.WalletDID
+	request.ThreadID = delivery.ThreadID
+	return request
+}
+
+// responseThreadID returns the thread id of an authorization response token without verifying it
+func responseThreadID(token string) string {
+	t, err := jwz.Parse(token)
+	if err != nil {
+		return ""
+	}
+	var msg protocol.AuthorizationResponseMessage
+	if err := json.Unmarshal(t.GetPayload(), &msg); err != nil {
+		return ""
+	}
+	return msg.ThreadID
+}
+
+// checkWalletBinding checks that the verified response of a session delivered to wallets answers the request delivered
+// to the wallet it comes from, which binds the session to that wallet
+func (s *Server) checkWalletBinding(sessionID uuid.UUID, response protocol.AuthorizationResponseMessage) error {
+	delivered, ok := s.walletDeliveries(sessionID)
+	if !ok {
+		return nil
+	}
+	var fromWallet bool
+	for _, delivery := range delivered {
+		if delivery.WalletDID != response.From {
+			continue
+		}
+		if delivery.ThreadID == response.ThreadID {
+			return nil
+		}
+		fromWallet = true
+	}
+	if !fromWallet {
+		return i18n.New(i18n.CodeSessionBoundToWallet, sessionID)
+	}
+	return i18n.New(i18n.CodeWalletThreadMismatch, sessionID)
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-circuits/v2"
+	auth "github.com/iden3/go-iden3-auth/v2"
+	"github.com/iden3/go-iden3-auth/v2/loaders"
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/e2etest"
+)
+
+// TestEndToEnd drives sign-in, the qr-store, the callback of a wallet with real proofs and the status. It needs the
+// circuits of the iden3 circuits release, see make tests/e2e.
+func TestEndToEnd(t *testing.T) {
+	prover, err := e2etest.ProverFromEnv()
+	if errors.Is(err, e2etest.ErrNoCircuits) {
+		t.Skip(err)
+	}
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	resolver := e2etest.NewStateResolver()
+	wallet, err := e2etest.NewWallet(ctx, prover, resolver)
+	require.NoError(t, err)
+	verifier, err := auth.NewVerifier(&loaders.FSKeyLoader{Dir: "../../keys"},
+		map[string]pubsignals.StateResolver{"polygon:amoy": resolver}, auth.WithDocumentLoader(e2etest.DocumentLoader{}))
+	require.NoError(t, err)
+	server := New(cfg, verifier, map[string]string{amoyNetwork: amoySenderDID})
+
+	// signIn creates a session of the scope and returns it with the request that a wallet reads from the qr-store
+	signIn := func(t *testing.T, scope ScopeRequest) (uuid.UUID, protocol.AuthorizationRequestMessage) {
+		resp, err := server.SignIn(ctx, SignInRequestObject{
+			Body: &SignInJSONRequestBody{ChainID: common.ToPointer(amoyNetwork), Scope: []ScopeRequest{scope}},
+		})
+		require.NoError(t, err)
+		signIn := resp.(SignIn200JSONResponse)
+
+		qrResp, err := server.GetQRCodeFromStore(ctx, GetQRCodeFromStoreRequestObject{
+			Params: GetQRCodeFromStoreParams{Id: isValidaQrStoreCallback(t, signIn.QrCode)},
+		})
+		require.NoError(t, err)
+		body, err := json.Marshal(qrResp.(GetQRCodeFromStore200JSONResponse).Body)
+		require.NoError(t, err)
+		var request protocol.AuthorizationRequestMessage
+		require.NoError(t, json.Unmarshal(body, &request))
+		require.True(t, isValidCallBack(t, request.Body.CallbackURL))
+		return signIn.SessionID, request
+	}
+	callback := func(t *testing.T, sessionID uuid.UUID, token string) CallbackResponseObject {
+		resp, err := server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: sessionID}, Body: &token})
+		require.NoError(t, err)
+		return resp
+	}
+	status := func(t *testing.T, sessionID uuid.UUID) Status200JSONResponse {
+		resp, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+		require.NoError(t, err)
+		return resp.(Status200JSONResponse)
+	}
+
+	tests := []struct {
+		name  string
+		scope ScopeRequest
+	}{
+		{
+			name: "sigV2",
+			scope: ScopeRequest{
+				Id:        1,
+				CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+				Query: jsonToMap(t, `{
+					"context": "`+e2etest.KYCContextURL+`",
+					"allowedIssuers": ["*"],
+					"type": "KYCAgeCredential",
+					"credentialSubject": {"birthday": {"$lt": 20000101}}
+				}`),
+			},
+		},
+		{
+			name: "v3 signature",
+			scope: ScopeRequest{
+				Id:        2,
+				CircuitId: string(circuits.AtomicQueryV3CircuitID),
+				Query: jsonToMap(t, `{
+					"context": "`+e2etest.KYCContextURL+`",
+					"allowedIssuers": ["*"],
+					"type": "KYCAgeCredential",
+					"credentialSubject": {"documentType": {"$eq": 99}}
+				}`),
+			},
+		},
+		{
+			name: "v3 merkle tree proof",
+			scope: ScopeRequest{
+				Id:        3,
+				CircuitId: string(circuits.AtomicQueryV3CircuitID),
+				Query: jsonToMap(t, `{
+					"context": "`+e2etest.KYCContextURL+`",
+					"allowedIssuers": ["*"],
+					"type": "KYCAgeCredential",
+					"proofType": "Iden3SparseMerkleTreeProof",
+					"credentialSubject": {"birthday": {"$in": [19960424, 20010101]}}
+				}`),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sessionID, request := signIn(t, tc.scope)
+			assert.Equal(t, statusPending, status(t, sessionID).Status)
+
+			token, err := wallet.Respond(ctx, request)
+			require.NoError(t, err)
+			require.Equal(t, Callback200JSONResponse{}, callback(t, sessionID, token))
+
+			result := status(t, sessionID)
+			require.Equal(t, statusSuccess, result.Status, common.FromPointer(result.Message))
+			assert.Equal(t, token, *result.Jwz)
+			assert.Equal(t, wallet.User.DID.String(), result.JwzMetadata.UserDID)
+		})
+	}
+
+	t.Run("issuer not allowed", func(t *testing.T) {
+		sessionID, request := signIn(t, ScopeRequest{
+			Id:        4,
+			CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+			Query: jsonToMap(t, `{
+				"context": "`+e2etest.KYCContextURL+`",
+				"allowedIssuers": ["did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK"],
+				"type": "KYCAgeCredential",
+				"credentialSubject": {"birthday": {"$lt": 20000101}}
+			}`),
+		})
+		token, err := wallet.Respond(ctx, request)
+		require.NoError(t, err)
+		assert.IsType(t, Callback500JSONResponse{}, callback(t, sessionID, token))
+		assert.Equal(t, statusError, status(t, sessionID).Status)
+	})
+}
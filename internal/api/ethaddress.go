@@ -0,0 +1,51 @@
+package api
+
+import (
+	common2 "github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/patrickmn/go-cache"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+const ethAddressRequiredKeyPrefix = "eth-address-required-"
+
+// genesisEthAddress returns the Ethereum address the identity of did was created from, the genesis state of the
+// identities created from an Ethereum address being the address itself. It is derived from the DID alone: no signature
+// of the address is checked, so it is not a proof that the user controls the address.
+func genesisEthAddress(did string) (string, bool) {
+	parsed, err := w3c.ParseDID(did)
+	if err != nil {
+		return "", false
+	}
+	id, err := core.IDFromDID(*parsed)
+	if err != nil {
+		return "", false
+	}
+	address, err := core.EthAddressFromID(id)
+	if err != nil {
+		return "", false
+	}
+	return common2.BytesToAddress(address[:]).Hex(), true
+}
+
+func (s *Server) setEthAddressRequired(sessionID uuid.UUID, require *bool) {
+	if require == nil || !*require {
+		return
+	}
+	s.cache.Set(ethAddressRequiredKeyPrefix+sessionID.String(), true, cache.DefaultExpiration)
+}
+
+// checkGenesisEthAddress rejects the users whose identity was not created from an Ethereum address in the sessions that
+// require one
+func (s *Server) checkGenesisEthAddress(sessionID uuid.UUID, userDID string) error {
+	if _, required := s.cache.Get(ethAddressRequiredKeyPrefix + sessionID.String()); !required {
+		return nil
+	}
+	if _, ok := genesisEthAddress(userDID); !ok {
+		return i18n.New(i18n.CodeEthAddressRequired, userDID)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"github.com/google/uuid"
+	"github.com/iden3/iden3comm/v2/protocol"
+
+	verrors "github.com/0xPolygonID/verifier-backend/internal/errors"
+	"github.com/0xPolygonID/verifier-backend/internal/events"
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+)
+
+// WithEvents publishes the lifecycle events of the sessions to the bus
+func WithEvents(b *events.Bus) Option {
+	return func(s *Server) {
+		s.events = b
+	}
+}
+
+// emitSessionCreated publishes the creation of the session with the circuits of its scopes
+func (s *Server) emitSessionCreated(sessionID uuid.UUID, scopes []ScopeRequest) {
+	if s.events == nil {
+		return
+	}
+	circuitIDs := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		circuitIDs = append(circuitIDs, scope.CircuitId)
+	}
+	s.events.Emit(events.Event{
+		Type:       events.TypeSessionCreated,
+		SessionID:  sessionID.String(),
+		Tenant:     s.getSessionTenant(sessionID),
+		CircuitIDs: circuitIDs,
+	})
+}
+
+// emitVerification publishes the result of the callback of the session, read from the session cache
+func (s *Server) emitVerification(sessionID uuid.UUID, scopes []protocol.ZeroKnowledgeProofRequest, verified bool) {
+	if s.events == nil {
+		return
+	}
+	event := events.Event{
+		Type:       events.TypeVerificationSucceeded,
+		SessionID:  sessionID.String(),
+		Tenant:     s.getSessionTenant(sessionID),
+		CircuitIDs: make([]string, 0, len(scopes)),
+	}
+	for _, scope := range scopes {
+		event.CircuitIDs = append(event.CircuitIDs, scope.CircuitID)
+	}
+	item, _ := s.cache.Get(sessionID.String())
+	if verified {
+		if verification, ok := item.(models.VerificationResponse); ok {
+			event.UserDID = verification.UserDID
+		}
+	} else {
+		event.Type = events.TypeVerificationFailed
+		event.ErrorCode = string(verrors.CodeVerificationFailed)
+		if err, ok := item.(error); ok {
+			event.ErrorCode, event.Error = string(verrors.Classify(err)), err.Error()
+		}
+	}
+	s.events.Emit(event)
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/iden3/iden3comm/v2/protocol"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+const (
+	minDateInt = 19000101
+	maxDateInt = 99991231
+)
+
+// checkCredentialExpiration rejects the proofs of credentials that may have expired since the proofs were generated,
+// and the proofs disclosing an expiration date in the past. It does not depend on the conditions of the query.
+func (s *Server) checkCredentialExpiration(response protocol.AuthorizationResponseMessage, now time.Time) error {
+	if !s.cfg.Expiration.Enabled {
+		return nil
+	}
+
+	for _, scope := range response.Body.Scope {
+		output, err := getProofOutput(scope)
+		if err != nil {
+			return err
+		}
+		if timestamp, ok := output["timestamp"].(int64); ok {
+			generatedAt := time.Unix(timestamp, 0).UTC()
+			if now.Sub(generatedAt) > s.cfg.Expiration.MaxProofAge.AsDuration() {
+				return i18n.New(i18n.CodeProofOutdated, scope.ID, generatedAt.Format(time.RFC3339))
+			}
+		}
+
+		expiration, ok := s.disclosedExpiration(scope)
+		if ok && expiration.Before(now) {
+			return i18n.New(i18n.CodeCredentialExpired, scope.ID, expiration.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// disclosedExpiration returns the earliest expiration field disclosed in the verifiable presentation of the scope
+func (s *Server) disclosedExpiration(scope protocol.ZeroKnowledgeProofResponse) (time.Time, bool) {
+	if len(scope.VerifiablePresentation) == 0 {
+		return time.Time{}, false
+	}
+	var vp struct {
+		VerifiableCredential struct {
+			CredentialSubject map[string]any `json:"credentialSubject"`
+		} `json:"verifiableCredential"`
+	}
+	if err := json.Unmarshal(scope.VerifiablePresentation, &vp); err != nil {
+		return time.Time{}, false
+	}
+
+	var (
+		earliest time.Time
+		found    bool
+	)
+	for _, field := range s.cfg.Expiration.Fields {
+		expiration, ok := parseExpiration(vp.VerifiableCredential.CredentialSubject[field])
+		if ok && (!found || expiration.Before(earliest)) {
+			earliest, found = expiration, true
+		}
+	}
+	return earliest, found
+}
+
+// parseExpiration parses xsd:dateTime and xsd:date strings, integer dates formatted as yyyymmdd and unix timestamps.
+// Credentials expiring on a date are valid until the end of that day.
+func parseExpiration(value any) (time.Time, bool) {
+	switch v := value.(type) {
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, true
+		}
+		if t, err := time.Parse(time.DateOnly, v); err == nil {
+			return t.AddDate(0, 0, 1), true
+		}
+	case float64:
+		n := int64(v)
+		if n >= minDateInt && n <= maxDateInt {
+			return time.Date(int(n/10000), time.Month(n/100%100), int(n%100)+1, 0, 0, 0, 0, time.UTC), true
+		}
+		return time.Unix(n, 0).UTC(), true
+	}
+	return time.Time{}, false
+}
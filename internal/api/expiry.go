@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/sessions"
+	"github.com/0xPolygonID/verifier-backend/internal/webhook"
+)
+
+const (
+	statusExpired   = "expired"
+	statusAbandoned = "abandoned"
+
+	pendingQRCodeKeyPrefix  = "pending-qr-code-"
+	requestExpiresKeyPrefix = "request-expires-"
+	maxExpiryDelay          = time.Minute
+)
+
+// expiredSession replaces an off-chain session that expired without a callback
+type expiredSession struct {
+	ExpiredAt time.Time
+	// Abandoned is set when the QR code of the session was fetched by a wallet
+	Abandoned bool
+}
+
+func (e expiredSession) status() string {
+	if e.Abandoned {
+		return statusAbandoned
+	}
+	return statusExpired
+}
+
+// pendingSession tracks an off-chain session until its callback or its expiration
+type pendingSession struct {
+	scanned  atomic.Bool
+	answered atomic.Bool
+}
+
+// newPendingSessions creates the cache of the pending sessions, that expires the sessions without a callback after ttl.
+// Expired sessions are checked every minute at most, so they are reported late by up to a minute.
+func (s *Server) newPendingSessions(ttl time.Duration) *cache.Cache {
+	pending := cache.New(ttl, min(ttl, maxExpiryDelay))
+	pending.OnEvicted(func(key string, value any) {
+		session, ok := value.(*pendingSession)
+		if !ok || session.answered.Load() {
+			return
+		}
+		sessionID, err := uuid.Parse(key)
+		if err != nil {
+			return
+		}
+		s.expireSession(sessionID, session.scanned.Load())
+	})
+	return pending
+}
+
+// trackSession starts the expiration of an off-chain session, after ttl
+func (s *Server) trackSession(sessionID uuid.UUID, qrToken string, ttl time.Duration) {
+	if s.pending == nil {
+		return
+	}
+	s.pending.Set(sessionID.String(), &pendingSession{}, ttl)
+	s.pending.Set(pendingQRCodeKeyPrefix+qrToken, sessionID.String(), ttl)
+}
+
+// scanSession records that the QR code of a session was fetched by a wallet
+func (s *Server) scanSession(qrToken string) {
+	if session, ok := s.pendingSession(qrToken); ok {
+		session.scanned.Store(true)
+	}
+}
+
+// answerSession stops the expiration of a session when its callback is received
+func (s *Server) answerSession(sessionID uuid.UUID) {
+	if s.pending == nil {
+		return
+	}
+	if item, ok := s.pending.Get(sessionID.String()); ok {
+		item.(*pendingSession).answered.Store(true)
+	}
+}
+
+func (s *Server) pendingSession(qrToken string) (*pendingSession, bool) {
+	if s.pending == nil {
+		return nil, false
+	}
+	sessionID, ok := s.pending.Get(pendingQRCodeKeyPrefix + qrToken)
+	if !ok {
+		return nil, false
+	}
+	item, ok := s.pending.Get(sessionID.(string))
+	if !ok {
+		return nil, false
+	}
+	return item.(*pendingSession), true
+}
+
+// expireSession replaces a session still waiting for its callback with an expiredSession,
+// so its status reports the expiration until the session is removed from the cache
+func (s *Server) expireSession(sessionID uuid.UUID, abandoned bool) {
+	s.resultsMu.Lock()
+	item, ok := s.cache.Get(sessionID.String())
+	if _, pending := item.(protocol.AuthorizationRequestMessage); !ok || !pending {
+		s.resultsMu.Unlock()
+		return
+	}
+	expired := expiredSession{ExpiredAt: time.Now().UTC(), Abandoned: abandoned}
+	s.cache.Set(sessionID.String(), expired, cache.DefaultExpiration)
+	s.resultsMu.Unlock()
+
+	s.tags.expire(sessionID, abandoned)
+	s.logger.WithFields(log.Fields{"sessionID": sessionID, "status": expired.status()}).Info("session expired without a callback")
+	s.publishStatus(sessionID)
+	s.notifyExpiry(sessionID, expired)
+}
+
+// notifyExpiry sends the expiration of a session to the webhook of the integrator, in the background so the
+// eviction of the other sessions is not delayed
+func (s *Server) notifyExpiry(sessionID uuid.UUID, expired expiredSession) {
+	sender := s.sessionWebhook(sessionID)
+	if sender == nil {
+		return
+	}
+	event := webhook.Event{
+		Type:      webhook.EventSessionExpired,
+		SessionID: sessionID.String(),
+		Status:    expired.status(),
+		Time:      expired.ExpiredAt,
+		Metadata:  s.sessionMetadata(context.Background(), sessionID),
+	}
+	if expired.Abandoned {
+		event.Type = webhook.EventSessionAbandoned
+	}
+	s.webhooks.Deliver(sender, s.sessionWebhookName(sessionID), event)
+}
+
+// recordSession stores the lifetime and the metadata of a new session in the ledger and returns when it expires, after ttl
+func (s *Server) recordSession(ctx context.Context, sessionID uuid.UUID, ttl time.Duration, metadata *SessionMetadata) time.Time {
+	now := time.Now().UTC()
+	record := sessions.Record{CreatedAt: now, ExpiresAt: now.Add(ttl)}
+	if metadata != nil {
+		record.Metadata = *metadata
+	}
+	if err := s.ledger.Add(ctx, sessionID.String(), record); err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to record the session")
+	}
+	return record.ExpiresAt
+}
+
+// sessionRecord returns the lifetime of a session, kept by the ledger after the session is removed from the cache
+func (s *Server) sessionRecord(ctx context.Context, sessionID uuid.UUID) (sessions.Record, bool) {
+	record, ok, err := s.ledger.Get(ctx, sessionID.String())
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to read the session record")
+		return sessions.Record{}, false
+	}
+	return record, ok
+}
+
+func (s *Server) sessionExpiresAt(ctx context.Context, sessionID uuid.UUID) *time.Time {
+	record, ok := s.sessionRecord(ctx, sessionID)
+	if !ok {
+		return nil
+	}
+	return &record.ExpiresAt
+}
+
+// requestValidity returns when the request of a session that lives for ttl is created and when it expires, after the
+// request validity when it is shorter than ttl. Requests of sessions that never expire have no expiration.
+func (s *Server) requestValidity(ttl time.Duration) (time.Time, time.Time) {
+	if validity := s.cfg.RequestValidity.AsDuration(); validity > 0 && (ttl <= 0 || validity < ttl) {
+		ttl = validity
+	}
+	created := time.Now().UTC()
+	if ttl <= 0 {
+		return created, time.Time{}
+	}
+	return created, created.Add(ttl)
+}
+
+// requestExpiresAt returns when the request of an off-chain session expires
+func (s *Server) requestExpiresAt(sessionID uuid.UUID) (time.Time, bool) {
+	item, ok := s.cache.Get(requestExpiresKeyPrefix + sessionID.String())
+	if !ok {
+		return time.Time{}, false
+	}
+	return item.(time.Time), true
+}
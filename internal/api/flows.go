@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/logging"
+	"github.com/0xPolygonID/verifier-backend/internal/webhook"
+)
+
+const sessionFlowKeyPrefix = "session-flow-"
+
+// SignInFlow - create a session from a verification flow
+func (s *Server) SignInFlow(ctx context.Context, request SignInFlowRequestObject) (SignInFlowResponseObject, error) {
+	flow, ok := s.flows[request.Name]
+	if !ok {
+		return SignInFlow404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeFlowNotFound, request.Name)}}, nil
+	}
+	ctx = logging.WithEntry(ctx, s.log(ctx).WithFields(log.Fields{"flow": flow.Name, "flowVersion": flow.Version}))
+
+	body, err := flowSignInRequest(flow, request.Body)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Error("invalid flow")
+		return SignInFlow500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	resp, err := s.signIn(ctx, SignInRequestObject{Params: SignInParams{XAPIKey: request.Params.XAPIKey}, Body: body}, &flow)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r := resp.(type) {
+	case SignIn200JSONResponse:
+		return SignInFlow200JSONResponse(r), nil
+	case SignIn400JSONResponse:
+		return SignInFlow400JSONResponse(r), nil
+	case SignIn401JSONResponse:
+		return SignInFlow401JSONResponse(r), nil
+	case SignIn403JSONResponse:
+		return SignInFlow403JSONResponse(r), nil
+	case SignIn500JSONResponse:
+		return SignInFlow500JSONResponse(r), nil
+	default:
+		return SignInFlow500JSONResponse{N500JSONResponse{Message: "unexpected sign-in response"}}, nil
+	}
+}
+
+// flowSignInRequest returns the sign-in request of a flow. The request of the frontend only sets the chain, when the
+// flow does not set one, and the fields describing the user.
+func flowSignInRequest(flow config.Flow, request *SignInFlowRequest) (*SignInRequest, error) {
+	if request == nil {
+		request = &SignInFlowRequest{}
+	}
+	body := &SignInRequest{
+		ChainID:  request.ChainID,
+		To:       request.To,
+		Tags:     request.Tags,
+		Metadata: request.Metadata,
+		Scope:    make([]ScopeRequest, 0, len(flow.Scope)),
+	}
+	if flow.ChainID != "" {
+		body.ChainID = common.ToPointer(flow.ChainID)
+	}
+	if flow.Reason != "" {
+		body.Reason = common.ToPointer(flow.Reason)
+	}
+	if flow.RequiredScopes > 0 {
+		body.RequiredScopes = common.ToPointer(flow.RequiredScopes)
+	}
+	if flow.EnforceUniqueNullifier {
+		body.EnforceUniqueNullifier = common.ToPointer(true)
+	}
+	if flow.TrustProfile != "" {
+		body.TrustProfile = common.ToPointer(flow.TrustProfile)
+	}
+
+	for _, flowScope := range flow.Scope {
+		scope := ScopeRequest{Id: flowScope.ID, CircuitId: flowScope.CircuitID}
+		if flowScope.Template != "" {
+			scope.Template = common.ToPointer(flowScope.Template)
+		}
+		// the queries are copied through json, so every session gets its own query with the numbers of a json request
+		if len(flowScope.Query) > 0 {
+			if err := jsonCopy(flowScope.Query, &scope.Query); err != nil {
+				return nil, fmt.Errorf("invalid query of scope %d of flow %s: %w", flowScope.ID, flow.Name, err)
+			}
+		}
+		if len(flowScope.Params) > 0 {
+			params := ScopeParams{}
+			if err := jsonCopy(flowScope.Params, &params); err != nil {
+				return nil, fmt.Errorf("invalid params of scope %d of flow %s: %w", flowScope.ID, flow.Name, err)
+			}
+			scope.Params = &params
+		}
+		body.Scope = append(body.Scope, scope)
+	}
+	return body, nil
+}
+
+func jsonCopy(in, out any) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+// flowTTL returns how long the off-chain sessions of a flow wait for their callback, ttl when the flow does not set it
+func flowTTL(flow *config.Flow, ttl time.Duration) time.Duration {
+	if flow != nil && flow.TTL > 0 {
+		return flow.TTL
+	}
+	return ttl
+}
+
+// setSessionFlow stores the flow of the session, whose webhook receives the events of the session
+func (s *Server) setSessionFlow(sessionID uuid.UUID, flow *config.Flow) {
+	if flow == nil {
+		return
+	}
+	s.cache.Set(sessionFlowKeyPrefix+sessionID.String(), flow.Name, cache.DefaultExpiration)
+}
+
+// sessionWebhook returns the webhook of the flow of the session, or the session webhook
+func (s *Server) sessionWebhook(sessionID uuid.UUID) *webhook.Sender {
+	return s.webhookSender(s.sessionWebhookName(sessionID))
+}
+
+// sessionWebhookName returns the name of the webhook of the session, the name of its flow when the flow has a webhook
+// and empty for the session webhook
+func (s *Server) sessionWebhookName(sessionID uuid.UUID) string {
+	if name, ok := s.cache.Get(sessionFlowKeyPrefix + sessionID.String()); ok {
+		if _, ok := s.flowWebhooks[name.(string)]; ok {
+			return name.(string)
+		}
+	}
+	return ""
+}
+
+// webhookSender returns the webhook of the flow name, the session webhook when name is empty
+func (s *Server) webhookSender(name string) *webhook.Sender {
+	if name == "" {
+		return s.webhook
+	}
+	return s.flowWebhooks[name]
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+type forwardedURLKey struct{}
+
+// ForwardedURL stores in the context of the request the URL the client reached the verifier at, derived from the
+// Forwarded or X-Forwarded-Proto, X-Forwarded-Host and X-Forwarded-Prefix headers, so the links are built on the
+// address of the ingress. The headers are only used when the request comes from one of the trusted proxies.
+func ForwardedURL(trusted []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !trustedProxy(trusted, r.RemoteAddr) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if u := requestForwardedURL(r); u != "" {
+				r = r.WithContext(context.WithValue(r.Context(), forwardedURLKey{}, u))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func forwardedURL(ctx context.Context) string {
+	u, _ := ctx.Value(forwardedURLKey{}).(string)
+	return u
+}
+
+func trustedProxy(trusted []*net.IPNet, remoteAddr string) bool {
+	addr, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		addr = remoteAddr
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range trusted {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestForwardedURL returns the URL set by the forwarding headers of the request, the Forwarded header first, or ""
+// when the request has none of them. The scheme and the host the proxy does not forward are the ones of the request.
+func requestForwardedURL(r *http.Request) string {
+	proto, host := forwardedProtoHost(r.Header.Get("Forwarded"))
+	if proto == "" {
+		proto = firstHeaderValue(r.Header.Get("X-Forwarded-Proto"))
+	}
+	if host == "" {
+		host = firstHeaderValue(r.Header.Get("X-Forwarded-Host"))
+	}
+	prefix := strings.Trim(firstHeaderValue(r.Header.Get("X-Forwarded-Prefix")), "/")
+	if proto == "" && host == "" && prefix == "" {
+		return ""
+	}
+
+	if proto == "" {
+		proto = "http"
+		if r.TLS != nil {
+			proto = "https"
+		}
+	}
+	if host == "" {
+		host = r.Host
+	}
+	u := strings.ToLower(proto) + "://" + host
+	if prefix != "" {
+		u += "/" + prefix
+	}
+	if err := config.ValidatePublicURL(u); err != nil {
+		return ""
+	}
+	return u
+}
+
+// forwardedProtoHost returns the proto and host parameters of the first element of a Forwarded header (RFC 7239),
+// the one set by the proxy the client reached
+func forwardedProtoHost(header string) (proto, host string) {
+	first, _, _ := strings.Cut(header, ",")
+	for _, pair := range strings.Split(first, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		switch strings.ToLower(name) {
+		case "proto":
+			proto = value
+		case "host":
+			host = value
+		}
+	}
+	return proto, host
+}
+
+func firstHeaderValue(header string) string {
+	first, _, _ := strings.Cut(header, ",")
+	return strings.TrimSpace(first)
+}
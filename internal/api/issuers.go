@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/iden3/go-circuits/v2"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/iden3comm/v2/protocol"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/policy"
+)
+
+// GetIssuerPolicy - get the trusted issuers per credential type
+func (s *Server) GetIssuerPolicy(ctx context.Context, request GetIssuerPolicyRequestObject) (GetIssuerPolicyResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return GetIssuerPolicy401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	return GetIssuerPolicy200JSONResponse(s.getIssuerPolicy()), nil
+}
+
+// SetIssuerPolicy - set the trusted issuers of a credential type
+func (s *Server) SetIssuerPolicy(ctx context.Context, request SetIssuerPolicyRequestObject) (SetIssuerPolicyResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleAdmin) {
+		return SetIssuerPolicy401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+
+	if err := s.issuerPolicy.Set(request.CredentialType, request.Body.Issuers); err != nil {
+		return SetIssuerPolicy400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+	s.log(ctx).WithFields(log.Fields{
+		"credentialType": request.CredentialType,
+		"issuers":        request.Body.Issuers,
+	}).Info("issuer policy updated")
+
+	return SetIssuerPolicy200JSONResponse(s.getIssuerPolicy()), nil
+}
+
+// DeleteIssuerPolicy - remove the trusted issuers of a credential type
+func (s *Server) DeleteIssuerPolicy(ctx context.Context, request DeleteIssuerPolicyRequestObject) (DeleteIssuerPolicyResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleAdmin) {
+		return DeleteIssuerPolicy401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+
+	s.issuerPolicy.Delete(request.CredentialType)
+	s.log(ctx).WithFields(log.Fields{"credentialType": request.CredentialType}).Info("issuer policy removed")
+
+	return DeleteIssuerPolicy200JSONResponse(s.getIssuerPolicy()), nil
+}
+
+func (s *Server) getIssuerPolicy() IssuerPolicy {
+	return IssuerPolicy{
+		Mode:    string(s.issuerPolicy.Mode()),
+		Issuers: s.issuerPolicy.List(),
+	}
+}
+
+// isAdmin checks the api key against the configured admin keys. Admin endpoints are disabled when no key is configured.
+func (s *Server) isAdmin(apiKey *string) bool {
+	return apiKey != nil && *apiKey != "" && containsKey(s.cfg.AdminAPIKeys, *apiKey)
+}
+
+// applyIssuerPolicy returns a copy of the query with the allowedIssuers allowed by the issuer policy
+func (s *Server) applyIssuerPolicy(query map[string]interface{}) (map[string]interface{}, error) {
+	credentialType, _ := query["type"].(string)
+	requested, err := toStringSlice(query["allowedIssuers"])
+	if err != nil {
+		return nil, fmt.Errorf("field allowedIssuers is not valid: %w", err)
+	}
+
+	allowed, err := s.issuerPolicy.AllowedIssuers(credentialType, requested)
+	if err != nil {
+		return nil, err
+	}
+	if equalStrings(allowed, requested) {
+		return query, nil
+	}
+
+	out := make(map[string]interface{}, len(query))
+	for k, v := range query {
+		out[k] = v
+	}
+	out["allowedIssuers"] = allowed
+	return out, nil
+}
+
+// checkIssuerPolicy checks that the issuers used in the proofs are trusted for the requested credential types
+func (s *Server) checkIssuerPolicy(request protocol.AuthorizationRequestMessage, response protocol.AuthorizationResponseMessage) error {
+	credentialTypes := make(map[uint32]string, len(request.Body.Scope))
+	for _, scope := range request.Body.Scope {
+		credentialTypes[scope.ID], _ = scope.Query["type"].(string)
+	}
+
+	for _, scope := range response.Body.Scope {
+		issuer, err := getProofIssuer(scope)
+		if err != nil {
+			return err
+		}
+		if !s.issuerPolicy.IsAllowed(credentialTypes[scope.ID], issuer) {
+			return i18n.Wrap(policy.ErrIssuerNotAllowed, i18n.CodeIssuerNotAllowed, issuer, credentialTypes[scope.ID])
+		}
+	}
+	return nil
+}
+
+func getProofIssuer(scope protocol.ZeroKnowledgeProofResponse) (string, error) {
+	output, err := getProofOutput(scope)
+	if err != nil {
+		return "", err
+	}
+
+	issuerID, ok := output["issuerID"].(*core.ID)
+	if !ok || issuerID == nil {
+		return "", fmt.Errorf("issuerID not found in pub signals of scope %d", scope.ID)
+	}
+
+	did, err := core.ParseDIDFromID(*issuerID)
+	if err != nil {
+		return "", err
+	}
+	return did.String(), nil
+}
+
+// getProofOutput returns the pub signals of the proof by name
+func getProofOutput(scope protocol.ZeroKnowledgeProofResponse) (map[string]interface{}, error) {
+	signals, err := json.Marshal(scope.PubSignals)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := circuits.UnmarshalCircuitOutput(circuits.CircuitID(scope.CircuitID), signals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pub signals of scope %d: %w", scope.ID, err)
+	}
+	return output, nil
+}
+
+func toStringSlice(v interface{}) ([]string, error) {
+	switch values := v.(type) {
+	case []string:
+		return values, nil
+	case []interface{}:
+		out := make([]string, 0, len(values))
+		for _, value := range values {
+			str, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%v is not a string", value)
+			}
+			out = append(out, str)
+		}
+		return out, nil
+	default:
+		return nil, errors.New("it must be an array of strings")
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+const (
+	mediaTypeJSON = "application/json"
+	mediaTypeText = "text/plain"
+)
+
+// BodyLimits rejects the request bodies larger than maxSize bytes with a 413, and the bodies whose content type is
+// not the one of their endpoint with a 415: the callbacks take a text/plain token and the other endpoints take JSON.
+// Bodies sent without a Content-Length are cut at maxSize.
+func BodyLimits(maxSize int64) MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if maxSize > 0 {
+				if r.ContentLength > maxSize {
+					writeError(w, http.StatusRequestEntityTooLarge, i18n.Message(r.Context(), i18n.CodeBodyTooLarge, maxSize))
+					return
+				}
+				r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+			}
+
+			expected := mediaTypeJSON
+			if r.URL.Path == config.CallbackURL {
+				expected = mediaTypeText
+			}
+			contentType := r.Header.Get("Content-Type")
+			if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != expected {
+				writeError(w, http.StatusUnsupportedMediaType, i18n.Message(r.Context(), i18n.CodeContentTypeUnsupported, contentType, expected))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", mediaTypeJSON)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(GenericErrorMessage{Message: message})
+}
+
+// checkToken rejects the callback tokens that are too large, that are not made of three base64url segments, or that
+// carry more proofs than allowed, before their proofs are verified
+func checkToken(token string, limits config.Limits) error {
+	if limits.MaxTokenSize > 0 && len(token) > limits.MaxTokenSize {
+		return i18n.New(i18n.CodeTokenTooLarge, limits.MaxTokenSize)
+	}
+	segments := strings.Split(strings.TrimSpace(token), ".")
+	if len(segments) != 3 {
+		return i18n.New(i18n.CodeTokenMalformed, "it must have 3 segments")
+	}
+	decoded := make([][]byte, 0, len(segments))
+	for _, segment := range segments {
+		b, err := base64.RawURLEncoding.DecodeString(segment)
+		if err != nil {
+			return i18n.New(i18n.CodeTokenMalformed, "its segments must be base64url encoded")
+		}
+		decoded = append(decoded, b)
+	}
+
+	var header map[string]interface{}
+	if err := json.Unmarshal(decoded[0], &header); err != nil {
+		return i18n.New(i18n.CodeTokenMalformed, "its header must be a JSON object")
+	}
+	// the JWZ library does not check the types of the headers it reads
+	if _, ok := header["alg"].(string); !ok {
+		return i18n.New(i18n.CodeTokenMalformed, "its header must have an alg")
+	}
+	if _, ok := header["circuitId"].(string); !ok {
+		return i18n.New(i18n.CodeTokenMalformed, "its header must have a circuitId")
+	}
+	critical, ok := header["crit"].([]interface{})
+	if !ok {
+		return i18n.New(i18n.CodeTokenMalformed, "its header must list its critical headers")
+	}
+	for _, key := range critical {
+		if name, ok := key.(string); !ok || header[name] == nil {
+			return i18n.New(i18n.CodeTokenMalformed, "its critical headers must be present")
+		}
+	}
+	var payload struct {
+		Body struct {
+			Scope []json.RawMessage `json:"scope"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(decoded[1], &payload); err != nil {
+		return i18n.New(i18n.CodeTokenMalformed, "its payload must be an iden3comm message")
+	}
+	if limits.MaxScopes > 0 && len(payload.Body.Scope) > limits.MaxScopes {
+		return i18n.New(i18n.CodeTokenTooManyScopes, len(payload.Body.Scope), limits.MaxScopes)
+	}
+	return nil
+}
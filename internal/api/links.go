@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+type urlShortener interface {
+	Shorten(ctx context.Context, link string) (string, error)
+}
+
+func newSignInLinkRates(cfg config.SignInLink, c qrCache) *rateLimiter {
+	return newRateLimiter(c, "sign-in-link-rate-", cfg.RateLimit, cfg.RateWindow.AsDuration())
+}
+
+// SignInLink - create a session for a query template and redirect to the wallet
+func (s *Server) SignInLink(ctx context.Context, request SignInLinkRequestObject) (SignInLinkResponseObject, error) {
+	if addr := clientAddr(ctx); addr != "" && !s.linkRates.Allow(addr) {
+		s.log(ctx).WithFields(log.Fields{"addr": addr}).Warn("sign-in links rate limited")
+		return SignInLink429JSONResponse{N429JSONResponse{Message: i18n.Message(ctx, i18n.CodeSignInLinkRateLimited)}}, nil
+	}
+
+	apiKey := request.Params.XAPIKey
+	if request.Params.Signature != nil {
+		key, err := s.signInLinkKey(request.Params, time.Now())
+		if err != nil {
+			s.log(ctx).WithFields(log.Fields{"templateId": request.Params.TemplateId, "err": err}).Warn("sign-in link rejected")
+			return SignInLink401JSONResponse{N401JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+		}
+		apiKey = &key
+	}
+
+	resp, err := s.SignIn(ctx, SignInRequestObject{
+		Params: SignInParams{XAPIKey: apiKey},
+		Body: &SignInRequest{
+			ChainID: common.ToPointer(request.Params.ChainId),
+			Scope:   []ScopeRequest{{Id: 1, Template: common.ToPointer(request.Params.TemplateId)}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch r := resp.(type) {
+	case SignIn200JSONResponse:
+		location := r.QrCode
+		if request.Params.LinkType != nil && *request.Params.LinkType == Universal {
+			location = s.universalLink(strings.TrimPrefix(r.QrCode, iden3commRequestURIPrefix))
+		}
+		return SignInLink302Response{Headers: SignInLink302ResponseHeaders{Location: location}}, nil
+	case SignIn400JSONResponse:
+		return SignInLink400JSONResponse(r), nil
+	case SignIn401JSONResponse:
+		return SignInLink401JSONResponse(r), nil
+	case SignIn403JSONResponse:
+		return SignInLink403JSONResponse(r), nil
+	case SignIn500JSONResponse:
+		return SignInLink500JSONResponse(r), nil
+	default:
+		return SignInLink500JSONResponse{N500JSONResponse{Message: "unexpected sign-in response"}}, nil
+	}
+}
+
+// signInLinkKey returns the API key that signed the link. Links are signed with the keys of VERIFIER_BACKEND_API_KEYS
+// or of the tenants, so they stop working when their key is removed.
+func (s *Server) signInLinkKey(params SignInLinkParams, now time.Time) (string, error) {
+	var expires int64
+	if params.Expires != nil {
+		expires = *params.Expires
+		if now.Unix() >= expires {
+			return "", i18n.New(i18n.CodeSignInLinkExpired)
+		}
+	}
+	signature, err := hex.DecodeString(common.FromPointer(params.Signature))
+	if err != nil {
+		return "", i18n.New(i18n.CodeSignInLinkInvalid)
+	}
+
+	keys := append([]string{}, s.cfg.APIKeys...)
+	for _, tenant := range s.cfg.Tenants {
+		keys = append(keys, tenant.APIKeys...)
+	}
+	for _, key := range keys {
+		if hmac.Equal(signature, signInLinkSignature(key, params.TemplateId, params.ChainId, expires)) {
+			return key, nil
+		}
+	}
+	return "", i18n.New(i18n.CodeSignInLinkInvalid)
+}
+
+// signInLinkSignature is the HMAC-SHA256 with apiKey of the template, the chain and the expiration (0 when the link
+// does not expire) of a sign-in link, separated by new lines
+func signInLinkSignature(apiKey, templateID, chainID string, expires int64) []byte {
+	mac := hmac.New(sha256.New, []byte(apiKey))
+	_, _ = fmt.Fprintf(mac, "%s\n%s\n%d", templateID, chainID, expires)
+	return mac.Sum(nil)
+}
+
+// qrCodeLink returns the link to the QR code of the token, shortened when a shortener is configured
+func (s *Server) qrCodeLink(ctx context.Context, token string, publicURL *string) string {
+	link := s.qrStoreLink(ctx, token, publicURL)
+	if s.shortener == nil {
+		return link
+	}
+
+	short, err := s.shortener.Shorten(ctx, link)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"link": link, "err": err}).Warn("failed to shorten qr code link")
+		return link
+	}
+	return short
+}
+
+// qrStoreLink returns the link to the QR code of the token, on the public URL of the request when its sign-in sets one
+// or when no base URL is configured
+func (s *Server) qrStoreLink(ctx context.Context, token string, publicURL *string) string {
+	baseURL := s.cfg.QRLink.BaseURL
+	if baseURL == "" || common.FromPointer(publicURL) != "" {
+		baseURL = s.publicURL(ctx, publicURL) + "/qr-store"
+	}
+	return fmt.Sprintf("%s?id=%s", baseURL, token)
+}
+
+// universalLink returns the first wallet universal link that fetches the request from requestURI
+func (s *Server) universalLink(requestURI string) string {
+	return universalLinkOn(s.universalLinkURLs()[0], requestURI)
+}
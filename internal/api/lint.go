@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	"github.com/iden3/go-schema-processor/v2/merklize"
+	"github.com/piprate/json-gold/ld"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+// WithQueryLinter checks the queries of the sign-in requests against their JSON-LD contexts, loaded with l
+func WithQueryLinter(l ld.DocumentLoader) Option {
+	return func(s *Server) {
+		s.queryLoader = l
+	}
+}
+
+// lintQueries checks that the credential types and the fields of the queries are defined in their JSON-LD contexts,
+// and that the operators and their values match the datatypes of the fields. It does nothing without query linter.
+func (s *Server) lintQueries(ctx context.Context, scopes []ScopeRequest) error {
+	if s.queryLoader == nil {
+		return nil
+	}
+	for _, scope := range scopes {
+		if err := s.lintQuery(ctx, scope.Id, scope.Query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) lintQuery(ctx context.Context, scopeID uint32, query map[string]interface{}) error {
+	schemaContext, _ := query["context"].(string)
+	credentialType, _ := query["type"].(string)
+	if schemaContext == "" || credentialType == "" {
+		return nil
+	}
+	// the contexts that cannot be loaded are not linted, the wallets and the verification report their errors
+	doc, err := s.queryLoader.LoadDocument(schemaContext)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"context": schemaContext, "err": err}).Warn("failed to load the context of the query, it is not linted")
+		return nil
+	}
+	if err := lintContext(s.queryLoader, doc, scopeID, query); err != nil {
+		return err
+	}
+	// the queries the non-merklized credentials cannot prove are only logged, the builder reports them to the integrators
+	subject, _ := query["credentialSubject"].(map[string]interface{})
+	if serialization, err := contextSerialization(s.queryLoader, doc, credentialType); err == nil && len(subject) > 0 {
+		for field := range subject {
+			if warning := serialization.warning(scopeID, credentialType, field); warning != nil {
+				s.log(ctx).WithFields(log.Fields{"context": schemaContext, "err": warning}).Warn("the query cannot be proved with non-merklized credentials")
+			}
+		}
+	}
+	return nil
+}
+
+// lintContext checks the type and the fields of a query against its JSON-LD context, loaded in doc
+func lintContext(loader ld.DocumentLoader, doc *ld.RemoteDocument, scopeID uint32, query map[string]interface{}) error {
+	schemaContext, _ := query["context"].(string)
+	credentialType, _ := query["type"].(string)
+	ctxBytes, err := json.Marshal(doc.Document)
+	if err != nil {
+		return err
+	}
+
+	opts := merklize.Options{DocumentLoader: loader}
+	if _, err := opts.TypeIDFromContext(ctxBytes, credentialType); err != nil {
+		return i18n.New(i18n.CodeQueryTypeUnknown, credentialType, scopeID, schemaContext)
+	}
+
+	subject, _ := query["credentialSubject"].(map[string]interface{})
+	fields := make([]string, 0, len(subject))
+	for field := range subject {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		datatype, err := opts.TypeFromContext(ctxBytes, credentialType+"."+field)
+		if err != nil {
+			return i18n.New(i18n.CodeQueryFieldUnknown, field, scopeID, credentialType)
+		}
+		expression, _ := subject[field].(map[string]interface{})
+		for operator, value := range expression {
+			if err := lintOperator(scopeID, field, datatype, operator, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// lintOperator checks that operator can be used with the datatype of field, and that its values can be converted to it
+func lintOperator(scopeID uint32, field, datatype, operator string, value interface{}) error {
+	if datatype == "" {
+		return nil
+	}
+	if op, ok := circuits.QueryOperators[operator]; ok && !pubsignals.IsValidOperation(datatype, op) {
+		return i18n.New(i18n.CodeQueryOperatorDatatype, operator, field, scopeID, datatype)
+	}
+	if operator == "$exists" {
+		return nil
+	}
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+	for _, v := range values {
+		if _, err := merklize.HashValue(datatype, v); err != nil {
+			return i18n.New(i18n.CodeQueryValueDatatype, v, field, scopeID, datatype)
+		}
+	}
+	return nil
+}
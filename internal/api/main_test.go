@@ -10,6 +10,9 @@ import (
 	"github.com/0xPolygonID/verifier-backend/internal/config"
 )
 
+// invalidProofToken is a well-formed JWZ token without a valid proof, so it is rejected by the verification
+const invalidProofToken = "eyJhbGciOiJncm90aDE2IiwiY2lyY3VpdElkIjoiYXV0aFYyIiwiY3JpdCI6WyJjaXJjdWl0SWQiXSwidHlwIjoiYXBwbGljYXRpb24vaWRlbjMtemtwLWpzb24ifQ.eyJib2R5Ijp7InNjb3BlIjpbXX19.e30"
+
 var (
 	cfg        config.Config
 	keysLoader *loaders.FSKeyLoader
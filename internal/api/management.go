@@ -0,0 +1,151 @@
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/webhook"
+)
+
+const (
+	// ManagementSignatureHeader is the header with the HMAC-SHA256 of the management requests, see SignManagementRequest
+	ManagementSignatureHeader = "X-Signature"
+	// ManagementTimestampHeader is the header with the unix time, in seconds, the management request was signed at
+	ManagementTimestampHeader = "X-Signature-Timestamp"
+
+	managementPathPrefix         = "/admin/"
+	managementSignatureKeyPrefix = "management-signature-"
+)
+
+// ManagementAuth rejects the requests to the management endpoints that are not signed with one of the HMAC secrets,
+// whose timestamp is outside the replay window or whose signature was already used, and, when client certificates are
+// configured, the ones that do not present a verified client certificate. The signatures are kept in seen for twice
+// the replay window, so a shared store protects all the replicas. The signed bodies are read up to maxBodySize bytes.
+func ManagementAuth(cfg config.ManagementAuth, maxBodySize int64, seen qrCache) func(http.Handler) http.Handler {
+	window := cfg.ReplayWindow.AsDuration()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, managementPathPrefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx := r.Context()
+			if cfg.ClientCAPath != "" && (r.TLS == nil || len(r.TLS.VerifiedChains) == 0) {
+				writeError(w, http.StatusUnauthorized, i18n.Message(ctx, i18n.CodeManagementClientCertRequired))
+				return
+			}
+			if len(cfg.HMACSecrets) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			timestamp, signature := r.Header.Get(ManagementTimestampHeader), r.Header.Get(ManagementSignatureHeader)
+			if timestamp == "" || signature == "" {
+				writeError(w, http.StatusUnauthorized, i18n.Message(ctx, i18n.CodeManagementSignatureRequired,
+					ManagementTimestampHeader, ManagementSignatureHeader))
+				return
+			}
+			seconds, err := strconv.ParseInt(timestamp, 10, 64)
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, i18n.Message(ctx, i18n.CodeManagementSignatureInvalid))
+				return
+			}
+			if age := time.Since(time.Unix(seconds, 0)); age > window || age < -window {
+				writeError(w, http.StatusUnauthorized, i18n.Message(ctx, i18n.CodeManagementSignatureExpired, window))
+				return
+			}
+
+			body, err := readSignedBody(w, r, maxBodySize)
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					writeError(w, http.StatusRequestEntityTooLarge, i18n.Message(ctx, i18n.CodeBodyTooLarge, maxBodySize))
+					return
+				}
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if !validManagementSignature(cfg.HMACSecrets, timestamp, r.Method, r.URL.RequestURI(), body, signature) {
+				writeError(w, http.StatusUnauthorized, i18n.Message(ctx, i18n.CodeManagementSignatureInvalid))
+				return
+			}
+			if _, ok := seen.Get(managementSignatureKeyPrefix + signature); ok {
+				writeError(w, http.StatusUnauthorized, i18n.Message(ctx, i18n.CodeManagementSignatureReplayed))
+				return
+			}
+			seen.Set(managementSignatureKeyPrefix+signature, true, 2*window)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SignManagementRequest returns the value of the ManagementSignatureHeader of a management request: the HMAC-SHA256
+// of the timestamp, the method, the path with its query and the body, separated by new lines
+func SignManagementRequest(secret, timestamp, method, requestURI string, body []byte) string {
+	payload := make([]byte, 0, len(timestamp)+len(method)+len(requestURI)+len(body)+3)
+	payload = append(payload, timestamp+"\n"+method+"\n"+requestURI+"\n"...)
+	payload = append(payload, body...)
+	return webhook.Sign([]byte(secret), payload)
+}
+
+// ManagementTLSConfig returns the tls configuration of the api server, which asks the clients for a certificate issued
+// by the certificate authorities of cfg.ClientCAPath. The certificates are only required on the management endpoints.
+func ManagementTLSConfig(cfg config.ManagementAuth) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.ClientCAPath == "" {
+		return tlsConfig, nil
+	}
+	if cfg.TLSCertPath == "" {
+		return nil, errors.New("the client certificate authorities require a tls certificate")
+	}
+	pem, err := os.ReadFile(cfg.ClientCAPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the client certificate authorities: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in %s", cfg.ClientCAPath)
+	}
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	return tlsConfig, nil
+}
+
+func validManagementSignature(secrets []string, timestamp, method, requestURI string, body []byte, signature string) bool {
+	for _, secret := range secrets {
+		expected := SignManagementRequest(secret, timestamp, method, requestURI, body)
+		if hmac.Equal([]byte(expected), []byte(signature)) {
+			return true
+		}
+	}
+	return false
+}
+
+// readSignedBody reads the body of the request and replaces it, so the handlers can read it again
+func readSignedBody(w http.ResponseWriter, r *http.Request, maxBodySize int64) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	reader := r.Body
+	if maxBodySize > 0 {
+		reader = http.MaxBytesReader(w, r.Body, maxBodySize)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-circuits/v2"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+const (
+	maxMetadataKeys        = 16
+	maxMetadataKeyLength   = 64
+	maxMetadataValueLength = 256
+)
+
+// validateMetadata checks the size of the metadata of a sign-in, and that only the off-chain sessions set a message
+func validateMetadata(body *SignInRequest) error {
+	if body.Message != nil {
+		switch circuits.CircuitID(body.Scope[0].CircuitId) {
+		case circuits.AtomicQuerySigV2OnChainCircuitID, circuits.AtomicQueryMTPV2OnChainCircuitID, circuits.AtomicQueryV3OnChainCircuitID:
+			return i18n.New(i18n.CodeMessageOnChain)
+		}
+	}
+	if body.Metadata == nil {
+		return nil
+	}
+	if len(*body.Metadata) > maxMetadataKeys {
+		return i18n.New(i18n.CodeInvalidMetadata, maxMetadataKeys, maxMetadataKeyLength, maxMetadataValueLength)
+	}
+	for key, value := range *body.Metadata {
+		if key == "" || utf8.RuneCountInString(key) > maxMetadataKeyLength || utf8.RuneCountInString(value) > maxMetadataValueLength {
+			return i18n.New(i18n.CodeInvalidMetadata, maxMetadataKeys, maxMetadataKeyLength, maxMetadataValueLength)
+		}
+	}
+	return nil
+}
+
+// sessionMetadata returns the metadata of the sign-in of the session, kept by the ledger with the lifetime of the session
+func (s *Server) sessionMetadata(ctx context.Context, sessionID uuid.UUID) map[string]string {
+	record, ok := s.sessionRecord(ctx, sessionID)
+	if !ok {
+		return nil
+	}
+	return record.Metadata
+}
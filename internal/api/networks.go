@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+)
+
+// GetNetworks - lists the networks of the resolver settings
+func (s *Server) GetNetworks(_ context.Context, _ GetNetworksRequestObject) (GetNetworksResponseObject, error) {
+	networks := make(GetNetworks200JSONResponse, 0)
+	for blockchain, chainSettings := range s.cfg.ResolverSettings {
+		for network, settings := range chainSettings {
+			n := Network{
+				Name:          blockchain + ":" + network,
+				Blockchain:    blockchain,
+				Network:       network,
+				ChainID:       settings.ChainID,
+				Method:        settings.Method,
+				StateContract: settings.ContractAddress,
+				Confirmations: settings.Confirmations,
+			}
+			if settings.VerifierContract != "" {
+				n.VerifierContract = common.ToPointer(settings.VerifierContract)
+			}
+			if settings.VerifierMethodID != "" {
+				n.VerifierMethodID = common.ToPointer(settings.VerifierMethodID)
+			}
+			// the networks without their own DID send the requests from the fallback sender DID, if any
+			if did, err := s.getSenderDID(settings.ChainID); err == nil && did != "" {
+				n.VerifierDID = &did
+			}
+			networks = append(networks, n)
+		}
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].Name < networks[j].Name
+	})
+	return networks, nil
+}
+
+// applyContractPreset sets the verifier contract, method and network missing in the transaction data of an on-chain
+// sign-in to the preset of the network of its chain
+func (s *Server) applyContractPreset(data *TransactionData) {
+	if data == nil || data.ChainID <= 0 {
+		return
+	}
+	chainID := strconv.Itoa(data.ChainID)
+	for blockchain, chainSettings := range s.cfg.ResolverSettings {
+		for network, settings := range chainSettings {
+			if settings.ChainID != chainID {
+				continue
+			}
+			if data.ContractAddress == "" {
+				data.ContractAddress = settings.VerifierContract
+			}
+			if data.MethodID == "" {
+				data.MethodID = settings.VerifierMethodID
+			}
+			if data.Network == "" {
+				data.Network = blockchain + "-" + network
+			}
+			return
+		}
+	}
+}
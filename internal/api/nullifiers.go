@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/nullifier"
+)
+
+const (
+	nullifierRegistryDisabled = "nullifier registry is not enabled"
+	uniqueNullifierKeyPrefix  = "session-unique-nullifier-"
+)
+
+// GetNullifierCheckpoints - get the checkpoints of the nullifier registry
+func (s *Server) GetNullifierCheckpoints(ctx context.Context, _ GetNullifierCheckpointsRequestObject) (GetNullifierCheckpointsResponseObject, error) {
+	if s.nullifiers == nil {
+		return GetNullifierCheckpoints404JSONResponse{N404JSONResponse{Message: nullifierRegistryDisabled}}, nil
+	}
+
+	checkpoints, err := s.nullifiers.Checkpoints(ctx)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to get nullifier checkpoints")
+		return GetNullifierCheckpoints500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp := make(GetNullifierCheckpoints200JSONResponse, 0, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		resp = append(resp, toNullifierCheckpointResponse(checkpoint))
+	}
+	return resp, nil
+}
+
+// GetNullifierProof - get a proof of inclusion or non-inclusion of a nullifier at a checkpoint
+func (s *Server) GetNullifierProof(ctx context.Context, request GetNullifierProofRequestObject) (GetNullifierProofResponseObject, error) {
+	if s.nullifiers == nil {
+		return GetNullifierProof404JSONResponse{N404JSONResponse{Message: nullifierRegistryDisabled}}, nil
+	}
+
+	value, ok := new(big.Int).SetString(request.Nullifier, defaultBigIntBase)
+	if !ok || value.Sign() < 0 {
+		return GetNullifierProof400JSONResponse{N400JSONResponse{Message: "nullifier is not a valid big integer"}}, nil
+	}
+
+	proof, leaf, checkpoint, err := s.nullifiers.Prove(ctx, value, request.Params.Checkpoint)
+	if err != nil {
+		if errors.Is(err, nullifier.ErrCheckpointNotFound) {
+			return GetNullifierProof404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		s.log(ctx).WithFields(log.Fields{"err": err, "nullifier": request.Nullifier}).Error("failed to generate nullifier proof")
+		return GetNullifierProof500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp := GetNullifierProof200JSONResponse{
+		Nullifier:  value.String(),
+		Exists:     proof.Existence,
+		Checkpoint: toNullifierCheckpointResponse(*checkpoint),
+		Proof:      *proof,
+	}
+	if leaf != nil {
+		resp.Value = common.ToPointer(leaf.String())
+	}
+	return resp, nil
+}
+
+// scopeNullifier is the nullifier proved for a scope in its nullifier session, the value is nil when the proof has none
+type scopeNullifier struct {
+	ScopeID   uint32
+	SessionID *big.Int
+	Value     *big.Int
+}
+
+// proofNullifiers returns the nullifiers of the proofs of the scopes
+func proofNullifiers(scopes []protocol.ZeroKnowledgeProofResponse) ([]scopeNullifier, error) {
+	nullifiers := make([]scopeNullifier, 0, len(scopes))
+	for _, scope := range scopes {
+		output, err := getProofOutput(scope)
+		if err != nil {
+			return nil, err
+		}
+		sessionValue, _ := output["nullifierSessionID"].(*big.Int)
+		value, _ := output["nullifier"].(*big.Int)
+		nullifiers = append(nullifiers, scopeNullifier{ScopeID: scope.ID, SessionID: sessionValue, Value: value})
+	}
+	return nullifiers, nil
+}
+
+// recordNullifiers adds the nullifiers to the registry. Reused nullifiers are only logged.
+func (s *Server) recordNullifiers(ctx context.Context, sessionID string, nullifiers []scopeNullifier) error {
+	if s.nullifiers == nil {
+		return nil
+	}
+
+	for _, n := range nullifiers {
+		if n.Value == nil || n.Value.Sign() == 0 {
+			continue
+		}
+
+		added, err := s.nullifiers.Add(ctx, n.Value)
+		if err != nil {
+			return fmt.Errorf("failed to add nullifier of scope %d: %w", n.ScopeID, err)
+		}
+		if !added {
+			s.log(ctx).WithFields(log.Fields{"sessionID": sessionID, "scopeID": n.ScopeID, "nullifier": n.Value.String()}).Warn("nullifier already seen")
+		}
+	}
+	return nil
+}
+
+// validateUniqueNullifier checks that every scope proves a nullifier when the request enforces unique nullifiers
+func validateUniqueNullifier(body *SignInRequest) error {
+	if body.EnforceUniqueNullifier == nil || !*body.EnforceUniqueNullifier {
+		return nil
+	}
+	for _, scope := range body.Scope {
+		circuitID := circuits.CircuitID(scope.CircuitId)
+		if (circuitID != circuits.AtomicQueryV3CircuitID && circuitID != circuits.AtomicQueryV3OnChainCircuitID) || scope.Params == nil {
+			return i18n.New(i18n.CodeNullifierSessionRequired, scope.Id, circuits.AtomicQueryV3CircuitID)
+		}
+		if id, ok := (*scope.Params)["nullifierSessionID"].(string); !ok || id == "" || id == "0" {
+			return i18n.New(i18n.CodeNullifierSessionRequired, scope.Id, circuits.AtomicQueryV3CircuitID)
+		}
+	}
+	return nil
+}
+
+// setUniqueNullifier marks the session as one where the nullifiers must not have been used before
+func (s *Server) setUniqueNullifier(sessionID uuid.UUID, enforce *bool) {
+	if enforce == nil || !*enforce {
+		return
+	}
+	s.cache.Set(uniqueNullifierKeyPrefix+sessionID.String(), true, cache.DefaultExpiration)
+}
+
+// claimNullifiers marks the nullifiers as used when the session enforces unique nullifiers.
+// It returns a CodeNullifierAlreadyUsed error when one of them was already used in its nullifier session.
+func (s *Server) claimNullifiers(ctx context.Context, sessionID uuid.UUID, nullifiers []scopeNullifier) error {
+	if _, ok := s.cache.Get(uniqueNullifierKeyPrefix + sessionID.String()); !ok {
+		return nil
+	}
+
+	keys := make([]nullifier.Key, 0, len(nullifiers))
+	scopeIDs := make(map[nullifier.Key]uint32, len(nullifiers))
+	for _, n := range nullifiers {
+		if n.SessionID == nil || n.Value == nil || n.Value.Sign() == 0 {
+			return fmt.Errorf("the proof of scope %d has no nullifier", n.ScopeID)
+		}
+		key := nullifier.Key{SessionID: n.SessionID.String(), Nullifier: n.Value.String()}
+		keys = append(keys, key)
+		if _, ok := scopeIDs[key]; !ok {
+			scopeIDs[key] = n.ScopeID
+		}
+	}
+
+	used, err := s.nullifierStore.Claim(ctx, keys)
+	if err != nil {
+		return err
+	}
+	if used != nil {
+		return i18n.New(i18n.CodeNullifierAlreadyUsed, scopeIDs[*used], used.SessionID)
+	}
+	return nil
+}
+
+func toNullifierCheckpointResponse(checkpoint nullifier.Checkpoint) NullifierCheckpoint {
+	return NullifierCheckpoint{
+		Id:        checkpoint.ID,
+		Root:      checkpoint.Root.Hex(),
+		Size:      checkpoint.Size,
+		CreatedAt: checkpoint.CreatedAt,
+	}
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/issuernode"
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+)
+
+const (
+	credentialOfferKeyPrefix = "credential-offer-"
+	issuerNodeTimeout        = 10 * time.Second
+)
+
+// CreateCredentialOffer - create a credential offer in the issuer node for the user of a successful session
+func (s *Server) CreateCredentialOffer(ctx context.Context, request CreateCredentialOfferRequestObject) (CreateCredentialOfferResponseObject, error) {
+	id := request.SessionID
+	if request.Params.XAPIKey == nil || *request.Params.XAPIKey == "" {
+		return CreateCredentialOffer401JSONResponse{N401JSONResponse{Message: i18n.Localize(ctx, errAPIKeyRequired)}}, nil
+	}
+	if !s.canConsumeSession(id, *request.Params.XAPIKey) {
+		return CreateCredentialOffer403JSONResponse{N403JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionForbidden, id)}}, nil
+	}
+	offer, ok := s.credentialOffer(request.Params.Offer)
+	if !ok {
+		name := ""
+		if request.Params.Offer != nil {
+			name = *request.Params.Offer
+		}
+		return CreateCredentialOffer404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeCredentialOfferNotFound, name)}}, nil
+	}
+
+	item, err := s.takeSessionResult(id, false)
+	switch {
+	case errors.Is(err, errSessionNotFound):
+		if _, known := s.sessionRecord(ctx, id); known {
+			return CreateCredentialOffer410JSONResponse{N410JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionExpired, id)}}, nil
+		}
+		return CreateCredentialOffer404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionNotFound)}}, nil
+	case errors.Is(err, errSessionPending):
+		return CreateCredentialOffer409JSONResponse{N409JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionPending, id)}}, nil
+	case errors.Is(err, errSessionConsumed):
+		return CreateCredentialOffer410JSONResponse{N410JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionConsumed, id)}}, nil
+	}
+	verification, ok := item.(models.VerificationResponse)
+	if !ok {
+		return CreateCredentialOffer409JSONResponse{N409JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionNotVerified, id)}}, nil
+	}
+
+	created, err := s.createCredentialOffer(ctx, id, offer, verification.UserDID)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"offer": offer.Name, "err": err}).Error("failed to create the credential offer")
+		return CreateCredentialOffer500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return CreateCredentialOffer200JSONResponse{
+		Offer:         offer.Name,
+		CredentialID:  created.CredentialID,
+		UniversalLink: created.UniversalLink,
+	}, nil
+}
+
+// credentialOffer returns the credential offer of name, or the only configured offer when name is not set
+func (s *Server) credentialOffer(name *string) (config.CredentialOffer, bool) {
+	if name == nil || *name == "" {
+		if len(s.cfg.CredentialOffers) == 1 {
+			return s.cfg.CredentialOffers[0], true
+		}
+		return config.CredentialOffer{}, false
+	}
+	for _, offer := range s.cfg.CredentialOffers {
+		if offer.Name == *name {
+			return offer, true
+		}
+	}
+	return config.CredentialOffer{}, false
+}
+
+// createCredentialOffer creates the credential of the offer for the user of the session once. The offer is kept with
+// the session, so the retries and the concurrent calls return the same offer instead of issuing another credential.
+func (s *Server) createCredentialOffer(ctx context.Context, sessionID uuid.UUID, offer config.CredentialOffer, userDID string) (issuernode.Offer, error) {
+	key := credentialOfferKeyPrefix + sessionID.String() + "-" + offer.Name
+	if created, ok := s.cache.Get(key); ok {
+		return created.(issuernode.Offer), nil
+	}
+	created, err, _ := s.credentialOffers.Do(key, func() (any, error) {
+		if created, ok := s.cache.Get(key); ok {
+			return created, nil
+		}
+		created, err := s.issuerNode.Offer(context.WithoutCancel(ctx), offer, issuernode.Session{
+			UserDID:   userDID,
+			SessionID: sessionID.String(),
+			Metadata:  s.sessionMetadata(ctx, sessionID),
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.cache.Set(key, created, cache.DefaultExpiration)
+		s.logger.WithFields(log.Fields{"sessionID": sessionID, "offer": offer.Name, "credentialID": created.CredentialID}).
+			Info("credential offer created")
+		return created, nil
+	})
+	if err != nil {
+		return issuernode.Offer{}, err
+	}
+	return created.(issuernode.Offer), nil
+}
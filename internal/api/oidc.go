@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+	"github.com/0xPolygonID/verifier-backend/internal/oidc"
+)
+
+// CreateSession creates a sign-in session for a query template and returns its iden3comm URI. It implements oidc.Sessions.
+func (s *Server) CreateSession(ctx context.Context, template string, chainID string) (uuid.UUID, string, error) {
+	resp, err := s.SignIn(ctx, SignInRequestObject{
+		Body: &SignInRequest{
+			ChainID: common.ToPointer(chainID),
+			Scope:   []ScopeRequest{{Id: 1, Template: common.ToPointer(template)}},
+		},
+	})
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	switch r := resp.(type) {
+	case SignIn200JSONResponse:
+		return r.SessionID, r.QrCode, nil
+	case SignIn400JSONResponse:
+		return uuid.Nil, "", errors.New(r.Message)
+	case SignIn401JSONResponse:
+		return uuid.Nil, "", errors.New(r.Message)
+	case SignIn403JSONResponse:
+		return uuid.Nil, "", errors.New(r.Message)
+	case SignIn500JSONResponse:
+		return uuid.Nil, "", errors.New(r.Message)
+	default:
+		return uuid.Nil, "", errors.New("unexpected sign-in response")
+	}
+}
+
+// ConsumeIdentity returns the DID and the disclosed claims of a verified session, deleting its result.
+// It implements oidc.Sessions.
+func (s *Server) ConsumeIdentity(sessionID uuid.UUID) (*oidc.Identity, error) {
+	item, err := s.takeSessionResult(sessionID, true)
+	if errors.Is(err, errSessionPending) {
+		return nil, oidc.ErrPending
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch value := item.(type) {
+	case error:
+		return nil, value
+	case models.VerificationResponse:
+		vps, err := s.verifiablePresentations(value.Jwz)
+		if err != nil {
+			return nil, err
+		}
+		claims := make(map[string]any)
+		for _, vp := range vps {
+			for k, v := range vp.CredentialSubject {
+				if k == "@type" || k == "id" {
+					continue
+				}
+				claims[k] = v
+			}
+		}
+		return &oidc.Identity{DID: value.UserDID, Claims: claims}, nil
+	}
+	return nil, errors.New("unexpected session result")
+}
@@ -0,0 +1,249 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	common2 "github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/hooks"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+	"github.com/0xPolygonID/verifier-backend/internal/onchain"
+)
+
+const onChainCompletionKeyPrefix = "onchain-completion-"
+
+// WithOnChainReader sets the reader of the proofs submitted to the verifier contracts by the on-chain sign-ins
+func WithOnChainReader(r onchain.Reader) Option {
+	return func(s *Server) {
+		s.onChain = r
+	}
+}
+
+// CheckOnChainProofs - completes an on-chain session once its verifier contract recorded the proofs of all its scopes
+func (s *Server) CheckOnChainProofs(ctx context.Context, request CheckOnChainProofsRequestObject) (CheckOnChainProofsResponseObject, error) {
+	id := request.SessionID
+	if !common2.IsHexAddress(request.Body.Address) {
+		return CheckOnChainProofs400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeInvalidEthAddress, request.Body.Address)}}, nil
+	}
+	item, ok := s.cache.Get(id.String())
+	if !ok {
+		if _, known := s.sessionRecord(ctx, id); known {
+			return CheckOnChainProofs410JSONResponse{N410JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionExpired, id)}}, nil
+		}
+		return CheckOnChainProofs404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionNotFound)}}, nil
+	}
+	switch value := item.(type) {
+	case protocol.AuthorizationRequestMessage:
+		return CheckOnChainProofs400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionNotOnChain, id)}}, nil
+	case protocol.ContractInvokeRequestMessage:
+		sender := common2.HexToAddress(request.Body.Address)
+		verification, complete, err := s.readSessionProofs(ctx, id, value, sender)
+		if err != nil {
+			s.log(ctx).WithFields(log.Fields{"sessionID": id, "err": err}).Error("failed to read on-chain proofs")
+			return CheckOnChainProofs500JSONResponse{N500JSONResponse{Message: i18n.Message(ctx, i18n.CodeOnChainProofsUnreadable, err.Error())}}, nil
+		}
+		if complete {
+			s.completeOnChainSession(ctx, id, value, sender, verification)
+		}
+	}
+
+	// the finished sessions answer their result, as the status endpoint does
+	resp, err := s.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: id}})
+	if err != nil {
+		return nil, err
+	}
+	switch status := resp.(type) {
+	case Status200JSONResponse:
+		return CheckOnChainProofs200JSONResponse(status), nil
+	case Status404JSONResponse:
+		return CheckOnChainProofs404JSONResponse(status), nil
+	}
+	return CheckOnChainProofs500JSONResponse{N500JSONResponse{Message: fmt.Sprintf("unexpected status of session %s", id)}}, nil
+}
+
+// readSessionProofs reads the proofs of the on-chain session submitted by sender. The proofs mined before the session
+// was created answer an earlier request, so the session is not complete until they are submitted again.
+func (s *Server) readSessionProofs(ctx context.Context, sessionID uuid.UUID, request protocol.ContractInvokeRequestMessage,
+	sender common2.Address,
+) (models.VerificationResponse, bool, error) {
+	record, ok := s.sessionRecord(ctx, sessionID)
+	if !ok {
+		return models.VerificationResponse{}, false, fmt.Errorf("the creation of session %s is unknown", sessionID)
+	}
+	// the timestamps of the blocks are in seconds
+	return s.readOnChainProofs(ctx, request, sender, record.CreatedAt.Truncate(time.Second))
+}
+
+// readOnChainProofs reads the proofs of the scopes of the request submitted by sender to its verifier contract since
+// the given time. It reports whether all of them are recorded, with the verification made of the user and the
+// nullifiers of the proofs.
+func (s *Server) readOnChainProofs(ctx context.Context, request protocol.ContractInvokeRequestMessage, sender common2.Address,
+	since time.Time,
+) (models.VerificationResponse, bool, error) {
+	data := request.Body.TransactionData
+	contract := common2.HexToAddress(data.ContractAddress)
+	verification := models.VerificationResponse{}
+	for _, scope := range request.Body.Scope {
+		proof, err := s.onChain.Proof(ctx, data.ChainID, contract, sender, uint64(scope.ID))
+		if err != nil {
+			return models.VerificationResponse{}, false, err
+		}
+		if !proof.Verified || proof.Time.Before(since) {
+			return models.VerificationResponse{}, false, nil
+		}
+		userDID, err := didFromUserID(proof)
+		if err != nil {
+			return models.VerificationResponse{}, false, err
+		}
+		if verification.UserDID != "" && verification.UserDID != userDID {
+			return models.VerificationResponse{}, false, fmt.Errorf("the proofs of %s were submitted by different users", sender.Hex())
+		}
+		verification.UserDID = userDID
+		if proof.Nullifier != nil {
+			nullifierSessionID, _ := scope.Params["nullifierSessionId"].(string)
+			verification.Scopes = append(verification.Scopes, models.VerificationResponseScope{
+				ID:                 scope.ID,
+				NullifierSessionID: nullifierSessionID,
+				Nullifier:          proof.Nullifier.String(),
+			})
+		}
+	}
+	verification.EthAddress, _ = genesisEthAddress(verification.UserDID)
+	return verification, true, nil
+}
+
+func didFromUserID(proof onchain.Proof) (string, error) {
+	id, err := core.IDFromInt(proof.UserID)
+	if err != nil {
+		return "", err
+	}
+	did, err := core.ParseDIDFromID(id)
+	if err != nil {
+		return "", err
+	}
+	return did.String(), nil
+}
+
+// onChainNullifiers returns the nullifiers of the scopes of the request proved in the verification
+func onChainNullifiers(request protocol.ContractInvokeRequestMessage, verification models.VerificationResponse) []scopeNullifier {
+	proved := make(map[uint32]models.VerificationResponseScope, len(verification.Scopes))
+	for _, scope := range verification.Scopes {
+		proved[scope.ID] = scope
+	}
+	nullifiers := make([]scopeNullifier, 0, len(request.Body.Scope))
+	for _, scope := range request.Body.Scope {
+		n := scopeNullifier{ScopeID: scope.ID}
+		if proof, ok := proved[scope.ID]; ok {
+			n.SessionID, _ = new(big.Int).SetString(proof.NullifierSessionID, defaultBigIntBase)
+			n.Value, _ = new(big.Int).SetString(proof.Nullifier, defaultBigIntBase)
+		}
+		nullifiers = append(nullifiers, n)
+	}
+	return nullifiers
+}
+
+// onChainMessages returns the request of the on-chain session and the response of its user as the messages of the
+// off-chain sessions, for the verification hooks. The scopes of the response have no proofs, they were verified by the
+// contract.
+func onChainMessages(request protocol.ContractInvokeRequestMessage, verification models.VerificationResponse,
+) (protocol.AuthorizationRequestMessage, protocol.AuthorizationResponseMessage) {
+	authRequest := protocol.AuthorizationRequestMessage{
+		ID:       request.ID,
+		Typ:      request.Typ,
+		Type:     protocol.AuthorizationRequestMessageType,
+		ThreadID: request.ThreadID,
+		From:     request.From,
+		To:       request.To,
+		Body: protocol.AuthorizationRequestMessageBody{
+			Reason: request.Body.Reason,
+			Scope:  request.Body.Scope,
+		},
+	}
+	response := protocol.AuthorizationResponseMessage{
+		ID:       uuid.NewString(),
+		Typ:      request.Typ,
+		Type:     protocol.AuthorizationResponseMessageType,
+		ThreadID: request.ThreadID,
+		From:     verification.UserDID,
+		To:       request.From,
+	}
+	for _, scope := range request.Body.Scope {
+		response.Body.Scope = append(response.Body.Scope, protocol.ZeroKnowledgeProofResponse{ID: scope.ID, CircuitID: scope.CircuitID})
+	}
+	return authRequest, response
+}
+
+// completeOnChainSession runs the checks of the verified callbacks on the proofs of the on-chain session submitted by
+// sender, and stores the verification of the session, unless the session was completed by a concurrent check
+func (s *Server) completeOnChainSession(ctx context.Context, sessionID uuid.UUID, request protocol.ContractInvokeRequestMessage,
+	sender common2.Address, verification models.VerificationResponse,
+) {
+	completion := onChainCompletionKeyPrefix + sessionID.String()
+	if err := s.cache.Add(completion, true, cache.DefaultExpiration); err != nil {
+		return
+	}
+	defer s.cache.Delete(completion)
+	item, _ := s.cache.Get(sessionID.String())
+	if pending, ok := item.(protocol.ContractInvokeRequestMessage); !ok || pending.ID != request.ID {
+		return
+	}
+
+	s.recordSessionEvent(sessionID, sessionEventVerifying, "")
+	verified := false
+	defer func() {
+		s.publishStatus(sessionID)
+		s.emitVerification(sessionID, request.Body.Scope, verified)
+		s.recordVerificationEvent(sessionID, verified)
+		s.recordUser(ctx, sessionID, request.Body.Scope, verified)
+		s.recordAudit(ctx, sessionID, request.Body.Scope, verified)
+	}()
+	fail := func(err error, msg string) {
+		s.log(ctx).WithFields(log.Fields{"sessionID": sessionID, "err": err}).Error(msg)
+		s.cache.Set(sessionID.String(), err, cache.DefaultExpiration)
+		s.tags.finish(sessionID, false)
+	}
+
+	authRequest, response := onChainMessages(request, verification)
+	if err := hooks.Run(ctx, s.verificationHooks, hooks.Session{ID: sessionID.String(), Request: authRequest}, response); err != nil {
+		fail(i18n.Wrap(err, i18n.CodeVerificationRejected, err.Error()), "verification rejected by a hook")
+		return
+	}
+
+	nullifiers := onChainNullifiers(request, verification)
+	if err := s.claimNullifiers(ctx, sessionID, nullifiers); err != nil {
+		fail(err, "nullifier uniqueness check failed")
+		return
+	}
+	if err := s.recordNullifiers(ctx, sessionID.String(), nullifiers); err != nil {
+		s.log(ctx).WithFields(log.Fields{
+			"sessionID": sessionID,
+			"err":       err,
+		}).Error("failed to record nullifiers")
+	}
+
+	if s.cfg.JWT.Enabled {
+		token, err := s.issueToken(sessionID, request.Body.Scope, verification)
+		if err != nil {
+			s.log(ctx).WithFields(log.Fields{
+				"sessionID": sessionID,
+				"err":       err,
+			}).Error("failed to issue token")
+		}
+		verification.Token = token
+	}
+
+	s.cache.Set(sessionID.String(), verification, cache.DefaultExpiration)
+	s.log(ctx).WithFields(log.Fields{"sessionID": sessionID, "userDID": verification.UserDID}).Info("on-chain proofs recorded")
+	s.tags.finish(sessionID, true)
+	s.scheduleOnChainReverification(sessionID, request, sender)
+	verified = true
+}
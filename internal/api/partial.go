@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/patrickmn/go-cache"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+)
+
+const (
+	requiredScopesKeyPrefix = "session-required-scopes-"
+	scopeStatusFailed       = "failed"
+	// maxRequiredScopesScopes caps the scopes of the requests with requiredScopes, as each of them can be verified on its own
+	maxRequiredScopesScopes = 5
+)
+
+// validateRequiredScopes checks that the number of required scopes is between 1 and the number of scopes,
+// and that the scopes are verified off-chain. Linked scopes are rejected, as the verifier only checks that their
+// credentials share the same holder when they are verified together.
+func validateRequiredScopes(body *SignInRequest) error {
+	if body.RequiredScopes == nil {
+		return nil
+	}
+	if *body.RequiredScopes < 1 || *body.RequiredScopes > len(body.Scope) {
+		return i18n.New(i18n.CodeRequiredScopesInvalid, len(body.Scope))
+	}
+	if len(body.Scope) > maxRequiredScopesScopes {
+		return i18n.New(i18n.CodeRequiredScopesTooMany, maxRequiredScopesScopes)
+	}
+	for _, scope := range body.Scope {
+		if groupID, ok := scope.Query["groupId"]; ok && groupID != nil && groupID != float64(0) && groupID != 0 {
+			return i18n.New(i18n.CodeRequiredScopesLinked, scope.Id)
+		}
+	}
+	switch circuits.CircuitID(body.Scope[0].CircuitId) {
+	case circuits.AtomicQuerySigV2OnChainCircuitID, circuits.AtomicQueryMTPV2OnChainCircuitID, circuits.AtomicQueryV3OnChainCircuitID:
+		return i18n.New(i18n.CodeRequiredScopesOnChain)
+	}
+	return nil
+}
+
+// setRequiredScopes stores the number of scopes the callback of the session must verify, when it is not all of them
+func (s *Server) setRequiredScopes(sessionID uuid.UUID, body *SignInRequest) {
+	if body.RequiredScopes == nil || *body.RequiredScopes == len(body.Scope) {
+		return
+	}
+	s.cache.Set(requiredScopesKeyPrefix+sessionID.String(), *body.RequiredScopes, cache.DefaultExpiration)
+}
+
+// requiredScopes returns the number of scopes the callback of the session must verify, false when all of them are required
+func (s *Server) requiredScopes(sessionID uuid.UUID) (int, bool) {
+	required, ok := s.cache.Get(requiredScopesKeyPrefix + sessionID.String())
+	if !ok {
+		return 0, false
+	}
+	return required.(int), true
+}
+
+// verifyScopes verifies the scopes of the request one by one, after the verification of all of them failed.
+// It returns the response and the request restricted to the verified scopes, and the statuses of the failed scopes,
+// or an error when fewer than required scopes are verified.
+func (s *Server) verifyScopes(ctx context.Context, token string, request protocol.AuthorizationRequestMessage, required int,
+	opts ...pubsignals.VerifyOpt,
+) (*protocol.AuthorizationResponseMessage, protocol.AuthorizationRequestMessage, []models.ScopeStatus, error) {
+	var (
+		response *protocol.AuthorizationResponseMessage
+		verified = request
+		failed   []models.ScopeStatus
+		reasons  []string
+	)
+	verified.Body.Scope = make([]protocol.ZeroKnowledgeProofRequest, 0, len(request.Body.Scope))
+	for i, scope := range request.Body.Scope {
+		// the scopes left cannot make up for the failed ones
+		if len(verified.Body.Scope)+len(request.Body.Scope)-i < required {
+			failed = append(failed, models.ScopeStatus{ID: scope.ID, Status: scopeStatusFailed, Reason: "not verified"})
+			continue
+		}
+		single := request
+		single.Body.Scope = []protocol.ZeroKnowledgeProofRequest{scope}
+		resp, err := s.verifier.FullVerify(ctx, token, single, opts...)
+		if err != nil {
+			failed = append(failed, models.ScopeStatus{ID: scope.ID, Status: scopeStatusFailed, Reason: err.Error()})
+			reasons = append(reasons, fmt.Sprintf("scope %d: %v", scope.ID, err))
+			continue
+		}
+		response = resp
+		verified.Body.Scope = append(verified.Body.Scope, scope)
+	}
+
+	if len(verified.Body.Scope) < required {
+		return nil, verified, failed, i18n.New(i18n.CodeScopesNotVerified,
+			len(verified.Body.Scope), len(request.Body.Scope), required, strings.Join(reasons, "; "))
+	}
+
+	restricted := *response
+	restricted.Body.Scope = make([]protocol.ZeroKnowledgeProofResponse, 0, len(verified.Body.Scope))
+	for _, scope := range response.Body.Scope {
+		if !isFailedScope(failed, scope.ID) {
+			restricted.Body.Scope = append(restricted.Body.Scope, scope)
+		}
+	}
+	return &restricted, verified, failed, nil
+}
+
+// mergeScopeStatuses adds the statuses of the failed scopes to the reconciled ones, sorted by scope
+func mergeScopeStatuses(reconciled, failed []models.ScopeStatus) []models.ScopeStatus {
+	statuses := make([]models.ScopeStatus, 0, len(reconciled)+len(failed))
+	statuses = append(append(statuses, reconciled...), failed...)
+	sort.SliceStable(statuses, func(i, j int) bool { return statuses[i].ID < statuses[j].ID })
+	return statuses
+}
+
+func isFailedScope(failed []models.ScopeStatus, id uint32) bool {
+	for _, status := range failed {
+		if status.ID == id {
+			return true
+		}
+	}
+	return false
+}
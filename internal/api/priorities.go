@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
+
+	"github.com/0xPolygonID/verifier-backend/internal/lanes"
+)
+
+const sessionPriorityKeyPrefix = "session-priority-"
+
+type batchKey struct{}
+
+// withBatch marks the sign-ins of a batch, whose verifications are low priority
+func withBatch(ctx context.Context) context.Context {
+	return context.WithValue(ctx, batchKey{}, true)
+}
+
+// priority returns the priority class of the verifications of a sign-in: the class of the tenant of the api key,
+// low for batches and sandbox keys, normal otherwise
+func (s *Server) priority(ctx context.Context, apiKey *string) lanes.Class {
+	if batch, _ := ctx.Value(batchKey{}).(bool); batch {
+		return lanes.Low
+	}
+	if apiKey == nil || *apiKey == "" {
+		return lanes.Normal
+	}
+	for _, tenant := range s.cfg.Tenants {
+		for _, key := range tenant.APIKeys {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(*apiKey)) == 1 {
+				class, _ := lanes.ParseClass(tenant.Priority)
+				return class
+			}
+		}
+	}
+	for _, key := range s.cfg.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(*apiKey)) == 1 {
+			return lanes.Normal
+		}
+	}
+	if _, err := s.apiKeys.Get(*apiKey); err == nil {
+		return lanes.Low
+	}
+	return lanes.Normal
+}
+
+// setSessionPriority stores the priority class of the verification of the session, when it is not normal
+func (s *Server) setSessionPriority(sessionID uuid.UUID, class lanes.Class) {
+	if s.lanes == nil || class == lanes.Normal {
+		return
+	}
+	s.cache.Set(sessionPriorityKeyPrefix+sessionID.String(), class, cache.DefaultExpiration)
+}
+
+// reserveVerification takes a verification slot in the priority class of the session, or queues the verification.
+// It returns nil when the verifications are not limited.
+func (s *Server) reserveVerification(sessionID uuid.UUID) (*lanes.Reservation, error) {
+	if s.lanes == nil {
+		return nil, nil
+	}
+	class := lanes.Normal
+	if item, ok := s.cache.Get(sessionPriorityKeyPrefix + sessionID.String()); ok {
+		class, _ = item.(lanes.Class)
+	}
+	return s.lanes.Reserve(class)
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/patrickmn/go-cache"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+const (
+	sessionTrustProfileKeyPrefix = "session-trust-profile-"
+	// selectiveDisclosureOperator is the operator of the trust profiles that allows selective disclosure
+	selectiveDisclosureOperator = "$sd"
+	anyIssuer                   = "*"
+)
+
+// applyTrustProfile checks the scopes against the trust profile of the request and completes them with the
+// allowed issuers and the revocation check of the profile. It returns the name of the profile, empty without profile.
+func (s *Server) applyTrustProfile(body *SignInRequest) (string, error) {
+	if body.TrustProfile == nil || *body.TrustProfile == "" {
+		return "", nil
+	}
+	profile, ok := s.trustProfiles[*body.TrustProfile]
+	if !ok {
+		return "", i18n.New(i18n.CodeTrustProfileNotFound, *body.TrustProfile)
+	}
+
+	for i, scope := range body.Scope {
+		query := make(Query, len(scope.Query)+2)
+		for k, v := range scope.Query {
+			query[k] = v
+		}
+
+		if err := checkProfileSchema(profile, scope.Id, query); err != nil {
+			return "", err
+		}
+		if err := checkProfileOperators(profile, scope.Id, query); err != nil {
+			return "", err
+		}
+
+		if len(profile.AllowedIssuers) > 0 {
+			requested, err := toStringSlice(query["allowedIssuers"])
+			if err != nil || len(requested) == 0 || contains(requested, anyIssuer) {
+				requested = profile.AllowedIssuers
+			}
+			for _, issuer := range requested {
+				if !contains(profile.AllowedIssuers, issuer) {
+					return "", i18n.New(i18n.CodeTrustProfileIssuer, profile.Name, issuer, scope.Id)
+				}
+			}
+			query["allowedIssuers"] = requested
+		}
+
+		if profile.Revocation == config.RevocationPolicyRequired {
+			if skip, _ := query["skipClaimRevocationCheck"].(bool); skip {
+				return "", i18n.New(i18n.CodeTrustProfileRevocation, profile.Name, scope.Id)
+			}
+			query["skipClaimRevocationCheck"] = false
+		}
+		body.Scope[i].Query = query
+	}
+	return profile.Name, nil
+}
+
+// setSessionTrustProfile stores the trust profile the callback of the session must comply with
+func (s *Server) setSessionTrustProfile(sessionID uuid.UUID, name string) {
+	if name == "" {
+		return
+	}
+	s.cache.Set(sessionTrustProfileKeyPrefix+sessionID.String(), name, cache.DefaultExpiration)
+}
+
+// checkTrustProfile checks the issuers, the revocation check and the age of the proofs against the trust profile of the session
+func (s *Server) checkTrustProfile(sessionID uuid.UUID, response protocol.AuthorizationResponseMessage, now time.Time) error {
+	name, ok := s.cache.Get(sessionTrustProfileKeyPrefix + sessionID.String())
+	if !ok {
+		return nil
+	}
+	profile, ok := s.trustProfiles[name.(string)]
+	if !ok {
+		return i18n.New(i18n.CodeTrustProfileNotFound, name)
+	}
+
+	for _, scope := range response.Body.Scope {
+		output, err := getProofOutput(scope)
+		if err != nil {
+			return err
+		}
+
+		if len(profile.AllowedIssuers) > 0 {
+			issuer, err := getProofIssuer(scope)
+			if err != nil {
+				return err
+			}
+			if !contains(profile.AllowedIssuers, issuer) {
+				return i18n.New(i18n.CodeTrustProfileIssuer, profile.Name, issuer, scope.ID)
+			}
+		}
+
+		if profile.Revocation == config.RevocationPolicyRequired {
+			if checked, _ := output["isRevocationChecked"].(int); checked != 1 {
+				return i18n.New(i18n.CodeTrustProfileRevocation, profile.Name, scope.ID)
+			}
+		}
+
+		if profile.MaxProofAge > 0 {
+			timestamp, _ := output["timestamp"].(int64)
+			generatedAt := time.Unix(timestamp, 0).UTC()
+			if now.Sub(generatedAt) > profile.MaxProofAge {
+				return i18n.New(i18n.CodeTrustProfileProofAge, profile.Name, profile.MaxProofAge, scope.ID, generatedAt.Format(time.RFC3339))
+			}
+		}
+	}
+	return nil
+}
+
+func checkProfileSchema(profile config.TrustProfile, scopeID uint32, query Query) error {
+	if len(profile.Schemas) == 0 {
+		return nil
+	}
+	credentialType, _ := query["type"].(string)
+	schemaContext, _ := query["context"].(string)
+	for _, schema := range profile.Schemas {
+		if schema.Type == credentialType && (schema.Context == "" || schema.Context == schemaContext) {
+			return nil
+		}
+	}
+	return i18n.New(i18n.CodeTrustProfileSchema, profile.Name, credentialType, scopeID)
+}
+
+func checkProfileOperators(profile config.TrustProfile, scopeID uint32, query Query) error {
+	if len(profile.Operators) == 0 {
+		return nil
+	}
+	subject, _ := query["credentialSubject"].(map[string]interface{})
+	fields := make([]string, 0, len(subject))
+	for field := range subject {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		expression, _ := subject[field].(map[string]interface{})
+		if len(expression) == 0 && !contains(profile.Operators, selectiveDisclosureOperator) {
+			return i18n.New(i18n.CodeTrustProfileOperator, profile.Name, selectiveDisclosureOperator, scopeID)
+		}
+		operators := make([]string, 0, len(expression))
+		for operator := range expression {
+			operators = append(operators, operator)
+		}
+		sort.Strings(operators)
+		for _, operator := range operators {
+			if !contains(profile.Operators, operator) {
+				return i18n.New(i18n.CodeTrustProfileOperator, profile.Name, operator, scopeID)
+			}
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
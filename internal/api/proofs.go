@@ -0,0 +1,80 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/iden3/go-circuits/v2"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+	"github.com/iden3/iden3comm/v2/protocol"
+
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+)
+
+// the values of the proofType output of the credentialAtomicQueryV3 circuits
+const (
+	v3ProofTypeSignature = 1
+	v3ProofTypeMTP       = 2
+)
+
+// getScopeProofs returns how the credential of each scope was proven. The scopes of the circuits that do not prove a
+// credential of an issuer, e.g. the linked multi query, are skipped.
+func getScopeProofs(scopes []protocol.ZeroKnowledgeProofResponse) ([]models.ScopeProof, error) {
+	proofs := make([]models.ScopeProof, 0, len(scopes))
+	for _, scope := range scopes {
+		var proofType verifiable.ProofType
+		var stateOutput string
+		switch circuits.CircuitID(scope.CircuitID) {
+		case circuits.AtomicQuerySigV2CircuitID:
+			proofType, stateOutput = verifiable.BJJSignatureProofType, "issuerAuthState"
+		case circuits.AtomicQueryMTPV2CircuitID:
+			proofType, stateOutput = verifiable.Iden3SparseMerkleTreeProofType, "issuerClaimIdenState"
+		case circuits.AtomicQueryV3CircuitID:
+			stateOutput = "issuerState"
+		default:
+			continue
+		}
+
+		output, err := getProofOutput(scope)
+		if err != nil {
+			return nil, err
+		}
+		if proofType == "" {
+			switch output["proofType"] {
+			case v3ProofTypeSignature:
+				proofType = verifiable.BJJSignatureProofType
+			case v3ProofTypeMTP:
+				proofType = verifiable.Iden3SparseMerkleTreeProofType
+			default:
+				return nil, fmt.Errorf("unknown proof type %v in pub signals of scope %d", output["proofType"], scope.ID)
+			}
+		}
+		issuerID, ok := output["issuerID"].(*core.ID)
+		if !ok || issuerID == nil {
+			return nil, fmt.Errorf("issuerID not found in pub signals of scope %d", scope.ID)
+		}
+		state, ok := output[stateOutput].(*merkletree.Hash)
+		if !ok || state == nil {
+			return nil, fmt.Errorf("%s not found in pub signals of scope %d", stateOutput, scope.ID)
+		}
+		issuerDID, err := core.ParseDIDFromID(*issuerID)
+		if err != nil {
+			return nil, err
+		}
+		genesis, err := core.CheckGenesisStateID(issuerID.BigInt(), state.BigInt())
+		if err != nil {
+			return nil, err
+		}
+
+		proofs = append(proofs, models.ScopeProof{
+			ID:                 scope.ID,
+			ProofType:          string(proofType),
+			IssuerDID:          issuerDID.String(),
+			IssuerState:        state.Hex(),
+			IssuerStateGenesis: genesis,
+			Merklized:          output["merklized"] == 1,
+		})
+	}
+	return proofs, nil
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+// publicURL returns the URL the wallets reach the verifier at for a request, the URL set by its sign-in if any, else the
+// configured public URL, else the URL forwarded by a trusted proxy
+func (s *Server) publicURL(ctx context.Context, override *string) string {
+	if u := common.FromPointer(override); u != "" {
+		return strings.TrimSuffix(u, "/")
+	}
+	if u := forwardedURL(ctx); s.cfg.PublicURL == "" && u != "" {
+		return u
+	}
+	return s.cfg.WalletURL()
+}
+
+// validatePublicURL checks the public URL a sign-in sets, which must be one of the allowed public URLs when the
+// verifier restricts them, so the callbacks of the wallets cannot be sent elsewhere
+func (s *Server) validatePublicURL(override *string) error {
+	u := common.FromPointer(override)
+	if u == "" {
+		return nil
+	}
+	if err := config.ValidatePublicURL(u); err != nil {
+		return i18n.New(i18n.CodePublicURLInvalid, u)
+	}
+	if len(s.cfg.AllowedPublicURLs) == 0 {
+		return nil
+	}
+	for _, allowed := range s.cfg.AllowedPublicURLs {
+		if strings.TrimSuffix(allowed, "/") == strings.TrimSuffix(u, "/") {
+			return nil
+		}
+	}
+	return i18n.New(i18n.CodePublicURLNotAllowed, u)
+}
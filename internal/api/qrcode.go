@@ -1,48 +1,249 @@
 package api
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
-	"github.com/google/uuid"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+const (
+	qrIDBytes  = 9
+	qrMACBytes = 9
+	qrCodeTTL  = time.Hour
+	// qrCodeVersion is the version of the format of the stored QR codes, the QR codes stored before the versions are 0
+	qrCodeVersion = 1
+	// qrReadBackoff is the first wait before a QR code that is not found is read again, the waits double
+	qrReadBackoff = 10 * time.Millisecond
+	// requestURIKey is the prefix of the keys of the request uris of the QR codes
+	requestURIKey = "qr-request-uri-"
 )
 
+var errQRCodeNotFound = errors.New("qr code not found")
+
 type qrCache interface {
 	Get(id string) (any, bool)
 	Set(id string, data any, duration time.Duration)
 }
 
+// qrWriter is a qrCache whose writes return the errors of the store, so the QR codes are only handed out once the
+// other replicas can read them
+type qrWriter interface {
+	Store(id string, value []byte, duration time.Duration) error
+}
+
+// storedQRCode is the stored format of the QR codes, so the replicas of different versions of the verifier can share
+// a store: a replica does not serve the QR codes of a version it does not know
+type storedQRCode struct {
+	Version int             `json:"version"`
+	QRCode  json.RawMessage `json:"qrCode"`
+}
+
 // QRcodeStore is a storage of qrCodes in a cache.
+// QR codes are stored as json, so they can be kept in caches shared by the replicas of the verifier.
+// They are referenced by tokens made of a random id and its HMAC, so tokens cannot be guessed.
+// The tokens of the QR codes that are not found are read again for the read retry window, as another replica may have
+// saved them in a store that did not replicate them yet.
 type QRcodeStore struct {
-	cache qrCache
+	cache     qrCache
+	secret    []byte
+	readRetry time.Duration
 }
 
-// NewQRCodeStore creates a new QRcodeStore.
-func NewQRCodeStore(c qrCache) *QRcodeStore {
-	return &QRcodeStore{cache: c}
+// NewQRCodeStore creates a new QRcodeStore that signs its tokens with secret.
+func NewQRCodeStore(c qrCache, secret []byte) *QRcodeStore {
+	return &QRcodeStore{cache: c, secret: secret}
 }
 
-// Get returns a QRCode from the cache using the qr code id as key
-func (s *QRcodeStore) Get(id uuid.UUID) (*QRCode, error) {
-	data, ok := s.cache.Get(s.key() + id.String())
+// Get returns a QRCode from the cache using the token of the qr code
+func (s *QRcodeStore) Get(token string) (*QRCode, error) {
+	id, ok := s.verify(token)
+	if !ok {
+		return nil, errQRCodeNotFound
+	}
+
+	data, ok := s.read(s.key() + id)
 	if !ok {
-		return nil, errors.New("sessionID not found")
+		return nil, errQRCodeNotFound
 	}
 
-	qr, ok := data.(QRCode)
+	b, ok := data.([]byte)
 	if !ok {
 		return nil, errors.New("failed to cast data to QRCode")
 	}
+	var stored storedQRCode
+	if err := json.Unmarshal(b, &stored); err != nil {
+		return nil, err
+	}
+	switch {
+	case stored.Version == 0 && stored.QRCode == nil:
+		// the QR codes stored before the versions are not wrapped
+		stored.QRCode = b
+	case stored.Version > qrCodeVersion:
+		return nil, fmt.Errorf("qr code stored with the unknown version %d", stored.Version)
+	}
+	var qr QRCode
+	if err := json.Unmarshal(stored.QRCode, &qr); err != nil {
+		return nil, err
+	}
 	return &qr, nil
 }
 
-// Save stores a QRCode in the cache and returns the id of the qr code.
-func (s *QRcodeStore) Save(qrCode QRCode) (uuid.UUID, error) {
-	id := uuid.New()
-	s.cache.Set(s.key()+id.String(), qrCode, 1*time.Hour)
-	return id, nil
+// Save stores a QRCode in the cache and returns the token of the qr code. When the cache returns the errors of its
+// writes, the token is only returned once the QR code is stored.
+func (s *QRcodeStore) Save(qrCode QRCode) (string, error) {
+	qr, err := json.Marshal(qrCode)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(storedQRCode{Version: qrCodeVersion, QRCode: qr})
+	if err != nil {
+		return "", err
+	}
+	id := make([]byte, qrIDBytes)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	if err := s.write(s.key()+base64.RawURLEncoding.EncodeToString(id), b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(append(id, s.mac(id)...)), nil
+}
+
+// SetRequestURI stores the request uri of the QR code of token, the link handed out to the wallets, so every replica
+// serves the interstitial page of the QR code with it
+func (s *QRcodeStore) SetRequestURI(token, requestURI string) error {
+	id, ok := s.verify(token)
+	if !ok {
+		return errQRCodeNotFound
+	}
+	return s.write(requestURIKey+id, []byte(requestURI))
+}
+
+// RequestURI returns the request uri of the QR code of token
+func (s *QRcodeStore) RequestURI(token string) (string, bool) {
+	id, ok := s.verify(token)
+	if !ok {
+		return "", false
+	}
+	data, ok := s.read(requestURIKey + id)
+	if !ok {
+		return "", false
+	}
+	b, ok := data.([]byte)
+	return string(b), ok
+}
+
+// write stores b under key for the lifetime of the QR codes, through the Store of the cache when it has one
+func (s *QRcodeStore) write(key string, b []byte) error {
+	if w, ok := s.cache.(qrWriter); ok {
+		return w.Store(key, b, qrCodeTTL)
+	}
+	s.cache.Set(key, b, qrCodeTTL)
+	return nil
+}
+
+// read returns the value of key, read again with growing waits for the read retry window when it is not found
+func (s *QRcodeStore) read(key string) (any, bool) {
+	data, ok := s.cache.Get(key)
+	if ok || s.readRetry <= 0 {
+		return data, ok
+	}
+	deadline := time.Now().Add(s.readRetry)
+	for backoff := qrReadBackoff; ; backoff *= 2 {
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return nil, false
+		}
+		time.Sleep(min(backoff, wait))
+		if data, ok := s.cache.Get(key); ok {
+			return data, true
+		}
+	}
+}
+
+// verify checks the HMAC of the token and returns its id
+func (s *QRcodeStore) verify(token string) (string, bool) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(b) != qrIDBytes+qrMACBytes {
+		return "", false
+	}
+	id, mac := b[:qrIDBytes], b[qrIDBytes:]
+	if !hmac.Equal(mac, s.mac(id)) {
+		return "", false
+	}
+	return base64.RawURLEncoding.EncodeToString(id), true
+}
+
+func (s *QRcodeStore) mac(id []byte) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write(id)
+	return h.Sum(nil)[:qrMACBytes]
 }
 
 func (s *QRcodeStore) key() string {
 	return "qr-code-"
 }
+
+// qrSecret returns the secret of the QR code tokens, a random one when it is not configured
+func qrSecret(cfg config.QRLink) []byte {
+	if cfg.Secret != "" {
+		return []byte(cfg.Secret)
+	}
+	secret := make([]byte, sha256.Size)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+	return secret
+}
+
+// qrCodeCacheControl lets the wallet cache the QR codes, which never change, while their session waits for the callback.
+// They are private so shared caches do not serve them, which would hide the scans of the QR codes and leak the requests.
+func qrCodeCacheControl(sessionTTL time.Duration) string {
+	maxAge := qrCodeTTL
+	if sessionTTL > 0 && sessionTTL < maxAge {
+		maxAge = sessionTTL
+	}
+	return fmt.Sprintf("private, max-age=%d", int(maxAge.Seconds()))
+}
+
+// qrCodeETag returns the ETag of the QR code of token. The QR code of a token never changes, so the token identifies its content.
+func qrCodeETag(token string) string {
+	return `"` + token + `"`
+}
+
+// etagMatches reports whether the If-None-Match header matches etag
+func etagMatches(ifNoneMatch *string, etag string) bool {
+	if ifNoneMatch == nil {
+		return false
+	}
+	for _, candidate := range strings.Split(*ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// NoCache disables the caching of the responses, except the responses of the qr-store GET requests, which set their own cache headers
+func NoCache(next http.Handler) http.Handler {
+	noCache := chiMiddleware.NoCache(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/qr-store" && r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+		noCache.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+	"github.com/piprate/json-gold/ld"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/pkg/verifier"
+)
+
+const defaultBuiltScopeID = 1
+
+// WithQueryBuilder sets the document loader the query builder resolves the JSON-LD contexts of the credentials with
+func WithQueryBuilder(l ld.DocumentLoader) Option {
+	return func(s *Server) {
+		s.builderLoader = l
+	}
+}
+
+// BuildQuery - build the scope of a sign-in request from a credential type, a field, an operator and a value
+func (s *Server) BuildQuery(ctx context.Context, request BuildQueryRequestObject) (BuildQueryResponseObject, error) {
+	if s.builderLoader == nil {
+		return BuildQuery500JSONResponse{N500JSONResponse{Message: "the query builder is not configured"}}, nil
+	}
+	if request.Body == nil {
+		return BuildQuery400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeFieldEmpty, "body")}}, nil
+	}
+	built, err := s.buildScope(ctx, *request.Body)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"context": request.Body.Context, "type": request.Body.Type, "err": err}).Warn("failed to build the query")
+		return BuildQuery400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+	}
+
+	if common.FromPointer(request.Body.ChainID) != "" {
+		built.SignIn = &SignInRequest{ChainID: request.Body.ChainID, Scope: []ScopeRequest{built.Scope}}
+	}
+	return BuildQuery200JSONResponse(built), nil
+}
+
+// buildScope writes the query of the predicate of body, and checks it as the sign-in requests are checked, and against
+// the JSON-LD context of the credential
+func (s *Server) buildScope(ctx context.Context, body BuildQueryRequest) (BuildQueryResponse, error) {
+	scopeID := uint32(defaultBuiltScopeID)
+	if body.ScopeID != nil {
+		scopeID = *body.ScopeID
+	}
+	allowedIssuers := []string{"*"}
+	if body.AllowedIssuers != nil && len(*body.AllowedIssuers) > 0 {
+		allowedIssuers = *body.AllowedIssuers
+	}
+	query := map[string]interface{}{
+		"context":        body.Context,
+		"type":           body.Type,
+		"allowedIssuers": allowedIssuers,
+	}
+	if body.SkipClaimRevocationCheck != nil {
+		query["skipClaimRevocationCheck"] = *body.SkipClaimRevocationCheck
+	}
+
+	field := strings.TrimSpace(common.FromPointer(body.Field))
+	operator := strings.TrimSpace(common.FromPointer(body.Operator))
+	if operator != "" && !strings.HasPrefix(operator, "$") {
+		operator = "$" + operator
+	}
+	switch {
+	case field != "":
+		// without operator the field is selectively disclosed
+		expression := map[string]interface{}{}
+		if operator != "" {
+			expression[operator] = common.FromPointer(body.Value)
+		}
+		query["credentialSubject"] = map[string]interface{}{field: expression}
+	case operator != "":
+		return BuildQueryResponse{}, i18n.New(i18n.CodeQueryFieldEmpty, "field")
+	}
+
+	circuitID := common.FromPointer(body.CircuitId)
+	if circuitID == "" {
+		circuitID = string(circuits.AtomicQuerySigV2CircuitID)
+		if verifier.IsV3Operator(operator) {
+			circuitID = string(circuits.AtomicQueryV3CircuitID)
+		}
+	}
+
+	scope := ScopeRequest{Id: scopeID, CircuitId: circuitID, Query: query}
+	if err := validateRequestQuery(true, []ScopeRequest{scope}); err != nil {
+		return BuildQueryResponse{}, err
+	}
+	doc, err := s.builderLoader.LoadDocument(body.Context)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"context": body.Context, "err": err}).Warn("failed to load the context of the query")
+		return BuildQueryResponse{}, i18n.New(i18n.CodeQueryContextUnavailable, body.Context, scopeID)
+	}
+	if err := lintContext(s.builderLoader, doc, scopeID, query); err != nil {
+		return BuildQueryResponse{}, err
+	}
+
+	built := BuildQueryResponse{Scope: scope}
+	if !common.FromPointer(body.DetectMerklization) {
+		return built, nil
+	}
+	serialization, err := contextSerialization(s.builderLoader, doc, body.Type)
+	if err != nil {
+		return BuildQueryResponse{}, err
+	}
+	built.Merklized = common.ToPointer(serialization.merklized())
+	if !serialization.merklized() {
+		slots := serialization.slots()
+		built.Slots = &slots
+		if warning := serialization.warning(scopeID, body.Type, field); warning != nil {
+			built.Warnings = &[]string{i18n.Localize(ctx, warning)}
+		}
+	}
+	return built, nil
+}
+
+// serialization holds the fields of a non-merklized credential type stored in the slots of its claims, none for the
+// merklized types
+type serialization struct {
+	indexA, indexB, valueA, valueB string
+}
+
+// contextSerialization reads the iden3_serialization of the credential type in its JSON-LD context, loaded in doc
+func contextSerialization(loader ld.DocumentLoader, doc *ld.RemoteDocument, credentialType string) (serialization, error) {
+	document, _ := doc.Document.(map[string]interface{})
+	opts := ld.NewJsonLdOptions("")
+	opts.DocumentLoader = loader
+	ldCtx, err := ld.NewContext(nil, opts).Parse(document["@context"])
+	if err != nil {
+		return serialization{}, err
+	}
+	attr, err := verifiable.GetSerializationAttrFromParsedContext(ldCtx, credentialType)
+	if err != nil || attr == "" {
+		return serialization{}, err
+	}
+	paths, err := verifiable.ParseSerializationAttr(attr)
+	if err != nil {
+		return serialization{}, err
+	}
+	return serialization{indexA: paths.IndexAPath, indexB: paths.IndexBPath, valueA: paths.ValueAPath, valueB: paths.ValueBPath}, nil
+}
+
+func (s serialization) merklized() bool {
+	return s == serialization{}
+}
+
+// slots returns the fields stored in the slots, the only fields the queries of a non-merklized type can use
+func (s serialization) slots() []string {
+	slots := make([]string, 0, 4)
+	for _, slot := range []string{s.indexA, s.indexB, s.valueA, s.valueB} {
+		if slot != "" {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+// warning returns why the wallets cannot prove a query of field, when it is a path of the merklized credentials that
+// is not stored in a slot of the non-merklized ones
+func (s serialization) warning(scopeID uint32, credentialType, field string) error {
+	if field == "" || s.merklized() {
+		return nil
+	}
+	slots := s.slots()
+	for _, slot := range slots {
+		if field == slot {
+			return nil
+		}
+	}
+	return i18n.New(i18n.CodeQueryFieldNotInSlot, credentialType, field, scopeID, strings.Join(slots, ", "))
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/iden3comm/v2/protocol"
+
+	"github.com/0xPolygonID/verifier-backend/internal/lanes"
+)
+
+// queuedVerification is the verification of a callback answered with a 202, run by a worker once it takes a slot
+type queuedVerification struct {
+	ctx         context.Context
+	sessionID   uuid.UUID
+	authRequest protocol.AuthorizationRequestMessage
+	token       string
+	queuedAt    time.Time
+	release     func()
+}
+
+// markQueued marks the session as having a queued verification. It returns false when the session already has one,
+// so the callbacks sent again by the wallets do not take more places in the queue.
+func (s *Server) markQueued(sessionID uuid.UUID) bool {
+	s.queuedMu.Lock()
+	defer s.queuedMu.Unlock()
+	if _, queued := s.queued[sessionID]; queued {
+		return false
+	}
+	s.queued[sessionID] = struct{}{}
+	return true
+}
+
+func (s *Server) unmarkQueued(sessionID uuid.UUID) {
+	s.queuedMu.Lock()
+	defer s.queuedMu.Unlock()
+	delete(s.queued, sessionID)
+}
+
+// queueVerification hands the verification over to the workers once its reservation takes a slot
+func (s *Server) queueVerification(reservation *lanes.Reservation, verification queuedVerification) {
+	reservation.OnAdmit(func(release func()) {
+		verification.release = release
+		// the channel holds a verification per slot, so handing the slot over never blocks
+		s.verifications <- verification
+	})
+}
+
+// RunVerificationWorkers runs the verifications of the queued callbacks with one worker per verification slot,
+// until ctx is done
+func (s *Server) RunVerificationWorkers(ctx context.Context) {
+	done := make(chan struct{})
+	for i := 0; i < s.cfg.VerificationConcurrency; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case verification := <-s.verifications:
+					s.runQueuedVerification(verification)
+				}
+			}
+		}()
+	}
+	for i := 0; i < s.cfg.VerificationConcurrency; i++ {
+		<-done
+	}
+}
+
+// runQueuedVerification verifies a queued callback, unless its session was removed from the cache while it waited
+func (s *Server) runQueuedVerification(verification queuedVerification) {
+	defer verification.release()
+	s.unmarkQueued(verification.sessionID)
+	if ttl := s.cfg.CacheExpiration.AsDuration(); ttl > 0 && time.Since(verification.queuedAt) > ttl {
+		s.log(verification.ctx).Error("queued verification dropped, its session expired while waiting for a slot")
+		return
+	}
+	s.verifyCallback(verification.ctx, verification.sessionID, verification.authRequest, verification.token)
+}
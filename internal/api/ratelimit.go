@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type rateCount struct {
+	Count   int
+	ResetAt time.Time
+}
+
+// rateLimiter counts the requests of subjects in fixed windows. Counts are stored as JSON under prefix, so the cache
+// can be shared by the replicas.
+type rateLimiter struct {
+	cache  qrCache
+	prefix string
+	limit  int
+	window time.Duration
+}
+
+func newRateLimiter(c qrCache, prefix string, limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{cache: c, prefix: prefix, limit: limit, window: window}
+}
+
+// Allow counts a request of subject, and returns false once the subject made more than the limit of requests in the
+// current window. All the requests are allowed when the limit is not positive.
+func (l *rateLimiter) Allow(subject string) bool {
+	if l.limit <= 0 {
+		return true
+	}
+	now := time.Now()
+	var rate rateCount
+	if data, ok := l.cache.Get(l.prefix + subject); ok {
+		if b, ok := data.([]byte); !ok || json.Unmarshal(b, &rate) != nil {
+			rate = rateCount{}
+		}
+	}
+	if !now.Before(rate.ResetAt) {
+		rate = rateCount{ResetAt: now.Add(l.window)}
+	}
+	rate.Count++
+	b, err := json.Marshal(rate)
+	if err != nil {
+		return false
+	}
+	l.cache.Set(l.prefix+subject, b, time.Until(rate.ResetAt))
+	return rate.Count <= l.limit
+}
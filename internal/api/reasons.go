@@ -0,0 +1,228 @@
+package api
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/iden3/go-circuits/v2"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/text/language"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+// ReasonTemplateStore is a storage of named reason templates, seeded with the reason templates of the configuration.
+// The templates set through the API are kept in memory, and are lost on restart unless they are added to the file.
+type ReasonTemplateStore struct {
+	mu        sync.RWMutex
+	templates map[string]ReasonTemplate
+}
+
+// NewReasonTemplateStore creates a new ReasonTemplateStore with the reason templates of the configuration
+func NewReasonTemplateStore(templates []config.ReasonTemplate) *ReasonTemplateStore {
+	s := &ReasonTemplateStore{templates: make(map[string]ReasonTemplate, len(templates))}
+	for _, template := range templates {
+		locales := make(map[string]LocalizedReason, len(template.Locales))
+		for locale, reason := range template.Locales {
+			localized := LocalizedReason{Reason: reason.Reason}
+			if reason.Message != "" {
+				localized.Message = common.ToPointer(reason.Message)
+			}
+			locales[locale] = localized
+		}
+		s.templates[template.Name] = ReasonTemplate{
+			Name:          template.Name,
+			DefaultLocale: template.DefaultLocale,
+			Locales:       locales,
+			UpdatedAt:     time.Now().UTC(),
+		}
+	}
+	return s
+}
+
+// Save creates or replaces a reason template.
+func (s *ReasonTemplateStore) Save(template ReasonTemplate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.templates[template.Name] = template
+}
+
+// Get returns a reason template by name.
+func (s *ReasonTemplateStore) Get(name string) (*ReasonTemplate, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	template, ok := s.templates[name]
+	if !ok {
+		return nil, false
+	}
+	return &template, true
+}
+
+// Delete removes a reason template by name.
+func (s *ReasonTemplateStore) Delete(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.templates, name)
+}
+
+// List returns all the reason templates sorted by name.
+func (s *ReasonTemplateStore) List() []ReasonTemplate {
+	s.mu.RLock()
+	templates := make([]ReasonTemplate, 0, len(s.templates))
+	for _, template := range s.templates {
+		templates = append(templates, template)
+	}
+	s.mu.RUnlock()
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates
+}
+
+// ListReasonTemplates - list the reason templates
+func (s *Server) ListReasonTemplates(ctx context.Context, request ListReasonTemplatesRequestObject) (ListReasonTemplatesResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return ListReasonTemplates401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	return ListReasonTemplates200JSONResponse(s.reasonTemplates.List()), nil
+}
+
+// SetReasonTemplate - create or replace a reason template
+func (s *Server) SetReasonTemplate(ctx context.Context, request SetReasonTemplateRequestObject) (SetReasonTemplateResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleAdmin) {
+		return SetReasonTemplate401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+
+	if !templateNameRegex.MatchString(request.TemplateName) {
+		return SetReasonTemplate400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeTemplateNameInvalid)}}, nil
+	}
+
+	template := ReasonTemplate{
+		Name:          request.TemplateName,
+		DefaultLocale: request.Body.DefaultLocale,
+		Locales:       request.Body.Locales,
+		UpdatedAt:     time.Now().UTC(),
+	}
+	if err := validateReasonTemplate(template); err != nil {
+		return SetReasonTemplate400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeReasonTemplateInvalid, err.Error())}}, nil
+	}
+
+	s.reasonTemplates.Save(template)
+	s.log(ctx).WithFields(log.Fields{"template": template.Name}).Info("reason template saved")
+
+	return SetReasonTemplate200JSONResponse(template), nil
+}
+
+// DeleteReasonTemplate - delete a reason template
+func (s *Server) DeleteReasonTemplate(ctx context.Context, request DeleteReasonTemplateRequestObject) (DeleteReasonTemplateResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleAdmin) {
+		return DeleteReasonTemplate401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+
+	template, ok := s.reasonTemplates.Get(request.TemplateName)
+	if !ok {
+		return DeleteReasonTemplate404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeReasonTemplateNotFound, request.TemplateName)}}, nil
+	}
+	s.reasonTemplates.Delete(request.TemplateName)
+	s.log(ctx).WithFields(log.Fields{"template": template.Name}).Info("reason template deleted")
+
+	return DeleteReasonTemplate200JSONResponse(*template), nil
+}
+
+// applyReasonTemplate sets the reason and the message of the sign-in that are not set with the ones of its reason
+// template, rendered in the locale of the user. The on-chain sessions only take the reason.
+func (s *Server) applyReasonTemplate(body *SignInRequest) error {
+	if body.ReasonTemplate == nil {
+		return nil
+	}
+	ref := body.ReasonTemplate
+	template, ok := s.reasonTemplates.Get(ref.Name)
+	if !ok {
+		return i18n.New(i18n.CodeReasonTemplateNotFound, ref.Name)
+	}
+
+	var variables map[string]string
+	if ref.Variables != nil {
+		variables = *ref.Variables
+	}
+	localized := localizedReason(*template, common.FromPointer(ref.Locale))
+	reason, err := renderReason(localized.Reason, variables)
+	if err != nil {
+		return i18n.Wrap(err, i18n.CodeReasonTemplateRender, template.Name, err.Error())
+	}
+	message, err := renderReason(common.FromPointer(localized.Message), variables)
+	if err != nil {
+		return i18n.Wrap(err, i18n.CodeReasonTemplateRender, template.Name, err.Error())
+	}
+
+	if body.Reason == nil {
+		body.Reason = common.ToPointer(reason)
+	}
+	switch circuits.CircuitID(body.Scope[0].CircuitId) {
+	case circuits.AtomicQuerySigV2OnChainCircuitID, circuits.AtomicQueryMTPV2OnChainCircuitID, circuits.AtomicQueryV3OnChainCircuitID:
+		return nil
+	}
+	if body.Message == nil && message != "" {
+		body.Message = common.ToPointer(message)
+	}
+	return nil
+}
+
+// localizedReason returns the reason of the locale in the template, or the one of its language, e.g. pt for pt-BR, or
+// the one of the default locale of the template
+func localizedReason(template ReasonTemplate, locale string) LocalizedReason {
+	if tag, err := language.Parse(locale); err == nil {
+		base, _ := tag.Base()
+		baseTag := language.Make(base.String())
+		var fallback *LocalizedReason
+		for key, reason := range template.Locales {
+			keyTag, err := language.Parse(key)
+			if err != nil {
+				continue
+			}
+			if keyTag == tag {
+				return reason
+			}
+			if keyTag == baseTag {
+				fallback = common.ToPointer(reason)
+			}
+		}
+		if fallback != nil {
+			return *fallback
+		}
+	}
+	return template.Locales[template.DefaultLocale]
+}
+
+// renderReason executes a reason or a message of a template with the variables of the sign-in. A variable the sign-in
+// does not set is an error rather than an empty string in the wallet.
+func renderReason(text string, variables map[string]string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	t, err := texttemplate.New("reason").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, variables); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func validateReasonTemplate(template ReasonTemplate) error {
+	locales := make(map[string]config.LocalizedReason, len(template.Locales))
+	for locale, reason := range template.Locales {
+		locales[locale] = config.LocalizedReason{Reason: reason.Reason, Message: common.FromPointer(reason.Message)}
+	}
+	return config.ValidateReasonTemplate(config.ReasonTemplate{
+		Name:          template.Name,
+		DefaultLocale: template.DefaultLocale,
+		Locales:       locales,
+	})
+}
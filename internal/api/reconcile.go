@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/iden3/iden3comm/v2/protocol"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+)
+
+// Statuses of the reconciliation of the requested scopes with the response
+const (
+	scopeStatusSatisfied  = "satisfied"
+	scopeStatusMissing    = "missing"
+	scopeStatusMismatched = "mismatched"
+)
+
+// presentation holds the fields of the verifiable presentation of a proof that are reconciled with the query
+type presentation struct {
+	VerifiableCredential struct {
+		Context           []string               `json:"@context"`
+		Type              []string               `json:"@type"`
+		CredentialSubject map[string]interface{} `json:"credentialSubject"`
+	} `json:"verifiableCredential"`
+}
+
+// reconcileScopes checks that every requested scope was answered once, with the requested circuit, an allowed issuer
+// and a presentation of the requested credential and fields. Responses to scopes that were not requested are mismatched.
+// It returns the status of every scope, and an error for the first scope that is not satisfied, unless it is an optional missing scope.
+func reconcileScopes(request protocol.AuthorizationRequestMessage, response protocol.AuthorizationResponseMessage) ([]models.ScopeStatus, error) {
+	answers := make(map[uint32][]protocol.ZeroKnowledgeProofResponse, len(response.Body.Scope))
+	for _, scope := range response.Body.Scope {
+		answers[scope.ID] = append(answers[scope.ID], scope)
+	}
+
+	statuses := make([]models.ScopeStatus, 0, len(request.Body.Scope))
+	var err error
+	for _, requested := range request.Body.Scope {
+		status := reconcileScope(requested, answers[requested.ID])
+		statuses = append(statuses, status)
+		delete(answers, requested.ID)
+
+		optional := requested.Optional != nil && *requested.Optional
+		if err == nil && status.Status != scopeStatusSatisfied && !(optional && status.Status == scopeStatusMissing) {
+			err = i18n.New(i18n.CodeScopeNotSatisfied, status.ID, status.Reason)
+		}
+	}
+	for _, scope := range response.Body.Scope {
+		if _, ok := answers[scope.ID]; !ok {
+			continue
+		}
+		delete(answers, scope.ID)
+		status := models.ScopeStatus{ID: scope.ID, Status: scopeStatusMismatched, Reason: "the scope was not requested"}
+		statuses = append(statuses, status)
+		if err == nil {
+			err = i18n.New(i18n.CodeScopeNotSatisfied, status.ID, status.Reason)
+		}
+	}
+	return statuses, err
+}
+
+func reconcileScope(requested protocol.ZeroKnowledgeProofRequest, answers []protocol.ZeroKnowledgeProofResponse) models.ScopeStatus {
+	mismatched := func(format string, args ...any) models.ScopeStatus {
+		return models.ScopeStatus{ID: requested.ID, Status: scopeStatusMismatched, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	switch {
+	case len(answers) == 0:
+		return models.ScopeStatus{ID: requested.ID, Status: scopeStatusMissing, Reason: "the scope was not answered"}
+	case len(answers) > 1:
+		return mismatched("the scope was answered %d times", len(answers))
+	}
+	answer := answers[0]
+	if answer.CircuitID != requested.CircuitID {
+		return mismatched("circuit %s was requested, %s was presented", requested.CircuitID, answer.CircuitID)
+	}
+
+	allowed, _ := toStringSlice(requested.Query["allowedIssuers"])
+	if len(allowed) > 0 && !contains(allowed, anyIssuer) {
+		issuer, err := getProofIssuer(answer)
+		if err != nil {
+			return mismatched("%v", err)
+		}
+		if !contains(allowed, issuer) {
+			return mismatched("issuer %s is not allowed", issuer)
+		}
+	}
+
+	if len(answer.VerifiablePresentation) == 0 || string(answer.VerifiablePresentation) == "null" {
+		return models.ScopeStatus{ID: requested.ID, Status: scopeStatusSatisfied}
+	}
+	var vp presentation
+	if err := json.Unmarshal(answer.VerifiablePresentation, &vp); err != nil {
+		return mismatched("invalid verifiable presentation: %v", err)
+	}
+	if credentialType, _ := requested.Query["type"].(string); credentialType != "" && !contains(vp.VerifiableCredential.Type, credentialType) {
+		return mismatched("credential type %s was requested, %v was presented", credentialType, vp.VerifiableCredential.Type)
+	}
+	if schemaContext, _ := requested.Query["context"].(string); schemaContext != "" && !contains(vp.VerifiableCredential.Context, schemaContext) {
+		return mismatched("schema context %s was requested, it was not presented", schemaContext)
+	}
+	subject, _ := requested.Query["credentialSubject"].(map[string]interface{})
+	for field := range vp.VerifiableCredential.CredentialSubject {
+		if field == "@type" {
+			continue
+		}
+		if _, ok := subject[field]; !ok {
+			return mismatched("field %s was disclosed without being requested", field)
+		}
+	}
+	return models.ScopeStatus{ID: requested.ID, Status: scopeStatusSatisfied}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-circuits/v2"
+	"github.com/patrickmn/go-cache"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+const (
+	redirectURIKeyPrefix = "redirect-uri-"
+	maxRedirectURILength = 2048
+
+	// callbackRedirectURIField is the field of the body of the callback responses with the redirect uri of the session
+	callbackRedirectURIField = "redirectUri"
+)
+
+// blockedRedirectSchemes are the schemes that would run code in the wallets that open the redirect uri in a web view
+var blockedRedirectSchemes = []string{"javascript", "data", "vbscript", "file"}
+
+// validateRedirectURI rejects the redirect uris of the on-chain sign-ins, whose proofs are not sent to the callback
+func validateRedirectURI(body *SignInRequest) error {
+	if body.RedirectUri == nil {
+		return nil
+	}
+	switch circuits.CircuitID(body.Scope[0].CircuitId) {
+	case circuits.AtomicQuerySigV2OnChainCircuitID, circuits.AtomicQueryMTPV2OnChainCircuitID, circuits.AtomicQueryV3OnChainCircuitID:
+		return i18n.New(i18n.CodeRedirectURIOnChain)
+	}
+	return checkRedirectURI(*body.RedirectUri)
+}
+
+// checkRedirectURI accepts the absolute urls, the deep links of the apps included, except the ones of the schemes that
+// run code
+func checkRedirectURI(redirectURI string) error {
+	u, err := url.Parse(redirectURI)
+	if err != nil || !u.IsAbs() || len(redirectURI) > maxRedirectURILength {
+		return i18n.New(i18n.CodeInvalidRedirectURI, maxRedirectURILength)
+	}
+	for _, scheme := range blockedRedirectSchemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return i18n.New(i18n.CodeInvalidRedirectURI, maxRedirectURILength)
+		}
+	}
+	return nil
+}
+
+func (s *Server) setRedirectURI(sessionID uuid.UUID, redirectURI *string) {
+	if redirectURI == nil {
+		return
+	}
+	s.cache.Set(redirectURIKeyPrefix+sessionID.String(), *redirectURI, cache.DefaultExpiration)
+}
+
+// callbackResponse is the response of the successful callbacks of the session: the wallets open the redirect uri of the
+// sign-in, when it is set, to send the user back to the app of the verifier
+func (s *Server) callbackResponse(sessionID uuid.UUID) Callback200JSONResponse {
+	redirectURI, ok := s.cache.Get(redirectURIKeyPrefix + sessionID.String())
+	if !ok {
+		return Callback200JSONResponse{}
+	}
+	return Callback200JSONResponse{callbackRedirectURIField: redirectURI}
+}
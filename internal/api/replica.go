@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+const sessionStatusKeyPrefix = "session-status-"
+
+// readOnlyPaths are the endpoints served by read-only replicas
+var readOnlyPaths = map[string]bool{
+	"/":                         true,
+	"/health":                   true,
+	"/status":                   true,
+	"/qr-store":                 true,
+	"/metrics":                  true,
+	"/static/docs/api/api.yaml": true,
+	"/favicon.ico":              true,
+}
+
+// WithStatusCache publishes the status of the sessions to c, the store shared with the read-only replicas
+func WithStatusCache(c qrCache) Option {
+	return func(s *Server) {
+		s.statusCache = c
+	}
+}
+
+// ReadOnly rejects the requests to the endpoints that are not served by read-only replicas
+func ReadOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if (r.Method == http.MethodGet || r.Method == http.MethodHead) &&
+			(readOnlyPaths[r.URL.Path] || strings.HasPrefix(r.URL.Path, interstitialPathPrefix)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(GenericErrorMessage{Message: i18n.Message(r.Context(), i18n.CodeReadOnlyReplica)})
+	})
+}
+
+// publishStatus stores the status of the session in the shared store, so read-only replicas can serve it.
+// Messages are published in the default language.
+func (s *Server) publishStatus(sessionID uuid.UUID) {
+	if s.statusCache == nil || s.cfg.ReadOnly {
+		return
+	}
+	resp, err := s.Status(context.Background(), StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+	if err != nil {
+		return
+	}
+	status, ok := resp.(Status200JSONResponse)
+	if !ok {
+		return
+	}
+	b, err := json.Marshal(status)
+	if err != nil {
+		s.logger.WithFields(log.Fields{"sessionID": sessionID, "err": err}).Error("failed to publish session status")
+		return
+	}
+	s.statusCache.Set(sessionStatusKeyPrefix+sessionID.String(), b, s.cfg.CacheExpiration.AsDuration())
+}
+
+// replicaStatus returns the status of the session published in the shared store
+func (s *Server) replicaStatus(ctx context.Context, sessionID uuid.UUID) (StatusResponseObject, error) {
+	notFound := Status404JSONResponse{N404JSONResponse: N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionNotFound)}}
+	if s.statusCache == nil {
+		return notFound, nil
+	}
+	data, ok := s.statusCache.Get(sessionStatusKeyPrefix + sessionID.String())
+	if !ok {
+		return notFound, nil
+	}
+	b, ok := data.([]byte)
+	if !ok {
+		return notFound, nil
+	}
+	var status Status200JSONResponse
+	if err := json.Unmarshal(b, &status); err != nil {
+		s.log(ctx).WithFields(log.Fields{"sessionID": sessionID, "err": err}).Error("invalid published session status")
+		return notFound, nil
+	}
+	return status, nil
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	verrors "github.com/0xPolygonID/verifier-backend/internal/errors"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+)
+
+const (
+	statusConsumed        = "consumed"
+	sessionOwnerKeyPrefix = "session-owner-"
+)
+
+// consumedResult replaces the result of a session once it has been consumed, so it cannot be returned again
+type consumedResult struct {
+	ConsumedAt time.Time
+	// JwzHash is the hash of the token of a successful verification, to acknowledge the retries of its callback
+	JwzHash [sha256.Size]byte
+}
+
+var (
+	errSessionNotFound = errors.New("session not found")
+	errSessionPending  = errors.New("session pending")
+	errSessionConsumed = errors.New("session consumed")
+)
+
+// GetSessionResult - get the result of a session, deleting it when consume is set
+func (s *Server) GetSessionResult(ctx context.Context, request GetSessionResultRequestObject) (GetSessionResultResponseObject, error) {
+	id := request.SessionID
+	if !s.isSessionOwner(id, request.Params.XAPIKey) {
+		return GetSessionResult403JSONResponse{N403JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionForbidden, id)}}, nil
+	}
+
+	consume := request.Params.Consume != nil && *request.Params.Consume
+	if consume {
+		if request.Params.XAPIKey == nil || *request.Params.XAPIKey == "" {
+			return GetSessionResult401JSONResponse{N401JSONResponse{Message: i18n.Localize(ctx, errAPIKeyRequired)}}, nil
+		}
+		if !s.canConsumeSession(id, *request.Params.XAPIKey) {
+			return GetSessionResult403JSONResponse{N403JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionForbidden, id)}}, nil
+		}
+	}
+	item, err := s.takeSessionResult(id, consume)
+	switch {
+	case errors.Is(err, errSessionNotFound):
+		if _, known := s.sessionRecord(ctx, id); known {
+			return GetSessionResult410JSONResponse{N410JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionExpired, id)}}, nil
+		}
+		return GetSessionResult404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionNotFound)}}, nil
+	case errors.Is(err, errSessionPending):
+		return GetSessionResult409JSONResponse{N409JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionPending, id)}}, nil
+	case errors.Is(err, errSessionConsumed):
+		return GetSessionResult410JSONResponse{N410JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionConsumed, id)}}, nil
+	}
+
+	switch value := item.(type) {
+	case expiredSession:
+		return GetSessionResult200JSONResponse{Status: value.status()}, nil
+	case invalidatedVerification:
+		return GetSessionResult200JSONResponse{
+			Status:    value.status(),
+			Message:   common.ToPointer(i18n.Localize(ctx, value.Err)),
+			ErrorCode: common.ToPointer(verrors.Classify(value.Err)),
+		}, nil
+	case error:
+		return GetSessionResult200JSONResponse{
+			Status:    statusError,
+			Message:   common.ToPointer(i18n.Localize(ctx, value)),
+			ErrorCode: common.ToPointer(verrors.Classify(value)),
+		}, nil
+	case models.VerificationResponse:
+		vps, err := s.verifiablePresentations(value.Jwz)
+		if err != nil {
+			s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to get verifiable presentations")
+			return GetSessionResult500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		}
+		return GetSessionResult200JSONResponse(getStatusVerificationResponse(value, vps)), nil
+	}
+	return GetSessionResult500JSONResponse{N500JSONResponse{Message: "unexpected session result"}}, nil
+}
+
+// FinalizeSession - delete the result of a session without returning it
+func (s *Server) FinalizeSession(ctx context.Context, request FinalizeSessionRequestObject) (FinalizeSessionResponseObject, error) {
+	id := request.SessionID
+	if request.Params.XAPIKey == nil || *request.Params.XAPIKey == "" {
+		return FinalizeSession401JSONResponse{N401JSONResponse{Message: i18n.Localize(ctx, errAPIKeyRequired)}}, nil
+	}
+	if !s.canConsumeSession(id, *request.Params.XAPIKey) {
+		return FinalizeSession403JSONResponse{N403JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionForbidden, id)}}, nil
+	}
+
+	_, err := s.takeSessionResult(id, true)
+	switch {
+	case errors.Is(err, errSessionNotFound):
+		if _, known := s.sessionRecord(ctx, id); known {
+			return FinalizeSession410JSONResponse{N410JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionExpired, id)}}, nil
+		}
+		return FinalizeSession404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionNotFound)}}, nil
+	case errors.Is(err, errSessionPending):
+		return FinalizeSession409JSONResponse{N409JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionPending, id)}}, nil
+	case errors.Is(err, errSessionConsumed):
+		return FinalizeSession410JSONResponse{N410JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionConsumed, id)}}, nil
+	}
+	return FinalizeSession200JSONResponse{Status: statusConsumed}, nil
+}
+
+// takeSessionResult returns the result of a finished session. When consume is set, the result is replaced
+// by a consumedResult under the same lock, so concurrent calls cannot return it twice.
+func (s *Server) takeSessionResult(id uuid.UUID, consume bool) (any, error) {
+	s.resultsMu.Lock()
+	defer s.resultsMu.Unlock()
+
+	item, ok := s.cache.Get(id.String())
+	if !ok {
+		return nil, errSessionNotFound
+	}
+
+	switch item.(type) {
+	case protocol.AuthorizationRequestMessage, protocol.ContractInvokeRequestMessage:
+		return nil, errSessionPending
+	case consumedResult:
+		return nil, errSessionConsumed
+	}
+
+	if consume {
+		consumed := consumedResult{ConsumedAt: time.Now().UTC()}
+		if verification, ok := item.(models.VerificationResponse); ok && verification.Jwz != "" {
+			consumed.JwzHash = sha256.Sum256([]byte(verification.Jwz))
+		}
+		s.cache.Set(id.String(), consumed, cache.DefaultExpiration)
+		s.logger.WithFields(log.Fields{"sessionID": id}).Info("session result consumed")
+		s.publishStatus(id)
+	}
+	return item, nil
+}
+
+// isCallbackRetry checks whether token is the token of the successful verification of the session result item,
+// so the callback can be acknowledged again without verifying the token
+func isCallbackRetry(item any, token string) bool {
+	switch value := item.(type) {
+	case models.VerificationResponse:
+		return value.Jwz == token
+	case consumedResult:
+		return value.JwzHash != [sha256.Size]byte{} && value.JwzHash == sha256.Sum256([]byte(token))
+	}
+	return false
+}
+
+// setSessionOwner stores the hash of the api key that created the session, so only its holders can consume the result
+func (s *Server) setSessionOwner(sessionID uuid.UUID, apiKey *string) {
+	if apiKey == nil || *apiKey == "" {
+		return
+	}
+	s.cache.Set(sessionOwnerKeyPrefix+sessionID.String(), sha256.Sum256([]byte(*apiKey)), cache.DefaultExpiration)
+}
+
+// canConsumeSession checks that apiKey is an admin key, a key of the tenant of the session or the key that created it.
+// Sessions created without an api key have no owner and can only be consumed with an admin key.
+func (s *Server) canConsumeSession(id uuid.UUID, apiKey string) bool {
+	if s.isAdmin(&apiKey) {
+		return true
+	}
+	if tenantID := s.getSessionTenant(id); tenantID != "" {
+		return tenantID == s.tenantID(&apiKey)
+	}
+	owner, ok := s.cache.Get(sessionOwnerKeyPrefix + id.String())
+	if !ok {
+		return false
+	}
+	hash, _ := owner.([sha256.Size]byte)
+	keyHash := sha256.Sum256([]byte(apiKey))
+	return subtle.ConstantTimeCompare(hash[:], keyHash[:]) == 1
+}
+
+// isSessionOwner checks that sessions created by a tenant are only read with one of its api keys
+func (s *Server) isSessionOwner(id uuid.UUID, apiKey *string) bool {
+	tenantID := s.getSessionTenant(id)
+	return tenantID == "" || tenantID == s.tenantID(apiKey)
+}
@@ -0,0 +1,295 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	common2 "github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-jwz/v2"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+
+	verrors "github.com/0xPolygonID/verifier-backend/internal/errors"
+	"github.com/0xPolygonID/verifier-backend/internal/events"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+	"github.com/0xPolygonID/verifier-backend/internal/webhook"
+)
+
+const (
+	statusStale   = "stale"
+	statusRevoked = "revoked"
+
+	reverificationKeyPrefix = "reverification-"
+)
+
+// WithStateResolvers sets the resolvers of the states of the networks, by blockchain:network, used to check again
+// the states of the successful verifications
+func WithStateResolvers(resolvers map[string]pubsignals.StateResolver) Option {
+	return func(s *Server) {
+		s.stateResolvers = resolvers
+	}
+}
+
+// reverification is the next check of the states of a successful verification
+type reverification struct {
+	Jwz string
+	Due time.Time
+	// OnChain is set for the verifications of the on-chain sessions, whose proofs are read again from their contract
+	OnChain *onChainReverification
+}
+
+// onChainReverification is the request of an on-chain session and the sender of its proofs
+type onChainReverification struct {
+	Request protocol.ContractInvokeRequestMessage
+	Sender  common2.Address
+}
+
+// invalidatedVerification replaces a successful verification whose proofs rely on a state that was replaced since
+type invalidatedVerification struct {
+	CheckedAt time.Time
+	Err       error
+	// Revoked is set when the issuer revoked credentials since the issuer state of a non-revocation proof
+	Revoked bool
+}
+
+func (v invalidatedVerification) status() string {
+	if v.Revoked {
+		return statusRevoked
+	}
+	return statusStale
+}
+
+// scheduleReverification checks again the states of the successful verification of the session after the configured delay
+func (s *Server) scheduleReverification(sessionID uuid.UUID, jwzToken string) {
+	if !s.cfg.Reverification.Enabled || s.isTestToken(jwzToken) {
+		return
+	}
+	due := time.Now().Add(s.cfg.Reverification.After.AsDuration())
+	s.cache.Set(reverificationKeyPrefix+sessionID.String(), reverification{Jwz: jwzToken, Due: due}, cache.DefaultExpiration)
+}
+
+// scheduleOnChainReverification reads again the proofs of the on-chain session submitted by sender after the
+// configured delay
+func (s *Server) scheduleOnChainReverification(sessionID uuid.UUID, request protocol.ContractInvokeRequestMessage,
+	sender common2.Address,
+) {
+	if !s.cfg.Reverification.Enabled {
+		return
+	}
+	due := time.Now().Add(s.cfg.Reverification.After.AsDuration())
+	next := reverification{Due: due, OnChain: &onChainReverification{Request: request, Sender: sender}}
+	s.cache.Set(reverificationKeyPrefix+sessionID.String(), next, cache.DefaultExpiration)
+}
+
+// RunReverification checks the due verifications every interval until ctx is done
+func (s *Server) RunReverification(ctx context.Context) {
+	interval := s.cfg.Reverification.Interval.AsDuration()
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.reverify(ctx, time.Now())
+		}
+	}
+}
+
+// reverify checks the states of the verifications due at now. The verifications that are still valid, or whose
+// states could not be resolved, are checked again after the interval. The results that were consumed or replaced
+// are not checked anymore.
+func (s *Server) reverify(ctx context.Context, now time.Time) {
+	for key, item := range s.cache.Items() {
+		if !strings.HasPrefix(key, reverificationKeyPrefix) {
+			continue
+		}
+		next, ok := item.Object.(reverification)
+		if !ok || now.Before(next.Due) {
+			continue
+		}
+		sessionID, err := uuid.Parse(strings.TrimPrefix(key, reverificationKeyPrefix))
+		if err != nil {
+			s.cache.Delete(key)
+			continue
+		}
+		item, _ := s.cache.Get(sessionID.String())
+		verification, ok := item.(models.VerificationResponse)
+		if !ok || verification.Jwz != next.Jwz {
+			s.cache.Delete(key)
+			continue
+		}
+
+		// provisional verifications are checked again after the interval
+		if !verification.Provisional {
+			if err := s.checkVerification(ctx, next, verification); err != nil {
+				switch verrors.Classify(err) {
+				case verrors.CodeRevokedCredential:
+					s.invalidateVerification(sessionID, next.Jwz, i18n.Wrap(err, i18n.CodeVerificationRevoked, err.Error()), true)
+					s.cache.Delete(key)
+					continue
+				case verrors.CodeExpiredState, verrors.CodeStateReverted:
+					s.invalidateVerification(sessionID, next.Jwz, i18n.Wrap(err, i18n.CodeVerificationStale, err.Error()), false)
+					s.cache.Delete(key)
+					continue
+				default:
+					s.logger.WithFields(log.Fields{"sessionID": sessionID, "err": err}).Warn("failed to check the states of a verification")
+				}
+			}
+		}
+		next.Due = now.Add(s.cfg.Reverification.Interval.AsDuration())
+		s.cache.Set(key, next, cache.DefaultExpiration)
+	}
+}
+
+// checkVerification checks again the successful verification: the states of the proofs of its token, or the proofs
+// of the on-chain session still recorded by its verifier contract
+func (s *Server) checkVerification(ctx context.Context, next reverification, verification models.VerificationResponse) error {
+	if next.OnChain == nil {
+		return s.checkStates(ctx, next.Jwz)
+	}
+	proved, complete, err := s.readOnChainProofs(ctx, next.OnChain.Request, next.OnChain.Sender, time.Time{})
+	if err != nil {
+		return err
+	}
+	if !complete || proved.UserDID != verification.UserDID {
+		return i18n.New(i18n.CodeOnChainProofsReplaced, next.OnChain.Sender.Hex())
+	}
+	return nil
+}
+
+// checkStates checks the issuer states of the proofs of jwzToken against the latest states of their networks,
+// accepting the states replaced for less than the accepted state transition delay, as the callbacks do. The replaced
+// states of non-revocation proofs are accepted as long as the issuer did not revoke credentials since.
+func (s *Server) checkStates(ctx context.Context, jwzToken string) error {
+	token, err := jwz.Parse(jwzToken)
+	if err != nil {
+		return err
+	}
+	var msg protocol.AuthorizationResponseMessage
+	if err := json.Unmarshal(token.GetPayload(), &msg); err != nil {
+		return err
+	}
+	for _, scope := range msg.Body.Scope {
+		verifier, err := pubsignals.GetVerifier(circuits.CircuitID(scope.CircuitID))
+		if err != nil {
+			return err
+		}
+		signals, err := json.Marshal(scope.PubSignals)
+		if err != nil {
+			return err
+		}
+		if err := verifier.PubSignalsUnmarshal(signals); err != nil {
+			return err
+		}
+		err = verifier.VerifyStates(ctx, s.stateResolvers, pubsignals.WithAcceptedStateTransitionDelay(stateTransitionDelay))
+		if errors.Is(err, pubsignals.ErrIssuerNonRevocationClaimStateIsNotValid) {
+			// a replaced issuer state alone does not revoke the credential
+			err = s.checkRevocations(ctx, scope)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRevocations checks that the issuer of the proof of scope did not revoke credentials since the issuer state of
+// its non-revocation proof. The revocation nonce of the credential is a private input of the proof, so any revocation
+// of the issuer since then may have revoked it.
+func (s *Server) checkRevocations(ctx context.Context, scope protocol.ZeroKnowledgeProofResponse) error {
+	output, err := getProofOutput(scope)
+	if err != nil {
+		return err
+	}
+	issuerID, _ := output["issuerID"].(*core.ID)
+	nonRevState, _ := output["issuerClaimNonRevState"].(*merkletree.Hash)
+	if issuerID == nil || nonRevState == nil {
+		return fmt.Errorf("the proof of scope %d has no non-revocation state", scope.ID)
+	}
+	issuerDID, err := core.ParseDIDFromID(*issuerID)
+	if err != nil {
+		return err
+	}
+	revoked, err := s.revocationChecker.RevokedSince(ctx, issuerDID, nonRevState)
+	if err != nil {
+		return fmt.Errorf("failed to check the revocations of issuer %s: %w", issuerDID, err)
+	}
+	if revoked {
+		return i18n.New(i18n.CodeCredentialsRevokedSince, issuerDID.String(), scope.ID)
+	}
+	return nil
+}
+
+// invalidateVerification replaces the successful verification of jwzToken with an invalidatedVerification, and
+// notifies it. Results that were consumed or replaced in the meantime are left untouched.
+func (s *Server) invalidateVerification(sessionID uuid.UUID, jwzToken string, err error, revoked bool) {
+	s.resultsMu.Lock()
+	item, _ := s.cache.Get(sessionID.String())
+	verification, ok := item.(models.VerificationResponse)
+	if !ok || verification.Jwz != jwzToken {
+		s.resultsMu.Unlock()
+		return
+	}
+	invalidated := invalidatedVerification{CheckedAt: time.Now().UTC(), Err: err, Revoked: revoked}
+	s.cache.Set(sessionID.String(), invalidated, cache.DefaultExpiration)
+	s.resultsMu.Unlock()
+
+	s.logger.WithFields(log.Fields{"sessionID": sessionID, "status": invalidated.status(), "err": err}).
+		Warn("verification invalidated by its re-verification")
+	s.publishStatus(sessionID)
+	s.emitInvalidation(sessionID, verification.UserDID, invalidated)
+	s.notifyInvalidation(sessionID, invalidated)
+}
+
+// emitInvalidation publishes the invalidation of the verification of the session
+func (s *Server) emitInvalidation(sessionID uuid.UUID, userDID string, invalidated invalidatedVerification) {
+	if s.events == nil {
+		return
+	}
+	event := events.Event{
+		Type:      events.TypeVerificationStale,
+		SessionID: sessionID.String(),
+		Tenant:    s.getSessionTenant(sessionID),
+		UserDID:   userDID,
+		ErrorCode: string(verrors.Classify(invalidated.Err)),
+		Error:     invalidated.Err.Error(),
+	}
+	if invalidated.Revoked {
+		event.Type = events.TypeVerificationRevoked
+	}
+	s.events.Emit(event)
+}
+
+// notifyInvalidation posts the invalidation of the verification of the session to the session webhook
+func (s *Server) notifyInvalidation(sessionID uuid.UUID, invalidated invalidatedVerification) {
+	sender := s.sessionWebhook(sessionID)
+	if sender == nil {
+		return
+	}
+	event := webhook.Event{
+		Type:      webhook.EventVerificationStale,
+		SessionID: sessionID.String(),
+		Status:    invalidated.status(),
+		Time:      invalidated.CheckedAt,
+		Metadata:  s.sessionMetadata(context.Background(), sessionID),
+	}
+	if invalidated.Revoked {
+		event.Type = webhook.EventVerificationRevoked
+	}
+	s.webhooks.Deliver(sender, s.sessionWebhookName(sessionID), event)
+}
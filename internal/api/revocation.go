@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/revocation"
+)
+
+// revocationChecker checks the revocation of the credentials, it is implemented by revocation.Checker
+type revocationChecker interface {
+	Check(ctx context.Context, issuerDID *w3c.DID, status verifiable.CredentialStatus) (*revocation.Result, error)
+	StatusFromNonce(revocationNonce uint64) (verifiable.CredentialStatus, error)
+	RevokedSince(ctx context.Context, issuerDID *w3c.DID, state *merkletree.Hash) (bool, error)
+}
+
+// CredentialRevocationStatus - check the revocation status of a credential
+func (s *Server) CredentialRevocationStatus(ctx context.Context, request CredentialRevocationStatusRequestObject) (CredentialRevocationStatusResponseObject, error) {
+	issuerDID, status, err := s.getRevocationStatusParams(request.Body)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Error("invalid revocation status request")
+		return CredentialRevocationStatus400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	result, err := s.revocationChecker.Check(ctx, issuerDID, status)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{
+			"issuerDID": issuerDID.String(),
+			"err":       err,
+		}).Error("failed to check revocation status")
+		if errors.Is(err, revocation.ErrUnsupportedStatusType) || errors.Is(err, revocation.ErrHostNotAllowed) {
+			return CredentialRevocationStatus400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		return CredentialRevocationStatus500JSONResponse{N500JSONResponse{Message: fmt.Sprintf("failed to check revocation status: %s", err.Error())}}, nil
+	}
+
+	return CredentialRevocationStatus200JSONResponse{
+		Revoked: result.Revoked,
+		Issuer:  result.Status.Issuer,
+		Mtp:     result.Status.MTP,
+	}, nil
+}
+
+func (s *Server) getRevocationStatusParams(body *CredentialRevocationStatusJSONRequestBody) (*w3c.DID, verifiable.CredentialStatus, error) {
+	if body == nil {
+		return nil, verifiable.CredentialStatus{}, errors.New("request body is empty")
+	}
+
+	issuer := body.IssuerDID
+	status := body.CredentialStatus
+	if body.Credential != nil {
+		credentialStatus, err := getCredentialStatus(body.Credential.CredentialStatus)
+		if err != nil {
+			return nil, verifiable.CredentialStatus{}, err
+		}
+		status = credentialStatus
+		issuer = &body.Credential.Issuer
+	}
+
+	if issuer == nil || *issuer == "" {
+		return nil, verifiable.CredentialStatus{}, errors.New("field issuerDID is empty")
+	}
+
+	issuerDID, err := w3c.ParseDID(*issuer)
+	if err != nil {
+		return nil, verifiable.CredentialStatus{}, fmt.Errorf("field issuerDID is not a valid DID: %w", err)
+	}
+
+	if status != nil {
+		return issuerDID, *status, nil
+	}
+
+	if body.RevocationNonce == nil {
+		return nil, verifiable.CredentialStatus{}, errors.New("either credentialStatus or revocationNonce must be provided")
+	}
+
+	rhsStatus, err := s.revocationChecker.StatusFromNonce(*body.RevocationNonce)
+	if err != nil {
+		return nil, verifiable.CredentialStatus{}, err
+	}
+	return issuerDID, rhsStatus, nil
+}
+
+func getCredentialStatus(credentialStatus any) (*verifiable.CredentialStatus, error) {
+	switch status := credentialStatus.(type) {
+	case verifiable.CredentialStatus:
+		return &status, nil
+	case *verifiable.CredentialStatus:
+		return status, nil
+	case map[string]any:
+		b, err := json.Marshal(status)
+		if err != nil {
+			return nil, err
+		}
+		var result verifiable.CredentialStatus
+		if err := json.Unmarshal(b, &result); err != nil {
+			return nil, err
+		}
+		return &result, nil
+	default:
+		return nil, errors.New("credential does not contain a valid credentialStatus")
+	}
+}
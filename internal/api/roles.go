@@ -0,0 +1,251 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+// role is the role of the caller of the management endpoints, a role is allowed what the lower ones are
+type role int
+
+const (
+	roleNone role = iota
+	roleReadOnly
+	roleOperator
+	roleAdmin
+)
+
+func (r role) String() string {
+	switch r {
+	case roleReadOnly:
+		return "read-only"
+	case roleOperator:
+		return "operator"
+	case roleAdmin:
+		return "admin"
+	default:
+		return "none"
+	}
+}
+
+// usersPath is the path of the endpoints of the user registry, which are management endpoints too
+const usersPath = "/users"
+
+// managementRoute is a management endpoint, by its method and its route pattern, and the role it requires
+type managementRoute struct {
+	method  string
+	pattern string
+	role    role
+}
+
+// managementRoutes are the management endpoints with the role each of them requires. The requests of the management
+// endpoints missing here require the admin role, and TestManagementRoutes fails for them.
+var managementRoutes = []managementRoute{
+	{http.MethodGet, "/admin/issuer-policy", roleReadOnly},
+	{http.MethodPut, "/admin/issuer-policy/{credentialType}", roleAdmin},
+	{http.MethodDelete, "/admin/issuer-policy/{credentialType}", roleAdmin},
+	{http.MethodGet, "/admin/shadow-verification", roleReadOnly},
+	{http.MethodGet, "/admin/verification-timings", roleReadOnly},
+	{http.MethodGet, "/admin/webhooks/dead-letters", roleReadOnly},
+	{http.MethodPost, "/admin/webhooks/dead-letters/{deliveryID}/replay", roleAdmin},
+	{http.MethodGet, "/admin/sli", roleReadOnly},
+	{http.MethodGet, "/admin/sessions", roleReadOnly},
+	{http.MethodGet, "/admin/tags/stats", roleReadOnly},
+	{http.MethodGet, "/admin/stats", roleReadOnly},
+	{http.MethodGet, "/admin/circuits", roleReadOnly},
+	{http.MethodPost, "/admin/circuits/reload", roleAdmin},
+	{http.MethodGet, "/admin/keys", roleReadOnly},
+	{http.MethodPost, "/admin/keys/rotate", roleAdmin},
+	{http.MethodGet, "/admin/schema-allowlist", roleReadOnly},
+	{http.MethodPut, "/admin/schema-allowlist", roleAdmin},
+	{http.MethodGet, "/admin/schemas", roleReadOnly},
+	{http.MethodPost, "/admin/schemas/prewarm", roleOperator},
+	{http.MethodGet, "/admin/query-templates", roleReadOnly},
+	{http.MethodGet, "/admin/query-templates/{templateName}", roleReadOnly},
+	{http.MethodPut, "/admin/query-templates/{templateName}", roleAdmin},
+	{http.MethodDelete, "/admin/query-templates/{templateName}", roleAdmin},
+	{http.MethodGet, "/admin/reason-templates", roleReadOnly},
+	{http.MethodPut, "/admin/reason-templates/{templateName}", roleAdmin},
+	{http.MethodDelete, "/admin/reason-templates/{templateName}", roleAdmin},
+	{http.MethodGet, "/admin/audit/export", roleAdmin},
+	{http.MethodGet, usersPath, roleAdmin},
+	{http.MethodGet, usersPath + "/{did}", roleAdmin},
+}
+
+type roleCtxKey struct{}
+
+var (
+	errBearerTokensDisabled = errors.New("no oidc client is allowed to grant roles")
+	errBearerTokenAudience  = errors.New("the token was not issued to a client allowed to grant roles")
+)
+
+// withRole returns a copy of ctx with the role of the bearer token of the request
+func withRole(ctx context.Context, r role) context.Context {
+	return context.WithValue(ctx, roleCtxKey{}, r)
+}
+
+// isManagementPath returns whether path is a management endpoint, an /admin one or one of the user registry
+func isManagementPath(path string) bool {
+	return strings.HasPrefix(path, managementPathPrefix) || path == usersPath || strings.HasPrefix(path, usersPath+"/")
+}
+
+// requiredRole returns the role required by a management request, the admin role for the requests of the endpoints
+// that are not classified
+func requiredRole(method, path string) role {
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	for _, route := range managementRoutes {
+		if method == route.method && matchRoute(route.pattern, path) {
+			return route.role
+		}
+	}
+	return roleAdmin
+}
+
+// matchRoute returns whether path matches the route pattern, whose {param} segments match any segment
+func matchRoute(pattern, path string) bool {
+	patternSegments, pathSegments := strings.Split(pattern, "/"), strings.Split(path, "/")
+	if len(patternSegments) != len(pathSegments) {
+		return false
+	}
+	for i, segment := range patternSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if pathSegments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if segment != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Roles enforces the roles of the management endpoints, the /admin ones and the user registry: the caller needs the
+// role of the request, granted to its X-API-Key or to the DID of its bearer token. The role of the bearer token is kept
+// in the context of the request for the handlers.
+func (s *Server) Roles(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isManagementPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx := r.Context()
+		if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			tokenRole, err := s.bearerRole(token)
+			if err != nil {
+				s.log(ctx).WithField("err", err).Warn("invalid bearer token")
+				writeError(w, http.StatusUnauthorized, i18n.Message(ctx, i18n.CodeBearerTokenInvalid))
+				return
+			}
+			ctx = withRole(ctx, tokenRole)
+			r = r.WithContext(ctx)
+		}
+
+		apiKey := r.Header.Get("X-API-Key")
+		required, got := requiredRole(r.Method, r.URL.Path), s.role(ctx, &apiKey)
+		switch {
+		case got == roleNone:
+			writeError(w, http.StatusUnauthorized, i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired))
+		case got < required:
+			writeError(w, http.StatusForbidden, i18n.Message(ctx, i18n.CodeRoleRequired, required))
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// hasRole returns whether the caller has the role r, through its api key or the bearer token of the request
+func (s *Server) hasRole(ctx context.Context, apiKey *string, r role) bool {
+	return s.role(ctx, apiKey) >= r
+}
+
+// role returns the highest role of the caller, granted to its api key or to the bearer token of the request
+func (s *Server) role(ctx context.Context, apiKey *string) role {
+	got, _ := ctx.Value(roleCtxKey{}).(role)
+	if apiKey == nil || *apiKey == "" {
+		return got
+	}
+	for _, grant := range []struct {
+		keys []string
+		role role
+	}{
+		{s.cfg.AdminAPIKeys, roleAdmin},
+		{s.cfg.Roles.OperatorAPIKeys, roleOperator},
+		{s.cfg.Roles.ReadOnlyAPIKeys, roleReadOnly},
+	} {
+		if grant.role > got && containsKey(grant.keys, *apiKey) {
+			got = grant.role
+		}
+	}
+	return got
+}
+
+// bearerRole returns the role of the DID of an ID token issued by the OpenID Connect provider of the verifier to one of
+// the clients allowed by the roles
+func (s *Server) bearerRole(token string) (role, error) {
+	if s.keys == nil || len(s.cfg.Roles.OIDCClients) == 0 {
+		return roleNone, errBearerTokensDisabled
+	}
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return roleNone, err
+	}
+	jwks, err := s.keys.JWKS("")
+	if err != nil {
+		return roleNone, err
+	}
+	var claims jwt.Claims
+	if err := parsed.Claims(jwks, &claims); err != nil {
+		return roleNone, err
+	}
+	if err := claims.Validate(jwt.Expected{
+		Issuer: strings.TrimSuffix(s.cfg.Host, "/") + "/oidc",
+		Time:   time.Now(),
+	}); err != nil {
+		return roleNone, err
+	}
+	audience := false
+	for _, client := range s.cfg.Roles.OIDCClients {
+		audience = audience || claims.Audience.Contains(client)
+	}
+	if !audience {
+		return roleNone, errBearerTokenAudience
+	}
+
+	for _, grant := range []struct {
+		dids []string
+		role role
+	}{
+		{s.cfg.Roles.AdminDIDs, roleAdmin},
+		{s.cfg.Roles.OperatorDIDs, roleOperator},
+		{s.cfg.Roles.ReadOnlyDIDs, roleReadOnly},
+	} {
+		for _, did := range grant.dids {
+			if did == claims.Subject {
+				return grant.role, nil
+			}
+		}
+	}
+	return roleNone, nil
+}
+
+// containsKey returns whether key is one of keys, in constant time for each of them
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+const sandboxVerificationSubject = "Your verifier sandbox verification code"
+
+type clientAddrKey struct{}
+
+// ClientAddr stores the address of the client in the context of the request, so the sandbox keys can be rate limited by address
+func ClientAddr(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addr, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			addr = r.RemoteAddr
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientAddrKey{}, addr)))
+	})
+}
+
+func clientAddr(ctx context.Context) string {
+	addr, _ := ctx.Value(clientAddrKey{}).(string)
+	return addr
+}
+
+func newSandboxKeyStore(cfg config.Sandbox, c qrCache) *APIKeyStore {
+	return NewAPIKeyStore(c, cfg.MaxVerificationAttempts, cfg.RateLimit, cfg.RateWindow.AsDuration())
+}
+
+// CreateSandboxKey - create a sandbox API key
+func (s *Server) CreateSandboxKey(ctx context.Context, request CreateSandboxKeyRequestObject) (CreateSandboxKeyResponseObject, error) {
+	if !s.cfg.Sandbox.Enabled {
+		return CreateSandboxKey404JSONResponse{N404JSONResponse{Message: "sandbox keys are not enabled"}}, nil
+	}
+
+	email, err := parseEmail(request.Body.Email)
+	if err != nil {
+		return CreateSandboxKey400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	allowEmail := s.apiKeys.Allow("email:" + strings.ToLower(email))
+	if addr := clientAddr(ctx); !allowEmail || addr != "" && !s.apiKeys.Allow("addr:"+addr) {
+		s.log(ctx).WithFields(log.Fields{"email": email, "addr": clientAddr(ctx)}).Warn("sandbox key requests rate limited")
+		return CreateSandboxKey429JSONResponse{N429JSONResponse{Message: i18n.Message(ctx, i18n.CodeSandboxRateLimited)}}, nil
+	}
+
+	if s.cfg.Sandbox.EmailVerification {
+		code, err := s.apiKeys.NewVerificationCode(email)
+		if err != nil {
+			return CreateSandboxKey500JSONResponse{N500JSONResponse{Message: fmt.Sprintf("failed to create verification code: %s", err.Error())}}, nil
+		}
+		if err := s.mailer.Send(email, sandboxVerificationSubject, fmt.Sprintf("Your verification code is %s", code)); err != nil {
+			s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to send verification email")
+			return CreateSandboxKey500JSONResponse{N500JSONResponse{Message: "failed to send verification email"}}, nil
+		}
+		return CreateSandboxKey202JSONResponse{Message: fmt.Sprintf("verification code sent to %s", email)}, nil
+	}
+
+	key, err := s.apiKeys.Mint(email, s.cfg.Sandbox.ChainIDs, s.cfg.Sandbox.KeyTTL.AsDuration())
+	if err != nil {
+		return CreateSandboxKey500JSONResponse{N500JSONResponse{Message: fmt.Sprintf("failed to create sandbox key: %s", err.Error())}}, nil
+	}
+	s.log(ctx).WithFields(log.Fields{"email": email, "expiresAt": key.ExpiresAt}).Info("sandbox key created")
+
+	return CreateSandboxKey201JSONResponse(toSandboxKeyResponse(key)), nil
+}
+
+// VerifySandboxKey - verify the email and create a sandbox API key
+func (s *Server) VerifySandboxKey(ctx context.Context, request VerifySandboxKeyRequestObject) (VerifySandboxKeyResponseObject, error) {
+	if !s.cfg.Sandbox.Enabled || !s.cfg.Sandbox.EmailVerification {
+		return VerifySandboxKey404JSONResponse{N404JSONResponse{Message: "sandbox email verification is not enabled"}}, nil
+	}
+
+	email, err := parseEmail(request.Body.Email)
+	if err != nil {
+		return VerifySandboxKey400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	if err := s.apiKeys.CheckVerificationCode(email, request.Body.Code); err != nil {
+		s.log(ctx).WithFields(log.Fields{"email": email, "err": err}).Warn("invalid sandbox verification code")
+		if errors.Is(err, errVerificationCodeLocked) {
+			return VerifySandboxKey429JSONResponse{N429JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+		}
+		if errors.Is(err, errVerificationCodeInvalid) {
+			return VerifySandboxKey400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+		}
+		return VerifySandboxKey500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	key, err := s.apiKeys.Mint(email, s.cfg.Sandbox.ChainIDs, s.cfg.Sandbox.KeyTTL.AsDuration())
+	if err != nil {
+		return VerifySandboxKey500JSONResponse{N500JSONResponse{Message: fmt.Sprintf("failed to create sandbox key: %s", err.Error())}}, nil
+	}
+	s.log(ctx).WithFields(log.Fields{"email": email, "expiresAt": key.ExpiresAt}).Info("sandbox key created")
+
+	return VerifySandboxKey201JSONResponse(toSandboxKeyResponse(key)), nil
+}
+
+// authorizeSignIn checks that the api key sent in the request can be used to create requests on the chain.
+// Production and tenant keys can be used on every chain, sandbox keys only on the configured sandbox chains.
+// When no production or tenant keys are configured, requests without api key are accepted.
+func (s *Server) authorizeSignIn(ctx context.Context, request SignInRequestObject) (SignInResponseObject, bool) {
+	if request.Params.XAPIKey == nil || *request.Params.XAPIKey == "" {
+		if len(s.cfg.APIKeys) == 0 && len(s.cfg.Tenants) == 0 {
+			return nil, true
+		}
+		return SignIn401JSONResponse{N401JSONResponse{Message: i18n.Localize(ctx, errAPIKeyRequired)}}, false
+	}
+
+	apiKey := *request.Params.XAPIKey
+	for _, key := range s.cfg.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+			return nil, true
+		}
+	}
+	if s.tenantID(request.Params.XAPIKey) != "" {
+		return nil, true
+	}
+
+	sandboxKey, err := s.apiKeys.Get(apiKey)
+	if err != nil {
+		return SignIn401JSONResponse{N401JSONResponse{Message: i18n.Localize(ctx, err)}}, false
+	}
+
+	chainID := getRequestChainID(request)
+	if !sandboxKey.AllowsChain(chainID) {
+		s.log(ctx).WithFields(log.Fields{"email": sandboxKey.Email, "chainID": chainID}).Warn("sandbox key used on a restricted chain")
+		return SignIn403JSONResponse{N403JSONResponse{Message: i18n.Message(ctx, i18n.CodeSandboxChainNotAllowed, chainID)}}, false
+	}
+
+	return nil, true
+}
+
+func getRequestChainID(request SignInRequestObject) string {
+	if request.Body.TransactionData != nil {
+		return strconv.Itoa(request.Body.TransactionData.ChainID)
+	}
+	if request.Body.ChainID != nil {
+		return *request.Body.ChainID
+	}
+	return ""
+}
+
+func toSandboxKeyResponse(key SandboxAPIKey) SandboxKey {
+	return SandboxKey{
+		ApiKey:    key.Key,
+		ChainIDs:  key.ChainIDs,
+		ExpiresAt: key.ExpiresAt,
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+// prewarmConcurrency limits the documents fetched at the same time by a prewarm
+const prewarmConcurrency = 4
+
+// documentPinner fetches a JSON-LD document and keeps it in memory
+type documentPinner interface {
+	Pin(u string) error
+}
+
+// WithDocumentPinner sets the document loader of the verifier, so the JSON-LD contexts of the queries can be prewarmed
+func WithDocumentPinner(p documentPinner) Option {
+	return func(s *Server) {
+		s.documents = p
+	}
+}
+
+// ListSchemas - list the pinned JSON-LD documents
+func (s *Server) ListSchemas(ctx context.Context, request ListSchemasRequestObject) (ListSchemasResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return ListSchemas401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	return ListSchemas200JSONResponse(s.schemaStatuses()), nil
+}
+
+// PrewarmSchemas - fetch and pin the JSON-LD documents of the query templates
+func (s *Server) PrewarmSchemas(ctx context.Context, request PrewarmSchemasRequestObject) (PrewarmSchemasResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleOperator) {
+		return PrewarmSchemas401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	if s.documents == nil {
+		return PrewarmSchemas500JSONResponse{N500JSONResponse{Message: "the document loader does not pin documents"}}, nil
+	}
+	var urls []string
+	if request.Body != nil && request.Body.Urls != nil {
+		urls = *request.Body.Urls
+	}
+	return PrewarmSchemas200JSONResponse(s.Prewarm(ctx, urls...)), nil
+}
+
+// Prewarm fetches and pins the JSON-LD contexts referenced by the query templates and the trust profiles,
+// the configured ones and urls, and returns the status of each of them
+func (s *Server) Prewarm(ctx context.Context, urls ...string) []SchemaStatus {
+	if s.documents == nil {
+		return nil
+	}
+	documents := s.prewarmDocuments(urls)
+
+	statuses := make([]SchemaStatus, 0, len(documents))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, prewarmConcurrency)
+	for u, templates := range documents {
+		wg.Add(1)
+		go func(u string, templates []string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			status := s.pin(u, templates)
+			mu.Lock()
+			statuses = append(statuses, status)
+			mu.Unlock()
+		}(u, templates)
+	}
+	wg.Wait()
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Url < statuses[j].Url })
+
+	s.schemasMu.Lock()
+	for _, status := range statuses {
+		s.schemas[status.Url] = status
+	}
+	s.schemasMu.Unlock()
+
+	var failed int
+	for _, status := range statuses {
+		if status.Status == SchemaFailed {
+			failed++
+			s.log(ctx).WithFields(log.Fields{"url": status.Url, "err": *status.Error}).Warn("failed to pin json-ld document")
+		}
+	}
+	s.log(ctx).WithFields(log.Fields{"documents": len(statuses), "failed": failed}).Info("json-ld documents prewarmed")
+	return statuses
+}
+
+// prewarmDocuments returns the documents to pin with the query templates that reference them
+func (s *Server) prewarmDocuments(urls []string) map[string][]string {
+	documents := make(map[string][]string)
+	for _, template := range s.queryTemplates.List() {
+		if u, ok := template.Query["context"].(string); ok && u != "" {
+			documents[u] = append(documents[u], template.Name)
+		}
+	}
+	for _, profile := range s.cfg.TrustProfiles {
+		for _, schema := range profile.Schemas {
+			if _, ok := documents[schema.Context]; !ok && schema.Context != "" {
+				documents[schema.Context] = nil
+			}
+		}
+	}
+	for _, u := range append(s.cfg.DocumentCache.Pinned, urls...) {
+		if _, ok := documents[u]; !ok && u != "" {
+			documents[u] = nil
+		}
+	}
+	return documents
+}
+
+func (s *Server) pin(u string, templates []string) SchemaStatus {
+	start := time.Now()
+	err := s.documents.Pin(u)
+	status := SchemaStatus{
+		Url:        u,
+		Status:     SchemaPinned,
+		CheckedAt:  start.UTC(),
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if len(templates) > 0 {
+		status.Templates = &templates
+	}
+	if err != nil {
+		status.Status = SchemaFailed
+		status.Error = common.ToPointer(err.Error())
+	}
+	return status
+}
+
+func (s *Server) schemaStatuses() []SchemaStatus {
+	s.schemasMu.Lock()
+	defer s.schemasMu.Unlock()
+	statuses := make([]SchemaStatus, 0, len(s.schemas))
+	for _, status := range s.schemas {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Url < statuses[j].Url })
+	return statuses
+}
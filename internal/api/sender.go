@@ -0,0 +1,35 @@
+package api
+
+import (
+	"strconv"
+
+	common2 "github.com/ethereum/go-ethereum/common"
+	core "github.com/iden3/go-iden3-core/v2"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+// deriveSenderDID builds the DID of the configured ethereum address on the network of the chain.
+func deriveSenderDID(chainID string, cfg config.SenderDID) (string, error) {
+	id, err := strconv.Atoi(chainID)
+	if err != nil {
+		return "", err
+	}
+
+	blockchain, network, err := core.NetworkByChainID(core.ChainID(id))
+	if err != nil {
+		return "", err
+	}
+
+	typ, err := core.BuildDIDType(core.DIDMethod(cfg.DIDMethod), blockchain, network)
+	if err != nil {
+		return "", err
+	}
+
+	did, err := core.NewDID(typ, core.GenesisFromEthAddress(common2.HexToAddress(cfg.EthAddress)))
+	if err != nil {
+		return "", err
+	}
+
+	return did.String(), nil
+}
@@ -5,28 +5,54 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math/big"
 	"net/http"
 	"os"
 	"strconv"
+	"sync"
 	"time"
 
-	common2 "github.com/ethereum/go-ethereum/common"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/iden3/go-circuits/v2"
-	auth "github.com/iden3/go-iden3-auth/v2"
 	"github.com/iden3/go-iden3-auth/v2/pubsignals"
-	core "github.com/iden3/go-iden3-core/v2"
-	"github.com/iden3/go-iden3-core/v2/w3c"
 	"github.com/iden3/go-jwz/v2"
 	"github.com/iden3/iden3comm/v2/protocol"
 	"github.com/patrickmn/go-cache"
+	"github.com/piprate/json-gold/ld"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
 
+	"github.com/0xPolygonID/verifier-backend/internal/audit"
+	"github.com/0xPolygonID/verifier-backend/internal/circuitkeys"
 	"github.com/0xPolygonID/verifier-backend/internal/common"
 	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/confirmations"
+	verrors "github.com/0xPolygonID/verifier-backend/internal/errors"
+	"github.com/0xPolygonID/verifier-backend/internal/events"
+	"github.com/0xPolygonID/verifier-backend/internal/hooks"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/issuernode"
+	"github.com/0xPolygonID/verifier-backend/internal/keystore"
+	"github.com/0xPolygonID/verifier-backend/internal/lanes"
+	"github.com/0xPolygonID/verifier-backend/internal/logging"
+	"github.com/0xPolygonID/verifier-backend/internal/mail"
+	"github.com/0xPolygonID/verifier-backend/internal/memstore"
+	"github.com/0xPolygonID/verifier-backend/internal/messages"
 	"github.com/0xPolygonID/verifier-backend/internal/models"
+	"github.com/0xPolygonID/verifier-backend/internal/nullifier"
+	"github.com/0xPolygonID/verifier-backend/internal/onchain"
+	"github.com/0xPolygonID/verifier-backend/internal/policy"
+	"github.com/0xPolygonID/verifier-backend/internal/revocation"
+	"github.com/0xPolygonID/verifier-backend/internal/sessions"
+	"github.com/0xPolygonID/verifier-backend/internal/shadow"
+	"github.com/0xPolygonID/verifier-backend/internal/signing"
+	"github.com/0xPolygonID/verifier-backend/internal/sli"
+	"github.com/0xPolygonID/verifier-backend/internal/stateresolver"
+	"github.com/0xPolygonID/verifier-backend/internal/stats"
+	"github.com/0xPolygonID/verifier-backend/internal/timing"
+	"github.com/0xPolygonID/verifier-backend/internal/users"
+	"github.com/0xPolygonID/verifier-backend/internal/webhook"
+	"github.com/0xPolygonID/verifier-backend/pkg/verifier"
 )
 
 const (
@@ -36,27 +62,254 @@ const (
 	statusError          = "error"
 	defaultReason        = "for testing purposes"
 	defaultBigIntBase    = 10
+
+	iden3commRequestURIPrefix = "iden3comm://?request_uri="
 )
 
 // Server represents the API server
 type Server struct {
 	cfg        config.Config
 	qrStore    *QRcodeStore
-	cache      *cache.Cache
-	verifier   *auth.Verifier
+	shortener  urlShortener
+	tags       *sessionTags
+	cache      *memstore.Store
+	pending    *cache.Cache
+	ledger     sessions.Ledger
+	users      users.Registry
+	audit      audit.Log
+	webhook    *webhook.Sender
+	webhooks   *webhook.Dispatcher
+	events     *events.Bus
+	verifier   Verifier
 	senderDIDs map[string]string
+
+	revocationChecker revocationChecker
+	apiKeys           *APIKeyStore
+	linkRates         *rateLimiter
+	widgetRates       *rateLimiter
+	mailer            *mail.Sender
+	issuerPolicy      *policy.IssuerPolicy
+	schemaAllowlist   *policy.SchemaAllowlist
+	shadowVerifier    *shadow.Verifier
+	queryTemplates    *QueryTemplateStore
+	reasonTemplates   *ReasonTemplateStore
+	nullifiers        *nullifier.Registry
+	nullifierStore    nullifier.Store
+	campaignSessions  campaignDerivation
+	keys              *signing.KeyRing
+	keystore          *keystore.Keystore
+	timings           *timing.Stats
+	sli               *sli.Tracker
+	stats             *stats.Tracker
+	trustProfiles     map[string]config.TrustProfile
+	flows             map[string]config.Flow
+	flowWebhooks      map[string]*webhook.Sender
+	deadLetters       webhook.DeadLetters
+	deliveriesMu      sync.Mutex
+	timelineMu        sync.Mutex
+	statusCache       qrCache
+	logger            *log.Logger
+	circuitKeys       *circuitkeys.Loader
+	lanes             *lanes.Limiter
+	verifications     chan queuedVerification
+	queuedMu          sync.Mutex
+	queued            map[uuid.UUID]struct{}
+	documents         documentPinner
+	verificationHooks []hooks.Hook
+	queryLoader       ld.DocumentLoader
+	builderLoader     ld.DocumentLoader
+	stateResolvers    map[string]pubsignals.StateResolver
+	onChain           onchain.Reader
+	schemasMu         sync.Mutex
+	schemas           map[string]SchemaStatus
+	resultsMu         sync.Mutex
+	issuerNode        *issuernode.Client
+	credentialOffers  singleflight.Group
+}
+
+// Verifier verifies the tokens of the callbacks against the authorization requests of their sessions
+type Verifier interface {
+	FullVerify(ctx context.Context, token string, request protocol.AuthorizationRequestMessage,
+		opts ...pubsignals.VerifyOpt) (*protocol.AuthorizationResponseMessage, error)
+}
+
+// Option configures optional Server dependencies
+type Option func(*Server)
+
+// WithIssuerPolicy sets the trusted issuers policy enforced on sign-in and callback
+func WithIssuerPolicy(p *policy.IssuerPolicy) Option {
+	return func(s *Server) {
+		s.issuerPolicy = p
+	}
+}
+
+// WithShadowVerifier sets a second verifier that runs every callback to compare its results with the primary verifier
+func WithShadowVerifier(v *shadow.Verifier) Option {
+	return func(s *Server) {
+		s.shadowVerifier = v
+	}
+}
+
+// WithNetworkRPCs sets the clients of the RPC urls of the networks, by blockchain:network, so the revocation checks
+// and the on-chain sign-ins read the networks with the retries and the breakers of the state resolutions
+func WithNetworkRPCs(rpcs map[string]*stateresolver.Resolver) Option {
+	return func(s *Server) {
+		s.revocationChecker = revocation.NewChecker(s.cfg.ResolverSettings, s.cfg.RHSURL, s.cfg.RevocationAllowedHosts,
+			revocation.WithRPCs(rpcs))
+		s.onChain = onchain.NewETHReader(s.cfg.ResolverSettings, rpcs)
+	}
+}
+
+// WithQueryTemplates sets the store of the query templates
+func WithQueryTemplates(store *QueryTemplateStore) Option {
+	return func(s *Server) {
+		s.queryTemplates = store
+	}
+}
+
+// WithNullifierRegistry sets the registry where the nullifiers of the verified proofs are stored
+func WithNullifierRegistry(r *nullifier.Registry) Option {
+	return func(s *Server) {
+		s.nullifiers = r
+	}
+}
+
+// WithNullifierStore sets the store of the nullifiers used in the sessions that enforce unique nullifiers
+func WithNullifierStore(store nullifier.Store) Option {
+	return func(s *Server) {
+		s.nullifierStore = store
+	}
+}
+
+// WithKeyRing sets the signing keys of the verifier and its tenants
+func WithKeyRing(keys *signing.KeyRing) Option {
+	return func(s *Server) {
+		s.keys = keys
+	}
+}
+
+// WithAPIKeyCache stores the sandbox keys and the rate limits in c instead of an in-memory cache, so they are shared by the replicas
+func WithAPIKeyCache(c qrCache) Option {
+	return func(s *Server) {
+		s.apiKeys = newSandboxKeyStore(s.cfg.Sandbox, c)
+		s.linkRates = newSignInLinkRates(s.cfg.SignInLink, c)
+		s.widgetRates = newWidgetRates(s.cfg.Widget, c)
+	}
+}
+
+// WithQRCache stores the QR codes in c instead of the in-memory cache of the sessions, the QR codes that are not found
+// are read again for the read retry window of the QR store
+func WithQRCache(c qrCache) Option {
+	return func(s *Server) {
+		s.qrStore = NewQRCodeStore(c, s.qrStore.secret)
+		s.qrStore.readRetry = s.cfg.QRStore.ReadRetryWindow.AsDuration()
+	}
+}
+
+// WithURLShortener shortens the links to the QR codes with sh
+func WithURLShortener(sh urlShortener) Option {
+	return func(s *Server) {
+		s.shortener = sh
+	}
+}
+
+// WithSessionLedger stores the lifetime of the sessions in l, so the sessions removed from the cache are reported as expired
+func WithSessionLedger(l sessions.Ledger) Option {
+	return func(s *Server) {
+		s.ledger = l
+	}
+}
+
+// WithVerificationHooks runs h in order on the verified callbacks, any of them can reject the verification
+func WithVerificationHooks(h ...hooks.Hook) Option {
+	return func(s *Server) {
+		s.verificationHooks = append(s.verificationHooks, h...)
+	}
+}
+
+// WithLogger sets the logger of the logs that are not scoped to a request
+func WithLogger(logger *log.Logger) Option {
+	return func(s *Server) {
+		s.logger = logger
+	}
 }
 
 // New creates a new API server
-func New(cfg config.Config, verifier *auth.Verifier, senderDIDs map[string]string) *Server {
-	c := cache.New(cfg.CacheExpiration.AsDuration(), cfg.CacheExpiration.AsDuration())
-	return &Server{
+func New(cfg config.Config, verifier Verifier, senderDIDs map[string]string, opts ...Option) *Server {
+	c := memstore.New(cfg.CacheExpiration.AsDuration(), cfg.CacheExpiration.AsDuration(), cfg.SessionStore.MaxEntries)
+	issuerPolicy, _ := policy.NewIssuerPolicy(config.IssuerPolicy{})
+	schemaAllowlist, _ := policy.NewSchemaAllowlist(config.SchemaAllowlist{})
+	keys, _ := signing.NewKeyRing(config.Config{})
+	ks, _ := keystore.New(context.Background(), config.Config{})
+	s := &Server{
 		cfg:        cfg,
-		qrStore:    NewQRCodeStore(c),
+		qrStore:    NewQRCodeStore(c, qrSecret(cfg.QRLink)),
+		tags:       newSessionTags(cfg.CacheExpiration.AsDuration()),
 		cache:      c,
+		ledger:     sessions.NewMemoryLedger(),
 		verifier:   verifier,
 		senderDIDs: senderDIDs,
+
+		revocationChecker: revocation.NewChecker(cfg.ResolverSettings, cfg.RHSURL, cfg.RevocationAllowedHosts),
+		apiKeys:           newSandboxKeyStore(cfg.Sandbox, cache.New(cache.NoExpiration, cfg.CacheExpiration.AsDuration())),
+		linkRates:         newSignInLinkRates(cfg.SignInLink, cache.New(cache.NoExpiration, time.Minute)),
+		widgetRates:       newWidgetRates(cfg.Widget, cache.New(cache.NoExpiration, time.Minute)),
+		mailer:            mail.NewSender(cfg.SMTP),
+		issuerPolicy:      issuerPolicy,
+		schemaAllowlist:   schemaAllowlist,
+		queryTemplates:    NewQueryTemplateStore(),
+		reasonTemplates:   NewReasonTemplateStore(cfg.ReasonTemplates),
+		nullifierStore:    nullifier.NewMemoryStore(),
+		campaignSessions:  newCampaignDerivation(cfg.Nullifiers.Derivation),
+		keys:              keys,
+		keystore:          ks,
+		timings:           timing.NewStats(),
+		sli:               sli.NewTracker(),
+		stats:             stats.NewTracker(nil),
+		trustProfiles:     make(map[string]config.TrustProfile, len(cfg.TrustProfiles)),
+		flows:             make(map[string]config.Flow, len(cfg.Flows)),
+		flowWebhooks:      make(map[string]*webhook.Sender),
+		deadLetters:       webhook.NewMemoryDeadLetters(cfg.SessionWebhook.DeadLetterSize),
+		logger:            log.StandardLogger(),
+		circuitKeys:       circuitkeys.NewLoader(circuitkeys.FSSource{Dir: cfg.KeyDIR}, "", nil),
+		schemas:           make(map[string]SchemaStatus),
+		onChain:           onchain.NewETHReader(cfg.ResolverSettings, nil),
+		issuerNode:        issuernode.New(issuerNodeTimeout),
 	}
+	for _, profile := range cfg.TrustProfiles {
+		s.trustProfiles[profile.Name] = profile
+	}
+	for _, flow := range cfg.Flows {
+		s.flows[flow.Name] = flow
+		if flow.Webhook.URL != "" {
+			s.flowWebhooks[flow.Name] = webhook.NewSender(flow.Webhook.URL, flow.Webhook.Secret, cfg.SessionWebhook.Timeout.AsDuration())
+		}
+	}
+	if cfg.VerificationConcurrency > 0 {
+		s.lanes = lanes.NewLimiter(cfg.VerificationConcurrency, cfg.VerificationQueueSize)
+		s.verifications = make(chan queuedVerification, cfg.VerificationConcurrency)
+		s.queued = make(map[uuid.UUID]struct{})
+	}
+	if ttl := cfg.SessionTTL.AsDuration(); ttl > 0 && !cfg.ReadOnly {
+		s.pending = s.newPendingSessions(ttl)
+	}
+	if cfg.SessionWebhook.URL != "" {
+		s.webhook = webhook.NewSender(cfg.SessionWebhook.URL, cfg.SessionWebhook.Secret, cfg.SessionWebhook.Timeout.AsDuration())
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.webhooks = webhook.NewDispatcher(webhook.Retry{
+		Attempts:   cfg.SessionWebhook.MaxAttempts,
+		Backoff:    cfg.SessionWebhook.Backoff.AsDuration(),
+		MaxBackoff: cfg.SessionWebhook.MaxBackoff.AsDuration(),
+	}, s.deadLetters, s.recordDelivery)
+	return s
+}
+
+// log returns the logger of the request, with its request id and session id
+func (s *Server) log(ctx context.Context) *log.Entry {
+	return logging.FromContext(ctx, s.logger)
 }
 
 // RegisterStatic add method to the mux that are not documented in the API.
@@ -66,6 +319,14 @@ func RegisterStatic(mux *chi.Mux) {
 	mux.Get("/favicon.ico", favicon)
 }
 
+// RegisterRoutes adds to the mux the endpoints of the server that are not documented in the API
+func (s *Server) RegisterRoutes(mux *chi.Mux) {
+	mux.Get("/metrics", s.Metrics)
+	mux.Get("/r/{id}", s.Interstitial)
+	mux.Get("/verify/{flowName}", s.Widget)
+	mux.Get("/admin/audit/export", s.ExportAudit)
+}
+
 // Health is a method
 func (s *Server) Health(_ context.Context, _ HealthRequestObject) (HealthResponseObject, error) {
 	var resp Health200JSONResponse = Health{"healthy": true}
@@ -81,42 +342,300 @@ func (s *Server) GetDocumentation(_ context.Context, _ GetDocumentationRequestOb
 func (s *Server) Callback(ctx context.Context, request CallbackRequestObject) (CallbackResponseObject, error) {
 	sessionID := request.Params.SessionID
 
-	log.WithFields(log.Fields{
+	s.log(ctx).WithFields(log.Fields{
 		"sessionID": sessionID,
 		"token":     request.Body,
 	}).Info("callback")
 
 	authRequest, b := s.cache.Get(sessionID.String())
 	if !b {
-		log.WithFields(log.Fields{
+		if _, known := s.sessionRecord(ctx, sessionID); known {
+			s.log(ctx).Warn("callback of an expired session removed from the cache")
+			return Callback404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionExpired, sessionID)}}, nil
+		}
+		s.log(ctx).WithFields(log.Fields{
 			"sessionID": sessionID,
 		}).Error("sessionID not found")
 		return nil, fmt.Errorf("sessionID not found")
 	}
+	s.recordSessionEvent(sessionID, sessionEventCallback, "")
+
+	if isCallbackRetry(authRequest, *request.Body) {
+		s.log(ctx).WithFields(log.Fields{
+			"sessionID": sessionID,
+		}).Info("callback retried after a successful verification")
+		return s.callbackResponse(sessionID), nil
+	}
+
+	if expired, ok := authRequest.(expiredSession); ok {
+		s.log(ctx).WithFields(log.Fields{"status": expired.status()}).Warn("callback of an expired session")
+		return Callback404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionExpired, sessionID)}}, nil
+	}
 
 	if _, ok := authRequest.(protocol.AuthorizationRequestMessage); !ok {
-		log.Error("failed to cast authRequest to AuthorizationRequestMessage")
+		s.log(ctx).Error("failed to cast authRequest to AuthorizationRequestMessage")
 		return Callback500JSONResponse{
 			N500JSONResponse: N500JSONResponse{
 				Message: "failed to cast authRequest to AuthorizationRequestMessage",
 			},
 		}, nil
 	}
+	if expires, ok := s.requestExpiresAt(sessionID); ok && time.Now().After(expires) {
+		s.log(ctx).WithFields(log.Fields{"expiresAt": expires}).Warn("callback of an expired request")
+		return Callback404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeRequestExpired, sessionID)}}, nil
+	}
+	// the malformed tokens are rejected before they reach the verification, and do not fail the session
+	if !s.isTestToken(*request.Body) {
+		if err := checkToken(*request.Body, s.cfg.Limits); err != nil {
+			s.log(ctx).WithFields(log.Fields{"err": err}).Warn("callback token rejected")
+			return Callback400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+		}
+	}
+	async := s.cfg.VerificationAsync && s.lanes != nil
+	if async && !s.markQueued(sessionID) {
+		s.log(ctx).Info("callback of a session whose verification is already queued")
+		return Callback202JSONResponse{}, nil
+	}
+	reservation, err := s.reserveVerification(sessionID)
+	if err != nil {
+		s.unmarkQueued(sessionID)
+		s.log(ctx).Warn("callback rejected, the verification queue is full")
+		return Callback429JSONResponse{N429JSONResponse{Message: i18n.Message(ctx, i18n.CodeVerificationQueueFull)}}, nil
+	}
+	s.answerSession(sessionID)
+
+	if async && !reservation.Admitted() {
+		// the wallet is answered at once, and the verification is run by a worker when its slot is free
+		s.queueVerification(reservation, queuedVerification{
+			// the queued verifications outlive the request
+			ctx:         context.WithoutCancel(ctx),
+			sessionID:   sessionID,
+			authRequest: authRequest.(protocol.AuthorizationRequestMessage),
+			token:       *request.Body,
+			queuedAt:    time.Now(),
+		})
+		s.log(ctx).Info("callback accepted, its verification is queued")
+		return Callback202JSONResponse{}, nil
+	}
+	if async {
+		s.unmarkQueued(sessionID)
+	}
 
-	authRespMsg, err := s.verifier.FullVerify(ctx, *request.Body,
-		authRequest.(protocol.AuthorizationRequestMessage),
+	if reservation != nil {
+		release, err := reservation.Wait(ctx)
+		if err != nil {
+			s.log(ctx).WithFields(log.Fields{"err": err}).Warn("callback canceled while waiting for a verification slot")
+			return Callback500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		}
+		defer release()
+	}
+	return s.verifyCallback(ctx, sessionID, authRequest.(protocol.AuthorizationRequestMessage), *request.Body), nil
+}
+
+// verifyCallback verifies the token of the callback of the session and stores the result of the session
+func (s *Server) verifyCallback(ctx context.Context, sessionID uuid.UUID, authRequest protocol.AuthorizationRequestMessage,
+	token string,
+) CallbackResponseObject {
+	defer s.publishStatus(sessionID)
+
+	recorder := timing.NewRecorder()
+	ctx = timing.WithRecorder(ctx, recorder)
+	pending := confirmations.NewPending()
+	ctx = confirmations.WithPending(ctx, pending)
+	start, verified := time.Now(), false
+	defer func() {
+		rpcCalls, rpcErrors := recorder.RPCCalls()
+		s.setSessionTimings(sessionID, recorder.Spans())
+		s.sli.ObserveVerification(verified, time.Since(start), rpcCalls, rpcErrors)
+		s.observeStats(sessionID.String(), authRequest, verified, time.Since(start))
+		s.emitVerification(sessionID, authRequest.Body.Scope, verified)
+		s.recordVerificationEvent(sessionID, verified)
+		s.recordUser(ctx, sessionID, authRequest.Body.Scope, verified)
+		s.recordAudit(ctx, sessionID, authRequest.Body.Scope, verified)
+		s.log(ctx).WithFields(log.Fields{
+			"verified":   verified,
+			"durationMs": time.Since(start).Milliseconds(),
+			"rpcCalls":   rpcCalls,
+		}).Info("callback verification finished")
+	}()
+	s.recordSessionEvent(sessionID, sessionEventVerifying, "")
+	stopParse := recorder.Start(timing.StageParse)
+	expectIssuerResolutions(recorder, token)
+	stopParse()
+
+	verifyStart := time.Now()
+	verifiedRequest := s.walletRequest(sessionID, authRequest, token)
+	authRespMsg, err := s.verifier.FullVerify(ctx, token, verifiedRequest,
 		pubsignals.WithAcceptedStateTransitionDelay(stateTransitionDelay))
+	if s.shadowVerifier != nil {
+		s.shadowVerifier.Submit(sessionID.String(), token,
+			authRequest, err,
+			pubsignals.WithAcceptedStateTransitionDelay(stateTransitionDelay))
+	}
+	var failedScopes []models.ScopeStatus
+	if required, ok := s.requiredScopes(sessionID); ok && err != nil {
+		s.log(ctx).WithFields(log.Fields{
+			"sessionID": sessionID,
+			"err":       err,
+		}).Info("verifying the scopes one by one")
+		authRespMsg, verifiedRequest, failedScopes, err = s.verifyScopes(ctx, token, verifiedRequest, required,
+			pubsignals.WithAcceptedStateTransitionDelay(stateTransitionDelay))
+	}
+	// the proof verification, pairings included, is the time of the full verification without its state resolutions
+	resolutions := recorder.Breakdown()
+	proofVerification := time.Since(verifyStart) - resolutions[timing.StageStateResolution] - resolutions[timing.StageRevocationCheck]
+	recorder.Add(timing.StageProofVerification, proofVerification)
+	recorder.AddSpan(string(timing.StageProofVerification), nil, verifyStart, proofVerification)
 	if err != nil {
-		log.WithFields(log.Fields{
+		s.log(ctx).WithFields(log.Fields{
 			"sessionID": sessionID,
 			"err":       err,
 		}).Error("failed to verify")
+		verifyErr := err
+		if !i18n.HasCode(err, i18n.CodeScopesNotVerified) {
+			verifyErr = i18n.Wrap(err, i18n.CodeVerificationFailed, err.Error())
+		}
+		s.cache.Set(sessionID.String(), verifyErr, cache.DefaultExpiration)
+		s.tags.finish(sessionID, false)
+		return Callback500JSONResponse{
+			N500JSONResponse: N500JSONResponse{
+				Message: i18n.Localize(ctx, verifyErr),
+			},
+		}
+	}
+
+	// the callbacks of other wallets do not fail the session, so they cannot keep its wallet from answering
+	if err := s.checkWalletBinding(sessionID, *authRespMsg); err != nil {
+		s.log(ctx).WithFields(log.Fields{
+			"sessionID": sessionID,
+			"userDID":   authRespMsg.From,
+			"err":       err,
+		}).Warn("callback of a session bound to another wallet")
+		return Callback409JSONResponse{
+			N409JSONResponse: N409JSONResponse{
+				Message: i18n.Localize(ctx, err),
+			},
+		}
+	}
+
+	stopPostProcessing := recorder.Start(timing.StagePostProcessing)
+	scopeStatuses, err := reconcileScopes(verifiedRequest, *authRespMsg)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{
+			"sessionID": sessionID,
+			"err":       err,
+			"scopes":    scopeStatuses,
+		}).Error("scope reconciliation failed")
 		s.cache.Set(sessionID.String(), err, cache.DefaultExpiration)
+		s.tags.finish(sessionID, false)
 		return Callback500JSONResponse{
 			N500JSONResponse: N500JSONResponse{
-				Message: err.Error(),
+				Message: i18n.Localize(ctx, err),
 			},
-		}, nil
+		}
+	}
+
+	if err := s.checkCredentialExpiration(*authRespMsg, time.Now()); err != nil {
+		s.log(ctx).WithFields(log.Fields{
+			"sessionID": sessionID,
+			"err":       err,
+		}).Error("credential expiration check failed")
+		s.cache.Set(sessionID.String(), err, cache.DefaultExpiration)
+		s.tags.finish(sessionID, false)
+		return Callback500JSONResponse{
+			N500JSONResponse: N500JSONResponse{
+				Message: i18n.Localize(ctx, err),
+			},
+		}
+	}
+
+	if err := s.checkIssuerPolicy(verifiedRequest, *authRespMsg); err != nil {
+		s.log(ctx).WithFields(log.Fields{
+			"sessionID": sessionID,
+			"err":       err,
+		}).Error("issuer policy check failed")
+		s.cache.Set(sessionID.String(), err, cache.DefaultExpiration)
+		s.tags.finish(sessionID, false)
+		return Callback500JSONResponse{
+			N500JSONResponse: N500JSONResponse{
+				Message: i18n.Localize(ctx, err),
+			},
+		}
+	}
+
+	if err := s.checkTrustProfile(sessionID, *authRespMsg, time.Now()); err != nil {
+		s.log(ctx).WithFields(log.Fields{
+			"sessionID": sessionID,
+			"err":       err,
+		}).Error("trust profile check failed")
+		s.cache.Set(sessionID.String(), err, cache.DefaultExpiration)
+		s.tags.finish(sessionID, false)
+		return Callback500JSONResponse{
+			N500JSONResponse: N500JSONResponse{
+				Message: i18n.Localize(ctx, err),
+			},
+		}
+	}
+
+	if err := s.checkGenesisEthAddress(sessionID, authRespMsg.From); err != nil {
+		s.log(ctx).WithFields(log.Fields{
+			"sessionID": sessionID,
+			"err":       err,
+		}).Error("ethereum address check failed")
+		s.cache.Set(sessionID.String(), err, cache.DefaultExpiration)
+		s.tags.finish(sessionID, false)
+		return Callback500JSONResponse{
+			N500JSONResponse: N500JSONResponse{
+				Message: i18n.Localize(ctx, err),
+			},
+		}
+	}
+
+	if err := hooks.Run(ctx, s.verificationHooks, hooks.Session{ID: sessionID.String(), Request: verifiedRequest}, *authRespMsg); err != nil {
+		s.log(ctx).WithFields(log.Fields{
+			"sessionID": sessionID,
+			"err":       err,
+		}).Error("verification rejected by a hook")
+		err = i18n.Wrap(err, i18n.CodeVerificationRejected, err.Error())
+		s.cache.Set(sessionID.String(), err, cache.DefaultExpiration)
+		s.tags.finish(sessionID, false)
+		return Callback500JSONResponse{
+			N500JSONResponse: N500JSONResponse{
+				Message: i18n.Localize(ctx, err),
+			},
+		}
+	}
+
+	nullifiers, err := proofNullifiers(authRespMsg.Body.Scope)
+	if err == nil {
+		err = s.claimNullifiers(ctx, sessionID, nullifiers)
+	}
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{
+			"sessionID": sessionID,
+			"err":       err,
+		}).Error("nullifier uniqueness check failed")
+		s.cache.Set(sessionID.String(), err, cache.DefaultExpiration)
+		s.tags.finish(sessionID, false)
+		if i18n.HasCode(err, i18n.CodeNullifierAlreadyUsed) {
+			return Callback409JSONResponse{
+				N409JSONResponse: N409JSONResponse{
+					Message: i18n.Localize(ctx, err),
+				},
+			}
+		}
+		return Callback500JSONResponse{
+			N500JSONResponse: N500JSONResponse{
+				Message: i18n.Localize(ctx, err),
+			},
+		}
+	}
+
+	if err := s.recordNullifiers(ctx, sessionID.String(), nullifiers); err != nil {
+		s.log(ctx).WithFields(log.Fields{
+			"sessionID": sessionID,
+			"err":       err,
+		}).Error("failed to record nullifiers")
 	}
 
 	scopes, err := getVerificationResponseScopes(authRespMsg.Body.Scope)
@@ -125,17 +644,65 @@ func (s *Server) Callback(ctx context.Context, request CallbackRequestObject) (C
 			N500JSONResponse: N500JSONResponse{
 				Message: err.Error(),
 			},
-		}, nil
+		}
+	}
+
+	proofs, err := getScopeProofs(authRespMsg.Body.Scope)
+	if err != nil {
+		return Callback500JSONResponse{
+			N500JSONResponse: N500JSONResponse{
+				Message: err.Error(),
+			},
+		}
 	}
 
-	s.cache.Set(sessionID.String(), models.VerificationResponse{Jwz: *request.Body, UserDID: authRespMsg.From, Scopes: scopes}, cache.DefaultExpiration)
+	unconfirmed := pending.States()
+	verification := models.VerificationResponse{
+		Jwz:           token,
+		UserDID:       authRespMsg.From,
+		Scopes:        scopes,
+		Provisional:   len(unconfirmed) > 0,
+		ScopeStatuses: mergeScopeStatuses(scopeStatuses, failedScopes),
+		Proofs:        proofs,
+	}
+	verification.EthAddress, _ = genesisEthAddress(authRespMsg.From)
+	if s.cfg.JWT.Enabled {
+		token, err := s.issueToken(sessionID, authRequest.Body.Scope, verification)
+		if err != nil {
+			s.log(ctx).WithFields(log.Fields{
+				"sessionID": sessionID,
+				"err":       err,
+			}).Error("failed to issue token")
+		}
+		verification.Token = token
+	}
+
+	stopPostProcessing()
+	verification.Timings = recorder.Breakdown()
+	s.timings.Observe(verification.Timings)
+	s.log(ctx).WithFields(log.Fields{
+		"sessionID": sessionID,
+		"timings":   verification.Timings,
+	}).Debug("verification timings")
+
+	s.cache.Set(sessionID.String(), verification, cache.DefaultExpiration)
+	s.tags.finish(sessionID, true)
+	s.scheduleReverification(sessionID, verification.Jwz)
+	verified = true
+	if verification.Provisional {
+		s.log(ctx).WithFields(log.Fields{"sessionID": sessionID}).Info("verification is provisional until its states are confirmed")
+		go s.watchConfirmations(sessionID, verification.Jwz, unconfirmed)
+	}
 
-	return Callback200JSONResponse{}, nil
+	return s.callbackResponse(sessionID)
 }
 
 // GetQRCodeFromStore - get QR code from store
-func (s *Server) GetQRCodeFromStore(_ context.Context, request GetQRCodeFromStoreRequestObject) (GetQRCodeFromStoreResponseObject, error) {
+func (s *Server) GetQRCodeFromStore(ctx context.Context, request GetQRCodeFromStoreRequestObject) (GetQRCodeFromStoreResponseObject, error) {
 	qrCode, err := s.qrStore.Get(request.Params.Id)
+	if errors.Is(err, errQRCodeNotFound) {
+		return GetQRCodeFromStore404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeQRCodeNotFound)}}, nil
+	}
 	if err != nil {
 		return GetQRCodeFromStore500JSONResponse{
 			N500JSONResponse: N500JSONResponse{
@@ -143,80 +710,248 @@ func (s *Server) GetQRCodeFromStore(_ context.Context, request GetQRCodeFromStor
 			},
 		}, nil
 	}
-	return GetQRCodeFromStore200JSONResponse(*qrCode), nil
+	s.tags.scan(request.Params.Id)
+	s.scanSession(request.Params.Id)
+	s.recordQRCodeFetched(request.Params.Id)
+
+	etag := qrCodeETag(request.Params.Id)
+	cacheControl := qrCodeCacheControl(s.cfg.SessionTTL.AsDuration())
+	if etagMatches(request.Params.IfNoneMatch, etag) {
+		return GetQRCodeFromStore304Response{GetQRCodeFromStore304ResponseHeaders{CacheControl: cacheControl, ETag: etag}}, nil
+	}
+	return GetQRCodeFromStore200JSONResponse{
+		Body:    *qrCode,
+		Headers: GetQRCodeFromStore200ResponseHeaders{CacheControl: cacheControl, ETag: etag},
+	}, nil
 }
 
 // SignIn - sign in
-func (s *Server) SignIn(_ context.Context, request SignInRequestObject) (SignInResponseObject, error) {
+func (s *Server) SignIn(ctx context.Context, request SignInRequestObject) (SignInResponseObject, error) {
+	return s.signIn(ctx, request, nil)
+}
+
+// signIn creates a session for the request, of the verification flow when flow is set
+func (s *Server) signIn(ctx context.Context, request SignInRequestObject, flow *config.Flow) (SignInResponseObject, error) {
 	sessionID := uuid.New()
+	ctx = logging.WithEntry(ctx, s.log(ctx).WithField(logging.FieldSessionID, sessionID))
+
+	if resp, ok := s.authorizeSignIn(ctx, request); !ok {
+		return resp, nil
+	}
+	s.setSessionTenant(sessionID, s.tenantID(request.Params.XAPIKey))
+	s.setSessionOwner(sessionID, request.Params.XAPIKey)
+	s.setSessionPriority(sessionID, s.priority(ctx, request.Params.XAPIKey))
+	s.setSessionFlow(sessionID, flow)
 
 	if len(request.Body.Scope) == 0 {
-		log.Error("field scope is empty")
-		return SignIn400JSONResponse{N400JSONResponse{Message: "field scope is empty"}}, nil
+		s.log(ctx).Error("field scope is empty")
+		return SignIn400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeScopeEmpty)}}, nil
+	}
+
+	if err := validateTags(request.Body.Tags); err != nil {
+		s.log(ctx).Error(err)
+		return SignIn400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+	}
+
+	if err := s.validatePublicURL(request.Body.PublicURL); err != nil {
+		s.log(ctx).Error(err)
+		return SignIn400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+	}
+
+	if err := s.applyQueryTemplates(request.Body.Scope); err != nil {
+		s.log(ctx).Error(err)
+		return SignIn400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+	}
+
+	trustProfile, err := s.applyTrustProfile(request.Body)
+	if err != nil {
+		s.log(ctx).Error(err)
+		return SignIn400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+	}
+	s.setSessionTrustProfile(sessionID, trustProfile)
+
+	if err := s.checkSchemaAllowlist(request.Body.Scope); err != nil {
+		s.log(ctx).Error(err)
+		return SignIn400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+	}
+
+	if err := validateUniqueNullifier(request.Body); err != nil {
+		s.log(ctx).Error(err)
+		return SignIn400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+	}
+
+	if err := validateRequiredScopes(request.Body); err != nil {
+		s.log(ctx).Error(err)
+		return SignIn400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+	}
+
+	if err := validateMetadata(request.Body); err != nil {
+		s.log(ctx).Error(err)
+		return SignIn400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+	}
+
+	if err := validateRedirectURI(request.Body); err != nil {
+		s.log(ctx).Error(err)
+		return SignIn400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+	}
+
+	if err := s.applyReasonTemplate(request.Body); err != nil {
+		s.log(ctx).Error(err)
+		return SignIn400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
 	}
 
 	switch circuits.CircuitID(request.Body.Scope[0].CircuitId) {
 	case circuits.AtomicQuerySigV2CircuitID, circuits.AtomicQueryMTPV2CircuitID, circuits.AtomicQueryV3CircuitID:
-		authReq, err := s.getAuthRequestOffChain(request, sessionID)
+		authReq, err := s.getAuthRequestOffChain(ctx, request, sessionID)
 		if err != nil {
-			log.Error(err)
-			return SignIn400JSONResponse{N400JSONResponse{err.Error()}}, nil
+			s.log(ctx).Error(err)
+			return SignIn400JSONResponse{N400JSONResponse{i18n.Localize(ctx, err)}}, nil
 		}
-		s.cache.Set(sessionID.String(), authReq, cache.DefaultExpiration)
-		qrCode := getAuthReqQRCode(authReq)
-		qrID, err := s.qrStore.Save(qrCode)
-		if err != nil {
-			return SignIn500JSONResponse{N500JSONResponse{Message: fmt.Sprintf("failed to cache QR code: %s", err.Error())}}, nil
+		if err := s.lintQueries(ctx, request.Body.Scope); err != nil {
+			s.log(ctx).Error(err)
+			return SignIn400JSONResponse{N400JSONResponse{i18n.Localize(ctx, err)}}, nil
 		}
-		return SignIn200JSONResponse{
-			QrCode:    fmt.Sprintf("iden3comm://?request_uri=%s%s?id=%s", s.cfg.Host, "/qr-store", qrID.String()),
-			SessionID: sessionID,
-		}, nil
+		s.setUniqueNullifier(sessionID, request.Body.EnforceUniqueNullifier)
+		s.setRequiredScopes(sessionID, request.Body)
+		s.setEthAddressRequired(sessionID, request.Body.RequireEthAddress)
+		s.setRedirectURI(sessionID, request.Body.RedirectUri)
+		resp := s.startOffChainSession(ctx, sessionID, authReq, request.Body, flowTTL(flow, s.cfg.SessionTTL.AsDuration()))
+		if _, ok := resp.(SignIn200JSONResponse); ok {
+			s.log(ctx).WithFields(log.Fields{"circuitID": request.Body.Scope[0].CircuitId, "scopes": len(request.Body.Scope)}).Info("sign-in")
+		}
+		return resp, nil
 	case circuits.AtomicQuerySigV2OnChainCircuitID, circuits.AtomicQueryMTPV2OnChainCircuitID, circuits.AtomicQueryV3OnChainCircuitID:
 		invokeReq, err := s.getContractInvokeRequestOnChain(request)
 		if err != nil {
-			log.Error(err)
-			return SignIn400JSONResponse{N400JSONResponse{err.Error()}}, nil
+			s.log(ctx).Error(err)
+			return SignIn400JSONResponse{N400JSONResponse{i18n.Localize(ctx, err)}}, nil
+		}
+		if err := s.lintQueries(ctx, request.Body.Scope); err != nil {
+			s.log(ctx).Error(err)
+			return SignIn400JSONResponse{N400JSONResponse{i18n.Localize(ctx, err)}}, nil
 		}
+		s.setUniqueNullifier(sessionID, request.Body.EnforceUniqueNullifier)
 		s.cache.Set(sessionID.String(), invokeReq, cache.DefaultExpiration)
-		qrCode := getInvokeContractQRCode(invokeReq)
-		qrID, err := s.qrStore.Save(qrCode)
+		qrCode := messages.ContractInvokeQRCode(invokeReq)
+		if created, expires := s.requestValidity(s.cfg.CacheExpiration.AsDuration()); !expires.IsZero() {
+			qrCode = qrCode.WithValidity(created, expires)
+		}
+		qrToken, err := s.qrStore.Save(qrCode)
 		if err != nil {
 			return SignIn500JSONResponse{N500JSONResponse{Message: fmt.Sprintf("failed to cache QR code: %s", err.Error())}}, nil
 		}
+		s.tags.add(sessionID, qrToken, request.Body.Tags)
+		s.emitSessionCreated(sessionID, request.Body.Scope)
+		s.recordSessionCreated(sessionID, qrToken)
+		expiresAt := s.recordSession(ctx, sessionID, s.cfg.CacheExpiration.AsDuration(), request.Body.Metadata)
+		s.log(ctx).WithFields(log.Fields{"circuitID": request.Body.Scope[0].CircuitId, "scopes": len(request.Body.Scope)}).Info("sign-in")
+		requestURI := s.qrCodeLink(ctx, qrToken, request.Body.PublicURL)
 		return SignIn200JSONResponse{
-			QrCode:    fmt.Sprintf("iden3comm://?request_uri=%s%s?id=%s", s.cfg.Host, "/qr-store", qrID.String()),
+			QrCode:    iden3commRequestURIPrefix + requestURI,
+			Links:     s.walletLinks(ctx, qrToken, requestURI, request.Body.PublicURL),
 			SessionID: sessionID,
+			ExpiresAt: expiresAt,
 		}, nil
 	default:
-		log.Errorf("invalid circuitID: %s", request.Body.Scope[0].CircuitId)
-		return SignIn400JSONResponse{N400JSONResponse{Message: "invalid circuitID"}}, nil
+		s.log(ctx).Errorf("invalid circuitID: %s", request.Body.Scope[0].CircuitId)
+		return SignIn400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeInvalidCircuitID)}}, nil
+	}
+}
+
+// startOffChainSession stores the authorization request of a new off-chain session, that waits for its callback for
+// ttl, and the QR code to fetch it
+func (s *Server) startOffChainSession(ctx context.Context, sessionID uuid.UUID, authReq protocol.AuthorizationRequestMessage, body *SignInRequest, ttl time.Duration) SignInResponseObject {
+	s.cache.Set(sessionID.String(), authReq, cache.DefaultExpiration)
+	s.publishStatus(sessionID)
+	created, expires := s.requestValidity(ttl)
+	qrCode := messages.AuthRequestQRCode(authReq)
+	if !expires.IsZero() {
+		qrCode = qrCode.WithValidity(created, expires)
+		s.cache.Set(requestExpiresKeyPrefix+sessionID.String(), expires, cache.DefaultExpiration)
+	}
+	qrToken, err := s.qrStore.Save(qrCode)
+	if err != nil {
+		return SignIn500JSONResponse{N500JSONResponse{Message: fmt.Sprintf("failed to cache QR code: %s", err.Error())}}
+	}
+	s.tags.add(sessionID, qrToken, body.Tags)
+	s.emitSessionCreated(sessionID, body.Scope)
+	s.recordSessionCreated(sessionID, qrToken)
+	s.trackSession(sessionID, qrToken, ttl)
+	expiresAt := s.recordSession(ctx, sessionID, ttl, body.Metadata)
+	requestURI := s.qrCodeLink(ctx, qrToken, body.PublicURL)
+	return SignIn200JSONResponse{
+		QrCode:    iden3commRequestURIPrefix + requestURI,
+		Links:     s.walletLinks(ctx, qrToken, requestURI, body.PublicURL),
+		SessionID: sessionID,
+		ExpiresAt: expiresAt,
 	}
 }
 
 // Status - status
-func (s *Server) Status(_ context.Context, request StatusRequestObject) (StatusResponseObject, error) {
+func (s *Server) Status(ctx context.Context, request StatusRequestObject) (StatusResponseObject, error) {
 	id := request.Params.SessionID
+	debug := common.FromPointer(request.Params.Debug)
+	if debug && !s.canConsumeSession(id, common.FromPointer(request.Params.XAPIKey)) {
+		return Status401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionTimingsForbidden, id)}}, nil
+	}
+	if s.cfg.ReadOnly {
+		return s.replicaStatus(ctx, id)
+	}
+	resp, err := s.currentStatus(ctx, id)
+	if status, ok := resp.(Status200JSONResponse); ok {
+		if metadata := s.sessionMetadata(ctx, id); len(metadata) > 0 {
+			status.Metadata = (*SessionMetadata)(&metadata)
+		}
+		if debug {
+			status.Timings = s.sessionTimings(id)
+		}
+		return status, err
+	}
+	return resp, err
+}
+
+// currentStatus returns the status of the session from the cache, or from the ledger once it was removed
+func (s *Server) currentStatus(ctx context.Context, id uuid.UUID) (StatusResponseObject, error) {
 	item, ok := s.cache.Get(id.String())
 	if !ok {
-		log.WithFields(log.Fields{"sessionID": id}).Error("sessionID not found")
-		return Status404JSONResponse{N404JSONResponse: N404JSONResponse{Message: "sessionID not found"}}, nil
+		if record, known := s.sessionRecord(ctx, id); known {
+			return Status200JSONResponse{Status: statusExpired, ExpiresAt: &record.ExpiresAt}, nil
+		}
+		s.log(ctx).WithFields(log.Fields{"sessionID": id}).Error("sessionID not found")
+		return Status404JSONResponse{N404JSONResponse: N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionNotFound)}}, nil
 	}
 
 	switch value := item.(type) {
-	case protocol.AuthorizationRequestMessage:
+	case protocol.AuthorizationRequestMessage, protocol.ContractInvokeRequestMessage:
+		return Status200JSONResponse{
+			Status:    statusPending,
+			ExpiresAt: s.sessionExpiresAt(ctx, id),
+		}, nil
+	case consumedResult:
 		return Status200JSONResponse{
-			Status: statusPending,
+			Status: statusConsumed,
+		}, nil
+	case expiredSession:
+		return Status200JSONResponse{
+			Status:    value.status(),
+			ExpiresAt: s.sessionExpiresAt(ctx, id),
+		}, nil
+	case invalidatedVerification:
+		return Status200JSONResponse{
+			Status:    value.status(),
+			Message:   common.ToPointer(i18n.Localize(ctx, value.Err)),
+			ErrorCode: common.ToPointer(verrors.Classify(value.Err)),
 		}, nil
 	case error:
 		return Status200JSONResponse{
-			Status:  statusError,
-			Message: common.ToPointer(value.Error()),
+			Status:    statusError,
+			Message:   common.ToPointer(i18n.Localize(ctx, value)),
+			ErrorCode: common.ToPointer(verrors.Classify(value)),
 		}, nil
 	case models.VerificationResponse:
-		vps, err := getVerifiablePresentations(value.Jwz)
+		vps, err := s.verifiablePresentations(value.Jwz)
 		if err != nil {
-			log.WithFields(log.Fields{"err": err}).Error("failed to get verifiable presentations")
+			s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to get verifiable presentations")
 			return Status200JSONResponse{
 				Status:  statusError,
 				Message: common.ToPointer(err.Error()),
@@ -227,6 +962,20 @@ func (s *Server) Status(_ context.Context, request StatusRequestObject) (StatusR
 	return nil, nil
 }
 
+// verifiablePresentations returns the presentations of the token of a verification.
+// The canned token of the test mode is not a JWZ and has none, and the on-chain verifications have no token.
+func (s *Server) verifiablePresentations(jwzToken string) (VerifiablePresentations, error) {
+	if jwzToken == "" || s.isTestToken(jwzToken) {
+		return nil, nil
+	}
+	return getVerifiablePresentations(jwzToken)
+}
+
+// isTestToken reports whether token is the canned token of the test mode
+func (s *Server) isTestToken(token string) bool {
+	return s.cfg.TestMode.Enabled && token == s.cfg.TestMode.Token
+}
+
 func getVerifiablePresentations(jwzToken string) (VerifiablePresentations, error) {
 	token, err := jwz.Parse(jwzToken)
 	if err != nil {
@@ -276,80 +1025,9 @@ func writeFile(path string, mimeType string, w http.ResponseWriter) {
 	_, _ = w.Write(f)
 }
 
-func getAuthReqQRCode(request protocol.AuthorizationRequestMessage) QRCode {
-	scopes := make([]Scope, 0, len(request.Body.Scope))
-	for _, scope := range request.Body.Scope {
-		sc := Scope{
-			CircuitId: scope.CircuitID,
-			Id:        scope.ID,
-			Query:     scope.Query,
-		}
-		if scope.Params != nil {
-			sc.Params = common.ToPointer(scope.Params)
-		}
-		scopes = append(scopes, sc)
-	}
-
-	qrCode := QRCode{
-		From: request.From,
-		Id:   request.ID,
-		Thid: request.ThreadID,
-		Typ:  string(request.Typ),
-		Type: string(request.Type),
-		Body: Body{
-			CallbackUrl: common.ToPointer(request.Body.CallbackURL),
-			Reason:      request.Body.Reason,
-			Scope:       scopes,
-		},
-	}
-	if request.To != "" {
-		qrCode.To = &request.To
-	}
-
-	return qrCode
-}
-
-func getInvokeContractQRCode(request protocol.ContractInvokeRequestMessage) QRCode {
-	scopes := make([]Scope, 0, len(request.Body.Scope))
-	for _, scope := range request.Body.Scope {
-		sc := Scope{
-			CircuitId: scope.CircuitID,
-			Id:        scope.ID,
-			Query:     scope.Query,
-		}
-		if scope.Params != nil {
-			sc.Params = common.ToPointer(scope.Params)
-		}
-		scopes = append(scopes, sc)
-	}
-
-	qrCode := QRCode{
-		From: request.From,
-		Id:   request.ID,
-		Thid: request.ThreadID,
-		Typ:  string(request.Typ),
-		Type: string(request.Type),
-		Body: Body{
-			Reason: request.Body.Reason,
-			Scope:  scopes,
-			TransactionData: &TransactionDataResponse{
-				ChainId:         request.Body.TransactionData.ChainID,
-				ContractAddress: request.Body.TransactionData.ContractAddress,
-				MethodId:        request.Body.TransactionData.MethodID,
-				Network:         request.Body.TransactionData.Network,
-			},
-		},
-	}
-	if request.To != "" {
-		qrCode.To = &request.To
-	}
-
-	return qrCode
-}
-
 func validateOffChainRequest(request SignInRequestObject) error {
 	if request.Body.ChainID == nil {
-		return errors.New("field chainId is empty")
+		return i18n.New(i18n.CodeFieldEmpty, "chainId")
 	}
 
 	if err := validateRequestQuery(true, request.Body.Scope); err != nil {
@@ -359,91 +1037,56 @@ func validateOffChainRequest(request SignInRequestObject) error {
 	return nil
 }
 
+// validateRequestQuery checks the scopes of an off-chain request, or of an on-chain one when offChainRequest is not set
 func validateRequestQuery(offChainRequest bool, scope []ScopeRequest) error {
-	reqIds := make(map[uint32]bool, 0)
+	scopes := make([]verifier.Scope, 0, len(scope))
 	for _, scope := range scope {
-		if reqIds[scope.Id] {
-			return fmt.Errorf("field scope id must be unique, got %d multiple times", scope.Id)
-		}
-		reqIds[scope.Id] = true
-
-		if scope.Id <= 0 {
-			return errors.New("field scope id is empty")
-		}
-
-		if scope.CircuitId == "" {
-			return errors.New("field circuitId is empty")
-		}
-
-		circuitID := circuits.CircuitID(scope.CircuitId)
-		if offChainRequest {
-			if circuitID != circuits.AtomicQuerySigV2CircuitID && circuitID != circuits.AtomicQueryMTPV2CircuitID && circuitID != circuits.AtomicQueryV3CircuitID {
-				return fmt.Errorf("field circuitId value is wrong, got %s, expected %s or %s or %s", scope.CircuitId, circuits.AtomicQuerySigV2CircuitID, circuits.AtomicQueryMTPV2CircuitID, circuits.AtomicQueryV3CircuitID)
-			}
-		}
-
-		if !offChainRequest {
-			if circuitID != circuits.AtomicQuerySigV2OnChainCircuitID && circuitID != circuits.AtomicQueryMTPV2OnChainCircuitID && circuitID != circuits.AtomicQueryV3OnChainCircuitID {
-				return fmt.Errorf("field circuitId value is wrong, got %s, expected %s or %s or %s", scope.CircuitId, circuits.AtomicQuerySigV2OnChainCircuitID, circuits.AtomicQueryMTPV2OnChainCircuitID, circuits.AtomicQueryV3OnChainCircuitID)
-			}
-		}
-
-		if scope.Query == nil {
-			return errors.New("field query is empty")
-		}
-
-		if scope.Query["context"] == nil || scope.Query["context"] == "" {
-			return errors.New("context cannot be empty")
-		}
-
-		if scope.Query["type"] == nil || scope.Query["type"] == "" {
-			return errors.New("type cannot be empty")
-		}
-
-		if scope.Query["allowedIssuers"] == nil {
-			return errors.New("allowedIssuers cannot be empty")
-		}
+		scopes = append(scopes, verifier.Scope{ID: scope.Id, CircuitID: scope.CircuitId, Query: scope.Query, Params: scope.Params})
 	}
-
-	return nil
+	return verifier.ValidateScopes(!offChainRequest, scopes)
 }
 
-func (s *Server) getAuthRequestOffChain(req SignInRequestObject, sessionID uuid.UUID) (protocol.AuthorizationRequestMessage, error) {
+func (s *Server) getAuthRequestOffChain(ctx context.Context, req SignInRequestObject, sessionID uuid.UUID) (protocol.AuthorizationRequestMessage, error) {
 	if err := validateOffChainRequest(req); err != nil {
 		return protocol.AuthorizationRequestMessage{}, err
 	}
 
-	senderDID, err := s.getSenderDID(*req.Body.ChainID)
+	senderDID, err := s.requestSenderDID(*req.Body.ChainID)
 	if err != nil {
 		return protocol.AuthorizationRequestMessage{}, err
 	}
 
-	id := uuid.NewString()
-	authReq := auth.CreateAuthorizationRequest(getReason(req.Body.Reason), senderDID, getUri(s.cfg, sessionID))
-	authReq.ID = id
-	authReq.ThreadID = id
-	authReq.To = ""
-	if req.Body.To != nil {
-		authReq.To = *req.Body.To
+	scopes, err := s.proofRequests(req.Body.Scope)
+	if err != nil {
+		return protocol.AuthorizationRequestMessage{}, err
 	}
 
-	for _, scope := range req.Body.Scope {
-		mtpProofRequest := protocol.ZeroKnowledgeProofRequest{
-			ID:        scope.Id,
-			CircuitID: scope.CircuitId,
-			Query:     scope.Query,
-		}
-		if scope.Params != nil {
-			params, err := getParams(*scope.Params)
-			if err != nil {
-				return protocol.AuthorizationRequestMessage{}, err
-			}
+	return messages.AuthRequest(messages.Request{
+		ID:          uuid.NewString(),
+		Reason:      s.reason(req.Body.Reason),
+		Message:     common.FromPointer(req.Body.Message),
+		From:        senderDID,
+		To:          common.FromPointer(req.Body.To),
+		CallbackURL: getUri(s.publicURL(ctx, req.Body.PublicURL), sessionID),
+		Scope:       scopes,
+	}), nil
+}
 
-			mtpProofRequest.Params = params
+// proofRequests builds the proof requests of the scopes, applying the issuer policy to their queries
+func (s *Server) proofRequests(scopes []ScopeRequest) ([]protocol.ZeroKnowledgeProofRequest, error) {
+	requests := make([]protocol.ZeroKnowledgeProofRequest, 0, len(scopes))
+	for _, scope := range scopes {
+		query, err := s.applyIssuerPolicy(scope.Query)
+		if err != nil {
+			return nil, err
 		}
-		authReq.Body.Scope = append(authReq.Body.Scope, mtpProofRequest)
+		request, err := messages.ProofRequest(scope.Id, scope.CircuitId, query, scope.Params)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
 	}
-	return authReq, nil
+	return requests, nil
 }
 
 func checkOnChainRequest(req SignInRequestObject) error {
@@ -452,48 +1095,37 @@ func checkOnChainRequest(req SignInRequestObject) error {
 	}
 
 	if req.Body.TransactionData == nil {
-		return errors.New("field transactionData is empty")
+		return i18n.New(i18n.CodeFieldEmpty, "transactionData")
 	}
 
 	if req.Body.TransactionData.ChainID <= 0 {
-		return errors.New("field chainId is empty")
+		return i18n.New(i18n.CodeFieldEmpty, "chainId")
 	}
 
 	if req.Body.TransactionData.ContractAddress == "" {
-		return errors.New("field contractAddress is empty")
+		return i18n.New(i18n.CodeFieldEmpty, "contractAddress")
 	}
 
 	if req.Body.TransactionData.MethodID == "" {
-		return errors.New("field methodId is empty")
+		return i18n.New(i18n.CodeFieldEmpty, "methodId")
 	}
 
 	if req.Body.TransactionData.Network == "" {
-		return errors.New("field network is empty")
+		return i18n.New(i18n.CodeFieldEmpty, "network")
 	}
 
 	return nil
 }
 
 func (s *Server) getContractInvokeRequestOnChain(req SignInRequestObject) (protocol.ContractInvokeRequestMessage, error) {
+	s.applyContractPreset(req.Body.TransactionData)
 	if err := checkOnChainRequest(req); err != nil {
 		return protocol.ContractInvokeRequestMessage{}, err
 	}
 
-	mtpProofRequests := make([]protocol.ZeroKnowledgeProofRequest, 0, len(req.Body.Scope))
-	for _, scope := range req.Body.Scope {
-		zkProofReq := protocol.ZeroKnowledgeProofRequest{
-			ID:        scope.Id,
-			CircuitID: scope.CircuitId,
-			Query:     scope.Query,
-		}
-		if scope.Params != nil {
-			params, err := getParams(*scope.Params)
-			if err != nil {
-				return protocol.ContractInvokeRequestMessage{}, err
-			}
-			zkProofReq.Params = params
-		}
-		mtpProofRequests = append(mtpProofRequests, zkProofReq)
+	scopes, err := s.proofRequests(req.Body.Scope)
+	if err != nil {
+		return protocol.ContractInvokeRequestMessage{}, err
 	}
 
 	transactionData := protocol.TransactionData{
@@ -507,114 +1139,64 @@ func (s *Server) getContractInvokeRequestOnChain(req SignInRequestObject) (proto
 		return protocol.ContractInvokeRequestMessage{}, err
 	}
 
-	authReq := auth.CreateContractInvokeRequest(getReason(req.Body.Reason), senderDID, transactionData, mtpProofRequests...)
-	id := uuid.NewString()
-	authReq.ID = id
-	authReq.ThreadID = id
-	authReq.To = ""
-
-	verifierDID, err := buildOnchainVerifierDID(transactionData)
-	if err != nil {
-		return protocol.ContractInvokeRequestMessage{}, err
-	}
-
-	authReq.From = verifierDID.String()
-	if req.Body.To != nil {
-		authReq.To = *req.Body.To
-	}
-
-	return authReq, nil
-}
-
-func buildOnchainVerifierDID(transactionData protocol.TransactionData) (*w3c.DID, error) {
-	address := common2.HexToAddress(transactionData.ContractAddress)
-	var ethAddr [20]byte
-	copy(ethAddr[:], address.Bytes())
-
-	currentState := core.GenesisFromEthAddress(ethAddr)
-
-	blockchain, network, err := core.NetworkByChainID(core.ChainID(transactionData.ChainID))
-	if err != nil {
-		return nil, err
-	}
-	didType, err := core.BuildDIDType(core.DIDMethodIden3, blockchain, network)
-	if err != nil {
-		return nil, err
-	}
-
-	did, err := core.NewDID(didType, currentState)
-	if err != nil {
-		return nil, err
-	}
-	return did, nil
-}
-
-func getParams(params ScopeParams) (map[string]interface{}, error) {
-	val, ok := params["nullifierSessionID"]
-	if !ok {
-		return nil, errors.New("nullifierSessionID is empty")
-	}
-
-	nullifierSessionID := new(big.Int)
-	if _, ok := nullifierSessionID.SetString(val.(string), defaultBigIntBase); !ok {
-		return nil, errors.New("nullifierSessionID is not a valid big integer")
-	}
-
-	return map[string]interface{}{"nullifierSessionId": nullifierSessionID.String()}, nil
+	return messages.ContractInvokeRequest(messages.Request{
+		ID:     uuid.NewString(),
+		Reason: s.reason(req.Body.Reason),
+		From:   senderDID,
+		To:     common.FromPointer(req.Body.To),
+		Scope:  scopes,
+	}, transactionData)
 }
 
 func (s *Server) getSenderDID(chainID string) (string, error) {
 	val, ok := s.senderDIDs[chainID]
-	if !ok {
-		return "", fmt.Errorf("sender not found for chainID %s", chainID)
+	if ok {
+		return val, nil
 	}
 
-	return val, nil
+	switch s.cfg.SenderDID.Fallback {
+	case config.SenderDIDFallbackDefault:
+		return s.cfg.SenderDID.DefaultDID, nil
+	case config.SenderDIDFallbackDerive:
+		did, err := deriveSenderDID(chainID, s.cfg.SenderDID)
+		if err != nil {
+			s.logger.WithField("chainID", chainID).WithError(err).Warn("failed to derive sender did")
+			return "", i18n.New(i18n.CodeSenderNotFound, chainID)
+		}
+		return did, nil
+	}
+
+	return "", i18n.New(i18n.CodeSenderNotFound, chainID)
 }
 
-func getUri(cfg config.Config, sessionID uuid.UUID) string {
-	return fmt.Sprintf("%s%s?sessionID=%s", cfg.Host, config.CallbackURL, sessionID)
+func getUri(publicURL string, sessionID uuid.UUID) string {
+	return fmt.Sprintf("%s%s?sessionID=%s", publicURL, config.CallbackURL, sessionID)
 }
 
-func getReason(reason *string) string {
-	if reason == nil {
-		return defaultReason
+// reason returns the reason of a request, the configured default reason when the sign-in does not set one
+func (s *Server) reason(reason *string) string {
+	if reason != nil {
+		return *reason
+	}
+	if s.cfg.DefaultReason != "" {
+		return s.cfg.DefaultReason
 	}
-	return *reason
+	return defaultReason
 }
 
 func getVerificationResponseScopes(scopes []protocol.ZeroKnowledgeProofResponse) ([]models.VerificationResponseScope, error) {
-	if len(scopes) == 0 {
-		return nil, errors.New("scopes are empty")
-	}
-
-	if scopes[0].CircuitID != string(circuits.AtomicQueryV3CircuitID) {
-		return []models.VerificationResponseScope{}, nil
+	results, err := verifier.ScopeResults(scopes)
+	if err != nil {
+		return nil, err
 	}
-
-	resp := make([]models.VerificationResponseScope, 0, len(scopes))
-	for _, scope := range scopes {
-		ps := circuits.AtomicQueryV3PubSignals{}
-		if scope.CircuitID != string(circuits.AtomicQueryV3CircuitID) {
-			return []models.VerificationResponseScope{}, nil
-		}
-
-		signals, err := json.Marshal(scope.PubSignals)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := ps.PubSignalsUnmarshal(signals); err != nil {
-			return nil, err
-		}
-
+	resp := make([]models.VerificationResponseScope, 0, len(results))
+	for _, result := range results {
 		resp = append(resp, models.VerificationResponseScope{
-			ID:                 scope.ID,
-			NullifierSessionID: ps.NullifierSessionID.String(),
-			Nullifier:          ps.Nullifier.String(),
+			ID:                 result.ID,
+			NullifierSessionID: result.NullifierSessionID,
+			Nullifier:          result.Nullifier,
 		})
 	}
-
 	return resp, nil
 }
 
@@ -622,6 +1204,9 @@ func getStatusVerificationResponse(verification models.VerificationResponse, vcs
 	jwzMetadata := &JWZMetadata{
 		UserDID: verification.UserDID,
 	}
+	if verification.EthAddress != "" {
+		jwzMetadata.EthAddress = &verification.EthAddress
+	}
 	if len(vcs) > 0 {
 		jwzMetadata.VerifiablePresentations = vcs
 	}
@@ -638,9 +1223,45 @@ func getStatusVerificationResponse(verification models.VerificationResponse, vcs
 		jwzMetadata.Nullifiers = &nullifiers
 	}
 
-	return Status200JSONResponse{
-		Jwz:         common.ToPointer(verification.Jwz),
+	if len(verification.Proofs) > 0 {
+		proofs := make([]ScopeProof, 0, len(verification.Proofs))
+		for _, proof := range verification.Proofs {
+			proofs = append(proofs, ScopeProof{
+				IssuerDID:          proof.IssuerDID,
+				IssuerState:        proof.IssuerState,
+				IssuerStateGenesis: proof.IssuerStateGenesis,
+				Merklized:          proof.Merklized,
+				ProofType:          ScopeProofProofType(proof.ProofType),
+				ScopeID:            proof.ID,
+			})
+		}
+		jwzMetadata.Proofs = &proofs
+	}
+
+	if len(verification.ScopeStatuses) > 0 {
+		statuses := make([]ScopeStatus, 0, len(verification.ScopeStatuses))
+		for _, status := range verification.ScopeStatuses {
+			scopeStatus := ScopeStatus{ScopeID: status.ID, Status: ScopeStatusStatus(status.Status)}
+			if status.Reason != "" {
+				scopeStatus.Reason = common.ToPointer(status.Reason)
+			}
+			statuses = append(statuses, scopeStatus)
+		}
+		jwzMetadata.Scopes = &statuses
+	}
+
+	resp := Status200JSONResponse{
 		JwzMetadata: jwzMetadata,
 		Status:      statusSuccess,
 	}
+	if verification.Jwz != "" {
+		resp.Jwz = common.ToPointer(verification.Jwz)
+	}
+	if verification.Provisional {
+		resp.Provisional = common.ToPointer(true)
+	}
+	if verification.Token != "" {
+		resp.Token = common.ToPointer(verification.Token)
+	}
+	return resp
 }
@@ -2,19 +2,68 @@ package api
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
 	"math/big"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	common2 "github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	"github.com/iden3/go-iden3-auth/v2/state"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-merkletree-sql/v2"
 	"github.com/iden3/iden3comm/v2/packers"
 	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/patrickmn/go-cache"
+	"github.com/piprate/json-gold/ld"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
 
+	"github.com/0xPolygonID/verifier-backend/internal/audit"
 	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	verrors "github.com/0xPolygonID/verifier-backend/internal/errors"
+	"github.com/0xPolygonID/verifier-backend/internal/events"
+	"github.com/0xPolygonID/verifier-backend/internal/hooks"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/keystore"
+	"github.com/0xPolygonID/verifier-backend/internal/lanes"
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+	"github.com/0xPolygonID/verifier-backend/internal/nullifier"
+	"github.com/0xPolygonID/verifier-backend/internal/onchain"
+	"github.com/0xPolygonID/verifier-backend/internal/sessions"
+	"github.com/0xPolygonID/verifier-backend/internal/shadow"
+	"github.com/0xPolygonID/verifier-backend/internal/signing"
+	"github.com/0xPolygonID/verifier-backend/internal/testmode"
+	"github.com/0xPolygonID/verifier-backend/internal/timing"
+	"github.com/0xPolygonID/verifier-backend/internal/users"
+	"github.com/0xPolygonID/verifier-backend/internal/webhook"
+	"github.com/0xPolygonID/verifier-backend/pkg/client"
 )
 
 const (
@@ -1037,8 +1086,9 @@ func TestSignIn(t *testing.T) {
 						Params: GetQRCodeFromStoreParams{Id: id},
 					})
 				require.NoError(t, err)
-				got, ok := rr2.(GetQRCodeFromStore200JSONResponse)
+				stored, ok := rr2.(GetQRCodeFromStore200JSONResponse)
 				require.True(t, ok)
+				got := stored.Body
 
 				require.Len(t, expected.Body.Scope, len(got.Body.Scope))
 				require.Equal(t, expected.Body.Scope, got.Body.Scope)
@@ -1076,7 +1126,52 @@ func TestSignIn(t *testing.T) {
 	}
 }
 
-func isValidaQrStoreCallback(t *testing.T, url string) uuid.UUID {
+func TestSignInBatch(t *testing.T) {
+	ctx := context.Background()
+	batchCfg := cfg
+	batchCfg.SignInBatchMaxSize = 2
+	server := New(batchCfg, nil, map[string]string{"80002": amoySenderDID})
+
+	validRequest := SignInRequest{
+		ChainID: common.ToPointer("80002"),
+		Scope: []ScopeRequest{
+			{
+				CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+				Id:        1,
+				Query: jsonToMap(t, `{
+					"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+					"allowedIssuers": ["*"],
+					"type": "KYCAgeCredential"
+				}`),
+			},
+		},
+	}
+
+	resp, err := server.SignInBatch(ctx, SignInBatchRequestObject{
+		Body: &SignInBatchJSONRequestBody{Requests: []SignInRequest{validRequest, {ChainID: common.ToPointer("80002")}}},
+	})
+	require.NoError(t, err)
+	require.IsType(t, SignInBatch200JSONResponse{}, resp)
+	results := resp.(SignInBatch200JSONResponse).Results
+	require.Len(t, results, 2)
+	require.NotNil(t, results[0].SessionID)
+	isValidaQrStoreCallback(t, *results[0].QrCode)
+	assert.Nil(t, results[0].Error)
+	assert.Nil(t, results[1].SessionID)
+	assert.Equal(t, "field scope is empty", *results[1].Error)
+
+	resp, err = server.SignInBatch(ctx, SignInBatchRequestObject{
+		Body: &SignInBatchJSONRequestBody{Requests: []SignInRequest{validRequest, validRequest, validRequest}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, SignInBatch400JSONResponse{N400JSONResponse{Message: "field requests cannot have more than 2 items"}}, resp)
+
+	resp, err = server.SignInBatch(ctx, SignInBatchRequestObject{Body: &SignInBatchJSONRequestBody{}})
+	require.NoError(t, err)
+	assert.Equal(t, SignInBatch400JSONResponse{N400JSONResponse{Message: "field requests is empty"}}, resp)
+}
+
+func isValidaQrStoreCallback(t *testing.T, url string) string {
 	t.Helper()
 	callBackURL := url
 	items := strings.Split(callBackURL, "/qr-store?")
@@ -1086,11 +1181,9 @@ func isValidaQrStoreCallback(t *testing.T, url string) uuid.UUID {
 
 	queryItems := strings.Split(items[1], "=")
 	require.Len(t, queryItems, 2)
+	require.Len(t, queryItems[1], 24)
 
-	id, err := uuid.Parse(queryItems[1])
-	require.NoError(t, err)
-
-	return id
+	return queryItems[1]
 }
 
 func isValidCallBack(t *testing.T, url string) bool {
@@ -1112,3 +1205,4096 @@ func isValidCallBack(t *testing.T, url string) bool {
 	require.NoError(t, err)
 	return true
 }
+
+func TestGetSessionResult(t *testing.T) {
+	ctx := context.Background()
+	server := New(cfg, nil, map[string]string{"80002": amoySenderDID})
+
+	pendingID, finishedID := uuid.New(), uuid.New()
+	server.cache.Set(pendingID.String(), protocol.AuthorizationRequestMessage{}, 0)
+	server.cache.Set(finishedID.String(), errors.New("proof is not valid"), 0)
+	server.setSessionOwner(finishedID, common.ToPointer("owner-key"))
+
+	resp, err := server.GetSessionResult(ctx, GetSessionResultRequestObject{SessionID: uuid.New()})
+	require.NoError(t, err)
+	assert.IsType(t, GetSessionResult404JSONResponse{}, resp)
+
+	resp, err = server.GetSessionResult(ctx, GetSessionResultRequestObject{SessionID: pendingID})
+	require.NoError(t, err)
+	assert.IsType(t, GetSessionResult409JSONResponse{}, resp)
+
+	resp, err = server.GetSessionResult(ctx, GetSessionResultRequestObject{SessionID: finishedID})
+	require.NoError(t, err)
+	assert.Equal(t, GetSessionResult200JSONResponse{
+		Status:    statusError,
+		Message:   common.ToPointer("proof is not valid"),
+		ErrorCode: common.ToPointer(verrors.CodeVerificationFailed),
+	}, resp)
+
+	// the public session id is not enough to consume the result
+	consume := GetSessionResultParams{Consume: common.ToPointer(true)}
+	resp, err = server.GetSessionResult(ctx, GetSessionResultRequestObject{SessionID: finishedID, Params: consume})
+	require.NoError(t, err)
+	assert.IsType(t, GetSessionResult401JSONResponse{}, resp)
+
+	consume.XAPIKey = common.ToPointer("other-key")
+	resp, err = server.GetSessionResult(ctx, GetSessionResultRequestObject{SessionID: finishedID, Params: consume})
+	require.NoError(t, err)
+	assert.IsType(t, GetSessionResult403JSONResponse{}, resp)
+
+	consume.XAPIKey = common.ToPointer("owner-key")
+	resp, err = server.GetSessionResult(ctx, GetSessionResultRequestObject{SessionID: finishedID, Params: consume})
+	require.NoError(t, err)
+	assert.Equal(t, GetSessionResult200JSONResponse{
+		Status:    statusError,
+		Message:   common.ToPointer("proof is not valid"),
+		ErrorCode: common.ToPointer(verrors.CodeVerificationFailed),
+	}, resp)
+
+	resp, err = server.GetSessionResult(ctx, GetSessionResultRequestObject{SessionID: finishedID, Params: consume})
+	require.NoError(t, err)
+	assert.IsType(t, GetSessionResult410JSONResponse{}, resp)
+
+	finalizeResp, err := server.FinalizeSession(ctx, FinalizeSessionRequestObject{SessionID: finishedID})
+	require.NoError(t, err)
+	assert.IsType(t, FinalizeSession401JSONResponse{}, finalizeResp)
+
+	finalizeResp, err = server.FinalizeSession(ctx, FinalizeSessionRequestObject{
+		SessionID: finishedID,
+		Params:    FinalizeSessionParams{XAPIKey: common.ToPointer("owner-key")},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, FinalizeSession410JSONResponse{}, finalizeResp)
+
+	// sessions created without an api key have no owner
+	unownedID := uuid.New()
+	server.cache.Set(unownedID.String(), errors.New("proof is not valid"), 0)
+	finalizeResp, err = server.FinalizeSession(ctx, FinalizeSessionRequestObject{
+		SessionID: unownedID,
+		Params:    FinalizeSessionParams{XAPIKey: common.ToPointer("owner-key")},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, FinalizeSession403JSONResponse{}, finalizeResp)
+
+	statusResp, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: finishedID}})
+	require.NoError(t, err)
+	assert.Equal(t, Status200JSONResponse{Status: statusConsumed}, statusResp)
+}
+
+func TestIssueToken(t *testing.T) {
+	jwtCfg := cfg
+	jwtCfg.JWT = config.JWT{Enabled: true, Audience: []string{"relying-party"}, TTL: config.CacheTTL(time.Hour)}
+	server := New(jwtCfg, nil, map[string]string{"80002": amoySenderDID})
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key, err := signing.NewKey(privateKey)
+	require.NoError(t, err)
+	server.keys.SetTenantKey("acme", key)
+
+	sessionID := uuid.New()
+	server.setSessionTenant(sessionID, "acme")
+	request := protocol.AuthorizationRequestMessage{
+		Body: protocol.AuthorizationRequestMessageBody{
+			Scope: []protocol.ZeroKnowledgeProofRequest{
+				{ID: 1, CircuitID: string(circuits.AtomicQueryV3CircuitID), Query: map[string]interface{}{"type": "KYCAgeCredential"}},
+			},
+		},
+	}
+	verification := models.VerificationResponse{
+		UserDID: "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK",
+		Scopes:  []models.VerificationResponseScope{{ID: 1, Nullifier: "123"}},
+	}
+
+	token, err := server.issueToken(sessionID, request.Body.Scope, verification)
+	require.NoError(t, err)
+
+	parsed, err := jwt.ParseSigned(token)
+	require.NoError(t, err)
+	require.Len(t, parsed.Headers, 1)
+	assert.Equal(t, key.KeyID(), parsed.Headers[0].KeyID)
+
+	var claims verificationClaims
+	require.NoError(t, parsed.Claims(&privateKey.PublicKey, &claims))
+	assert.Equal(t, verification.UserDID, claims.Subject)
+	assert.Equal(t, sessionID.String(), claims.ID)
+	assert.Equal(t, "acme", claims.Tenant)
+	assert.NoError(t, claims.Validate(jwt.Expected{Audience: jwt.Audience{"relying-party"}, Time: time.Now()}))
+	assert.Equal(t, []verifiedScope{{ID: 1, CircuitID: string(circuits.AtomicQueryV3CircuitID), Type: "KYCAgeCredential"}}, claims.Scopes)
+	assert.Equal(t, []string{"123"}, claims.Nullifiers)
+}
+
+func TestSignInLink(t *testing.T) {
+	ctx := context.Background()
+	linkCfg := cfg
+	linkCfg.UniversalLinkURL = "https://wallet.privado.id"
+	server := New(linkCfg, nil, map[string]string{"80002": amoySenderDID})
+	require.NoError(t, server.queryTemplates.Save(QueryTemplate{
+		Name:      "kyc-age",
+		CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+		Query: jsonToMap(t, `{
+			"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+			"allowedIssuers": ["*"],
+			"type": "KYCAgeCredential"
+		}`),
+	}))
+
+	resp, err := server.SignInLink(ctx, SignInLinkRequestObject{Params: SignInLinkParams{TemplateId: "kyc-age", ChainId: "80002"}})
+	require.NoError(t, err)
+	require.IsType(t, SignInLink302Response{}, resp)
+	isValidaQrStoreCallback(t, resp.(SignInLink302Response).Headers.Location)
+
+	linkType := Universal
+	resp, err = server.SignInLink(ctx, SignInLinkRequestObject{Params: SignInLinkParams{TemplateId: "kyc-age", ChainId: "80002", LinkType: &linkType}})
+	require.NoError(t, err)
+	require.IsType(t, SignInLink302Response{}, resp)
+	assert.True(t, strings.HasPrefix(resp.(SignInLink302Response).Headers.Location, "https://wallet.privado.id/#request_uri=http%3A%2F%2Flocalhost%2Fqr-store%3Fid%3D"))
+
+	resp, err = server.SignInLink(ctx, SignInLinkRequestObject{Params: SignInLinkParams{TemplateId: "unknown", ChainId: "80002"}})
+	require.NoError(t, err)
+	assert.Equal(t, SignInLink400JSONResponse{N400JSONResponse{Message: "query template unknown not found"}}, resp)
+}
+
+func TestSignedSignInLink(t *testing.T) {
+	ctx := context.WithValue(context.Background(), clientAddrKey{}, "192.0.2.1")
+	linkCfg := cfg
+	linkCfg.APIKeys = []string{"integrator-key"}
+	linkCfg.SignInLink = config.SignInLink{RateLimit: 3, RateWindow: config.CacheTTL(time.Minute)}
+	server := New(linkCfg, nil, map[string]string{"80002": amoySenderDID})
+	require.NoError(t, server.queryTemplates.Save(QueryTemplate{
+		Name:      "kyc-age",
+		CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+		Query: jsonToMap(t, `{
+			"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+			"allowedIssuers": ["*"],
+			"type": "KYCAgeCredential"
+		}`),
+	}))
+	signedLink := func(key string, expires time.Time) SignInLinkParams {
+		link, err := client.SignInLinkURL("http://localhost", key, "kyc-age", "80002", expires)
+		require.NoError(t, err)
+		u, err := url.Parse(link)
+		require.NoError(t, err)
+		params := SignInLinkParams{
+			TemplateId: u.Query().Get("templateId"),
+			ChainId:    u.Query().Get("chainId"),
+			Signature:  common.ToPointer(u.Query().Get("signature")),
+		}
+		if e := u.Query().Get("expires"); e != "" {
+			expiresAt, err := strconv.ParseInt(e, 10, 64)
+			require.NoError(t, err)
+			params.Expires = &expiresAt
+		}
+		return params
+	}
+
+	resp, err := server.SignInLink(ctx, SignInLinkRequestObject{Params: signedLink("integrator-key", time.Now().Add(time.Hour))})
+	require.NoError(t, err)
+	require.IsType(t, SignInLink302Response{}, resp)
+
+	resp, err = server.SignInLink(ctx, SignInLinkRequestObject{Params: signedLink("other-key", time.Time{})})
+	require.NoError(t, err)
+	assert.Equal(t, SignInLink401JSONResponse{N401JSONResponse{Message: "the signature of the sign-in link is invalid"}}, resp)
+
+	resp, err = server.SignInLink(ctx, SignInLinkRequestObject{Params: signedLink("integrator-key", time.Now().Add(-time.Minute))})
+	require.NoError(t, err)
+	assert.Equal(t, SignInLink401JSONResponse{N401JSONResponse{Message: "the sign-in link expired"}}, resp)
+
+	// the address opened its 3 links of the window
+	resp, err = server.SignInLink(ctx, SignInLinkRequestObject{Params: signedLink("integrator-key", time.Time{})})
+	require.NoError(t, err)
+	assert.Equal(t, SignInLink429JSONResponse{N429JSONResponse{Message: "too many sign-in link requests, try again later"}}, resp)
+}
+
+func TestGetSenderDID(t *testing.T) {
+	type testConfig struct {
+		name     string
+		cfg      config.SenderDID
+		chainID  string
+		expected string
+		err      string
+	}
+
+	for _, tc := range []testConfig{
+		{
+			name:     "configured chain",
+			cfg:      config.SenderDID{Fallback: config.SenderDIDFallbackNone},
+			chainID:  "80002",
+			expected: amoySenderDID,
+		},
+		{
+			name:    "no fallback",
+			cfg:     config.SenderDID{Fallback: config.SenderDIDFallbackNone},
+			chainID: "137",
+			err:     "sender not found for chainID 137",
+		},
+		{
+			name:     "default fallback",
+			cfg:      config.SenderDID{Fallback: config.SenderDIDFallbackDefault, DefaultDID: amoySenderDID},
+			chainID:  "137",
+			expected: amoySenderDID,
+		},
+		{
+			name:     "derive fallback",
+			cfg:      config.SenderDID{Fallback: config.SenderDIDFallbackDerive, EthAddress: "0x8D7F2E0f2B7b0C4A2e2F5a3b6D1bAe2E8c3e4A1F", DIDMethod: "iden3"},
+			chainID:  "80002",
+			expected: "did:iden3:polygon:amoy:x6x5sor7zpy8TSnh2d6XZU9UP1cRvTp7h7xWPen8L",
+		},
+		{
+			name:    "derive fallback with unknown chain",
+			cfg:     config.SenderDID{Fallback: config.SenderDIDFallbackDerive, EthAddress: "0x8D7F2E0f2B7b0C4A2e2F5a3b6D1bAe2E8c3e4A1F", DIDMethod: "iden3"},
+			chainID: "1234567",
+			err:     "sender not found for chainID 1234567",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			senderCfg := cfg
+			senderCfg.SenderDID = tc.cfg
+			senderDIDs := map[string]string{}
+			if tc.cfg.Fallback == config.SenderDIDFallbackNone {
+				senderDIDs["80002"] = amoySenderDID
+			}
+			server := New(senderCfg, nil, senderDIDs)
+
+			did, err := server.getSenderDID(tc.chainID)
+			if tc.err != "" {
+				require.Error(t, err)
+				assert.Equal(t, tc.err, i18n.Localize(context.Background(), err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, did)
+		})
+	}
+}
+
+func TestSessionTags(t *testing.T) {
+	ctx := context.Background()
+	tagsCfg := cfg
+	tagsCfg.AdminAPIKeys = []string{"admin"}
+	server := New(tagsCfg, nil, map[string]string{"80002": amoySenderDID})
+	admin := common.ToPointer("admin")
+
+	signIn := func(tags ...string) SignInResponseObject {
+		resp, err := server.SignIn(ctx, SignInRequestObject{
+			Body: &SignInJSONRequestBody{
+				ChainID: common.ToPointer("80002"),
+				Tags:    &tags,
+				Scope: []ScopeRequest{
+					{
+						Id:        1,
+						CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+						Query: jsonToMap(t, `{
+							"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+							"allowedIssuers": ["*"],
+							"type": "KYCAgeCredential"
+						}`),
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := signIn("campaign:spring airdrop")
+	assert.Equal(t, SignIn400JSONResponse{N400JSONResponse{Message: "invalid tag campaign:spring airdrop, tags can only contain letters, digits, and the characters _ . : - and have up to 64 characters"}}, resp)
+
+	first := signIn("campaign:spring-airdrop", "region:eu").(SignIn200JSONResponse)
+	second := signIn("campaign:spring-airdrop").(SignIn200JSONResponse)
+	signIn("campaign:summer")
+
+	_, err := server.GetQRCodeFromStore(ctx, GetQRCodeFromStoreRequestObject{Params: GetQRCodeFromStoreParams{Id: isValidaQrStoreCallback(t, first.QrCode)}})
+	require.NoError(t, err)
+	server.cache.Set(first.SessionID.String(), errors.New("proof is not valid"), 0)
+	server.tags.finish(first.SessionID, false)
+	server.cache.Set(first.SessionID.String(), models.VerificationResponse{}, 0)
+	server.tags.finish(first.SessionID, true)
+
+	sessions, err := server.SearchSessions(ctx, SearchSessionsRequestObject{Params: SearchSessionsParams{Tag: "campaign:spring-airdrop", XAPIKey: admin}})
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, second.SessionID, sessions.(SearchSessions200JSONResponse)[0].SessionID)
+	assert.Equal(t, statusPending, sessions.(SearchSessions200JSONResponse)[0].Status)
+	assert.Equal(t, statusSuccess, sessions.(SearchSessions200JSONResponse)[1].Status)
+
+	status := SessionStatusSuccess
+	sessions, err = server.SearchSessions(ctx, SearchSessionsRequestObject{Params: SearchSessionsParams{Tag: "campaign:spring-airdrop", Status: &status, XAPIKey: admin}})
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, []string{"campaign:spring-airdrop", "region:eu"}, sessions.(SearchSessions200JSONResponse)[0].Tags)
+
+	stats, err := server.GetTagStats(ctx, GetTagStatsRequestObject{Params: GetTagStatsParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	assert.Equal(t, GetTagStats200JSONResponse{
+		{Tag: "campaign:spring-airdrop", Created: 2, Scanned: 1, Verified: 1},
+		{Tag: "campaign:summer", Created: 1},
+		{Tag: "region:eu", Created: 1, Scanned: 1, Verified: 1},
+	}, stats)
+
+	stats, err = server.GetTagStats(ctx, GetTagStatsRequestObject{})
+	require.NoError(t, err)
+	assert.IsType(t, GetTagStats401JSONResponse{}, stats)
+}
+
+type fakeShortener struct {
+	err error
+}
+
+func (f fakeShortener) Shorten(_ context.Context, _ string) (string, error) {
+	return "https://s.example.com/abc", f.err
+}
+
+func TestQRCodeStore(t *testing.T) {
+	ctx := context.Background()
+	server := New(cfg, nil, map[string]string{"80002": amoySenderDID})
+
+	token, err := server.qrStore.Save(QRCode{From: amoySenderDID, Typ: string(packers.MediaTypePlainMessage)})
+	require.NoError(t, err)
+	resp, err := server.GetQRCodeFromStore(ctx, GetQRCodeFromStoreRequestObject{Params: GetQRCodeFromStoreParams{Id: token}})
+	require.NoError(t, err)
+	stored := resp.(GetQRCodeFromStore200JSONResponse)
+	assert.Equal(t, amoySenderDID, stored.Body.From)
+	assert.Equal(t, `"`+token+`"`, stored.Headers.ETag)
+	assert.Equal(t, "private, max-age=3600", stored.Headers.CacheControl)
+
+	// wallets revalidate the cached qr codes with their ETag
+	resp, err = server.GetQRCodeFromStore(ctx, GetQRCodeFromStoreRequestObject{Params: GetQRCodeFromStoreParams{Id: token, IfNoneMatch: common.ToPointer(`W/"other", "` + token + `"`)}})
+	require.NoError(t, err)
+	assert.Equal(t, GetQRCodeFromStore304Response{GetQRCodeFromStore304ResponseHeaders{CacheControl: "private, max-age=3600", ETag: `"` + token + `"`}}, resp)
+
+	// the qr codes are not cached longer than their session
+	shortSessions := cfg
+	shortSessions.SessionTTL = config.CacheTTL(10 * time.Minute)
+	shortServer := New(shortSessions, nil, map[string]string{"80002": amoySenderDID})
+	shortToken, err := shortServer.qrStore.Save(QRCode{From: amoySenderDID, Typ: string(packers.MediaTypePlainMessage)})
+	require.NoError(t, err)
+	resp, err = shortServer.GetQRCodeFromStore(ctx, GetQRCodeFromStoreRequestObject{Params: GetQRCodeFromStoreParams{Id: shortToken}})
+	require.NoError(t, err)
+	assert.Equal(t, "private, max-age=600", resp.(GetQRCodeFromStore200JSONResponse).Headers.CacheControl)
+
+	tampered := []byte(token)
+	tampered[0] ^= 1
+	for _, id := range []string{string(tampered), token[:12], "89d298fa-15a6-4a1d-ab13-d1069467eedd"} {
+		resp, err = server.GetQRCodeFromStore(ctx, GetQRCodeFromStoreRequestObject{Params: GetQRCodeFromStoreParams{Id: id}})
+		require.NoError(t, err)
+		assert.Equal(t, GetQRCodeFromStore404JSONResponse{N404JSONResponse{Message: "qr code not found or expired"}}, resp)
+	}
+
+	// tokens signed with another secret are rejected
+	other := NewQRCodeStore(server.cache, []byte("other secret"))
+	_, err = other.Get(token)
+	assert.ErrorIs(t, err, errQRCodeNotFound)
+
+	linkCfg := cfg
+	linkCfg.QRLink.BaseURL = "https://qr.example.com"
+	server = New(linkCfg, nil, map[string]string{"80002": amoySenderDID})
+	assert.Equal(t, "https://qr.example.com?id="+token, server.qrCodeLink(ctx, token, nil))
+
+	server = New(linkCfg, nil, map[string]string{"80002": amoySenderDID}, WithURLShortener(fakeShortener{}))
+	assert.Equal(t, "https://s.example.com/abc", server.qrCodeLink(ctx, token, nil))
+
+	server = New(linkCfg, nil, map[string]string{"80002": amoySenderDID}, WithURLShortener(fakeShortener{err: errors.New("unavailable")}))
+	assert.Equal(t, "https://qr.example.com?id="+token, server.qrCodeLink(ctx, token, nil))
+}
+
+func TestCallbackRetry(t *testing.T) {
+	ctx := context.Background()
+	// the server has no verifier, so the retries must not verify the token again
+	server := New(cfg, nil, map[string]string{"80002": amoySenderDID})
+	sessionID := uuid.New()
+	server.cache.Set(sessionID.String(), models.VerificationResponse{Jwz: "jwz-token", UserDID: amoySenderDID}, 0)
+
+	callback := func(token string) CallbackResponseObject {
+		resp, err := server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: sessionID}, Body: &token})
+		require.NoError(t, err)
+		return resp
+	}
+
+	assert.Equal(t, Callback200JSONResponse{}, callback("jwz-token"))
+	assert.IsType(t, Callback500JSONResponse{}, callback("another-jwz-token"))
+
+	_, err := server.takeSessionResult(sessionID, true)
+	require.NoError(t, err)
+	assert.Equal(t, Callback200JSONResponse{}, callback("jwz-token"))
+	assert.IsType(t, Callback500JSONResponse{}, callback("another-jwz-token"))
+}
+
+// sigV2PubSignals returns the pub signals of a credentialAtomicQuerySigV2 proof generated at timestamp
+func sigV2PubSignals(timestamp int64) []string {
+	signals := []string{
+		"0",
+		"23148936466334350744548790012294489365207440754509988986684797708370051073",
+		"2943483356559152311923412925436024635269538717812859789851139200242297094",
+		"23",
+		"21933750065545691586450392143787330185992517860945727248803138245838110721",
+		"1",
+		"2943483356559152311923412925436024635269538717812859789851139200242297094",
+		strconv.FormatInt(timestamp, 10),
+		"180410020913331409885634153623124536270",
+		"0",
+		"0",
+		"2",
+		"1",
+		"10",
+	}
+	for len(signals) < 77 {
+		signals = append(signals, "0")
+	}
+	return signals
+}
+
+func TestCheckCredentialExpiration(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	expirationCfg := cfg
+	expirationCfg.Expiration = config.Expiration{Enabled: true, MaxProofAge: config.CacheTTL(time.Hour), Fields: []string{"expirationDate", "validUntil"}}
+
+	type testConfig struct {
+		name        string
+		disabled    bool
+		generatedAt time.Time
+		vp          string
+		err         string
+	}
+
+	for _, tc := range []testConfig{
+		{
+			name:        "recent proof without disclosed expiration",
+			generatedAt: now.Add(-time.Minute),
+		},
+		{
+			name:        "outdated proof",
+			generatedAt: now.Add(-2 * time.Hour),
+			err:         "the proof of scope 1 was generated at 2025-06-15T10:00:00Z, the credential may have expired since",
+		},
+		{
+			name:        "outdated proof with the check disabled",
+			disabled:    true,
+			generatedAt: now.Add(-2 * time.Hour),
+		},
+		{
+			name:        "disclosed date in the future",
+			generatedAt: now.Add(-time.Minute),
+			vp:          `{"verifiableCredential": {"credentialSubject": {"expirationDate": "2025-06-15"}}}`,
+		},
+		{
+			name:        "disclosed date in the past",
+			generatedAt: now.Add(-time.Minute),
+			vp:          `{"verifiableCredential": {"credentialSubject": {"expirationDate": "2025-06-14"}}}`,
+			err:         "the credential of scope 1 expired on 2025-06-15T00:00:00Z",
+		},
+		{
+			name:        "disclosed integer date in the past",
+			generatedAt: now.Add(-time.Minute),
+			vp:          `{"verifiableCredential": {"credentialSubject": {"validUntil": 20250601}}}`,
+			err:         "the credential of scope 1 expired on 2025-06-02T00:00:00Z",
+		},
+		{
+			name:        "disclosed timestamp in the past",
+			generatedAt: now.Add(-time.Minute),
+			vp:          `{"verifiableCredential": {"credentialSubject": {"validUntil": 1749985200, "expirationDate": "2026-01-01T00:00:00Z"}}}`,
+			err:         "the credential of scope 1 expired on 2025-06-15T11:00:00Z",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testCfg := expirationCfg
+			testCfg.Expiration.Enabled = !tc.disabled
+			server := New(testCfg, nil, map[string]string{"80002": amoySenderDID})
+
+			scope := protocol.ZeroKnowledgeProofResponse{ID: 1, CircuitID: string(circuits.AtomicQuerySigV2CircuitID)}
+			scope.PubSignals = sigV2PubSignals(tc.generatedAt.Unix())
+			if tc.vp != "" {
+				scope.VerifiablePresentation = []byte(tc.vp)
+			}
+			response := protocol.AuthorizationResponseMessage{Body: protocol.AuthorizationMessageResponseBody{
+				Scope: []protocol.ZeroKnowledgeProofResponse{scope},
+			}}
+
+			err := server.checkCredentialExpiration(response, now)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestValidateUniqueNullifier(t *testing.T) {
+	v3Scope := func(params *ScopeParams) ScopeRequest {
+		return ScopeRequest{Id: 1, CircuitId: string(circuits.AtomicQueryV3CircuitID), Params: params}
+	}
+
+	type testConfig struct {
+		name   string
+		enable *bool
+		scope  ScopeRequest
+		err    string
+	}
+
+	for _, tc := range []testConfig{
+		{
+			name:  "not enforced",
+			scope: ScopeRequest{Id: 1, CircuitId: string(circuits.AtomicQuerySigV2CircuitID)},
+		},
+		{
+			name:   "v3 scope with nullifier session",
+			enable: common.ToPointer(true),
+			scope:  v3Scope(&ScopeParams{"nullifierSessionID": "123456"}),
+		},
+		{
+			name:   "v3 scope without params",
+			enable: common.ToPointer(true),
+			scope:  v3Scope(nil),
+			err:    "enforceUniqueNullifier requires scope 1 to use the credentialAtomicQueryV3-beta.1 circuit with a nullifierSessionID param",
+		},
+		{
+			name:   "v3 scope with an empty nullifier session",
+			enable: common.ToPointer(true),
+			scope:  v3Scope(&ScopeParams{"nullifierSessionID": "0"}),
+			err:    "enforceUniqueNullifier requires scope 1 to use the credentialAtomicQueryV3-beta.1 circuit with a nullifierSessionID param",
+		},
+		{
+			name:   "sig v2 scope",
+			enable: common.ToPointer(true),
+			scope:  ScopeRequest{Id: 1, CircuitId: string(circuits.AtomicQuerySigV2CircuitID)},
+			err:    "enforceUniqueNullifier requires scope 1 to use the credentialAtomicQueryV3-beta.1 circuit with a nullifierSessionID param",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateUniqueNullifier(&SignInRequest{EnforceUniqueNullifier: tc.enable, Scope: []ScopeRequest{tc.scope}})
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTrustProfile(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	scope := protocol.ZeroKnowledgeProofResponse{ID: 1, CircuitID: string(circuits.AtomicQuerySigV2CircuitID)}
+	scope.PubSignals = sigV2PubSignals(now.Add(-time.Minute).Unix())
+	issuer, err := getProofIssuer(scope)
+	require.NoError(t, err)
+
+	profileCfg := cfg
+	profileCfg.TrustProfiles = []config.TrustProfile{{
+		Name:           "kyc",
+		AllowedIssuers: []string{issuer, "did:iden3:polygon:amoy:xJNgo2KgA4wNDLXy9hrKmVyB3qQjLHUj1PDFeThDV"},
+		Schemas:        []config.TrustProfileSchema{{Type: "KYCAgeCredential"}},
+		Operators:      []string{"$lt", "$eq"},
+		Revocation:     config.RevocationPolicyRequired,
+		MaxProofAge:    10 * time.Minute,
+	}}
+	server := New(profileCfg, nil, map[string]string{"80002": amoySenderDID})
+
+	type testConfig struct {
+		name    string
+		profile string
+		query   Query
+		err     string
+	}
+
+	for _, tc := range []testConfig{
+		{
+			name:    "unknown profile",
+			profile: "aml",
+			query:   Query{"type": "KYCAgeCredential"},
+			err:     "trust profile aml not found",
+		},
+		{
+			name:    "schema not allowed",
+			profile: "kyc",
+			query:   Query{"type": "KYCCountryOfResidenceCredential"},
+			err:     "the trust profile kyc does not allow the credential type KYCCountryOfResidenceCredential of scope 1",
+		},
+		{
+			name:    "operator not allowed",
+			profile: "kyc",
+			query:   Query{"type": "KYCAgeCredential", "credentialSubject": map[string]interface{}{"birthday": map[string]interface{}{"$gt": 20000101}}},
+			err:     "the trust profile kyc does not allow the operator $gt of scope 1",
+		},
+		{
+			name:    "selective disclosure not allowed",
+			profile: "kyc",
+			query:   Query{"type": "KYCAgeCredential", "credentialSubject": map[string]interface{}{"birthday": map[string]interface{}{}}},
+			err:     "the trust profile kyc does not allow the operator $sd of scope 1",
+		},
+		{
+			name:    "issuer not allowed",
+			profile: "kyc",
+			query:   Query{"type": "KYCAgeCredential", "allowedIssuers": []interface{}{"did:iden3:polygon:amoy:x6suHR8HkEYczV9yVeAKKiXCZAd25P8WS6QvNhszk"}},
+			err:     "the trust profile kyc does not allow the issuer did:iden3:polygon:amoy:x6suHR8HkEYczV9yVeAKKiXCZAd25P8WS6QvNhszk of scope 1",
+		},
+		{
+			name:    "revocation check skipped",
+			profile: "kyc",
+			query:   Query{"type": "KYCAgeCredential", "skipClaimRevocationCheck": true},
+			err:     "the trust profile kyc requires the revocation check of scope 1",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			body := &SignInRequest{TrustProfile: &tc.profile, Scope: []ScopeRequest{{Id: 1, Query: tc.query}}}
+			_, err := server.applyTrustProfile(body)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+
+	profile := "kyc"
+	body := &SignInRequest{TrustProfile: &profile, Scope: []ScopeRequest{{Id: 1, Query: Query{
+		"type":              "KYCAgeCredential",
+		"allowedIssuers":    []interface{}{"*"},
+		"credentialSubject": map[string]interface{}{"birthday": map[string]interface{}{"$lt": 20000101}},
+	}}}}
+	name, err := server.applyTrustProfile(body)
+	require.NoError(t, err)
+	assert.Equal(t, "kyc", name)
+	assert.Equal(t, profileCfg.TrustProfiles[0].AllowedIssuers, body.Scope[0].Query["allowedIssuers"])
+	assert.Equal(t, false, body.Scope[0].Query["skipClaimRevocationCheck"])
+
+	sessionID := uuid.New()
+	response := protocol.AuthorizationResponseMessage{Body: protocol.AuthorizationMessageResponseBody{
+		Scope: []protocol.ZeroKnowledgeProofResponse{scope},
+	}}
+	require.NoError(t, server.checkTrustProfile(sessionID, response, now))
+	server.setSessionTrustProfile(sessionID, name)
+	require.NoError(t, server.checkTrustProfile(sessionID, response, now))
+	assert.EqualError(t, server.checkTrustProfile(sessionID, response, now.Add(time.Hour)),
+		"the trust profile kyc only accepts proofs generated in the last 10m0s, the proof of scope 1 was generated at 2025-06-15T11:59:00Z")
+}
+
+func TestSignInUnique(t *testing.T) {
+	ctx := context.Background()
+	server := New(cfg, nil, map[string]string{"80002": amoySenderDID})
+	nullifierSessionID := server.campaignNullifierSession(nil, "spring-airdrop", "").NullifierSessionID
+
+	resp, err := server.SignInUnique(ctx, SignInUniqueRequestObject{Body: &SignInUniqueRequest{
+		Campaign: "spring-airdrop",
+		ChainID:  "80002",
+		Scope: []ScopeRequest{{
+			Id: 1,
+			Query: jsonToMap(t, `{
+				"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+				"allowedIssuers": ["*"],
+				"type": "KYCAgeCredential"
+			}`),
+		}},
+	}})
+	require.NoError(t, err)
+	require.IsType(t, SignInUnique200JSONResponse{}, resp)
+	sessionID := resp.(SignInUnique200JSONResponse).SessionID
+
+	item, ok := server.cache.Get(sessionID.String())
+	require.True(t, ok)
+	authReq := item.(protocol.AuthorizationRequestMessage)
+	assert.Equal(t, string(circuits.AtomicQueryV3CircuitID), authReq.Body.Scope[0].CircuitID)
+	assert.Equal(t, map[string]interface{}{"nullifierSessionId": nullifierSessionID}, authReq.Body.Scope[0].Params)
+	_, ok = server.cache.Get(uniqueNullifierKeyPrefix + sessionID.String())
+	assert.True(t, ok)
+
+	resp, err = server.SignInUnique(ctx, SignInUniqueRequestObject{Body: &SignInUniqueRequest{Campaign: "spring airdrop", ChainID: "80002"}})
+	require.NoError(t, err)
+	assert.Equal(t, SignInUnique400JSONResponse{N400JSONResponse{Message: invalidCampaignName}}, resp)
+
+	_, err = server.nullifierStore.Claim(ctx, []nullifier.Key{
+		{SessionID: nullifierSessionID, Nullifier: "1234"},
+		{SessionID: "1", Nullifier: "5678"},
+	})
+	require.NoError(t, err)
+
+	nullifiers, err := server.GetCampaignNullifiers(ctx, GetCampaignNullifiersRequestObject{Campaign: "spring-airdrop"})
+	require.NoError(t, err)
+	assert.Equal(t, GetCampaignNullifiers200JSONResponse{
+		Campaign:           "spring-airdrop",
+		NullifierSessionID: nullifierSessionID,
+		Nullifiers:         []string{"1234"},
+	}, nullifiers)
+
+	nullifiers, err = server.GetCampaignNullifiers(ctx, GetCampaignNullifiersRequestObject{
+		Campaign: "spring-airdrop",
+		Params:   GetCampaignNullifiersParams{Nullifier: common.ToPointer("5678")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, nullifiers.(GetCampaignNullifiers200JSONResponse).Nullifiers)
+}
+
+func TestCampaignNullifierSession(t *testing.T) {
+	ctx := context.Background()
+	server := New(cfg, nil, map[string]string{"80002": amoySenderDID})
+
+	resp, err := server.GetCampaignNullifierSession(ctx, GetCampaignNullifierSessionRequestObject{Campaign: "spring-airdrop"})
+	require.NoError(t, err)
+	assert.Equal(t, GetCampaignNullifierSession200JSONResponse{
+		Campaign:           "spring-airdrop",
+		Derivation:         nullifier.DerivationCampaign,
+		NullifierSessionID: nullifier.SessionID("campaign", "", "spring-airdrop").String(),
+	}, resp)
+
+	resp, err = server.GetCampaignNullifierSession(ctx, GetCampaignNullifierSessionRequestObject{Campaign: "spring airdrop"})
+	require.NoError(t, err)
+	assert.Equal(t, GetCampaignNullifierSession400JSONResponse{N400JSONResponse{Message: invalidCampaignName}}, resp)
+
+	namespaced := cfg
+	namespaced.Nullifiers.Derivation = nullifier.DerivationCredentialType
+	namespaced.Nullifiers.Namespace = "staging"
+	server = New(namespaced, nil, map[string]string{"80002": amoySenderDID})
+	resp, err = server.GetCampaignNullifierSession(ctx, GetCampaignNullifierSessionRequestObject{
+		Campaign: "spring-airdrop",
+		Params:   GetCampaignNullifierSessionParams{CredentialType: common.ToPointer("KYCAgeCredential")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, GetCampaignNullifierSession200JSONResponse{
+		Campaign:           "spring-airdrop",
+		CredentialType:     common.ToPointer("KYCAgeCredential"),
+		Derivation:         nullifier.DerivationCredentialType,
+		Namespace:          common.ToPointer("staging"),
+		NullifierSessionID: nullifier.SessionID("staging", "credential-type", "", "spring-airdrop", "KYCAgeCredential").String(),
+	}, resp)
+}
+
+func TestGetScopeProofs(t *testing.T) {
+	genesis := protocol.ZeroKnowledgeProofResponse{ID: 1, CircuitID: string(circuits.AtomicQuerySigV2CircuitID)}
+	genesis.PubSignals = sigV2PubSignals(time.Now().Unix())
+	published := protocol.ZeroKnowledgeProofResponse{ID: 2, CircuitID: string(circuits.AtomicQuerySigV2CircuitID)}
+	published.PubSignals = sigV2PubSignals(time.Now().Unix())
+	published.PubSignals[0] = "1"
+	published.PubSignals[2] = "12345"
+	linked := protocol.ZeroKnowledgeProofResponse{ID: 3, CircuitID: string(circuits.LinkedMultiQuery10CircuitID)}
+	issuer, err := getProofIssuer(genesis)
+	require.NoError(t, err)
+
+	proofs, err := getScopeProofs([]protocol.ZeroKnowledgeProofResponse{genesis, published, linked})
+	require.NoError(t, err)
+	assert.Equal(t, []models.ScopeProof{
+		{
+			ID:                 1,
+			ProofType:          "BJJSignature2021",
+			IssuerDID:          issuer,
+			IssuerState:        "0629eeb209f60a1e1318e6d51af12b5648360249b2f1998ac2e79edd7baa0100",
+			IssuerStateGenesis: true,
+		},
+		{
+			ID:          2,
+			ProofType:   "BJJSignature2021",
+			IssuerDID:   issuer,
+			IssuerState: "3930000000000000000000000000000000000000000000000000000000000000",
+			Merklized:   true,
+		},
+	}, proofs)
+
+	status := getStatusVerificationResponse(models.VerificationResponse{Proofs: proofs}, nil)
+	require.NotNil(t, status.JwzMetadata.Proofs)
+	assert.Equal(t, BJJSignature2021, (*status.JwzMetadata.Proofs)[0].ProofType)
+	assert.True(t, (*status.JwzMetadata.Proofs)[0].IssuerStateGenesis)
+}
+
+func TestReconcileScopes(t *testing.T) {
+	sigV2 := string(circuits.AtomicQuerySigV2CircuitID)
+	answer := protocol.ZeroKnowledgeProofResponse{ID: 1, CircuitID: sigV2}
+	answer.PubSignals = sigV2PubSignals(time.Now().Unix())
+	issuer, err := getProofIssuer(answer)
+	require.NoError(t, err)
+	disclosure := answer
+	disclosure.VerifiablePresentation = []byte(`{"verifiableCredential":{"@context":["https://www.w3.org/2018/credentials/v1","https://example.com/kyc-v3.json-ld"],` +
+		`"@type":["VerifiableCredential","KYCAgeCredential"],"credentialSubject":{"@type":"KYCAgeCredential","birthday":19960424}}}`)
+	kycQuery := map[string]interface{}{
+		"context":           "https://example.com/kyc-v3.json-ld",
+		"type":              "KYCAgeCredential",
+		"allowedIssuers":    []interface{}{issuer},
+		"credentialSubject": map[string]interface{}{"birthday": map[string]interface{}{}},
+	}
+
+	type testConfig struct {
+		name      string
+		requested []protocol.ZeroKnowledgeProofRequest
+		answers   []protocol.ZeroKnowledgeProofResponse
+		expected  []models.ScopeStatus
+		err       string
+	}
+	for _, tc := range []testConfig{
+		{
+			name:      "satisfied",
+			requested: []protocol.ZeroKnowledgeProofRequest{{ID: 1, CircuitID: sigV2, Query: kycQuery}},
+			answers:   []protocol.ZeroKnowledgeProofResponse{disclosure},
+			expected:  []models.ScopeStatus{{ID: 1, Status: scopeStatusSatisfied}},
+		},
+		{
+			name: "optional scope missing",
+			requested: []protocol.ZeroKnowledgeProofRequest{
+				{ID: 1, CircuitID: sigV2, Query: kycQuery},
+				{ID: 2, CircuitID: sigV2, Query: kycQuery, Optional: common.ToPointer(true)},
+			},
+			answers: []protocol.ZeroKnowledgeProofResponse{answer},
+			expected: []models.ScopeStatus{
+				{ID: 1, Status: scopeStatusSatisfied},
+				{ID: 2, Status: scopeStatusMissing, Reason: "the scope was not answered"},
+			},
+		},
+		{
+			name: "scope missing",
+			requested: []protocol.ZeroKnowledgeProofRequest{
+				{ID: 1, CircuitID: sigV2, Query: kycQuery},
+				{ID: 2, CircuitID: sigV2, Query: kycQuery},
+			},
+			answers: []protocol.ZeroKnowledgeProofResponse{answer},
+			expected: []models.ScopeStatus{
+				{ID: 1, Status: scopeStatusSatisfied},
+				{ID: 2, Status: scopeStatusMissing, Reason: "the scope was not answered"},
+			},
+			err: "the response does not satisfy scope 2: the scope was not answered",
+		},
+		{
+			name:      "different circuit",
+			requested: []protocol.ZeroKnowledgeProofRequest{{ID: 1, CircuitID: string(circuits.AtomicQueryMTPV2CircuitID), Query: kycQuery}},
+			answers:   []protocol.ZeroKnowledgeProofResponse{answer},
+			expected: []models.ScopeStatus{{ID: 1, Status: scopeStatusMismatched,
+				Reason: "circuit credentialAtomicQueryMTPV2 was requested, credentialAtomicQuerySigV2 was presented"}},
+			err: "the response does not satisfy scope 1: circuit credentialAtomicQueryMTPV2 was requested, credentialAtomicQuerySigV2 was presented",
+		},
+		{
+			name:      "issuer not allowed",
+			requested: []protocol.ZeroKnowledgeProofRequest{{ID: 1, CircuitID: sigV2, Query: map[string]interface{}{"allowedIssuers": []interface{}{amoySenderDID}}}},
+			answers:   []protocol.ZeroKnowledgeProofResponse{answer},
+			expected:  []models.ScopeStatus{{ID: 1, Status: scopeStatusMismatched, Reason: "issuer " + issuer + " is not allowed"}},
+			err:       "the response does not satisfy scope 1: issuer " + issuer + " is not allowed",
+		},
+		{
+			name:      "credential type not requested",
+			requested: []protocol.ZeroKnowledgeProofRequest{{ID: 1, CircuitID: sigV2, Query: map[string]interface{}{"type": "KYCCountryOfResidenceCredential"}}},
+			answers:   []protocol.ZeroKnowledgeProofResponse{disclosure},
+			expected: []models.ScopeStatus{{ID: 1, Status: scopeStatusMismatched,
+				Reason: "credential type KYCCountryOfResidenceCredential was requested, [VerifiableCredential KYCAgeCredential] was presented"}},
+			err: "the response does not satisfy scope 1: credential type KYCCountryOfResidenceCredential was requested, [VerifiableCredential KYCAgeCredential] was presented",
+		},
+		{
+			name:      "field not requested",
+			requested: []protocol.ZeroKnowledgeProofRequest{{ID: 1, CircuitID: sigV2, Query: map[string]interface{}{"type": "KYCAgeCredential"}}},
+			answers:   []protocol.ZeroKnowledgeProofResponse{disclosure},
+			expected:  []models.ScopeStatus{{ID: 1, Status: scopeStatusMismatched, Reason: "field birthday was disclosed without being requested"}},
+			err:       "the response does not satisfy scope 1: field birthday was disclosed without being requested",
+		},
+		{
+			name:      "scope not requested",
+			requested: []protocol.ZeroKnowledgeProofRequest{{ID: 2, CircuitID: sigV2, Query: kycQuery}},
+			answers:   []protocol.ZeroKnowledgeProofResponse{answer, func() protocol.ZeroKnowledgeProofResponse { a := answer; a.ID = 2; return a }()},
+			expected: []models.ScopeStatus{
+				{ID: 2, Status: scopeStatusSatisfied},
+				{ID: 1, Status: scopeStatusMismatched, Reason: "the scope was not requested"},
+			},
+			err: "the response does not satisfy scope 1: the scope was not requested",
+		},
+		{
+			name:      "scope answered twice",
+			requested: []protocol.ZeroKnowledgeProofRequest{{ID: 1, CircuitID: sigV2, Query: kycQuery}},
+			answers:   []protocol.ZeroKnowledgeProofResponse{answer, answer},
+			expected:  []models.ScopeStatus{{ID: 1, Status: scopeStatusMismatched, Reason: "the scope was answered 2 times"}},
+			err:       "the response does not satisfy scope 1: the scope was answered 2 times",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			request := protocol.AuthorizationRequestMessage{Body: protocol.AuthorizationRequestMessageBody{Scope: tc.requested}}
+			response := protocol.AuthorizationResponseMessage{Body: protocol.AuthorizationMessageResponseBody{Scope: tc.answers}}
+			statuses, err := reconcileScopes(request, response)
+			assert.Equal(t, tc.expected, statuses)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestRequiredScopes(t *testing.T) {
+	sigV2 := string(circuits.AtomicQuerySigV2CircuitID)
+	type testConfig struct {
+		name     string
+		required *int
+		circuit  string
+		err      string
+		stored   bool
+		groupID  any
+		scopes   int
+	}
+	for _, tc := range []testConfig{
+		{name: "all scopes by default", circuit: sigV2},
+		{name: "all scopes", required: common.ToPointer(2), circuit: sigV2},
+		{name: "one of two scopes", required: common.ToPointer(1), circuit: sigV2, stored: true},
+		{name: "no scope", required: common.ToPointer(0), circuit: sigV2, err: "requiredScopes must be between 1 and the number of scopes 2"},
+		{name: "more than the scopes", required: common.ToPointer(3), circuit: sigV2, err: "requiredScopes must be between 1 and the number of scopes 2"},
+		{
+			name:     "on-chain",
+			required: common.ToPointer(1),
+			circuit:  string(circuits.AtomicQuerySigV2OnChainCircuitID),
+			err:      "requiredScopes is not supported by on-chain verifications",
+		},
+		{
+			name:     "linked scopes",
+			required: common.ToPointer(1),
+			circuit:  string(circuits.AtomicQueryV3CircuitID),
+			groupID:  float64(1),
+			err:      "requiredScopes cannot be used with linked scopes, scope 2 has a groupId",
+		},
+		{name: "unlinked scopes", required: common.ToPointer(1), circuit: sigV2, groupID: float64(0), stored: true},
+		{
+			name:     "too many scopes",
+			required: common.ToPointer(1),
+			circuit:  sigV2,
+			scopes:   maxRequiredScopesScopes + 1,
+			err:      "requiredScopes can be used with at most 5 scopes",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := New(cfg, nil, map[string]string{"80002": amoySenderDID})
+			body := &SignInRequest{RequiredScopes: tc.required, Scope: []ScopeRequest{
+				{Id: 1, CircuitId: tc.circuit},
+				{Id: 2, CircuitId: tc.circuit, Query: map[string]interface{}{"groupId": tc.groupID}},
+			}}
+			for id := 3; id <= tc.scopes; id++ {
+				body.Scope = append(body.Scope, ScopeRequest{Id: uint32(id), CircuitId: tc.circuit})
+			}
+			err := validateRequiredScopes(body)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			sessionID := uuid.New()
+			server.setRequiredScopes(sessionID, body)
+			required, ok := server.requiredScopes(sessionID)
+			assert.Equal(t, tc.stored, ok)
+			if ok {
+				assert.Equal(t, *tc.required, required)
+			}
+		})
+	}
+
+	statuses := mergeScopeStatuses(
+		[]models.ScopeStatus{{ID: 1, Status: scopeStatusSatisfied}, {ID: 3, Status: scopeStatusSatisfied}},
+		[]models.ScopeStatus{{ID: 2, Status: scopeStatusFailed, Reason: "proof is not valid"}},
+	)
+	assert.Equal(t, []models.ScopeStatus{
+		{ID: 1, Status: scopeStatusSatisfied},
+		{ID: 2, Status: scopeStatusFailed, Reason: "proof is not valid"},
+		{ID: 3, Status: scopeStatusSatisfied},
+	}, statuses)
+}
+
+func TestReadOnlyReplica(t *testing.T) {
+	ctx := context.Background()
+	shared := cache.New(time.Hour, time.Hour)
+	server := New(cfg, nil, map[string]string{"80002": amoySenderDID}, WithStatusCache(shared))
+	replicaCfg := cfg
+	replicaCfg.ReadOnly = true
+	replica := New(replicaCfg, nil, map[string]string{"80002": amoySenderDID}, WithStatusCache(shared))
+
+	status := func(sessionID uuid.UUID) StatusResponseObject {
+		resp, err := replica.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+		require.NoError(t, err)
+		return resp
+	}
+
+	sessionID := uuid.New()
+	assert.IsType(t, Status404JSONResponse{}, status(sessionID))
+
+	server.cache.Set(sessionID.String(), protocol.AuthorizationRequestMessage{ID: sessionID.String()}, 0)
+	server.publishStatus(sessionID)
+	assert.Equal(t, Status200JSONResponse{Status: statusPending}, status(sessionID))
+
+	server.cache.Set(sessionID.String(), models.VerificationResponse{Jwz: "jwz-token", UserDID: amoySenderDID}, 0)
+	_, err := server.takeSessionResult(sessionID, true)
+	require.NoError(t, err)
+	assert.Equal(t, Status200JSONResponse{Status: statusConsumed}, status(sessionID))
+
+	// replicas do not publish the statuses they serve
+	replica.cache.Set(sessionID.String(), protocol.AuthorizationRequestMessage{ID: sessionID.String()}, 0)
+	replica.publishStatus(sessionID)
+	assert.Equal(t, Status200JSONResponse{Status: statusConsumed}, status(sessionID))
+
+	handler := ReadOnly(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }))
+	for _, tc := range []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{method: http.MethodGet, path: "/status", code: http.StatusOK},
+		{method: http.MethodGet, path: "/qr-store", code: http.StatusOK},
+		{method: http.MethodGet, path: "/metrics", code: http.StatusOK},
+		{method: http.MethodGet, path: "/r/token", code: http.StatusOK},
+		{method: http.MethodPost, path: "/sign-in", code: http.StatusServiceUnavailable},
+		{method: http.MethodPost, path: "/callback", code: http.StatusServiceUnavailable},
+		{method: http.MethodGet, path: "/sessions/" + sessionID.String() + "/result", code: http.StatusServiceUnavailable},
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+		assert.Equal(t, tc.code, rec.Code, tc.path)
+	}
+}
+
+func TestSessionExpiry(t *testing.T) {
+	ctx := context.Background()
+	expiryCfg := cfg
+	expiryCfg.AdminAPIKeys = []string{"admin"}
+	expiryCfg.SessionTTL = config.CacheTTL(20 * time.Millisecond)
+	var (
+		eventsMu sync.Mutex
+		events   = map[string]webhook.Event{}
+	)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.True(t, webhook.Verify([]byte("secret"), body, r.Header.Get(webhook.SignatureHeader)))
+		var event webhook.Event
+		require.NoError(t, json.Unmarshal(body, &event))
+		eventsMu.Lock()
+		events[event.SessionID] = event
+		eventsMu.Unlock()
+	}))
+	defer hook.Close()
+	expiryCfg.SessionWebhook = config.SessionWebhook{URL: hook.URL, Secret: "secret", Timeout: config.CacheTTL(time.Second)}
+	server := New(expiryCfg, nil, map[string]string{"80002": amoySenderDID})
+	admin := common.ToPointer("admin")
+
+	signIn := func() SignIn200JSONResponse {
+		resp, err := server.SignIn(ctx, SignInRequestObject{
+			Body: &SignInJSONRequestBody{
+				ChainID:  common.ToPointer("80002"),
+				Tags:     &[]string{"campaign:spring-airdrop"},
+				Metadata: &SessionMetadata{"orderID": "1234"},
+				Scope: []ScopeRequest{
+					{
+						Id:        1,
+						CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+						Query: jsonToMap(t, `{
+							"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+							"allowedIssuers": ["*"],
+							"type": "KYCAgeCredential"
+						}`),
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		return resp.(SignIn200JSONResponse)
+	}
+	status := func(sessionID uuid.UUID) string {
+		resp, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+		require.NoError(t, err)
+		return resp.(Status200JSONResponse).Status
+	}
+
+	expired, abandoned, answered := signIn(), signIn(), signIn()
+	_, err := server.GetQRCodeFromStore(ctx, GetQRCodeFromStoreRequestObject{Params: GetQRCodeFromStoreParams{Id: isValidaQrStoreCallback(t, abandoned.QrCode)}})
+	require.NoError(t, err)
+	server.answerSession(answered.SessionID)
+
+	require.Eventually(t, func() bool { return status(expired.SessionID) == statusExpired }, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return status(abandoned.SessionID) == statusAbandoned }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, statusPending, status(answered.SessionID))
+
+	resp, err := server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: expired.SessionID}, Body: common.ToPointer("jwz-token")})
+	require.NoError(t, err)
+	assert.IsType(t, Callback404JSONResponse{}, resp)
+
+	stats, err := server.GetTagStats(ctx, GetTagStatsRequestObject{Params: GetTagStatsParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	assert.Equal(t, GetTagStats200JSONResponse{{Tag: "campaign:spring-airdrop", Created: 3, Scanned: 1, Expired: 1, Abandoned: 1}}, stats)
+
+	require.Eventually(t, func() bool {
+		eventsMu.Lock()
+		defer eventsMu.Unlock()
+		return len(events) == 2
+	}, time.Second, 10*time.Millisecond)
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+	assert.Equal(t, webhook.EventSessionExpired, events[expired.SessionID.String()].Type)
+	assert.Equal(t, statusExpired, events[expired.SessionID.String()].Status)
+	assert.Equal(t, webhook.EventSessionAbandoned, events[abandoned.SessionID.String()].Type)
+	assert.Equal(t, statusAbandoned, events[abandoned.SessionID.String()].Status)
+	assert.Equal(t, map[string]string{"orderID": "1234"}, events[abandoned.SessionID.String()].Metadata)
+}
+
+func TestWebhookDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	webhookCfg := cfg
+	webhookCfg.AdminAPIKeys = []string{"admin"}
+	webhookCfg.SessionTTL = config.CacheTTL(20 * time.Millisecond)
+	var available atomic.Bool
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer hook.Close()
+	webhookCfg.SessionWebhook = config.SessionWebhook{
+		URL:         hook.URL,
+		Timeout:     config.CacheTTL(time.Second),
+		MaxAttempts: 2,
+		Backoff:     config.CacheTTL(time.Millisecond),
+	}
+	server := New(webhookCfg, nil, map[string]string{"80002": amoySenderDID})
+	defer server.Close()
+	admin := common.ToPointer("admin")
+
+	resp, err := server.SignIn(ctx, SignInRequestObject{
+		Body: &SignInJSONRequestBody{
+			ChainID: common.ToPointer("80002"),
+			Scope: []ScopeRequest{
+				{
+					Id:        1,
+					CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+					Query: jsonToMap(t, `{
+						"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+						"allowedIssuers": ["*"],
+						"type": "KYCAgeCredential"
+					}`),
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	sessionID := resp.(SignIn200JSONResponse).SessionID
+	deliveries := func() GetSessionWebhooks200JSONResponse {
+		resp, err := server.GetSessionWebhooks(ctx, GetSessionWebhooksRequestObject{SessionID: sessionID})
+		require.NoError(t, err)
+		return resp.(GetSessionWebhooks200JSONResponse)
+	}
+	assert.Empty(t, deliveries())
+
+	// the expiry event fails every attempt and is moved to the dead letters
+	require.Eventually(t, func() bool {
+		d := deliveries()
+		return len(d) == 1 && d[0].Status == webhook.DeliveryDead
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, deliveries()[0].Attempts)
+	assert.Equal(t, webhook.EventSessionExpired, deliveries()[0].Event.Type)
+
+	listResp, err := server.ListWebhookDeadLetters(ctx, ListWebhookDeadLettersRequestObject{})
+	require.NoError(t, err)
+	assert.IsType(t, ListWebhookDeadLetters401JSONResponse{}, listResp)
+	listResp, err = server.ListWebhookDeadLetters(ctx, ListWebhookDeadLettersRequestObject{Params: ListWebhookDeadLettersParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	letters := listResp.(ListWebhookDeadLetters200JSONResponse)
+	require.Len(t, letters, 1)
+	assert.Equal(t, sessionID.String(), letters[0].Event.SessionID)
+	require.NotNil(t, letters[0].LastError)
+	assert.Equal(t, "unexpected status code from webhook: 503", *letters[0].LastError)
+
+	// the replayed delivery is sent again once the webhook is available
+	available.Store(true)
+	replayResp, err := server.ReplayWebhookDeadLetter(ctx, ReplayWebhookDeadLetterRequestObject{
+		DeliveryID: letters[0].Id,
+		Params:     ReplayWebhookDeadLetterParams{XAPIKey: admin},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, webhook.DeliveryPending, replayResp.(ReplayWebhookDeadLetter202JSONResponse).Status)
+	require.Eventually(t, func() bool { return deliveries()[0].Status == webhook.DeliveryDelivered }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 3, deliveries()[0].Attempts)
+
+	replayResp, err = server.ReplayWebhookDeadLetter(ctx, ReplayWebhookDeadLetterRequestObject{
+		DeliveryID: letters[0].Id,
+		Params:     ReplayWebhookDeadLetterParams{XAPIKey: admin},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, ReplayWebhookDeadLetter404JSONResponse{}, replayResp)
+
+	webhooksResp, err := server.GetSessionWebhooks(ctx, GetSessionWebhooksRequestObject{SessionID: uuid.New()})
+	require.NoError(t, err)
+	assert.IsType(t, GetSessionWebhooks404JSONResponse{}, webhooksResp)
+}
+
+func TestSessionTimeline(t *testing.T) {
+	ctx := context.Background()
+	server := New(cfg, nil, map[string]string{"80002": amoySenderDID})
+	resp, err := server.SignIn(ctx, SignInRequestObject{
+		Params: SignInParams{XAPIKey: common.ToPointer("owner-key")},
+		Body: &SignInJSONRequestBody{
+			ChainID: common.ToPointer("80002"),
+			Scope: []ScopeRequest{{
+				Id:        1,
+				CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+				Query: jsonToMap(t, `{
+					"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+					"allowedIssuers": ["*"],
+					"type": "KYCAgeCredential"
+				}`),
+			}},
+		},
+	})
+	require.NoError(t, err)
+	signIn := resp.(SignIn200JSONResponse)
+	eventTypes := func() []string {
+		resp, err := server.GetSessionEvents(ctx, GetSessionEventsRequestObject{
+			SessionID: signIn.SessionID,
+			Params:    GetSessionEventsParams{XAPIKey: common.ToPointer("owner-key")},
+		})
+		require.NoError(t, err)
+		types := []string{}
+		for _, event := range resp.(GetSessionEvents200JSONResponse) {
+			types = append(types, event.Type)
+		}
+		return types
+	}
+	assert.Equal(t, []string{sessionEventCreated}, eventTypes())
+
+	_, err = server.GetQRCodeFromStore(ctx, GetQRCodeFromStoreRequestObject{Params: GetQRCodeFromStoreParams{Id: isValidaQrStoreCallback(t, signIn.QrCode)}})
+	require.NoError(t, err)
+	_, err = server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: signIn.SessionID}, Body: common.ToPointer("jwz-token")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{sessionEventCreated, sessionEventQRFetched, sessionEventCallback}, eventTypes()[:3])
+
+	server.cache.Set(signIn.SessionID.String(), errors.New("invalid proof"), cache.DefaultExpiration)
+	server.recordVerificationEvent(signIn.SessionID, false)
+	resp2, err := server.GetSessionEvents(ctx, GetSessionEventsRequestObject{
+		SessionID: signIn.SessionID,
+		Params:    GetSessionEventsParams{XAPIKey: common.ToPointer("owner-key")},
+	})
+	require.NoError(t, err)
+	events := resp2.(GetSessionEvents200JSONResponse)
+	last := events[len(events)-1]
+	assert.Equal(t, sessionEventFailed, last.Type)
+	assert.Equal(t, common.ToPointer("invalid proof"), last.Message)
+
+	forbidden, err := server.GetSessionEvents(ctx, GetSessionEventsRequestObject{SessionID: signIn.SessionID})
+	require.NoError(t, err)
+	assert.IsType(t, GetSessionEvents403JSONResponse{}, forbidden)
+	notFound, err := server.GetSessionEvents(ctx, GetSessionEventsRequestObject{SessionID: uuid.New()})
+	require.NoError(t, err)
+	assert.IsType(t, GetSessionEvents404JSONResponse{}, notFound)
+}
+
+func TestSignInMetadata(t *testing.T) {
+	ctx := context.Background()
+	testCfg := cfg
+	testCfg.DefaultReason = "age check"
+	server := New(testCfg, nil, map[string]string{"80002": amoySenderDID})
+
+	signIn := func(body SignInJSONRequestBody) SignInResponseObject {
+		if body.ChainID == nil && body.TransactionData == nil {
+			body.ChainID = common.ToPointer("80002")
+		}
+		if body.Scope == nil {
+			body.Scope = []ScopeRequest{{
+				Id:        1,
+				CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+				Query: jsonToMap(t, `{
+					"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+					"allowedIssuers": ["*"],
+					"type": "KYCAgeCredential"
+				}`),
+			}}
+		}
+		resp, err := server.SignIn(ctx, SignInRequestObject{Body: &body})
+		require.NoError(t, err)
+		return resp
+	}
+	request := func(sessionID uuid.UUID) protocol.AuthorizationRequestMessage {
+		item, ok := server.cache.Get(sessionID.String())
+		require.True(t, ok)
+		return item.(protocol.AuthorizationRequestMessage)
+	}
+
+	sessionID := signIn(SignInJSONRequestBody{}).(SignIn200JSONResponse).SessionID
+	assert.Equal(t, "age check", request(sessionID).Body.Reason)
+	status, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+	require.NoError(t, err)
+	assert.Nil(t, status.(Status200JSONResponse).Metadata)
+
+	sessionID = signIn(SignInJSONRequestBody{
+		Reason:   common.ToPointer("membership"),
+		Message:  common.ToPointer("Sign in to Acme"),
+		Metadata: &SessionMetadata{"orderID": "1234"},
+	}).(SignIn200JSONResponse).SessionID
+	assert.Equal(t, "membership", request(sessionID).Body.Reason)
+	assert.Equal(t, "Sign in to Acme", request(sessionID).Body.Message)
+	status, err = server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+	require.NoError(t, err)
+	assert.Equal(t, &SessionMetadata{"orderID": "1234"}, status.(Status200JSONResponse).Metadata)
+
+	tooMany := SessionMetadata{}
+	for i := 0; i <= maxMetadataKeys; i++ {
+		tooMany[strconv.Itoa(i)] = "value"
+	}
+	for _, metadata := range []SessionMetadata{tooMany, {"": "value"}, {"orderID": strings.Repeat("1", maxMetadataValueLength+1)}} {
+		resp := signIn(SignInJSONRequestBody{Metadata: &metadata})
+		assert.Equal(t, SignIn400JSONResponse{N400JSONResponse{
+			Message: "metadata can have up to 16 keys of up to 64 characters, with values of up to 256 characters",
+		}}, resp)
+	}
+
+	resp := signIn(SignInJSONRequestBody{
+		Message: common.ToPointer("Sign in to Acme"),
+		Scope: []ScopeRequest{{
+			Id:        1,
+			CircuitId: string(circuits.AtomicQueryV3OnChainCircuitID),
+			Query: jsonToMap(t, `{
+				"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+				"allowedIssuers": ["*"],
+				"type": "KYCAgeCredential"
+			}`),
+		}},
+		TransactionData: &TransactionData{
+			ChainID:         80002,
+			ContractAddress: "0xfcc86A79fCb057A8e55C6B853dff9479C3cf607c",
+			MethodID:        "b68967e2",
+			Network:         "polygon-amoy",
+		},
+	})
+	assert.Equal(t, SignIn400JSONResponse{N400JSONResponse{Message: "message is not supported by on-chain verifications"}}, resp)
+}
+
+func TestSessionLedger(t *testing.T) {
+	ctx := context.Background()
+	ledgerCfg := cfg
+	ledgerCfg.SessionTTL = config.CacheTTL(time.Hour)
+	server := New(ledgerCfg, nil, map[string]string{"80002": amoySenderDID}, WithSessionLedger(sessions.NewMemoryLedger()))
+
+	resp, err := server.SignIn(ctx, SignInRequestObject{
+		Body: &SignInJSONRequestBody{
+			ChainID: common.ToPointer("80002"),
+			Scope: []ScopeRequest{
+				{
+					Id:        1,
+					CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+					Query: jsonToMap(t, `{
+						"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+						"allowedIssuers": ["*"],
+						"type": "KYCAgeCredential"
+					}`),
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	signIn := resp.(SignIn200JSONResponse)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), signIn.ExpiresAt, time.Minute)
+
+	status, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: signIn.SessionID}})
+	require.NoError(t, err)
+	assert.Equal(t, Status200JSONResponse{Status: statusPending, ExpiresAt: &signIn.ExpiresAt}, status)
+
+	// the session is removed from the cache, as after the cache expiration or a restart
+	server.cache.Delete(signIn.SessionID.String())
+
+	status, err = server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: signIn.SessionID}})
+	require.NoError(t, err)
+	assert.Equal(t, Status200JSONResponse{Status: statusExpired, ExpiresAt: &signIn.ExpiresAt}, status)
+
+	status, err = server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: uuid.New()}})
+	require.NoError(t, err)
+	assert.IsType(t, Status404JSONResponse{}, status)
+
+	callback, err := server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: signIn.SessionID}, Body: common.ToPointer("jwz-token")})
+	require.NoError(t, err)
+	assert.Equal(t, Callback404JSONResponse{N404JSONResponse{Message: "session " + signIn.SessionID.String() + " expired without a response, start a new session"}}, callback)
+
+	result, err := server.GetSessionResult(ctx, GetSessionResultRequestObject{SessionID: signIn.SessionID})
+	require.NoError(t, err)
+	assert.IsType(t, GetSessionResult410JSONResponse{}, result)
+}
+
+type fakePinner struct {
+	mu     sync.Mutex
+	pinned []string
+}
+
+func (p *fakePinner) Pin(u string) error {
+	if strings.HasPrefix(u, "https://unavailable") {
+		return errors.New("unexpected status 502 Bad Gateway")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pinned = append(p.pinned, u)
+	return nil
+}
+
+func TestPrewarmSchemas(t *testing.T) {
+	ctx := context.Background()
+	kycContext := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld"
+	prewarmCfg := cfg
+	prewarmCfg.AdminAPIKeys = []string{"admin"}
+	prewarmCfg.DocumentCache.Pinned = []string{"https://unavailable.example.com/context.json"}
+	prewarmCfg.TrustProfiles = []config.TrustProfile{{Name: "eidas", Schemas: []config.TrustProfileSchema{{Context: kycContext, Type: "KYCAgeCredential"}}}}
+	pinner := &fakePinner{}
+	server := New(prewarmCfg, nil, nil, WithDocumentPinner(pinner))
+	require.NoError(t, server.queryTemplates.Save(QueryTemplate{
+		Name:      "kyc-age",
+		CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+		Query:     Query{"context": kycContext, "type": "KYCAgeCredential"},
+	}))
+	admin := common.ToPointer("admin")
+
+	resp, err := server.PrewarmSchemas(ctx, PrewarmSchemasRequestObject{Params: PrewarmSchemasParams{XAPIKey: common.ToPointer("user")}})
+	require.NoError(t, err)
+	assert.IsType(t, PrewarmSchemas401JSONResponse{}, resp)
+
+	resp, err = server.PrewarmSchemas(ctx, PrewarmSchemasRequestObject{
+		Params: PrewarmSchemasParams{XAPIKey: admin},
+		Body:   &PrewarmSchemasJSONRequestBody{Urls: &[]string{"ipfs://QmXwNcbWDpHvUMvG7ALfR7KdYDpLMqjNYJ3QmrDAjLiCfe"}},
+	})
+	require.NoError(t, err)
+	statuses := resp.(PrewarmSchemas200JSONResponse)
+	require.Len(t, statuses, 3)
+	assert.Equal(t, kycContext, statuses[0].Url)
+	assert.Equal(t, SchemaPinned, statuses[0].Status)
+	assert.Equal(t, &[]string{"kyc-age"}, statuses[0].Templates)
+	assert.Equal(t, SchemaFailed, statuses[1].Status)
+	assert.Equal(t, "unexpected status 502 Bad Gateway", *statuses[1].Error)
+	assert.Equal(t, "ipfs://QmXwNcbWDpHvUMvG7ALfR7KdYDpLMqjNYJ3QmrDAjLiCfe", statuses[2].Url)
+	assert.Equal(t, SchemaPinned, statuses[2].Status)
+	assert.ElementsMatch(t, []string{kycContext, "ipfs://QmXwNcbWDpHvUMvG7ALfR7KdYDpLMqjNYJ3QmrDAjLiCfe"}, pinner.pinned)
+
+	list, err := server.ListSchemas(ctx, ListSchemasRequestObject{Params: ListSchemasParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	assert.Equal(t, ListSchemas200JSONResponse(statuses), list)
+}
+
+func TestTestMode(t *testing.T) {
+	ctx := context.Background()
+	testCfg := cfg
+	testCfg.TestMode = config.TestMode{Enabled: true, Token: "canned-token"}
+	userDID := "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"
+	mock, err := testmode.NewVerifier("canned-token", userDID, "did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK")
+	require.NoError(t, err)
+	server := New(testCfg, mock, map[string]string{"80002": amoySenderDID})
+
+	signIn := func() uuid.UUID {
+		resp, err := server.SignIn(ctx, SignInRequestObject{
+			Body: &SignInJSONRequestBody{
+				ChainID: common.ToPointer("80002"),
+				Scope: []ScopeRequest{
+					{
+						Id:        1,
+						CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+						Query: jsonToMap(t, `{
+							"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+							"allowedIssuers": ["*"],
+							"type": "KYCAgeCredential"
+						}`),
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		return resp.(SignIn200JSONResponse).SessionID
+	}
+	callback := func(sessionID uuid.UUID, token string) CallbackResponseObject {
+		resp, err := server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: sessionID}, Body: &token})
+		require.NoError(t, err)
+		return resp
+	}
+
+	sessionID := signIn()
+	assert.Equal(t, Callback200JSONResponse{}, callback(sessionID, "canned-token"))
+	resp, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+	require.NoError(t, err)
+	status := resp.(Status200JSONResponse)
+	assert.Equal(t, statusSuccess, status.Status)
+	assert.Equal(t, userDID, status.JwzMetadata.UserDID)
+
+	assert.IsType(t, Callback500JSONResponse{}, callback(signIn(), invalidProofToken))
+}
+
+func TestVerificationHooks(t *testing.T) {
+	ctx := context.Background()
+	testCfg := cfg
+	testCfg.TestMode = config.TestMode{Enabled: true, Token: "canned-token"}
+	userDID := "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"
+	mock, err := testmode.NewVerifier("canned-token", userDID, "did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK")
+	require.NoError(t, err)
+	banned := map[string]bool{}
+	banList := hooks.Func(func(_ context.Context, _ hooks.Session, response protocol.AuthorizationResponseMessage) error {
+		if banned[response.From] {
+			return fmt.Errorf("user %s is banned", response.From)
+		}
+		return nil
+	})
+	server := New(testCfg, mock, map[string]string{"80002": amoySenderDID}, WithVerificationHooks(banList))
+
+	callback := func() (uuid.UUID, CallbackResponseObject) {
+		resp, err := server.SignIn(ctx, SignInRequestObject{
+			Body: &SignInJSONRequestBody{
+				ChainID: common.ToPointer("80002"),
+				Scope: []ScopeRequest{
+					{
+						Id:        1,
+						CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+						Query: jsonToMap(t, `{
+							"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+							"allowedIssuers": ["*"],
+							"type": "KYCAgeCredential"
+						}`),
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		sessionID := resp.(SignIn200JSONResponse).SessionID
+		callback, err := server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: sessionID}, Body: common.ToPointer("canned-token")})
+		require.NoError(t, err)
+		return sessionID, callback
+	}
+
+	_, resp := callback()
+	assert.Equal(t, Callback200JSONResponse{}, resp)
+
+	banned[userDID] = true
+	sessionID, resp := callback()
+	assert.Equal(t, Callback500JSONResponse{N500JSONResponse{Message: "the verification was rejected: user " + userDID + " is banned"}}, resp)
+	status, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+	require.NoError(t, err)
+	assert.NotEqual(t, statusSuccess, status.(Status200JSONResponse).Status)
+}
+
+func TestStatusDebugTimings(t *testing.T) {
+	ctx := context.Background()
+	testCfg := cfg
+	testCfg.TestMode = config.TestMode{Enabled: true, Token: "canned-token"}
+	testCfg.AdminAPIKeys = []string{"admin"}
+	mock, err := testmode.NewVerifier("canned-token", "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK",
+		"did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK")
+	require.NoError(t, err)
+	server := New(testCfg, mock, map[string]string{"80002": amoySenderDID})
+
+	resp, err := server.SignIn(ctx, SignInRequestObject{
+		Params: SignInParams{XAPIKey: common.ToPointer("owner-key")},
+		Body: &SignInJSONRequestBody{
+			ChainID: common.ToPointer("80002"),
+			Scope: []ScopeRequest{
+				{
+					Id:        1,
+					CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+					Query: jsonToMap(t, `{
+						"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+						"allowedIssuers": ["*"],
+						"type": "KYCAgeCredential"
+					}`),
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	sessionID := resp.(SignIn200JSONResponse).SessionID
+	debugStatus := func(apiKey *string) StatusResponseObject {
+		status, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID, Debug: common.ToPointer(true), XAPIKey: apiKey}})
+		require.NoError(t, err)
+		return status
+	}
+
+	status := debugStatus(common.ToPointer("owner-key"))
+	require.IsType(t, Status200JSONResponse{}, status)
+	assert.Nil(t, status.(Status200JSONResponse).Timings)
+
+	callback, err := server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: sessionID}, Body: common.ToPointer("canned-token")})
+	require.NoError(t, err)
+	assert.Equal(t, Callback200JSONResponse{}, callback)
+
+	// the timings are only returned to the callers that can read the session
+	assert.Equal(t, Status401JSONResponse{N401JSONResponse{Message: "the timings of session " + sessionID.String() +
+		" require the api key that created it, a key of its tenant or an admin key"}}, debugStatus(nil))
+	assert.IsType(t, Status401JSONResponse{}, debugStatus(common.ToPointer("other-key")))
+	status, err = server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+	require.NoError(t, err)
+	assert.Nil(t, status.(Status200JSONResponse).Timings)
+
+	for _, apiKey := range []string{"owner-key", "admin"} {
+		status = debugStatus(common.ToPointer(apiKey))
+		require.IsType(t, Status200JSONResponse{}, status)
+		timings := status.(Status200JSONResponse).Timings
+		require.NotNil(t, timings)
+		names := make([]string, 0, len(*timings))
+		for i, span := range *timings {
+			names = append(names, span.Name)
+			if i > 0 {
+				assert.GreaterOrEqual(t, span.StartMs, (*timings)[i-1].StartMs)
+			}
+		}
+		assert.Subset(t, names, []string{timing.SpanUnpack, string(timing.StageParse), string(timing.StageProofVerification),
+			string(timing.StagePostProcessing)})
+	}
+}
+
+func TestDirectDelivery(t *testing.T) {
+	ctx := context.Background()
+	testCfg := cfg
+	testCfg.TestMode = config.TestMode{Enabled: true, Token: "canned-token"}
+	walletDID := "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"
+	otherDID := "did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK"
+	mock, err := testmode.NewVerifier("canned-token", walletDID, otherDID)
+	require.NoError(t, err)
+	server := New(testCfg, mock, map[string]string{"80002": amoySenderDID})
+
+	signIn := func() (uuid.UUID, string) {
+		resp, err := server.SignIn(ctx, SignInRequestObject{
+			Body: &SignInJSONRequestBody{
+				ChainID: common.ToPointer("80002"),
+				Scope: []ScopeRequest{
+					{
+						Id:        1,
+						CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+						Query: jsonToMap(t, `{
+							"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+							"allowedIssuers": ["*"],
+							"type": "KYCAgeCredential"
+						}`),
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		signIn := resp.(SignIn200JSONResponse)
+		return signIn.SessionID, isValidaQrStoreCallback(t, signIn.QrCode)
+	}
+	fetch := func(token, walletDID, nonce string) FetchQRCodeFromStoreResponseObject {
+		resp, err := server.FetchQRCodeFromStore(ctx, FetchQRCodeFromStoreRequestObject{
+			Params: FetchQRCodeFromStoreParams{Id: token},
+			Body:   &FetchQRCodeFromStoreJSONRequestBody{WalletDID: walletDID, Nonce: nonce},
+		})
+		require.NoError(t, err)
+		return resp
+	}
+	callback := func(sessionID uuid.UUID) CallbackResponseObject {
+		resp, err := server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: sessionID}, Body: common.ToPointer("canned-token")})
+		require.NoError(t, err)
+		return resp
+	}
+
+	sessionID, token := signIn()
+	assert.IsType(t, FetchQRCodeFromStore400JSONResponse{}, fetch(token, walletDID, ""))
+	assert.IsType(t, FetchQRCodeFromStore400JSONResponse{}, fetch(token, "not-a-did", "nonce-1"))
+	assert.IsType(t, FetchQRCodeFromStore404JSONResponse{}, fetch("3q2-7wEjRWeJq83vASNFZ4mr", walletDID, "nonce-1"))
+
+	first := fetch(token, walletDID, "nonce-1").(FetchQRCodeFromStore200JSONResponse)
+	assert.Equal(t, walletDID, *first.To)
+	assert.NotEqual(t, first.Id, first.Thid)
+	assert.Equal(t, FetchQRCodeFromStore409JSONResponse{N409JSONResponse{Message: "the nonce was already used to fetch session " + sessionID.String()}},
+		fetch(token, walletDID, "nonce-1"))
+	// the DID of a fetch is not proven, so a fetch with the DID of another wallet does not bind the session
+	assert.IsType(t, FetchQRCodeFromStore200JSONResponse{}, fetch(token, otherDID, "nonce-2"))
+
+	second := fetch(token, walletDID, "nonce-3").(FetchQRCodeFromStore200JSONResponse)
+	assert.NotEqual(t, first.Thid, second.Thid)
+	assert.Equal(t, Callback200JSONResponse{}, callback(sessionID))
+	assert.IsType(t, FetchQRCodeFromStore409JSONResponse{}, fetch(token, walletDID, "nonce-4"))
+
+	// the callback of another wallet does not fail the session bound to otherDID
+	sessionID, token = signIn()
+	assert.IsType(t, FetchQRCodeFromStore200JSONResponse{}, fetch(token, otherDID, "nonce-1"))
+	assert.Equal(t, Callback409JSONResponse{N409JSONResponse{Message: "session " + sessionID.String() + " is bound to another wallet"}}, callback(sessionID))
+	status, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+	require.NoError(t, err)
+	assert.Equal(t, statusPending, status.(Status200JSONResponse).Status)
+
+	// only the last deliveries of a session are kept
+	for i := 0; i < 2*maxWalletDeliveries; i++ {
+		assert.IsType(t, FetchQRCodeFromStore200JSONResponse{}, fetch(token, otherDID, fmt.Sprintf("delivery-%d", i)))
+	}
+	delivered, ok := server.walletDeliveries(sessionID)
+	require.True(t, ok)
+	assert.Len(t, delivered, maxWalletDeliveries)
+}
+
+func TestRequestExpiration(t *testing.T) {
+	ctx := context.Background()
+	testCfg := cfg
+	testCfg.SessionTTL = config.CacheTTL(time.Hour)
+	testCfg.RequestValidity = config.CacheTTL(30 * time.Minute)
+	testCfg.TestMode = config.TestMode{Enabled: true, Token: "canned-token"}
+	mock, err := testmode.NewVerifier("canned-token", "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK",
+		"did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK")
+	require.NoError(t, err)
+	server := New(testCfg, mock, map[string]string{"80002": amoySenderDID})
+
+	signIn := func() (uuid.UUID, string) {
+		resp, err := server.SignIn(ctx, SignInRequestObject{
+			Body: &SignInJSONRequestBody{
+				ChainID: common.ToPointer("80002"),
+				Scope: []ScopeRequest{
+					{
+						Id:        1,
+						CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+						Query: jsonToMap(t, `{
+							"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+							"allowedIssuers": ["*"],
+							"type": "KYCAgeCredential"
+						}`),
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		signIn := resp.(SignIn200JSONResponse)
+		return signIn.SessionID, isValidaQrStoreCallback(t, signIn.QrCode)
+	}
+	callback := func(sessionID uuid.UUID) CallbackResponseObject {
+		resp, err := server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: sessionID}, Body: common.ToPointer("canned-token")})
+		require.NoError(t, err)
+		return resp
+	}
+
+	sessionID, token := signIn()
+	resp, err := server.GetQRCodeFromStore(ctx, GetQRCodeFromStoreRequestObject{Params: GetQRCodeFromStoreParams{Id: token}})
+	require.NoError(t, err)
+	qrCode := resp.(GetQRCodeFromStore200JSONResponse).Body
+	require.NotNil(t, qrCode.CreatedTime)
+	require.NotNil(t, qrCode.ExpiresTime)
+	assert.InDelta(t, time.Now().Unix(), *qrCode.CreatedTime, 5)
+	assert.Equal(t, int64(30*60), *qrCode.ExpiresTime-*qrCode.CreatedTime)
+	assert.Equal(t, Callback200JSONResponse{}, callback(sessionID))
+
+	// the request expires before the session
+	sessionID, _ = signIn()
+	server.cache.Set(requestExpiresKeyPrefix+sessionID.String(), time.Now().Add(-time.Second), cache.DefaultExpiration)
+	assert.Equal(t, Callback404JSONResponse{N404JSONResponse{Message: "the request of session " + sessionID.String() + " expired, start a new session"}}, callback(sessionID))
+	status, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+	require.NoError(t, err)
+	assert.Equal(t, statusPending, status.(Status200JSONResponse).Status)
+}
+
+// contextLoader serves the JSON-LD documents of its map
+type contextLoader map[string]string
+
+func (l contextLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	doc, ok := l[u]
+	if !ok {
+		return nil, errors.New("document not found")
+	}
+	document, err := ld.DocumentFromReader(strings.NewReader(doc))
+	if err != nil {
+		return nil, err
+	}
+	return &ld.RemoteDocument{DocumentURL: u, Document: document}, nil
+}
+
+func TestQueryLinter(t *testing.T) {
+	ctx := context.Background()
+	kycContext := "https://example.com/kyc.jsonld"
+	loader := contextLoader{kycContext: `{"@context": [{
+		"@version": 1.1, "@protected": true, "id": "@id", "type": "@type",
+		"KYCAgeCredential": {"@id": "https://example.com/kyc#KYCAgeCredential", "@context": {
+			"@version": 1.1, "@protected": true, "id": "@id", "type": "@type",
+			"vocab": "https://example.com/kyc-vocab#", "xsd": "http://www.w3.org/2001/XMLSchema#",
+			"birthday": {"@id": "vocab:birthday", "@type": "xsd:integer"},
+			"country": {"@id": "vocab:country", "@type": "xsd:string"}
+		}}
+	}]}`}
+	server := New(cfg, nil, map[string]string{"80002": amoySenderDID}, WithQueryLinter(loader))
+
+	signIn := func(query string) SignInResponseObject {
+		resp, err := server.SignIn(ctx, SignInRequestObject{
+			Body: &SignInJSONRequestBody{
+				ChainID: common.ToPointer("80002"),
+				Scope:   []ScopeRequest{{Id: 1, CircuitId: string(circuits.AtomicQuerySigV2CircuitID), Query: jsonToMap(t, query)}},
+			},
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	assert.IsType(t, SignIn200JSONResponse{}, signIn(`{"context": "`+kycContext+`", "allowedIssuers": ["*"], "type": "KYCAgeCredential",
+		"credentialSubject": {"birthday": {"$lt": 20000101}}}`))
+	assert.IsType(t, SignIn200JSONResponse{}, signIn(`{"context": "`+kycContext+`", "allowedIssuers": ["*"], "type": "KYCAgeCredential",
+		"credentialSubject": {"country": {"$in": ["AR", "ES"]}}}`))
+	// the contexts that cannot be loaded are not linted
+	assert.IsType(t, SignIn200JSONResponse{}, signIn(`{"context": "https://example.com/unavailable.jsonld", "allowedIssuers": ["*"], "type": "KYCAgeCredential",
+		"credentialSubject": {"birthdy": {"$lt": 20000101}}}`))
+
+	assert.Equal(t, SignIn400JSONResponse{N400JSONResponse{Message: "the type KYCAgeCredentail of scope 1 is not defined in the context " + kycContext}},
+		signIn(`{"context": "`+kycContext+`", "allowedIssuers": ["*"], "type": "KYCAgeCredentail"}`))
+	assert.Equal(t, SignIn400JSONResponse{N400JSONResponse{Message: "the field birthdy of scope 1 is not defined for the type KYCAgeCredential in its context"}},
+		signIn(`{"context": "`+kycContext+`", "allowedIssuers": ["*"], "type": "KYCAgeCredential", "credentialSubject": {"birthdy": {"$lt": 20000101}}}`))
+	assert.Equal(t, SignIn400JSONResponse{N400JSONResponse{Message: "the operator $lt of field country in scope 1 cannot be used with the datatype http://www.w3.org/2001/XMLSchema#string"}},
+		signIn(`{"context": "`+kycContext+`", "allowedIssuers": ["*"], "type": "KYCAgeCredential", "credentialSubject": {"country": {"$lt": "AR"}}}`))
+	assert.Equal(t, SignIn400JSONResponse{N400JSONResponse{Message: "the value 2000-01-01 of field birthday in scope 1 is not a valid http://www.w3.org/2001/XMLSchema#integer"}},
+		signIn(`{"context": "`+kycContext+`", "allowedIssuers": ["*"], "type": "KYCAgeCredential", "credentialSubject": {"birthday": {"$eq": "2000-01-01"}}}`))
+}
+
+func TestSignInAuth(t *testing.T) {
+	ctx := context.Background()
+	userDID := "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"
+	testCfg := cfg
+	testCfg.TestMode = config.TestMode{Enabled: true, Token: "canned-token"}
+	mock, err := testmode.NewVerifier("canned-token", userDID, "did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK")
+	require.NoError(t, err)
+	server := New(testCfg, mock, map[string]string{"80002": amoySenderDID})
+
+	resp, err := server.SignInAuth(ctx, SignInAuthRequestObject{Body: &SignInAuthJSONRequestBody{ChainID: ""}})
+	require.NoError(t, err)
+	assert.Equal(t, SignInAuth400JSONResponse{N400JSONResponse{Message: "field chainID is empty"}}, resp)
+
+	resp, err = server.SignInAuth(ctx, SignInAuthRequestObject{Body: &SignInAuthJSONRequestBody{
+		ChainID: "80002",
+		Reason:  common.ToPointer("login"),
+		Tags:    &[]string{"login"},
+	}})
+	require.NoError(t, err)
+	signIn, ok := resp.(SignInAuth200JSONResponse)
+	require.True(t, ok)
+	token := isValidaQrStoreCallback(t, signIn.QrCode)
+
+	qrResp, err := server.GetQRCodeFromStore(ctx, GetQRCodeFromStoreRequestObject{Params: GetQRCodeFromStoreParams{Id: token}})
+	require.NoError(t, err)
+	qrCode := qrResp.(GetQRCodeFromStore200JSONResponse).Body
+	assert.Equal(t, "login", qrCode.Body.Reason)
+	assert.Empty(t, qrCode.Body.Scope)
+
+	callback, err := server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: signIn.SessionID}, Body: common.ToPointer("canned-token")})
+	require.NoError(t, err)
+	assert.Equal(t, Callback200JSONResponse{}, callback)
+
+	status, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: signIn.SessionID}})
+	require.NoError(t, err)
+	result := status.(Status200JSONResponse)
+	assert.Equal(t, statusSuccess, result.Status)
+	require.NotNil(t, result.JwzMetadata)
+	assert.Equal(t, userDID, result.JwzMetadata.UserDID)
+	assert.Nil(t, result.JwzMetadata.Nullifiers)
+}
+
+func TestRedirectURI(t *testing.T) {
+	ctx := context.Background()
+	userDID := "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"
+	testCfg := cfg
+	testCfg.TestMode = config.TestMode{Enabled: true, Token: "canned-token"}
+	mock, err := testmode.NewVerifier("canned-token", userDID, "did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK")
+	require.NoError(t, err)
+	server := New(testCfg, mock, map[string]string{"80002": amoySenderDID})
+
+	resp, err := server.SignInAuth(ctx, SignInAuthRequestObject{Body: &SignInAuthJSONRequestBody{
+		ChainID:     "80002",
+		RedirectUri: common.ToPointer("javascript:alert(1)"),
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, SignInAuth400JSONResponse{N400JSONResponse{
+		Message: "redirectUri must be an absolute url of up to 2048 characters, whose scheme is not javascript, data, vbscript or file",
+	}}, resp)
+
+	resp, err = server.SignInAuth(ctx, SignInAuthRequestObject{Body: &SignInAuthJSONRequestBody{
+		ChainID:     "80002",
+		RedirectUri: common.ToPointer("acme://verified?state=1"),
+	}})
+	require.NoError(t, err)
+	signIn, ok := resp.(SignInAuth200JSONResponse)
+	require.True(t, ok)
+	callback := func() CallbackResponseObject {
+		resp, err := server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: signIn.SessionID}, Body: common.ToPointer("canned-token")})
+		require.NoError(t, err)
+		return resp
+	}
+	assert.Equal(t, Callback200JSONResponse{"redirectUri": "acme://verified?state=1"}, callback())
+	assert.Equal(t, Callback200JSONResponse{"redirectUri": "acme://verified?state=1"}, callback(), "retried callback")
+
+	for redirectURI, valid := range map[string]bool{
+		"https://app.example.com/verified": true,
+		"acme://verified":                  true,
+		"/verified":                        false,
+		"DATA:text/html,verified":          false,
+		"https://app.example.com/" + strings.Repeat("a", maxRedirectURILength): false,
+	} {
+		assert.Equal(t, valid, checkRedirectURI(redirectURI) == nil, redirectURI)
+	}
+	err = validateRedirectURI(&SignInRequest{
+		RedirectUri: common.ToPointer("https://app.example.com/verified"),
+		Scope:       []ScopeRequest{{CircuitId: string(circuits.AtomicQueryV3OnChainCircuitID)}},
+	})
+	assert.EqualError(t, err, "redirectUri is not supported by on-chain verifications")
+}
+
+func TestWalletLinks(t *testing.T) {
+	ctx := context.Background()
+	linksCfg := cfg
+	linksCfg.UniversalLinkURL = "https://wallet.privado.id"
+	linksCfg.WalletLinks = config.WalletLinks{
+		DeepLinkScheme:    "polygonid",
+		UniversalLinkURLs: []string{"https://wallet.example.com/", "https://wallet.privado.id"},
+	}
+	server := New(linksCfg, nil, map[string]string{"80002": amoySenderDID})
+	resp, err := server.SignIn(ctx, SignInRequestObject{Body: &SignInJSONRequestBody{
+		ChainID: common.ToPointer("80002"),
+		Scope: []ScopeRequest{{
+			Id:        1,
+			CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+			Query: jsonToMap(t, `{
+				"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+				"allowedIssuers": ["*"],
+				"type": "KYCAgeCredential"
+			}`),
+		}},
+	}})
+	require.NoError(t, err)
+	signIn, ok := resp.(SignIn200JSONResponse)
+	require.True(t, ok, resp)
+	token := isValidaQrStoreCallback(t, signIn.QrCode)
+	requestURI := url.QueryEscape("http://localhost/qr-store?id=" + token)
+	assert.Equal(t, &WalletLinks{
+		DeepLink:     "polygonid://?request_uri=" + requestURI,
+		Interstitial: "http://localhost/r/" + token,
+		UniversalLinks: []string{
+			"https://wallet.example.com/#request_uri=" + requestURI,
+			"https://wallet.privado.id/#request_uri=" + requestURI,
+		},
+	}, signIn.Links)
+
+	router := chi.NewRouter()
+	router.Get("/r/{id}", server.Interstitial)
+	interstitial := func(id, userAgent string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/r/"+id, nil)
+		req.Header.Set("User-Agent", userAgent)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := interstitial(token, "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)")
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://wallet.example.com/#request_uri="+requestURI, rec.Header().Get("Location"))
+
+	rec = interstitial(token, "Mozilla/5.0 (X11; Linux x86_64)")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "private, no-store", rec.Header().Get("Cache-Control"))
+	assert.Contains(t, rec.Body.String(), `href="polygonid://?request_uri=`+requestURI+`"`)
+
+	rec = interstitial("89d298fa-15a6-4a1d-ab13-d1069467eedd", "Mozilla/5.0 (X11; Linux x86_64)")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// sharedStore is a store shared by the replicas of the verifier, whose values are only seen lag after they are stored,
+// like the ones of a replicated store
+type sharedStore struct {
+	mu     sync.Mutex
+	lag    time.Duration
+	err    error
+	values map[string]sharedValue
+}
+
+type sharedValue struct {
+	value   []byte
+	visible time.Time
+}
+
+func newSharedStore(lag time.Duration) *sharedStore {
+	return &sharedStore{lag: lag, values: map[string]sharedValue{}}
+}
+
+func (s *sharedStore) Get(id string) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[id]
+	if !ok || time.Now().Before(v.visible) {
+		return nil, false
+	}
+	return v.value, true
+}
+
+func (s *sharedStore) Set(id string, data any, duration time.Duration) {
+	b, _ := data.([]byte)
+	_ = s.Store(id, b, duration)
+}
+
+func (s *sharedStore) Store(id string, value []byte, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.values[id] = sharedValue{value: value, visible: time.Now().Add(s.lag)}
+	return nil
+}
+
+func TestSharedQRStore(t *testing.T) {
+	ctx := context.Background()
+	replicaCfg := cfg
+	replicaCfg.QRLink.Secret = "shared secret"
+	replicaCfg.QRStore.ReadRetryWindow = config.CacheTTL(time.Second)
+	replicaCfg.WalletLinks = config.WalletLinks{DeepLinkScheme: "polygonid", UniversalLinkURLs: []string{"https://wallet.privado.id"}}
+	store := newSharedStore(50 * time.Millisecond)
+	a := New(replicaCfg, nil, map[string]string{"80002": amoySenderDID}, WithQRCache(store))
+	b := New(replicaCfg, nil, map[string]string{"80002": amoySenderDID}, WithQRCache(store))
+
+	signIn := func(server *Server) SignInResponseObject {
+		resp, err := server.SignIn(ctx, SignInRequestObject{Body: &SignInJSONRequestBody{
+			ChainID:   common.ToPointer("80002"),
+			PublicURL: common.ToPointer("https://verifier.example.com"),
+			Scope: []ScopeRequest{{
+				Id:        1,
+				CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+				Query: jsonToMap(t, `{
+					"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+					"allowedIssuers": ["*"],
+					"type": "KYCAgeCredential"
+				}`),
+			}},
+		}})
+		require.NoError(t, err)
+		return resp
+	}
+
+	// the wallet fetches the request from the other replica right after the sign-in, before the store replicated it
+	resp := signIn(a)
+	created, ok := resp.(SignIn200JSONResponse)
+	require.True(t, ok, resp)
+	require.True(t, strings.HasPrefix(created.QrCode, "iden3comm://?request_uri=https://verifier.example.com/qr-store?id="), created.QrCode)
+	token := created.QrCode[strings.LastIndex(created.QrCode, "=")+1:]
+	fetched, err := b.GetQRCodeFromStore(ctx, GetQRCodeFromStoreRequestObject{Params: GetQRCodeFromStoreParams{Id: token}})
+	require.NoError(t, err)
+	qrCode, ok := fetched.(GetQRCodeFromStore200JSONResponse)
+	require.True(t, ok, fetched)
+	assert.Equal(t, amoySenderDID, qrCode.Body.From)
+
+	// the interstitial page of the other replica links to the request uri handed out by the sign-in
+	router := chi.NewRouter()
+	router.Get("/r/{id}", b.Interstitial)
+	req := httptest.NewRequest(http.MethodGet, "/r/"+token, nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, created.Links.UniversalLinks[0], rec.Header().Get("Location"))
+	assert.Contains(t, rec.Header().Get("Location"), url.QueryEscape("https://verifier.example.com/qr-store?id="+token))
+
+	// the QR codes stored before the versions are still served, the ones of newer versions are not
+	id, ok := b.qrStore.verify(token)
+	require.True(t, ok)
+	legacy, err := json.Marshal(QRCode{From: amoySenderDID, Typ: string(packers.MediaTypePlainMessage)})
+	require.NoError(t, err)
+	store.lag = 0
+	store.Set("qr-code-"+id, legacy, time.Hour)
+	stored, err := b.qrStore.Get(token)
+	require.NoError(t, err)
+	assert.Equal(t, amoySenderDID, stored.From)
+	store.Set("qr-code-"+id, []byte(`{"version":2,"qrCode":{}}`), time.Hour)
+	_, err = b.qrStore.Get(token)
+	assert.ErrorContains(t, err, "unknown version 2")
+
+	// the sign-in fails when the QR code cannot be stored, rather than handing out a link no replica can serve
+	store.err = errors.New("store unavailable")
+	resp = signIn(a)
+	failed, ok := resp.(SignIn500JSONResponse)
+	require.True(t, ok, resp)
+	assert.Contains(t, failed.Message, "failed to cache QR code: store unavailable")
+}
+
+func TestUserRegistry(t *testing.T) {
+	ctx := context.Background()
+	userDID := "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"
+	testCfg := cfg
+	testCfg.TestMode = config.TestMode{Enabled: true, Token: "canned-token"}
+	testCfg.APIKeys = []string{"production-key"}
+	testCfg.Tenants = []config.Tenant{{ID: "acme", APIKeys: []string{"acme-key"}}}
+	testCfg.AdminAPIKeys = []string{"admin"}
+	mock, err := testmode.NewVerifier("canned-token", userDID, "did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK")
+	require.NoError(t, err)
+
+	resp, err := New(testCfg, mock, map[string]string{"80002": amoySenderDID}).GetUser(ctx, GetUserRequestObject{Did: userDID})
+	require.NoError(t, err)
+	assert.Equal(t, GetUser404JSONResponse{N404JSONResponse{Message: "user registry is not enabled"}}, resp)
+
+	server := New(testCfg, mock, map[string]string{"80002": amoySenderDID}, WithUserRegistry(users.NewMemoryRegistry()))
+	signIn, err := server.SignIn(ctx, SignInRequestObject{
+		Params: SignInParams{XAPIKey: common.ToPointer("acme-key")},
+		Body: &SignInJSONRequestBody{
+			ChainID: common.ToPointer("80002"),
+			Scope: []ScopeRequest{{
+				Id:        1,
+				CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+				Query: jsonToMap(t, `{
+					"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+					"allowedIssuers": ["*"],
+					"type": "KYCAgeCredential"
+				}`),
+			}},
+		},
+	})
+	require.NoError(t, err)
+	callback, err := server.Callback(ctx, CallbackRequestObject{
+		Params: CallbackParams{SessionID: signIn.(SignIn200JSONResponse).SessionID},
+		Body:   common.ToPointer("canned-token"),
+	})
+	require.NoError(t, err)
+	require.IsType(t, Callback200JSONResponse{}, callback)
+
+	resp, err = server.GetUser(ctx, GetUserRequestObject{Did: userDID, Params: GetUserParams{
+		TenantID: common.ToPointer("acme"),
+		XAPIKey:  common.ToPointer("admin"),
+	}})
+	require.NoError(t, err)
+	user, ok := resp.(GetUser200JSONResponse)
+	require.True(t, ok, resp)
+	assert.Equal(t, userDID, user.Did)
+	assert.Equal(t, 1, user.Verifications)
+	assert.Equal(t, []string{"KYCAgeCredential"}, user.CredentialTypes)
+	assert.Equal(t, user.FirstVerifiedAt, user.LastVerifiedAt)
+
+	// the users of the tenant are not returned without the tenant, and only to the admins
+	resp, err = server.GetUser(ctx, GetUserRequestObject{Did: userDID, Params: GetUserParams{XAPIKey: common.ToPointer("admin")}})
+	require.NoError(t, err)
+	assert.Equal(t, GetUser404JSONResponse{N404JSONResponse{Message: "user not found"}}, resp)
+	for _, apiKey := range []*string{nil, common.ToPointer("acme-key"), common.ToPointer("production-key")} {
+		resp, err = server.GetUser(ctx, GetUserRequestObject{Did: userDID, Params: GetUserParams{TenantID: common.ToPointer("acme"), XAPIKey: apiKey}})
+		require.NoError(t, err)
+		assert.IsType(t, GetUser401JSONResponse{}, resp)
+	}
+
+	list := func(credentialType string) ListUsersResponseObject {
+		resp, err := server.ListUsers(ctx, ListUsersRequestObject{Params: ListUsersParams{
+			CredentialType: common.ToPointer(credentialType),
+			TenantID:       common.ToPointer("acme"),
+			XAPIKey:        common.ToPointer("admin"),
+		}})
+		require.NoError(t, err)
+		return resp
+	}
+	assert.Equal(t, ListUsers200JSONResponse{UserRecord(user)}, list("KYCAgeCredential"))
+	assert.Equal(t, ListUsers200JSONResponse{}, list("KYCCountryOfResidenceCredential"))
+}
+
+func TestExportAudit(t *testing.T) {
+	ctx := context.Background()
+	userDID := "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"
+	testCfg := cfg
+	testCfg.TestMode = config.TestMode{Enabled: true, Token: "canned-token"}
+	testCfg.AdminAPIKeys = []string{"admin"}
+	mock, err := testmode.NewVerifier("canned-token", userDID, "did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK")
+	require.NoError(t, err)
+	server := New(testCfg, mock, map[string]string{"80002": amoySenderDID}, WithAuditLog(audit.NewMemoryLog(0)))
+
+	for _, token := range []string{"canned-token", invalidProofToken} {
+		signIn, err := server.SignIn(ctx, SignInRequestObject{
+			Body: &SignInJSONRequestBody{
+				ChainID: common.ToPointer("80002"),
+				Scope: []ScopeRequest{{
+					Id:        1,
+					CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+					Query: jsonToMap(t, `{
+						"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+						"allowedIssuers": ["*"],
+						"type": "KYCAgeCredential"
+					}`),
+				}},
+			},
+		})
+		require.NoError(t, err)
+		_, err = server.Callback(ctx, CallbackRequestObject{
+			Params: CallbackParams{SessionID: signIn.(SignIn200JSONResponse).SessionID},
+			Body:   common.ToPointer(token),
+		})
+		require.NoError(t, err)
+	}
+
+	export := func(query, apiKey string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, "/admin/audit/export"+query, nil)
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		rec := httptest.NewRecorder()
+		server.ExportAudit(rec, req)
+		return rec.Result()
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, export("", "").StatusCode)
+	assert.Equal(t, http.StatusBadRequest, export("?format=xml", "admin").StatusCode)
+	assert.Equal(t, http.StatusBadRequest, export("?from=yesterday", "admin").StatusCode)
+	assert.Equal(t, http.StatusBadRequest, export("?limit=0", "admin").StatusCode)
+
+	resp := export("?limit=1", "admin")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var record audit.Record
+	require.NoError(t, json.Unmarshal(body, &record))
+	assert.Equal(t, uint64(1), record.Seq)
+	assert.Equal(t, audit.TypeVerificationSucceeded, record.Type)
+	assert.Equal(t, userDID, record.UserDID)
+	assert.Equal(t, []string{"KYCAgeCredential"}, record.CredentialTypes)
+	assert.Equal(t, "1", resp.Trailer.Get("X-Next-Cursor"))
+
+	resp = export("?format=csv&after=1", "admin")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "seq", rows[0][0])
+	assert.Equal(t, []string{"2", audit.TypeVerificationFailed}, []string{rows[1][0], rows[1][2]})
+	assert.NotEmpty(t, rows[1][8])
+	assert.Empty(t, resp.Trailer.Get("X-Next-Cursor"))
+
+	resp = export("?from="+time.Now().Add(time.Hour).UTC().Format(time.RFC3339), "admin")
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Empty(t, body)
+
+	disabled := New(testCfg, mock, map[string]string{"80002": amoySenderDID})
+	rec := httptest.NewRecorder()
+	disabled.ExportAudit(rec, httptest.NewRequest(http.MethodGet, "/admin/audit/export", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGenesisEthAddress(t *testing.T) {
+	ctx := context.Background()
+	ethDID := "did:iden3:polygon:amoy:x6x5sor7zpxu8n3BAEZsyR2RTC82yjQEH3rMEdih6"
+	userDID := "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"
+
+	address, ok := genesisEthAddress(ethDID)
+	require.True(t, ok)
+	assert.Equal(t, "0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f", address)
+	_, ok = genesisEthAddress(userDID)
+	assert.False(t, ok)
+
+	verify := func(did string) (CallbackResponseObject, Status200JSONResponse) {
+		testCfg := cfg
+		testCfg.TestMode = config.TestMode{Enabled: true, Token: "canned-token"}
+		mock, err := testmode.NewVerifier("canned-token", did, "did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK")
+		require.NoError(t, err)
+		server := New(testCfg, mock, map[string]string{"80002": amoySenderDID})
+
+		resp, err := server.SignInAuth(ctx, SignInAuthRequestObject{Body: &SignInAuthJSONRequestBody{
+			ChainID:           "80002",
+			RequireEthAddress: common.ToPointer(true),
+		}})
+		require.NoError(t, err)
+		sessionID := resp.(SignInAuth200JSONResponse).SessionID
+		callback, err := server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: sessionID}, Body: common.ToPointer("canned-token")})
+		require.NoError(t, err)
+		status, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+		require.NoError(t, err)
+		return callback, status.(Status200JSONResponse)
+	}
+
+	callback, status := verify(ethDID)
+	assert.Equal(t, Callback200JSONResponse{}, callback)
+	require.NotNil(t, status.JwzMetadata)
+	assert.Equal(t, ethDID, status.JwzMetadata.UserDID)
+	assert.Equal(t, common.ToPointer(address), status.JwzMetadata.EthAddress)
+
+	callback, status = verify(userDID)
+	message := "the DID " + userDID + " was not created from an Ethereum address, use an Ethereum-based identity"
+	assert.Equal(t, Callback500JSONResponse{N500JSONResponse{Message: message}}, callback)
+	assert.Equal(t, statusError, status.Status)
+	assert.Equal(t, common.ToPointer(message), status.Message)
+}
+
+func TestGetNetworks(t *testing.T) {
+	testCfg := cfg
+	testCfg.ResolverSettings = config.ResolverSettings{
+		"polygon": {
+			"main": {ContractAddress: "0x624ce98D2d27b20b8f8d521723Df8fC4db71D79D", NetworkURL: "https://polygon-mainnet", ChainID: "137", Method: "polygonid"},
+			"amoy": {
+				ContractAddress: "0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124",
+				NetworkURL:      "https://polygon-amoy",
+				ChainID:         "80002",
+				Method:          "polygonid",
+				DID:             amoySenderDID,
+				Confirmations:   32,
+			},
+		},
+		"privado": {
+			"main": {ContractAddress: "0x3C9acB2205Aa72A05F6D77d708b5Cf85FCa3a896", NetworkURL: "https://rpc-mainnet.privado.id", ChainID: "21000", Method: "iden3"},
+		},
+	}
+	server := New(testCfg, nil, map[string]string{"80002": amoySenderDID})
+
+	resp, err := server.GetNetworks(context.Background(), GetNetworksRequestObject{})
+	require.NoError(t, err)
+	assert.Equal(t, GetNetworks200JSONResponse{
+		{
+			Name: "polygon:amoy", Blockchain: "polygon", Network: "amoy", ChainID: "80002", Method: "polygonid",
+			StateContract: "0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124", VerifierDID: common.ToPointer(amoySenderDID), Confirmations: 32,
+		},
+		{Name: "polygon:main", Blockchain: "polygon", Network: "main", ChainID: "137", Method: "polygonid", StateContract: "0x624ce98D2d27b20b8f8d521723Df8fC4db71D79D"},
+		{Name: "privado:main", Blockchain: "privado", Network: "main", ChainID: "21000", Method: "iden3", StateContract: "0x3C9acB2205Aa72A05F6D77d708b5Cf85FCa3a896"},
+	}, resp)
+}
+
+func TestContractPreset(t *testing.T) {
+	ctx := context.Background()
+	testCfg := cfg
+	testCfg.ResolverSettings = config.ResolverSettings{
+		"polygon": {
+			"amoy": {ChainID: "80002", VerifierContract: "0xfcc86A79fCb057A8e55C6B853dff9479C3cf607c", VerifierMethodID: "b68967e2"},
+			"main": {ChainID: "137"},
+		},
+	}
+	server := New(testCfg, nil, map[string]string{"80002": amoySenderDID, "137": amoySenderDID})
+
+	signIn := func(data TransactionData) SignInResponseObject {
+		resp, err := server.SignIn(ctx, SignInRequestObject{
+			Body: &SignInJSONRequestBody{
+				Scope: []ScopeRequest{
+					{
+						Id:        1,
+						CircuitId: string(circuits.AtomicQuerySigV2OnChainCircuitID),
+						Query: jsonToMap(t, `{
+							"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+							"allowedIssuers": ["*"],
+							"type": "KYCAgeCredential"
+						}`),
+					},
+				},
+				TransactionData: &data,
+			},
+		})
+		require.NoError(t, err)
+		return resp
+	}
+	transactionData := func(resp SignInResponseObject) *TransactionDataResponse {
+		token := isValidaQrStoreCallback(t, resp.(SignIn200JSONResponse).QrCode)
+		qrResp, err := server.GetQRCodeFromStore(ctx, GetQRCodeFromStoreRequestObject{Params: GetQRCodeFromStoreParams{Id: token}})
+		require.NoError(t, err)
+		return qrResp.(GetQRCodeFromStore200JSONResponse).Body.Body.TransactionData
+	}
+
+	assert.Equal(t, &TransactionDataResponse{
+		ContractAddress: "0xfcc86A79fCb057A8e55C6B853dff9479C3cf607c",
+		MethodId:        "b68967e2",
+		ChainId:         80002,
+		Network:         "polygon-amoy",
+	}, transactionData(signIn(TransactionData{ChainID: 80002})))
+
+	// the fields of the sign-in override the preset
+	assert.Equal(t, &TransactionDataResponse{
+		ContractAddress: "0x36eB0E70a456c310D8d8d15ae01F6D5A7C15309A",
+		MethodId:        "b68967e2",
+		ChainId:         80002,
+		Network:         "polygon-amoy",
+	}, transactionData(signIn(TransactionData{ChainID: 80002, ContractAddress: "0x36eB0E70a456c310D8d8d15ae01F6D5A7C15309A"})))
+
+	assert.Equal(t, SignIn400JSONResponse{N400JSONResponse{Message: "field contractAddress is empty"}}, signIn(TransactionData{ChainID: 137}))
+}
+
+func TestBodyLimits(t *testing.T) {
+	handler := BodyLimits(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	tests := []struct {
+		name        string
+		path        string
+		body        io.Reader
+		contentType string
+		code        int
+	}{
+		{name: "json", path: "/sign-in", body: strings.NewReader(`{}`), contentType: "application/json", code: http.StatusOK},
+		{name: "json charset", path: "/sign-in", body: strings.NewReader(`{}`), contentType: "application/json; charset=utf-8", code: http.StatusOK},
+		{name: "callback", path: "/callback", body: strings.NewReader("token"), contentType: "text/plain", code: http.StatusOK},
+		{name: "no body", path: "/sign-in", code: http.StatusOK},
+		{name: "too large", path: "/sign-in", body: strings.NewReader(`{"reason": "too large"}`), contentType: "application/json", code: http.StatusRequestEntityTooLarge},
+		{
+			name: "too large without length", path: "/sign-in", body: io.MultiReader(strings.NewReader(`{"reason": "too large"}`)),
+			contentType: "application/json", code: http.StatusRequestEntityTooLarge,
+		},
+		{name: "callback json", path: "/callback", body: strings.NewReader("token"), contentType: "application/json", code: http.StatusUnsupportedMediaType},
+		{name: "form", path: "/sign-in", body: strings.NewReader("a=b"), contentType: "application/x-www-form-urlencoded", code: http.StatusUnsupportedMediaType},
+		{name: "no content type", path: "/sign-in", body: strings.NewReader(`{}`), code: http.StatusUnsupportedMediaType},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tc.path, tc.body)
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+}
+
+func TestCheckToken(t *testing.T) {
+	limits := config.Limits{MaxTokenSize: 1024, MaxScopes: 1}
+	segment := func(v string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(v))
+	}
+	header := segment(`{"alg": "groth16", "circuitId": "authV2", "crit": ["circuitId"]}`)
+	tests := []struct {
+		name  string
+		token string
+		err   string
+	}{
+		{name: "valid", token: invalidProofToken},
+		{name: "too large", token: strings.Repeat("a", 1025), err: "the token is larger than 1024 bytes"},
+		{name: "segments", token: "jwz-token", err: "the token is not a valid JWZ: it must have 3 segments"},
+		{name: "base64", token: "a.b!.c", err: "the token is not a valid JWZ: its segments must be base64url encoded"},
+		{name: "header", token: segment("[]") + "." + segment("{}") + ".", err: "the token is not a valid JWZ: its header must be a JSON object"},
+		{
+			name:  "critical headers",
+			token: segment(`{"alg": "groth16", "circuitId": "authV2"}`) + "." + segment("{}") + ".",
+			err:   "the token is not a valid JWZ: its header must list its critical headers",
+		},
+		{
+			name:  "missing critical header",
+			token: segment(`{"alg": "groth16", "circuitId": "authV2", "crit": ["typ"]}`) + "." + segment("{}") + ".",
+			err:   "the token is not a valid JWZ: its critical headers must be present",
+		},
+		{name: "payload", token: header + "." + segment("[]") + ".", err: "the token is not a valid JWZ: its payload must be an iden3comm message"},
+		{
+			name:  "scopes",
+			token: header + "." + segment(`{"body": {"scope": [{"id": 1}, {"id": 2}]}}`) + ".",
+			err:   "the token has 2 proofs, at most 1 are accepted",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkToken(tc.token, limits)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+
+	server := New(cfg, nil, map[string]string{"80002": amoySenderDID})
+	sessionID := uuid.New()
+	server.cache.Set(sessionID.String(), protocol.AuthorizationRequestMessage{ID: sessionID.String()}, cache.DefaultExpiration)
+	resp, err := server.Callback(context.Background(), CallbackRequestObject{Params: CallbackParams{SessionID: sessionID}, Body: common.ToPointer("jwz-token")})
+	require.NoError(t, err)
+	assert.Equal(t, Callback400JSONResponse{N400JSONResponse{Message: "the token is not a valid JWZ: it must have 3 segments"}}, resp)
+	status, err := server.Status(context.Background(), StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+	require.NoError(t, err)
+	assert.Equal(t, statusPending, status.(Status200JSONResponse).Status)
+}
+
+// issuerStateResolver resolves the issuer auth state 1 as latest, and the other states as replaced at transition.
+// No state is found when unknown is set.
+type issuerStateResolver struct {
+	transition time.Time
+	unknown    bool
+	err        error
+}
+
+func (r issuerStateResolver) Resolve(_ context.Context, _, st *big.Int) (*state.ResolvedState, error) {
+	if r.err != nil || r.unknown {
+		return nil, r.err
+	}
+	if st.Cmp(big.NewInt(1)) == 0 {
+		return &state.ResolvedState{State: st.String(), Latest: true}, nil
+	}
+	return &state.ResolvedState{State: st.String(), TransitionTimestamp: r.transition.Unix()}, nil
+}
+
+func (r issuerStateResolver) ResolveGlobalRoot(_ context.Context, st *big.Int) (*state.ResolvedState, error) {
+	return &state.ResolvedState{State: st.String(), Latest: true}, nil
+}
+
+// issuerRevocations reports whether the issuers revoked credentials since the non-revocation state 2
+type issuerRevocations struct {
+	revocationChecker
+	revoked bool
+	err     error
+}
+
+func (r issuerRevocations) RevokedSince(_ context.Context, _ *w3c.DID, st *merkletree.Hash) (bool, error) {
+	if st.BigInt().Cmp(big.NewInt(2)) != 0 {
+		return false, fmt.Errorf("unexpected non-revocation state %s", st.BigInt())
+	}
+	return r.revoked, r.err
+}
+
+// sigV2Token returns a JWZ with a credentialAtomicQuerySigV2 proof of an issuer of Amoy, with the issuer auth state 1
+// and the non-revocation state nonRevState
+func sigV2Token(t *testing.T, nonRevState int64) string {
+	t.Helper()
+	did, err := w3c.ParseDID(amoySenderDID)
+	require.NoError(t, err)
+	issuerID, err := core.IDFromDID(*did)
+	require.NoError(t, err)
+
+	// 13 signals and the 64 values of the query
+	signals := make([]string, 13+64)
+	for i := range signals {
+		signals[i] = "0"
+	}
+	signals[1] = issuerID.BigInt().String()
+	signals[2] = "1"
+	signals[4] = issuerID.BigInt().String()
+	signals[5] = "1"
+	signals[6] = strconv.FormatInt(nonRevState, 10)
+
+	var scope protocol.ZeroKnowledgeProofResponse
+	scope.ID = 1
+	scope.CircuitID = string(circuits.AtomicQuerySigV2CircuitID)
+	scope.PubSignals = signals
+	payload, err := json.Marshal(protocol.AuthorizationResponseMessage{
+		From: amoySenderDID,
+		Body: protocol.AuthorizationMessageResponseBody{Scope: []protocol.ZeroKnowledgeProofResponse{scope}},
+	})
+	require.NoError(t, err)
+	header := `{"alg":"groth16","circuitId":"authV2","crit":["circuitId"],"typ":"application/iden3-zkp-json"}`
+	return base64.RawURLEncoding.EncodeToString([]byte(header)) + "." + base64.RawURLEncoding.EncodeToString(payload) + ".e30"
+}
+
+func TestReverification(t *testing.T) {
+	ctx := context.Background()
+	replaced := time.Now().Add(-time.Hour)
+
+	for _, tc := range []struct {
+		name      string
+		resolver  issuerStateResolver
+		checker   issuerRevocations
+		token     string
+		status    string
+		errorCode verrors.Code
+		event     string
+	}{
+		{
+			name:     "non-revocation state is latest",
+			resolver: issuerStateResolver{transition: replaced},
+			token:    sigV2Token(t, 1),
+			status:   statusSuccess,
+		},
+		{
+			name:     "non-revocation state replaced within the accepted delay",
+			resolver: issuerStateResolver{transition: time.Now()},
+			token:    sigV2Token(t, 2),
+			status:   statusSuccess,
+		},
+		{
+			name:     "non-revocation state replaced without revocations",
+			resolver: issuerStateResolver{transition: replaced},
+			checker:  issuerRevocations{},
+			token:    sigV2Token(t, 2),
+			status:   statusSuccess,
+		},
+		{
+			name:      "non-revocation state replaced by revocations",
+			resolver:  issuerStateResolver{transition: replaced},
+			checker:   issuerRevocations{revoked: true},
+			token:     sigV2Token(t, 2),
+			status:    statusRevoked,
+			errorCode: verrors.CodeRevokedCredential,
+			event:     events.TypeVerificationRevoked,
+		},
+		{
+			name:     "revocations cannot be checked",
+			resolver: issuerStateResolver{transition: replaced},
+			checker:  issuerRevocations{err: errors.New("rhs unavailable")},
+			token:    sigV2Token(t, 2),
+			status:   statusSuccess,
+		},
+		{
+			name:      "issuer auth state not found",
+			resolver:  issuerStateResolver{unknown: true},
+			token:     sigV2Token(t, 1),
+			status:    statusStale,
+			errorCode: verrors.CodeExpiredState,
+			event:     events.TypeVerificationStale,
+		},
+		{
+			name:     "states cannot be resolved",
+			resolver: issuerStateResolver{err: errors.New("rpc unavailable")},
+			token:    sigV2Token(t, 2),
+			status:   statusSuccess,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				received []webhook.Event
+			)
+			hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var event webhook.Event
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+				mu.Lock()
+				received = append(received, event)
+				mu.Unlock()
+			}))
+			defer hook.Close()
+
+			testCfg := cfg
+			testCfg.Reverification = config.Reverification{Enabled: true, After: config.CacheTTL(time.Hour), Interval: config.CacheTTL(time.Hour)}
+			testCfg.SessionWebhook = config.SessionWebhook{URL: hook.URL, Timeout: config.CacheTTL(time.Second)}
+			publisher := &recordingPublisher{}
+			bus := events.NewBus(publisher, 10)
+			server := New(testCfg, nil, map[string]string{"80002": amoySenderDID}, WithEvents(bus),
+				WithStateResolvers(map[string]pubsignals.StateResolver{"polygon:amoy": tc.resolver}))
+			server.revocationChecker = tc.checker
+
+			sessionID := uuid.New()
+			server.cache.Set(sessionID.String(), models.VerificationResponse{Jwz: tc.token, UserDID: amoySenderDID}, cache.DefaultExpiration)
+			server.scheduleReverification(sessionID, tc.token)
+
+			// the verifications are not checked before they are due
+			server.reverify(ctx, time.Now())
+			status, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+			require.NoError(t, err)
+			assert.Equal(t, statusSuccess, status.(Status200JSONResponse).Status)
+
+			server.reverify(ctx, time.Now().Add(2*time.Hour))
+			status, err = server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+			require.NoError(t, err)
+			resp := status.(Status200JSONResponse)
+			assert.Equal(t, tc.status, resp.Status)
+			require.NoError(t, bus.Close())
+
+			next, scheduled := server.cache.Get(reverificationKeyPrefix + sessionID.String())
+			if tc.event == "" {
+				assert.Nil(t, resp.ErrorCode)
+				require.True(t, scheduled)
+				assert.WithinDuration(t, time.Now().Add(3*time.Hour), next.(reverification).Due, time.Minute)
+				assert.Empty(t, publisher.events)
+				return
+			}
+			assert.False(t, scheduled)
+			require.NotNil(t, resp.ErrorCode)
+			assert.Equal(t, tc.errorCode, *resp.ErrorCode)
+			require.Len(t, publisher.events, 1)
+			assert.Equal(t, tc.event, publisher.events[0].Type)
+			assert.Equal(t, amoySenderDID, publisher.events[0].UserDID)
+			assert.Equal(t, string(tc.errorCode), publisher.events[0].ErrorCode)
+			require.Eventually(t, func() bool {
+				mu.Lock()
+				defer mu.Unlock()
+				return len(received) == 1
+			}, time.Second, 10*time.Millisecond)
+			assert.Equal(t, tc.event, received[0].Type)
+			assert.Equal(t, tc.status, received[0].Status)
+		})
+	}
+}
+
+func TestReverificationSkipsFinishedResults(t *testing.T) {
+	testCfg := cfg
+	testCfg.Reverification = config.Reverification{Enabled: true, After: config.CacheTTL(time.Hour), Interval: config.CacheTTL(time.Hour)}
+	server := New(testCfg, nil, map[string]string{"80002": amoySenderDID},
+		WithStateResolvers(map[string]pubsignals.StateResolver{"polygon:amoy": issuerStateResolver{transition: time.Now().Add(-time.Hour)}}))
+
+	token := sigV2Token(t, 2)
+	sessionID := uuid.New()
+	server.cache.Set(sessionID.String(), consumedResult{ConsumedAt: time.Now()}, cache.DefaultExpiration)
+	server.scheduleReverification(sessionID, token)
+	server.reverify(context.Background(), time.Now().Add(2*time.Hour))
+
+	item, _ := server.cache.Get(sessionID.String())
+	assert.IsType(t, consumedResult{}, item)
+	_, scheduled := server.cache.Get(reverificationKeyPrefix + sessionID.String())
+	assert.False(t, scheduled)
+}
+
+func TestCallbackQueue(t *testing.T) {
+	ctx := context.Background()
+	testCfg := cfg
+	testCfg.TestMode = config.TestMode{Enabled: true, Token: "canned-token"}
+	testCfg.VerificationConcurrency = 1
+	testCfg.VerificationQueueSize = 1
+	testCfg.VerificationAsync = true
+	userDID := "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"
+	mock, err := testmode.NewVerifier("canned-token", userDID, "did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK")
+	require.NoError(t, err)
+	server := New(testCfg, mock, map[string]string{"80002": amoySenderDID})
+	workers, stop := context.WithCancel(ctx)
+	defer stop()
+	go server.RunVerificationWorkers(workers)
+
+	signIn := func() uuid.UUID {
+		resp, err := server.SignIn(ctx, SignInRequestObject{
+			Body: &SignInJSONRequestBody{
+				ChainID: common.ToPointer("80002"),
+				Scope: []ScopeRequest{
+					{
+						Id:        1,
+						CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+						Query: jsonToMap(t, `{
+							"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+							"allowedIssuers": ["*"],
+							"type": "KYCAgeCredential"
+						}`),
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		return resp.(SignIn200JSONResponse).SessionID
+	}
+	callback := func(sessionID uuid.UUID) CallbackResponseObject {
+		token := "canned-token"
+		resp, err := server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: sessionID}, Body: &token})
+		require.NoError(t, err)
+		return resp
+	}
+	status := func(sessionID uuid.UUID) string {
+		resp, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+		require.NoError(t, err)
+		return resp.(Status200JSONResponse).Status
+	}
+
+	// the callbacks are verified inline while a slot is free
+	assert.IsType(t, Callback200JSONResponse{}, callback(signIn()))
+
+	release, err := server.lanes.Acquire(ctx, lanes.Normal)
+	require.NoError(t, err)
+	queued, rejected := signIn(), signIn()
+	assert.IsType(t, Callback202JSONResponse{}, callback(queued))
+	assert.IsType(t, Callback202JSONResponse{}, callback(queued), "the session already has a queued verification")
+	assert.IsType(t, Callback429JSONResponse{}, callback(rejected))
+	assert.Equal(t, statusPending, status(queued))
+	assert.Equal(t, statusPending, status(rejected))
+
+	release()
+	require.Eventually(t, func() bool { return status(queued) == statusSuccess }, time.Second, 10*time.Millisecond)
+	assert.IsType(t, Callback200JSONResponse{}, callback(rejected), "the rejected callbacks can be sent again")
+}
+
+// proofReader answers the proofs of the requests of the verifier contract
+type proofReader map[uint64]onchain.Proof
+
+func (r proofReader) Proof(_ context.Context, _ int, _, _ common2.Address, requestID uint64) (onchain.Proof, error) {
+	return r[requestID], nil
+}
+
+func TestCheckOnChainProofs(t *testing.T) {
+	ctx := context.Background()
+	userDID, err := w3c.ParseDID("did:polygonid:polygon:amoy:2qV9QXdhXXmN5sKjN1YueMjxgRbnJcEGK2kGpvk3cq")
+	require.NoError(t, err)
+	userID, err := core.IDFromDID(*userDID)
+	require.NoError(t, err)
+	reader := proofReader{}
+	testCfg := cfg
+	testCfg.Reverification = config.Reverification{Enabled: true, After: config.CacheTTL(time.Hour), Interval: config.CacheTTL(time.Hour)}
+	var hooked []string
+	server := New(testCfg, nil, map[string]string{"80002": amoySenderDID}, WithOnChainReader(reader),
+		WithVerificationHooks(hooks.Func(func(_ context.Context, _ hooks.Session, response protocol.AuthorizationResponseMessage) error {
+			hooked = append(hooked, response.From)
+			return nil
+		})))
+
+	scope := func(id uint32, nullifierSessionID string) ScopeRequest {
+		return ScopeRequest{
+			Id:        id,
+			CircuitId: string(circuits.AtomicQueryV3OnChainCircuitID),
+			Query: jsonToMap(t, `{
+				"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+				"allowedIssuers": ["*"],
+				"type": "KYCAgeCredential"
+			}`),
+			Params: &map[string]interface{}{"nullifierSessionID": nullifierSessionID},
+		}
+	}
+	signIn := func() uuid.UUID {
+		resp, err := server.SignIn(ctx, SignInRequestObject{
+			Body: &SignInJSONRequestBody{
+				Scope:                  []ScopeRequest{scope(1, "11"), scope(2, "22")},
+				EnforceUniqueNullifier: common.ToPointer(true),
+				TransactionData: &TransactionData{
+					ChainID:         80002,
+					ContractAddress: "0xfcc86A79fCb057A8e55C6B853dff9479C3cf607c",
+					MethodID:        "b68967e2",
+					Network:         "polygon-amoy",
+				},
+			},
+		})
+		require.NoError(t, err)
+		return resp.(SignIn200JSONResponse).SessionID
+	}
+	sessionID := signIn()
+	check := func(address string) CheckOnChainProofsResponseObject {
+		resp, err := server.CheckOnChainProofs(ctx, CheckOnChainProofsRequestObject{
+			SessionID: sessionID,
+			Body:      &CheckOnChainProofsJSONRequestBody{Address: address},
+		})
+		require.NoError(t, err)
+		return resp
+	}
+	const sender = "0x9b3F1d5C0A8F7fBc3c1a7C7dB3fE3a9B6a6A2fB1"
+
+	assert.IsType(t, CheckOnChainProofs400JSONResponse{}, check("0x12"))
+
+	// the proofs mined before the session answer an earlier request
+	reader[1] = onchain.Proof{Verified: true, UserID: userID.BigInt(), Nullifier: big.NewInt(100), Time: time.Now().Add(-time.Hour)}
+	reader[2] = onchain.Proof{Verified: true, UserID: userID.BigInt(), Nullifier: big.NewInt(200), Time: time.Now().Add(-time.Hour)}
+	status := check(sender).(CheckOnChainProofs200JSONResponse)
+	assert.Equal(t, statusPending, status.Status)
+
+	// the session is pending until the proofs of all its scopes are recorded
+	reader[1] = onchain.Proof{Verified: true, UserID: userID.BigInt(), Nullifier: big.NewInt(100), Time: time.Now()}
+	status = check(sender).(CheckOnChainProofs200JSONResponse)
+	assert.Equal(t, statusPending, status.Status)
+
+	reader[2] = onchain.Proof{Verified: true, UserID: userID.BigInt(), Nullifier: big.NewInt(200), Time: time.Now()}
+	status = check(sender).(CheckOnChainProofs200JSONResponse)
+	assert.Equal(t, statusSuccess, status.Status)
+	assert.Nil(t, status.Jwz)
+	assert.Equal(t, userDID.String(), status.JwzMetadata.UserDID)
+	assert.Equal(t, &[]JWZProofs{
+		{ScopeID: 1, NullifierSessionID: "11", Nullifier: "100"},
+		{ScopeID: 2, NullifierSessionID: "22", Nullifier: "200"},
+	}, status.JwzMetadata.Nullifiers)
+	assert.Equal(t, []string{userDID.String()}, hooked)
+
+	statusResp, err := server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: sessionID}})
+	require.NoError(t, err)
+	assert.Equal(t, statusSuccess, statusResp.(Status200JSONResponse).Status)
+
+	// the proofs replaced in the contract invalidate the verification
+	verified := sessionID
+	delete(reader, 2)
+	server.reverify(ctx, time.Now().Add(2*time.Hour))
+	statusResp, err = server.Status(ctx, StatusRequestObject{Params: StatusParams{SessionID: verified}})
+	require.NoError(t, err)
+	assert.Equal(t, statusStale, statusResp.(Status200JSONResponse).Status)
+
+	// the nullifiers of the proofs cannot be used again
+	sessionID = signIn()
+	reader[1] = onchain.Proof{Verified: true, UserID: userID.BigInt(), Nullifier: big.NewInt(100), Time: time.Now()}
+	reader[2] = onchain.Proof{Verified: true, UserID: userID.BigInt(), Nullifier: big.NewInt(200), Time: time.Now()}
+	status = check(sender).(CheckOnChainProofs200JSONResponse)
+	assert.Equal(t, statusError, status.Status)
+
+	// the off-chain sessions are rejected
+	offChain, err := server.SignIn(ctx, SignInRequestObject{
+		Body: &SignInJSONRequestBody{
+			ChainID: common.ToPointer("80002"),
+			Scope: []ScopeRequest{{
+				Id:        1,
+				CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+				Query: jsonToMap(t, `{
+					"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+					"allowedIssuers": ["*"],
+					"type": "KYCAgeCredential"
+				}`),
+			}},
+		},
+	})
+	require.NoError(t, err)
+	sessionID = offChain.(SignIn200JSONResponse).SessionID
+	assert.IsType(t, CheckOnChainProofs400JSONResponse{}, check(sender))
+}
+
+func TestVerificationStats(t *testing.T) {
+	ctx := context.Background()
+	testCfg := cfg
+	testCfg.AdminAPIKeys = []string{"admin-key"}
+	testCfg.TestMode = config.TestMode{Enabled: true, Token: "canned-token"}
+	mock, err := testmode.NewVerifier("canned-token", "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK",
+		"did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK")
+	require.NoError(t, err)
+	server := New(testCfg, mock, map[string]string{amoyNetwork: amoySenderDID})
+
+	for _, token := range []string{"canned-token", "canned-token", invalidProofToken} {
+		resp, err := server.SignIn(ctx, SignInRequestObject{
+			Body: &SignInJSONRequestBody{
+				ChainID: common.ToPointer(amoyNetwork),
+				Scope: []ScopeRequest{
+					{
+						Id:        1,
+						CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+						Query: jsonToMap(t, `{
+							"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+							"allowedIssuers": ["*"],
+							"type": "KYCAgeCredential"
+						}`),
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		_, err = server.Callback(ctx, CallbackRequestObject{
+			Params: CallbackParams{SessionID: resp.(SignIn200JSONResponse).SessionID},
+			Body:   &token,
+		})
+		require.NoError(t, err)
+	}
+
+	resp, err := server.GetVerificationStats(ctx, GetVerificationStatsRequestObject{})
+	require.NoError(t, err)
+	assert.IsType(t, GetVerificationStats401JSONResponse{}, resp)
+
+	adminKey := common.ToPointer("admin-key")
+	resp, err = server.GetVerificationStats(ctx, GetVerificationStatsRequestObject{Params: GetVerificationStatsParams{XAPIKey: adminKey}})
+	require.NoError(t, err)
+	stats := resp.(GetVerificationStats200JSONResponse)
+	assert.Len(t, stats.Days, 7)
+	assert.Equal(t, 3, stats.Verifications)
+	assert.Equal(t, 1, stats.Failures)
+	assert.InDelta(t, 2.0/3, *stats.SuccessRate, 1e-9)
+	assert.Equal(t, []CredentialTypeStats{{Type: "KYCAgeCredential", Verifications: 3}}, stats.CredentialTypes)
+	assert.Equal(t, []ErrorCodeStats{{Code: "VERIFICATION_FAILED", Failures: 1}}, stats.ErrorCodes)
+
+	resp, err = server.GetVerificationStats(ctx, GetVerificationStatsRequestObject{Params: GetVerificationStatsParams{
+		XAPIKey: adminKey, From: common.ToPointer("2025-06-10"), To: common.ToPointer("2025-06-01"),
+	}})
+	require.NoError(t, err)
+	assert.IsType(t, GetVerificationStats400JSONResponse{}, resp)
+}
+
+// countingVerifier answers every scope of the token, fails the verification of the scopes of failing,
+// and counts the verifications
+type countingVerifier struct {
+	scopes  []uint32
+	failing map[uint32]bool
+	calls   int
+}
+
+func (v *countingVerifier) FullVerify(_ context.Context, _ string, request protocol.AuthorizationRequestMessage,
+	_ ...pubsignals.VerifyOpt,
+) (*protocol.AuthorizationResponseMessage, error) {
+	v.calls++
+	for _, scope := range request.Body.Scope {
+		if v.failing[scope.ID] {
+			return nil, errors.New("proof is not valid")
+		}
+	}
+	resp := &protocol.AuthorizationResponseMessage{}
+	for _, id := range v.scopes {
+		resp.Body.Scope = append(resp.Body.Scope, protocol.ZeroKnowledgeProofResponse{ID: id})
+	}
+	return resp, nil
+}
+
+func TestVerifyScopes(t *testing.T) {
+	scopes := []uint32{1, 2, 3, 4}
+	request := protocol.AuthorizationRequestMessage{}
+	for _, id := range scopes {
+		request.Body.Scope = append(request.Body.Scope, protocol.ZeroKnowledgeProofRequest{ID: id})
+	}
+
+	verifier := &countingVerifier{scopes: scopes, failing: map[uint32]bool{2: true}}
+	server := New(cfg, verifier, nil)
+	resp, verified, failed, err := server.verifyScopes(context.Background(), "token", request, 3)
+	require.NoError(t, err)
+	assert.Len(t, resp.Body.Scope, 3)
+	assert.Len(t, verified.Body.Scope, 3)
+	assert.Equal(t, []models.ScopeStatus{{ID: 2, Status: scopeStatusFailed, Reason: "proof is not valid"}}, failed)
+
+	// the scopes left are not verified once the required scopes cannot be reached
+	verifier = &countingVerifier{scopes: scopes, failing: map[uint32]bool{1: true, 2: true}}
+	server = New(cfg, verifier, nil)
+	_, _, failed, err = server.verifyScopes(context.Background(), "token", request, 3)
+	assert.True(t, i18n.HasCode(err, i18n.CodeScopesNotVerified))
+	assert.Len(t, failed, 4)
+	assert.Equal(t, 2, verifier.calls)
+}
+
+func TestSandboxKeys(t *testing.T) {
+	sandboxCfg := cfg
+	sandboxCfg.Sandbox = config.Sandbox{
+		Enabled:                 true,
+		KeyTTL:                  config.CacheTTL(time.Hour),
+		ChainIDs:                []string{"80002"},
+		MaxVerificationAttempts: 3,
+		RateLimit:               2,
+		RateWindow:              config.CacheTTL(time.Hour),
+	}
+	server := New(sandboxCfg, nil, nil)
+	create := func(addr, email string) CreateSandboxKeyResponseObject {
+		ctx := context.WithValue(context.Background(), clientAddrKey{}, addr)
+		resp, err := server.CreateSandboxKey(ctx, CreateSandboxKeyRequestObject{Body: &CreateSandboxKeyJSONRequestBody{Email: email}})
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := create("10.0.0.1", "alice@example.com")
+	require.IsType(t, CreateSandboxKey201JSONResponse{}, resp)
+	key, err := server.apiKeys.Get(resp.(CreateSandboxKey201JSONResponse).ApiKey)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", key.Email)
+	assert.True(t, key.AllowsChain("80002"))
+
+	assert.IsType(t, CreateSandboxKey201JSONResponse{}, create("10.0.0.2", "Alice@example.com"))
+	assert.IsType(t, CreateSandboxKey429JSONResponse{}, create("10.0.0.3", "alice@example.com"), "email rate limited")
+	assert.IsType(t, CreateSandboxKey201JSONResponse{}, create("10.0.0.1", "bob@example.com"))
+	assert.IsType(t, CreateSandboxKey429JSONResponse{}, create("10.0.0.1", "carol@example.com"), "address rate limited")
+	assert.IsType(t, CreateSandboxKey201JSONResponse{}, create("10.0.0.4", "carol@example.com"))
+
+	_, err = server.apiKeys.Get("sbx_unknown")
+	assert.ErrorIs(t, err, errAPIKeyInvalid)
+}
+
+func TestSandboxVerificationCode(t *testing.T) {
+	sandboxCfg := cfg
+	sandboxCfg.Sandbox = config.Sandbox{Enabled: true, EmailVerification: true, KeyTTL: config.CacheTTL(time.Hour), ChainIDs: []string{"80002"}, MaxVerificationAttempts: 3}
+	server := New(sandboxCfg, nil, nil)
+	ctx := context.Background()
+	verify := func(email, code string) VerifySandboxKeyResponseObject {
+		resp, err := server.VerifySandboxKey(ctx, VerifySandboxKeyRequestObject{Body: &VerifySandboxKeyJSONRequestBody{Email: email, Code: code}})
+		require.NoError(t, err)
+		return resp
+	}
+	wrong := func(code string) string {
+		if code == "000000" {
+			return "000001"
+		}
+		return "000000"
+	}
+
+	code, err := server.apiKeys.NewVerificationCode("alice@example.com")
+	require.NoError(t, err)
+	assert.IsType(t, VerifySandboxKey400JSONResponse{}, verify("alice@example.com", wrong(code)))
+	assert.IsType(t, VerifySandboxKey400JSONResponse{}, verify("alice@example.com", wrong(code)))
+	assert.IsType(t, VerifySandboxKey429JSONResponse{}, verify("alice@example.com", wrong(code)))
+	assert.IsType(t, VerifySandboxKey429JSONResponse{}, verify("alice@example.com", code), "locked codes cannot be used")
+
+	code, err = server.apiKeys.NewVerificationCode("alice@example.com")
+	require.NoError(t, err)
+	assert.IsType(t, VerifySandboxKey400JSONResponse{}, verify("bob@example.com", code))
+	assert.IsType(t, VerifySandboxKey201JSONResponse{}, verify("alice@example.com", code))
+	assert.IsType(t, VerifySandboxKey400JSONResponse{}, verify("alice@example.com", code), "codes can only be used once")
+}
+
+func TestGetNullifierProof(t *testing.T) {
+	ctx := context.Background()
+	registry, err := nullifier.NewRegistry(ctx, nullifier.NewMemoryStorage())
+	require.NoError(t, err)
+	added, err := registry.Add(ctx, big.NewInt(42))
+	require.NoError(t, err)
+	require.True(t, added)
+	checkpoint, err := registry.Checkpoint(ctx)
+	require.NoError(t, err)
+	server := New(cfg, nil, nil, WithNullifierRegistry(registry))
+
+	resp, err := server.GetNullifierProof(ctx, GetNullifierProofRequestObject{Nullifier: "42"})
+	require.NoError(t, err)
+	proof := resp.(GetNullifierProof200JSONResponse)
+	assert.True(t, proof.Exists)
+	require.NotNil(t, proof.Value)
+	value, ok := new(big.Int).SetString(*proof.Value, 10)
+	require.True(t, ok)
+	assert.True(t, merkletree.VerifyProof(checkpoint.Root, &proof.Proof, big.NewInt(42), value))
+
+	resp, err = server.GetNullifierProof(ctx, GetNullifierProofRequestObject{Nullifier: "43"})
+	require.NoError(t, err)
+	proof = resp.(GetNullifierProof200JSONResponse)
+	assert.False(t, proof.Exists)
+	assert.Nil(t, proof.Value)
+	assert.True(t, merkletree.VerifyProof(checkpoint.Root, &proof.Proof, big.NewInt(43), big.NewInt(0)))
+}
+
+func TestGetShadowVerificationReport(t *testing.T) {
+	ctx := context.Background()
+	shadowCfg := cfg
+	shadowCfg.AdminAPIKeys = []string{"admin"}
+
+	resp, err := New(shadowCfg, nil, map[string]string{"80002": amoySenderDID}).GetShadowVerificationReport(ctx,
+		GetShadowVerificationReportRequestObject{Params: GetShadowVerificationReportParams{XAPIKey: common.ToPointer("admin")}})
+	require.NoError(t, err)
+	assert.IsType(t, GetShadowVerificationReport404JSONResponse{}, resp)
+
+	server := New(shadowCfg, nil, map[string]string{"80002": amoySenderDID}, WithShadowVerifier(shadow.NewVerifier(nil, 1)))
+	resp, err = server.GetShadowVerificationReport(ctx,
+		GetShadowVerificationReportRequestObject{Params: GetShadowVerificationReportParams{XAPIKey: common.ToPointer("user")}})
+	require.NoError(t, err)
+	assert.IsType(t, GetShadowVerificationReport401JSONResponse{}, resp)
+
+	resp, err = server.GetShadowVerificationReport(ctx,
+		GetShadowVerificationReportRequestObject{Params: GetShadowVerificationReportParams{XAPIKey: common.ToPointer("admin")}})
+	require.NoError(t, err)
+	assert.Equal(t, GetShadowVerificationReport200JSONResponse{RecentDisagreements: []ShadowVerificationDisagreement{}}, resp)
+}
+
+func TestQueryTemplates(t *testing.T) {
+	ctx := context.Background()
+	templatesCfg := cfg
+	templatesCfg.AdminAPIKeys = []string{"admin"}
+	server := New(templatesCfg, nil, map[string]string{"80002": amoySenderDID})
+	admin := common.ToPointer("admin")
+	body := &SetQueryTemplateJSONRequestBody{
+		CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+		Query: jsonToMap(t, `{
+			"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+			"allowedIssuers": ["*"],
+			"type": "KYCAgeCredential"
+		}`),
+	}
+
+	resp, err := server.SetQueryTemplate(ctx, SetQueryTemplateRequestObject{TemplateName: "kyc age", Params: SetQueryTemplateParams{XAPIKey: admin}, Body: body})
+	require.NoError(t, err)
+	assert.Equal(t, SetQueryTemplate400JSONResponse{N400JSONResponse{Message: "template name must have between 1 and 64 letters, digits, '-' or '_'"}}, resp)
+
+	for _, name := range []string{"kyc-age", "basic"} {
+		resp, err = server.SetQueryTemplate(ctx, SetQueryTemplateRequestObject{TemplateName: name, Params: SetQueryTemplateParams{XAPIKey: admin}, Body: body})
+		require.NoError(t, err)
+		require.IsType(t, SetQueryTemplate200JSONResponse{}, resp)
+	}
+
+	// templates are not stored with the sessions
+	assert.Empty(t, server.cache.Items())
+
+	list, err := server.ListQueryTemplates(ctx, ListQueryTemplatesRequestObject{Params: ListQueryTemplatesParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "basic", list.(ListQueryTemplates200JSONResponse)[0].Name)
+	assert.Equal(t, "kyc-age", list.(ListQueryTemplates200JSONResponse)[1].Name)
+
+	scopes := []ScopeRequest{{Id: 1, CircuitId: string(circuits.AtomicQueryMTPV2CircuitID), Template: common.ToPointer("kyc-age")}}
+	assert.EqualError(t, server.applyQueryTemplates(scopes), "field circuitId "+string(circuits.AtomicQueryMTPV2CircuitID)+" does not match the circuitId of template kyc-age")
+
+	deleted, err := server.DeleteQueryTemplate(ctx, DeleteQueryTemplateRequestObject{TemplateName: "kyc-age", Params: DeleteQueryTemplateParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	require.IsType(t, DeleteQueryTemplate200JSONResponse{}, deleted)
+
+	got, err := server.GetQueryTemplate(ctx, GetQueryTemplateRequestObject{TemplateName: "kyc-age", Params: GetQueryTemplateParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	assert.Equal(t, GetQueryTemplate404JSONResponse{N404JSONResponse{Message: "query template kyc-age not found"}}, got)
+}
+
+func TestQueryTemplateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "query_templates.json")
+	store, err := OpenQueryTemplateFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, store.List())
+
+	template := QueryTemplate{
+		Name:      "kyc-age",
+		CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+		Query: Query{
+			"context":        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+			"type":           "KYCAgeCredential",
+			"allowedIssuers": []any{"*"},
+		},
+		UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, store.Save(template))
+
+	// a restarted server, or another replica sharing the file, loads the templates
+	replica, err := OpenQueryTemplateFile(path)
+	require.NoError(t, err)
+	got, ok := replica.Get("kyc-age")
+	require.True(t, ok)
+	assert.Equal(t, template, *got)
+
+	require.NoError(t, replica.Delete("kyc-age"))
+	// the file is newer than the one read by store, so it is reloaded
+	require.NoError(t, os.Chtimes(path, time.Now().Add(time.Second), time.Now().Add(time.Second)))
+	_, ok = store.Get("kyc-age")
+	assert.False(t, ok)
+}
+
+func TestReasonTemplates(t *testing.T) {
+	ctx := context.Background()
+	reasonsCfg := cfg
+	reasonsCfg.AdminAPIKeys = []string{"admin"}
+	reasonsCfg.ReasonTemplates = []config.ReasonTemplate{{
+		Name:          "age-check",
+		DefaultLocale: "en",
+		Locales: map[string]config.LocalizedReason{
+			"en": {Reason: "{{.company}} needs to check your age", Message: "Prove you are over 18 to {{.purpose}}"},
+			"pt": {Reason: "{{.company}} precisa verificar sua idade"},
+		},
+	}}
+	server := New(reasonsCfg, nil, map[string]string{"80002": amoySenderDID})
+	admin := common.ToPointer("admin")
+
+	signIn := func(ref ReasonTemplateRef, reason *string) SignInResponseObject {
+		resp, err := server.SignIn(ctx, SignInRequestObject{Body: &SignInJSONRequestBody{
+			ChainID:        common.ToPointer("80002"),
+			Reason:         reason,
+			ReasonTemplate: &ref,
+			Scope: []ScopeRequest{{
+				Id:        1,
+				CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+				Query: jsonToMap(t, `{
+					"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+					"allowedIssuers": ["*"],
+					"type": "KYCAgeCredential"
+				}`),
+			}},
+		}})
+		require.NoError(t, err)
+		return resp
+	}
+	request := func(resp SignInResponseObject) protocol.AuthorizationRequestMessage {
+		require.IsType(t, SignIn200JSONResponse{}, resp)
+		item, ok := server.cache.Get(resp.(SignIn200JSONResponse).SessionID.String())
+		require.True(t, ok)
+		return item.(protocol.AuthorizationRequestMessage)
+	}
+	variables := &map[string]string{"company": "Acme", "purpose": "buy tickets"}
+
+	authReq := request(signIn(ReasonTemplateRef{Name: "age-check", Locale: common.ToPointer("pt-BR"), Variables: variables}, nil))
+	assert.Equal(t, "Acme precisa verificar sua idade", authReq.Body.Reason)
+	assert.Empty(t, authReq.Body.Message)
+
+	authReq = request(signIn(ReasonTemplateRef{Name: "age-check", Locale: common.ToPointer("fr"), Variables: variables}, nil))
+	assert.Equal(t, "Acme needs to check your age", authReq.Body.Reason)
+	assert.Equal(t, "Prove you are over 18 to buy tickets", authReq.Body.Message)
+
+	authReq = request(signIn(ReasonTemplateRef{Name: "age-check", Variables: variables}, common.ToPointer("membership")))
+	assert.Equal(t, "membership", authReq.Body.Reason)
+
+	resp := signIn(ReasonTemplateRef{Name: "age-check", Variables: &map[string]string{"company": "Acme"}}, nil)
+	require.IsType(t, SignIn400JSONResponse{}, resp)
+	assert.Contains(t, resp.(SignIn400JSONResponse).Message, "reason template age-check cannot be rendered")
+	resp = signIn(ReasonTemplateRef{Name: "unknown"}, nil)
+	assert.Equal(t, SignIn400JSONResponse{N400JSONResponse{Message: "reason template unknown not found"}}, resp)
+
+	set, err := server.SetReasonTemplate(ctx, SetReasonTemplateRequestObject{TemplateName: "welcome", Params: SetReasonTemplateParams{XAPIKey: admin}, Body: &SetReasonTemplateJSONRequestBody{
+		DefaultLocale: "es",
+		Locales:       map[string]LocalizedReason{"en": {Reason: "Welcome to {{.company}}"}},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, SetReasonTemplate400JSONResponse{N400JSONResponse{Message: `invalid reason template: the default locale "es" has no reason`}}, set)
+
+	set, err = server.SetReasonTemplate(ctx, SetReasonTemplateRequestObject{TemplateName: "welcome", Params: SetReasonTemplateParams{XAPIKey: admin}, Body: &SetReasonTemplateJSONRequestBody{
+		DefaultLocale: "en",
+		Locales:       map[string]LocalizedReason{"en": {Reason: "Welcome to {{.company}}"}},
+	}})
+	require.NoError(t, err)
+	require.IsType(t, SetReasonTemplate200JSONResponse{}, set)
+
+	list, err := server.ListReasonTemplates(ctx, ListReasonTemplatesRequestObject{Params: ListReasonTemplatesParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "age-check", list.(ListReasonTemplates200JSONResponse)[0].Name)
+	assert.Equal(t, "welcome", list.(ListReasonTemplates200JSONResponse)[1].Name)
+
+	deleted, err := server.DeleteReasonTemplate(ctx, DeleteReasonTemplateRequestObject{TemplateName: "welcome", Params: DeleteReasonTemplateParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	require.IsType(t, DeleteReasonTemplate200JSONResponse{}, deleted)
+	deleted, err = server.DeleteReasonTemplate(ctx, DeleteReasonTemplateRequestObject{TemplateName: "welcome", Params: DeleteReasonTemplateParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	assert.Equal(t, DeleteReasonTemplate404JSONResponse{N404JSONResponse{Message: "reason template welcome not found"}}, deleted)
+}
+
+// recordingPublisher collects the published events
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event events.Event, _ []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error {
+	return nil
+}
+
+func TestSessionEvents(t *testing.T) {
+	ctx := context.Background()
+	testCfg := cfg
+	testCfg.TestMode = config.TestMode{Enabled: true, Token: "canned-token"}
+	userDID := "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"
+	mock, err := testmode.NewVerifier("canned-token", userDID, "did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK")
+	require.NoError(t, err)
+	publisher := &recordingPublisher{}
+	bus := events.NewBus(publisher, 10)
+	server := New(testCfg, mock, map[string]string{"80002": amoySenderDID}, WithEvents(bus))
+
+	var sessionIDs []string
+	for _, token := range []string{"canned-token", invalidProofToken} {
+		resp, err := server.SignIn(ctx, SignInRequestObject{
+			Body: &SignInJSONRequestBody{
+				ChainID: common.ToPointer("80002"),
+				Scope: []ScopeRequest{
+					{
+						Id:        1,
+						CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+						Query: jsonToMap(t, `{
+							"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+							"allowedIssuers": ["*"],
+							"type": "KYCAgeCredential"
+						}`),
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		sessionID := resp.(SignIn200JSONResponse).SessionID
+		_, err = server.Callback(ctx, CallbackRequestObject{Params: CallbackParams{SessionID: sessionID}, Body: &token})
+		require.NoError(t, err)
+		sessionIDs = append(sessionIDs, sessionID.String())
+	}
+	require.NoError(t, bus.Close())
+
+	require.Len(t, publisher.events, 4)
+	for _, event := range publisher.events {
+		assert.Equal(t, events.SchemaVersion, event.SchemaVersion)
+		assert.NotEmpty(t, event.ID)
+		assert.Equal(t, []string{string(circuits.AtomicQuerySigV2CircuitID)}, event.CircuitIDs)
+	}
+	assert.Equal(t, events.TypeSessionCreated, publisher.events[0].Type)
+	assert.Equal(t, sessionIDs[0], publisher.events[0].SessionID)
+	assert.Equal(t, events.TypeVerificationSucceeded, publisher.events[1].Type)
+	assert.Equal(t, userDID, publisher.events[1].UserDID)
+	assert.Equal(t, events.TypeSessionCreated, publisher.events[2].Type)
+	assert.Equal(t, events.TypeVerificationFailed, publisher.events[3].Type)
+	assert.Equal(t, sessionIDs[1], publisher.events[3].SessionID)
+	assert.Equal(t, "VERIFICATION_FAILED", publisher.events[3].ErrorCode)
+	assert.NotEmpty(t, publisher.events[3].Error)
+}
+
+func TestGetDIDDocument(t *testing.T) {
+	ctx := context.Background()
+	resp, err := New(cfg, nil, nil).GetDIDDocument(ctx, GetDIDDocumentRequestObject{})
+	require.NoError(t, err)
+	assert.IsType(t, GetDIDDocument404JSONResponse{}, resp)
+
+	docCfg := cfg
+	docCfg.Host = "https://verifier.example.com"
+	docCfg.DIDDocument = config.DIDDocument{Enabled: true, Sender: true, PushURL: "https://push.example.com/api/v1"}
+	docCfg.ResolverSettings = config.ResolverSettings{"polygon": {"amoy": {ChainID: "80002"}}}
+	server := New(docCfg, nil, map[string]string{"80002": amoySenderDID})
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key, err := signing.NewKey(privateKey)
+	require.NoError(t, err)
+	server.keys.SetTenantKey("", key)
+
+	resp, err = server.GetDIDDocument(ctx, GetDIDDocumentRequestObject{})
+	require.NoError(t, err)
+	doc := DIDDocument(resp.(GetDIDDocument200JSONResponse))
+	assert.Equal(t, "did:web:verifier.example.com", doc.Id)
+	assert.Equal(t, []string{didContext, jwsContext}, doc.Context)
+	assert.Equal(t, &[]string{amoySenderDID}, doc.AlsoKnownAs)
+	assert.Equal(t, []DIDService{
+		{Id: "did:web:verifier.example.com#iden3-communication", Type: iden3CommServiceType, ServiceEndpoint: "https://verifier.example.com/callback"},
+		{Id: "did:web:verifier.example.com#push", Type: pushServiceType, ServiceEndpoint: "https://push.example.com/api/v1"},
+	}, doc.Service)
+	require.NotNil(t, doc.VerificationMethod)
+	require.Len(t, *doc.VerificationMethod, 1)
+	method := (*doc.VerificationMethod)[0]
+	assert.Equal(t, "did:web:verifier.example.com#"+key.KeyID(), method.Id)
+	assert.Equal(t, jsonWebKeyType, method.Type)
+	assert.Equal(t, &[]string{method.Id}, doc.AssertionMethod)
+
+	// the off-chain requests are sent from the did:web identity of the verifier
+	request, err := server.getAuthRequestOffChain(context.Background(), SignInRequestObject{Body: &SignInJSONRequestBody{
+		ChainID: common.ToPointer("80002"),
+		Scope: []ScopeRequest{{
+			Id:        1,
+			CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+			Query: jsonToMap(t, `{
+				"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+				"allowedIssuers": ["*"],
+				"type": "KYCAgeCredential"
+			}`),
+		}},
+	}}, uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, "did:web:verifier.example.com", request.From)
+}
+
+func TestVerifierKeys(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeSigningKey := func() {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalECPrivateKey(privateKey)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "signing.pem"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600))
+	}
+	writeETHKey := func(key string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "eth.key"), []byte(key), 0o600))
+	}
+	writeSigningKey()
+	writeETHKey(strings.Repeat("01", 32))
+
+	keysCfg := cfg
+	keysCfg.AdminAPIKeys = []string{"admin"}
+	keysCfg.SigningKeyPath = filepath.Join(dir, "signing.pem")
+	keysCfg.Keystore = config.Keystore{ETHKeyPath: filepath.Join(dir, "eth.key")}
+	ring, err := signing.NewKeyRing(keysCfg)
+	require.NoError(t, err)
+	ks, err := keystore.New(ctx, keysCfg)
+	require.NoError(t, err)
+	server := New(keysCfg, nil, map[string]string{"80002": amoySenderDID}, WithKeyRing(ring), WithKeystore(ks))
+	admin := common.ToPointer("admin")
+
+	list, err := server.ListVerifierKeys(ctx, ListVerifierKeysRequestObject{})
+	require.NoError(t, err)
+	assert.IsType(t, ListVerifierKeys401JSONResponse{}, list)
+	list, err = server.ListVerifierKeys(ctx, ListVerifierKeysRequestObject{Params: ListVerifierKeysParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	jwtKey, ethKey := mustSigningKey(t, ring), mustVerifierKey(t, ks)
+	expected := []VerifierKey{{Type: "JWT", KeyId: jwtKey.KeyID()}, {Type: "ETH", KeyId: ethKey.ID()}}
+	assert.Equal(t, expected, VerifierKeys(list.(ListVerifierKeys200JSONResponse)).Keys)
+
+	// the keys did not change
+	rotated, err := server.RotateVerifierKeys(ctx, RotateVerifierKeysRequestObject{Params: RotateVerifierKeysParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	assert.Equal(t, expected, VerifierKeys(rotated.(RotateVerifierKeys200JSONResponse)).Keys)
+
+	// the key files are replaced, the keys they held are retired
+	writeSigningKey()
+	writeETHKey(strings.Repeat("02", 32))
+	rotated, err = server.RotateVerifierKeys(ctx, RotateVerifierKeysRequestObject{Params: RotateVerifierKeysParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	assert.Equal(t, []VerifierKey{
+		{Type: "JWT", KeyId: mustSigningKey(t, ring).KeyID()},
+		{Type: "JWT", KeyId: jwtKey.KeyID(), Retired: true},
+		{Type: "ETH", KeyId: mustVerifierKey(t, ks).ID()},
+		{Type: "ETH", KeyId: ethKey.ID(), Retired: true},
+	}, VerifierKeys(rotated.(RotateVerifierKeys200JSONResponse)).Keys)
+	assert.NotEqual(t, jwtKey.KeyID(), mustSigningKey(t, ring).KeyID())
+	assert.NotEqual(t, ethKey.ID(), mustVerifierKey(t, ks).ID())
+
+	// the retired signing key is still published
+	jwks, err := ring.JWKS("")
+	require.NoError(t, err)
+	assert.Len(t, jwks.Keys, 2)
+
+	writeETHKey("not a key")
+	rotated, err = server.RotateVerifierKeys(ctx, RotateVerifierKeysRequestObject{Params: RotateVerifierKeysParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	assert.IsType(t, RotateVerifierKeys500JSONResponse{}, rotated)
+}
+
+func mustSigningKey(t *testing.T, ring *signing.KeyRing) *signing.Key {
+	t.Helper()
+	key, err := ring.Key("")
+	require.NoError(t, err)
+	return key
+}
+
+func mustVerifierKey(t *testing.T, ks *keystore.Keystore) *keystore.Key {
+	t.Helper()
+	key, err := ks.Key(keystore.TypeETH)
+	require.NoError(t, err)
+	return key
+}
+
+func TestCreateCredentialOffer(t *testing.T) {
+	ctx := context.Background()
+	var created atomic.Int32
+	issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			created.Add(1)
+			_, _ = w.Write([]byte(`{"id": "8edd8112-c415-11ed-b036-debe37e1cbd6"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"universalLink": "https://wallet.privado.id#request_uri=offer"}`))
+	}))
+	defer issuer.Close()
+
+	offerCfg := cfg
+	offerCfg.CredentialOffers = []config.CredentialOffer{{
+		Name:              "kyc-passed",
+		IssuerURL:         issuer.URL,
+		IssuerDID:         "did:polygonid:polygon:amoy:2qQ68JkRcf3xrHPQPWZei3YeVzHPP58wYNxx2mEouR",
+		CredentialSchema:  "https://example.com/schemas/kyc-passed.json",
+		Type:              "KYCPassed",
+		CredentialSubject: map[string]any{"passed": true},
+	}}
+	server := New(offerCfg, nil, map[string]string{"80002": amoySenderDID})
+
+	pendingID, failedID, verifiedID := uuid.New(), uuid.New(), uuid.New()
+	server.cache.Set(pendingID.String(), protocol.AuthorizationRequestMessage{}, 0)
+	server.cache.Set(failedID.String(), errors.New("proof is not valid"), 0)
+	server.cache.Set(verifiedID.String(), models.VerificationResponse{UserDID: "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"}, 0)
+	for _, id := range []uuid.UUID{pendingID, failedID, verifiedID} {
+		server.setSessionOwner(id, common.ToPointer("owner-key"))
+	}
+	offer := func(id uuid.UUID, params CreateCredentialOfferParams) CreateCredentialOfferResponseObject {
+		resp, err := server.CreateCredentialOffer(ctx, CreateCredentialOfferRequestObject{SessionID: id, Params: params})
+		require.NoError(t, err)
+		return resp
+	}
+	owner := CreateCredentialOfferParams{XAPIKey: common.ToPointer("owner-key")}
+
+	assert.IsType(t, CreateCredentialOffer401JSONResponse{}, offer(verifiedID, CreateCredentialOfferParams{}))
+	assert.IsType(t, CreateCredentialOffer403JSONResponse{}, offer(verifiedID, CreateCredentialOfferParams{XAPIKey: common.ToPointer("other-key")}))
+	assert.IsType(t, CreateCredentialOffer404JSONResponse{}, offer(verifiedID, CreateCredentialOfferParams{XAPIKey: owner.XAPIKey, Offer: common.ToPointer("unknown")}))
+	assert.IsType(t, CreateCredentialOffer409JSONResponse{}, offer(pendingID, owner))
+	assert.IsType(t, CreateCredentialOffer409JSONResponse{}, offer(failedID, owner))
+
+	expected := CreateCredentialOffer200JSONResponse{
+		Offer:         "kyc-passed",
+		CredentialID:  "8edd8112-c415-11ed-b036-debe37e1cbd6",
+		UniversalLink: "https://wallet.privado.id#request_uri=offer",
+	}
+	assert.Equal(t, expected, offer(verifiedID, owner))
+	// the retries return the same offer
+	assert.Equal(t, expected, offer(verifiedID, CreateCredentialOfferParams{XAPIKey: owner.XAPIKey, Offer: common.ToPointer("kyc-passed")}))
+	assert.Equal(t, int32(1), created.Load())
+}
+
+func TestUnpackMessage(t *testing.T) {
+	ctx := context.Background()
+	server := New(cfg, nil, nil)
+
+	did, err := w3c.ParseDID(amoySenderDID)
+	require.NoError(t, err)
+	userID, err := core.IDFromDID(*did)
+	require.NoError(t, err)
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	token := encode(`{"alg":"groth16","circuitId":"authV2","crit":["circuitId"],"typ":"application/iden3-zkp-json"}`) + "." +
+		encode(`{"id":"1","from":"`+amoySenderDID+`","body":{"scope":[{"id":1,"circuitId":"unknown","pub_signals":["1"]}]}}`) + "." +
+		encode(`{"pub_signals":["`+userID.BigInt().String()+`","12345","0"]}`)
+
+	resp, err := server.UnpackMessage(ctx, UnpackMessageRequestObject{Body: &UnpackMessageJSONRequestBody{Message: token}})
+	require.NoError(t, err)
+	unpacked := UnpackResponse(resp.(UnpackMessage200JSONResponse))
+	assert.Equal(t, string(packers.MediaTypeZKPMessage), unpacked.MediaType)
+	require.NotNil(t, unpacked.Header)
+	assert.Equal(t, "groth16", (*unpacked.Header)["alg"])
+	assert.Equal(t, amoySenderDID, unpacked.Payload.From)
+	require.Len(t, unpacked.Circuits, 2)
+	auth := unpacked.Circuits[0]
+	assert.Equal(t, "authV2", auth.CircuitId)
+	assert.Nil(t, auth.ScopeID)
+	require.NotNil(t, auth.PubSignals)
+	assert.Equal(t, userID.String(), (*auth.PubSignals)["userID"])
+	assert.Equal(t, json.Number("12345"), (*auth.PubSignals)["challenge"])
+	// the pub signals of the scopes that can not be interpreted are reported with their circuit
+	scope := unpacked.Circuits[1]
+	assert.Equal(t, common.ToPointer(1), scope.ScopeID)
+	assert.Nil(t, scope.PubSignals)
+	assert.NotNil(t, scope.Error)
+
+	// the plain messages have no header nor auth proof
+	resp, err = server.UnpackMessage(ctx, UnpackMessageRequestObject{Body: &UnpackMessageJSONRequestBody{
+		Message: `{"id":"1","typ":"application/iden3comm-plain-json","body":{"scope":[]}}`,
+	}})
+	require.NoError(t, err)
+	unpacked = UnpackResponse(resp.(UnpackMessage200JSONResponse))
+	assert.Equal(t, string(packers.MediaTypePlainMessage), unpacked.MediaType)
+	assert.Nil(t, unpacked.Header)
+	assert.Empty(t, unpacked.Circuits)
+
+	for _, message := range []string{"", "not a message", "a.b.c"} {
+		resp, err = server.UnpackMessage(ctx, UnpackMessageRequestObject{Body: &UnpackMessageJSONRequestBody{Message: message}})
+		require.NoError(t, err)
+		assert.IsType(t, UnpackMessage400JSONResponse{}, resp, message)
+	}
+}
+
+func TestBuildQuery(t *testing.T) {
+	ctx := context.Background()
+	kycContext := "https://example.com/kyc.jsonld"
+	loader := contextLoader{kycContext: `{"@context": [{
+		"@version": 1.1, "@protected": true, "id": "@id", "type": "@type",
+		"KYCAgeCredential": {"@id": "https://example.com/kyc#KYCAgeCredential", "@context": {
+			"@version": 1.1, "@protected": true, "id": "@id", "type": "@type",
+			"vocab": "https://example.com/kyc-vocab#", "xsd": "http://www.w3.org/2001/XMLSchema#",
+			"birthday": {"@id": "vocab:birthday", "@type": "xsd:integer"},
+			"country": {"@id": "vocab:country", "@type": "xsd:string"}
+		}}
+	}]}`}
+	nonMerklizedContext := "https://example.com/kyc-nonmerklized.jsonld"
+	loader[nonMerklizedContext] = `{"@context": [{
+		"@version": 1.1, "@protected": true, "id": "@id", "type": "@type",
+		"KYCAgeCredential": {"@id": "https://example.com/kyc-nonmerklized#KYCAgeCredential", "@context": {
+			"@version": 1.1, "@protected": true, "id": "@id", "type": "@type",
+			"iden3_serialization": "iden3:v1:slotIndexA=birthday&slotIndexB=documentType",
+			"vocab": "https://example.com/kyc-vocab#", "xsd": "http://www.w3.org/2001/XMLSchema#",
+			"birthday": {"@id": "vocab:birthday", "@type": "xsd:integer"},
+			"documentType": {"@id": "vocab:documentType", "@type": "xsd:integer"},
+			"country": {"@id": "vocab:country", "@type": "xsd:string"}
+		}}
+	}]}`
+
+	resp, err := New(cfg, nil, nil).BuildQuery(ctx, BuildQueryRequestObject{Body: &BuildQueryJSONRequestBody{Context: kycContext, Type: "KYCAgeCredential"}})
+	require.NoError(t, err)
+	assert.IsType(t, BuildQuery500JSONResponse{}, resp)
+
+	server := New(cfg, nil, nil, WithQueryBuilder(loader))
+	build := func(body BuildQueryRequest) BuildQueryResponseObject {
+		resp, err := server.BuildQuery(ctx, BuildQueryRequestObject{Body: &body})
+		require.NoError(t, err)
+		return resp
+	}
+	value := func(v interface{}) *interface{} { return &v }
+
+	resp = build(BuildQueryRequest{
+		Context: kycContext, Type: "KYCAgeCredential", Field: common.ToPointer("birthday"), Operator: common.ToPointer("lt"),
+		Value: value(float64(20000101)), ChainID: common.ToPointer("80002"),
+	})
+	built := BuildQueryResponse(resp.(BuildQuery200JSONResponse))
+	assert.Equal(t, ScopeRequest{Id: 1, CircuitId: string(circuits.AtomicQuerySigV2CircuitID), Query: Query{
+		"context":           kycContext,
+		"type":              "KYCAgeCredential",
+		"allowedIssuers":    []string{"*"},
+		"credentialSubject": map[string]interface{}{"birthday": map[string]interface{}{"$lt": float64(20000101)}},
+	}}, built.Scope)
+	assert.Equal(t, &SignInRequest{ChainID: common.ToPointer("80002"), Scope: []ScopeRequest{built.Scope}}, built.SignIn)
+
+	// the operators of the V3 circuit only select it, and the selective disclosures have no operator
+	resp = build(BuildQueryRequest{
+		Context: kycContext, Type: "KYCAgeCredential", Field: common.ToPointer("birthday"), Operator: common.ToPointer("$between"),
+		Value: value([]interface{}{float64(19600101), float64(20000101)}),
+	})
+	built = BuildQueryResponse(resp.(BuildQuery200JSONResponse))
+	assert.Equal(t, string(circuits.AtomicQueryV3CircuitID), built.Scope.CircuitId)
+	assert.Nil(t, built.SignIn)
+	resp = build(BuildQueryRequest{Context: kycContext, Type: "KYCAgeCredential", Field: common.ToPointer("country")})
+	built = BuildQueryResponse(resp.(BuildQuery200JSONResponse))
+	assert.Equal(t, map[string]interface{}{"country": map[string]interface{}{}}, built.Scope.Query["credentialSubject"])
+
+	assert.Equal(t, BuildQuery400JSONResponse{N400JSONResponse{Message: "the field birthdy of scope 1 is not defined for the type KYCAgeCredential in its context"}},
+		build(BuildQueryRequest{Context: kycContext, Type: "KYCAgeCredential", Field: common.ToPointer("birthdy"), Operator: common.ToPointer("$lt"), Value: value(float64(1))}))
+	assert.Equal(t, BuildQuery400JSONResponse{N400JSONResponse{Message: "the operator $lt of field country in scope 1 cannot be used with the datatype http://www.w3.org/2001/XMLSchema#string"}},
+		build(BuildQueryRequest{Context: kycContext, Type: "KYCAgeCredential", Field: common.ToPointer("country"), Operator: common.ToPointer("$lt"), Value: value("ES")}))
+	assert.Equal(t, BuildQuery400JSONResponse{N400JSONResponse{Message: "the operator $in of field country in scope 1 expects an array of values"}},
+		build(BuildQueryRequest{Context: kycContext, Type: "KYCAgeCredential", Field: common.ToPointer("country"), Operator: common.ToPointer("$in"), Value: value("ES")}))
+	assert.Equal(t, BuildQuery400JSONResponse{N400JSONResponse{Message: "the context https://example.com/unavailable.jsonld of scope 1 cannot be loaded"}},
+		build(BuildQueryRequest{Context: "https://example.com/unavailable.jsonld", Type: "KYCAgeCredential"}))
+
+	// the types with an iden3_serialization are not merklized, their queries can only use the fields of the slots
+	resp = build(BuildQueryRequest{
+		Context: kycContext, Type: "KYCAgeCredential", Field: common.ToPointer("birthday"), Operator: common.ToPointer("$lt"),
+		Value: value(float64(20000101)), DetectMerklization: common.ToPointer(true), SkipClaimRevocationCheck: common.ToPointer(true),
+	})
+	built = BuildQueryResponse(resp.(BuildQuery200JSONResponse))
+	assert.Equal(t, common.ToPointer(true), built.Merklized)
+	assert.Nil(t, built.Warnings)
+	assert.Equal(t, true, built.Scope.Query["skipClaimRevocationCheck"])
+	resp = build(BuildQueryRequest{
+		Context: nonMerklizedContext, Type: "KYCAgeCredential", Field: common.ToPointer("birthday"), Operator: common.ToPointer("$lt"),
+		Value: value(float64(20000101)), DetectMerklization: common.ToPointer(true),
+	})
+	built = BuildQueryResponse(resp.(BuildQuery200JSONResponse))
+	assert.Equal(t, common.ToPointer(false), built.Merklized)
+	assert.Equal(t, &[]string{"birthday", "documentType"}, built.Slots)
+	assert.Nil(t, built.Warnings)
+	resp = build(BuildQueryRequest{
+		Context: nonMerklizedContext, Type: "KYCAgeCredential", Field: common.ToPointer("country"), DetectMerklization: common.ToPointer(true),
+	})
+	built = BuildQueryResponse(resp.(BuildQuery200JSONResponse))
+	assert.Equal(t, &[]string{"the type KYCAgeCredential is not merklized and its field country is not stored in a slot of its claims, " +
+		"so the wallets cannot prove the query of scope 1, query one of birthday, documentType"}, built.Warnings)
+}
+
+func TestSignInPublicURL(t *testing.T) {
+	ctx := context.Background()
+	publicCfg := cfg
+	publicCfg.PublicURL = "https://verifier.example.com"
+	publicCfg.AllowedPublicURLs = []string{"https://tunnel.example.com"}
+	server := New(publicCfg, nil, map[string]string{"80002": amoySenderDID})
+
+	signIn := func(publicURL *string) SignInResponseObject {
+		resp, err := server.SignIn(ctx, SignInRequestObject{Body: &SignInJSONRequestBody{
+			ChainID:   common.ToPointer("80002"),
+			PublicURL: publicURL,
+			Scope: []ScopeRequest{{
+				Id:        1,
+				CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+				Query: jsonToMap(t, `{
+					"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+					"allowedIssuers": ["*"],
+					"type": "KYCAgeCredential"
+				}`),
+			}},
+		}})
+		require.NoError(t, err)
+		return resp
+	}
+	assertLinks := func(resp SignInResponseObject, publicURL string) {
+		signedIn, ok := resp.(SignIn200JSONResponse)
+		require.True(t, ok, resp)
+		assert.True(t, strings.HasPrefix(signedIn.QrCode, iden3commRequestURIPrefix+publicURL+"/qr-store?id="), signedIn.QrCode)
+		authReq, ok := server.cache.Get(signedIn.SessionID.String())
+		require.True(t, ok)
+		assert.Equal(t, publicURL+"/callback?sessionID="+signedIn.SessionID.String(), authReq.(protocol.AuthorizationRequestMessage).Body.CallbackURL)
+	}
+
+	assertLinks(signIn(nil), "https://verifier.example.com")
+	assertLinks(signIn(common.ToPointer("https://tunnel.example.com/")), "https://tunnel.example.com")
+	assert.Equal(t, SignIn400JSONResponse{N400JSONResponse{Message: "publicURL https://other.example.com is not allowed"}},
+		signIn(common.ToPointer("https://other.example.com")))
+	assert.Equal(t, SignIn400JSONResponse{N400JSONResponse{Message: "publicURL tunnel.example.com must be an absolute http or https URL without query"}},
+		signIn(common.ToPointer("tunnel.example.com")))
+}
+
+func TestForwardedURL(t *testing.T) {
+	trusted, err := config.ParseTrustedProxies([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		expected   string
+	}{
+		{
+			name:       "x-forwarded headers of a trusted proxy",
+			remoteAddr: "10.1.2.3:4567",
+			headers:    map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "example.com", "X-Forwarded-Prefix": "/verifier/"},
+			expected:   "https://example.com/verifier",
+		},
+		{
+			name:       "forwarded header first",
+			remoteAddr: "10.1.2.3:4567",
+			headers:    map[string]string{"Forwarded": `for=192.0.2.60;proto=https;host="example.com", for=10.1.2.4`, "X-Forwarded-Host": "other.com"},
+			expected:   "https://example.com",
+		},
+		{
+			name:       "host of the request",
+			remoteAddr: "10.1.2.3:4567",
+			headers:    map[string]string{"X-Forwarded-Prefix": "verifier"},
+			expected:   "http://verifier.local/verifier",
+		},
+		{
+			name:       "untrusted client",
+			remoteAddr: "192.0.2.1:4567",
+			headers:    map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "example.com"},
+		},
+		{
+			name:       "invalid host",
+			remoteAddr: "10.1.2.3:4567",
+			headers:    map[string]string{"X-Forwarded-Host": "example.com/?a=b"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+			handler := ForwardedURL(trusted)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = forwardedURL(r.Context())
+			}))
+			r := httptest.NewRequest(http.MethodPost, "http://verifier.local/sign-in", nil)
+			r.RemoteAddr = tc.remoteAddr
+			for name, value := range tc.headers {
+				r.Header.Set(name, value)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+
+	server := New(cfg, nil, map[string]string{"80002": amoySenderDID})
+	ctx := context.WithValue(context.Background(), forwardedURLKey{}, "https://example.com/verifier")
+	assert.Equal(t, "https://example.com/verifier/qr-store?id=token", server.qrCodeLink(ctx, "token", nil))
+}
+
+func TestSignInFlow(t *testing.T) {
+	ctx := context.Background()
+	flowCfg := cfg
+	flowCfg.SessionTTL = config.CacheTTL(time.Hour)
+	flowCfg.Flows = []config.Flow{{
+		Name:    "kyc-age",
+		Version: "2",
+		ChainID: "80002",
+		Reason:  "age verification",
+		TTL:     10 * time.Minute,
+		Webhook: config.FlowWebhook{URL: "https://example.com/hooks/kyc"},
+		Scope: []config.FlowScope{{
+			ID:        1,
+			CircuitID: string(circuits.AtomicQuerySigV2CircuitID),
+			Query: map[string]any{
+				"context":        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+				"allowedIssuers": []any{"*"},
+				"type":           "KYCAgeCredential",
+				"credentialSubject": map[string]any{
+					"birthday": map[string]any{"$lt": 20000101},
+				},
+			},
+		}},
+	}}
+	server := New(flowCfg, nil, map[string]string{"80002": amoySenderDID})
+
+	resp, err := server.SignInFlow(ctx, SignInFlowRequestObject{Name: "unknown", Body: &SignInFlowRequest{}})
+	require.NoError(t, err)
+	assert.Equal(t, SignInFlow404JSONResponse{N404JSONResponse{Message: "verification flow unknown not found"}}, resp)
+
+	resp, err = server.SignInFlow(ctx, SignInFlowRequestObject{Name: "kyc-age", Body: &SignInFlowRequest{
+		ChainID:  common.ToPointer("1"),
+		Metadata: &SessionMetadata{"orderID": "1234"},
+	}})
+	require.NoError(t, err)
+	require.IsType(t, SignInFlow200JSONResponse{}, resp)
+	signedIn := resp.(SignInFlow200JSONResponse)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), signedIn.ExpiresAt, time.Minute)
+
+	item, ok := server.cache.Get(signedIn.SessionID.String())
+	require.True(t, ok)
+	authReq := item.(protocol.AuthorizationRequestMessage)
+	assert.Equal(t, "age verification", authReq.Body.Reason)
+	assert.Equal(t, amoySenderDID, authReq.From)
+	assert.Equal(t, "KYCAgeCredential", authReq.Body.Scope[0].Query["type"])
+	assert.Equal(t, map[string]string{"orderID": "1234"}, server.sessionMetadata(ctx, signedIn.SessionID))
+	assert.Same(t, server.flowWebhooks["kyc-age"], server.sessionWebhook(signedIn.SessionID))
+	assert.Nil(t, server.sessionWebhook(uuid.New()))
+}
+
+func TestWidget(t *testing.T) {
+	widgetCfg := cfg
+	widgetCfg.Widget = config.Widget{Title: "Verify your age", PrimaryColor: "#6c47ff", FrameAncestors: []string{"https://shop.example.com"}}
+	widgetCfg.Flows = []config.Flow{{
+		Name: "kyc-age",
+		Scope: []config.FlowScope{{
+			ID:        1,
+			CircuitID: string(circuits.AtomicQuerySigV2CircuitID),
+			Query: map[string]any{
+				"context":        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+				"allowedIssuers": []any{"*"},
+				"type":           "KYCAgeCredential",
+			},
+		}},
+	}}
+	widgetCfg.Widget.RateLimit = 3
+	widgetCfg.Widget.RateWindow = config.CacheTTL(time.Minute)
+	server := New(widgetCfg, nil, map[string]string{"80002": amoySenderDID})
+	router := chi.NewRouter()
+	router.Use(ClientAddr)
+	router.Get("/verify/{flowName}", server.Widget)
+	widget := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := widget("/verify/kyc-age?chainID=80002")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "frame-ancestors https://shop.example.com", rec.Header().Get("Content-Security-Policy"))
+	page := rec.Body.String()
+	assert.Contains(t, page, "<h2>Verify your age</h2>")
+	assert.Contains(t, page, `const targetOrigins = ["https://shop.example.com"];`)
+	sessions := 0
+	for key, item := range server.cache.Items() {
+		if _, ok := item.Object.(protocol.AuthorizationRequestMessage); ok {
+			sessions++
+			assert.Contains(t, page, `const statusURL = "http://localhost/status?sessionID=`+key+`";`)
+		}
+	}
+	assert.Equal(t, 1, sessions)
+
+	assert.Equal(t, http.StatusBadRequest, widget("/verify/kyc-age").Code, "no chain")
+	assert.Equal(t, http.StatusNotFound, widget("/verify/unknown").Code)
+	assert.Equal(t, http.StatusTooManyRequests, widget("/verify/kyc-age?chainID=80002").Code, "rate limited")
+
+	widgetCfg.Widget.FrameAncestors = nil
+	server = New(widgetCfg, nil, map[string]string{"80002": amoySenderDID})
+	router = chi.NewRouter()
+	router.Get("/verify/{flowName}", server.Widget)
+	rec = widget("/verify/kyc-age?chainID=80002")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "frame-ancestors 'self'", rec.Header().Get("Content-Security-Policy"))
+	assert.Contains(t, rec.Body.String(), `const targetOrigins = [];`)
+}
+
+func TestSchemaAllowlist(t *testing.T) {
+	ctx := context.Background()
+	allowlistCfg := cfg
+	allowlistCfg.AdminAPIKeys = []string{"admin"}
+	server := New(allowlistCfg, nil, map[string]string{"80002": amoySenderDID})
+	admin := common.ToPointer("admin")
+	kycContext := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld"
+
+	signIn := func(schemaContext string) SignInResponseObject {
+		resp, err := server.SignIn(ctx, SignInRequestObject{Body: &SignInJSONRequestBody{
+			ChainID: common.ToPointer("80002"),
+			Scope: []ScopeRequest{{
+				Id:        1,
+				CircuitId: string(circuits.AtomicQuerySigV2CircuitID),
+				Query:     Query{"context": schemaContext, "allowedIssuers": []interface{}{"*"}, "type": "KYCAgeCredential"},
+			}},
+		}})
+		require.NoError(t, err)
+		return resp
+	}
+	set := func(apiKey *string, body SchemaAllowlist) SetSchemaAllowlistResponseObject {
+		resp, err := server.SetSchemaAllowlist(ctx, SetSchemaAllowlistRequestObject{Params: SetSchemaAllowlistParams{XAPIKey: apiKey}, Body: &body})
+		require.NoError(t, err)
+		return resp
+	}
+
+	// every context is allowed while the allowlist is disabled
+	assert.IsType(t, SignIn200JSONResponse{}, signIn("https://schemas.example.com/kyc.json-ld"))
+
+	assert.IsType(t, SetSchemaAllowlist401JSONResponse{}, set(common.ToPointer("user"), SchemaAllowlist{Enabled: true, Entries: []string{kycContext}}))
+	assert.Equal(t, SetSchemaAllowlist400JSONResponse{N400JSONResponse{Message: `invalid schema allowlist: schema allowlist entry "ftp://schemas.example.com/" must be an http, https or ipfs url`}},
+		set(admin, SchemaAllowlist{Enabled: true, Entries: []string{"ftp://schemas.example.com/"}}))
+
+	allowlist := SchemaAllowlist{Enabled: true, Entries: []string{"https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/"}}
+	assert.Equal(t, SetSchemaAllowlist200JSONResponse(allowlist), set(admin, allowlist))
+	get, err := server.GetSchemaAllowlist(ctx, GetSchemaAllowlistRequestObject{Params: GetSchemaAllowlistParams{XAPIKey: admin}})
+	require.NoError(t, err)
+	assert.Equal(t, GetSchemaAllowlist200JSONResponse(allowlist), get)
+
+	assert.IsType(t, SignIn200JSONResponse{}, signIn(kycContext))
+	assert.Equal(t, SignIn400JSONResponse{N400JSONResponse{Message: "context https://schemas.example.com/kyc.json-ld of scope 1 is not in the schema allowlist"}},
+		signIn("https://schemas.example.com/kyc.json-ld"))
+}
+
+func TestRoles(t *testing.T) {
+	operatorDID := "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"
+	rolesCfg := cfg
+	rolesCfg.AdminAPIKeys = []string{"admin"}
+	rolesCfg.Roles = config.Roles{
+		OperatorAPIKeys: []string{"operator"},
+		ReadOnlyAPIKeys: []string{"auditor"},
+		OperatorDIDs:    []string{operatorDID},
+		OIDCClients:     []string{"console"},
+	}
+	server := New(rolesCfg, nil, map[string]string{"80002": amoySenderDID})
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key, err := signing.NewKey(privateKey)
+	require.NoError(t, err)
+	server.keys.Rotate(key)
+	idToken := func(audience, issuer string) string {
+		signer, err := key.Signer((&jose.SignerOptions{}).WithType("JWT"))
+		require.NoError(t, err)
+		token, err := jwt.Signed(signer).Claims(jwt.Claims{
+			Issuer:   issuer,
+			Subject:  operatorDID,
+			Audience: jwt.Audience{audience},
+			Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}).CompactSerialize()
+		require.NoError(t, err)
+		return token
+	}
+
+	// the handlers see the role of the bearer token
+	handler := server.Roles(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" && !server.hasRole(r.Context(), nil, roleOperator) {
+			w.WriteHeader(http.StatusTeapot)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	for _, tc := range []struct {
+		name   string
+		method string
+		path   string
+		apiKey string
+		token  string
+		code   int
+	}{
+		{name: "not a management endpoint", method: http.MethodPost, path: "/sign-in", code: http.StatusOK},
+		{name: "without key", method: http.MethodGet, path: "/admin/stats", code: http.StatusUnauthorized},
+		{name: "unknown key", method: http.MethodGet, path: "/admin/stats", apiKey: "other", code: http.StatusUnauthorized},
+		{name: "read-only reads", method: http.MethodGet, path: "/admin/stats", apiKey: "auditor", code: http.StatusOK},
+		{name: "read-only changes", method: http.MethodPost, path: "/admin/schemas/prewarm", apiKey: "auditor", code: http.StatusForbidden},
+		{name: "operator changes", method: http.MethodPost, path: "/admin/schemas/prewarm", apiKey: "operator", code: http.StatusOK},
+		{name: "operator changes the allowlist", method: http.MethodPut, path: "/admin/schema-allowlist", apiKey: "operator", code: http.StatusForbidden},
+		{name: "operator changes the issuer policy", method: http.MethodDelete, path: "/admin/issuer-policy/KYCAgeCredential", apiKey: "operator", code: http.StatusForbidden},
+		{name: "admin changes the allowlist", method: http.MethodPut, path: "/admin/schema-allowlist", apiKey: "admin", code: http.StatusOK},
+		{name: "operator reloads the circuits", method: http.MethodPost, path: "/admin/circuits/reload", apiKey: "operator", code: http.StatusForbidden},
+		{name: "read-only lists the verifier keys", method: http.MethodGet, path: "/admin/keys", apiKey: "auditor", code: http.StatusOK},
+		{name: "operator rotates the verifier keys", method: http.MethodPost, path: "/admin/keys/rotate", apiKey: "operator", code: http.StatusForbidden},
+		{name: "operator replays a dead letter", method: http.MethodPost, path: "/admin/webhooks/dead-letters/42/replay", apiKey: "operator", code: http.StatusForbidden},
+		{name: "operator changes a template", method: http.MethodPut, path: "/admin/query-templates/kyc-age", apiKey: "operator", code: http.StatusForbidden},
+		{name: "read-only exports the audit log", method: http.MethodGet, path: "/admin/audit/export", apiKey: "auditor", code: http.StatusForbidden},
+		{name: "read-only reads the users", method: http.MethodGet, path: "/users/did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK", apiKey: "auditor", code: http.StatusForbidden},
+		{name: "users without key", method: http.MethodGet, path: "/users", code: http.StatusUnauthorized},
+		{name: "admin reads the users", method: http.MethodGet, path: "/users", apiKey: "admin", code: http.StatusOK},
+		{name: "unclassified endpoint", method: http.MethodPost, path: "/admin/stats", apiKey: "operator", code: http.StatusForbidden},
+		{name: "bearer token", method: http.MethodPost, path: "/admin/schemas/prewarm", token: idToken("console", "http://localhost/oidc"), code: http.StatusOK},
+		{name: "bearer token changes the allowlist", method: http.MethodPut, path: "/admin/schema-allowlist", token: idToken("console", "http://localhost/oidc"), code: http.StatusForbidden},
+		{name: "bearer token of another client", method: http.MethodGet, path: "/admin/stats", token: idToken("shop", "http://localhost/oidc"), code: http.StatusUnauthorized},
+		{name: "bearer token of another issuer", method: http.MethodGet, path: "/admin/stats", token: idToken("console", "http://other/oidc"), code: http.StatusUnauthorized},
+		{name: "invalid bearer token", method: http.MethodGet, path: "/admin/stats", token: "token", code: http.StatusUnauthorized},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.apiKey != "" {
+				req.Header.Set("X-API-Key", tc.apiKey)
+			}
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tc.code, rec.Code)
+			if tc.code == http.StatusForbidden {
+				assert.Contains(t, rec.Body.String(), "role is required")
+			}
+		})
+	}
+
+	// the handlers check the roles of the api keys
+	ctx := context.Background()
+	list, err := server.ListSchemas(ctx, ListSchemasRequestObject{Params: ListSchemasParams{XAPIKey: common.ToPointer("auditor")}})
+	require.NoError(t, err)
+	assert.IsType(t, ListSchemas200JSONResponse{}, list)
+	reload, err := server.ReloadCircuitKeys(ctx, ReloadCircuitKeysRequestObject{Params: ReloadCircuitKeysParams{XAPIKey: common.ToPointer("operator")}})
+	require.NoError(t, err)
+	assert.IsType(t, ReloadCircuitKeys401JSONResponse{}, reload)
+	deleted, err := server.DeleteIssuerPolicy(ctx, DeleteIssuerPolicyRequestObject{
+		CredentialType: "KYCAgeCredential",
+		Params:         DeleteIssuerPolicyParams{XAPIKey: common.ToPointer("operator")},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, DeleteIssuerPolicy401JSONResponse{}, deleted)
+}
+
+// TestManagementRoutes fails for the management endpoints of the router that are not classified by role, and for the
+// classified ones that are not routed anymore
+func TestManagementRoutes(t *testing.T) {
+	server := New(cfg, nil, map[string]string{"80002": amoySenderDID})
+	router := chi.NewRouter()
+	HandlerFromMux(NewStrictHandler(server, nil), router)
+	server.RegisterRoutes(router)
+
+	classified := make(map[string]bool, len(managementRoutes))
+	for _, route := range managementRoutes {
+		classified[route.method+" "+route.pattern] = true
+	}
+	routed := make(map[string]bool)
+	require.NoError(t, chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if !isManagementPath(route) {
+			return nil
+		}
+		routed[method+" "+route] = true
+		assert.True(t, classified[method+" "+route], "the role of %s %s is not classified", method, route)
+		return nil
+	}))
+	for route := range classified {
+		assert.True(t, routed[route], "%s is classified but not routed", route)
+	}
+}
+
+func TestManagementAuth(t *testing.T) {
+	authCfg := config.ManagementAuth{HMACSecrets: []string{"old", "new"}, ReplayWindow: config.CacheTTL(time.Minute)}
+	handler := ManagementAuth(authCfg, 64, cache.New(time.Minute, time.Minute))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		_, _ = w.Write(body)
+	}))
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	body := `{"enabled":true,"entries":["https://schemas.example.com/"]}`
+	send := func(path, timestamp, signature, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(body))
+		if timestamp != "" {
+			req.Header.Set(ManagementTimestampHeader, timestamp)
+		}
+		if signature != "" {
+			req.Header.Set(ManagementSignatureHeader, signature)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := send("/admin/schema-allowlist", now, SignManagementRequest("new", now, http.MethodPut, "/admin/schema-allowlist", []byte(body)), body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, body, rec.Body.String())
+	rec = send("/admin/schema-allowlist", now, SignManagementRequest("new", now, http.MethodPut, "/admin/schema-allowlist", []byte(body)), body)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"message":"the request signature was already used"}`, rec.Body.String())
+
+	old := strconv.FormatInt(time.Now().Add(-2*time.Minute).Unix(), 10)
+	for _, tc := range []struct {
+		name      string
+		path      string
+		timestamp string
+		signature string
+		body      string
+		code      int
+	}{
+		{name: "other secret", path: "/admin/schemas", timestamp: now, signature: SignManagementRequest("old", now, http.MethodPut, "/admin/schemas", []byte(body)), body: body, code: http.StatusOK},
+		{name: "not a management endpoint", path: "/sign-in", body: body, code: http.StatusOK},
+		{name: "unsigned", path: "/admin/schemas", body: body, code: http.StatusUnauthorized},
+		{name: "unknown secret", path: "/admin/schemas", timestamp: now, signature: SignManagementRequest("other", now, http.MethodPut, "/admin/schemas", []byte(body)), body: body, code: http.StatusUnauthorized},
+		{name: "other path", path: "/admin/stats", timestamp: now, signature: SignManagementRequest("new", now, http.MethodPut, "/admin/schemas", []byte(body)), body: body, code: http.StatusUnauthorized},
+		{name: "other body", path: "/admin/schemas", timestamp: now, signature: SignManagementRequest("new", now, http.MethodPut, "/admin/schemas", []byte(body)), body: `{}`, code: http.StatusUnauthorized},
+		{name: "expired", path: "/admin/schemas", timestamp: old, signature: SignManagementRequest("new", old, http.MethodPut, "/admin/schemas", []byte(body)), body: body, code: http.StatusUnauthorized},
+		{name: "invalid timestamp", path: "/admin/schemas", timestamp: "now", signature: SignManagementRequest("new", "now", http.MethodPut, "/admin/schemas", []byte(body)), body: body, code: http.StatusUnauthorized},
+		{name: "too large", path: "/admin/schemas", timestamp: now, signature: "sha256=00", body: body + strings.Repeat(" ", 64), code: http.StatusRequestEntityTooLarge},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.code, send(tc.path, tc.timestamp, tc.signature, tc.body).Code)
+		})
+	}
+
+	// the management requests must present a client certificate when client certificate authorities are configured
+	certHandler := ManagementAuth(config.ManagementAuth{ClientCAPath: "ca.pem"}, 0, cache.New(time.Minute, time.Minute))(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }))
+	rec = httptest.NewRecorder()
+	certHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/stats", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"message":"the management requests require a trusted client certificate"}`, rec.Body.String())
+	rec = httptest.NewRecorder()
+	certHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"context"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+// GetShadowVerificationReport - get the comparison between the primary and the shadow verifier
+func (s *Server) GetShadowVerificationReport(ctx context.Context, request GetShadowVerificationReportRequestObject) (GetShadowVerificationReportResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return GetShadowVerificationReport401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	if s.shadowVerifier == nil {
+		return GetShadowVerificationReport404JSONResponse{N404JSONResponse{Message: "shadow verification is not enabled"}}, nil
+	}
+
+	report := s.shadowVerifier.Report()
+	disagreements := make([]ShadowVerificationDisagreement, 0, len(report.RecentDisagreements))
+	for _, d := range report.RecentDisagreements {
+		disagreement := ShadowVerificationDisagreement{
+			SessionID: d.SessionID,
+			Timestamp: d.Timestamp,
+		}
+		if d.PrimaryErr != "" {
+			disagreement.PrimaryError = common.ToPointer(d.PrimaryErr)
+		}
+		if d.ShadowErr != "" {
+			disagreement.ShadowError = common.ToPointer(d.ShadowErr)
+		}
+		disagreements = append(disagreements, disagreement)
+	}
+
+	return GetShadowVerificationReport200JSONResponse{
+		Total:               report.Total,
+		Agreements:          report.Agreements,
+		Disagreements:       report.Disagreements,
+		Dropped:             report.Dropped,
+		RecentDisagreements: disagreements,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/sli"
+)
+
+// GetSLI - get the rolling service level indicators of the verifications
+func (s *Server) GetSLI(ctx context.Context, request GetSLIRequestObject) (GetSLIResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return GetSLI401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+
+	resp := make(GetSLI200JSONResponse, 0, len(sli.Windows))
+	for _, window := range sli.Windows {
+		indicators := s.sli.Indicators(window)
+		item := SLIWindow{
+			Window:        window.Name,
+			Verifications: indicators.Verifications,
+			Failures:      indicators.Failures,
+			P95LatencyMs:  milliseconds(indicators.P95Latency),
+			RpcCalls:      indicators.RPCCalls,
+			RpcErrors:     indicators.RPCErrors,
+		}
+		if rate, ok := indicators.SuccessRate(); ok {
+			item.SuccessRate = &rate
+		}
+		if ratio, ok := indicators.RPCErrorRatio(); ok {
+			item.RpcErrorRatio = &ratio
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+// prometheusWriter writes its metrics in the Prometheus text format
+type prometheusWriter interface {
+	WritePrometheus(w io.Writer) error
+}
+
+// Metrics serves the verification metrics and indicators in the Prometheus text format
+func (s *Server) Metrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := s.sli.WritePrometheus(w); err != nil {
+		s.log(r.Context()).WithFields(log.Fields{"err": err}).Error("failed to write metrics")
+		return
+	}
+	if err := s.cache.WritePrometheus(w); err != nil {
+		s.log(r.Context()).WithFields(log.Fields{"err": err}).Error("failed to write metrics")
+		return
+	}
+	if s.lanes != nil {
+		if err := s.lanes.WritePrometheus(w); err != nil {
+			s.log(r.Context()).WithFields(log.Fields{"err": err}).Error("failed to write metrics")
+			return
+		}
+	}
+	// the document loader set with WithDocumentPinner also writes the metrics of its memory cache
+	if documents, ok := s.documents.(prometheusWriter); ok {
+		if err := documents.WritePrometheus(w); err != nil {
+			s.log(r.Context()).WithFields(log.Fields{"err": err}).Error("failed to write metrics")
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/iden3/iden3comm/v2/protocol"
+	log "github.com/sirupsen/logrus"
+
+	verrors "github.com/0xPolygonID/verifier-backend/internal/errors"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/stats"
+)
+
+const (
+	// defaultStatsDays is the number of days of the statistics when the range has no start
+	defaultStatsDays = 7
+	// topCredentialTypes is the number of credential types of the statistics
+	topCredentialTypes = 10
+)
+
+// WithStats sets the tracker of the daily verification statistics
+func WithStats(t *stats.Tracker) Option {
+	return func(s *Server) {
+		s.stats = t
+	}
+}
+
+// GetVerificationStats - get the verification statistics of a range of days
+func (s *Server) GetVerificationStats(ctx context.Context, request GetVerificationStatsRequestObject) (GetVerificationStatsResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return GetVerificationStats401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+
+	to := time.Now().UTC()
+	if request.Params.To != nil {
+		date, err := time.Parse(stats.DateLayout, *request.Params.To)
+		if err != nil {
+			return GetVerificationStats400JSONResponse{N400JSONResponse{Message: "invalid to date, expected YYYY-MM-DD"}}, nil
+		}
+		to = date
+	}
+	from := to.AddDate(0, 0, 1-defaultStatsDays)
+	if request.Params.From != nil {
+		date, err := time.Parse(stats.DateLayout, *request.Params.From)
+		if err != nil {
+			return GetVerificationStats400JSONResponse{N400JSONResponse{Message: "invalid from date, expected YYYY-MM-DD"}}, nil
+		}
+		from = date
+	}
+	if to.Before(from) || to.Sub(from) >= stats.MaxDays*24*time.Hour {
+		return GetVerificationStats400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("the range must end after it starts and span at most %d days", stats.MaxDays)}}, nil
+	}
+
+	days, err := s.stats.Days(ctx, from, to)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to read verification stats")
+		return GetVerificationStats500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return GetVerificationStats200JSONResponse(verificationStats(from, to, days)), nil
+}
+
+// observeStats records a finished verification of the session in the daily statistics, with the error code
+// of the failure stored in the session
+func (s *Server) observeStats(sessionID string, request protocol.AuthorizationRequestMessage, verified bool, latency time.Duration) {
+	var code string
+	if !verified {
+		code = string(verrors.CodeVerificationFailed)
+		if item, ok := s.cache.Get(sessionID); ok {
+			if err, ok := item.(error); ok {
+				code = string(verrors.Classify(err))
+			}
+		}
+	}
+	types := make([]string, 0, len(request.Body.Scope))
+	for _, scope := range request.Body.Scope {
+		if credentialType, _ := scope.Query["type"].(string); credentialType != "" {
+			types = append(types, credentialType)
+		}
+	}
+	s.stats.ObserveVerification(verified, latency, types, code)
+}
+
+func verificationStats(from, to time.Time, days []stats.Day) VerificationStats {
+	var total stats.Day
+	resp := VerificationStats{
+		From:            from.Format(stats.DateLayout),
+		To:              to.Format(stats.DateLayout),
+		Days:            make([]DailyStats, 0, len(days)),
+		CredentialTypes: []CredentialTypeStats{},
+		ErrorCodes:      []ErrorCodeStats{},
+	}
+	for _, day := range days {
+		total.Add(day)
+		daily := DailyStats{Date: day.Date, Verifications: day.Verifications, Failures: day.Failures}
+		daily.SuccessRate, daily.AverageLatencyMs = rates(day)
+		resp.Days = append(resp.Days, daily)
+	}
+	resp.Verifications, resp.Failures = total.Verifications, total.Failures
+	resp.SuccessRate, resp.AverageLatencyMs = rates(total)
+
+	for typ, n := range total.CredentialTypes {
+		resp.CredentialTypes = append(resp.CredentialTypes, CredentialTypeStats{Type: typ, Verifications: n})
+	}
+	sort.Slice(resp.CredentialTypes, func(i, j int) bool {
+		a, b := resp.CredentialTypes[i], resp.CredentialTypes[j]
+		return a.Verifications > b.Verifications || a.Verifications == b.Verifications && a.Type < b.Type
+	})
+	if len(resp.CredentialTypes) > topCredentialTypes {
+		resp.CredentialTypes = resp.CredentialTypes[:topCredentialTypes]
+	}
+
+	for code, n := range total.ErrorCodes {
+		resp.ErrorCodes = append(resp.ErrorCodes, ErrorCodeStats{Code: code, Failures: n})
+	}
+	sort.Slice(resp.ErrorCodes, func(i, j int) bool {
+		a, b := resp.ErrorCodes[i], resp.ErrorCodes[j]
+		return a.Failures > b.Failures || a.Failures == b.Failures && a.Code < b.Code
+	})
+	return resp
+}
+
+// rates returns the success rate and the average latency in milliseconds of a day, nil without verifications
+func rates(day stats.Day) (*float64, *float64) {
+	if day.Verifications == 0 {
+		return nil, nil
+	}
+	successRate := float64(day.Verifications-day.Failures) / float64(day.Verifications)
+	averageLatency := day.LatencySeconds * 1000 / float64(day.Verifications)
+	return &successRate, &averageLatency
+}
@@ -0,0 +1,288 @@
+package api
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/iden3comm/v2/protocol"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+)
+
+const maxSessionTags = 10
+
+var tagPattern = regexp.MustCompile(`^[a-zA-Z0-9_.:-]{1,64}$`)
+
+type sessionOutcome int
+
+const (
+	outcomeNone sessionOutcome = iota
+	outcomeVerified
+	outcomeFailed
+	outcomeExpired
+)
+
+type taggedSession struct {
+	id        uuid.UUID
+	qrToken   string
+	tags      []string
+	createdAt time.Time
+	scanned   bool
+	outcome   sessionOutcome
+}
+
+// sessionTags indexes the tagged sessions by tag until they expire, and counts the funnel of every tag
+// since the server started.
+type sessionTags struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	order    []*taggedSession
+	sessions map[uuid.UUID]*taggedSession
+	qrCodes  map[string]*taggedSession
+	byTag    map[string][]*taggedSession
+	stats    map[string]*TagStats
+}
+
+func newSessionTags(ttl time.Duration) *sessionTags {
+	return &sessionTags{
+		ttl:      ttl,
+		sessions: make(map[uuid.UUID]*taggedSession),
+		qrCodes:  make(map[string]*taggedSession),
+		byTag:    make(map[string][]*taggedSession),
+		stats:    make(map[string]*TagStats),
+	}
+}
+
+func validateTags(tags *[]string) error {
+	if tags == nil {
+		return nil
+	}
+	if len(*tags) > maxSessionTags {
+		return i18n.New(i18n.CodeTooManyTags, maxSessionTags)
+	}
+	for _, tag := range *tags {
+		if !tagPattern.MatchString(tag) {
+			return i18n.New(i18n.CodeInvalidTag, tag)
+		}
+	}
+	return nil
+}
+
+// add indexes a new session with its tags and the token of its QR code
+func (t *sessionTags) add(sessionID uuid.UUID, qrToken string, tags *[]string) {
+	if tags == nil || len(*tags) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now().UTC()
+	t.prune(now)
+
+	session := &taggedSession{id: sessionID, qrToken: qrToken, tags: unique(*tags), createdAt: now}
+	t.order = append(t.order, session)
+	t.sessions[sessionID] = session
+	t.qrCodes[qrToken] = session
+	for _, tag := range session.tags {
+		t.byTag[tag] = append(t.byTag[tag], session)
+		t.tagStats(tag).Created++
+	}
+}
+
+// scan records that the QR code of a session was fetched by a wallet
+func (t *sessionTags) scan(qrToken string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	session, ok := t.qrCodes[qrToken]
+	if !ok || session.scanned {
+		return
+	}
+	session.scanned = true
+	for _, tag := range session.tags {
+		t.tagStats(tag).Scanned++
+	}
+}
+
+// finish records the outcome of the callback of a session. A failed session verified on a later callback
+// is counted as verified.
+func (t *sessionTags) finish(sessionID uuid.UUID, verified bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	session, ok := t.sessions[sessionID]
+	if !ok || session.outcome == outcomeVerified {
+		return
+	}
+	for _, tag := range session.tags {
+		stats := t.tagStats(tag)
+		switch {
+		case verified && session.outcome == outcomeFailed:
+			stats.Failed--
+			stats.Verified++
+		case verified:
+			stats.Verified++
+		case session.outcome == outcomeNone:
+			stats.Failed++
+		}
+	}
+	if verified {
+		session.outcome = outcomeVerified
+	} else {
+		session.outcome = outcomeFailed
+	}
+}
+
+// expire records that a session expired without a callback, abandoned when its QR code was scanned
+func (t *sessionTags) expire(sessionID uuid.UUID, abandoned bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	session, ok := t.sessions[sessionID]
+	if !ok || session.outcome != outcomeNone {
+		return
+	}
+	session.outcome = outcomeExpired
+	for _, tag := range session.tags {
+		if abandoned {
+			t.tagStats(tag).Abandoned++
+		} else {
+			t.tagStats(tag).Expired++
+		}
+	}
+}
+
+// search returns the sessions with the tag that have not expired, most recent first
+func (t *sessionTags) search(tag string) []taggedSession {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.prune(time.Now().UTC())
+
+	sessions := t.byTag[tag]
+	result := make([]taggedSession, 0, len(sessions))
+	for i := len(sessions) - 1; i >= 0; i-- {
+		result = append(result, *sessions[i])
+	}
+	return result
+}
+
+// snapshot returns the stats of every tag sorted by tag
+func (t *sessionTags) snapshot() []TagStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make([]TagStats, 0, len(t.stats))
+	for _, stats := range t.stats {
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Tag < result[j].Tag })
+	return result
+}
+
+func (t *sessionTags) tagStats(tag string) *TagStats {
+	stats, ok := t.stats[tag]
+	if !ok {
+		stats = &TagStats{Tag: tag}
+		t.stats[tag] = stats
+	}
+	return stats
+}
+
+// prune removes the expired sessions from the index. Sessions are kept in creation order,
+// so the expired ones are at the beginning of every list. As in the cache, sessions do not expire without ttl.
+func (t *sessionTags) prune(now time.Time) {
+	if t.ttl <= 0 {
+		return
+	}
+	var expired int
+	for expired < len(t.order) && now.Sub(t.order[expired].createdAt) > t.ttl {
+		session := t.order[expired]
+		delete(t.sessions, session.id)
+		delete(t.qrCodes, session.qrToken)
+		for _, tag := range session.tags {
+			t.byTag[tag] = t.byTag[tag][1:]
+			if len(t.byTag[tag]) == 0 {
+				delete(t.byTag, tag)
+			}
+		}
+		expired++
+	}
+	t.order = t.order[expired:]
+}
+
+func unique(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if !seen[tag] {
+			seen[tag] = true
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// SearchSessions - search the sessions by tag
+func (s *Server) SearchSessions(ctx context.Context, request SearchSessionsRequestObject) (SearchSessionsResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return SearchSessions401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+
+	resp := SearchSessions200JSONResponse{}
+	for _, session := range s.tags.search(request.Params.Tag) {
+		item, ok := s.cache.Get(session.id.String())
+		if !ok {
+			continue
+		}
+		status := sessionStatus(item)
+		if request.Params.Status != nil && string(*request.Params.Status) != status {
+			continue
+		}
+		resp = append(resp, TaggedSession{
+			SessionID: session.id,
+			Status:    status,
+			Tags:      session.tags,
+			CreatedAt: session.createdAt,
+		})
+	}
+	return resp, nil
+}
+
+// GetTagStats - get the funnel stats of the tags
+func (s *Server) GetTagStats(ctx context.Context, request GetTagStatsRequestObject) (GetTagStatsResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return GetTagStats401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+
+	resp := GetTagStats200JSONResponse{}
+	for _, stats := range s.tags.snapshot() {
+		if request.Params.Tag != nil && *request.Params.Tag != stats.Tag {
+			continue
+		}
+		resp = append(resp, stats)
+	}
+	return resp, nil
+}
+
+// sessionStatus returns the status of a session from its item in the cache
+func sessionStatus(item any) string {
+	switch value := item.(type) {
+	case protocol.AuthorizationRequestMessage, protocol.ContractInvokeRequestMessage:
+		return statusPending
+	case models.VerificationResponse:
+		return statusSuccess
+	case consumedResult:
+		return statusConsumed
+	case expiredSession:
+		return value.status()
+	case invalidatedVerification:
+		return value.status()
+	default:
+		return statusError
+	}
+}
@@ -0,0 +1,311 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iden3/go-circuits/v2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/messages"
+)
+
+var templateNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// QueryTemplateStore is a storage of named query templates. Templates never expire, so they are kept
+// apart from the session cache, and listing them does not scan the sessions.
+// A store opened with OpenQueryTemplateFile persists the templates in a json file rewritten on every change, so they
+// survive restarts, and reloads the file when it is changed by another replica sharing it.
+type QueryTemplateStore struct {
+	mu        sync.RWMutex
+	templates map[string]QueryTemplate
+	path      string
+	modTime   time.Time
+}
+
+// NewQueryTemplateStore creates a new in-memory QueryTemplateStore.
+func NewQueryTemplateStore() *QueryTemplateStore {
+	return &QueryTemplateStore{templates: make(map[string]QueryTemplate)}
+}
+
+// OpenQueryTemplateFile opens the QueryTemplateStore persisted at path. The file is created on the first change
+// when it does not exist.
+func OpenQueryTemplateFile(path string) (*QueryTemplateStore, error) {
+	s := &QueryTemplateStore{templates: make(map[string]QueryTemplate), path: path}
+	if err := s.reloadLocked(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Save creates or replaces a query template.
+func (s *QueryTemplateStore) Save(template QueryTemplate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return err
+	}
+	previous, existed := s.templates[template.Name]
+	s.templates[template.Name] = template
+	if err := s.persistLocked(); err != nil {
+		if existed {
+			s.templates[template.Name] = previous
+		} else {
+			delete(s.templates, template.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// Get returns a query template by name.
+func (s *QueryTemplateStore) Get(name string) (*QueryTemplate, bool) {
+	s.refresh()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	template, ok := s.templates[name]
+	if !ok {
+		return nil, false
+	}
+	return &template, true
+}
+
+// Delete removes a query template by name.
+func (s *QueryTemplateStore) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		return err
+	}
+	template, ok := s.templates[name]
+	if !ok {
+		return nil
+	}
+	delete(s.templates, name)
+	if err := s.persistLocked(); err != nil {
+		s.templates[name] = template
+		return err
+	}
+	return nil
+}
+
+// List returns all the query templates sorted by name.
+func (s *QueryTemplateStore) List() []QueryTemplate {
+	s.refresh()
+	s.mu.RLock()
+	templates := make([]QueryTemplate, 0, len(s.templates))
+	for _, template := range s.templates {
+		templates = append(templates, template)
+	}
+	s.mu.RUnlock()
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates
+}
+
+// refresh reloads the file of the store if it changed since it was read. The templates in memory are kept when the
+// file cannot be read.
+func (s *QueryTemplateStore) refresh() {
+	if s.path == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.reloadLocked(); err != nil {
+		log.WithFields(log.Fields{"path": s.path, "err": err}).Error("failed to reload query templates")
+	}
+}
+
+// reloadLocked reads the file of the store if it changed since it was read
+func (s *QueryTemplateStore) reloadLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	info, err := os.Stat(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.ModTime().Equal(s.modTime) {
+		return nil
+	}
+
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+	var templates []QueryTemplate
+	if err := json.Unmarshal(b, &templates); err != nil {
+		return fmt.Errorf("invalid query templates file %s: %w", s.path, err)
+	}
+	s.templates = make(map[string]QueryTemplate, len(templates))
+	for _, template := range templates {
+		s.templates[template.Name] = template
+	}
+	s.modTime = info.ModTime()
+	return nil
+}
+
+// persistLocked rewrites the file of the store through a temporary file, so a crash leaves one of them whole
+func (s *QueryTemplateStore) persistLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	templates := make([]QueryTemplate, 0, len(s.templates))
+	for _, template := range s.templates {
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	b, err := json.MarshalIndent(templates, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("failed to persist query templates: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to persist query templates: %w", err)
+	}
+	if info, err := os.Stat(s.path); err == nil {
+		s.modTime = info.ModTime()
+	}
+	return nil
+}
+
+// ListQueryTemplates - list the query templates
+func (s *Server) ListQueryTemplates(ctx context.Context, request ListQueryTemplatesRequestObject) (ListQueryTemplatesResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return ListQueryTemplates401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	return ListQueryTemplates200JSONResponse(s.queryTemplates.List()), nil
+}
+
+// GetQueryTemplate - get a query template
+func (s *Server) GetQueryTemplate(ctx context.Context, request GetQueryTemplateRequestObject) (GetQueryTemplateResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return GetQueryTemplate401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+
+	template, ok := s.queryTemplates.Get(request.TemplateName)
+	if !ok {
+		return GetQueryTemplate404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeTemplateNotFound, request.TemplateName)}}, nil
+	}
+	return GetQueryTemplate200JSONResponse(*template), nil
+}
+
+// SetQueryTemplate - create or replace a query template
+func (s *Server) SetQueryTemplate(ctx context.Context, request SetQueryTemplateRequestObject) (SetQueryTemplateResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleAdmin) {
+		return SetQueryTemplate401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+
+	if !templateNameRegex.MatchString(request.TemplateName) {
+		return SetQueryTemplate400JSONResponse{N400JSONResponse{Message: i18n.Message(ctx, i18n.CodeTemplateNameInvalid)}}, nil
+	}
+
+	template := QueryTemplate{
+		Name:      request.TemplateName,
+		CircuitId: request.Body.CircuitId,
+		Query:     request.Body.Query,
+		Params:    request.Body.Params,
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := validateQueryTemplate(template); err != nil {
+		return SetQueryTemplate400JSONResponse{N400JSONResponse{Message: i18n.Localize(ctx, err)}}, nil
+	}
+
+	if err := s.queryTemplates.Save(template); err != nil {
+		s.log(ctx).WithFields(log.Fields{"template": template.Name, "err": err}).Error("failed to save query template")
+		return SetQueryTemplate500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	s.log(ctx).WithFields(log.Fields{"template": template.Name}).Info("query template saved")
+
+	return SetQueryTemplate200JSONResponse(template), nil
+}
+
+// DeleteQueryTemplate - delete a query template
+func (s *Server) DeleteQueryTemplate(ctx context.Context, request DeleteQueryTemplateRequestObject) (DeleteQueryTemplateResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleAdmin) {
+		return DeleteQueryTemplate401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+
+	template, ok := s.queryTemplates.Get(request.TemplateName)
+	if !ok {
+		return DeleteQueryTemplate404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeTemplateNotFound, request.TemplateName)}}, nil
+	}
+	if err := s.queryTemplates.Delete(request.TemplateName); err != nil {
+		s.log(ctx).WithFields(log.Fields{"template": template.Name, "err": err}).Error("failed to delete query template")
+		return DeleteQueryTemplate500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	s.log(ctx).WithFields(log.Fields{"template": template.Name}).Info("query template deleted")
+
+	return DeleteQueryTemplate200JSONResponse(*template), nil
+}
+
+// applyQueryTemplates replaces the template references in the scopes with the template circuitId, query and params.
+// Inline query fields override the fields of the template query.
+func (s *Server) applyQueryTemplates(scopes []ScopeRequest) error {
+	for i, scope := range scopes {
+		if scope.Template == nil {
+			continue
+		}
+
+		template, ok := s.queryTemplates.Get(*scope.Template)
+		if !ok {
+			return i18n.New(i18n.CodeTemplateNotFound, *scope.Template)
+		}
+		if scope.CircuitId != "" && scope.CircuitId != template.CircuitId {
+			return i18n.New(i18n.CodeTemplateCircuitMismatch, scope.CircuitId, template.Name)
+		}
+
+		query := make(Query, len(template.Query)+len(scope.Query))
+		for k, v := range template.Query {
+			query[k] = v
+		}
+		for k, v := range scope.Query {
+			query[k] = v
+		}
+
+		scopes[i].CircuitId = template.CircuitId
+		scopes[i].Query = query
+		if scope.Params == nil {
+			scopes[i].Params = template.Params
+		}
+	}
+	return nil
+}
+
+func validateQueryTemplate(template QueryTemplate) error {
+	var offChain bool
+	switch circuits.CircuitID(template.CircuitId) {
+	case circuits.AtomicQuerySigV2CircuitID, circuits.AtomicQueryMTPV2CircuitID, circuits.AtomicQueryV3CircuitID:
+		offChain = true
+	case circuits.AtomicQuerySigV2OnChainCircuitID, circuits.AtomicQueryMTPV2OnChainCircuitID, circuits.AtomicQueryV3OnChainCircuitID:
+		offChain = false
+	default:
+		return i18n.New(i18n.CodeInvalidCircuitID)
+	}
+
+	scope := ScopeRequest{Id: 1, CircuitId: template.CircuitId, Query: template.Query}
+	if err := validateRequestQuery(offChain, []ScopeRequest{scope}); err != nil {
+		return err
+	}
+
+	if template.Params != nil {
+		if _, err := messages.Params(template.CircuitId, *template.Params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
+)
+
+const sessionTenantKeyPrefix = "session-tenant-"
+
+// GetJWKS - get the public keys of the verifier
+func (s *Server) GetJWKS(_ context.Context, _ GetJWKSRequestObject) (GetJWKSResponseObject, error) {
+	jwks, err := s.keys.JWKS("")
+	if err != nil {
+		return GetJWKS404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+	}
+	return GetJWKS200JSONResponse(jwks), nil
+}
+
+// GetTenantJWKS - get the public keys used on behalf of a tenant
+func (s *Server) GetTenantJWKS(_ context.Context, request GetTenantJWKSRequestObject) (GetTenantJWKSResponseObject, error) {
+	if !s.isTenant(request.TenantID) {
+		return GetTenantJWKS404JSONResponse{N404JSONResponse{Message: "tenant not found"}}, nil
+	}
+
+	jwks, err := s.keys.JWKS(request.TenantID)
+	if err != nil {
+		return GetTenantJWKS404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+	}
+	return GetTenantJWKS200JSONResponse(jwks), nil
+}
+
+// tenantID returns the tenant that owns the api key, or an empty string
+func (s *Server) tenantID(apiKey *string) string {
+	if apiKey == nil || *apiKey == "" {
+		return ""
+	}
+	for _, tenant := range s.cfg.Tenants {
+		for _, key := range tenant.APIKeys {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(*apiKey)) == 1 {
+				return tenant.ID
+			}
+		}
+	}
+	return ""
+}
+
+func (s *Server) isTenant(tenantID string) bool {
+	for _, tenant := range s.cfg.Tenants {
+		if tenant.ID == tenantID {
+			return true
+		}
+	}
+	return false
+}
+
+// setSessionTenant stores the tenant that created the session
+func (s *Server) setSessionTenant(sessionID uuid.UUID, tenantID string) {
+	if tenantID == "" {
+		return
+	}
+	s.cache.Set(sessionTenantKeyPrefix+sessionID.String(), tenantID, cache.DefaultExpiration)
+}
+
+// getSessionTenant returns the tenant that created the session, or an empty string
+func (s *Server) getSessionTenant(sessionID uuid.UUID) string {
+	tenantID, ok := s.cache.Get(sessionTenantKeyPrefix + sessionID.String())
+	if !ok {
+		return ""
+	}
+	id, _ := tenantID.(string)
+	return id
+}
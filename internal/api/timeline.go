@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+const (
+	sessionEventsKeyPrefix = "session-events-"
+	sessionQRCodeKeyPrefix = "session-qr-code-"
+	maxSessionEvents       = 100
+)
+
+// Types of the events of the timelines of the sessions
+const (
+	sessionEventCreated   = "created"
+	sessionEventQRFetched = "qr-fetched"
+	sessionEventCallback  = "callback-received"
+	sessionEventVerifying = "verification-started"
+	sessionEventVerified  = "verified"
+	sessionEventFailed    = "failed"
+)
+
+// GetSessionEvents - get the timeline of the events of a session
+func (s *Server) GetSessionEvents(ctx context.Context, request GetSessionEventsRequestObject) (GetSessionEventsResponseObject, error) {
+	id := request.SessionID
+	if !s.isSessionOwner(id, request.Params.XAPIKey) {
+		return GetSessionEvents403JSONResponse{N403JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionForbidden, id)}}, nil
+	}
+	events := s.sessionEvents(id)
+	if len(events) == 0 {
+		return GetSessionEvents404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionNotFound)}}, nil
+	}
+	return GetSessionEvents200JSONResponse(events), nil
+}
+
+// recordSessionCreated starts the timeline of a new session, whose QR code is fetched with qrToken
+func (s *Server) recordSessionCreated(sessionID uuid.UUID, qrToken string) {
+	s.cache.Set(sessionQRCodeKeyPrefix+qrToken, sessionID, cache.DefaultExpiration)
+	s.recordSessionEvent(sessionID, sessionEventCreated, "")
+}
+
+// recordQRCodeFetched adds the fetches of the QR codes to the timeline of their session, the wallets fetch them when
+// they scan them
+func (s *Server) recordQRCodeFetched(qrToken string) {
+	if item, ok := s.cache.Get(sessionQRCodeKeyPrefix + qrToken); ok {
+		s.recordSessionEvent(item.(uuid.UUID), sessionEventQRFetched, "")
+	}
+}
+
+// recordVerificationEvent adds the result of the verification of the session to its timeline, with the error of the
+// failed ones read from the session cache
+func (s *Server) recordVerificationEvent(sessionID uuid.UUID, verified bool) {
+	if verified {
+		s.recordSessionEvent(sessionID, sessionEventVerified, "")
+		return
+	}
+	message := ""
+	if item, ok := s.cache.Get(sessionID.String()); ok {
+		if err, ok := item.(error); ok {
+			message = err.Error()
+		}
+	}
+	s.recordSessionEvent(sessionID, sessionEventFailed, message)
+}
+
+// recordSessionEvent appends an event to the timeline of the session, for as long as the sessions are cached. The oldest
+// events are dropped from the timelines that reach maxSessionEvents, e.g. when a wallet keeps fetching the QR code.
+func (s *Server) recordSessionEvent(sessionID uuid.UUID, eventType, message string) {
+	key := sessionEventsKeyPrefix + sessionID.String()
+	s.timelineMu.Lock()
+	defer s.timelineMu.Unlock()
+	var events []SessionEvent
+	if item, ok := s.cache.Get(key); ok {
+		events = item.([]SessionEvent)
+	}
+	event := SessionEvent{Type: eventType, Time: time.Now().UTC()}
+	if message != "" {
+		event.Message = &message
+	}
+	if len(events) >= maxSessionEvents {
+		events = events[len(events)-maxSessionEvents+1:]
+	}
+	updated := make([]SessionEvent, 0, len(events)+1)
+	updated = append(updated, events...)
+	s.cache.Set(key, append(updated, event), cache.DefaultExpiration)
+}
+
+// sessionEvents returns the timeline of the session, the oldest event first
+func (s *Server) sessionEvents(sessionID uuid.UUID) []SessionEvent {
+	s.timelineMu.Lock()
+	defer s.timelineMu.Unlock()
+	if item, ok := s.cache.Get(sessionEventsKeyPrefix + sessionID.String()); ok {
+		return item.([]SessionEvent)
+	}
+	return nil
+}
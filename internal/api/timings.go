@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-jwz/v2"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/patrickmn/go-cache"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/timing"
+)
+
+const sessionTimingsKeyPrefix = "session-timings-"
+
+// GetVerificationTimings - get the time spent in each stage of the verifications
+func (s *Server) GetVerificationTimings(ctx context.Context, request GetVerificationTimingsRequestObject) (GetVerificationTimingsResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return GetVerificationTimings401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+
+	verifications, stages := s.timings.Snapshot()
+	resp := GetVerificationTimings200JSONResponse{Verifications: verifications, Stages: make([]StageTimings, 0, len(timing.Stages))}
+	for _, stage := range timing.Stages {
+		stats, ok := stages[stage]
+		if !ok {
+			continue
+		}
+		resp.Stages = append(resp.Stages, StageTimings{
+			Stage:     string(stage),
+			AverageMs: milliseconds(stats.Average()),
+			MaxMs:     milliseconds(stats.Max),
+			TotalMs:   milliseconds(stats.Total),
+		})
+	}
+	return resp, nil
+}
+
+// expectIssuerResolutions tells the recorder which issuer state resolutions of the token are revocation checks, and of
+// which scope, timing the unpacking of the token and the parsing of the pub signals of each scope
+func expectIssuerResolutions(recorder *timing.Recorder, token string) {
+	stopUnpack := recorder.StartSpan(timing.SpanUnpack, nil)
+	t, err := jwz.Parse(token)
+	if err != nil {
+		stopUnpack()
+		return
+	}
+	var msg protocol.AuthorizationResponseMessage
+	err = json.Unmarshal(t.GetPayload(), &msg)
+	stopUnpack()
+	if err != nil {
+		return
+	}
+
+	for _, scope := range msg.Body.Scope {
+		scopeID := scope.ID
+		stopParse := recorder.StartSpan(timing.SpanPubSignalsParse, &scopeID)
+		output, err := getProofOutput(scope)
+		stopParse()
+		if err != nil {
+			continue
+		}
+		issuerID, ok := output["issuerID"].(*core.ID)
+		if !ok || issuerID == nil {
+			continue
+		}
+		revocationChecked, _ := output["isRevocationChecked"].(int)
+		recorder.ExpectIssuerResolution(scope.ID, issuerID.BigInt(), revocationChecked == 1)
+	}
+}
+
+// setSessionTimings stores the spans of the last verification of the session, for the debug status
+func (s *Server) setSessionTimings(sessionID uuid.UUID, spans []timing.Span) {
+	s.cache.Set(sessionTimingsKeyPrefix+sessionID.String(), spans, cache.DefaultExpiration)
+}
+
+// sessionTimings returns the spans of the last verification of the session, nil before its first callback
+func (s *Server) sessionTimings(sessionID uuid.UUID) *[]TimingSpan {
+	item, ok := s.cache.Get(sessionTimingsKeyPrefix + sessionID.String())
+	if !ok {
+		return nil
+	}
+	spans, _ := item.([]timing.Span)
+	timings := make([]TimingSpan, 0, len(spans))
+	for _, span := range spans {
+		timings = append(timings, TimingSpan{
+			Name:       span.Name,
+			ScopeID:    span.ScopeID,
+			StartMs:    milliseconds(span.Start),
+			DurationMs: milliseconds(span.Duration),
+		})
+	}
+	sort.SliceStable(timings, func(i, j int) bool { return timings[i].StartMs < timings[j].StartMs })
+	return &timings
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+)
+
+// verificationClaims are the claims of the token issued after a successful verification
+type verificationClaims struct {
+	jwt.Claims
+	// Tenant is the tenant of the session, tenants without their own key share the key of the verifier
+	Tenant     string          `json:"tenant,omitempty"`
+	Scopes     []verifiedScope `json:"scopes"`
+	Nullifiers []string        `json:"nullifiers,omitempty"`
+	// EthAddress is the Ethereum address the identity of the subject was created from, if any
+	EthAddress string `json:"ethAddress,omitempty"`
+}
+
+// verifiedScope summarizes a scope of the verification request
+type verifiedScope struct {
+	ID        uint32 `json:"id"`
+	CircuitID string `json:"circuitId"`
+	Type      string `json:"type,omitempty"`
+}
+
+// issueToken mints a JWT for the user of a verified session, signed with the key of the session tenant
+func (s *Server) issueToken(sessionID uuid.UUID, scopes []protocol.ZeroKnowledgeProofRequest, verification models.VerificationResponse) (string, error) {
+	tenant := s.getSessionTenant(sessionID)
+	key, err := s.keys.Key(tenant)
+	if err != nil {
+		return "", err
+	}
+	signer, err := key.Signer((&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return "", err
+	}
+
+	issuer := s.cfg.JWT.Issuer
+	if issuer == "" {
+		issuer = s.cfg.Host
+	}
+	now := time.Now()
+	claims := verificationClaims{
+		Claims: jwt.Claims{
+			ID:        sessionID.String(),
+			Issuer:    issuer,
+			Subject:   verification.UserDID,
+			Audience:  s.cfg.JWT.Audience,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Expiry:    jwt.NewNumericDate(now.Add(s.cfg.JWT.TTL.AsDuration())),
+		},
+		Tenant:     tenant,
+		Scopes:     make([]verifiedScope, 0, len(scopes)),
+		EthAddress: verification.EthAddress,
+	}
+	for _, scope := range scopes {
+		credentialType, _ := scope.Query["type"].(string)
+		claims.Scopes = append(claims.Scopes, verifiedScope{ID: scope.ID, CircuitID: scope.CircuitID, Type: credentialType})
+	}
+	for _, scope := range verification.Scopes {
+		if scope.Nullifier != "" && scope.Nullifier != "0" {
+			claims.Nullifiers = append(claims.Nullifiers, scope.Nullifier)
+		}
+	}
+
+	return jwt.Signed(signer).Claims(claims).CompactSerialize()
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/go-jwz/v2"
+	"github.com/iden3/iden3comm/v2/packers"
+	log "github.com/sirupsen/logrus"
+)
+
+// UnpackMessage - decode a JWZ token or a plain iden3comm message without verifying it
+func (s *Server) UnpackMessage(ctx context.Context, request UnpackMessageRequestObject) (UnpackMessageResponseObject, error) {
+	if request.Body == nil || strings.TrimSpace(request.Body.Message) == "" {
+		return UnpackMessage400JSONResponse{N400JSONResponse{Message: "message is required"}}, nil
+	}
+	resp, err := unpackMessage(request.Body.Message)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Warn("failed to unpack the message")
+		return UnpackMessage400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+	return UnpackMessage200JSONResponse(resp), nil
+}
+
+// unpackMessage decodes a message, the circuits of its proofs and their public signals. The public signals that can not
+// be interpreted are reported with the circuit, so a broken proof is still shown with the rest of the message.
+func unpackMessage(message string) (UnpackResponse, error) {
+	resp := UnpackResponse{Circuits: make([]UnpackedCircuit, 0)}
+	payload := []byte(strings.TrimSpace(message))
+
+	token, err := jwz.Parse(message)
+	switch {
+	case err == nil:
+		header := make(map[string]interface{}, len(token.GetHeader()))
+		for key, value := range token.GetHeader() {
+			header[string(key)] = value
+		}
+		resp.MediaType = string(packers.MediaTypeZKPMessage)
+		resp.Header = &header
+		payload = token.GetPayload()
+
+		auth := UnpackedCircuit{CircuitId: token.CircuitID}
+		if token.ZkProof != nil {
+			auth.PubSignals, auth.Error = circuitSignals(token.CircuitID, token.ZkProof.PubSignals)
+		}
+		resp.Circuits = append(resp.Circuits, auth)
+	case json.Valid(payload):
+		resp.MediaType = string(packers.MediaTypePlainMessage)
+	default:
+		return UnpackResponse{}, fmt.Errorf("message is neither a JWZ token nor a JSON message: %w", err)
+	}
+
+	if err := json.Unmarshal(payload, &resp.Payload); err != nil {
+		return UnpackResponse{}, fmt.Errorf("invalid payload: %w", err)
+	}
+	for _, scope := range resp.Payload.Body.Scope {
+		id := scope.Id
+		circuit := UnpackedCircuit{ScopeID: &id, CircuitId: scope.CircuitId}
+		circuit.PubSignals, circuit.Error = circuitSignals(scope.CircuitId, scope.PubSignals)
+		resp.Circuits = append(resp.Circuits, circuit)
+	}
+	return resp, nil
+}
+
+// circuitSignals returns the public signals of a proof by name, or why they can not be interpreted
+func circuitSignals(circuitID string, signals []string) (*map[string]interface{}, *string) {
+	out, err := interpretSignals(circuitID, signals)
+	if err != nil {
+		msg := err.Error()
+		return nil, &msg
+	}
+	return &out, nil
+}
+
+func interpretSignals(circuitID string, signals []string) (map[string]interface{}, error) {
+	if circuitID == "" {
+		return nil, errors.New("circuit id is not set")
+	}
+	raw, err := json.Marshal(signals)
+	if err != nil {
+		return nil, err
+	}
+	output, err := circuits.UnmarshalCircuitOutput(circuits.CircuitID(circuitID), raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the pub signals of circuit %s: %w", circuitID, err)
+	}
+
+	// the outputs hold ids, hashes and big integers, they are shown as they are encoded, without losing precision
+	encoded, err := json.Marshal(output)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var out map[string]interface{}
+	if err := decoder.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
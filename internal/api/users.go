@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/iden3comm/v2/protocol"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/models"
+	"github.com/0xPolygonID/verifier-backend/internal/nullifier"
+	"github.com/0xPolygonID/verifier-backend/internal/users"
+)
+
+// WithUserRegistry records the users of the successful verifications in r
+func WithUserRegistry(r users.Registry) Option {
+	return func(s *Server) {
+		s.users = r
+	}
+}
+
+// ListUsers - list the users of the user registry
+func (s *Server) ListUsers(ctx context.Context, request ListUsersRequestObject) (ListUsersResponseObject, error) {
+	if s.users == nil {
+		return ListUsers404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeUserRegistryDisabled)}}, nil
+	}
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleAdmin) {
+		return ListUsers401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	tenant := common.FromPointer(request.Params.TenantID)
+
+	records, err := s.users.List(ctx, tenant, common.FromPointer(request.Params.CredentialType))
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to list users")
+		return ListUsers500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	resp := make(ListUsers200JSONResponse, 0, len(records))
+	for _, record := range records {
+		resp = append(resp, toUserRecord(record))
+	}
+	return resp, nil
+}
+
+// GetUser - get a user of the user registry
+func (s *Server) GetUser(ctx context.Context, request GetUserRequestObject) (GetUserResponseObject, error) {
+	if s.users == nil {
+		return GetUser404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeUserRegistryDisabled)}}, nil
+	}
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleAdmin) {
+		return GetUser401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	tenant := common.FromPointer(request.Params.TenantID)
+
+	record, found, err := s.users.Get(ctx, tenant, request.Did)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err, "did": request.Did}).Error("failed to get user")
+		return GetUser500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	if !found {
+		return GetUser404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeUserNotFound)}}, nil
+	}
+	return GetUser200JSONResponse(toUserRecord(record)), nil
+}
+
+// recordUser upserts the user of the verified session in the user registry, with the credential types of the scopes
+// it proved and their nullifiers
+func (s *Server) recordUser(ctx context.Context, sessionID uuid.UUID, scopes []protocol.ZeroKnowledgeProofRequest, verified bool) {
+	if s.users == nil || !verified {
+		return
+	}
+	item, _ := s.cache.Get(sessionID.String())
+	verification, ok := item.(models.VerificationResponse)
+	if !ok || verification.UserDID == "" {
+		return
+	}
+
+	user := users.Verification{DID: verification.UserDID, VerifiedAt: time.Now().UTC()}
+	for _, scope := range scopes {
+		credentialType, _ := scope.Query["type"].(string)
+		if credentialType != "" && isProvedScope(verification.ScopeStatuses, scope.ID) {
+			user.CredentialTypes = append(user.CredentialTypes, credentialType)
+		}
+	}
+	for _, scope := range verification.Scopes {
+		if scope.Nullifier != "" && scope.Nullifier != "0" {
+			user.Nullifiers = append(user.Nullifiers, nullifier.Key{SessionID: scope.NullifierSessionID, Nullifier: scope.Nullifier})
+		}
+	}
+	if _, err := s.users.Upsert(ctx, s.getSessionTenant(sessionID), user); err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err, "sessionID": sessionID}).Error("failed to record user")
+	}
+}
+
+// isProvedScope returns whether the scope was proved, the statuses of the partial verifications report the other ones
+func isProvedScope(statuses []models.ScopeStatus, id uint32) bool {
+	for _, status := range statuses {
+		if status.ID == id {
+			return status.Status == scopeStatusSatisfied
+		}
+	}
+	return true
+}
+
+func toUserRecord(record users.Record) UserRecord {
+	nullifiers := make([]UserNullifier, 0, len(record.Nullifiers))
+	for _, key := range record.Nullifiers {
+		nullifiers = append(nullifiers, UserNullifier{NullifierSessionID: key.SessionID, Nullifier: key.Nullifier})
+	}
+	credentialTypes := record.CredentialTypes
+	if credentialTypes == nil {
+		credentialTypes = []string{}
+	}
+	return UserRecord{
+		Did:             record.DID,
+		FirstVerifiedAt: record.FirstVerifiedAt,
+		LastVerifiedAt:  record.LastVerifiedAt,
+		Verifications:   record.Verifications,
+		CredentialTypes: credentialTypes,
+		Nullifiers:      nullifiers,
+	}
+}
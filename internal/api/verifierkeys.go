@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/keystore"
+)
+
+const jwtKeyType = "JWT"
+
+// WithKeystore sets the BJJ and ETH keys of the verifier, so they can be listed and rotated
+func WithKeystore(ks *keystore.Keystore) Option {
+	return func(s *Server) {
+		s.keystore = ks
+	}
+}
+
+// ListVerifierKeys - list the keys of the verifier
+func (s *Server) ListVerifierKeys(ctx context.Context, request ListVerifierKeysRequestObject) (ListVerifierKeysResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return ListVerifierKeys401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	return ListVerifierKeys200JSONResponse(s.listVerifierKeys()), nil
+}
+
+// RotateVerifierKeys - load the keys of the verifier again from their sources and rotate the keys that changed
+func (s *Server) RotateVerifierKeys(ctx context.Context, request RotateVerifierKeysRequestObject) (RotateVerifierKeysResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleAdmin) {
+		return RotateVerifierKeys401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	jwtRotated, err := s.keys.Reload(s.cfg)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to rotate the signing key")
+		return RotateVerifierKeys500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	rotated, err := s.keystore.Rotate(ctx)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to rotate the verifier keys")
+		return RotateVerifierKeys500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	if jwtRotated {
+		rotated = append([]keystore.Type{jwtKeyType}, rotated...)
+	}
+	s.log(ctx).WithFields(log.Fields{"rotated": rotated}).Info("verifier keys rotated")
+	return RotateVerifierKeys200JSONResponse(s.listVerifierKeys()), nil
+}
+
+func (s *Server) listVerifierKeys() VerifierKeys {
+	keys := make([]VerifierKey, 0)
+	if current, retired := s.keys.Keys(); current != nil {
+		keys = append(keys, VerifierKey{Type: jwtKeyType, KeyId: current.KeyID()})
+		for _, key := range retired {
+			keys = append(keys, VerifierKey{Type: jwtKeyType, KeyId: key.KeyID(), Retired: true})
+		}
+	}
+	current, retired := s.keystore.Keys()
+	for _, key := range current {
+		keys = append(keys, VerifierKey{Type: string(key.Type()), KeyId: key.ID()})
+	}
+	for _, key := range retired {
+		keys = append(keys, VerifierKey{Type: string(key.Type()), KeyId: key.ID(), Retired: true})
+	}
+	return VerifierKeys{Keys: keys}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+const (
+	// interstitialPathPrefix is the path of the interstitial pages of the QR codes, followed by their token
+	interstitialPathPrefix = "/r/"
+)
+
+// mobileUserAgents are the parts of the user agents of the devices that open the wallet links instead of scanning the
+// QR codes
+var mobileUserAgents = []string{"Android", "iPhone", "iPad", "iPod"}
+
+//go:embed interstitial.html
+var interstitialPage string
+
+var interstitialTemplate = template.Must(template.New("interstitial").Parse(interstitialPage))
+
+// walletLinks returns the links that open the request of requestURI in the wallets, and the interstitial page of the
+// QR code of token, that redirects to the same request uri
+func (s *Server) walletLinks(ctx context.Context, token, requestURI string, publicURL *string) *WalletLinks {
+	if err := s.qrStore.SetRequestURI(token, requestURI); err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to store the request uri of the qr code")
+	}
+	baseURLs := s.universalLinkURLs()
+	universalLinks := make([]string, 0, len(baseURLs))
+	for _, baseURL := range baseURLs {
+		universalLinks = append(universalLinks, universalLinkOn(baseURL, requestURI))
+	}
+	return &WalletLinks{
+		DeepLink:       s.deepLink(requestURI),
+		Interstitial:   s.publicURL(ctx, publicURL) + interstitialPathPrefix + token,
+		UniversalLinks: universalLinks,
+	}
+}
+
+// deepLink returns the link of the wallet app scheme that fetches the request from requestURI
+func (s *Server) deepLink(requestURI string) string {
+	return fmt.Sprintf("%s://?request_uri=%s", s.cfg.WalletLinks.DeepLinkScheme, url.QueryEscape(requestURI))
+}
+
+// universalLinkURLs returns the base URLs of the universal links, the universal link URL when none is configured
+func (s *Server) universalLinkURLs() []string {
+	if len(s.cfg.WalletLinks.UniversalLinkURLs) > 0 {
+		return s.cfg.WalletLinks.UniversalLinkURLs
+	}
+	return []string{s.cfg.UniversalLinkURL}
+}
+
+// universalLinkOn returns the universal link on baseURL that fetches the request from requestURI
+func universalLinkOn(baseURL, requestURI string) string {
+	return fmt.Sprintf("%s/#request_uri=%s", strings.TrimSuffix(baseURL, "/"), url.QueryEscape(requestURI))
+}
+
+// Interstitial serves the page of the QR code of the token of the path. The mobile devices are redirected to the first
+// universal link, that opens the wallet app when it is installed and the web wallet otherwise, and the other devices
+// get a page with the QR code to scan and the wallet links.
+func (s *Server) Interstitial(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	token := chi.URLParam(r, "id")
+	if _, err := s.qrStore.Get(token); err != nil {
+		if errors.Is(err, errQRCodeNotFound) {
+			writeError(w, http.StatusNotFound, i18n.Message(ctx, i18n.CodeQRCodeNotFound))
+			return
+		}
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Error getting QRCode: %s", err.Error()))
+		return
+	}
+
+	requestURI, ok := s.qrStore.RequestURI(token)
+	if !ok {
+		requestURI = s.qrStoreLink(ctx, token, nil)
+	}
+	universalLink := universalLinkOn(s.universalLinkURLs()[0], requestURI)
+	w.Header().Set("Cache-Control", "private, no-store")
+	if isMobile(r.UserAgent()) {
+		http.Redirect(w, r, universalLink, http.StatusFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+	if err := interstitialTemplate.Execute(w, map[string]any{
+		"QRCode":        iden3commRequestURIPrefix + requestURI,
+		"DeepLink":      template.URL(s.deepLink(requestURI)), //nolint:gosec //reason: the scheme is configured by the operator
+		"UniversalLink": universalLink,
+	}); err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to render interstitial page")
+	}
+}
+
+func isMobile(userAgent string) bool {
+	for _, mobile := range mobileUserAgents {
+		if strings.Contains(userAgent, mobile) {
+			return true
+		}
+	}
+	return false
+}
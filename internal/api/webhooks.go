@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/webhook"
+)
+
+const (
+	webhookDeliveriesKeyPrefix = "webhook-deliveries-"
+
+	webhookDeliveryNotFound = "webhook delivery not found in the dead letters"
+	webhookNotConfigured    = "the webhook of the delivery is not configured anymore"
+)
+
+// WithWebhookDeadLetters keeps the webhook deliveries that failed in d, instead of in memory
+func WithWebhookDeadLetters(d webhook.DeadLetters) Option {
+	return func(s *Server) {
+		s.deadLetters = d
+	}
+}
+
+// Close stops the retries of the webhook deliveries, the pending ones are moved to the dead letters
+func (s *Server) Close() {
+	s.webhooks.Close()
+}
+
+// ListWebhookDeadLetters - list the webhook deliveries that failed
+func (s *Server) ListWebhookDeadLetters(ctx context.Context, request ListWebhookDeadLettersRequestObject) (ListWebhookDeadLettersResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleReadOnly) {
+		return ListWebhookDeadLetters401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	deliveries, err := s.webhooks.DeadLetters(ctx)
+	if err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to list webhook dead letters")
+		return ListWebhookDeadLetters500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	resp := make(ListWebhookDeadLetters200JSONResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		resp = append(resp, toWebhookDelivery(delivery))
+	}
+	return resp, nil
+}
+
+// ReplayWebhookDeadLetter - send a webhook delivery that failed again
+func (s *Server) ReplayWebhookDeadLetter(ctx context.Context, request ReplayWebhookDeadLetterRequestObject) (ReplayWebhookDeadLetterResponseObject, error) {
+	if !s.hasRole(ctx, request.Params.XAPIKey, roleAdmin) {
+		return ReplayWebhookDeadLetter401JSONResponse{N401JSONResponse{Message: i18n.Message(ctx, i18n.CodeAdminAPIKeyRequired)}}, nil
+	}
+	delivery, err := s.webhooks.Replay(ctx, request.DeliveryID, s.webhookSender)
+	switch {
+	case errors.Is(err, webhook.ErrDeliveryNotFound):
+		return ReplayWebhookDeadLetter404JSONResponse{N404JSONResponse{Message: webhookDeliveryNotFound}}, nil
+	case errors.Is(err, webhook.ErrWebhookNotFound):
+		return ReplayWebhookDeadLetter409JSONResponse{N409JSONResponse{Message: webhookNotConfigured}}, nil
+	case err != nil:
+		s.log(ctx).WithFields(log.Fields{"err": err, "delivery": request.DeliveryID}).Error("failed to replay webhook dead letter")
+		return ReplayWebhookDeadLetter500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return ReplayWebhookDeadLetter202JSONResponse(toWebhookDelivery(delivery)), nil
+}
+
+// GetSessionWebhooks - get the webhook deliveries of a session
+func (s *Server) GetSessionWebhooks(ctx context.Context, request GetSessionWebhooksRequestObject) (GetSessionWebhooksResponseObject, error) {
+	id := request.SessionID
+	if !s.isSessionOwner(id, request.Params.XAPIKey) {
+		return GetSessionWebhooks403JSONResponse{N403JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionForbidden, id)}}, nil
+	}
+	deliveries := s.sessionDeliveries(id)
+	if len(deliveries) == 0 {
+		if _, ok := s.cache.Get(id.String()); !ok {
+			if _, known := s.sessionRecord(ctx, id); !known {
+				return GetSessionWebhooks404JSONResponse{N404JSONResponse{Message: i18n.Message(ctx, i18n.CodeSessionNotFound)}}, nil
+			}
+		}
+	}
+	resp := make(GetSessionWebhooks200JSONResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		resp = append(resp, toWebhookDelivery(delivery))
+	}
+	return resp, nil
+}
+
+// recordDelivery keeps the last status of the webhook delivery with the other deliveries of its session, for as long
+// as the sessions are cached
+func (s *Server) recordDelivery(delivery webhook.Delivery) {
+	key := webhookDeliveriesKeyPrefix + delivery.Event.SessionID
+	s.deliveriesMu.Lock()
+	defer s.deliveriesMu.Unlock()
+	var deliveries []webhook.Delivery
+	if item, ok := s.cache.Get(key); ok {
+		deliveries = item.([]webhook.Delivery)
+	}
+	updated := make([]webhook.Delivery, 0, len(deliveries)+1)
+	found := false
+	for _, d := range deliveries {
+		if d.ID == delivery.ID {
+			d, found = delivery, true
+		}
+		updated = append(updated, d)
+	}
+	if !found {
+		updated = append(updated, delivery)
+	}
+	s.cache.Set(key, updated, cache.DefaultExpiration)
+}
+
+// sessionDeliveries returns the webhook deliveries of the session, the oldest first
+func (s *Server) sessionDeliveries(sessionID uuid.UUID) []webhook.Delivery {
+	s.deliveriesMu.Lock()
+	defer s.deliveriesMu.Unlock()
+	if item, ok := s.cache.Get(webhookDeliveriesKeyPrefix + sessionID.String()); ok {
+		return item.([]webhook.Delivery)
+	}
+	return nil
+}
+
+func toWebhookDelivery(delivery webhook.Delivery) WebhookDelivery {
+	resp := WebhookDelivery{
+		Id:        delivery.ID,
+		Status:    delivery.Status,
+		Attempts:  delivery.Attempts,
+		CreatedAt: delivery.CreatedAt,
+		UpdatedAt: delivery.UpdatedAt,
+		Event: WebhookDeliveryEvent{
+			Type:      delivery.Event.Type,
+			SessionID: delivery.Event.SessionID,
+			Status:    delivery.Event.Status,
+			Time:      delivery.Event.Time,
+		},
+	}
+	if delivery.Webhook != "" {
+		resp.Webhook = common.ToPointer(delivery.Webhook)
+	}
+	if delivery.LastError != "" {
+		resp.LastError = common.ToPointer(delivery.LastError)
+	}
+	if len(delivery.Event.Metadata) > 0 {
+		resp.Event.Metadata = &delivery.Event.Metadata
+	}
+	return resp
+}
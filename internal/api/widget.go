@@ -0,0 +1,97 @@
+package api
+
+import (
+	_ "embed"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+//go:embed widget.html
+var widgetPage string
+
+var widgetTemplate = template.Must(template.New("widget").Parse(widgetPage))
+
+func newWidgetRates(cfg config.Widget, c qrCache) *rateLimiter {
+	return newRateLimiter(c, "widget-rate-", cfg.RateLimit, cfg.RateWindow.AsDuration())
+}
+
+// Widget serves the hosted verification page of the flow of the path, to embed in an iframe. It creates a session of
+// the flow, on the chain of the chainID query parameter when the flow does not set one, shows its QR code and polls its
+// status, and posts the final status to the page that embeds it, e.g.
+// {"type": "verifier-widget-result", "sessionID": "...", "status": "success"}. Only the configured frame ancestors can
+// embed the page and receive its result, the origin of the verifier when there are none.
+func (s *Server) Widget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if addr := clientAddr(ctx); addr != "" && !s.widgetRates.Allow(addr) {
+		s.log(ctx).WithFields(log.Fields{"addr": addr}).Warn("widget pages rate limited")
+		writeError(w, http.StatusTooManyRequests, i18n.Message(ctx, i18n.CodeWidgetRateLimited))
+		return
+	}
+	body := &SignInFlowRequest{}
+	if chainID := r.URL.Query().Get("chainID"); chainID != "" {
+		body.ChainID = common.ToPointer(chainID)
+	}
+	params := SignInFlowParams{}
+	if s.cfg.Widget.APIKey != "" {
+		params.XAPIKey = common.ToPointer(s.cfg.Widget.APIKey)
+	}
+	resp, err := s.SignInFlow(ctx, SignInFlowRequestObject{Name: chi.URLParam(r, "flowName"), Params: params, Body: body})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var signIn SignInFlow200JSONResponse
+	switch resp := resp.(type) {
+	case SignInFlow200JSONResponse:
+		signIn = resp
+	case SignInFlow400JSONResponse:
+		writeError(w, http.StatusBadRequest, resp.Message)
+		return
+	case SignInFlow401JSONResponse:
+		writeError(w, http.StatusUnauthorized, resp.Message)
+		return
+	case SignInFlow403JSONResponse:
+		writeError(w, http.StatusForbidden, resp.Message)
+		return
+	case SignInFlow404JSONResponse:
+		writeError(w, http.StatusNotFound, resp.Message)
+		return
+	case SignInFlow500JSONResponse:
+		writeError(w, http.StatusInternalServerError, resp.Message)
+		return
+	default:
+		writeError(w, http.StatusInternalServerError, "unexpected sign-in response")
+		return
+	}
+
+	// without frame ancestors the page posts its result to its own origin, the only one allowed to embed it
+	targetOrigins, frameAncestors := []string{}, "'self'"
+	if len(s.cfg.Widget.FrameAncestors) > 0 {
+		targetOrigins = s.cfg.Widget.FrameAncestors
+		frameAncestors = strings.Join(s.cfg.Widget.FrameAncestors, " ")
+	}
+	w.Header().Set("Content-Security-Policy", "frame-ancestors "+frameAncestors)
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+	if err := widgetTemplate.Execute(w, map[string]any{
+		"Title":         s.cfg.Widget.Title,
+		"LogoURL":       s.cfg.Widget.LogoURL,
+		"PrimaryColor":  s.cfg.Widget.PrimaryColor,
+		"QRCode":        signIn.QrCode,
+		"UniversalLink": s.universalLink(strings.TrimPrefix(signIn.QrCode, iden3commRequestURIPrefix)),
+		"SessionID":     signIn.SessionID.String(),
+		"StatusURL":     s.publicURL(ctx, nil) + "/status?sessionID=" + signIn.SessionID.String(),
+		"TargetOrigins": targetOrigins,
+	}); err != nil {
+		s.log(ctx).WithFields(log.Fields{"err": err}).Error("failed to render widget page")
+	}
+}
@@ -0,0 +1,105 @@
+// Package audit keeps the audit log of the verifications, so compliance reviews can export them without access to the
+// servers. The records are numbered in the order they were appended, and the number of the last record of a page is
+// the cursor of the next one.
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// TypeVerificationSucceeded is the type of the records of the successful verifications
+	TypeVerificationSucceeded = "verification.succeeded"
+	// TypeVerificationFailed is the type of the records of the failed verifications
+	TypeVerificationFailed = "verification.failed"
+)
+
+// Record is an entry of the audit log
+type Record struct {
+	Seq             uint64    `json:"seq"`
+	Time            time.Time `json:"time"`
+	Type            string    `json:"type"`
+	SessionID       string    `json:"sessionID"`
+	Tenant          string    `json:"tenant,omitempty"`
+	UserDID         string    `json:"userDID,omitempty"`
+	CircuitIDs      []string  `json:"circuitIDs"`
+	CredentialTypes []string  `json:"credentialTypes"`
+	ErrorCode       string    `json:"errorCode,omitempty"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// Filter selects the records appended at or after From and before To, when they are set, and numbered after After
+type Filter struct {
+	From  time.Time
+	To    time.Time
+	After uint64
+}
+
+// Match returns whether the record is selected by the filter
+func (f Filter) Match(record Record) bool {
+	return record.Seq > f.After &&
+		(f.From.IsZero() || !record.Time.Before(f.From)) &&
+		(f.To.IsZero() || record.Time.Before(f.To))
+}
+
+// Log stores the records of the audit log
+type Log interface {
+	// Append numbers the record and stores it
+	Append(ctx context.Context, record Record) (Record, error)
+	// Scan calls fn with the records selected by the filter, in the order they were appended, until fn returns an
+	// error, which is returned. The records appended while the log is scanned are not seen.
+	Scan(ctx context.Context, filter Filter, fn func(Record) error) error
+}
+
+// MemoryLog is a Log that keeps the last records in memory
+type MemoryLog struct {
+	mu      sync.RWMutex
+	size    int
+	seq     uint64
+	records []Record
+}
+
+// NewMemoryLog creates a MemoryLog that keeps the last size records, every record when size is not positive
+func NewMemoryLog(size int) *MemoryLog {
+	return &MemoryLog{size: size}
+}
+
+// Append numbers the record and stores it, dropping the oldest record when the log is full
+func (m *MemoryLog) Append(_ context.Context, record Record) (Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.seq++
+	record.Seq = m.seq
+	// the oldest records are dropped in batches, so appends are not slowed down by copies, and into a new array, so the
+	// scans in progress do not see them change
+	if m.size > 0 && len(m.records) >= 2*m.size {
+		m.records = append(make([]Record, 0, 2*m.size), m.records[len(m.records)-m.size+1:]...)
+	}
+	m.records = append(m.records, record)
+	return record, nil
+}
+
+// Scan calls fn with the records selected by the filter, without holding the lock of the log while fn runs
+func (m *MemoryLog) Scan(ctx context.Context, filter Filter, fn func(Record) error) error {
+	m.mu.RLock()
+	records := m.records[:len(m.records):len(m.records)]
+	m.mu.RUnlock()
+	if m.size > 0 && len(records) > m.size {
+		records = records[len(records)-m.size:]
+	}
+
+	for _, record := range records {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !filter.Match(record) {
+			continue
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collect(t *testing.T, log Log, filter Filter) []uint64 {
+	t.Helper()
+	seqs := make([]uint64, 0)
+	require.NoError(t, log.Scan(context.Background(), filter, func(record Record) error {
+		seqs = append(seqs, record.Seq)
+		return nil
+	}))
+	return seqs
+}
+
+func appendRecords(t *testing.T, log Log, now time.Time, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		record, err := log.Append(context.Background(), Record{
+			Time:      now.Add(time.Duration(i) * time.Hour),
+			Type:      TypeVerificationSucceeded,
+			SessionID: "8f1c4b4e-7c0c-4d4e-9d59-6e0b2d1f6a11",
+		})
+		require.NoError(t, err)
+		require.Equal(t, uint64(i+1), record.Seq)
+	}
+}
+
+func TestMemoryLog(t *testing.T) {
+	now := time.Date(2025, 6, 16, 10, 0, 0, 0, time.UTC)
+	log := NewMemoryLog(0)
+	appendRecords(t, log, now, 5)
+
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, collect(t, log, Filter{}))
+	assert.Equal(t, []uint64{2, 3}, collect(t, log, Filter{From: now.Add(time.Hour), To: now.Add(3 * time.Hour)}))
+	assert.Equal(t, []uint64{4, 5}, collect(t, log, Filter{After: 3}))
+
+	// the scans stop at the first error of fn
+	stop := errors.New("stop")
+	calls := 0
+	err := log.Scan(context.Background(), Filter{}, func(Record) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+
+	// only the last records are kept
+	log = NewMemoryLog(3)
+	appendRecords(t, log, now, 10)
+	assert.Equal(t, []uint64{8, 9, 10}, collect(t, log, Filter{}))
+}
+
+func TestFileLog(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 16, 10, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	log, err := OpenFileLog(path)
+	require.NoError(t, err)
+	appendRecords(t, log, now, 3)
+	require.NoError(t, log.Close())
+
+	// the records are numbered after the last one of the file when it is opened again
+	log, err = OpenFileLog(path)
+	require.NoError(t, err)
+	record, err := log.Append(ctx, Record{Time: now.Add(3 * time.Hour), Type: TypeVerificationFailed, ErrorCode: "INVALID_PROOF"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), record.Seq)
+	assert.Equal(t, []uint64{2, 3, 4}, collect(t, log, Filter{From: now.Add(time.Hour)}))
+	assert.Equal(t, []uint64{4}, collect(t, log, Filter{After: 3}))
+
+	// the records appended during a scan are not seen
+	seqs := make([]uint64, 0)
+	require.NoError(t, log.Scan(ctx, Filter{}, func(record Record) error {
+		seqs = append(seqs, record.Seq)
+		if record.Seq == 1 {
+			_, err := log.Append(ctx, Record{Time: now, Type: TypeVerificationSucceeded})
+			return err
+		}
+		return nil
+	}))
+	assert.Equal(t, []uint64{1, 2, 3, 4}, seqs)
+	require.NoError(t, log.Close())
+
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o600))
+	_, err = OpenFileLog(path)
+	assert.ErrorContains(t, err, "invalid record at line 1")
+}
@@ -0,0 +1,120 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// maxRecordSize is the size of the longest line of a FileLog, a record with a long error
+const maxRecordSize = 1 << 20
+
+// FileLog is a Log that persists the records in an append-only file, one json record per line. Unlike the other file
+// stores, the records are not kept in memory: the file is read again by every scan, so the log can grow past the memory
+// of the server. The file is never compacted, and must be rotated while the server is stopped.
+type FileLog struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	seq  uint64
+	size int64
+}
+
+// OpenFileLog opens the FileLog at path, creating the file if it does not exist, and reads the number of its last record
+func OpenFileLog(path string) (*FileLog, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &FileLog{path: path, file: f}
+	if err := l.scan(context.Background(), -1, func(record Record) error {
+		l.seq = record.Seq
+		return nil
+	}); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	l.size = info.Size()
+	return l, nil
+}
+
+// Append numbers the record and appends it to the file
+func (l *FileLog) Append(_ context.Context, record Record) (Record, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	record.Seq = l.seq + 1
+	b, err := json.Marshal(record)
+	if err != nil {
+		return Record{}, err
+	}
+	n, err := l.file.Write(append(b, '\n'))
+	l.size += int64(n)
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to persist audit record: %w", err)
+	}
+	l.seq = record.Seq
+	return record, nil
+}
+
+// Scan reads the records selected by the filter from the file, up to its size when the scan started so the records
+// being appended are not read half written. The file is read as fn consumes the records.
+func (l *FileLog) Scan(ctx context.Context, filter Filter, fn func(Record) error) error {
+	l.mu.Lock()
+	size := l.size
+	l.mu.Unlock()
+	return l.scan(ctx, size, func(record Record) error {
+		if !filter.Match(record) {
+			return nil
+		}
+		return fn(record)
+	})
+}
+
+// Close closes the file of the log
+func (l *FileLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// scan calls fn with the records of the first size bytes of the file, of the whole file when size is negative
+func (l *FileLog) scan(ctx context.Context, size int64, fn func(Record) error) error {
+	f, err := os.Open(l.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if size >= 0 {
+		r = io.LimitReader(f, size)
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxRecordSize)
+	for line := 1; scanner.Scan(); line++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("invalid record at line %d of %s: %w", line, l.path, err)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
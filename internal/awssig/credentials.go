@@ -0,0 +1,282 @@
+package awssig
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	stsEndpoint        = "https://sts.amazonaws.com/"
+	imdsEndpoint       = "http://169.254.169.254"
+	ecsEndpoint        = "http://169.254.170.2"
+	imdsTokenTTL       = "21600"
+	imdsTimeout        = time.Second
+	providerTimeout    = 5 * time.Second
+	maxProviderBody    = 1 << 16
+	refreshMargin      = 5 * time.Minute
+	anonymousRetry     = 5 * time.Minute
+	defaultSessionName = "verifier-backend"
+)
+
+// Credentials are the AWS credentials the requests are signed with. Expires is zero for credentials that do not expire.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Expires         time.Time
+}
+
+// Retrieve returns the credentials themselves, so static credentials can be used as a Provider
+func (c Credentials) Retrieve(context.Context) (Credentials, error) {
+	return c, nil
+}
+
+// Anonymous reports whether the credentials are empty, the requests are not signed then
+func (c Credentials) Anonymous() bool {
+	return c.AccessKeyID == ""
+}
+
+// Provider returns the credentials to sign a request with
+type Provider interface {
+	Retrieve(ctx context.Context) (Credentials, error)
+}
+
+// EnvCredentials returns the credentials of the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables
+func EnvCredentials() Credentials {
+	return Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+// DefaultProvider returns the provider of the credentials of the environment, looked up in the order of the AWS SDKs:
+//   - the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables
+//   - the web identity token of AWS_WEB_IDENTITY_TOKEN_FILE exchanged for the role AWS_ROLE_ARN (IAM roles for service accounts on EKS)
+//   - the container credentials of AWS_CONTAINER_CREDENTIALS_RELATIVE_URI or AWS_CONTAINER_CREDENTIALS_FULL_URI (ECS tasks)
+//   - the role of the EC2 instance, from the instance metadata service, unless AWS_EC2_METADATA_DISABLED is true
+//
+// Shared credentials files and profiles are not supported. The requests are not signed when none of them provides credentials.
+func DefaultProvider() Provider {
+	client := &http.Client{Timeout: providerTimeout}
+	switch {
+	case os.Getenv("AWS_ACCESS_KEY_ID") != "":
+		return EnvCredentials()
+	case os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE") != "" && os.Getenv("AWS_ROLE_ARN") != "":
+		return NewCachedProvider(&WebIdentityProvider{
+			TokenFile:   os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"),
+			RoleARN:     os.Getenv("AWS_ROLE_ARN"),
+			SessionName: os.Getenv("AWS_ROLE_SESSION_NAME"),
+			Endpoint:    stsEndpoint,
+			Client:      client,
+		})
+	case os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI") != "":
+		return NewCachedProvider(&ContainerProvider{
+			URL:    ecsEndpoint + os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"),
+			Token:  os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN"),
+			Client: client,
+		})
+	case os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI") != "":
+		return NewCachedProvider(&ContainerProvider{
+			URL:    os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI"),
+			Token:  os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN"),
+			Client: client,
+		})
+	case strings.EqualFold(os.Getenv("AWS_EC2_METADATA_DISABLED"), "true"):
+		return Credentials{}
+	default:
+		return NewCachedProvider(&IMDSProvider{Endpoint: imdsEndpoint, Client: &http.Client{Timeout: imdsTimeout}})
+	}
+}
+
+// CachedProvider caches the credentials of a provider until 5 minutes before they expire
+type CachedProvider struct {
+	provider Provider
+
+	mu    sync.Mutex
+	creds Credentials
+}
+
+// NewCachedProvider creates a CachedProvider of provider
+func NewCachedProvider(provider Provider) *CachedProvider {
+	return &CachedProvider{provider: provider}
+}
+
+// Retrieve returns the cached credentials, or the credentials of the provider when they are about to expire
+func (p *CachedProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.creds.Expires.IsZero() && time.Now().Add(refreshMargin).Before(p.creds.Expires) {
+		return p.creds, nil
+	}
+	creds, err := p.provider.Retrieve(ctx)
+	if err != nil {
+		return Credentials{}, err
+	}
+	p.creds = creds
+	return creds, nil
+}
+
+// WebIdentityProvider exchanges the web identity token of TokenFile for the credentials of the role RoleARN with
+// STS AssumeRoleWithWebIdentity. The token file is read again on every exchange, as it is rotated.
+type WebIdentityProvider struct {
+	TokenFile   string
+	RoleARN     string
+	SessionName string
+	Endpoint    string
+	Client      *http.Client
+}
+
+// Retrieve assumes the role with the web identity token
+func (p *WebIdentityProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	token, err := os.ReadFile(p.TokenFile)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to read the web identity token: %w", err)
+	}
+	sessionName := p.SessionName
+	if sessionName == "" {
+		sessionName = defaultSessionName
+	}
+	query := url.Values{
+		"Action":           {"AssumeRoleWithWebIdentity"},
+		"Version":          {"2011-06-15"},
+		"RoleArn":          {p.RoleARN},
+		"RoleSessionName":  {sessionName},
+		"WebIdentityToken": {strings.TrimSpace(string(token))},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.Endpoint, strings.NewReader(query.Encode()))
+	if err != nil {
+		return Credentials{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	body, err := fetch(p.Client, req)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to assume role %s with web identity: %w", p.RoleARN, err)
+	}
+
+	var resp struct {
+		Credentials struct {
+			AccessKeyID     string    `xml:"AccessKeyId"`
+			SecretAccessKey string    `xml:"SecretAccessKey"`
+			SessionToken    string    `xml:"SessionToken"`
+			Expiration      time.Time `xml:"Expiration"`
+		} `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+	}
+	if err := xml.Unmarshal(body, &resp); err != nil {
+		return Credentials{}, fmt.Errorf("invalid response of AssumeRoleWithWebIdentity: %w", err)
+	}
+	return Credentials{
+		AccessKeyID:     resp.Credentials.AccessKeyID,
+		SecretAccessKey: resp.Credentials.SecretAccessKey,
+		SessionToken:    resp.Credentials.SessionToken,
+		Expires:         resp.Credentials.Expiration,
+	}, nil
+}
+
+// ContainerProvider reads the credentials of the task role from the ECS container credentials endpoint
+type ContainerProvider struct {
+	URL    string
+	Token  string
+	Client *http.Client
+}
+
+// Retrieve reads the credentials of the task role
+func (p *ContainerProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if p.Token != "" {
+		req.Header.Set("Authorization", p.Token)
+	}
+	body, err := fetch(p.Client, req)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to get the container credentials: %w", err)
+	}
+	return parseRoleCredentials(body)
+}
+
+// IMDSProvider reads the credentials of the role of the EC2 instance from the instance metadata service (IMDSv2).
+// The requests are not signed when the service is not reachable, e.g. outside EC2, it is tried again 5 minutes later.
+type IMDSProvider struct {
+	Endpoint string
+	Client   *http.Client
+}
+
+// Retrieve reads the credentials of the role of the instance
+func (p *IMDSProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, p.Endpoint+"/latest/api/token", nil)
+	if err != nil {
+		return Credentials{}, err
+	}
+	req.Header.Set("X-Aws-Ec2-Metadata-Token-Ttl-Seconds", imdsTokenTTL)
+	token, err := fetch(p.Client, req)
+	if err != nil {
+		// not an EC2 instance, the anonymous credentials are cached by CachedProvider until refreshMargin before they expire
+		return Credentials{Expires: time.Now().Add(refreshMargin + anonymousRetry)}, nil
+	}
+
+	get := func(path string) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.Endpoint+"/latest/meta-data/iam/security-credentials/"+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-Aws-Ec2-Metadata-Token", string(token))
+		return fetch(p.Client, req)
+	}
+	role, err := get("")
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to get the role of the instance: %w", err)
+	}
+	body, err := get(strings.TrimSpace(strings.SplitN(string(role), "\n", 2)[0]))
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to get the credentials of the instance: %w", err)
+	}
+	return parseRoleCredentials(body)
+}
+
+// parseRoleCredentials parses the credentials returned by the container and the instance metadata endpoints
+func parseRoleCredentials(body []byte) (Credentials, error) {
+	var resp struct {
+		AccessKeyID     string    `json:"AccessKeyId"`
+		SecretAccessKey string    `json:"SecretAccessKey"`
+		Token           string    `json:"Token"`
+		Expiration      time.Time `json:"Expiration"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return Credentials{}, fmt.Errorf("invalid role credentials: %w", err)
+	}
+	return Credentials{
+		AccessKeyID:     resp.AccessKeyID,
+		SecretAccessKey: resp.SecretAccessKey,
+		SessionToken:    resp.Token,
+		Expires:         resp.Expiration,
+	}, nil
+}
+
+func fetch(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxProviderBody))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return body, nil
+}
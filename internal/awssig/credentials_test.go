@@ -0,0 +1,147 @@
+package awssig
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultProvider(t *testing.T) {
+	for _, name := range []string{
+		"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "AWS_WEB_IDENTITY_TOKEN_FILE", "AWS_ROLE_ARN",
+		"AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "AWS_CONTAINER_CREDENTIALS_FULL_URI", "AWS_EC2_METADATA_DISABLED",
+	} {
+		t.Setenv(name, "")
+	}
+
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	assert.Equal(t, Credentials{}, DefaultProvider())
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "")
+	assert.IsType(t, &IMDSProvider{}, DefaultProvider().(*CachedProvider).provider)
+
+	t.Setenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "/v2/credentials/task")
+	assert.Equal(t, "http://169.254.170.2/v2/credentials/task", DefaultProvider().(*CachedProvider).provider.(*ContainerProvider).URL)
+
+	t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "/var/run/secrets/eks.amazonaws.com/serviceaccount/token")
+	t.Setenv("AWS_ROLE_ARN", "arn:aws:iam::123456789012:role/verifier")
+	assert.IsType(t, &WebIdentityProvider{}, DefaultProvider().(*CachedProvider).provider)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "access")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	assert.Equal(t, Credentials{AccessKeyID: "access", SecretAccessKey: "secret"}, DefaultProvider())
+}
+
+func TestWebIdentityProvider(t *testing.T) {
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.Form.Get("Action") != "AssumeRoleWithWebIdentity" || r.Form.Get("WebIdentityToken") != "eyJhbGciOiJSUzI1NiJ9" ||
+			r.Form.Get("RoleArn") != "arn:aws:iam::123456789012:role/verifier" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleWithWebIdentityResult>
+    <Credentials>
+      <AccessKeyId>ASIAEXAMPLE</AccessKeyId>
+      <SecretAccessKey>secret</SecretAccessKey>
+      <SessionToken>token</SessionToken>
+      <Expiration>%s</Expiration>
+    </Credentials>
+  </AssumeRoleWithWebIdentityResult>
+</AssumeRoleWithWebIdentityResponse>`, expires.Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("eyJhbGciOiJSUzI1NiJ9\n"), 0o600))
+	provider := &WebIdentityProvider{TokenFile: tokenFile, RoleARN: "arn:aws:iam::123456789012:role/verifier", Endpoint: server.URL, Client: server.Client()}
+	creds, err := provider.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{AccessKeyID: "ASIAEXAMPLE", SecretAccessKey: "secret", SessionToken: "token", Expires: expires}, creds)
+
+	provider.RoleARN = "arn:aws:iam::123456789012:role/other"
+	_, err = provider.Retrieve(context.Background())
+	assert.ErrorContains(t, err, "403")
+}
+
+func TestContainerProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/credentials/task" || r.Header.Get("Authorization") != "auth" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"AccessKeyId":"ASIAEXAMPLE","SecretAccessKey":"secret","Token":"token","Expiration":"2030-01-02T03:04:05Z"}`))
+	}))
+	defer server.Close()
+
+	creds, err := (&ContainerProvider{URL: server.URL + "/v2/credentials/task", Token: "auth", Client: server.Client()}).Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{AccessKeyID: "ASIAEXAMPLE", SecretAccessKey: "secret", SessionToken: "token", Expires: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)}, creds)
+}
+
+func TestIMDSProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/latest/api/token":
+			_, _ = w.Write([]byte("imds-token"))
+		case r.Header.Get("X-Aws-Ec2-Metadata-Token") != "imds-token":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/latest/meta-data/iam/security-credentials/":
+			_, _ = w.Write([]byte("verifier-role"))
+		case r.URL.Path == "/latest/meta-data/iam/security-credentials/verifier-role":
+			_, _ = w.Write([]byte(`{"Code":"Success","AccessKeyId":"ASIAEXAMPLE","SecretAccessKey":"secret","Token":"token","Expiration":"2030-01-02T03:04:05Z"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	creds, err := (&IMDSProvider{Endpoint: server.URL, Client: server.Client()}).Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{AccessKeyID: "ASIAEXAMPLE", SecretAccessKey: "secret", SessionToken: "token", Expires: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)}, creds)
+
+	// outside EC2 the requests are anonymous
+	server.Close()
+	creds, err = (&IMDSProvider{Endpoint: server.URL, Client: server.Client()}).Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.True(t, creds.Anonymous())
+	assert.WithinDuration(t, time.Now().Add(refreshMargin+anonymousRetry), creds.Expires, time.Minute)
+}
+
+type countingProvider struct {
+	calls int
+	ttl   time.Duration
+}
+
+func (p *countingProvider) Retrieve(context.Context) (Credentials, error) {
+	p.calls++
+	return Credentials{AccessKeyID: fmt.Sprint("key-", p.calls), Expires: time.Now().Add(p.ttl)}, nil
+}
+
+func TestCachedProvider(t *testing.T) {
+	ctx := context.Background()
+	long := &countingProvider{ttl: time.Hour}
+	cached := NewCachedProvider(long)
+	for i := 0; i < 3; i++ {
+		creds, err := cached.Retrieve(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "key-1", creds.AccessKeyID)
+	}
+
+	// credentials about to expire are refreshed
+	short := &countingProvider{ttl: time.Minute}
+	cached = NewCachedProvider(short)
+	_, err := cached.Retrieve(ctx)
+	require.NoError(t, err)
+	creds, err := cached.Retrieve(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", creds.AccessKeyID)
+}
@@ -0,0 +1,192 @@
+// Package circuitkeys loads the verification keys of the circuits from the filesystem, an http(s) server or an s3 bucket,
+// so keys can be rotated or added without a redeploy.
+package circuitkeys
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iden3/go-circuits/v2"
+)
+
+const fetchTimeout = 30 * time.Second
+
+// ErrChecksumMismatch is returned when the sha256 of a key does not match its configured checksum
+var ErrChecksumMismatch = errors.New("verification key checksum mismatch")
+
+// Source fetches the verification key of a circuit
+type Source interface {
+	Fetch(ctx context.Context, id circuits.CircuitID) ([]byte, error)
+	// Location describes where the keys are fetched from
+	Location() string
+}
+
+// Key describes a loaded verification key
+type Key struct {
+	CircuitID circuits.CircuitID
+	Checksum  string
+	LoadedAt  time.Time
+}
+
+type loadedKey struct {
+	data []byte
+	Key
+}
+
+// Loader is a verification key loader of the verifier that keeps the keys fetched from its source in memory,
+// and in cacheDir when it is set, so the verifier can restart while the source is unavailable.
+type Loader struct {
+	source    Source
+	cacheDir  string
+	checksums map[circuits.CircuitID]string
+
+	mu   sync.RWMutex
+	keys map[circuits.CircuitID]loadedKey
+}
+
+// NewLoader creates a Loader. Checksums maps circuit ids to the hex encoded sha256 of their keys.
+func NewLoader(source Source, cacheDir string, checksums map[string]string) *Loader {
+	l := &Loader{
+		source:    source,
+		cacheDir:  cacheDir,
+		checksums: make(map[circuits.CircuitID]string, len(checksums)),
+		keys:      make(map[circuits.CircuitID]loadedKey),
+	}
+	for id, checksum := range checksums {
+		l.checksums[circuits.CircuitID(id)] = strings.ToLower(checksum)
+	}
+	return l
+}
+
+// Location returns where the keys are fetched from
+func (l *Loader) Location() string {
+	return l.source.Location()
+}
+
+// Load returns the verification key of the circuit, fetching it on first use
+func (l *Loader) Load(id circuits.CircuitID) ([]byte, error) {
+	l.mu.RLock()
+	key, ok := l.keys[id]
+	l.mu.RUnlock()
+	if ok {
+		return key.data, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+	key, err := l.fetch(ctx, id)
+	if err != nil {
+		// keep serving the last key fetched before a restart while the source is unavailable
+		if key, ok := l.readCache(id); ok {
+			l.store(key)
+			return key.data, nil
+		}
+		return nil, err
+	}
+	l.store(key)
+	return key.data, nil
+}
+
+// Reload fetches again the loaded keys and the keys with a checksum. Keys that cannot be fetched are kept.
+func (l *Loader) Reload(ctx context.Context) error {
+	l.mu.RLock()
+	ids := make(map[circuits.CircuitID]bool, len(l.keys)+len(l.checksums))
+	for id := range l.keys {
+		ids[id] = true
+	}
+	l.mu.RUnlock()
+	for id := range l.checksums {
+		ids[id] = true
+	}
+
+	var errs []error
+	for id := range ids {
+		key, err := l.fetch(ctx, id)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		l.store(key)
+	}
+	return errors.Join(errs...)
+}
+
+// Keys returns the loaded keys sorted by circuit id
+func (l *Loader) Keys() []Key {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	keys := make([]Key, 0, len(l.keys))
+	for _, key := range l.keys {
+		keys = append(keys, key.Key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CircuitID < keys[j].CircuitID })
+	return keys
+}
+
+func (l *Loader) fetch(ctx context.Context, id circuits.CircuitID) (loadedKey, error) {
+	// circuit ids come from the tokens of the wallets, only the keys of known circuits are fetched
+	if _, err := circuits.GetCircuit(id); err != nil {
+		return loadedKey{}, fmt.Errorf("unknown circuit %s", id)
+	}
+	data, err := l.source.Fetch(ctx, id)
+	if err != nil {
+		return loadedKey{}, fmt.Errorf("failed to fetch the verification key of %s from %s: %w", id, l.source.Location(), err)
+	}
+	key, err := l.verify(id, data)
+	if err != nil {
+		return loadedKey{}, err
+	}
+	l.writeCache(key)
+	return key, nil
+}
+
+// verify checks the checksum of the key, when it has one
+func (l *Loader) verify(id circuits.CircuitID, data []byte) (loadedKey, error) {
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	if expected, ok := l.checksums[id]; ok && expected != checksum {
+		return loadedKey{}, fmt.Errorf("%w: circuit %s has checksum %s, expected %s", ErrChecksumMismatch, id, checksum, expected)
+	}
+	return loadedKey{data: data, Key: Key{CircuitID: id, Checksum: checksum, LoadedAt: time.Now().UTC()}}, nil
+}
+
+func (l *Loader) store(key loadedKey) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.keys[key.CircuitID] = key
+}
+
+func (l *Loader) readCache(id circuits.CircuitID) (loadedKey, bool) {
+	if l.cacheDir == "" {
+		return loadedKey{}, false
+	}
+	data, err := os.ReadFile(l.cachePath(id))
+	if err != nil {
+		return loadedKey{}, false
+	}
+	key, err := l.verify(id, data)
+	return key, err == nil
+}
+
+func (l *Loader) writeCache(key loadedKey) {
+	if l.cacheDir == "" {
+		return
+	}
+	if err := os.MkdirAll(l.cacheDir, 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(l.cachePath(key.CircuitID), key.data, 0o600)
+}
+
+func (l *Loader) cachePath(id circuits.CircuitID) string {
+	return filepath.Join(l.cacheDir, string(id)+".json")
+}
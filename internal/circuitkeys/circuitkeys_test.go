@@ -0,0 +1,130 @@
+package circuitkeys
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/iden3/go-circuits/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var verificationKey = []byte(`{"protocol":"groth16","curve":"bn128"}`)
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestLoader(t *testing.T) {
+	var unavailable atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if unavailable.Load() || r.URL.Path != "/keys/authV2.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(verificationKey)
+	}))
+	defer server.Close()
+
+	source, err := NewSource(server.URL+"/keys/", "", "")
+	require.NoError(t, err)
+	cacheDir := t.TempDir()
+	l := NewLoader(source, cacheDir, map[string]string{string(circuits.AuthV2CircuitID): strings.ToUpper(checksum(verificationKey))})
+	assert.Equal(t, server.URL+"/keys", l.Location())
+
+	key, err := l.Load(circuits.AuthV2CircuitID)
+	require.NoError(t, err)
+	assert.Equal(t, verificationKey, key)
+	cached, err := os.ReadFile(filepath.Join(cacheDir, "authV2.json"))
+	require.NoError(t, err)
+	assert.Equal(t, verificationKey, cached)
+
+	keys := l.Keys()
+	require.Len(t, keys, 1)
+	assert.Equal(t, circuits.AuthV2CircuitID, keys[0].CircuitID)
+	assert.Equal(t, checksum(verificationKey), keys[0].Checksum)
+
+	_, err = l.Load("../../etc/passwd")
+	assert.ErrorContains(t, err, "unknown circuit")
+
+	// a restarted verifier serves the cached key while the source is unavailable
+	unavailable.Store(true)
+	restarted := NewLoader(source, cacheDir, nil)
+	key, err = restarted.Load(circuits.AuthV2CircuitID)
+	require.NoError(t, err)
+	assert.Equal(t, verificationKey, key)
+	assert.Error(t, restarted.Reload(context.Background()))
+	assert.Len(t, restarted.Keys(), 1)
+
+	unavailable.Store(false)
+	require.NoError(t, restarted.Reload(context.Background()))
+}
+
+func TestLoaderChecksumMismatch(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "authV2.json"), verificationKey, 0o600))
+
+	l := NewLoader(FSSource{Dir: dir}, "", map[string]string{string(circuits.AuthV2CircuitID): checksum([]byte("other"))})
+	_, err := l.Load(circuits.AuthV2CircuitID)
+	assert.True(t, errors.Is(err, ErrChecksumMismatch))
+	assert.Empty(t, l.Keys())
+}
+
+func TestNewSource(t *testing.T) {
+	type testConfig struct {
+		name     string
+		location string
+		expected Source
+		err      bool
+	}
+	for _, tc := range []testConfig{
+		{name: "directory", location: "./keys", expected: FSSource{Dir: "./keys"}},
+		{name: "https", location: "https://keys.example.com/v1/", expected: HTTPSource{BaseURL: "https://keys.example.com/v1", Client: http.DefaultClient}},
+		{name: "s3 without bucket", location: "s3:///keys", err: true},
+		{name: "unsupported scheme", location: "ftp://keys.example.com", err: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			source, err := NewSource(tc.location, "us-east-1", "")
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, source)
+		})
+	}
+}
+
+func TestS3Source(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bucket/keys/authV2.json" ||
+			!strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") ||
+			r.Header.Get("X-Amz-Security-Token") != "session" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write(verificationKey)
+	}))
+	defer server.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "access")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "session")
+	source, err := NewSource("s3://bucket/keys", "eu-west-1", server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "s3://bucket/keys", source.Location())
+
+	key, err := source.Fetch(context.Background(), circuits.AuthV2CircuitID)
+	require.NoError(t, err)
+	assert.Equal(t, verificationKey, key)
+}
@@ -0,0 +1,95 @@
+package circuitkeys
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/iden3/go-circuits/v2"
+
+	"github.com/0xPolygonID/verifier-backend/internal/objectstore"
+)
+
+// maxKeySize limits the size of the keys fetched from http sources
+const maxKeySize = 16 << 20
+
+// NewSource creates the source of the keys of location: a directory, an http(s) URL or an s3://bucket/prefix URL.
+// S3 requests are signed with the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables when they are set.
+// An endpoint can be set for s3 compatible stores, the bucket is then addressed in the path.
+func NewSource(location, s3Region, s3Endpoint string) (Source, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "":
+		return FSSource{Dir: location}, nil
+	case "http", "https":
+		return HTTPSource{BaseURL: strings.TrimSuffix(location, "/"), Client: http.DefaultClient}, nil
+	case "s3":
+		if u.Host == "" {
+			return nil, fmt.Errorf("s3 location %s has no bucket", location)
+		}
+		return S3Source{S3: objectstore.NewS3(u.Host, strings.Trim(u.Path, "/"), s3Region, s3Endpoint)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported verification keys location %s, expected a directory, an http(s) or an s3 URL", location)
+	}
+}
+
+// FSSource reads the keys from Dir
+type FSSource struct {
+	Dir string
+}
+
+// Fetch reads the key of the circuit
+func (s FSSource) Fetch(_ context.Context, id circuits.CircuitID) ([]byte, error) {
+	return os.ReadFile(filepath.Join(s.Dir, string(id)+".json"))
+}
+
+// Location returns the directory of the keys
+func (s FSSource) Location() string {
+	return s.Dir
+}
+
+// HTTPSource fetches the keys from BaseURL/{circuitID}.json
+type HTTPSource struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// Fetch downloads the key of the circuit
+func (s HTTPSource) Fetch(ctx context.Context, id circuits.CircuitID) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.BaseURL+"/"+url.PathEscape(string(id))+".json", http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxKeySize))
+}
+
+// Location returns the base URL of the keys
+func (s HTTPSource) Location() string {
+	return s.BaseURL
+}
+
+// S3Source fetches the keys from the objects {prefix}/{circuitID}.json of a bucket
+type S3Source struct {
+	*objectstore.S3
+}
+
+// Fetch downloads the key of the circuit
+func (s S3Source) Fetch(ctx context.Context, id circuits.CircuitID) ([]byte, error) {
+	return s.Get(ctx, string(id)+".json")
+}
@@ -4,3 +4,12 @@ package common
 func ToPointer[T any](p T) *T {
 	return &p
 }
+
+// FromPointer is a helper function to get the value of a pointer, or the zero value of nil pointers.
+func FromPointer[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
@@ -1,14 +1,24 @@
 package config
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	texttemplate "text/template"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/kelseyhightower/envconfig"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/text/language"
 	"gopkg.in/yaml.v3"
+
+	"github.com/0xPolygonID/verifier-backend/internal/lanes"
 )
 
 // CallbackURL is the callback endpoint
@@ -21,13 +31,683 @@ type CacheTTL time.Duration
 type Config struct {
 	Host                 string   `envconfig:"host" default:"http://localhost"`
 	ApiPort              string   `envconfig:"port" default:"3009"`
+	GRPCPort             string   `envconfig:"grpc_port"`
 	KeyDIR               string   `envconfig:"keydir" default:"./keys"`
 	IPFSURL              string   `envconfig:"ipfs_url" default:"https://gateway.pinata.cloud"`
+	IPFSGateways         []string `envconfig:"ipfs_gateways"`
+	IPFSNodeURL          string   `envconfig:"ipfs_node_url"`
 	ResolverSettingsPath string   `envconfig:"resolver_settings_path" default:"./resolvers_settings.yaml"`
 	CacheExpiration      CacheTTL `envconfig:"cache_expiration" default:"48h"`
-	ResolverSettings     ResolverSettings
+	RHSURL               string   `envconfig:"rhs_url"`
+	APIKeys              []string `envconfig:"api_keys"`
+	AdminAPIKeys         []string `envconfig:"admin_api_keys"`
+	IssuerPolicyPath     string   `envconfig:"issuer_policy_path"`
+	SignInBatchMaxSize   int      `envconfig:"sign_in_batch_max_size" default:"1000"`
+	UniversalLinkURL     string   `envconfig:"universal_link_url" default:"https://wallet.privado.id"`
+	SigningKeyPath       string   `envconfig:"signing_key_path"`
+	TenantsPath          string   `envconfig:"tenants_path"`
+	TrustProfilesPath    string   `envconfig:"trust_profiles_path"`
+	QueryTemplatesPath   string   `envconfig:"query_templates_path"`
+	CredentialOffersPath string   `envconfig:"credential_offers_path"`
+	FlowsPath            string   `envconfig:"flows_path"`
+	// ReasonTemplatesPath is a yaml file of reason templates, the API can add or replace them at runtime
+	ReasonTemplatesPath string `envconfig:"reason_templates_path"`
+	// SigningKey is the PEM encoded key of the verifier, used instead of SigningKeyPath when it is set
+	SigningKey string `envconfig:"signing_key"`
+	// SigningPreviousKeyPaths are the retired keys of the verifier, still published so the tokens they signed can be verified
+	SigningPreviousKeyPaths []string `envconfig:"signing_previous_key_paths"`
+	// PublicURL is the URL the wallets reach the verifier at, the callbacks and the QR code links are built on it instead of
+	// Host when the verifier binds to an internal address, e.g. behind a tunnel
+	PublicURL string `envconfig:"public_url"`
+	// AllowedPublicURLs restricts the public URLs the sign-in requests can set, any http or https URL when it is empty
+	AllowedPublicURLs []string `envconfig:"allowed_public_urls"`
+	// TrustedProxies are the addresses or CIDR ranges of the reverse proxies whose Forwarded and X-Forwarded-* headers
+	// set the URL the links are built on when PublicURL is not set, the headers of the other clients are ignored
+	TrustedProxies []string `envconfig:"trusted_proxies"`
+	// DefaultReason is the reason of the requests whose sign-in does not set one
+	DefaultReason string `envconfig:"default_reason" default:"for testing purposes"`
+	// ConfirmationPollInterval is how often the confirmations of the states of provisional verifications are checked
+	ConfirmationPollInterval CacheTTL `envconfig:"confirmation_poll_interval" default:"15s"`
+	SessionTTL               CacheTTL `envconfig:"session_ttl"`
+	RequestValidity          CacheTTL `envconfig:"request_validity"`
+	QueryLint                bool     `envconfig:"query_lint" default:"false"`
+	VerificationConcurrency  int      `envconfig:"verification_concurrency"`
+	VerificationQueueSize    int      `envconfig:"verification_queue_size" default:"1000"`
+	VerificationAsync        bool     `envconfig:"verification_async" default:"false"`
+	ReadOnly                 bool     `envconfig:"read_only" default:"false"`
+	RevocationAllowedHosts   []string `envconfig:"revocation_allowed_hosts"`
+	LogFormat                string   `envconfig:"log_format" default:"text"`
+	Sandbox                  Sandbox
+	SMTP                     SMTP
+	Shadow                   Shadow
+	Nullifiers               Nullifiers
+	JWT                      JWT
+	OIDC                     OIDC
+	SenderDID                SenderDID         `envconfig:"sender_did"`
+	DIDDocument              DIDDocument       `envconfig:"did_document"`
+	QRStore                  QRStore           `envconfig:"qr_store"`
+	VerificationKeys         VerificationKeys  `envconfig:"verification_keys"`
+	DocumentCache            DocumentCache     `envconfig:"document_cache"`
+	SchemaAllowlist          SchemaAllowlist   `envconfig:"schema_allowlist"`
+	DocumentFetch            DocumentFetch     `envconfig:"document_fetch"`
+	QRLink                   QRLink            `envconfig:"qr_link"`
+	TestMode                 TestMode          `envconfig:"test_mode"`
+	DIDResolver              DIDResolver       `envconfig:"did_resolver"`
+	Expiration               Expiration        `envconfig:"credential_expiration"`
+	Stats                    Stats             `envconfig:"stats"`
+	SessionWebhook           SessionWebhook    `envconfig:"session_webhook"`
+	SigningKMS               SigningKMS        `envconfig:"signing_kms"`
+	SigningVault             SigningVault      `envconfig:"signing_vault"`
+	Keystore                 Keystore          `envconfig:"keystore"`
+	Events                   Events            `envconfig:"events"`
+	SessionLedger            SessionLedger     `envconfig:"session_ledger"`
+	UserRegistry             UserRegistry      `envconfig:"user_registry"`
+	AuditLog                 AuditLog          `envconfig:"audit_log"`
+	VerificationHooks        VerificationHooks `envconfig:"verification_hooks"`
+	Limits                   Limits            `envconfig:"limits"`
+	Reverification           Reverification    `envconfig:"reverification"`
+	StateResolver            StateResolver     `envconfig:"state_resolver"`
+	StateSnapshot            StateSnapshot     `envconfig:"state_snapshot"`
+	SignInLink               SignInLink        `envconfig:"sign_in_link"`
+	ManagementAuth           ManagementAuth    `envconfig:"management_auth"`
+	Roles                    Roles             `envconfig:"roles"`
+	CORS                     CORS              `envconfig:"cors"`
+	SessionStore             SessionStore      `envconfig:"session_store"`
+	WalletLinks              WalletLinks       `envconfig:"wallet_links"`
+	Widget                   Widget            `envconfig:"widget"`
+	ResolverSettings         ResolverSettings
+	IssuerPolicy             IssuerPolicy      `ignored:"true"`
+	Tenants                  []Tenant          `ignored:"true"`
+	TrustProfiles            []TrustProfile    `ignored:"true"`
+	CredentialOffers         []CredentialOffer `ignored:"true"`
+	Flows                    []Flow            `ignored:"true"`
+	ReasonTemplates          []ReasonTemplate  `ignored:"true"`
+}
+
+// Tenant is an integrator with its own api keys and signing key. Priority is the priority class of the verifications
+// of its sessions when the verification concurrency is limited.
+type Tenant struct {
+	ID             string   `yaml:"id"`
+	APIKeys        []string `yaml:"apiKeys"`
+	SigningKeyPath string   `yaml:"signingKeyPath"`
+	KMSKeyID       string   `yaml:"kmsKeyID"`
+	Priority       string   `yaml:"priority"`
+}
+
+// SigningKMS is the AWS KMS key of the verifier, used instead of SigningKeyPath when KeyID is set.
+// The KMS keys of the tenants are read from the same region and endpoint.
+type SigningKMS struct {
+	KeyID    string `envconfig:"key_id"`
+	Region   string `envconfig:"region" default:"us-east-1"`
+	Endpoint string `envconfig:"endpoint"`
+}
+
+// SigningVault is the key of the verifier in the transit secrets engine of HashiCorp Vault, used instead of SigningKeyPath
+// when KeyName is set.
+type SigningVault struct {
+	Address string `envconfig:"address" default:"http://127.0.0.1:8200"`
+	Token   string `envconfig:"token"`
+	Mount   string `envconfig:"mount" default:"transit"`
+	KeyName string `envconfig:"key_name"`
+}
+
+// Keystore is the BJJ and ETH keys of the verifier identity, used to sign its requests and its relayer transactions.
+// A key is read from the first source set among the secp256k1 key of AWS KMS for the ETH key, in the region and at the
+// endpoint of SigningKMS, the bjj and eth fields of the VaultPath secret of the KV engine of the Vault of SigningVault,
+// the hex encoded private key of the environment and the file holding it.
+type Keystore struct {
+	BJJKey      string `envconfig:"bjj_key"`
+	BJJKeyPath  string `envconfig:"bjj_key_path"`
+	ETHKey      string `envconfig:"eth_key"`
+	ETHKeyPath  string `envconfig:"eth_key_path"`
+	ETHKMSKeyID string `envconfig:"eth_kms_key_id"`
+	VaultMount  string `envconfig:"vault_mount" default:"secret"`
+	VaultPath   string `envconfig:"vault_path"`
+}
+
+// Events drivers
+const (
+	EventsDriverNATS  = "nats"
+	EventsDriverKafka = "kafka"
+)
+
+// Events configures the broker the lifecycle events of the sessions are published to, they are not published when
+// Driver is empty. URL is the url of the NATS server or of the Kafka REST proxy. With NATS, Topic is the prefix of
+// the subjects of the events. At most QueueSize events wait to be published, the next ones are dropped.
+type Events struct {
+	Driver    string `envconfig:"driver"`
+	URL       string `envconfig:"url"`
+	Topic     string `envconfig:"topic" default:"verifier.sessions"`
+	QueueSize int    `envconfig:"queue_size" default:"1000"`
+}
+
+// SessionLedger keeps when the sessions expire for Retention after their expiration, so their status reports them as
+// expired once they are removed from the cache. The records are kept in the file at Path when it is set, in memory
+// otherwise, and the expired ones are removed every ReapInterval.
+type SessionLedger struct {
+	Path         string   `envconfig:"path"`
+	Retention    CacheTTL `envconfig:"retention" default:"168h"`
+	ReapInterval CacheTTL `envconfig:"reap_interval" default:"1h"`
+}
+
+// UserRegistry records the users of the successful verifications by DID, with when they verified, the credential types
+// and the nullifiers they proved. The records are kept in the file at Path when it is set, in memory otherwise.
+type UserRegistry struct {
+	Enabled bool   `envconfig:"enabled" default:"false"`
+	Path    string `envconfig:"path"`
+}
+
+// AuditLog records the verifications in an audit log that admins can export. The records are appended to the file at
+// Path when it is set, otherwise the last MemorySize records are kept in memory.
+type AuditLog struct {
+	Enabled    bool   `envconfig:"enabled" default:"false"`
+	Path       string `envconfig:"path"`
+	MemorySize int    `envconfig:"memory_size" default:"100000"`
+}
+
+// VerificationHooks are run on the callbacks whose proofs were verified, and can reject them before they are marked
+// successful. Names are the hooks registered at build time, run in order before the external hook at URL when it is set.
+// The requests to the external hook are signed with an HMAC-SHA256 of Secret when it is set.
+type VerificationHooks struct {
+	Names   []string `envconfig:"names"`
+	URL     string   `envconfig:"url"`
+	Secret  string   `envconfig:"secret"`
+	Timeout CacheTTL `envconfig:"timeout" default:"5s"`
+}
+
+// Limits bound the requests before they are processed. MaxBodySize is the size in bytes of the largest request body,
+// MaxTokenSize the size of the largest callback token and MaxScopes the number of proofs a callback token can carry.
+type Limits struct {
+	MaxBodySize  int64 `envconfig:"max_body_size" default:"1048576"`
+	MaxTokenSize int   `envconfig:"max_token_size" default:"524288"`
+	MaxScopes    int   `envconfig:"max_scopes" default:"32"`
+}
+
+// Reverification checks again the states of the proofs of the successful verifications After they were made, then
+// every Interval while they are kept in the cache, and reports the ones relying on replaced states as stale or revoked.
+type Reverification struct {
+	Enabled  bool     `envconfig:"enabled" default:"false"`
+	After    CacheTTL `envconfig:"after" default:"24h"`
+	Interval CacheTTL `envconfig:"interval" default:"1h"`
+}
+
+// StateResolver configures the resolution of the states by the state contracts. The latest states and global roots are
+// cached for CacheTTL, and the replaced ones, which never change, for ReplacedCacheTTL. Failed RPC calls are retried
+// Retries times on the other RPC urls of the network in turn, after RetryBackoff doubled on each retry with a random
+// jitter. An RPC url failing BreakerThreshold times in a row is skipped for BreakerCooldown.
+type StateResolver struct {
+	CacheTTL         CacheTTL `envconfig:"cache_ttl" default:"30s"`
+	ReplacedCacheTTL CacheTTL `envconfig:"replaced_cache_ttl" default:"24h"`
+	Retries          int      `envconfig:"retries" default:"2"`
+	RetryBackoff     CacheTTL `envconfig:"retry_backoff" default:"200ms"`
+	Timeout          CacheTTL `envconfig:"timeout" default:"10s"`
+	BreakerThreshold int      `envconfig:"breaker_threshold" default:"3"`
+	BreakerCooldown  CacheTTL `envconfig:"breaker_cooldown" default:"30s"`
+}
+
+// StateSnapshot configures the local snapshots of the states resolved on the networks. The states are answered from
+// the snapshot of their network, and the latest ones are synced from the state contract every Interval; a latest state
+// not synced for MaxAge is resolved again on the hot path. States unused for Retention are dropped. The snapshots are
+// persisted in Dir, one file per network, and kept in memory when it is empty.
+type StateSnapshot struct {
+	Enabled   bool     `envconfig:"enabled" default:"false"`
+	Interval  CacheTTL `envconfig:"interval" default:"1m"`
+	MaxAge    CacheTTL `envconfig:"max_age" default:"5m"`
+	Retention CacheTTL `envconfig:"retention" default:"24h"`
+	Dir       string   `envconfig:"dir"`
+}
+
+// IssuerPolicy holds the trusted issuers per credential type
+type IssuerPolicy struct {
+	Mode    string              `yaml:"mode"`
+	Issuers map[string][]string `yaml:"issuers"`
+}
+
+// Revocation policies of the trust profiles
+const (
+	RevocationPolicyOptional = "optional"
+	RevocationPolicyRequired = "required"
+)
+
+// TrustProfile is a named set of constraints of a trust framework, enforced on the sign-in requests that reference it
+// and on their callbacks. Empty fields do not constrain the requests.
+type TrustProfile struct {
+	Name           string               `yaml:"name"`
+	AllowedIssuers []string             `yaml:"allowedIssuers"`
+	Schemas        []TrustProfileSchema `yaml:"schemas"`
+	// Operators are the query operators allowed e.g. $eq or $lt, $sd allows selective disclosure
+	Operators   []string      `yaml:"operators"`
+	Revocation  string        `yaml:"revocation"`
+	MaxProofAge time.Duration `yaml:"maxProofAge"`
+}
+
+// CredentialOffer is a credential created in an issuer node for the users of the successful sessions, e.g. a KYC passed
+// credential. The string values of CredentialSubject are text/template templates of the session, with the fields
+// UserDID, SessionID and Metadata. The credentials expire after Expiration, or never when it is zero.
+type CredentialOffer struct {
+	Name              string         `yaml:"name"`
+	IssuerURL         string         `yaml:"issuerURL"`
+	IssuerDID         string         `yaml:"issuerDID"`
+	User              string         `yaml:"user"`
+	Password          string         `yaml:"password"`
+	CredentialSchema  string         `yaml:"credentialSchema"`
+	Type              string         `yaml:"type"`
+	CredentialSubject map[string]any `yaml:"credentialSubject"`
+	Expiration        time.Duration  `yaml:"expiration"`
+}
+
+// Flow is a named verification flow, a sign-in whose scopes, trust profile, TTL and webhook are kept server-side, so the
+// frontends only reference its name. Version is the revision of the flow, logged with its sessions.
+type Flow struct {
+	Name                   string      `yaml:"name"`
+	Version                string      `yaml:"version"`
+	ChainID                string      `yaml:"chainID"`
+	Reason                 string      `yaml:"reason"`
+	Scope                  []FlowScope `yaml:"scope"`
+	RequiredScopes         int         `yaml:"requiredScopes"`
+	EnforceUniqueNullifier bool        `yaml:"enforceUniqueNullifier"`
+	TrustProfile           string      `yaml:"trustProfile"`
+	// TTL is how long the off-chain sessions of the flow wait for their callback, the session ttl when it is zero
+	TTL     time.Duration `yaml:"ttl"`
+	Webhook FlowWebhook   `yaml:"webhook"`
+}
+
+// FlowScope is a scope of a flow, with the fields of the scopes of the sign-in requests
+type FlowScope struct {
+	ID        uint32         `yaml:"id"`
+	CircuitID string         `yaml:"circuitId"`
+	Template  string         `yaml:"template"`
+	Query     map[string]any `yaml:"query"`
+	Params    map[string]any `yaml:"params"`
+}
+
+// FlowWebhook receives the webhook events of the sessions of a flow instead of the session webhook
+type FlowWebhook struct {
+	URL    string `yaml:"url"`
+	Secret string `yaml:"secret"`
+}
+
+// ReasonTemplate is a named reason of the authorization requests shown by the wallets, with its translations by locale,
+// e.g. en or pt-BR. The reasons and messages are text/template templates of the variables of the sign-in, e.g. {{.company}}.
+type ReasonTemplate struct {
+	Name          string                     `yaml:"name"`
+	DefaultLocale string                     `yaml:"defaultLocale"`
+	Locales       map[string]LocalizedReason `yaml:"locales"`
+}
+
+// LocalizedReason is the reason of a reason template, and the optional message, in one locale
+type LocalizedReason struct {
+	Reason  string `yaml:"reason"`
+	Message string `yaml:"message"`
+}
+
+// TrustProfileSchema is a credential schema allowed by a trust profile. An empty context allows any context.
+type TrustProfileSchema struct {
+	Context string `yaml:"context"`
+	Type    string `yaml:"type"`
+}
+
+// Sandbox holds the configuration of the self-service sandbox API keys.
+// An email or an address can request at most RateLimit keys or verification codes per RateWindow.
+type Sandbox struct {
+	Enabled                 bool     `envconfig:"enabled" default:"false"`
+	KeyTTL                  CacheTTL `envconfig:"key_ttl" default:"72h"`
+	ChainIDs                []string `envconfig:"chain_ids" default:"80002"`
+	EmailVerification       bool     `envconfig:"email_verification" default:"false"`
+	MaxVerificationAttempts int      `envconfig:"max_verification_attempts" default:"5"`
+	RateLimit               int      `envconfig:"rate_limit" default:"5"`
+	RateWindow              CacheTTL `envconfig:"rate_window" default:"1h"`
+}
+
+// SignInLink limits the sessions created by the sign-in links: a client address can open at most RateLimit links per
+// RateWindow, so link scanners and crawlers cannot create sessions without limit.
+type SignInLink struct {
+	RateLimit  int      `envconfig:"rate_limit" default:"20"`
+	RateWindow CacheTTL `envconfig:"rate_window" default:"1m"`
+}
+
+// SMTP holds the configuration of the mail server used to send emails
+type SMTP struct {
+	Addr     string `envconfig:"addr"`
+	From     string `envconfig:"from"`
+	User     string `envconfig:"user"`
+	Password string `envconfig:"password"`
+}
+
+// Shadow holds the configuration of the shadow verifier used to validate a new verifier configuration.
+// Shadow verification is enabled when KeyDIR is set. The resolver settings of the main verifier are used
+// when ResolverSettingsPath is empty. At most MaxConcurrent callbacks are verified again at a time, the others are dropped.
+type Shadow struct {
+	KeyDIR               string           `envconfig:"keydir"`
+	ResolverSettingsPath string           `envconfig:"resolver_settings_path"`
+	MaxConcurrent        int              `envconfig:"max_concurrent" default:"4"`
+	ResolverSettings     ResolverSettings `ignored:"true"`
+}
+
+// Nullifiers holds the configuration of the nullifier registry.
+// RegistryPath is the file where the tree of the registry and its checkpoints are persisted, and StorePath the file
+// where the nullifiers used in the sessions that enforce unique nullifiers are persisted. They are kept in memory when empty.
+// Derivation names the derivation of the nullifier sessions of the campaigns, and Namespace isolates them from the
+// campaigns of the other deployments.
+type Nullifiers struct {
+	Enabled            bool     `envconfig:"enabled" default:"false"`
+	CheckpointInterval CacheTTL `envconfig:"checkpoint_interval" default:"1h"`
+	RegistryPath       string   `envconfig:"registry_path"`
+	StorePath          string   `envconfig:"store_path"`
+	Derivation         string   `envconfig:"derivation" default:"campaign"`
+	Namespace          string   `envconfig:"namespace"`
+}
+
+// JWT holds the configuration of the tokens issued after a successful verification.
+// Tokens are signed with the key of the session tenant, or with the verifier key. Issuer defaults to Host.
+type JWT struct {
+	Enabled  bool     `envconfig:"enabled" default:"false"`
+	Issuer   string   `envconfig:"issuer"`
+	Audience []string `envconfig:"audience"`
+	TTL      CacheTTL `envconfig:"ttl" default:"1h"`
+}
+
+// OIDC holds the configuration of the OpenID Connect provider. The provider is enabled when ClientsPath is set.
+type OIDC struct {
+	ClientsPath string       `envconfig:"clients_path"`
+	TokenTTL    CacheTTL     `envconfig:"token_ttl" default:"1h"`
+	CodeTTL     CacheTTL     `envconfig:"code_ttl" default:"5m"`
+	Clients     []OIDCClient `ignored:"true"`
+}
+
+// OIDCClient is an application that signs in its users with the OpenID Connect provider.
+// Users are verified with the query template of the client.
+type OIDCClient struct {
+	ID           string   `yaml:"id"`
+	Secret       string   `yaml:"secret"`
+	RedirectURIs []string `yaml:"redirectURIs"`
+	Template     string   `yaml:"template"`
+	ChainID      string   `yaml:"chainID"`
+}
+
+// Expiration configures the check of the credential expiration in the callbacks. Circuits check the expiration
+// at the time the proof was generated, so proofs older than MaxProofAge are rejected, as well as the proofs
+// disclosing one of Fields with a date in the past.
+type Expiration struct {
+	Enabled     bool     `envconfig:"enabled" default:"true"`
+	MaxProofAge CacheTTL `envconfig:"max_proof_age" default:"1h"`
+	Fields      []string `envconfig:"fields" default:"expirationDate"`
+}
+
+// DIDResolver configures the universal resolver used to resolve DIDs of any method.
+// When URL is set, the sender DIDs are resolved on startup to check that they exist.
+type DIDResolver struct {
+	URL      string   `envconfig:"url"`
+	CacheTTL CacheTTL `envconfig:"cache_ttl" default:"10m"`
+}
+
+// Log formats
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+// QR store drivers
+const (
+	QRStoreDriverMemory    = "memory"
+	QRStoreDriverRedis     = "redis"
+	QRStoreDriverMemcached = "memcached"
+)
+
+// QRStore configures the cache of the QR codes. The memory driver keeps them in the process,
+// the redis and memcached drivers share them across the replicas of the verifier.
+// EncryptionKeys are base64 encoded 32 bytes keys that encrypt the values of the shared stores, the first one
+// encrypts and all of them decrypt, so keys can be rotated.
+// ReadRetryWindow is how long a replica retries reading a QR code it does not find yet, so the QR codes saved by another
+// replica can be fetched while the store replicates them. It is 0 to not retry.
+type QRStore struct {
+	Driver          string   `envconfig:"driver" default:"memory"`
+	RedisAddr       string   `envconfig:"redis_addr"`
+	RedisPassword   string   `envconfig:"redis_password"`
+	RedisDB         int      `envconfig:"redis_db"`
+	MemcachedAddrs  []string `envconfig:"memcached_addrs"`
+	EncryptionKeys  []string `envconfig:"encryption_keys"`
+	ReadRetryWindow CacheTTL `envconfig:"read_retry_window" default:"200ms"`
+}
+
+// DataKeys decodes the encryption keys of the store
+func (s QRStore) DataKeys() ([][]byte, error) {
+	keys := make([][]byte, 0, len(s.EncryptionKeys))
+	for i, encoded := range s.EncryptionKeys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("qr store encryption key %d is not base64 encoded: %w", i, err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("qr store encryption key %d has %d bytes, expected 32", i, len(key))
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// VerificationKeys configures the loader of the verification keys of the circuits. Keys are read from KeyDIR
+// when Location is empty, or fetched from an http(s) URL or an s3://bucket/prefix URL and cached in CacheDir.
+// Checksums maps circuit ids to the hex encoded sha256 of their keys, keys that do not match are rejected.
+type VerificationKeys struct {
+	Location   string            `envconfig:"location"`
+	CacheDir   string            `envconfig:"cache_dir"`
+	Checksums  map[string]string `envconfig:"checksums"`
+	S3Region   string            `envconfig:"s3_region" default:"us-east-1"`
+	S3Endpoint string            `envconfig:"s3_endpoint"`
+}
+
+// DocumentCache configures the persistent cache of the JSON-LD contexts and schemas, in a directory,
+// an s3://bucket/prefix or a gs://bucket/prefix Location. Http documents are revalidated once they are older than TTL.
+// When Prewarm is set, the contexts of the query templates and trust profiles, and the Pinned ones, are fetched at startup
+// and kept in memory. The last MemorySize loaded documents are kept in memory for the MemoryTTLs of their url scheme,
+// until they are evicted for the schemes without a TTL, and the failed loads for NegativeTTL.
+type DocumentCache struct {
+	Location    string              `envconfig:"location"`
+	TTL         CacheTTL            `envconfig:"ttl" default:"24h"`
+	S3Region    string              `envconfig:"s3_region" default:"us-east-1"`
+	S3Endpoint  string              `envconfig:"s3_endpoint"`
+	Prewarm     bool                `envconfig:"prewarm" default:"false"`
+	Pinned      []string            `envconfig:"pinned"`
+	MemorySize  int                 `envconfig:"memory_size" default:"500"`
+	MemoryTTLs  map[string]CacheTTL `envconfig:"memory_ttls" default:"http:1h,https:1h"`
+	NegativeTTL CacheTTL            `envconfig:"negative_ttl" default:"1m"`
+}
+
+// MemoryTTLDurations returns the MemoryTTLs by url scheme as durations
+func (c DocumentCache) MemoryTTLDurations() map[string]time.Duration {
+	ttls := make(map[string]time.Duration, len(c.MemoryTTLs))
+	for scheme, ttl := range c.MemoryTTLs {
+		ttls[scheme] = ttl.AsDuration()
+	}
+	return ttls
+}
+
+// DocumentFetch guards the fetches of the JSON-LD documents against server side request forgery. Only the documents of
+// the url Schemes are loaded, the hosts that resolve to private, loopback, link-local or reserved addresses are refused
+// unless AllowPrivateNetworks, and a fetch follows at most MaxRedirects redirects and takes at most Timeout. HostsPath is
+// a yaml file of the headers, basic auth and timeouts of the requests to the schema hosts and the ipfs gateways.
+type DocumentFetch struct {
+	Schemes              []string       `envconfig:"schemes" default:"http,https,ipfs"`
+	AllowPrivateNetworks bool           `envconfig:"allow_private_networks" default:"false"`
+	MaxRedirects         int            `envconfig:"max_redirects" default:"3"`
+	Timeout              CacheTTL       `envconfig:"timeout" default:"30s"`
+	HostsPath            string         `envconfig:"hosts_path"`
+	Hosts                []DocumentHost `ignored:"true"`
+}
+
+// DocumentHost holds the settings of the requests to a schema host or an ipfs gateway, e.g. the token of a private
+// gateway or the basic auth of a private schema registry. Host is the host of the urls, with its port when it is not
+// the default one.
+type DocumentHost struct {
+	Host     string            `yaml:"host"`
+	Headers  map[string]string `yaml:"headers"`
+	Username string            `yaml:"username"`
+	Password string            `yaml:"password"`
+	Timeout  time.Duration     `yaml:"timeout"`
 }
 
+// SchemaAllowlist restricts the JSON-LD contexts and schemas accepted in the queries and loaded by the verifier to
+// Entries when Enabled. An entry is an http(s) or ipfs url, a url prefix ending with a slash, or an ipfs CID.
+type SchemaAllowlist struct {
+	Enabled bool     `envconfig:"enabled" default:"false"`
+	Entries []string `envconfig:"entries"`
+}
+
+// CORS sets the origins allowed to call the api from the browsers, every origin by default
+type CORS struct {
+	AllowedOrigins []string `envconfig:"allowed_origins" default:"*"`
+}
+
+// SessionStore bounds the in-memory store of the sessions to MaxEntries entries, evicting the least recently used ones
+// before they expire, or leaves it unbounded when MaxEntries is not positive
+type SessionStore struct {
+	MaxEntries int `envconfig:"max_entries" default:"100000"`
+}
+
+// ManagementAuth hardens the management endpoints, the /admin ones, beyond the admin api keys. With HMACSecrets, their
+// requests must carry a timestamp and an HMAC-SHA256 signature of the request signed with one of the secrets, the
+// timestamps older or newer than ReplayWindow are refused and a signature is accepted once. With TLSCertPath and
+// TLSKeyPath the api is served over TLS, and with ClientCAPath the management requests must present a client
+// certificate issued by one of the certificate authorities of the file.
+type ManagementAuth struct {
+	HMACSecrets  []string `envconfig:"hmac_secrets"`
+	ReplayWindow CacheTTL `envconfig:"replay_window" default:"5m"`
+	TLSCertPath  string   `envconfig:"tls_cert_path"`
+	TLSKeyPath   string   `envconfig:"tls_key_path"`
+	ClientCAPath string   `envconfig:"client_ca_path"`
+}
+
+// Roles grants the roles of the management endpoints besides the AdminAPIKeys, which have the admin role. The read-only
+// role can only read, the operator role can also reload the circuits, prewarm the schemas and change the templates, and
+// the admin role can also change the issuer policy and the schema allowlist. Roles are granted to api keys, and to the
+// DIDs of the users signed in with the OpenID Connect provider of the verifier, who send the ID tokens issued to one of
+// OIDCClients as bearer tokens.
+type Roles struct {
+	OperatorAPIKeys []string `envconfig:"operator_api_keys"`
+	ReadOnlyAPIKeys []string `envconfig:"read_only_api_keys"`
+	AdminDIDs       []string `envconfig:"admin_dids"`
+	OperatorDIDs    []string `envconfig:"operator_dids"`
+	ReadOnlyDIDs    []string `envconfig:"read_only_dids"`
+	OIDCClients     []string `envconfig:"oidc_clients"`
+}
+
+// Stats configures the persistence of the daily verification statistics in a directory, an s3://bucket/prefix
+// or a gs://bucket/prefix Location. The statistics are only kept in memory when Location is empty, and are flushed
+// to Location every FlushInterval.
+type Stats struct {
+	Location      string   `envconfig:"location"`
+	FlushInterval CacheTTL `envconfig:"flush_interval" default:"1m"`
+	S3Region      string   `envconfig:"s3_region" default:"us-east-1"`
+	S3Endpoint    string   `envconfig:"s3_endpoint"`
+}
+
+// SessionWebhook is the endpoint of the integrator notified of the sessions that expire without a callback.
+// The events are signed with an HMAC-SHA256 of Secret when it is set.
+// The events of the session and flow webhooks are sent up to MaxAttempts times, waiting Backoff after the first failed
+// attempt and twice as long after every other one, up to MaxBackoff. The events that fail every attempt are kept in
+// the last DeadLetterSize dead letters, persisted to DeadLetterPath when it is set, until they are replayed.
+type SessionWebhook struct {
+	URL            string   `envconfig:"url"`
+	Secret         string   `envconfig:"secret"`
+	Timeout        CacheTTL `envconfig:"timeout" default:"10s"`
+	MaxAttempts    int      `envconfig:"max_attempts" default:"5"`
+	Backoff        CacheTTL `envconfig:"backoff" default:"1s"`
+	MaxBackoff     CacheTTL `envconfig:"max_backoff" default:"5m"`
+	DeadLetterPath string   `envconfig:"dead_letter_path"`
+	DeadLetterSize int      `envconfig:"dead_letter_size" default:"10000"`
+}
+
+// TestMode configures the mock verifier of the integration tests and staging environments. When it is enabled, the callbacks
+// with Token are accepted without checking their proofs, as the answers of UserDID with credentials of IssuerDID.
+// It must never be enabled in production.
+type TestMode struct {
+	Enabled   bool   `envconfig:"enabled" default:"false"`
+	Token     string `envconfig:"token"`
+	UserDID   string `envconfig:"user_did" default:"did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"`
+	IssuerDID string `envconfig:"issuer_did" default:"did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK"`
+}
+
+// QRLink configures the links to the QR codes. The ids of the links are signed with Secret, a random secret is used when it is empty.
+// Links are built on BaseURL, the public URL of the verifier followed by /qr-store by default, and shortened with the
+// service of ShortenerURL when it is set.
+type QRLink struct {
+	Secret       string `envconfig:"secret"`
+	BaseURL      string `envconfig:"base_url"`
+	ShortenerURL string `envconfig:"shortener_url"`
+}
+
+// WalletLinks configures the wallet links of the sign-in responses: the deep links of the DeepLinkScheme of the wallet
+// apps, and the universal links on UniversalLinkURLs, the universal link URL when it is empty
+type WalletLinks struct {
+	DeepLinkScheme    string   `envconfig:"deep_link_scheme" default:"polygonid"`
+	UniversalLinkURLs []string `envconfig:"universal_link_urls"`
+}
+
+// Widget configures the hosted verification page of the flows, to embed in an iframe: its branding, the FrameAncestors
+// origins that can embed it and receive its result, the origin of the verifier only when it is empty, and the APIKey
+// its sessions are created with, required when api keys or tenants are configured. Every page served creates a session,
+// so a client address can load at most RateLimit pages per RateWindow.
+type Widget struct {
+	Title          string   `envconfig:"title" default:"Verify with your wallet"`
+	LogoURL        string   `envconfig:"logo_url"`
+	PrimaryColor   string   `envconfig:"primary_color" default:"#6c47ff"`
+	FrameAncestors []string `envconfig:"frame_ancestors"`
+	APIKey         string   `envconfig:"api_key"`
+	RateLimit      int      `envconfig:"rate_limit" default:"20"`
+	RateWindow     CacheTTL `envconfig:"rate_window" default:"1m"`
+}
+
+// DIDDocument publishes the DID document of the did:web identity of the verifier at /.well-known/did.json. The DID is
+// built on Domain, the host of Host by default. When Sender is set, the off-chain requests are sent from the did:web
+// identity instead of the DIDs of the networks. PushURL is the push service endpoint of the document, if any.
+type DIDDocument struct {
+	Enabled bool   `envconfig:"enabled" default:"false"`
+	Domain  string `envconfig:"domain"`
+	Sender  bool   `envconfig:"sender" default:"false"`
+	PushURL string `envconfig:"push_url"`
+}
+
+// WebDID returns the did:web identity of the verifier. The port of the domain is percent-encoded, as did:web requires.
+func (d DIDDocument) WebDID(host string) (string, error) {
+	domain := d.Domain
+	if domain == "" {
+		u, err := url.Parse(host)
+		if err != nil {
+			return "", err
+		}
+		domain = u.Host
+	}
+	if domain == "" {
+		return "", errors.New("did document domain is empty")
+	}
+	return "did:web:" + strings.ReplaceAll(domain, ":", "%3A"), nil
+}
+
+// Sender DID fallbacks used on chains without a DID in the resolver settings
+const (
+	SenderDIDFallbackNone    = "none"
+	SenderDIDFallbackDefault = "default"
+	SenderDIDFallbackDerive  = "derive"
+)
+
+// SenderDID configures the sender of the requests on chains without a DID in the resolver settings.
+// With the default fallback DefaultDID is used, with the derive fallback a DID of the chain is derived from EthAddress.
+// With CheckState, the DIDs of the resolver settings must have published a state on the state contract of their network.
+type SenderDID struct {
+	Fallback   string `envconfig:"fallback" default:"none"`
+	DefaultDID string `envconfig:"default_did"`
+	EthAddress string `envconfig:"eth_address"`
+	DIDMethod  string `envconfig:"did_method" default:"iden3"`
+	CheckState bool   `envconfig:"check_state" default:"false"`
+}
+
+// DID methods known by the verifier without a method byte
+const (
+	DIDMethodIden3     = "iden3"
+	DIDMethodPolygonID = "polygonid"
+)
+
 // ResolverSettings holds the resolver settings
 type ResolverSettings map[string]map[string]ResolverSettingsAttrs
 
@@ -38,24 +718,331 @@ type ResolverSettingsAttrs struct {
 	ChainID         string `yaml:"chainID"`
 	NetworkFlag     byte   `yaml:"networkFlag"`
 	DID             string `yaml:"did"`
-	Method          string `yaml:"method"`
+	// Method is the DID method of the network, polygonid by default. Methods other than iden3 and polygonid need a MethodByte.
+	Method     string `yaml:"method"`
+	MethodByte *byte  `yaml:"methodByte"`
+	// Confirmations is the number of blocks required on top of the state transitions verifications rely on, 0 disables the check
+	Confirmations uint64 `yaml:"confirmations"`
+	// ConfirmationMode is what happens to verifications relying on states with fewer confirmations, reject by default
+	ConfirmationMode string `yaml:"confirmationMode"`
+	// VerifierContract and VerifierMethodID are the verifier contract preset of the on-chain sign-ins of the network,
+	// e.g. the Universal Verifier, used when the sign-ins do not set their contractAddress and methodID
+	VerifierContract string `yaml:"verifierContract"`
+	VerifierMethodID string `yaml:"verifierMethodID"`
+	// NetworkURLs are more RPC urls of the network, used with NetworkURL in the order of RPCSelection
+	NetworkURLs []string `yaml:"networkURLs"`
+	// RPCSelection is the order the RPC urls are tried in, failover by default
+	RPCSelection string `yaml:"rpcSelection"`
 }
 
+// Confirmation modes of the resolver settings
+const (
+	// ConfirmationModeReject fails the verification
+	ConfirmationModeReject = "reject"
+	// ConfirmationModeProvisional accepts the verification as provisional until the states are confirmed
+	ConfirmationModeProvisional = "provisional"
+)
+
+// RPC selections of the resolver settings
+const (
+	// RPCSelectionFailover tries NetworkURL first, then NetworkURLs in their order
+	RPCSelectionFailover = "failover"
+	// RPCSelectionLatency tries the RPC urls with the lowest average latency first
+	RPCSelectionLatency = "latency"
+)
+
 // Load loads the configuration from the environment
 func Load() (*Config, error) {
 	conf := &Config{}
-	if err := envconfig.Process("VERIFIER_BACKEND", conf); err != nil {
+	if err := envconfig.Process(envPrefix, conf); err != nil {
 		return nil, err
 	}
-	rs, err := parseResolversSettings(conf.ResolverSettingsPath)
-	if err != nil {
-		log.Error("failed to parse resolvers settings")
+	var fileResolvers ResolverSettings
+	if path := os.Getenv(FileEnv); path != "" {
+		var err error
+		if fileResolvers, err = loadFile(path, conf); err != nil {
+			return nil, fmt.Errorf("invalid configuration file %s: %w", path, err)
+		}
+	}
+	if err := validateSenderDID(conf.SenderDID); err != nil {
+		return nil, err
+	}
+	if err := validateDIDDocument(conf.DIDDocument, conf.Host); err != nil {
 		return nil, err
 	}
-	conf.ResolverSettings = rs
+	if conf.PublicURL != "" {
+		if err := ValidatePublicURL(conf.PublicURL); err != nil {
+			return nil, fmt.Errorf("invalid public url: %w", err)
+		}
+	}
+	if _, err := ParseTrustedProxies(conf.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	if err := validateQRStore(conf.QRStore); err != nil {
+		return nil, err
+	}
+	if err := validateEvents(conf.Events); err != nil {
+		return nil, err
+	}
+	if conf.SessionLedger.ReapInterval <= 0 {
+		return nil, errors.New("session ledger reap interval must be positive")
+	}
+	if conf.SessionWebhook.MaxAttempts < 1 {
+		return nil, errors.New("session webhook max attempts must be at least 1")
+	}
+	if err := validateManagementAuth(conf.ManagementAuth); err != nil {
+		return nil, err
+	}
+	if conf.LogFormat != LogFormatJSON && conf.LogFormat != LogFormatText {
+		return nil, fmt.Errorf("invalid log format %s, expected %s or %s", conf.LogFormat, LogFormatJSON, LogFormatText)
+	}
+	setSessionTTL(conf)
+	if conf.TestMode.Enabled && conf.TestMode.Token == "" {
+		return nil, errors.New("test mode requires a canned token")
+	}
+	if conf.ReadOnly && conf.QRStore.Driver == QRStoreDriverMemory {
+		return nil, errors.New("read-only replicas require a qr store shared with the verifiers")
+	}
+	if conf.QRStore.Driver != QRStoreDriverMemory && conf.QRLink.Secret == "" {
+		return nil, errors.New("qr link secret is required to share the qr store across replicas")
+	}
+	if fileResolvers != nil {
+		conf.ResolverSettings = fileResolvers
+	} else {
+		rs, err := parseResolversSettings(conf.ResolverSettingsPath)
+		if err != nil {
+			log.Error("failed to parse resolvers settings")
+			return nil, err
+		}
+		conf.ResolverSettings = rs
+	}
+
+	if conf.Shadow.ResolverSettingsPath != "" {
+		srs, err := parseResolversSettings(conf.Shadow.ResolverSettingsPath)
+		if err != nil {
+			log.Error("failed to parse shadow resolvers settings")
+			return nil, err
+		}
+		conf.Shadow.ResolverSettings = srs
+	}
+
+	if conf.IssuerPolicyPath != "" {
+		ip, err := parseIssuerPolicy(conf.IssuerPolicyPath)
+		if err != nil {
+			log.Error("failed to parse issuer policy")
+			return nil, err
+		}
+		conf.IssuerPolicy = ip
+	}
+	if conf.TenantsPath != "" {
+		tenants, err := parseTenants(conf.TenantsPath)
+		if err != nil {
+			log.Error("failed to parse tenants")
+			return nil, err
+		}
+		conf.Tenants = tenants
+	}
+	if conf.TrustProfilesPath != "" {
+		profiles, err := parseTrustProfiles(conf.TrustProfilesPath)
+		if err != nil {
+			log.Error("failed to parse trust profiles")
+			return nil, err
+		}
+		conf.TrustProfiles = profiles
+	}
+	if conf.DocumentFetch.HostsPath != "" {
+		hosts, err := parseDocumentHosts(conf.DocumentFetch.HostsPath)
+		if err != nil {
+			log.Error("failed to parse document hosts")
+			return nil, err
+		}
+		conf.DocumentFetch.Hosts = hosts
+	}
+	if conf.CredentialOffersPath != "" {
+		offers, err := parseCredentialOffers(conf.CredentialOffersPath)
+		if err != nil {
+			log.Error("failed to parse credential offers")
+			return nil, err
+		}
+		conf.CredentialOffers = offers
+	}
+	if conf.FlowsPath != "" {
+		flows, err := parseFlows(conf.FlowsPath, conf.TrustProfiles, conf.CacheExpiration.AsDuration())
+		if err != nil {
+			log.Error("failed to parse flows")
+			return nil, err
+		}
+		conf.Flows = flows
+	}
+	if conf.ReasonTemplatesPath != "" {
+		templates, err := parseReasonTemplates(conf.ReasonTemplatesPath)
+		if err != nil {
+			log.Error("failed to parse reason templates")
+			return nil, err
+		}
+		conf.ReasonTemplates = templates
+	}
+	if conf.OIDC.ClientsPath != "" {
+		clients, err := parseOIDCClients(conf.OIDC.ClientsPath)
+		if err != nil {
+			log.Error("failed to parse oidc clients")
+			return nil, err
+		}
+		conf.OIDC.Clients = clients
+	}
 	return conf, nil
 }
 
+// setSessionTTL defaults the session ttl to half of the cache expiration, and shortens a session ttl that is not shorter
+// than the cache expiration, so the expired sessions are still reported before they are removed from the cache
+func setSessionTTL(conf *Config) {
+	half := conf.CacheExpiration / 2
+	if conf.SessionTTL == 0 {
+		conf.SessionTTL = half
+		return
+	}
+	if conf.SessionTTL >= conf.CacheExpiration {
+		log.WithFields(log.Fields{
+			"sessionTTL":      conf.SessionTTL.AsDuration(),
+			"cacheExpiration": conf.CacheExpiration.AsDuration(),
+		}).Warn("session ttl is not shorter than the cache expiration, using half of the cache expiration")
+		conf.SessionTTL = half
+	}
+}
+
+func validateQRStore(cfg QRStore) error {
+	switch cfg.Driver {
+	case QRStoreDriverMemory:
+	case QRStoreDriverRedis:
+		if cfg.RedisAddr == "" {
+			return errors.New("redis qr store requires a redis address")
+		}
+	case QRStoreDriverMemcached:
+		if len(cfg.MemcachedAddrs) == 0 {
+			return errors.New("memcached qr store requires at least one memcached address")
+		}
+	default:
+		return fmt.Errorf("invalid qr store driver %s, expected %s, %s or %s",
+			cfg.Driver, QRStoreDriverMemory, QRStoreDriverRedis, QRStoreDriverMemcached)
+	}
+	if cfg.ReadRetryWindow < 0 {
+		return errors.New("qr store read retry window must not be negative")
+	}
+	_, err := cfg.DataKeys()
+	return err
+}
+
+func validateEvents(cfg Events) error {
+	switch cfg.Driver {
+	case "":
+		return nil
+	case EventsDriverNATS, EventsDriverKafka:
+	default:
+		return fmt.Errorf("invalid events driver %s, expected %s or %s", cfg.Driver, EventsDriverNATS, EventsDriverKafka)
+	}
+	if cfg.URL == "" {
+		return fmt.Errorf("%s events driver requires a url", cfg.Driver)
+	}
+	if cfg.Topic == "" {
+		return errors.New("events topic is empty")
+	}
+	return nil
+}
+
+func validateManagementAuth(cfg ManagementAuth) error {
+	for _, secret := range cfg.HMACSecrets {
+		if secret == "" {
+			return errors.New("management hmac secrets cannot be empty")
+		}
+	}
+	if len(cfg.HMACSecrets) > 0 && cfg.ReplayWindow <= 0 {
+		return errors.New("management replay window must be positive")
+	}
+	if (cfg.TLSCertPath == "") != (cfg.TLSKeyPath == "") {
+		return errors.New("management tls requires both a certificate and a key")
+	}
+	if cfg.ClientCAPath != "" && cfg.TLSCertPath == "" {
+		// the requests over plain http carry no client certificate, so every management request would be refused
+		return fmt.Errorf("%s_MANAGEMENT_AUTH_CLIENT_CA_PATH requires the api to be served over tls, set %s_MANAGEMENT_AUTH_TLS_CERT_PATH and %s_MANAGEMENT_AUTH_TLS_KEY_PATH",
+			envPrefix, envPrefix, envPrefix)
+	}
+	return nil
+}
+
+func validateDIDDocument(cfg DIDDocument, host string) error {
+	if !cfg.Enabled {
+		if cfg.Sender {
+			return errors.New("did:web sender requires the did document")
+		}
+		return nil
+	}
+	_, err := cfg.WebDID(host)
+	return err
+}
+
+// WalletURL returns the URL the wallets reach the verifier at, PublicURL or Host, without trailing slash
+func (c Config) WalletURL() string {
+	if c.PublicURL != "" {
+		return strings.TrimSuffix(c.PublicURL, "/")
+	}
+	return strings.TrimSuffix(c.Host, "/")
+}
+
+// ValidatePublicURL checks that u is an absolute http or https URL the wallets can reach the verifier at
+func ValidatePublicURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return err
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%s is not an absolute http or https url", u)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return fmt.Errorf("%s cannot have a query or a fragment", u)
+	}
+	return nil
+}
+
+// ParseTrustedProxies parses the addresses and CIDR ranges of the trusted proxies, an address is a range of one address
+func ParseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if ip := net.ParseIP(proxy); ip != nil {
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+			}
+			bits := 8 * len(ip)
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("%s is neither an address nor a CIDR range", proxy)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+func validateSenderDID(cfg SenderDID) error {
+	switch cfg.Fallback {
+	case SenderDIDFallbackNone:
+	case SenderDIDFallbackDefault:
+		if cfg.DefaultDID == "" {
+			return errors.New("sender did default fallback requires a default did")
+		}
+	case SenderDIDFallbackDerive:
+		if !common.IsHexAddress(cfg.EthAddress) {
+			return errors.New("sender did derive fallback requires a valid eth address")
+		}
+	default:
+		return fmt.Errorf("invalid sender did fallback %s, expected %s, %s or %s",
+			cfg.Fallback, SenderDIDFallbackNone, SenderDIDFallbackDefault, SenderDIDFallbackDerive)
+	}
+	return nil
+}
+
 func parseResolversSettings(resolverSettingsPath string) (ResolverSettings, error) {
 	f, err := os.Open(filepath.Clean(resolverSettingsPath))
 	if err != nil {
@@ -71,7 +1058,372 @@ func parseResolversSettings(resolverSettingsPath string) (ResolverSettings, erro
 	if err := yaml.NewDecoder(f).Decode(&settings); err != nil {
 		return nil, fmt.Errorf("invalid yaml file: %v", settings)
 	}
-	return settings, nil
+	return settings, normalizeResolverSettings(settings)
+}
+
+// normalizeResolverSettings sets the defaults of the resolver settings and validates them
+func normalizeResolverSettings(settings ResolverSettings) error {
+	for chainName, chainSettings := range settings {
+		for networkName, attrs := range chainSettings {
+			switch attrs.Method {
+			case "":
+				attrs.Method = DIDMethodPolygonID
+			case DIDMethodIden3, DIDMethodPolygonID:
+			default:
+				if attrs.MethodByte == nil {
+					return fmt.Errorf("%s:%s: did method %s requires a methodByte", chainName, networkName, attrs.Method)
+				}
+			}
+			switch attrs.ConfirmationMode {
+			case "":
+				attrs.ConfirmationMode = ConfirmationModeReject
+			case ConfirmationModeReject, ConfirmationModeProvisional:
+			default:
+				return fmt.Errorf("%s:%s: invalid confirmation mode %s, must be %s or %s", chainName, networkName,
+					attrs.ConfirmationMode, ConfirmationModeReject, ConfirmationModeProvisional)
+			}
+			switch attrs.RPCSelection {
+			case "":
+				attrs.RPCSelection = RPCSelectionFailover
+			case RPCSelectionFailover, RPCSelectionLatency:
+			default:
+				return fmt.Errorf("%s:%s: invalid rpc selection %s, must be %s or %s", chainName, networkName,
+					attrs.RPCSelection, RPCSelectionFailover, RPCSelectionLatency)
+			}
+			chainSettings[networkName] = attrs
+		}
+	}
+	return nil
+}
+
+func parseIssuerPolicy(issuerPolicyPath string) (IssuerPolicy, error) {
+	f, err := os.Open(filepath.Clean(issuerPolicyPath))
+	if err != nil {
+		return IssuerPolicy{}, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Println("failed to close issuer policy file:", err)
+		}
+	}()
+
+	policy := IssuerPolicy{}
+	if err := yaml.NewDecoder(f).Decode(&policy); err != nil {
+		return IssuerPolicy{}, fmt.Errorf("invalid issuer policy yaml file: %w", err)
+	}
+	return policy, nil
+}
+
+func parseTenants(tenantsPath string) ([]Tenant, error) {
+	f, err := os.Open(filepath.Clean(tenantsPath))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Println("failed to close tenants file:", err)
+		}
+	}()
+
+	var tenants struct {
+		Tenants []Tenant `yaml:"tenants"`
+	}
+	if err := yaml.NewDecoder(f).Decode(&tenants); err != nil {
+		return nil, fmt.Errorf("invalid tenants yaml file: %w", err)
+	}
+
+	ids := make(map[string]bool, len(tenants.Tenants))
+	for _, tenant := range tenants.Tenants {
+		if tenant.ID == "" {
+			return nil, errors.New("tenant id is empty")
+		}
+		if ids[tenant.ID] {
+			return nil, fmt.Errorf("tenant %s is defined more than once", tenant.ID)
+		}
+		if _, err := lanes.ParseClass(tenant.Priority); err != nil {
+			return nil, fmt.Errorf("tenant %s: %w", tenant.ID, err)
+		}
+		ids[tenant.ID] = true
+	}
+	return tenants.Tenants, nil
+}
+
+func parseTrustProfiles(profilesPath string) ([]TrustProfile, error) {
+	f, err := os.Open(filepath.Clean(profilesPath))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Println("failed to close trust profiles file:", err)
+		}
+	}()
+
+	var profiles struct {
+		Profiles []TrustProfile `yaml:"profiles"`
+	}
+	if err := yaml.NewDecoder(f).Decode(&profiles); err != nil {
+		return nil, fmt.Errorf("invalid trust profiles yaml file: %w", err)
+	}
+
+	names := make(map[string]bool, len(profiles.Profiles))
+	for i, profile := range profiles.Profiles {
+		switch {
+		case profile.Name == "":
+			return nil, errors.New("trust profile name is empty")
+		case names[profile.Name]:
+			return nil, fmt.Errorf("trust profile %s is defined more than once", profile.Name)
+		case profile.MaxProofAge < 0:
+			return nil, fmt.Errorf("trust profile %s has a negative maxProofAge", profile.Name)
+		}
+		switch profile.Revocation {
+		case "":
+			profiles.Profiles[i].Revocation = RevocationPolicyOptional
+		case RevocationPolicyOptional, RevocationPolicyRequired:
+		default:
+			return nil, fmt.Errorf("trust profile %s has an invalid revocation policy %s, expected %s or %s",
+				profile.Name, profile.Revocation, RevocationPolicyOptional, RevocationPolicyRequired)
+		}
+		for _, schema := range profile.Schemas {
+			if schema.Type == "" {
+				return nil, fmt.Errorf("trust profile %s has a schema without type", profile.Name)
+			}
+		}
+		names[profile.Name] = true
+	}
+	return profiles.Profiles, nil
+}
+
+func parseCredentialOffers(offersPath string) ([]CredentialOffer, error) {
+	f, err := os.Open(filepath.Clean(offersPath))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Println("failed to close credential offers file:", err)
+		}
+	}()
+
+	var offers struct {
+		Offers []CredentialOffer `yaml:"offers"`
+	}
+	if err := yaml.NewDecoder(f).Decode(&offers); err != nil {
+		return nil, fmt.Errorf("invalid credential offers yaml file: %w", err)
+	}
+
+	names := make(map[string]bool, len(offers.Offers))
+	for _, offer := range offers.Offers {
+		switch {
+		case offer.Name == "":
+			return nil, errors.New("credential offer name is empty")
+		case names[offer.Name]:
+			return nil, fmt.Errorf("credential offer %s is defined more than once", offer.Name)
+		case offer.IssuerURL == "" || offer.IssuerDID == "":
+			return nil, fmt.Errorf("credential offer %s must have an issuerURL and an issuerDID", offer.Name)
+		case offer.CredentialSchema == "" || offer.Type == "":
+			return nil, fmt.Errorf("credential offer %s must have a credentialSchema and a type", offer.Name)
+		case offer.Expiration < 0:
+			return nil, fmt.Errorf("credential offer %s has a negative expiration", offer.Name)
+		}
+		names[offer.Name] = true
+	}
+	return offers.Offers, nil
+}
+
+// parseDocumentHosts parses the document hosts file. The basic auth of a host cannot be set with an Authorization header.
+func parseDocumentHosts(hostsPath string) ([]DocumentHost, error) {
+	f, err := os.Open(filepath.Clean(hostsPath))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Println("failed to close document hosts file:", err)
+		}
+	}()
+
+	var hosts struct {
+		Hosts []DocumentHost `yaml:"hosts"`
+	}
+	if err := yaml.NewDecoder(f).Decode(&hosts); err != nil {
+		return nil, fmt.Errorf("invalid document hosts yaml file: %w", err)
+	}
+
+	names := make(map[string]bool, len(hosts.Hosts))
+	for _, host := range hosts.Hosts {
+		name := strings.ToLower(host.Host)
+		switch {
+		case name == "":
+			return nil, errors.New("document host is empty")
+		case strings.ContainsAny(name, "/?#@"):
+			return nil, fmt.Errorf("document host %s must be a host, not a url", host.Host)
+		case names[name]:
+			return nil, fmt.Errorf("document host %s is defined more than once", host.Host)
+		case host.Timeout < 0:
+			return nil, fmt.Errorf("document host %s has a negative timeout", host.Host)
+		case (host.Username != "" || host.Password != "") && hasHeader(host.Headers, "Authorization"):
+			return nil, fmt.Errorf("document host %s has both a basic auth and an Authorization header", host.Host)
+		}
+		names[name] = true
+	}
+	return hosts.Hosts, nil
+}
+
+func hasHeader(headers map[string]string, name string) bool {
+	for header := range headers {
+		if strings.EqualFold(header, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFlows parses the flows file. The trust profiles of the flows must exist, and their sessions must expire before
+// they are removed from the cache.
+func parseFlows(flowsPath string, profiles []TrustProfile, cacheExpiration time.Duration) ([]Flow, error) {
+	f, err := os.Open(filepath.Clean(flowsPath))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Println("failed to close flows file:", err)
+		}
+	}()
+
+	var flows struct {
+		Flows []Flow `yaml:"flows"`
+	}
+	if err := yaml.NewDecoder(f).Decode(&flows); err != nil {
+		return nil, fmt.Errorf("invalid flows yaml file: %w", err)
+	}
+
+	profileNames := make(map[string]bool, len(profiles))
+	for _, profile := range profiles {
+		profileNames[profile.Name] = true
+	}
+	names := make(map[string]bool, len(flows.Flows))
+	for _, flow := range flows.Flows {
+		switch {
+		case flow.Name == "":
+			return nil, errors.New("flow name is empty")
+		case names[flow.Name]:
+			return nil, fmt.Errorf("flow %s is defined more than once", flow.Name)
+		case len(flow.Scope) == 0:
+			return nil, fmt.Errorf("flow %s has no scope", flow.Name)
+		case flow.TrustProfile != "" && !profileNames[flow.TrustProfile]:
+			return nil, fmt.Errorf("flow %s uses the unknown trust profile %s", flow.Name, flow.TrustProfile)
+		case flow.TTL < 0:
+			return nil, fmt.Errorf("flow %s has a negative ttl", flow.Name)
+		case flow.TTL >= cacheExpiration:
+			return nil, fmt.Errorf("flow %s has a ttl that is not shorter than the cache expiration %s", flow.Name, cacheExpiration)
+		}
+		if flow.Webhook.URL != "" {
+			if u, err := url.Parse(flow.Webhook.URL); err != nil || u.Host == "" {
+				return nil, fmt.Errorf("flow %s has an invalid webhook url %s", flow.Name, flow.Webhook.URL)
+			}
+		}
+		names[flow.Name] = true
+	}
+	return flows.Flows, nil
+}
+
+func parseReasonTemplates(templatesPath string) ([]ReasonTemplate, error) {
+	f, err := os.Open(filepath.Clean(templatesPath))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Println("failed to close reason templates file:", err)
+		}
+	}()
+
+	var templates struct {
+		Templates []ReasonTemplate `yaml:"templates"`
+	}
+	if err := yaml.NewDecoder(f).Decode(&templates); err != nil {
+		return nil, fmt.Errorf("invalid reason templates yaml file: %w", err)
+	}
+
+	names := make(map[string]bool, len(templates.Templates))
+	for _, template := range templates.Templates {
+		switch {
+		case template.Name == "":
+			return nil, errors.New("reason template name is empty")
+		case names[template.Name]:
+			return nil, fmt.Errorf("reason template %s is defined more than once", template.Name)
+		}
+		if err := ValidateReasonTemplate(template); err != nil {
+			return nil, fmt.Errorf("reason template %s: %w", template.Name, err)
+		}
+		names[template.Name] = true
+	}
+	return templates.Templates, nil
+}
+
+// ValidateReasonTemplate checks that the reason template has a reason in its default locale, and that its reasons and
+// messages are valid templates
+func ValidateReasonTemplate(template ReasonTemplate) error {
+	if len(template.Locales) == 0 {
+		return errors.New("no locales")
+	}
+	if _, ok := template.Locales[template.DefaultLocale]; !ok {
+		return fmt.Errorf("the default locale %q has no reason", template.DefaultLocale)
+	}
+	for locale, reason := range template.Locales {
+		if _, err := language.Parse(locale); err != nil {
+			return fmt.Errorf("invalid locale %q", locale)
+		}
+		if strings.TrimSpace(reason.Reason) == "" {
+			return fmt.Errorf("the reason of locale %s is empty", locale)
+		}
+		if _, err := texttemplate.New("reason").Parse(reason.Reason); err != nil {
+			return fmt.Errorf("invalid reason of locale %s: %w", locale, err)
+		}
+		if _, err := texttemplate.New("message").Parse(reason.Message); err != nil {
+			return fmt.Errorf("invalid message of locale %s: %w", locale, err)
+		}
+	}
+	return nil
+}
+
+func parseOIDCClients(clientsPath string) ([]OIDCClient, error) {
+	f, err := os.Open(filepath.Clean(clientsPath))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Println("failed to close oidc clients file:", err)
+		}
+	}()
+
+	var clients struct {
+		Clients []OIDCClient `yaml:"clients"`
+	}
+	if err := yaml.NewDecoder(f).Decode(&clients); err != nil {
+		return nil, fmt.Errorf("invalid oidc clients yaml file: %w", err)
+	}
+
+	ids := make(map[string]bool, len(clients.Clients))
+	for _, client := range clients.Clients {
+		switch {
+		case client.ID == "":
+			return nil, errors.New("oidc client id is empty")
+		case ids[client.ID]:
+			return nil, fmt.Errorf("oidc client %s is defined more than once", client.ID)
+		case client.Secret == "":
+			return nil, fmt.Errorf("oidc client %s has no secret", client.ID)
+		case len(client.RedirectURIs) == 0:
+			return nil, fmt.Errorf("oidc client %s has no redirect uris", client.ID)
+		case client.Template == "" || client.ChainID == "":
+			return nil, fmt.Errorf("oidc client %s must have a template and a chainID", client.ID)
+		}
+		ids[client.ID] = true
+	}
+	return clients.Clients, nil
 }
 
 // Decode parses the duration string. It implements the envconfig.Decoder interface.
@@ -0,0 +1,278 @@
+package config
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetSessionTTL(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		cacheExpiration time.Duration
+		sessionTTL      time.Duration
+		expected        time.Duration
+	}{
+		{name: "default", cacheExpiration: time.Hour, expected: 30 * time.Minute},
+		{name: "shorter than the cache expiration", cacheExpiration: time.Hour, sessionTTL: 10 * time.Minute, expected: 10 * time.Minute},
+		{name: "equal to the cache expiration", cacheExpiration: time.Hour, sessionTTL: time.Hour, expected: 30 * time.Minute},
+		{name: "longer than the cache expiration", cacheExpiration: 30 * time.Minute, sessionTTL: time.Hour, expected: 15 * time.Minute},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := &Config{CacheExpiration: CacheTTL(tc.cacheExpiration), SessionTTL: CacheTTL(tc.sessionTTL)}
+			setSessionTTL(conf)
+			assert.Equal(t, tc.expected, conf.SessionTTL.AsDuration())
+		})
+	}
+}
+
+func TestWebDID(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		cfg      DIDDocument
+		host     string
+		expected string
+	}{
+		{name: "host", host: "https://verifier.example.com", expected: "did:web:verifier.example.com"},
+		{name: "host with port", host: "http://localhost:3009", expected: "did:web:localhost%3A3009"},
+		{name: "domain", cfg: DIDDocument{Domain: "id.example.com"}, host: "https://verifier.example.com", expected: "did:web:id.example.com"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			did, err := tc.cfg.WebDID(tc.host)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, did)
+		})
+	}
+
+	_, err := DIDDocument{}.WebDID("localhost")
+	assert.Error(t, err)
+}
+
+func TestWalletURL(t *testing.T) {
+	assert.Equal(t, "http://localhost:3009", Config{Host: "http://localhost:3009/"}.WalletURL())
+	assert.Equal(t, "https://verifier.eu.ngrok.io", Config{Host: "http://localhost:3009", PublicURL: "https://verifier.eu.ngrok.io/"}.WalletURL())
+
+	assert.NoError(t, ValidatePublicURL("https://example.com/verifier"))
+	for _, u := range []string{"example.com", "ftp://example.com", "https://example.com?a=b", "https://"} {
+		assert.Error(t, ValidatePublicURL(u), u)
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	nets, err := ParseTrustedProxies([]string{"10.0.0.1", "172.16.0.0/12", "::1"})
+	require.NoError(t, err)
+	require.Len(t, nets, 3)
+	assert.True(t, nets[0].Contains(net.ParseIP("10.0.0.1")))
+	assert.False(t, nets[0].Contains(net.ParseIP("10.0.0.2")))
+	assert.True(t, nets[1].Contains(net.ParseIP("172.20.1.1")))
+	assert.True(t, nets[2].Contains(net.ParseIP("::1")))
+
+	_, err = ParseTrustedProxies([]string{"proxy.local"})
+	assert.Error(t, err)
+}
+
+func TestDocumentCacheMemoryTTLs(t *testing.T) {
+	var documentCache DocumentCache
+	require.NoError(t, envconfig.Process("TEST_DOCUMENT_CACHE", &documentCache))
+	assert.Equal(t, 500, documentCache.MemorySize)
+	assert.Equal(t, map[string]time.Duration{"http": time.Hour, "https": time.Hour}, documentCache.MemoryTTLDurations())
+	assert.Equal(t, time.Minute, documentCache.NegativeTTL.AsDuration())
+
+	t.Setenv("TEST_DOCUMENT_CACHE_MEMORY_TTLS", "https:10m,ipfs:720h")
+	require.NoError(t, envconfig.Process("TEST_DOCUMENT_CACHE", &documentCache))
+	assert.Equal(t, map[string]time.Duration{"https": 10 * time.Minute, "ipfs": 720 * time.Hour}, documentCache.MemoryTTLDurations())
+}
+
+func TestValidateManagementAuth(t *testing.T) {
+	assert.NoError(t, validateManagementAuth(ManagementAuth{}))
+	assert.NoError(t, validateManagementAuth(ManagementAuth{
+		HMACSecrets: []string{"secret"}, ReplayWindow: CacheTTL(time.Minute),
+		TLSCertPath: "cert.pem", TLSKeyPath: "key.pem", ClientCAPath: "ca.pem",
+	}))
+	for _, cfg := range []ManagementAuth{
+		{HMACSecrets: []string{""}, ReplayWindow: CacheTTL(time.Minute)},
+		{HMACSecrets: []string{"secret"}},
+		{TLSCertPath: "cert.pem"},
+	} {
+		assert.Error(t, validateManagementAuth(cfg), cfg)
+	}
+	assert.EqualError(t, validateManagementAuth(ManagementAuth{ClientCAPath: "ca.pem"}),
+		"VERIFIER_BACKEND_MANAGEMENT_AUTH_CLIENT_CA_PATH requires the api to be served over tls, set "+
+			"VERIFIER_BACKEND_MANAGEMENT_AUTH_TLS_CERT_PATH and VERIFIER_BACKEND_MANAGEMENT_AUTH_TLS_KEY_PATH")
+}
+
+func TestParseFlows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flows.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`flows:
+  - name: kyc-age
+    version: "2"
+    chainID: "80002"
+    trustProfile: eu-kyc
+    ttl: 10m
+    webhook:
+      url: https://example.com/hooks/kyc
+    scope:
+      - id: 1
+        circuitId: credentialAtomicQuerySigV2
+        query:
+          context: https://example.com/kyc-v3.json-ld
+          type: KYCAgeCredential
+          credentialSubject:
+            birthday:
+              $lt: 20000101
+`), 0o600))
+
+	profiles := []TrustProfile{{Name: "eu-kyc"}}
+	flows, err := parseFlows(path, profiles, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, flows, 1)
+	assert.Equal(t, "kyc-age", flows[0].Name)
+	assert.Equal(t, 10*time.Minute, flows[0].TTL)
+	assert.Equal(t, "https://example.com/hooks/kyc", flows[0].Webhook.URL)
+	assert.Equal(t, "KYCAgeCredential", flows[0].Scope[0].Query["type"])
+
+	_, err = parseFlows(path, nil, time.Hour)
+	assert.EqualError(t, err, "flow kyc-age uses the unknown trust profile eu-kyc")
+	_, err = parseFlows(path, profiles, 5*time.Minute)
+	assert.Error(t, err)
+}
+
+func TestParseDocumentHosts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`hosts:
+  - host: gateway.pinata.cloud
+    headers:
+      x-pinata-gateway-token: token
+  - host: schemas.example.com:8443
+    username: verifier
+    password: secret
+    timeout: 1m
+`), 0o600))
+
+	hosts, err := parseDocumentHosts(path)
+	require.NoError(t, err)
+	assert.Equal(t, []DocumentHost{
+		{Host: "gateway.pinata.cloud", Headers: map[string]string{"x-pinata-gateway-token": "token"}},
+		{Host: "schemas.example.com:8443", Username: "verifier", Password: "secret", Timeout: time.Minute},
+	}, hosts)
+
+	for _, tc := range []struct {
+		name  string
+		hosts string
+		err   string
+	}{
+		{name: "url", hosts: "  - host: https://schemas.example.com/", err: "document host https://schemas.example.com/ must be a host, not a url"},
+		{name: "duplicate", hosts: "  - host: schemas.example.com\n  - host: Schemas.example.com", err: "document host Schemas.example.com is defined more than once"},
+		{
+			name:  "basic auth and authorization header",
+			hosts: "  - host: schemas.example.com\n    username: verifier\n    headers:\n      authorization: Bearer token",
+			err:   "document host schemas.example.com has both a basic auth and an Authorization header",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(path, []byte("hosts:\n"+tc.hosts), 0o600))
+			_, err := parseDocumentHosts(path)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestParseReasonTemplates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reasons.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`templates:
+  - name: age-check
+    defaultLocale: en
+    locales:
+      en:
+        reason: "{{.company}} needs to check your age"
+        message: "Prove you are over 18 to {{.purpose}}"
+      pt-BR:
+        reason: "{{.company}} precisa verificar sua idade"
+`), 0o600))
+
+	templates, err := parseReasonTemplates(path)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "age-check", templates[0].Name)
+	assert.Equal(t, "Prove you are over 18 to {{.purpose}}", templates[0].Locales["en"].Message)
+	assert.Equal(t, "", templates[0].Locales["pt-BR"].Message)
+
+	template := templates[0]
+	template.DefaultLocale = "es"
+	assert.EqualError(t, ValidateReasonTemplate(template), `the default locale "es" has no reason`)
+	template = ReasonTemplate{Name: "broken", DefaultLocale: "en", Locales: map[string]LocalizedReason{"en": {Reason: "{{.company"}}}
+	assert.Error(t, ValidateReasonTemplate(template))
+	template = ReasonTemplate{Name: "blank", DefaultLocale: "en", Locales: map[string]LocalizedReason{"en": {Reason: " "}}}
+	assert.EqualError(t, ValidateReasonTemplate(template), "the reason of locale en is empty")
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+port: 8080
+api_keys: [key-a, key-b]
+default_reason: for testing, purposes
+qr_store:
+  driver: redis
+  redis_addr: redis:6379
+document_cache:
+  memory_ttls: {https: 10m, ipfs: 720h}
+cors:
+  allowed_origins:
+    - https://app.example.com
+sandbox:
+  enabled: true
+resolvers:
+  polygon:
+    amoy:
+      contractAddress: 0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124
+      networkURL: https://rpc-amoy.polygon.technology
+      chainID: 80002
+      networkFlag: 0b0001_0011
+`), 0o600))
+	for _, name := range []string{"PORT", "API_KEYS", "DEFAULT_REASON", "QR_STORE_DRIVER", "QR_STORE_REDIS_ADDR",
+		"DOCUMENT_CACHE_MEMORY_TTLS", "CORS_ALLOWED_ORIGINS", "SANDBOX_ENABLED"} {
+		// restores the environment once the test is done
+		t.Setenv(envPrefix+"_"+name, "")
+		require.NoError(t, os.Unsetenv(envPrefix+"_"+name))
+	}
+	t.Setenv(envPrefix+"_PORT", "9090")
+
+	var conf Config
+	require.NoError(t, envconfig.Process(envPrefix, &conf))
+	resolvers, err := loadFile(path, &conf)
+	require.NoError(t, err)
+	_, ok := os.LookupEnv(envPrefix + "_API_KEYS")
+	assert.False(t, ok, "the file does not set the environment")
+	assert.Equal(t, "9090", conf.ApiPort)
+	assert.Equal(t, []string{"key-a", "key-b"}, conf.APIKeys)
+	assert.Equal(t, "for testing, purposes", conf.DefaultReason)
+	assert.Equal(t, QRStore{Driver: QRStoreDriverRedis, RedisAddr: "redis:6379", ReadRetryWindow: CacheTTL(200 * time.Millisecond)}, conf.QRStore)
+	assert.Equal(t, map[string]time.Duration{"https": 10 * time.Minute, "ipfs": 720 * time.Hour}, conf.DocumentCache.MemoryTTLDurations())
+	assert.Equal(t, []string{"https://app.example.com"}, conf.CORS.AllowedOrigins)
+	assert.True(t, conf.Sandbox.Enabled)
+	assert.Equal(t, 48*time.Hour, conf.CacheExpiration.AsDuration())
+	assert.Equal(t, ResolverSettingsAttrs{
+		ContractAddress: "0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124", NetworkURL: "https://rpc-amoy.polygon.technology",
+		ChainID: "80002", NetworkFlag: 0b0001_0011, Method: DIDMethodPolygonID, ConfirmationMode: ConfirmationModeReject,
+		RPCSelection: RPCSelectionFailover,
+	}, resolvers["polygon"]["amoy"])
+
+	for content, message := range map[string]string{
+		"qr_store: {unknown: 1}":                        "unknown setting qr_store.unknown",
+		"qr_store: redis":                               "setting qr_store is a section",
+		"api_keys: [a, [b]]":                            "setting api_keys: unexpected nested value [b]",
+		"allowed_public_urls: [a, 'b,c']":               `setting allowed_public_urls: item "b,c" cannot contain a comma`,
+		"resolvers: {polygon: {amoy: {method: other}}}": "resolvers: polygon:amoy: did method other requires a methodByte",
+	} {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		_, err := loadFile(path, &Config{})
+		assert.EqualError(t, err, message, content)
+	}
+}
@@ -0,0 +1,264 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	envPrefix = "VERIFIER_BACKEND"
+
+	// FileEnv is the environment variable with the path of the configuration file
+	FileEnv = envPrefix + "_CONFIG_FILE"
+
+	fileResolversKey = "resolvers"
+)
+
+var decoderType = reflect.TypeOf((*envconfig.Decoder)(nil)).Elem()
+
+// loadFile reads the yaml configuration file at path into conf, once envconfig has processed the environment. Its keys
+// are the names of the environment variables in lower case without the VERIFIER_BACKEND_ prefix, nested in sections,
+// e.g. qr_store: {driver: redis}, lists are yaml sequences and maps are yaml mappings. The values are parsed as
+// envconfig parses the environment variables, and set on the settings whose environment variable is not set, so the
+// environment overrides the file and the defaults apply to the settings set by neither. The process environment is
+// not modified. The resolvers section holds the resolver settings, used instead of the file of ResolverSettingsPath;
+// they are returned nil without it.
+func loadFile(path string, conf *Config) (ResolverSettings, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	var resolvers ResolverSettings
+	if section, ok := values[fileResolversKey]; ok {
+		delete(values, fileResolversKey)
+		if resolvers, err = fileResolverSettings(section); err != nil {
+			return nil, fmt.Errorf("%s: %w", fileResolversKey, err)
+		}
+	}
+
+	if err := fileSettings(envPrefix, "", reflect.ValueOf(conf).Elem(), values); err != nil {
+		return nil, err
+	}
+	return resolvers, nil
+}
+
+func fileResolverSettings(section any) (ResolverSettings, error) {
+	data, err := yaml.Marshal(section)
+	if err != nil {
+		return nil, err
+	}
+	settings := ResolverSettings{}
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	return settings, normalizeResolverSettings(settings)
+}
+
+// fileSettings sets the values of the section of the struct v whose environment variables, named as envconfig names
+// them, are not set. The section is at path in the file, for the errors.
+func fileSettings(prefix string, path string, v reflect.Value, values map[string]any) error {
+	t := v.Type()
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("ignored") == "true" {
+			continue
+		}
+		key := field.Tag.Get("envconfig")
+		if key == "" {
+			key = field.Name
+		}
+		fields[strings.ToLower(key)] = i
+	}
+
+	for key, value := range values {
+		name, setting := prefix+"_"+strings.ToUpper(key), path+key
+		i, ok := fields[strings.ToLower(key)]
+		if !ok {
+			return fmt.Errorf("unknown setting %s", setting)
+		}
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct && !reflect.PointerTo(field.Type()).Implements(decoderType) {
+			section, ok := value.(map[string]any)
+			if !ok {
+				return fmt.Errorf("setting %s is a section", setting)
+			}
+			if err := fileSettings(name, setting+".", field, section); err != nil {
+				return err
+			}
+			continue
+		}
+		envValue, err := fileEnvValue(value)
+		if err != nil {
+			return fmt.Errorf("setting %s: %w", setting, err)
+		}
+		if envSet(name, t.Field(i).Tag.Get("envconfig")) {
+			continue
+		}
+		if err := decodeSetting(field, envValue); err != nil {
+			return fmt.Errorf("setting %s: %w", setting, err)
+		}
+	}
+	return nil
+}
+
+// envSet reports whether the environment variable name, or alt as envconfig also looks it up, is set
+func envSet(name, alt string) bool {
+	if _, ok := os.LookupEnv(name); ok {
+		return true
+	}
+	if alt == "" {
+		return false
+	}
+	_, ok := os.LookupEnv(strings.ToUpper(alt))
+	return ok
+}
+
+// decodeSetting sets field to the value, parsed as envconfig parses the value of an environment variable
+func decodeSetting(field reflect.Value, value string) error {
+	if decoder, ok := field.Addr().Interface().(envconfig.Decoder); ok {
+		return decoder.Decode(value)
+	}
+	if unmarshaler, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(value))
+	}
+	switch field.Kind() {
+	case reflect.Pointer:
+		ptr := reflect.New(field.Type().Elem())
+		if err := decodeSetting(ptr.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(ptr)
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Type() == reflect.TypeOf(time.Duration(0)) {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return err
+			}
+			field.SetInt(int64(d))
+			return nil
+		}
+		n, err := strconv.ParseInt(value, 0, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 0, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		items := []string{}
+		if strings.TrimSpace(value) != "" {
+			items = strings.Split(value, ",")
+		}
+		slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeSetting(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+	case reflect.Map:
+		m := reflect.MakeMap(field.Type())
+		if strings.TrimSpace(value) != "" {
+			for _, item := range strings.Split(value, ",") {
+				k, v, ok := strings.Cut(item, ":")
+				if !ok {
+					return fmt.Errorf("invalid map item: %q", item)
+				}
+				key, elem := reflect.New(field.Type().Key()).Elem(), reflect.New(field.Type().Elem()).Elem()
+				if err := decodeSetting(key, k); err != nil {
+					return err
+				}
+				if err := decodeSetting(elem, v); err != nil {
+					return err
+				}
+				m.SetMapIndex(key, elem)
+			}
+		}
+		field.Set(m)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
+
+// fileEnvValue formats a value of the file as envconfig parses it: the items of the lists are separated by commas and
+// the entries of the maps are key:value items
+func fileEnvValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := fileEnvScalar(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	case map[string]any:
+		items := make([]string, 0, len(v))
+		for key, item := range v {
+			s, err := fileEnvScalar(item)
+			if err != nil {
+				return "", err
+			}
+			if strings.Contains(key, ":") {
+				return "", fmt.Errorf("key %q cannot contain a colon", key)
+			}
+			items = append(items, key+":"+s)
+		}
+		sort.Strings(items)
+		return strings.Join(items, ","), nil
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
+
+// fileEnvScalar formats an item of a list or a map
+func fileEnvScalar(value any) (string, error) {
+	switch value.(type) {
+	case []any, map[string]any:
+		return "", fmt.Errorf("unexpected nested value %v", value)
+	}
+	s := fmt.Sprint(value)
+	if strings.Contains(s, ",") {
+		return "", fmt.Errorf("item %q cannot contain a comma", s)
+	}
+	return s, nil
+}
@@ -0,0 +1,161 @@
+// Package configcheck checks a configuration of the verifier before it is deployed: the verification keys of the
+// circuits, the RPC urls and the state contracts of the networks, and the DIDs, and reports the checks that failed.
+package configcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iden3/go-circuits/v2"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/stateresolver"
+)
+
+// RequiredCircuits are the circuits of the proofs verified by the verifier, the on-chain proofs are verified by
+// their contracts
+var RequiredCircuits = []circuits.CircuitID{
+	circuits.AuthV2CircuitID,
+	circuits.AtomicQuerySigV2CircuitID,
+	circuits.AtomicQueryMTPV2CircuitID,
+	circuits.AtomicQueryV3CircuitID,
+}
+
+// KeyLoader loads the verification key of a circuit
+type KeyLoader interface {
+	Load(id circuits.CircuitID) ([]byte, error)
+}
+
+// Check is the result of a check, it failed when Err is set
+type Check struct {
+	Name string
+	Err  error
+}
+
+// Report holds the results of the checks in their order
+type Report struct {
+	Checks []Check
+}
+
+// Add records the result of the check name
+func (r *Report) Add(name string, err error) {
+	r.Checks = append(r.Checks, Check{Name: name, Err: err})
+}
+
+// Failed reports whether a check failed
+func (r *Report) Failed() bool {
+	for _, c := range r.Checks {
+		if c.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Write writes a line per check, and a summary line with the number of failed checks
+func (r *Report) Write(w io.Writer) error {
+	failed := 0
+	for _, c := range r.Checks {
+		var err error
+		if c.Err != nil {
+			failed++
+			_, err = fmt.Fprintf(w, "FAIL %s: %v\n", c.Name, c.Err)
+		} else {
+			_, err = fmt.Fprintf(w, "ok   %s\n", c.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d checks, %d failed\n", len(r.Checks), failed)
+	return err
+}
+
+// CheckKeys checks that the verification keys of the circuits can be loaded, match their checksums and are JSON
+func CheckKeys(r *Report, loader KeyLoader, ids []circuits.CircuitID) {
+	for _, id := range ids {
+		data, err := loader.Load(id)
+		if err == nil && !json.Valid(data) {
+			err = errors.New("the verification key is not JSON")
+		}
+		r.Add("verification key "+string(id), err)
+	}
+}
+
+// CheckRPCs checks that each RPC url of the networks answers its block number in timeout, and that the state contract
+// of its network is deployed. The urls are reported without their path, which often holds the key of the provider.
+func CheckRPCs(ctx context.Context, r *Report, rs config.ResolverSettings, dial func(rpcURL string) (stateresolver.Client, error),
+	timeout time.Duration,
+) {
+	for _, network := range Networks(rs) {
+		attrs := rs[network.Blockchain][network.Network]
+		for _, rpcURL := range append([]string{attrs.NetworkURL}, attrs.NetworkURLs...) {
+			name := fmt.Sprintf("rpc %s %s", network, redact(rpcURL))
+			r.Add(name, checkRPC(ctx, rpcURL, attrs.ContractAddress, dial, timeout))
+		}
+	}
+}
+
+func checkRPC(ctx context.Context, rpcURL, contract string, dial func(rpcURL string) (stateresolver.Client, error),
+	timeout time.Duration,
+) error {
+	if !common.IsHexAddress(contract) {
+		return fmt.Errorf("invalid state contract address %q", contract)
+	}
+	client, err := dial(rpcURL)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if _, err := client.BlockNumber(ctx); err != nil {
+		return err
+	}
+	code, err := client.CodeAt(ctx, common.HexToAddress(contract), nil)
+	if err != nil {
+		return err
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("no state contract at %s", contract)
+	}
+	return nil
+}
+
+// Network is a blockchain:network of the resolver settings
+type Network struct {
+	Blockchain string
+	Network    string
+}
+
+func (n Network) String() string {
+	return n.Blockchain + ":" + n.Network
+}
+
+// Networks returns the networks of the resolver settings sorted by name, so the reports are stable
+func Networks(rs config.ResolverSettings) []Network {
+	var networks []Network
+	for blockchain, chainSettings := range rs {
+		for network := range chainSettings {
+			networks = append(networks, Network{Blockchain: blockchain, Network: network})
+		}
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].String() < networks[j].String()
+	})
+	return networks
+}
+
+func redact(rpcURL string) string {
+	u, err := url.Parse(rpcURL)
+	if err != nil || u.Host == "" {
+		return "(invalid url)"
+	}
+	return u.Scheme + "://" + u.Host
+}
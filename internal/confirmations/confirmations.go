@@ -0,0 +1,201 @@
+// Package confirmations checks that the state transitions verifications rely on are buried under enough blocks,
+// protecting against reorg-based state spoofing on fast chains.
+package confirmations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iden3/contracts-abi/state/go/abi"
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	"github.com/iden3/go-iden3-auth/v2/state"
+
+	"github.com/0xPolygonID/verifier-backend/internal/stateresolver"
+)
+
+// ErrStateReverted is returned when a state that was resolved no longer exists in the state contract
+var ErrStateReverted = errors.New("state no longer exists in the state contract")
+
+// Chain reads the blocks of the state transitions of a state contract
+type Chain interface {
+	// Confirmations returns the number of blocks mined on top of the block where the state was created,
+	// including that block. id is nil for global roots.
+	Confirmations(ctx context.Context, id, state *big.Int) (uint64, error)
+}
+
+// ETHChain reads the state contract of an EVM network through the RPC urls of the network
+type ETHChain struct {
+	RPC             *stateresolver.Resolver
+	ContractAddress common.Address
+}
+
+// NewETHChain creates a new ETHChain
+func NewETHChain(rpc *stateresolver.Resolver, contract string) *ETHChain {
+	return &ETHChain{RPC: rpc, ContractAddress: common.HexToAddress(contract)}
+}
+
+// Confirmations returns the number of blocks mined on top of the block where the state was created
+func (c ETHChain) Confirmations(ctx context.Context, id, st *big.Int) (uint64, error) {
+	var confirmations uint64
+	err := c.RPC.Call(ctx, func(ctx context.Context, client stateresolver.Client) error {
+		caller, err := abi.NewStateCaller(c.ContractAddress, client)
+		if err != nil {
+			return err
+		}
+
+		opts := &bind.CallOpts{Context: ctx}
+		var createdAt *big.Int
+		if id == nil {
+			info, err := caller.GetGISTRootInfo(opts, st)
+			if err != nil {
+				return notFound(err)
+			}
+			createdAt = info.CreatedAtBlock
+		} else {
+			info, err := caller.GetStateInfoByIdAndState(opts, id, st)
+			if err != nil {
+				return notFound(err)
+			}
+			createdAt = info.CreatedAtBlock
+		}
+
+		head, err := client.BlockNumber(ctx)
+		if err != nil {
+			return err
+		}
+		if head >= createdAt.Uint64() {
+			confirmations = head - createdAt.Uint64() + 1
+		}
+		return nil
+	})
+	return confirmations, err
+}
+
+func notFound(err error) error {
+	if strings.Contains(err.Error(), "State does not exist") || strings.Contains(err.Error(), "Root does not exist") {
+		return fmt.Errorf("%w: %v", ErrStateReverted, err)
+	}
+	return err
+}
+
+// State is a state that did not have the required confirmations when a verification relied on it
+type State struct {
+	Network  string
+	ID       *big.Int
+	State    *big.Int
+	Required uint64
+	chain    Chain
+}
+
+// Confirmed checks whether the state has the required confirmations now.
+// It returns ErrStateReverted when the state was reorganized out of the chain.
+func (s State) Confirmed(ctx context.Context) (bool, error) {
+	confirmations, err := s.chain.Confirmations(ctx, s.ID, s.State)
+	if err != nil {
+		return false, err
+	}
+	return confirmations >= s.Required, nil
+}
+
+type ctxKey struct{}
+
+// Pending collects the unconfirmed states of a verification. A nil Pending rejects them instead.
+type Pending struct {
+	mu     sync.Mutex
+	states []State
+}
+
+// NewPending creates a new Pending
+func NewPending() *Pending {
+	return &Pending{}
+}
+
+// WithPending returns a copy of ctx with the pending states collector
+func WithPending(ctx context.Context, p *Pending) context.Context {
+	return context.WithValue(ctx, ctxKey{}, p)
+}
+
+// FromContext returns the pending states collector of the context, or nil
+func FromContext(ctx context.Context) *Pending {
+	p, _ := ctx.Value(ctxKey{}).(*Pending)
+	return p
+}
+
+// States returns the collected unconfirmed states
+func (p *Pending) States() []State {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]State(nil), p.states...)
+}
+
+func (p *Pending) add(s State) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.states = append(p.states, s)
+}
+
+// Resolver requires the states resolved by its network to have a number of confirmations. Unconfirmed states
+// are rejected, or collected in the Pending of the context when provisional verifications are allowed.
+type Resolver struct {
+	pubsignals.StateResolver
+	network       string
+	chain         Chain
+	confirmations uint64
+	provisional   bool
+}
+
+// NewResolver wraps the resolver of a network
+func NewResolver(network string, resolver pubsignals.StateResolver, chain Chain, confirmations uint64, provisional bool) *Resolver {
+	return &Resolver{
+		StateResolver: resolver,
+		network:       network,
+		chain:         chain,
+		confirmations: confirmations,
+		provisional:   provisional,
+	}
+}
+
+// Resolve resolves the state of an identity and checks its confirmations. Genesis states are not on chain.
+func (r *Resolver) Resolve(ctx context.Context, id *big.Int, st *big.Int) (*state.ResolvedState, error) {
+	resolved, err := r.StateResolver.Resolve(ctx, id, st)
+	if err != nil || resolved.Genesis {
+		return resolved, err
+	}
+	return resolved, r.check(ctx, id, st)
+}
+
+// ResolveGlobalRoot resolves a global identities tree root and checks its confirmations
+func (r *Resolver) ResolveGlobalRoot(ctx context.Context, st *big.Int) (*state.ResolvedState, error) {
+	resolved, err := r.StateResolver.ResolveGlobalRoot(ctx, st)
+	if err != nil {
+		return resolved, err
+	}
+	return resolved, r.check(ctx, nil, st)
+}
+
+func (r *Resolver) check(ctx context.Context, id, st *big.Int) error {
+	s := State{Network: r.network, ID: id, State: st, Required: r.confirmations, chain: r.chain}
+	confirmed, err := s.Confirmed(ctx)
+	if err != nil {
+		return err
+	}
+	if confirmed {
+		return nil
+	}
+
+	pending := FromContext(ctx)
+	if !r.provisional || pending == nil {
+		return fmt.Errorf("state %s of %s does not have %d block confirmations yet", st, r.network, r.confirmations)
+	}
+	pending.add(s)
+	return nil
+}
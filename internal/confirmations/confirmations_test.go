@@ -0,0 +1,82 @@
+package confirmations
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iden3/go-iden3-auth/v2/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResolver struct {
+	genesis bool
+}
+
+func (f fakeResolver) Resolve(_ context.Context, _ *big.Int, st *big.Int) (*state.ResolvedState, error) {
+	return &state.ResolvedState{State: st.String(), Latest: true, Genesis: f.genesis}, nil
+}
+
+func (f fakeResolver) ResolveGlobalRoot(_ context.Context, st *big.Int) (*state.ResolvedState, error) {
+	return &state.ResolvedState{State: st.String(), Latest: true}, nil
+}
+
+type fakeChain struct {
+	confirmations uint64
+	err           error
+}
+
+func (f *fakeChain) Confirmations(context.Context, *big.Int, *big.Int) (uint64, error) {
+	return f.confirmations, f.err
+}
+
+func TestResolver(t *testing.T) {
+	type testConfig struct {
+		name          string
+		genesis       bool
+		confirmations uint64
+		provisional   bool
+		collect       bool
+		expectedErr   bool
+		expectPending int
+	}
+	for _, tc := range []testConfig{
+		{name: "confirmed", confirmations: 12},
+		{name: "unconfirmed rejected", confirmations: 3, provisional: true, expectedErr: true},
+		{name: "unconfirmed in reject mode", confirmations: 3, collect: true, expectedErr: true},
+		{name: "unconfirmed provisional", confirmations: 3, provisional: true, collect: true, expectPending: 1},
+		{name: "genesis", genesis: true, confirmations: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			chain := &fakeChain{confirmations: tc.confirmations}
+			r := NewResolver("polygon:amoy", fakeResolver{genesis: tc.genesis}, chain, 12, tc.provisional)
+			ctx := context.Background()
+			pending := NewPending()
+			if tc.collect {
+				ctx = WithPending(ctx, pending)
+			}
+
+			_, err := r.Resolve(ctx, big.NewInt(1), big.NewInt(2))
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			states := pending.States()
+			require.Len(t, states, tc.expectPending)
+			if tc.expectPending == 0 {
+				return
+			}
+
+			assert.Equal(t, "polygon:amoy", states[0].Network)
+			confirmed, err := states[0].Confirmed(ctx)
+			require.NoError(t, err)
+			assert.False(t, confirmed)
+			chain.confirmations = 12
+			confirmed, err = states[0].Confirmed(ctx)
+			require.NoError(t, err)
+			assert.True(t, confirmed)
+		})
+	}
+}
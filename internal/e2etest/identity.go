@@ -0,0 +1,157 @@
+package e2etest
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/iden3/go-circuits/v2"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-iden3-crypto/babyjub"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/iden3/go-merkletree-sql/v2/db/memory"
+)
+
+// mtLevels is the number of levels of the identity trees in the circuits
+const mtLevels = 40
+
+// Identity is an amoy iden3 identity with in-memory claims, revocation and roots trees, authenticated by a baby jubjub key
+type Identity struct {
+	ID        core.ID
+	DID       w3c.DID
+	AuthClaim *core.Claim
+
+	key *babyjub.PrivateKey
+	clt *merkletree.MerkleTree
+	ret *merkletree.MerkleTree
+	rot *merkletree.MerkleTree
+}
+
+// NewIdentity creates the genesis identity of the hex encoded baby jubjub private key
+func NewIdentity(ctx context.Context, privateKey string) (*Identity, error) {
+	var key babyjub.PrivateKey
+	if _, err := hex.Decode(key[:], []byte(privateKey)); err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	i := &Identity{key: &key}
+	for _, tree := range []**merkletree.MerkleTree{&i.clt, &i.ret, &i.rot} {
+		mt, err := merkletree.NewMerkleTree(ctx, memory.NewMemoryStorage(), mtLevels)
+		if err != nil {
+			return nil, err
+		}
+		*tree = mt
+	}
+
+	public := key.Public()
+	revNonce, err := merkletree.HashElems(public.X)
+	if err != nil {
+		return nil, err
+	}
+	i.AuthClaim, err = core.NewClaim(core.AuthSchemaHash,
+		core.WithIndexDataInts(public.X, public.Y),
+		core.WithRevocationNonce(revNonce.BigInt().Uint64()))
+	if err != nil {
+		return nil, err
+	}
+	if err := i.AddClaim(ctx, i.AuthClaim); err != nil {
+		return nil, err
+	}
+
+	typ, err := core.BuildDIDType(core.DIDMethodIden3, core.Polygon, core.Amoy)
+	if err != nil {
+		return nil, err
+	}
+	state, err := i.State()
+	if err != nil {
+		return nil, err
+	}
+	id, err := core.NewIDFromIdenState(typ, state.BigInt())
+	if err != nil {
+		return nil, err
+	}
+	did, err := core.ParseDIDFromID(*id)
+	if err != nil {
+		return nil, err
+	}
+	i.ID, i.DID = *id, *did
+	return i, nil
+}
+
+// AddClaim adds claim to the claims tree, which changes the state of the identity
+func (i *Identity) AddClaim(ctx context.Context, claim *core.Claim) error {
+	hi, hv, err := claim.HiHv()
+	if err != nil {
+		return err
+	}
+	return i.clt.Add(ctx, hi, hv)
+}
+
+// State returns the current state of the identity
+func (i *Identity) State() (*merkletree.Hash, error) {
+	state, err := core.IdenState(i.clt.Root().BigInt(), i.ret.Root().BigInt(), i.rot.Root().BigInt())
+	if err != nil {
+		return nil, err
+	}
+	return merkletree.NewHashFromBigInt(state)
+}
+
+// TreeState returns the current state of the identity with the roots of its trees
+func (i *Identity) TreeState() (circuits.TreeState, error) {
+	state, err := i.State()
+	if err != nil {
+		return circuits.TreeState{}, err
+	}
+	return circuits.TreeState{
+		State:          state,
+		ClaimsRoot:     i.clt.Root(),
+		RevocationRoot: i.ret.Root(),
+		RootOfRoots:    i.rot.Root(),
+	}, nil
+}
+
+// ClaimProof returns the proof of inclusion of claim in the claims tree
+func (i *Identity) ClaimProof(ctx context.Context, claim *core.Claim) (circuits.MTProof, error) {
+	hi, err := claim.HIndex()
+	if err != nil {
+		return circuits.MTProof{}, err
+	}
+	return i.proof(ctx, i.clt, hi)
+}
+
+// NonRevocationProof returns the proof of non inclusion of the revocation nonce of claim in the revocation tree
+func (i *Identity) NonRevocationProof(ctx context.Context, claim *core.Claim) (circuits.MTProof, error) {
+	return i.proof(ctx, i.ret, new(big.Int).SetUint64(claim.GetRevocationNonce()))
+}
+
+func (i *Identity) proof(ctx context.Context, tree *merkletree.MerkleTree, key *big.Int) (circuits.MTProof, error) {
+	proof, _, err := tree.GenerateProof(ctx, key, nil)
+	if err != nil {
+		return circuits.MTProof{}, err
+	}
+	treeState, err := i.TreeState()
+	if err != nil {
+		return circuits.MTProof{}, err
+	}
+	return circuits.MTProof{Proof: proof, TreeState: treeState}, nil
+}
+
+// Sign signs the big endian integer of message with the key of the identity
+func (i *Identity) Sign(message []byte) *babyjub.Signature {
+	return i.key.SignPoseidon(new(big.Int).SetBytes(message))
+}
+
+// SignClaim signs the hash of the index and value of claim with the key of the identity
+func (i *Identity) SignClaim(claim *core.Claim) (*babyjub.Signature, error) {
+	hi, hv, err := claim.HiHv()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := merkletree.HashElems(hi, hv)
+	if err != nil {
+		return nil, err
+	}
+	return i.key.SignPoseidon(hash.BigInt()), nil
+}
@@ -0,0 +1,41 @@
+package e2etest
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+
+	"github.com/0xPolygonID/verifier-backend/internal/loader"
+	"github.com/piprate/json-gold/ld"
+)
+
+const (
+	// KYCContextURL is the url of the JSON-LD context of the fixture credential
+	KYCContextURL = "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-nonmerklized.jsonld"
+	// KYCAgeCredentialType is the type of the fixture credential
+	KYCAgeCredentialType = "KYCAgeCredential"
+)
+
+//go:embed testdata/kyc-nonmerklized.jsonld
+var kycContext []byte
+
+// DocumentLoader serves the fixture contexts from memory, so the end-to-end tests do not need the network
+type DocumentLoader struct{}
+
+// LoadDocument loads the fixture context of u
+func (DocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	var body []byte
+	switch u {
+	case KYCContextURL:
+		body = kycContext
+	case loader.W3CCredential2018ContextURL:
+		body = []byte(loader.W3CCredential2018ContextDocument)
+	default:
+		return nil, fmt.Errorf("no fixture document for %s", u)
+	}
+	doc, err := ld.DocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	return &ld.RemoteDocument{DocumentURL: u, Document: doc}, nil
+}
@@ -0,0 +1,90 @@
+package e2etest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/go-rapidsnark/prover"
+	"github.com/iden3/go-rapidsnark/types"
+	"github.com/iden3/go-rapidsnark/witness/v2"
+	"github.com/iden3/go-rapidsnark/witness/wazero"
+)
+
+// CircuitsDirEnv is the environment variable of the directory of the circuits that the end-to-end tests prove with
+const CircuitsDirEnv = "VERIFIER_BACKEND_E2E_CIRCUITS_DIR"
+
+// ErrNoCircuits is returned when the circuits directory is not configured
+var ErrNoCircuits = errors.New(CircuitsDirEnv + " is not set")
+
+// Prover generates groth16 proofs with the circuits of a directory laid out like the iden3 circuits release,
+// <dir>/<circuitID>/circuit.wasm and <dir>/<circuitID>/circuit_final.zkey
+type Prover struct {
+	dir string
+
+	mu          sync.Mutex
+	calculators map[circuits.CircuitID]witness.Calculator
+}
+
+// NewProver creates a Prover of the circuits of dir
+func NewProver(dir string) *Prover {
+	return &Prover{dir: dir, calculators: make(map[circuits.CircuitID]witness.Calculator)}
+}
+
+// ProverFromEnv creates a Prover of the circuits of the directory of CircuitsDirEnv
+func ProverFromEnv() (*Prover, error) {
+	dir := os.Getenv(CircuitsDirEnv)
+	if dir == "" {
+		return nil, ErrNoCircuits
+	}
+	return NewProver(dir), nil
+}
+
+// Files returns the proving key and the wasm of the circuit
+func (p *Prover) Files(circuitID circuits.CircuitID) (provingKey, wasm []byte, err error) {
+	provingKey, err = os.ReadFile(filepath.Join(p.dir, string(circuitID), "circuit_final.zkey"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the proving key of %s: %w", circuitID, err)
+	}
+	wasm, err = os.ReadFile(filepath.Join(p.dir, string(circuitID), "circuit.wasm"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read the wasm of %s: %w", circuitID, err)
+	}
+	return provingKey, wasm, nil
+}
+
+// Prove calculates the witness of the marshaled inputs of the circuit and proves it
+func (p *Prover) Prove(circuitID circuits.CircuitID, inputs []byte) (*types.ZKProof, error) {
+	provingKey, wasm, err := p.Files(circuitID)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := witness.ParseInputs(inputs)
+	if err != nil {
+		return nil, err
+	}
+	wtns, err := p.witness(circuitID, wasm, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate the witness of %s: %w", circuitID, err)
+	}
+	return prover.Groth16Prover(provingKey, wtns)
+}
+
+// witness calculates the witness with the calculator of the circuit, the calculators are reused and not concurrency safe
+func (p *Prover) witness(circuitID circuits.CircuitID, wasm []byte, inputs map[string]interface{}) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	calculator, ok := p.calculators[circuitID]
+	if !ok {
+		var err error
+		calculator, err = witness.NewCalculator(wasm, witness.WithWasmEngine(wazero.NewCircom2WZWitnessCalculator))
+		if err != nil {
+			return nil, err
+		}
+		p.calculators[circuitID] = calculator
+	}
+	return calculator.CalculateWTNSBin(inputs, true)
+}
@@ -0,0 +1,70 @@
+package e2etest
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/iden3/go-iden3-auth/v2/state"
+	core "github.com/iden3/go-iden3-core/v2"
+)
+
+// StateResolver resolves the states published by the wallets instead of the states of a state contract.
+// Genesis states resolve like the contract does, when they are not published.
+type StateResolver struct {
+	mu     sync.RWMutex
+	states map[string]*big.Int
+	roots  map[string]bool
+}
+
+// NewStateResolver creates an empty StateResolver
+func NewStateResolver() *StateResolver {
+	return &StateResolver{states: make(map[string]*big.Int), roots: make(map[string]bool)}
+}
+
+// Publish sets the latest state of id
+func (r *StateResolver) Publish(id core.ID, s *big.Int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[id.BigInt().String()] = s
+}
+
+// PublishRoot sets the latest global identities state tree root
+func (r *StateResolver) PublishRoot(root *big.Int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.roots = map[string]bool{root.String(): true}
+}
+
+// Resolve returns the latest published state of id, or its genesis state when nothing was published for id
+func (r *StateResolver) Resolve(_ context.Context, id, s *big.Int) (*state.ResolvedState, error) {
+	r.mu.RLock()
+	latest, ok := r.states[id.String()]
+	r.mu.RUnlock()
+	if ok {
+		if latest.Cmp(s) != 0 {
+			return nil, errors.New("state is not the latest published state")
+		}
+		return &state.ResolvedState{State: s.String(), Latest: true}, nil
+	}
+
+	isGenesis, err := core.CheckGenesisStateID(id, s)
+	if err != nil {
+		return nil, err
+	}
+	if !isGenesis {
+		return nil, errors.New("state is not genesis and not published")
+	}
+	return &state.ResolvedState{State: s.String(), Latest: true, Genesis: true}, nil
+}
+
+// ResolveGlobalRoot returns the latest published global identities state tree root
+func (r *StateResolver) ResolveGlobalRoot(_ context.Context, root *big.Int) (*state.ResolvedState, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if !r.roots[root.String()] {
+		return nil, errors.New("global root is not published")
+	}
+	return &state.ResolvedState{State: root.String(), Latest: true}, nil
+}
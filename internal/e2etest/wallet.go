@@ -0,0 +1,312 @@
+// Package e2etest is an end-to-end test harness: a wallet that holds a fixture credential and answers authorization
+// requests with real proofs, the state resolver of its identities and the loader of its JSON-LD contexts.
+// Proving needs the circuits of the iden3 circuits release, see ProverFromEnv.
+package e2etest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-jwz/v2"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/iden3/go-merkletree-sql/v2/db/memory"
+	"github.com/iden3/go-rapidsnark/types"
+	"github.com/iden3/go-schema-processor/v2/merklize"
+	"github.com/iden3/go-schema-processor/v2/utils"
+	"github.com/iden3/iden3comm/v2/packers"
+	"github.com/iden3/iden3comm/v2/protocol"
+)
+
+const (
+	userKey   = "28156abe7fe2fd433dc9df969286b96666489bac508612d0e16593e944c4f69f"
+	issuerKey = "21a5e7321d0e2f3ca1cc6504396e6594a2211544b08c206847cdee96f832421a"
+
+	// gistLevels is the number of levels of the global identities state tree in the circuits
+	gistLevels = 64
+)
+
+// The subject fields of the fixture credential
+const (
+	Birthday     = 19960424
+	DocumentType = 99
+)
+
+// Wallet answers authorization requests with proofs of its KYCAgeCredential, the credential of the subject fields,
+// issued to User by Issuer
+type Wallet struct {
+	User       *Identity
+	Issuer     *Identity
+	Credential *core.Claim
+
+	prover *Prover
+	gist   *merkletree.MerkleTree
+}
+
+// NewWallet creates the fixture identities, issues the credential and publishes the state of the issuer to resolver
+func NewWallet(ctx context.Context, prover *Prover, resolver *StateResolver) (*Wallet, error) {
+	user, err := NewIdentity(ctx, userKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the user: %w", err)
+	}
+	issuer, err := NewIdentity(ctx, issuerKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the issuer: %w", err)
+	}
+
+	schemaID, err := merklize.Options{DocumentLoader: DocumentLoader{}}.TypeIDFromContext(kycContext, KYCAgeCredentialType)
+	if err != nil {
+		return nil, err
+	}
+	credential, err := core.NewClaim(utils.CreateSchemaHash([]byte(schemaID)),
+		core.WithIndexID(user.ID),
+		core.WithIndexDataInts(big.NewInt(Birthday), big.NewInt(DocumentType)),
+		core.WithRevocationNonce(uint64(time.Now().UnixNano())))
+	if err != nil {
+		return nil, err
+	}
+	if err := issuer.AddClaim(ctx, credential); err != nil {
+		return nil, err
+	}
+	issuerState, err := issuer.State()
+	if err != nil {
+		return nil, err
+	}
+	resolver.Publish(issuer.ID, issuerState.BigInt())
+
+	// the user is a genesis identity, so it is proven not to be in the tree
+	gist, err := merkletree.NewMerkleTree(ctx, memory.NewMemoryStorage(), gistLevels)
+	if err != nil {
+		return nil, err
+	}
+	resolver.PublishRoot(gist.Root().BigInt())
+
+	return &Wallet{User: user, Issuer: issuer, Credential: credential, prover: prover, gist: gist}, nil
+}
+
+// Respond answers every scope of request with a proof of the credential, and packs the answer in a JWZ token
+// proven with the authV2 circuit
+func (w *Wallet) Respond(ctx context.Context, request protocol.AuthorizationRequestMessage) (string, error) {
+	response := protocol.AuthorizationResponseMessage{
+		ID:       uuid.NewString(),
+		Typ:      packers.MediaTypeZKPMessage,
+		Type:     protocol.AuthorizationResponseMessageType,
+		ThreadID: request.ThreadID,
+		From:     w.User.DID.String(),
+		To:       request.From,
+		Body: protocol.AuthorizationMessageResponseBody{
+			Message: request.Body.Message,
+			Scope:   make([]protocol.ZeroKnowledgeProofResponse, 0, len(request.Body.Scope)),
+		},
+	}
+	for _, scope := range request.Body.Scope {
+		proof, err := w.prove(ctx, request.From, scope)
+		if err != nil {
+			return "", fmt.Errorf("failed to prove scope %d: %w", scope.ID, err)
+		}
+		response.Body.Scope = append(response.Body.Scope, protocol.ZeroKnowledgeProofResponse{
+			ID:        scope.ID,
+			CircuitID: scope.CircuitID,
+			ZKProof:   *proof,
+		})
+	}
+
+	payload, err := json.Marshal(response)
+	if err != nil {
+		return "", err
+	}
+	provingKey, wasm, err := w.prover.Files(circuits.AuthV2CircuitID)
+	if err != nil {
+		return "", err
+	}
+	packer := packers.NewZKPPacker(map[jwz.ProvingMethodAlg]packers.ProvingParams{
+		jwz.AuthV2Groth16Alg: packers.NewProvingParams(w.authInputs(ctx), provingKey, wasm),
+	}, nil)
+	token, err := packer.Pack(payload, packers.ZKPPackerParams{SenderID: &w.User.DID, ProvingMethodAlg: jwz.AuthV2Groth16Alg})
+	if err != nil {
+		return "", err
+	}
+	return string(token), nil
+}
+
+// authInputs returns the preparer of the authV2 inputs, that signs the hash of the message as the challenge
+func (w *Wallet) authInputs(ctx context.Context) packers.DataPreparerHandlerFunc {
+	return func(hash []byte, _ *w3c.DID, _ circuits.CircuitID) ([]byte, error) {
+		authProof, err := w.User.ClaimProof(ctx, w.User.AuthClaim)
+		if err != nil {
+			return nil, err
+		}
+		authNonRevProof, err := w.User.NonRevocationProof(ctx, w.User.AuthClaim)
+		if err != nil {
+			return nil, err
+		}
+		gistKey, err := merkletree.HashElems(w.User.ID.BigInt())
+		if err != nil {
+			return nil, err
+		}
+		gistProof, _, err := w.gist.GenerateProof(ctx, gistKey.BigInt(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return circuits.AuthV2Inputs{
+			GenesisID:          &w.User.ID,
+			ProfileNonce:       big.NewInt(0),
+			AuthClaim:          w.User.AuthClaim,
+			AuthClaimIncMtp:    authProof.Proof,
+			AuthClaimNonRevMtp: authNonRevProof.Proof,
+			TreeState:          authProof.TreeState,
+			GISTProof:          circuits.GISTProof{Root: w.gist.Root(), Proof: gistProof},
+			Signature:          w.User.Sign(hash),
+			Challenge:          new(big.Int).SetBytes(hash),
+		}.InputsMarshal()
+	}
+}
+
+// prove proves the query of scope, for the verifier of verifierDID
+func (w *Wallet) prove(ctx context.Context, verifierDID string, scope protocol.ZeroKnowledgeProofRequest) (*types.ZKProof, error) {
+	query, err := w.query(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	claim, err := w.claimWithSigProof(ctx)
+	if err != nil {
+		return nil, err
+	}
+	skipRevocationCheck, _ := scope.Query["skipClaimRevocationCheck"].(bool)
+
+	var inputs []byte
+	switch circuits.CircuitID(scope.CircuitID) {
+	case circuits.AtomicQuerySigV2CircuitID:
+		inputs, err = circuits.AtomicQuerySigV2Inputs{
+			RequestID:                new(big.Int).SetUint64(uint64(scope.ID)),
+			ID:                       &w.User.ID,
+			ProfileNonce:             big.NewInt(0),
+			ClaimSubjectProfileNonce: big.NewInt(0),
+			Claim:                    claim,
+			SkipClaimRevocationCheck: skipRevocationCheck,
+			Query:                    query,
+			CurrentTimeStamp:         time.Now().Unix(),
+		}.InputsMarshal()
+	case circuits.AtomicQueryV3CircuitID:
+		v3 := circuits.AtomicQueryV3Inputs{
+			RequestID:                new(big.Int).SetUint64(uint64(scope.ID)),
+			ID:                       &w.User.ID,
+			ProfileNonce:             big.NewInt(0),
+			ClaimSubjectProfileNonce: big.NewInt(0),
+			Claim: circuits.ClaimWithSigAndMTPProof{
+				IssuerID:       claim.IssuerID,
+				Claim:          claim.Claim,
+				NonRevProof:    claim.NonRevProof,
+				SignatureProof: &claim.SignatureProof,
+			},
+			SkipClaimRevocationCheck: skipRevocationCheck,
+			Query:                    query,
+			CurrentTimeStamp:         time.Now().Unix(),
+			ProofType:                circuits.BJJSignatureProofType,
+		}
+		if proofType, _ := scope.Query["proofType"].(string); proofType == string(circuits.Iden3SparseMerkleTreeProofType) {
+			incProof, err := w.Issuer.ClaimProof(ctx, w.Credential)
+			if err != nil {
+				return nil, err
+			}
+			v3.ProofType, v3.Claim.IncProof, v3.Claim.SignatureProof = circuits.Iden3SparseMerkleTreeProofType, &incProof, nil
+		}
+		if err := nullifierSession(verifierDID, scope.Params, &v3); err != nil {
+			return nil, err
+		}
+		inputs, err = v3.InputsMarshal()
+	default:
+		return nil, fmt.Errorf("circuit %s is not supported by the wallet", scope.CircuitID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return w.prover.Prove(circuits.CircuitID(scope.CircuitID), inputs)
+}
+
+// query returns the circuit query of the query of scope, as the verifier parses it
+func (w *Wallet) query(ctx context.Context, scope protocol.ZeroKnowledgeProofRequest) (circuits.Query, error) {
+	if contextURL, _ := scope.Query["context"].(string); contextURL != KYCContextURL {
+		return circuits.Query{}, fmt.Errorf("the wallet has no credential of context %q", contextURL)
+	}
+	if typ, _ := scope.Query["type"].(string); typ != KYCAgeCredentialType {
+		return circuits.Query{}, fmt.Errorf("the wallet has no credential of type %q", typ)
+	}
+
+	credentialSubject, _ := scope.Query["credentialSubject"].(map[string]interface{})
+	metadata, err := pubsignals.ParseQueriesMetadata(ctx, KYCAgeCredentialType, string(kycContext), credentialSubject,
+		merklize.Options{DocumentLoader: DocumentLoader{}})
+	if err != nil {
+		return circuits.Query{}, err
+	}
+	switch len(metadata) {
+	case 0:
+		return circuits.Query{Operator: circuits.NOOP, Values: []*big.Int{}}, nil
+	case 1:
+		return circuits.Query{Operator: metadata[0].Operator, Values: metadata[0].Values, SlotIndex: metadata[0].SlotIndex}, nil
+	default:
+		return circuits.Query{}, errors.New("the wallet proves one field per query")
+	}
+}
+
+// claimWithSigProof returns the credential with the signature of the issuer and the proofs of the current state
+// of the issuer
+func (w *Wallet) claimWithSigProof(ctx context.Context) (circuits.ClaimWithSigProof, error) {
+	signature, err := w.Issuer.SignClaim(w.Credential)
+	if err != nil {
+		return circuits.ClaimWithSigProof{}, err
+	}
+	nonRevProof, err := w.Issuer.NonRevocationProof(ctx, w.Credential)
+	if err != nil {
+		return circuits.ClaimWithSigProof{}, err
+	}
+	authProof, err := w.Issuer.ClaimProof(ctx, w.Issuer.AuthClaim)
+	if err != nil {
+		return circuits.ClaimWithSigProof{}, err
+	}
+	authNonRevProof, err := w.Issuer.NonRevocationProof(ctx, w.Issuer.AuthClaim)
+	if err != nil {
+		return circuits.ClaimWithSigProof{}, err
+	}
+	return circuits.ClaimWithSigProof{
+		IssuerID:    &w.Issuer.ID,
+		Claim:       w.Credential,
+		NonRevProof: nonRevProof,
+		SignatureProof: circuits.BJJSignatureProof{
+			Signature:             signature,
+			IssuerAuthClaim:       w.Issuer.AuthClaim,
+			IssuerAuthIncProof:    authProof,
+			IssuerAuthNonRevProof: authNonRevProof,
+		},
+	}, nil
+}
+
+// nullifierSession sets the verifier and the nullifier session of the params of a V3 scope to its inputs
+func nullifierSession(verifierDID string, params map[string]interface{}, inputs *circuits.AtomicQueryV3Inputs) error {
+	sessionID, ok := params[pubsignals.ParamNameNullifierSessionID]
+	if !ok {
+		return nil
+	}
+	nullifierSessionID, ok := new(big.Int).SetString(fmt.Sprint(sessionID), 10)
+	if !ok {
+		return fmt.Errorf("invalid nullifier session id %v", sessionID)
+	}
+	did, err := w3c.ParseDID(verifierDID)
+	if err != nil {
+		return err
+	}
+	verifierID, err := core.IDFromDID(*did)
+	if err != nil {
+		return err
+	}
+	inputs.VerifierID, inputs.NullifierSessionID = &verifierID, nullifierSessionID
+	return nil
+}
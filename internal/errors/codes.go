@@ -0,0 +1,121 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+// Code is the machine-readable cause of a failed verification, so clients can react to it
+// without parsing the messages of the verification libraries
+type Code string
+
+// Verification error codes
+const (
+	CodeExpiredState         Code = "EXPIRED_STATE"
+	CodeRevokedCredential    Code = "REVOKED_CREDENTIAL"
+	CodeExpiredCredential    Code = "EXPIRED_CREDENTIAL"
+	CodeInvalidProof         Code = "INVALID_PROOF"
+	CodeQueryMismatch        Code = "QUERY_MISMATCH"
+	CodeIssuerNotAllowed     Code = "ISSUER_NOT_ALLOWED"
+	CodeNullifierAlreadyUsed Code = "NULLIFIER_ALREADY_USED"
+	CodeStateReverted        Code = "STATE_REVERTED"
+	CodeScopesNotVerified    Code = "SCOPES_NOT_VERIFIED"
+	CodeRPCError             Code = "RPC_ERROR"
+	CodeVerificationFailed   Code = "VERIFICATION_FAILED"
+)
+
+// i18nCodes classifies the errors raised by the verifier checks
+var i18nCodes = map[i18n.Code]Code{
+	i18n.CodeCredentialExpired:       CodeExpiredCredential,
+	i18n.CodeProofOutdated:           CodeExpiredCredential,
+	i18n.CodeTrustProfileProofAge:    CodeExpiredCredential,
+	i18n.CodeIssuerNotAllowed:        CodeIssuerNotAllowed,
+	i18n.CodeTrustProfileIssuer:      CodeIssuerNotAllowed,
+	i18n.CodeScopeNotSatisfied:       CodeQueryMismatch,
+	i18n.CodeTrustProfileSchema:      CodeQueryMismatch,
+	i18n.CodeTrustProfileOperator:    CodeQueryMismatch,
+	i18n.CodeTrustProfileRevocation:  CodeQueryMismatch,
+	i18n.CodeNullifierAlreadyUsed:    CodeNullifierAlreadyUsed,
+	i18n.CodeStateReverted:           CodeStateReverted,
+	i18n.CodeOnChainProofsReplaced:   CodeExpiredState,
+	i18n.CodeCredentialsRevokedSince: CodeRevokedCredential,
+	i18n.CodeScopesNotVerified:       CodeScopesNotVerified,
+}
+
+// sentinels classifies the errors of the verification library
+var sentinels = []struct {
+	err  error
+	code Code
+}{
+	{pubsignals.ErrGlobalStateIsNotValid, CodeExpiredState},
+	{pubsignals.ErrIssuerClaimStateIsNotValid, CodeExpiredState},
+	{pubsignals.ErrProofGenerationOutdated, CodeExpiredState},
+	// the non-revocation proof was built on a replaced issuer state, a replaced state alone does not revoke the credential
+	{pubsignals.ErrIssuerNonRevocationClaimStateIsNotValid, CodeExpiredState},
+	{pubsignals.ErrUnavailableIssuer, CodeIssuerNotAllowed},
+	{pubsignals.ErrSchemaID, CodeQueryMismatch},
+	{pubsignals.ErrRequestOperator, CodeQueryMismatch},
+	{pubsignals.ErrValuesSize, CodeQueryMismatch},
+	{pubsignals.ErrInvalidValues, CodeQueryMismatch},
+}
+
+// messages classifies the errors of the verification library that are not exported, by their message
+var messages = []struct {
+	substr string
+	code   Code
+}{
+	{"state is not latest", CodeExpiredState},
+	{"state was replaced", CodeExpiredState},
+	{"zero knowledge proof of jwz is not valid", CodeInvalidProof},
+	{"protocol is not supported", CodeInvalidProof},
+	{"invalid requestID in proof", CodeInvalidProof},
+	{"sender is not used for proof creation", CodeInvalidProof},
+	{"query hashes do not match", CodeQueryMismatch},
+	{"proof was generated for another", CodeQueryMismatch},
+	{"disclosed value", CodeQueryMismatch},
+	{"different value between proof and disclosure value", CodeQueryMismatch},
+	{"has different circuit id than requested", CodeQueryMismatch},
+	{"was not presented in the response", CodeQueryMismatch},
+	{"execution reverted", CodeRPCError},
+}
+
+// Classify returns the code of a verification error. Errors that cannot be classified are VERIFICATION_FAILED.
+func Classify(err error) Code {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if ie, ok := e.(*i18n.Error); ok {
+			if code, ok := i18nCodes[ie.Code]; ok {
+				return code
+			}
+		}
+	}
+	for _, s := range sentinels {
+		if errors.Is(err, s.err) {
+			return s.code
+		}
+	}
+	if isRPCError(err) {
+		return CodeRPCError
+	}
+	msg := err.Error()
+	for _, m := range messages {
+		if strings.Contains(msg, m.substr) {
+			return m.code
+		}
+	}
+	return CodeVerificationFailed
+}
+
+func isRPCError(err error) bool {
+	var netErr net.Error
+	var httpErr rpc.HTTPError
+	var rpcErr rpc.Error
+	return errors.As(err, &netErr) || errors.As(err, &httpErr) || errors.As(err, &rpcErr) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+func TestClassify(t *testing.T) {
+	type testConfig struct {
+		name     string
+		err      error
+		expected Code
+	}
+	for _, tc := range []testConfig{
+		{
+			name:     "outdated global state",
+			err:      i18n.Wrap(pubsignals.ErrGlobalStateIsNotValid, i18n.CodeVerificationFailed, pubsignals.ErrGlobalStateIsNotValid.Error()),
+			expected: CodeExpiredState,
+		},
+		{
+			name:     "outdated non-revocation state",
+			err:      pubsignals.ErrIssuerNonRevocationClaimStateIsNotValid,
+			expected: CodeExpiredState,
+		},
+		{
+			name:     "on-chain proofs replaced",
+			err:      i18n.Wrap(i18n.New(i18n.CodeOnChainProofsReplaced, "0x9b3F1d5C0A8F7fBc3c1a7C7dB3fE3a9B6a6A2fB1"), i18n.CodeVerificationStale, "replaced"),
+			expected: CodeExpiredState,
+		},
+		{
+			name:     "credentials revoked since the non-revocation proof",
+			err:      i18n.Wrap(i18n.New(i18n.CodeCredentialsRevokedSince, "did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK", 1), i18n.CodeVerificationRevoked, "revoked"),
+			expected: CodeRevokedCredential,
+		},
+		{
+			name:     "unexported library error",
+			err:      i18n.Wrap(errors.New("zero knowledge proof of jwz is not valid"), i18n.CodeVerificationFailed, "zero knowledge proof of jwz is not valid"),
+			expected: CodeInvalidProof,
+		},
+		{
+			name:     "query mismatch",
+			err:      fmt.Errorf("query hashes do not match"),
+			expected: CodeQueryMismatch,
+		},
+		{
+			name:     "issuer policy",
+			err:      i18n.New(i18n.CodeIssuerNotAllowed, "did:iden3:issuer", "KYCAgeCredential"),
+			expected: CodeIssuerNotAllowed,
+		},
+		{
+			name:     "expired credential",
+			err:      i18n.New(i18n.CodeCredentialExpired, 1, "2025-01-01T00:00:00Z"),
+			expected: CodeExpiredCredential,
+		},
+		{
+			name:     "rpc timeout",
+			err:      fmt.Errorf("failed to get state: %w", context.DeadlineExceeded),
+			expected: CodeRPCError,
+		},
+		{
+			name:     "rpc http error",
+			err:      fmt.Errorf("failed to get state: %w", rpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"}),
+			expected: CodeRPCError,
+		},
+		{
+			name:     "unknown",
+			err:      errors.New("unexpected"),
+			expected: CodeVerificationFailed,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Classify(tc.err))
+		})
+	}
+}
@@ -1,8 +1,17 @@
 package errors
 
-import "net/http"
+import (
+	"errors"
+	"net/http"
+)
 
-// RequestErrorHandlerFunc is a Request Error Handler that can be injected in oapi-codegen to handler errors in requests
+// RequestErrorHandlerFunc is a Request Error Handler that can be injected in oapi-codegen to handler errors in requests.
+// Bodies cut by the size limit of the requests are reported with a 413.
 func RequestErrorHandlerFunc(w http.ResponseWriter, _ *http.Request, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
 	http.Error(w, err.Error(), http.StatusBadRequest)
 }
@@ -0,0 +1,169 @@
+// Package events publishes the lifecycle events of the sessions to a message broker, so downstream systems
+// receive them without polling the API
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+const (
+	// SchemaVersion is the version of the schema of the events, it changes when a field is removed or changes its meaning
+	SchemaVersion = 1
+
+	// TypeSessionCreated is published when a sign-in creates a session
+	TypeSessionCreated = "session.created"
+	// TypeVerificationSucceeded is published when the proofs of the callback of a session are verified
+	TypeVerificationSucceeded = "verification.succeeded"
+	// TypeVerificationFailed is published when the callback of a session fails the verification
+	TypeVerificationFailed = "verification.failed"
+	// TypeVerificationStale is published when a successful verification relies on a state that was replaced since
+	TypeVerificationStale = "verification.stale"
+	// TypeVerificationRevoked is published when the credential of a successful verification may have been revoked since
+	TypeVerificationRevoked = "verification.revoked"
+
+	publishTimeout = 10 * time.Second
+	publishRetries = 3
+	retryDelay     = time.Second
+	closeTimeout   = 5 * time.Second
+)
+
+var errClosed = errors.New("event bus is closed")
+
+// Event is a lifecycle event of a session. ErrorCode and Error are set on failed verifications.
+type Event struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	Time          time.Time `json:"time"`
+	SessionID     string    `json:"sessionID"`
+	Tenant        string    `json:"tenant,omitempty"`
+	CircuitIDs    []string  `json:"circuitIDs,omitempty"`
+	UserDID       string    `json:"userDID,omitempty"`
+	ErrorCode     string    `json:"errorCode,omitempty"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// Publisher delivers the events to a broker
+type Publisher interface {
+	Publish(ctx context.Context, event Event, payload []byte) error
+	Close() error
+}
+
+// New creates the publisher of the driver of cfg
+func New(cfg config.Events) (Publisher, error) {
+	switch cfg.Driver {
+	case config.EventsDriverNATS:
+		return NewNATS(cfg.URL, cfg.Topic)
+	case config.EventsDriverKafka:
+		return NewKafka(cfg.URL, cfg.Topic), nil
+	default:
+		return nil, fmt.Errorf("invalid events driver %s, expected %s or %s", cfg.Driver, config.EventsDriverNATS, config.EventsDriverKafka)
+	}
+}
+
+// Bus queues the events and publishes them in the background, so a slow or unavailable broker never delays the
+// sign-ins and the callbacks. Events are dropped when the queue is full, and after a few failed attempts.
+type Bus struct {
+	publisher Publisher
+	queue     chan Event
+	done      chan struct{}
+
+	dropped atomic.Int64
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewBus creates a Bus of at most queueSize pending events and starts publishing them
+func NewBus(publisher Publisher, queueSize int) *Bus {
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	b := &Bus{
+		publisher: publisher,
+		queue:     make(chan Event, queueSize),
+		done:      make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Emit queues the event, setting its id, time and schema version
+func (b *Bus) Emit(event Event) {
+	event.SchemaVersion = SchemaVersion
+	event.ID = uuid.NewString()
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return
+	}
+	select {
+	case b.queue <- event:
+	default:
+		b.dropped.Add(1)
+		log.WithFields(log.Fields{"type": event.Type, "sessionID": event.SessionID}).Warn("event queue is full, event dropped")
+	}
+}
+
+// Dropped returns the number of events dropped because the queue was full
+func (b *Bus) Dropped() int64 {
+	return b.dropped.Load()
+}
+
+// Close publishes the queued events, for at most 5 seconds, and closes the publisher
+func (b *Bus) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return errClosed
+	}
+	b.closed = true
+	close(b.queue)
+	b.mu.Unlock()
+
+	select {
+	case <-b.done:
+	case <-time.After(closeTimeout):
+		log.Warn("timeout publishing the queued events")
+	}
+	return b.publisher.Close()
+}
+
+func (b *Bus) run() {
+	defer close(b.done)
+	for event := range b.queue {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			log.WithFields(log.Fields{"type": event.Type, "err": err}).Error("failed to encode event")
+			continue
+		}
+		for attempt := 1; ; attempt++ {
+			ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+			err = b.publisher.Publish(ctx, event, payload)
+			cancel()
+			if err == nil {
+				break
+			}
+			if attempt == publishRetries {
+				log.WithFields(log.Fields{"type": event.Type, "sessionID": event.SessionID, "err": err}).Error("failed to publish event")
+				break
+			}
+			time.Sleep(retryDelay * time.Duration(attempt))
+		}
+	}
+}
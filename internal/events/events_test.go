@@ -0,0 +1,182 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type blockingPublisher struct {
+	release chan struct{}
+	fail    int
+
+	mu       sync.Mutex
+	attempts int
+	events   []Event
+}
+
+func (p *blockingPublisher) Publish(_ context.Context, event Event, _ []byte) error {
+	<-p.release
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.attempts++
+	if p.attempts <= p.fail {
+		return errors.New("broker unavailable")
+	}
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *blockingPublisher) Close() error {
+	return nil
+}
+
+func TestBus(t *testing.T) {
+	publisher := &blockingPublisher{release: make(chan struct{})}
+	bus := NewBus(publisher, 1)
+
+	// the first event is being published, the second one waits in the queue and the third one is dropped
+	bus.Emit(Event{Type: TypeSessionCreated, SessionID: "s1"})
+	require.Eventually(t, func() bool { return len(bus.queue) == 0 }, time.Second, time.Millisecond)
+	bus.Emit(Event{Type: TypeSessionCreated, SessionID: "s2"})
+	bus.Emit(Event{Type: TypeSessionCreated, SessionID: "s3"})
+	assert.Equal(t, int64(1), bus.Dropped())
+
+	close(publisher.release)
+	require.NoError(t, bus.Close())
+	require.Len(t, publisher.events, 2)
+	assert.Equal(t, "s1", publisher.events[0].SessionID)
+	assert.Equal(t, "s2", publisher.events[1].SessionID)
+	assert.Equal(t, SchemaVersion, publisher.events[0].SchemaVersion)
+	assert.NotEmpty(t, publisher.events[0].ID)
+	assert.False(t, publisher.events[0].Time.IsZero())
+
+	// events emitted after Close are ignored
+	bus.Emit(Event{Type: TypeSessionCreated, SessionID: "s4"})
+	assert.Len(t, publisher.events, 2)
+	assert.Error(t, bus.Close())
+}
+
+func TestBusRetries(t *testing.T) {
+	publisher := &blockingPublisher{release: make(chan struct{}), fail: 1}
+	close(publisher.release)
+	bus := NewBus(publisher, 10)
+	bus.Emit(Event{Type: TypeVerificationSucceeded, SessionID: "s1"})
+	require.NoError(t, bus.Close())
+	assert.Equal(t, 2, publisher.attempts)
+	require.Len(t, publisher.events, 1)
+}
+
+// fakeNATS accepts one connection, answers the pings and records the published messages
+func fakeNATS(t *testing.T, reject string) (string, <-chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	messages := make(chan string, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = conn.Write([]byte(`INFO {"server_id":"test","max_payload":1048576}` + "\r\n"))
+		reader := bufio.NewReader(conn)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(line, "CONNECT "):
+				messages <- line
+			case strings.HasPrefix(line, "PUB "):
+				var subject string
+				var size int
+				_, _ = fmt.Sscanf(line, "PUB %s %d", &subject, &size)
+				payload := make([]byte, size+2)
+				if _, err := io.ReadFull(reader, payload); err != nil {
+					return
+				}
+				if subject == reject {
+					_, _ = conn.Write([]byte("-ERR 'Permissions Violation for Publish to " + subject + "'\r\n"))
+					continue
+				}
+				messages <- subject + " " + string(payload[:size])
+			case line == "PING":
+				_, _ = conn.Write([]byte("PONG\r\n"))
+			}
+		}
+	}()
+	return listener.Addr().String(), messages
+}
+
+func TestNATS(t *testing.T) {
+	addr, messages := fakeNATS(t, "verifier.sessions.verification.failed")
+	publisher, err := NewNATS("nats://user:pass@"+addr, "verifier.sessions")
+	require.NoError(t, err)
+	defer publisher.Close()
+
+	ctx := context.Background()
+	event := Event{SchemaVersion: SchemaVersion, Type: TypeSessionCreated, SessionID: "s1"}
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+	require.NoError(t, publisher.Publish(ctx, event, payload))
+
+	connect := <-messages
+	assert.Contains(t, connect, `"user":"user"`)
+	assert.Contains(t, connect, `"pass":"pass"`)
+	assert.Equal(t, "verifier.sessions.session.created "+string(payload), <-messages)
+
+	err = publisher.Publish(ctx, Event{Type: TypeVerificationFailed}, []byte(`{}`))
+	assert.ErrorContains(t, err, "Permissions Violation")
+
+	_, err = NewNATS("http://"+addr, "verifier.sessions")
+	assert.Error(t, err)
+	_, err = NewNATS("nats://"+addr, "verifier sessions")
+	assert.Error(t, err)
+}
+
+func TestKafka(t *testing.T) {
+	var (
+		path string
+		body map[string][]map[string]json.RawMessage
+	)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		assert.Equal(t, kafkaContentType, r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if strings.HasSuffix(path, "/failing") {
+			_, _ = w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":40403,"error":"topic not authorized"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":12,"error_code":null,"error":null}]}`))
+	}))
+	defer proxy.Close()
+
+	event := Event{SchemaVersion: SchemaVersion, Type: TypeSessionCreated, SessionID: "s1"}
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+	require.NoError(t, NewKafka(proxy.URL+"/", "verifier.sessions").Publish(context.Background(), event, payload))
+	assert.Equal(t, "/topics/verifier.sessions", path)
+	require.Len(t, body["records"], 1)
+	assert.JSONEq(t, `"s1"`, string(body["records"][0]["key"]))
+	assert.JSONEq(t, string(payload), string(body["records"][0]["value"]))
+
+	err = NewKafka(proxy.URL, "failing").Publish(context.Background(), event, payload)
+	assert.ErrorContains(t, err, "topic not authorized")
+}
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	kafkaContentType     = "application/vnd.kafka.json.v2+json"
+	maxKafkaResponseSize = 1 << 16
+)
+
+// Kafka publishes the events to a topic through a Kafka REST proxy (the v2 API of the Confluent REST proxy).
+// The events are keyed by their session, so the events of a session are kept in order in a partition.
+type Kafka struct {
+	url    string
+	client *http.Client
+}
+
+// NewKafka creates a Kafka publisher to the topic of the REST proxy at proxyURL
+func NewKafka(proxyURL, topic string) *Kafka {
+	return &Kafka{
+		url:    strings.TrimSuffix(proxyURL, "/") + "/topics/" + url.PathEscape(topic),
+		client: &http.Client{Timeout: publishTimeout},
+	}
+}
+
+// Publish produces the event to the topic
+func (k *Kafka) Publish(ctx context.Context, event Event, payload []byte) error {
+	body, err := json.Marshal(map[string]any{
+		"records": []map[string]any{{"key": event.SessionID, "value": json.RawMessage(payload)}},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxKafkaResponseSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s from kafka rest proxy: %s", resp.Status, data)
+	}
+
+	var result struct {
+		Offsets []struct {
+			ErrorCode *int   `json:"error_code"`
+			Error     string `json:"error"`
+		} `json:"offsets"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("invalid response of kafka rest proxy: %w", err)
+	}
+	for _, offset := range result.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("kafka rest proxy failed to produce the event: %d %s", *offset.ErrorCode, offset.Error)
+		}
+	}
+	return nil
+}
+
+// Close does nothing, the requests to the proxy do not hold a connection
+func (k *Kafka) Close() error {
+	return nil
+}
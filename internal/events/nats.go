@@ -0,0 +1,162 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	natsDialTimeout = 5 * time.Second
+	natsClientName  = "verifier-backend"
+)
+
+// NATS publishes the events to the subject <subject>.<event type> of a NATS server, with the core NATS protocol.
+// The url is nats://[user:password@|token@]host:port, or tls:// for a TLS connection. Every publication is flushed
+// with a PING, so an event is only considered published once the server has processed it.
+type NATS struct {
+	url     *url.URL
+	subject string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewNATS creates a NATS publisher, the connection is opened on the first event and opened again after a failure
+func NewNATS(rawURL, subject string) (*NATS, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return nil, fmt.Errorf("invalid nats url %s, expected a nats:// or tls:// url", rawURL)
+	}
+	if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	if strings.ContainsAny(subject, " \t\r\n") || subject == "" {
+		return nil, fmt.Errorf("invalid nats subject %q", subject)
+	}
+	return &NATS{url: u, subject: subject}, nil
+}
+
+// Publish publishes the payload of the event
+func (n *NATS) Publish(ctx context.Context, event Event, payload []byte) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn == nil {
+		if err := n.connect(ctx); err != nil {
+			return err
+		}
+	}
+	if err := n.publish(ctx, n.subject+"."+event.Type, payload); err != nil {
+		_ = n.conn.Close()
+		n.conn = nil
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection to the server
+func (n *NATS) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.conn == nil {
+		return nil
+	}
+	err := n.conn.Close()
+	n.conn = nil
+	return err
+}
+
+func (n *NATS) connect(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: natsDialTimeout}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if n.url.Scheme == "tls" {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: n.url.Hostname(), MinVersion: tls.VersionTLS12}}).
+			DialContext(ctx, "tcp", n.url.Host)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", n.url.Host)
+	}
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		_ = conn.Close()
+		return fmt.Errorf("unexpected nats greeting %q", strings.TrimSpace(line))
+	}
+
+	options := map[string]any{"verbose": false, "pedantic": false, "name": natsClientName, "lang": "go", "protocol": 1}
+	if user := n.url.User; user != nil {
+		if password, ok := user.Password(); ok {
+			options["user"], options["pass"] = user.Username(), password
+		} else {
+			options["auth_token"] = user.Username()
+		}
+	}
+	b, err := json.Marshal(options)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\n", b); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	n.conn, n.reader = conn, reader
+	return nil
+}
+
+// publish sends the message followed by a PING, and waits for the PONG or for the error of the server
+func (n *NATS) publish(ctx context.Context, subject string, payload []byte) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(publishTimeout)
+	}
+	if err := n.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(n.conn, "PUB %s %d\r\n%s\r\nPING\r\n", subject, len(payload), payload); err != nil {
+		return err
+	}
+	for {
+		line, err := n.reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := n.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New("nats: " + strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
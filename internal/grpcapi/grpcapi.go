@@ -0,0 +1,299 @@
+// Package grpcapi serves the sign-in, the status and the management of the sessions over gRPC, with the same handlers as
+// the REST API
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/0xPolygonID/verifier-backend/internal/api"
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/pkg/verifierpb"
+)
+
+const (
+	// APIKeyMetadata is the metadata with the API key, the X-API-Key header of the REST API
+	APIKeyMetadata = "x-api-key"
+	// LanguageMetadata is the metadata with the language of the messages, the Accept-Language header of the REST API
+	LanguageMetadata = "accept-language"
+)
+
+// Server implements verifierpb.VerifierServer with the handlers of the REST API
+type Server struct {
+	verifierpb.UnimplementedVerifierServer
+	handler api.StrictServerInterface
+}
+
+// NewServer creates a grpc.Server with the Verifier service of handler
+func NewServer(handler api.StrictServerInterface, opts ...grpc.ServerOption) *grpc.Server {
+	srv := grpc.NewServer(append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(language)}, opts...)...)
+	verifierpb.RegisterVerifierServer(srv, &Server{handler: handler})
+	return srv
+}
+
+// ReadOnly rejects every call but Status, as the REST API of the read-only replicas does
+func ReadOnly(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if info.FullMethod == verifierpb.Verifier_Status_FullMethodName {
+		return handler(ctx, req)
+	}
+	return nil, status.Error(codes.Unavailable, i18n.Message(ctx, i18n.CodeReadOnlyReplica))
+}
+
+// SignIn creates a session
+func (s *Server) SignIn(ctx context.Context, req *verifierpb.SignInRequest) (*verifierpb.SignInResponse, error) {
+	body := api.SignInRequest{
+		ChainID:                req.ChainId,
+		EnforceUniqueNullifier: req.EnforceUniqueNullifier,
+		Reason:                 req.Reason,
+		To:                     req.To,
+		TransactionData:        transactionData(req.TransactionData),
+		TrustProfile:           req.TrustProfile,
+	}
+	if req.RequiredScopes != nil {
+		body.RequiredScopes = common.ToPointer(int(*req.RequiredScopes))
+	}
+	if len(req.Tags) > 0 {
+		body.Tags = &req.Tags
+	}
+	for _, scope := range req.Scope {
+		r := api.ScopeRequest{
+			Id:              scope.Id,
+			CircuitId:       scope.CircuitId,
+			Query:           scope.Query.AsMap(),
+			Template:        scope.Template,
+			TransactionData: transactionData(scope.TransactionData),
+		}
+		if scope.Params != nil {
+			params := scope.Params.AsMap()
+			r.Params = &params
+		}
+		body.Scope = append(body.Scope, r)
+	}
+
+	resp, err := s.handler.SignIn(ctx, api.SignInRequestObject{
+		Params: api.SignInParams{XAPIKey: apiKey(ctx)},
+		Body:   &body,
+	})
+	var out api.SingInResponse
+	if err := reply(resp, err, func(w http.ResponseWriter) error { return resp.VisitSignInResponse(w) }, &out); err != nil {
+		return nil, err
+	}
+	return &verifierpb.SignInResponse{
+		SessionId: out.SessionID.String(),
+		QrCode:    out.QrCode,
+		ExpiresAt: timestamppb.New(out.ExpiresAt),
+	}, nil
+}
+
+// Status returns the status of a session
+func (s *Server) Status(ctx context.Context, req *verifierpb.StatusRequest) (*verifierpb.StatusResponse, error) {
+	id, err := sessionID(ctx, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.handler.Status(ctx, api.StatusRequestObject{Params: api.StatusParams{SessionID: id}})
+	var out api.StatusResponse
+	if err := reply(resp, err, func(w http.ResponseWriter) error { return resp.VisitStatusResponse(w) }, &out); err != nil {
+		return nil, err
+	}
+	return statusResponse(out)
+}
+
+// SearchSessions returns the sessions with a tag
+func (s *Server) SearchSessions(ctx context.Context, req *verifierpb.SearchSessionsRequest) (*verifierpb.SearchSessionsResponse, error) {
+	params := api.SearchSessionsParams{Tag: req.Tag, XAPIKey: apiKey(ctx)}
+	if req.Status != nil {
+		params.Status = common.ToPointer(api.SearchSessionsParamsStatus(*req.Status))
+	}
+	resp, err := s.handler.SearchSessions(ctx, api.SearchSessionsRequestObject{Params: params})
+	var out []api.TaggedSession
+	if err := reply(resp, err, func(w http.ResponseWriter) error { return resp.VisitSearchSessionsResponse(w) }, &out); err != nil {
+		return nil, err
+	}
+	sessions := make([]*verifierpb.TaggedSession, 0, len(out))
+	for _, session := range out {
+		sessions = append(sessions, &verifierpb.TaggedSession{
+			SessionId: session.SessionID.String(),
+			Status:    session.Status,
+			Tags:      session.Tags,
+			CreatedAt: timestamppb.New(session.CreatedAt),
+		})
+	}
+	return &verifierpb.SearchSessionsResponse{Sessions: sessions}, nil
+}
+
+// FinalizeSession deletes the result of a finished session
+func (s *Server) FinalizeSession(ctx context.Context, req *verifierpb.FinalizeSessionRequest) (*verifierpb.StatusResponse, error) {
+	id, err := sessionID(ctx, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.handler.FinalizeSession(ctx, api.FinalizeSessionRequestObject{
+		SessionID: id,
+		Params:    api.FinalizeSessionParams{XAPIKey: apiKey(ctx)},
+	})
+	var out api.StatusResponse
+	if err := reply(resp, err, func(w http.ResponseWriter) error { return resp.VisitFinalizeSessionResponse(w) }, &out); err != nil {
+		return nil, err
+	}
+	return statusResponse(out)
+}
+
+// GetSessionResult returns the result of a finished session
+func (s *Server) GetSessionResult(ctx context.Context, req *verifierpb.GetSessionResultRequest) (*verifierpb.StatusResponse, error) {
+	id, err := sessionID(ctx, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.handler.GetSessionResult(ctx, api.GetSessionResultRequestObject{
+		SessionID: id,
+		Params:    api.GetSessionResultParams{Consume: &req.Consume, XAPIKey: apiKey(ctx)},
+	})
+	var out api.StatusResponse
+	if err := reply(resp, err, func(w http.ResponseWriter) error { return resp.VisitGetSessionResultResponse(w) }, &out); err != nil {
+		return nil, err
+	}
+	return statusResponse(out)
+}
+
+// language sets the language of the messages from the LanguageMetadata, as i18n.Middleware does for the REST API
+func language(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var acceptLanguage string
+	if values := metadata.ValueFromIncomingContext(ctx, LanguageMetadata); len(values) > 0 {
+		acceptLanguage = values[0]
+	}
+	return handler(i18n.WithLanguage(ctx, i18n.Match(acceptLanguage)), req)
+}
+
+func apiKey(ctx context.Context) *api.ApiKey {
+	values := metadata.ValueFromIncomingContext(ctx, APIKeyMetadata)
+	if len(values) == 0 || values[0] == "" {
+		return nil
+	}
+	return &values[0]
+}
+
+func sessionID(ctx context.Context, id string) (uuid.UUID, error) {
+	sessionID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, i18n.Message(ctx, i18n.CodeSessionIDInvalid))
+	}
+	return sessionID, nil
+}
+
+func transactionData(data *verifierpb.TransactionData) *api.TransactionData {
+	if data == nil {
+		return nil
+	}
+	return &api.TransactionData{
+		ChainID:         int(data.ChainId),
+		ContractAddress: data.ContractAddress,
+		MethodID:        data.MethodId,
+		Network:         data.Network,
+	}
+}
+
+func statusResponse(resp api.StatusResponse) (*verifierpb.StatusResponse, error) {
+	out := &verifierpb.StatusResponse{
+		Status:      resp.Status,
+		Message:     resp.Message,
+		Jwz:         resp.Jwz,
+		Token:       resp.Token,
+		Provisional: resp.Provisional,
+	}
+	if resp.ExpiresAt != nil {
+		out.ExpiresAt = timestamppb.New(*resp.ExpiresAt)
+	}
+	if resp.ErrorCode != nil {
+		out.ErrorCode = common.ToPointer(string(*resp.ErrorCode))
+	}
+	if resp.JwzMetadata != nil {
+		b, err := json.Marshal(resp.JwzMetadata)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		out.JwzMetadata = &structpb.Struct{}
+		if err := out.JwzMetadata.UnmarshalJSON(b); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return out, nil
+}
+
+// reply decodes the 200 response of a handler of the REST API into out, and turns the other responses into the
+// status with the same meaning
+func reply(resp any, err error, visit func(w http.ResponseWriter) error, out any) error {
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if resp == nil {
+		return status.Error(codes.Internal, "empty response")
+	}
+	w := &responseRecorder{header: http.Header{}, code: http.StatusOK}
+	if err := visit(w); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if w.code != http.StatusOK {
+		var msg api.GenericErrorMessage
+		if err := json.Unmarshal(w.body.Bytes(), &msg); err != nil || msg.Message == "" {
+			msg.Message = http.StatusText(w.code)
+		}
+		return status.Error(statusCode(w.code), msg.Message)
+	}
+	if err := json.Unmarshal(w.body.Bytes(), out); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("invalid response: %s", err))
+	}
+	return nil
+}
+
+func statusCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound, http.StatusGone:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// responseRecorder is the http.ResponseWriter the responses of the REST API are written to
+type responseRecorder struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	r.code = code
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
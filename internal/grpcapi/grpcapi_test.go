@@ -0,0 +1,114 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-circuits/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/0xPolygonID/verifier-backend/internal/api"
+	"github.com/0xPolygonID/verifier-backend/internal/common"
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/testmode"
+	"github.com/0xPolygonID/verifier-backend/pkg/verifierpb"
+)
+
+const amoySenderDID = "did:iden3:polygon:amoy:x6x5sor7zpxixYDNyDZKnh3oJZRj7Vzn9jzoCNoxc"
+
+func newClient(t *testing.T, cfg config.Config, opts ...grpc.ServerOption) verifierpb.VerifierClient {
+	t.Helper()
+	mock, err := testmode.NewVerifier("canned-token", "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK",
+		"did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK")
+	require.NoError(t, err)
+	srv := NewServer(api.New(cfg, mock, map[string]string{"80002": amoySenderDID}), opts...)
+	lis := bufconn.Listen(1 << 20)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return verifierpb.NewVerifierClient(conn)
+}
+
+func signInRequest(t *testing.T) *verifierpb.SignInRequest {
+	t.Helper()
+	query, err := structpb.NewStruct(map[string]any{
+		"context":        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+		"allowedIssuers": []any{"*"},
+		"type":           "KYCAgeCredential",
+	})
+	require.NoError(t, err)
+	return &verifierpb.SignInRequest{
+		ChainId: common.ToPointer("80002"),
+		Scope:   []*verifierpb.Scope{{Id: 1, CircuitId: string(circuits.AtomicQuerySigV2CircuitID), Query: query}},
+		Tags:    []string{"campaign:grpc"},
+	}
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t, config.Config{Host: "http://localhost", AdminAPIKeys: []string{"admin-key"}})
+
+	signIn, err := client.SignIn(ctx, signInRequest(t))
+	require.NoError(t, err)
+	assert.NotEmpty(t, signIn.QrCode)
+	_, err = uuid.Parse(signIn.SessionId)
+	require.NoError(t, err)
+
+	resp, err := client.Status(ctx, &verifierpb.StatusRequest{SessionId: signIn.SessionId})
+	require.NoError(t, err)
+	assert.Equal(t, "pending", resp.Status)
+	assert.Equal(t, signIn.ExpiresAt.AsTime(), resp.ExpiresAt.AsTime())
+
+	_, err = client.Status(ctx, &verifierpb.StatusRequest{SessionId: uuid.NewString()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.Status(ctx, &verifierpb.StatusRequest{SessionId: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.SignIn(metadata.AppendToOutgoingContext(ctx, LanguageMetadata, "es"), &verifierpb.SignInRequest{ChainId: common.ToPointer("80002")})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "el campo scope está vacío", status.Convert(err).Message())
+
+	_, err = client.SearchSessions(ctx, &verifierpb.SearchSessionsRequest{Tag: "campaign:grpc"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	sessions, err := client.SearchSessions(metadata.AppendToOutgoingContext(ctx, APIKeyMetadata, "admin-key"),
+		&verifierpb.SearchSessionsRequest{Tag: "campaign:grpc"})
+	require.NoError(t, err)
+	require.Len(t, sessions.Sessions, 1)
+	assert.Equal(t, signIn.SessionId, sessions.Sessions[0].SessionId)
+	assert.Equal(t, []string{"campaign:grpc"}, sessions.Sessions[0].Tags)
+
+	_, err = client.GetSessionResult(ctx, &verifierpb.GetSessionResultRequest{SessionId: signIn.SessionId})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t, config.Config{Host: "http://localhost", ReadOnly: true}, grpc.ChainUnaryInterceptor(ReadOnly))
+
+	_, err := client.SignIn(ctx, signInRequest(t))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	_, err = client.Status(ctx, &verifierpb.StatusRequest{SessionId: uuid.NewString()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,84 @@
+// Package hooks runs the post-processing hooks of the deployments on the verified callbacks, so custom business checks
+// e.g. a ban list of user DIDs can reject a verification before it is marked successful
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/iden3/iden3comm/v2/protocol"
+)
+
+// Session is the session of a verified callback
+type Session struct {
+	ID      string
+	Request protocol.AuthorizationRequestMessage
+}
+
+// Hook is run on the callbacks whose proofs were verified, returning an error rejects the verification
+type Hook interface {
+	OnVerified(ctx context.Context, session Session, response protocol.AuthorizationResponseMessage) error
+}
+
+// Func is a function used as a Hook
+type Func func(ctx context.Context, session Session, response protocol.AuthorizationResponseMessage) error
+
+// OnVerified calls f
+func (f Func) OnVerified(ctx context.Context, session Session, response protocol.AuthorizationResponseMessage) error {
+	return f(ctx, session, response)
+}
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]Hook)
+)
+
+// Register makes a hook available by name to the deployments built with it, usually from the init function of the
+// file of the hook. It panics if a hook is registered twice with the same name.
+func Register(name string, hook Hook) {
+	mu.Lock()
+	defer mu.Unlock()
+	if hook == nil {
+		panic("hooks: Register hook is nil")
+	}
+	if _, ok := registry[name]; ok {
+		panic("hooks: Register called twice for hook " + name)
+	}
+	registry[name] = hook
+}
+
+// Lookup returns the registered hooks of names, in the same order
+func Lookup(names []string) ([]Hook, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	hooks := make([]Hook, 0, len(names))
+	for _, name := range names {
+		hook, ok := registry[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown verification hook %s, the registered hooks are %v", name, registered())
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, nil
+}
+
+func registered() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run runs the hooks in order, and returns the error of the first one that rejects the verification
+func Run(ctx context.Context, hooks []Hook, session Session, response protocol.AuthorizationResponseMessage) error {
+	for _, hook := range hooks {
+		if err := hook.OnVerified(ctx, session, response); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygonID/verifier-backend/internal/webhook"
+)
+
+func TestRegistry(t *testing.T) {
+	var calls []string
+	hook := func(name string, err error) Hook {
+		return Func(func(context.Context, Session, protocol.AuthorizationResponseMessage) error {
+			calls = append(calls, name)
+			return err
+		})
+	}
+	register(t, "test-accept", hook("accept", nil))
+	register(t, "test-reject", hook("reject", errors.New("banned")))
+	assert.Panics(t, func() { Register("test-accept", hook("accept", nil)) })
+
+	_, err := Lookup([]string{"test-accept", "unknown"})
+	assert.ErrorContains(t, err, "unknown verification hook unknown")
+
+	hooks, err := Lookup([]string{"test-accept", "test-reject", "test-accept"})
+	require.NoError(t, err)
+	err = Run(context.Background(), hooks, Session{ID: "session"}, protocol.AuthorizationResponseMessage{})
+	assert.EqualError(t, err, "banned")
+	assert.Equal(t, []string{"accept", "reject"}, calls)
+}
+
+// register registers the hook for the duration of the test, so the test can run several times in the same process
+func register(t *testing.T, name string, hook Hook) {
+	t.Helper()
+	Register(name, hook)
+	t.Cleanup(func() { unregister(name) })
+}
+
+// unregister removes a hook from the registry
+func unregister(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(registry, name)
+}
+
+func TestHTTP(t *testing.T) {
+	var (
+		got       Request
+		signature string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signature = r.Header.Get(webhook.SignatureHeader)
+		require.NoError(t, json.Unmarshal(body, &got))
+		assert.True(t, webhook.Verify([]byte("secret"), body, signature))
+		switch r.URL.Path {
+		case "/reject":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"reason": "user is banned"}`))
+		case "/failing":
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	session := Session{ID: "8f1c4b4e-7c0c-4d4e-9d59-6e0b2d1f6a11", Request: protocol.AuthorizationRequestMessage{ID: "request"}}
+	response := protocol.AuthorizationResponseMessage{From: "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"}
+
+	require.NoError(t, NewHTTP(srv.URL, "secret", time.Second).OnVerified(ctx, session, response))
+	assert.Equal(t, session.ID, got.SessionID)
+	assert.Equal(t, response.From, got.UserDID)
+	assert.Equal(t, "request", got.Request.ID)
+
+	assert.EqualError(t, NewHTTP(srv.URL+"/reject", "secret", time.Second).OnVerified(ctx, session, response), "user is banned")
+	assert.EqualError(t, NewHTTP(srv.URL+"/failing", "secret", time.Second).OnVerified(ctx, session, response),
+		"unexpected status code from verification hook: 502")
+
+	srv.Close()
+	assert.ErrorContains(t, NewHTTP(srv.URL, "secret", time.Second).OnVerified(ctx, session, response), "verification hook unavailable")
+}
@@ -0,0 +1,90 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/iden3/iden3comm/v2/protocol"
+
+	"github.com/0xPolygonID/verifier-backend/internal/webhook"
+)
+
+// maxReasonSize is the size of the largest body of a rejection read from an external hook
+const maxReasonSize = 1 << 12
+
+// Request is the body of the requests sent to an external hook
+type Request struct {
+	SessionID string                                `json:"sessionID"`
+	UserDID   string                                `json:"userDID"`
+	Request   protocol.AuthorizationRequestMessage  `json:"request"`
+	Response  protocol.AuthorizationResponseMessage `json:"response"`
+}
+
+// rejection is the optional body of the responses of an external hook that rejects a verification
+type rejection struct {
+	Reason string `json:"reason"`
+}
+
+// HTTP is a Hook that posts the verified callbacks to an external endpoint. A 2xx response accepts the verification,
+// any other response rejects it with the reason of its body, and so does an endpoint that can't be reached.
+type HTTP struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+// NewHTTP creates the external hook at url. The requests are signed with secret like the events of the session
+// webhook when it is not empty.
+func NewHTTP(url, secret string, timeout time.Duration) *HTTP {
+	return &HTTP{
+		url:    url,
+		secret: []byte(secret),
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// OnVerified posts the verified callback to the endpoint of the hook
+func (h *HTTP) OnVerified(ctx context.Context, session Session, response protocol.AuthorizationResponseMessage) error {
+	body, err := json.Marshal(Request{
+		SessionID: session.ID,
+		UserDID:   response.From,
+		Request:   session.Request,
+		Response:  response,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(h.secret) > 0 {
+		req.Header.Set(webhook.SignatureHeader, webhook.Sign(h.secret, body))
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("verification hook unavailable: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxReasonSize))
+	var r rejection
+	if err := json.Unmarshal(b, &r); err == nil && strings.TrimSpace(r.Reason) != "" {
+		return errors.New(r.Reason)
+	}
+	return fmt.Errorf("unexpected status code from verification hook: %d", resp.StatusCode)
+}
@@ -0,0 +1,278 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Code identifies an error message in the catalog
+type Code string
+
+// Error codes with a localized message
+const (
+	CodeSessionNotFound              Code = "SESSION_NOT_FOUND"
+	CodeScopeEmpty                   Code = "SCOPE_EMPTY"
+	CodeScopeIDNotUnique             Code = "SCOPE_ID_NOT_UNIQUE"
+	CodeFieldEmpty                   Code = "FIELD_EMPTY"
+	CodeQueryFieldEmpty              Code = "QUERY_FIELD_EMPTY"
+	CodeInvalidCircuitID             Code = "INVALID_CIRCUIT_ID"
+	CodeCircuitIDNotSupported        Code = "CIRCUIT_ID_NOT_SUPPORTED"
+	CodeSenderNotFound               Code = "SENDER_NOT_FOUND"
+	CodeVerificationFailed           Code = "VERIFICATION_FAILED"
+	CodeIssuerNotAllowed             Code = "ISSUER_NOT_ALLOWED"
+	CodeAPIKeyRequired               Code = "API_KEY_REQUIRED"
+	CodeAPIKeyInvalid                Code = "API_KEY_INVALID"
+	CodeSandboxChainNotAllowed       Code = "SANDBOX_CHAIN_NOT_ALLOWED"
+	CodeAdminAPIKeyRequired          Code = "ADMIN_API_KEY_REQUIRED"
+	CodeTemplateNotFound             Code = "TEMPLATE_NOT_FOUND"
+	CodeSessionPending               Code = "SESSION_PENDING"
+	CodeSessionConsumed              Code = "SESSION_CONSUMED"
+	CodeSessionForbidden             Code = "SESSION_FORBIDDEN"
+	CodeInvalidTag                   Code = "INVALID_TAG"
+	CodeTooManyTags                  Code = "TOO_MANY_TAGS"
+	CodeQRCodeNotFound               Code = "QR_CODE_NOT_FOUND"
+	CodeCredentialExpired            Code = "CREDENTIAL_EXPIRED"
+	CodeProofOutdated                Code = "PROOF_OUTDATED"
+	CodeNullifierAlreadyUsed         Code = "NULLIFIER_ALREADY_USED"
+	CodeNullifierSessionRequired     Code = "NULLIFIER_SESSION_REQUIRED"
+	CodeTrustProfileNotFound         Code = "TRUST_PROFILE_NOT_FOUND"
+	CodeTrustProfileSchema           Code = "TRUST_PROFILE_SCHEMA"
+	CodeTrustProfileOperator         Code = "TRUST_PROFILE_OPERATOR"
+	CodeTrustProfileIssuer           Code = "TRUST_PROFILE_ISSUER"
+	CodeTrustProfileRevocation       Code = "TRUST_PROFILE_REVOCATION"
+	CodeTrustProfileProofAge         Code = "TRUST_PROFILE_PROOF_AGE"
+	CodeStateReverted                Code = "STATE_REVERTED"
+	CodeScopeNotSatisfied            Code = "SCOPE_NOT_SATISFIED"
+	CodeRequiredScopesInvalid        Code = "REQUIRED_SCOPES_INVALID"
+	CodeRequiredScopesOnChain        Code = "REQUIRED_SCOPES_ON_CHAIN"
+	CodeScopesNotVerified            Code = "SCOPES_NOT_VERIFIED"
+	CodeReadOnlyReplica              Code = "READ_ONLY_REPLICA"
+	CodeSessionExpired               Code = "SESSION_EXPIRED"
+	CodeRequiredScopesLinked         Code = "REQUIRED_SCOPES_LINKED"
+	CodeRequiredScopesTooMany        Code = "REQUIRED_SCOPES_TOO_MANY"
+	CodeSandboxVerificationInvalid   Code = "SANDBOX_VERIFICATION_INVALID"
+	CodeSandboxVerificationLocked    Code = "SANDBOX_VERIFICATION_LOCKED"
+	CodeSandboxRateLimited           Code = "SANDBOX_RATE_LIMITED"
+	CodeTemplateNameInvalid          Code = "TEMPLATE_NAME_INVALID"
+	CodeTemplateCircuitMismatch      Code = "TEMPLATE_CIRCUIT_MISMATCH"
+	CodeTooManyBatchRequests         Code = "TOO_MANY_BATCH_REQUESTS"
+	CodeSessionIDInvalid             Code = "SESSION_ID_INVALID"
+	CodeVerificationRejected         Code = "VERIFICATION_REJECTED"
+	CodeWalletDIDInvalid             Code = "WALLET_DID_INVALID"
+	CodeDirectDeliveryOnChain        Code = "DIRECT_DELIVERY_ON_CHAIN"
+	CodeSessionNotPending            Code = "SESSION_NOT_PENDING"
+	CodeSessionBoundToWallet         Code = "SESSION_BOUND_TO_WALLET"
+	CodeWalletNonceReused            Code = "WALLET_NONCE_REUSED"
+	CodeWalletThreadMismatch         Code = "WALLET_THREAD_MISMATCH"
+	CodeRequestExpired               Code = "REQUEST_EXPIRED"
+	CodeQueryOperatorUnknown         Code = "QUERY_OPERATOR_UNKNOWN"
+	CodeQueryOperatorNotSupported    Code = "QUERY_OPERATOR_NOT_SUPPORTED"
+	CodeQueryMultipleFields          Code = "QUERY_MULTIPLE_FIELDS"
+	CodeQueryMultipleOperators       Code = "QUERY_MULTIPLE_OPERATORS"
+	CodeQueryValueArray              Code = "QUERY_VALUE_ARRAY"
+	CodeQueryValueRange              Code = "QUERY_VALUE_RANGE"
+	CodeQueryValueBoolean            Code = "QUERY_VALUE_BOOLEAN"
+	CodeQueryValueScalar             Code = "QUERY_VALUE_SCALAR"
+	CodeQueryValueNumber             Code = "QUERY_VALUE_NUMBER"
+	CodeQueryValueArrayItems         Code = "QUERY_VALUE_ARRAY_ITEMS"
+	CodeQueryValueArrayTooLong       Code = "QUERY_VALUE_ARRAY_TOO_LONG"
+	CodeQueryValueRangeOrder         Code = "QUERY_VALUE_RANGE_ORDER"
+	CodeQueryFieldInvalid            Code = "QUERY_FIELD_INVALID"
+	CodeQueryProofTypeInvalid        Code = "QUERY_PROOF_TYPE_INVALID"
+	CodeQueryTypeUnknown             Code = "QUERY_TYPE_UNKNOWN"
+	CodeQueryFieldUnknown            Code = "QUERY_FIELD_UNKNOWN"
+	CodeQueryFieldNotInSlot          Code = "QUERY_FIELD_NOT_IN_SLOT"
+	CodeQueryOperatorDatatype        Code = "QUERY_OPERATOR_DATATYPE"
+	CodeQueryValueDatatype           Code = "QUERY_VALUE_DATATYPE"
+	CodeEthAddressRequired           Code = "ETH_ADDRESS_REQUIRED"
+	CodeBodyTooLarge                 Code = "BODY_TOO_LARGE"
+	CodeContentTypeUnsupported       Code = "CONTENT_TYPE_UNSUPPORTED"
+	CodeTokenTooLarge                Code = "TOKEN_TOO_LARGE"
+	CodeTokenMalformed               Code = "TOKEN_MALFORMED"
+	CodeTokenTooManyScopes           Code = "TOKEN_TOO_MANY_SCOPES"
+	CodeVerificationStale            Code = "VERIFICATION_STALE"
+	CodeVerificationRevoked          Code = "VERIFICATION_REVOKED"
+	CodeVerificationQueueFull        Code = "VERIFICATION_QUEUE_FULL"
+	CodeSessionNotOnChain            Code = "SESSION_NOT_ON_CHAIN"
+	CodeInvalidEthAddress            Code = "INVALID_ETH_ADDRESS"
+	CodeOnChainProofsUnreadable      Code = "ON_CHAIN_PROOFS_UNREADABLE"
+	CodeSignInLinkInvalid            Code = "SIGN_IN_LINK_INVALID"
+	CodeSignInLinkExpired            Code = "SIGN_IN_LINK_EXPIRED"
+	CodeSignInLinkRateLimited        Code = "SIGN_IN_LINK_RATE_LIMITED"
+	CodeCredentialsRevokedSince      Code = "CREDENTIALS_REVOKED_SINCE"
+	CodeOnChainProofsReplaced        Code = "ON_CHAIN_PROOFS_REPLACED"
+	CodeInvalidMetadata              Code = "INVALID_METADATA"
+	CodeMessageOnChain               Code = "MESSAGE_ON_CHAIN"
+	CodeCredentialOfferNotFound      Code = "CREDENTIAL_OFFER_NOT_FOUND"
+	CodeSessionNotVerified           Code = "SESSION_NOT_VERIFIED"
+	CodeQueryContextUnavailable      Code = "QUERY_CONTEXT_UNAVAILABLE"
+	CodePublicURLInvalid             Code = "PUBLIC_URL_INVALID"
+	CodePublicURLNotAllowed          Code = "PUBLIC_URL_NOT_ALLOWED"
+	CodeFlowNotFound                 Code = "FLOW_NOT_FOUND"
+	CodeReasonTemplateNotFound       Code = "REASON_TEMPLATE_NOT_FOUND"
+	CodeReasonTemplateInvalid        Code = "REASON_TEMPLATE_INVALID"
+	CodeReasonTemplateRender         Code = "REASON_TEMPLATE_RENDER"
+	CodeSessionTimingsForbidden      Code = "SESSION_TIMINGS_FORBIDDEN"
+	CodeSchemaContextNotAllowed      Code = "SCHEMA_CONTEXT_NOT_ALLOWED"
+	CodeSchemaAllowlistInvalid       Code = "SCHEMA_ALLOWLIST_INVALID"
+	CodeManagementSignatureRequired  Code = "MANAGEMENT_SIGNATURE_REQUIRED"
+	CodeManagementSignatureInvalid   Code = "MANAGEMENT_SIGNATURE_INVALID"
+	CodeManagementSignatureExpired   Code = "MANAGEMENT_SIGNATURE_EXPIRED"
+	CodeManagementSignatureReplayed  Code = "MANAGEMENT_SIGNATURE_REPLAYED"
+	CodeManagementClientCertRequired Code = "MANAGEMENT_CLIENT_CERT_REQUIRED"
+	CodeInvalidRedirectURI           Code = "INVALID_REDIRECT_URI"
+	CodeRedirectURIOnChain           Code = "REDIRECT_URI_ON_CHAIN"
+	CodeRoleRequired                 Code = "ROLE_REQUIRED"
+	CodeBearerTokenInvalid           Code = "BEARER_TOKEN_INVALID"
+	CodeUserRegistryDisabled         Code = "USER_REGISTRY_DISABLED"
+	CodeUserNotFound                 Code = "USER_NOT_FOUND"
+	CodeWidgetRateLimited            Code = "WIDGET_RATE_LIMITED"
+)
+
+type ctxKey struct{}
+
+//go:embed locales/*.json
+var locales embed.FS
+
+var (
+	// defaultLanguage is used when the client does not send a supported language
+	defaultLanguage             = language.English
+	catalog, matcher, supported = mustLoadCatalog()
+)
+
+// Error is an error with a code that can be translated to the client language
+type Error struct {
+	Code Code
+	Args []any
+	Err  error
+}
+
+// New creates a new Error. Args are used to format the message of the code.
+func New(code Code, args ...any) *Error {
+	return &Error{Code: code, Args: args}
+}
+
+// Wrap creates a new Error that wraps err
+func Wrap(err error, code Code, args ...any) *Error {
+	return &Error{Code: code, Args: args, Err: err}
+}
+
+// Error returns the message in the default language
+func (e *Error) Error() string {
+	return e.Message(defaultLanguage)
+}
+
+// Unwrap returns the wrapped error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Message returns the message in the given language, falling back to the default language
+func (e *Error) Message(lang language.Tag) string {
+	return message(lang, e.Code, e.Args...)
+}
+
+// Message returns the message of the code in the language of the request
+func Message(ctx context.Context, code Code, args ...any) string {
+	return message(FromContext(ctx), code, args...)
+}
+
+// Localize returns the message of err in the language of the request.
+// Errors without code are returned as they are.
+func Localize(ctx context.Context, err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Message(FromContext(ctx))
+	}
+	return err.Error()
+}
+
+// HasCode reports whether err is an Error with the code
+func HasCode(err error, code Code) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Code == code
+}
+
+// WithLanguage returns a copy of ctx with the language
+func WithLanguage(ctx context.Context, lang language.Tag) context.Context {
+	return context.WithValue(ctx, ctxKey{}, lang)
+}
+
+// FromContext returns the language of the request, or the default language
+func FromContext(ctx context.Context) language.Tag {
+	if lang, ok := ctx.Value(ctxKey{}).(language.Tag); ok {
+		return lang
+	}
+	return defaultLanguage
+}
+
+// Match returns the supported language that best matches an Accept-Language header
+func Match(acceptLanguage string) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return defaultLanguage
+	}
+	_, idx, _ := matcher.Match(tags...)
+	return supported[idx]
+}
+
+// Middleware stores the language of the Accept-Language header in the request context
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := Match(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", lang.String())
+		next.ServeHTTP(w, r.WithContext(WithLanguage(r.Context(), lang)))
+	})
+}
+
+func message(lang language.Tag, code Code, args ...any) string {
+	format, ok := catalog[lang][code]
+	if !ok {
+		format, ok = catalog[defaultLanguage][code]
+	}
+	if !ok {
+		return string(code)
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// mustLoadCatalog loads the bundled catalogs. The default language is the first supported language,
+// so it is also the fallback of the matcher.
+func mustLoadCatalog() (map[language.Tag]map[Code]string, language.Matcher, []language.Tag) {
+	files, err := locales.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	c := make(map[language.Tag]map[Code]string, len(files))
+	tags := []language.Tag{defaultLanguage}
+	for _, f := range files {
+		lang, err := language.Parse(strings.TrimSuffix(f.Name(), ".json"))
+		if err != nil {
+			panic(fmt.Errorf("invalid locale file %s: %w", f.Name(), err))
+		}
+
+		content, err := locales.ReadFile(path.Join("locales", f.Name()))
+		if err != nil {
+			panic(err)
+		}
+		messages := make(map[Code]string)
+		if err := json.Unmarshal(content, &messages); err != nil {
+			panic(fmt.Errorf("invalid locale file %s: %w", f.Name(), err))
+		}
+
+		c[lang] = messages
+		if lang != defaultLanguage {
+			tags = append(tags, lang)
+		}
+	}
+	return c, language.NewMatcher(tags), tags
+}
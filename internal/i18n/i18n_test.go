@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestCatalog(t *testing.T) {
+	for lang, messages := range catalog {
+		for code := range catalog[defaultLanguage] {
+			assert.NotEmpty(t, messages[code], "missing %s message in %s catalog", code, lang)
+		}
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	type testConfig struct {
+		name           string
+		acceptLanguage string
+		err            error
+		expected       string
+	}
+
+	for _, tc := range []testConfig{
+		{
+			name:     "default language",
+			err:      New(CodeFieldEmpty, "chainId"),
+			expected: "field chainId is empty",
+		},
+		{
+			name:           "supported language",
+			acceptLanguage: "es-ES,es;q=0.9,en;q=0.8",
+			err:            New(CodeFieldEmpty, "chainId"),
+			expected:       "el campo chainId está vacío",
+		},
+		{
+			name:           "unsupported language",
+			acceptLanguage: "ja",
+			err:            New(CodeFieldEmpty, "chainId"),
+			expected:       "field chainId is empty",
+		},
+		{
+			name:           "wrapped error",
+			acceptLanguage: "fr",
+			err:            Wrap(errors.New("invalid proof"), CodeVerificationFailed, "invalid proof"),
+			expected:       "échec de la vérification de la preuve : invalid proof",
+		},
+		{
+			name:           "error without code",
+			acceptLanguage: "fr",
+			err:            errors.New("invalid proof"),
+			expected:       "invalid proof",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := WithLanguage(context.Background(), Match(tc.acceptLanguage))
+			assert.Equal(t, tc.expected, Localize(ctx, tc.err))
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	assert.Equal(t, language.English, Match(""))
+	assert.Equal(t, language.Spanish, Match("es-AR"))
+}
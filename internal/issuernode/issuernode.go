@@ -0,0 +1,143 @@
+// Package issuernode creates credentials and their offers with the API of an issuer node, so the verified users can be
+// issued a credential without a separate service
+package issuernode
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+const maxResponseSize = 1 << 20
+
+// Offer is a credential created for a user and the universal link of its offer
+type Offer struct {
+	CredentialID  string
+	UniversalLink string
+}
+
+// Session is the data of the templates of the credential subjects
+type Session struct {
+	UserDID   string
+	SessionID string
+	Metadata  map[string]string
+}
+
+// Client calls the issuer nodes of the credential offers
+type Client struct {
+	client *http.Client
+}
+
+// New creates a Client whose requests time out after timeout
+func New(timeout time.Duration) *Client {
+	return &Client{client: &http.Client{Timeout: timeout}}
+}
+
+// Offer creates the credential of offer for the user of the session, and returns the universal link of its offer
+func (c *Client) Offer(ctx context.Context, offer config.CredentialOffer, session Session) (Offer, error) {
+	subject, err := Subject(offer.CredentialSubject, session)
+	if err != nil {
+		return Offer{}, fmt.Errorf("invalid credential subject of offer %s: %w", offer.Name, err)
+	}
+	subject["id"] = session.UserDID
+
+	body := map[string]any{
+		"credentialSchema":  offer.CredentialSchema,
+		"type":              offer.Type,
+		"credentialSubject": subject,
+	}
+	if offer.Expiration > 0 {
+		body["expiration"] = time.Now().Add(offer.Expiration).Unix()
+	}
+	credentials := "/v2/identities/" + url.PathEscape(offer.IssuerDID) + "/credentials"
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := c.call(ctx, offer, http.MethodPost, credentials, body, &created); err != nil {
+		return Offer{}, fmt.Errorf("failed to create the credential of offer %s: %w", offer.Name, err)
+	}
+
+	var link struct {
+		UniversalLink string `json:"universalLink"`
+	}
+	path := credentials + "/" + url.PathEscape(created.ID) + "/offer?type=universalLink"
+	if err := c.call(ctx, offer, http.MethodGet, path, nil, &link); err != nil {
+		return Offer{}, fmt.Errorf("failed to get the offer of credential %s: %w", created.ID, err)
+	}
+	return Offer{CredentialID: created.ID, UniversalLink: link.UniversalLink}, nil
+}
+
+// Subject executes the templates of the string values of a credential subject, in the nested objects too
+func Subject(subject map[string]any, session Session) (map[string]any, error) {
+	out := make(map[string]any, len(subject))
+	for key, value := range subject {
+		switch v := value.(type) {
+		case string:
+			tmpl, err := template.New(key).Option("missingkey=error").Parse(v)
+			if err != nil {
+				return nil, err
+			}
+			var b strings.Builder
+			if err := tmpl.Execute(&b, session); err != nil {
+				return nil, err
+			}
+			out[key] = b.String()
+		case map[string]any:
+			nested, err := Subject(v, session)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = nested
+		default:
+			out[key] = value
+		}
+	}
+	return out, nil
+}
+
+// call sends a request to the issuer node of offer
+func (c *Client) call(ctx context.Context, offer config.CredentialOffer, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(offer.IssuerURL, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if offer.User != "" {
+		req.SetBasicAuth(offer.User, offer.Password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, data)
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,79 @@
+package issuernode
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+func TestOffer(t *testing.T) {
+	const issuerDID = "did:polygonid:polygon:amoy:2qQ68JkRcf3xrHPQPWZei3YeVzHPP58wYNxx2mEouR"
+	var created map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "user" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/identities/"+issuerDID+"/credentials":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": "8edd8112-c415-11ed-b036-debe37e1cbd6"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/identities/"+issuerDID+"/credentials/8edd8112-c415-11ed-b036-debe37e1cbd6/offer":
+			assert.Equal(t, "universalLink", r.URL.Query().Get("type"))
+			_, _ = w.Write([]byte(`{"universalLink": "https://wallet.privado.id#request_uri=offer"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	offer := config.CredentialOffer{
+		Name:             "kyc-passed",
+		IssuerURL:        srv.URL + "/",
+		IssuerDID:        issuerDID,
+		User:             "user",
+		Password:         "password",
+		CredentialSchema: "https://example.com/schemas/kyc-passed.json",
+		Type:             "KYCPassed",
+		CredentialSubject: map[string]any{
+			"passed":  true,
+			"orderID": "{{.Metadata.orderID}}",
+			"session": map[string]any{"id": "{{.SessionID}}"},
+		},
+		Expiration: time.Hour,
+	}
+	client := New(time.Second)
+	result, err := client.Offer(context.Background(), offer, Session{
+		UserDID:   "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK",
+		SessionID: "7a2a9d5e-1f6d-4b8b-9b3a-8f1b1c1d2e3f",
+		Metadata:  map[string]string{"orderID": "1234"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Offer{CredentialID: "8edd8112-c415-11ed-b036-debe37e1cbd6", UniversalLink: "https://wallet.privado.id#request_uri=offer"}, result)
+	assert.Equal(t, "KYCPassed", created["type"])
+	assert.Equal(t, map[string]any{
+		"id":      "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK",
+		"passed":  true,
+		"orderID": "1234",
+		"session": map[string]any{"id": "7a2a9d5e-1f6d-4b8b-9b3a-8f1b1c1d2e3f"},
+	}, created["credentialSubject"])
+	assert.InDelta(t, time.Now().Add(time.Hour).Unix(), created["expiration"], 5)
+
+	// a template of a missing metadata key
+	_, err = client.Offer(context.Background(), offer, Session{UserDID: "did:example:user"})
+	assert.ErrorContains(t, err, "invalid credential subject")
+
+	offer.Password = "other"
+	_, err = client.Offer(context.Background(), offer, Session{UserDID: "did:example:user", Metadata: map[string]string{"orderID": "1"}})
+	assert.ErrorContains(t, err, "401")
+}
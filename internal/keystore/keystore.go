@@ -0,0 +1,285 @@
+// Package keystore holds the BJJ and ETH keys of the verifier identity. The BJJ key signs the requests of the verifier
+// and the ETH key its relayer transactions, the JWTs are signed with the keys of the signing package.
+package keystore
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iden3/go-iden3-crypto/babyjub"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/signing"
+)
+
+const loadTimeout = 10 * time.Second
+
+// ErrKeyNotFound is returned when the verifier has no key of a type
+var ErrKeyNotFound = errors.New("verifier key not found")
+
+// Type is the type of a key of the verifier
+type Type string
+
+// Key types
+const (
+	TypeBJJ Type = "BJJ"
+	TypeETH Type = "ETH"
+)
+
+// keyTypes are the key types, in the order the keys are listed
+var keyTypes = []Type{TypeBJJ, TypeETH}
+
+// Key is a BJJ or an ETH key of the verifier. The id of a BJJ key is its hex encoded compressed public key, the id of
+// an ETH key is its address.
+type Key struct {
+	keyType Type
+	id      string
+	bjj     *babyjub.PrivateKey
+	address common.Address
+	eth     ethSigner
+}
+
+// ethSigner signs the 32 bytes hashes with a secp256k1 key, in the [R || S || V] format of go-ethereum
+type ethSigner interface {
+	signHash(ctx context.Context, hash []byte) ([]byte, error)
+}
+
+// Type returns the type of the key
+func (k *Key) Type() Type {
+	return k.keyType
+}
+
+// ID returns the id of the key
+func (k *Key) ID() string {
+	return k.id
+}
+
+// SignPoseidon signs the poseidon hash of a message with a BJJ key
+func (k *Key) SignPoseidon(msg *big.Int) (*babyjub.Signature, error) {
+	if k.bjj == nil {
+		return nil, fmt.Errorf("%s key %s cannot sign poseidon hashes", k.keyType, k.id)
+	}
+	return k.bjj.SignPoseidon(msg), nil
+}
+
+// Address returns the address of an ETH key
+func (k *Key) Address() common.Address {
+	return k.address
+}
+
+// SignHash signs a 32 bytes hash with an ETH key. The signature is in the [R || S || V] format, V is 0 or 1.
+func (k *Key) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	if k.eth == nil {
+		return nil, fmt.Errorf("%s key %s cannot sign ethereum hashes", k.keyType, k.id)
+	}
+	if len(hash) != common.HashLength {
+		return nil, fmt.Errorf("hash is required to be exactly %d bytes (%d)", common.HashLength, len(hash))
+	}
+	return k.eth.signHash(ctx, hash)
+}
+
+// TransactOpts returns the options of the transactions sent from an ETH key on a chain, e.g. by a bound contract
+func (k *Key) TransactOpts(ctx context.Context, chainID *big.Int) (*bind.TransactOpts, error) {
+	if k.eth == nil {
+		return nil, fmt.Errorf("%s key %s cannot sign transactions", k.keyType, k.id)
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	return &bind.TransactOpts{
+		From:    k.address,
+		Context: ctx,
+		Signer: func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if from != k.address {
+				return nil, bind.ErrNotAuthorized
+			}
+			signature, err := k.SignHash(ctx, signer.Hash(tx).Bytes())
+			if err != nil {
+				return nil, err
+			}
+			return tx.WithSignature(signer, signature)
+		},
+	}, nil
+}
+
+// Keystore holds the current BJJ and ETH keys of the verifier and the keys they replaced
+type Keystore struct {
+	cfg     config.Config
+	mu      sync.RWMutex
+	current map[Type]*Key
+	retired []*Key
+}
+
+// New creates a Keystore with the keys of the sources of the configuration, see config.Keystore
+func New(ctx context.Context, cfg config.Config) (*Keystore, error) {
+	keys, err := loadKeys(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Keystore{cfg: cfg, current: keys}, nil
+}
+
+// Key returns the current key of a type
+func (s *Keystore) Key(keyType Type) (*Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.current[keyType]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return key, nil
+}
+
+// Keys returns the current keys, the BJJ key first, and the retired keys, the most recently retired first
+func (s *Keystore) Keys() ([]*Key, []*Key) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	current := make([]*Key, 0, len(s.current))
+	for _, keyType := range keyTypes {
+		if key, ok := s.current[keyType]; ok {
+			current = append(current, key)
+		}
+	}
+	return current, append([]*Key(nil), s.retired...)
+}
+
+// Rotate loads the keys again from their sources, e.g. after a key file was replaced, the Vault secret was updated or
+// the KMS alias was moved to another key. A key that changed replaces the current key of its type, which is retired:
+// it no longer signs, but it is still listed so the signatures it made can be verified. The current key of a source
+// that is no longer set is kept. Rotate returns the types of the keys that changed.
+func (s *Keystore) Rotate(ctx context.Context) ([]Type, error) {
+	keys, err := loadKeys(ctx, s.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rotated := make([]Type, 0, len(keys))
+	for _, keyType := range keyTypes {
+		key, ok := keys[keyType]
+		if !ok {
+			continue
+		}
+		current, ok := s.current[keyType]
+		if ok && current.id == key.id {
+			continue
+		}
+		if ok {
+			s.retired = append([]*Key{current}, s.retired...)
+		}
+		s.current[keyType] = key
+		rotated = append(rotated, keyType)
+	}
+	return rotated, nil
+}
+
+// loadKeys reads the keys of the sources of the configuration
+func loadKeys(ctx context.Context, cfg config.Config) (map[Type]*Key, error) {
+	ctx, cancel := context.WithTimeout(ctx, loadTimeout)
+	defer cancel()
+
+	var secret map[string]string
+	if cfg.Keystore.VaultPath != "" {
+		vault := signing.NewVaultClient(cfg.SigningVault.Address, cfg.SigningVault.Token, cfg.SigningVault.Mount)
+		var err error
+		if secret, err = vault.ReadSecret(ctx, cfg.Keystore.VaultMount, cfg.Keystore.VaultPath); err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make(map[Type]*Key)
+	value, source, err := hexKey(secret, "bjj", cfg.Keystore.BJJKey, cfg.Keystore.BJJKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	if value != "" {
+		if keys[TypeBJJ], err = parseBJJKey(value, source); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Keystore.ETHKMSKeyID != "" {
+		kms := signing.NewKMSClient(cfg.SigningKMS.Region, cfg.SigningKMS.Endpoint)
+		if keys[TypeETH], err = newKMSKey(ctx, kms, cfg.Keystore.ETHKMSKeyID); err != nil {
+			return nil, err
+		}
+		return keys, nil
+	}
+	value, source, err = hexKey(secret, "eth", cfg.Keystore.ETHKey, cfg.Keystore.ETHKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	if value != "" {
+		if keys[TypeETH], err = parseETHKey(value, source); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// hexKey returns the hex encoded private key of the first source set among the field of the Vault secret, the
+// environment and the file, with the name of its source for the errors. The key is empty when no source is set.
+func hexKey(secret map[string]string, field, env, path string) (string, string, error) {
+	if value, ok := secret[field]; ok {
+		return value, "the " + field + " field of the vault secret", nil
+	}
+	if env != "" {
+		return env, "the " + field + " key of the environment", nil
+	}
+	if path == "" {
+		return "", "", nil
+	}
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", "", err
+	}
+	return string(content), path, nil
+}
+
+func decodeHex(value string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(value), "0x"))
+}
+
+func parseBJJKey(value, source string) (*Key, error) {
+	raw, err := decodeHex(value)
+	if err != nil || len(raw) != len(babyjub.PrivateKey{}) {
+		return nil, fmt.Errorf("invalid bjj private key in %s, 32 hex encoded bytes are expected", source)
+	}
+	var privateKey babyjub.PrivateKey
+	copy(privateKey[:], raw)
+	return &Key{keyType: TypeBJJ, id: privateKey.Public().Compress().String(), bjj: &privateKey}, nil
+}
+
+func parseETHKey(value, source string) (*Key, error) {
+	raw, err := decodeHex(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid eth private key in %s: %w", source, err)
+	}
+	privateKey, err := crypto.ToECDSA(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid eth private key in %s: %w", source, err)
+	}
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	return &Key{keyType: TypeETH, id: address.Hex(), address: address, eth: localSigner{privateKey}}, nil
+}
+
+// localSigner signs with an in-memory secp256k1 key
+type localSigner struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+func (s localSigner) signHash(_ context.Context, hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.privateKey)
+}
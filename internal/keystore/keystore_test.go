@@ -0,0 +1,254 @@
+package keystore
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iden3/go-iden3-crypto/babyjub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+func writeHexKey(t *testing.T, dir, name string, key []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0o600))
+	return path
+}
+
+func newETHKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return privateKey
+}
+
+// fakeKMS answers the GetPublicKey and Sign actions of AWS KMS with an in-memory secp256k1 key. The S of its signatures
+// is in the upper half of the order, so the signer has to normalize it.
+func fakeKMS(t *testing.T, keyID string) (*httptest.Server, *ecdsa.PrivateKey) {
+	t.Helper()
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "SECRET")
+	privateKey := newETHKey(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			KeyID            string `json:"KeyId"`
+			Message          []byte
+			SigningAlgorithm string
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		if in.KeyID != keyID {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			der, err := asn1.Marshal(struct {
+				Algorithm pkix.AlgorithmIdentifier
+				PublicKey asn1.BitString
+			}{
+				Algorithm: pkix.AlgorithmIdentifier{
+					Algorithm:  asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
+					Parameters: asn1.RawValue{FullBytes: []byte{0x06, 0x05, 0x2b, 0x81, 0x04, 0x00, 0x0a}},
+				},
+				PublicKey: asn1.BitString{Bytes: crypto.FromECDSAPub(&privateKey.PublicKey), BitLength: 65 * 8},
+			})
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string][]byte{"PublicKey": der})
+		case "TrentService.Sign":
+			assert.Equal(t, kmsSigningAlgorithm, in.SigningAlgorithm)
+			signature, err := crypto.Sign(in.Message, privateKey)
+			require.NoError(t, err)
+			s := new(big.Int).SetBytes(signature[32:64])
+			der, err := asn1.Marshal(struct{ R, S *big.Int }{
+				R: new(big.Int).SetBytes(signature[:32]),
+				S: s.Sub(crypto.S256().Params().N, s),
+			})
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string][]byte{"Signature": der})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, privateKey
+}
+
+// fakeVault answers the reads of a secret of the KV engine of Vault
+func fakeVault(t *testing.T, path string, fields map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/secret/data/"+path {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": fields}})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func mustKey(t *testing.T, ks *Keystore, keyType Type) *Key {
+	t.Helper()
+	key, err := ks.Key(keyType)
+	require.NoError(t, err)
+	return key
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	bjjKey := babyjub.NewRandPrivKey()
+	ethKey := newETHKey(t)
+
+	ks, err := New(ctx, config.Config{Keystore: config.Keystore{
+		BJJKey:     "0x" + hex.EncodeToString(bjjKey[:]),
+		ETHKeyPath: writeHexKey(t, dir, "eth.key", crypto.FromECDSA(ethKey)),
+	}})
+	require.NoError(t, err)
+
+	bjj := mustKey(t, ks, TypeBJJ)
+	assert.Equal(t, bjjKey.Public().Compress().String(), bjj.ID())
+	msg := big.NewInt(42)
+	signature, err := bjj.SignPoseidon(msg)
+	require.NoError(t, err)
+	assert.True(t, bjjKey.Public().VerifyPoseidon(msg, signature))
+	_, err = bjj.SignHash(ctx, make([]byte, common.HashLength))
+	assert.ErrorContains(t, err, "cannot sign ethereum hashes")
+
+	eth := mustKey(t, ks, TypeETH)
+	address := crypto.PubkeyToAddress(ethKey.PublicKey)
+	assert.Equal(t, address.Hex(), eth.ID())
+	assert.Equal(t, address, eth.Address())
+	_, err = eth.SignPoseidon(msg)
+	assert.ErrorContains(t, err, "cannot sign poseidon hashes")
+
+	opts, err := eth.TransactOpts(ctx, big.NewInt(80002))
+	require.NoError(t, err)
+	tx, err := opts.Signer(address, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(80002), Nonce: 1}))
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(80002)), tx)
+	require.NoError(t, err)
+	assert.Equal(t, address, sender)
+	_, err = opts.Signer(common.Address{}, tx)
+	assert.Error(t, err)
+
+	current, retired := ks.Keys()
+	assert.Equal(t, []*Key{bjj, eth}, current)
+	assert.Empty(t, retired)
+
+	ks, err = New(ctx, config.Config{})
+	require.NoError(t, err)
+	_, err = ks.Key(TypeBJJ)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestNewErrors(t *testing.T) {
+	ctx := context.Background()
+	_, err := New(ctx, config.Config{Keystore: config.Keystore{BJJKey: "abcd"}})
+	assert.ErrorContains(t, err, "bjj key of the environment")
+	_, err = New(ctx, config.Config{Keystore: config.Keystore{ETHKey: "not hex"}})
+	assert.ErrorContains(t, err, "eth key of the environment")
+	_, err = New(ctx, config.Config{Keystore: config.Keystore{ETHKeyPath: filepath.Join(t.TempDir(), "missing.key")}})
+	assert.Error(t, err)
+}
+
+func TestKMS(t *testing.T) {
+	ctx := context.Background()
+	srv, privateKey := fakeKMS(t, "alias/verifier")
+	ks, err := New(ctx, config.Config{
+		SigningKMS: config.SigningKMS{Region: "us-east-1", Endpoint: srv.URL},
+		Keystore:   config.Keystore{ETHKMSKeyID: "alias/verifier", ETHKey: hex.EncodeToString(crypto.FromECDSA(newETHKey(t)))},
+	})
+	require.NoError(t, err)
+
+	eth := mustKey(t, ks, TypeETH)
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	assert.Equal(t, address.Hex(), eth.ID())
+	hash := crypto.Keccak256([]byte("relayed"))
+	signature, err := eth.SignHash(ctx, hash)
+	require.NoError(t, err)
+	public, err := crypto.SigToPub(hash, signature)
+	require.NoError(t, err)
+	assert.Equal(t, address, crypto.PubkeyToAddress(*public))
+	assert.True(t, crypto.ValidateSignatureValues(signature[64], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:64]), true))
+}
+
+func TestVault(t *testing.T) {
+	ctx := context.Background()
+	bjjKey := babyjub.NewRandPrivKey()
+	ethKey := newETHKey(t)
+	srv := fakeVault(t, "verifier/keys", map[string]string{
+		"bjj": hex.EncodeToString(bjjKey[:]),
+		"eth": hex.EncodeToString(crypto.FromECDSA(ethKey)),
+	})
+	cfg := config.Config{
+		SigningVault: config.SigningVault{Address: srv.URL, Token: "token"},
+		Keystore:     config.Keystore{VaultMount: "secret", VaultPath: "verifier/keys", BJJKey: "not a key"},
+	}
+	ks, err := New(ctx, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, bjjKey.Public().Compress().String(), mustKey(t, ks, TypeBJJ).ID())
+	assert.Equal(t, crypto.PubkeyToAddress(ethKey.PublicKey).Hex(), mustKey(t, ks, TypeETH).ID())
+
+	cfg.SigningVault.Token = "wrong"
+	_, err = New(ctx, cfg)
+	assert.ErrorContains(t, err, "403")
+}
+
+func TestRotate(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	bjjKey := babyjub.NewRandPrivKey()
+	ethKey := newETHKey(t)
+	ethPath := writeHexKey(t, dir, "eth.key", crypto.FromECDSA(ethKey))
+	ks, err := New(ctx, config.Config{Keystore: config.Keystore{
+		BJJKeyPath: writeHexKey(t, dir, "bjj.key", bjjKey[:]),
+		ETHKeyPath: ethPath,
+	}})
+	require.NoError(t, err)
+	bjj := mustKey(t, ks, TypeBJJ)
+	eth := mustKey(t, ks, TypeETH)
+
+	// the keys did not change
+	rotated, err := ks.Rotate(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, rotated)
+
+	// the ETH key file is replaced
+	next := newETHKey(t)
+	writeHexKey(t, dir, "eth.key", crypto.FromECDSA(next))
+	rotated, err = ks.Rotate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Type{TypeETH}, rotated)
+	assert.Equal(t, crypto.PubkeyToAddress(next.PublicKey).Hex(), mustKey(t, ks, TypeETH).ID())
+	assert.Equal(t, bjj, mustKey(t, ks, TypeBJJ))
+	current, retired := ks.Keys()
+	assert.Len(t, current, 2)
+	assert.Equal(t, []*Key{eth}, retired)
+
+	// a key that cannot be read keeps the current keys
+	require.NoError(t, os.WriteFile(ethPath, []byte("not a key"), 0o600))
+	_, err = ks.Rotate(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(next.PublicKey).Hex(), mustKey(t, ks, TypeETH).ID())
+}
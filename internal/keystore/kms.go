@@ -0,0 +1,81 @@
+package keystore
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/0xPolygonID/verifier-backend/internal/signing"
+)
+
+// kmsSigningAlgorithm is the KMS algorithm of the ECC_SECG_P256K1 keys, the digest is signed as is so it can be a
+// keccak256 hash
+const kmsSigningAlgorithm = "ECDSA_SHA_256"
+
+// secp256k1HalfN is the order of secp256k1 divided by two, the S of the Ethereum signatures is not greater
+var secp256k1HalfN = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+
+// newKMSKey creates an ETH Key whose secp256k1 private key is held in AWS KMS. Its public key is read once from KMS.
+func newKMSKey(ctx context.Context, client *signing.KMSClient, keyID string) (*Key, error) {
+	der, err := client.PublicKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(der, &spki); err != nil {
+		return nil, fmt.Errorf("invalid public key of kms key %s: %w", keyID, err)
+	}
+	public, err := crypto.UnmarshalPubkey(spki.PublicKey.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("kms key %s is not a secp256k1 key: %w", keyID, err)
+	}
+	address := crypto.PubkeyToAddress(*public)
+	return &Key{keyType: TypeETH, id: address.Hex(), address: address, eth: &kmsSigner{client: client, keyID: keyID, public: public}}, nil
+}
+
+// kmsSigner signs the hashes with a secp256k1 KMS key
+type kmsSigner struct {
+	client *signing.KMSClient
+	keyID  string
+	public *ecdsa.PublicKey
+}
+
+// signHash converts the DER signature of KMS to the [R || S || V] format: S is normalized to the lower half of the
+// order, and V is the recovery id that recovers the public key of the KMS key
+func (s *kmsSigner) signHash(ctx context.Context, hash []byte) ([]byte, error) {
+	der, err := s.client.Sign(ctx, s.keyID, hash, kmsSigningAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	var rs struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(der, &rs); err != nil {
+		return nil, fmt.Errorf("invalid signature of kms key %s: %w", s.keyID, err)
+	}
+	if rs.S.Cmp(secp256k1HalfN) > 0 {
+		rs.S.Sub(crypto.S256().Params().N, rs.S)
+	}
+
+	signature := make([]byte, crypto.SignatureLength)
+	rs.R.FillBytes(signature[:32])
+	rs.S.FillBytes(signature[32:64])
+	public := crypto.FromECDSAPub(s.public)
+	for v := byte(0); v < 2; v++ {
+		signature[crypto.RecoveryIDOffset] = v
+		recovered, err := crypto.Ecrecover(hash, signature)
+		if err == nil && bytes.Equal(recovered, public) {
+			return signature, nil
+		}
+	}
+	return nil, fmt.Errorf("signature of kms key %s does not recover its public key", s.keyID)
+}
@@ -0,0 +1,136 @@
+package kvcache
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// keyIDBytes is the size of the prefix of the encrypted values that identifies their data key
+const keyIDBytes = 4
+
+// Cache is a cache of []byte values
+type Cache interface {
+	Get(id string) (any, bool)
+	Set(id string, data any, duration time.Duration)
+}
+
+// Writer is a Cache whose writes return the errors of the store, where Set only logs them, so the callers know the
+// value can be read by the other replicas once Store returns
+type Writer interface {
+	Store(id string, value []byte, duration time.Duration) error
+}
+
+type dataKey struct {
+	id   []byte
+	aead cipher.AEAD
+}
+
+// Encrypted encrypts the values of a cache with AES-256-GCM, so the QR codes and the statuses of the sessions,
+// with the DIDs of the users and the metadata of the requests, cannot be read from a compromised store.
+// Values are bound to their ids, so they cannot be swapped in the store either.
+type Encrypted struct {
+	cache Cache
+	keys  []dataKey
+}
+
+// NewEncrypted creates an Encrypted cache on top of c. Values are encrypted with the first of the 32 bytes keys
+// and decrypted with any of them, so keys can be rotated while the values encrypted with the previous key expire.
+func NewEncrypted(c Cache, keys [][]byte) (*Encrypted, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one encryption key is required")
+	}
+	e := &Encrypted{cache: c}
+	for i, key := range keys {
+		if len(key) != 32 {
+			return nil, fmt.Errorf("encryption key %d has %d bytes, expected 32", i, len(key))
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(key)
+		e.keys = append(e.keys, dataKey{id: sum[:keyIDBytes], aead: aead})
+	}
+	return e, nil
+}
+
+// Get returns the decrypted value of id. Values that cannot be decrypted are reported as missing.
+func (e *Encrypted) Get(id string) (any, bool) {
+	data, ok := e.cache.Get(id)
+	if !ok {
+		return nil, false
+	}
+	b, ok := data.([]byte)
+	if !ok || len(b) < keyIDBytes {
+		return nil, false
+	}
+	// values written before the encryption was enabled, or with a removed key, are not found
+	for _, key := range e.keys {
+		if !bytes.Equal(b[:keyIDBytes], key.id) {
+			continue
+		}
+		sealed := b[keyIDBytes:]
+		if len(sealed) < key.aead.NonceSize() {
+			return nil, false
+		}
+		nonce, ciphertext := sealed[:key.aead.NonceSize()], sealed[key.aead.NonceSize():]
+		plaintext, err := key.aead.Open(nil, nonce, ciphertext, []byte(id))
+		if err != nil {
+			log.WithField("key", id).WithError(err).Error("failed to decrypt cached value")
+			return nil, false
+		}
+		return plaintext, true
+	}
+	return nil, false
+}
+
+// Set encrypts data with the first key and stores it. Only []byte values are stored.
+func (e *Encrypted) Set(id string, data any, duration time.Duration) {
+	b, ok := data.([]byte)
+	if !ok {
+		log.WithField("key", id).Errorf("encrypted cache can not store values of type %T", data)
+		return
+	}
+	value, err := e.seal(id, b)
+	if err != nil {
+		log.WithField("key", id).WithError(err).Error("failed to encrypt cached value")
+		return
+	}
+	e.cache.Set(id, value, duration)
+}
+
+// Store encrypts the value with the first key and stores it, through the Store of the cache when it is a Writer
+func (e *Encrypted) Store(id string, value []byte, duration time.Duration) error {
+	sealed, err := e.seal(id, value)
+	if err != nil {
+		return err
+	}
+	if w, ok := e.cache.(Writer); ok {
+		return w.Store(id, sealed, duration)
+	}
+	e.cache.Set(id, sealed, duration)
+	return nil
+}
+
+// seal encrypts b with the first key, bound to id
+func (e *Encrypted) seal(id string, b []byte) ([]byte, error) {
+	key := e.keys[0]
+	nonce := make([]byte, key.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	value := append(append([]byte{}, key.id...), nonce...)
+	return key.aead.Seal(value, nonce, b, []byte(id)), nil
+}
@@ -0,0 +1,65 @@
+package kvcache
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memory map[string]any
+
+func (m memory) Get(id string) (any, bool) {
+	v, ok := m[id]
+	return v, ok
+}
+
+func (m memory) Set(id string, data any, _ time.Duration) {
+	m[id] = data
+}
+
+func TestEncrypted(t *testing.T) {
+	previous := bytes.Repeat([]byte{1}, 32)
+	current := bytes.Repeat([]byte{2}, 32)
+	store := memory{}
+	value := []byte(`{"body":{"scope":[]},"to":"did:iden3:polygon:amoy:x6x5sor7zpxixYDNyDZKnh3oJZRj7Vzn9jzoCNoxc"}`)
+
+	old, err := NewEncrypted(store, [][]byte{previous})
+	require.NoError(t, err)
+	old.Set("qr-code-1", value, time.Hour)
+	assert.NotContains(t, string(store["qr-code-1"].([]byte)), "did:iden3")
+
+	// values encrypted with the previous key are still read after a rotation
+	rotated, err := NewEncrypted(store, [][]byte{current, previous})
+	require.NoError(t, err)
+	got, ok := rotated.Get("qr-code-1")
+	require.True(t, ok)
+	assert.Equal(t, value, got)
+
+	rotated.Set("qr-code-2", value, time.Hour)
+	_, ok = old.Get("qr-code-2")
+	assert.False(t, ok)
+
+	// values are bound to their ids
+	store["qr-code-3"] = store["qr-code-2"]
+	_, ok = rotated.Get("qr-code-3")
+	assert.False(t, ok)
+
+	// the values stored through a cache that is not a Writer are set
+	require.NoError(t, rotated.Store("qr-code-5", value, time.Hour))
+	got, ok = rotated.Get("qr-code-5")
+	require.True(t, ok)
+	assert.Equal(t, value, got)
+
+	// plaintext values written before the encryption was enabled are not found
+	store["qr-code-4"] = value
+	_, ok = rotated.Get("qr-code-4")
+	assert.False(t, ok)
+
+	_, err = NewEncrypted(store, [][]byte{[]byte("short")})
+	assert.Error(t, err)
+	_, err = NewEncrypted(store, nil)
+	assert.Error(t, err)
+}
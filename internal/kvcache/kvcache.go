@@ -0,0 +1,78 @@
+// Package kvcache implements caches shared across the replicas of the verifier on top of redis and memcached.
+// Both caches store []byte values, so callers serialize the data they cache.
+package kvcache
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	maxIdleConns = 8
+	dialTimeout  = 5 * time.Second
+	ioTimeout    = 5 * time.Second
+)
+
+type conn struct {
+	net.Conn
+	rw *bufio.ReadWriter
+}
+
+func dial(addr string) (*conn, error) {
+	c, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: c, rw: bufio.NewReadWriter(bufio.NewReader(c), bufio.NewWriter(c))}, nil
+}
+
+// readLine reads a line terminated by \r\n, without the terminator
+func (c *conn) readLine() (string, error) {
+	line, err := c.rw.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\r\n"), nil
+}
+
+// pool keeps the idle connections to a server
+type pool struct {
+	dial func() (*conn, error)
+	idle chan *conn
+}
+
+func newPool(dial func() (*conn, error)) *pool {
+	return &pool{dial: dial, idle: make(chan *conn, maxIdleConns)}
+}
+
+// do runs fn on an idle or new connection. Connections are discarded when fn fails,
+// as they could have unread replies.
+func (p *pool) do(fn func(c *conn) error) error {
+	var c *conn
+	select {
+	case c = <-p.idle:
+	default:
+		var err error
+		if c, err = p.dial(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.SetDeadline(time.Now().Add(ioTimeout)); err != nil {
+		_ = c.Close()
+		return err
+	}
+	if err := fn(c); err != nil {
+		_ = c.Close()
+		return err
+	}
+
+	select {
+	case p.idle <- c:
+	default:
+		_ = c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package kvcache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer serves a minimal subset of the redis and memcached protocols from memory
+type fakeServer struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func listen(t *testing.T, handle func(s *fakeServer, rw *bufio.ReadWriter) error) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	s := &fakeServer{values: map[string][]byte{}}
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				rw := bufio.NewReadWriter(bufio.NewReader(c), bufio.NewWriter(c))
+				for handle(s, rw) == nil {
+				}
+			}()
+		}
+	}()
+	return l.Addr().String()
+}
+
+func readLine(rw *bufio.ReadWriter) (string, error) {
+	line, err := rw.ReadString('\n')
+	return strings.TrimSuffix(line, "\r\n"), err
+}
+
+func handleRedis(s *fakeServer, rw *bufio.ReadWriter) error {
+	line, err := readLine(rw)
+	if err != nil {
+		return err
+	}
+	n, _ := strconv.Atoi(strings.TrimPrefix(line, "*"))
+	args := make([]string, n)
+	for i := range args {
+		line, err := readLine(rw)
+		if err != nil {
+			return err
+		}
+		size, _ := strconv.Atoi(strings.TrimPrefix(line, "$"))
+		arg := make([]byte, size+2)
+		if _, err := io.ReadFull(rw, arg); err != nil {
+			return err
+		}
+		args[i] = string(arg[:size])
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch args[0] {
+	case "SET":
+		s.values[args[1]] = []byte(args[2])
+		_, _ = rw.WriteString("+OK\r\n")
+	case "GET":
+		value, ok := s.values[args[1]]
+		if !ok {
+			_, _ = rw.WriteString("$-1\r\n")
+			break
+		}
+		_, _ = fmt.Fprintf(rw, "$%d\r\n%s\r\n", len(value), value)
+	default:
+		_, _ = rw.WriteString("-ERR unknown command\r\n")
+	}
+	return rw.Flush()
+}
+
+func handleMemcached(s *fakeServer, rw *bufio.ReadWriter) error {
+	line, err := readLine(rw)
+	if err != nil {
+		return err
+	}
+	fields := strings.Fields(line)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch fields[0] {
+	case "set":
+		size, _ := strconv.Atoi(fields[4])
+		value := make([]byte, size+2)
+		if _, err := io.ReadFull(rw, value); err != nil {
+			return err
+		}
+		s.values[fields[1]] = value[:size]
+		_, _ = rw.WriteString("STORED\r\n")
+	case "get":
+		if value, ok := s.values[fields[1]]; ok {
+			_, _ = fmt.Fprintf(rw, "VALUE %s 0 %d\r\n%s\r\n", fields[1], len(value), value)
+		}
+		_, _ = rw.WriteString("END\r\n")
+	}
+	return rw.Flush()
+}
+
+func TestCaches(t *testing.T) {
+	for name, c := range map[string]Cache{
+		"redis":     NewRedis(listen(t, handleRedis), "", 0),
+		"memcached": NewMemcached([]string{listen(t, handleMemcached), listen(t, handleMemcached)}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, ok := c.Get("qr-code-missing")
+			assert.False(t, ok)
+
+			value := []byte("{\"id\":\"1\",\r\n\"typ\":\"application/iden3comm-plain-json\"}")
+			c.Set("qr-code-1", value, time.Hour)
+			got, ok := c.Get("qr-code-1")
+			require.True(t, ok)
+			assert.Equal(t, value, got)
+
+			// only []byte values are stored
+			c.Set("qr-code-2", "value", time.Hour)
+			_, ok = c.Get("qr-code-2")
+			assert.False(t, ok)
+
+			require.NoError(t, c.(Writer).Store("qr-code-3", value, time.Hour))
+			got, ok = c.Get("qr-code-3")
+			require.True(t, ok)
+			assert.Equal(t, value, got)
+		})
+	}
+}
+
+func TestStoreErrors(t *testing.T) {
+	// nothing listens on the address of a closed listener
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	key := make([]byte, 32)
+	for name, c := range map[string]Writer{
+		"redis":     NewRedis(addr, "", 0),
+		"memcached": NewMemcached([]string{addr}),
+		"encrypted": func() Writer {
+			e, err := NewEncrypted(NewRedis(addr, "", 0), [][]byte{key})
+			require.NoError(t, err)
+			return e
+		}(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, c.Store("qr-code-1", []byte("value"), time.Hour))
+		})
+	}
+}
+
+func TestExpiration(t *testing.T) {
+	assert.Equal(t, int64(1), expiration(time.Millisecond))
+	assert.Equal(t, int64(3600), expiration(time.Hour))
+	assert.Greater(t, expiration(60*24*time.Hour), time.Now().Unix())
+}
@@ -0,0 +1,136 @@
+package kvcache
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// memcached interprets expirations over 30 days as unix timestamps
+const maxRelativeExpiration = 30 * 24 * time.Hour
+
+// Memcached is a cache stored in memcached servers. Keys are distributed across the servers by their hash.
+type Memcached struct {
+	pools []*pool
+}
+
+// NewMemcached creates a new Memcached cache. Connections are opened on demand and reused.
+func NewMemcached(addrs []string) *Memcached {
+	m := &Memcached{}
+	for _, addr := range addrs {
+		addr := addr
+		m.pools = append(m.pools, newPool(func() (*conn, error) {
+			return dial(addr)
+		}))
+	}
+	return m
+}
+
+// Get returns the value of the key as a []byte
+func (m *Memcached) Get(id string) (any, bool) {
+	var value []byte
+	err := m.pool(id).do(func(c *conn) error {
+		fmt.Fprintf(c.rw, "get %s\r\n", id)
+		if err := c.rw.Flush(); err != nil {
+			return err
+		}
+		var err error
+		value, err = c.readMemcachedValue()
+		return err
+	})
+	if err != nil {
+		log.WithField("key", id).WithError(err).Error("failed to get value from memcached")
+		return nil, false
+	}
+	if value == nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Set stores a []byte value under the key for the given duration
+func (m *Memcached) Set(id string, data any, duration time.Duration) {
+	value, ok := data.([]byte)
+	if !ok {
+		log.WithField("key", id).Errorf("memcached cache can not store values of type %T", data)
+		return
+	}
+	if err := m.Store(id, value, duration); err != nil {
+		log.WithField("key", id).WithError(err).Error("failed to set value in memcached")
+	}
+}
+
+// Store stores the value under the key for the given duration, and returns once the server stored it
+func (m *Memcached) Store(id string, value []byte, duration time.Duration) error {
+	return m.pool(id).do(func(c *conn) error {
+		fmt.Fprintf(c.rw, "set %s 0 %d %d\r\n", id, expiration(duration), len(value))
+		_, _ = c.rw.Write(value)
+		_, _ = c.rw.WriteString("\r\n")
+		if err := c.rw.Flush(); err != nil {
+			return err
+		}
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		if line != "STORED" {
+			return fmt.Errorf("unexpected memcached reply %q", line)
+		}
+		return nil
+	})
+}
+
+func (m *Memcached) pool(id string) *pool {
+	return m.pools[crc32.ChecksumIEEE([]byte(id))%uint32(len(m.pools))]
+}
+
+// readMemcachedValue reads the reply of a get, returning nil when the key does not exist
+func (c *conn) readMemcachedValue() ([]byte, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if line == "END" {
+		return nil, nil
+	}
+
+	// VALUE <key> <flags> <bytes>
+	fields := strings.Fields(line)
+	if len(fields) != 4 || fields[0] != "VALUE" {
+		return nil, fmt.Errorf("unexpected memcached reply %q", line)
+	}
+	size, err := strconv.Atoi(fields[3])
+	if err != nil {
+		return nil, err
+	}
+	value := make([]byte, size+2)
+	if _, err := io.ReadFull(c.rw, value); err != nil {
+		return nil, err
+	}
+
+	line, err = c.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if line != "END" {
+		return nil, errors.New("missing end of memcached reply")
+	}
+	return value[:size], nil
+}
+
+func expiration(duration time.Duration) int64 {
+	if duration > maxRelativeExpiration {
+		return time.Now().Add(duration).Unix()
+	}
+	seconds := int64(duration / time.Second)
+	if duration%time.Second != 0 {
+		seconds++
+	}
+	return seconds
+}
@@ -0,0 +1,137 @@
+package kvcache
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Redis is a cache stored in a redis server
+type Redis struct {
+	pool *pool
+}
+
+// NewRedis creates a new Redis cache. Connections are opened on demand and reused.
+func NewRedis(addr, password string, db int) *Redis {
+	return &Redis{pool: newPool(func() (*conn, error) {
+		c, err := dial(addr)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.SetDeadline(time.Now().Add(ioTimeout)); err != nil {
+			_ = c.Close()
+			return nil, err
+		}
+		if password != "" {
+			if err := c.redisCommand("AUTH", password); err != nil {
+				_ = c.Close()
+				return nil, err
+			}
+		}
+		if db != 0 {
+			if err := c.redisCommand("SELECT", strconv.Itoa(db)); err != nil {
+				_ = c.Close()
+				return nil, err
+			}
+		}
+		return c, nil
+	})}
+}
+
+// Get returns the value of the key as a []byte
+func (r *Redis) Get(id string) (any, bool) {
+	var value []byte
+	err := r.pool.do(func(c *conn) error {
+		if err := c.writeRedisCommand("GET", id); err != nil {
+			return err
+		}
+		var err error
+		value, err = c.readRedisBulk()
+		return err
+	})
+	if err != nil {
+		log.WithField("key", id).WithError(err).Error("failed to get value from redis")
+		return nil, false
+	}
+	if value == nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Set stores a []byte value under the key for the given duration
+func (r *Redis) Set(id string, data any, duration time.Duration) {
+	value, ok := data.([]byte)
+	if !ok {
+		log.WithField("key", id).Errorf("redis cache can not store values of type %T", data)
+		return
+	}
+	if err := r.Store(id, value, duration); err != nil {
+		log.WithField("key", id).WithError(err).Error("failed to set value in redis")
+	}
+}
+
+// Store stores the value under the key for the given duration, and returns once the server acknowledged it
+func (r *Redis) Store(id string, value []byte, duration time.Duration) error {
+	return r.pool.do(func(c *conn) error {
+		return c.redisCommand("SET", id, string(value), "PX", strconv.FormatInt(duration.Milliseconds(), 10))
+	})
+}
+
+// redisCommand runs a command that replies with +OK
+func (c *conn) redisCommand(args ...string) error {
+	if err := c.writeRedisCommand(args...); err != nil {
+		return err
+	}
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	if line != "+OK" {
+		return redisReplyError(line)
+	}
+	return nil
+}
+
+// writeRedisCommand writes a command as an array of bulk strings
+func (c *conn) writeRedisCommand(args ...string) error {
+	fmt.Fprintf(c.rw, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(c.rw, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return c.rw.Flush()
+}
+
+// readRedisBulk reads a bulk string reply, returning nil when the key does not exist
+func (c *conn) readRedisBulk() ([]byte, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '$' {
+		return nil, redisReplyError(line)
+	}
+	size, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, err
+	}
+	if size < 0 {
+		return nil, nil
+	}
+	value := make([]byte, size+2)
+	if _, err := io.ReadFull(c.rw, value); err != nil {
+		return nil, err
+	}
+	return value[:size], nil
+}
+
+func redisReplyError(line string) error {
+	if len(line) > 0 && line[0] == '-' {
+		return errors.New(line[1:])
+	}
+	return fmt.Errorf("unexpected redis reply %q", line)
+}
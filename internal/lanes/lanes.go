@@ -0,0 +1,318 @@
+// Package lanes limits the number of concurrent verifications and admits the waiting ones by priority class.
+// Waiting verifications are admitted with a smooth weighted round robin over the classes, so the higher classes
+// are admitted first under load while the lower classes still get a share of the slots and are never starved.
+package lanes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Class is the priority class of a verification
+type Class string
+
+// Priority classes
+const (
+	High   Class = "high"
+	Normal Class = "normal"
+	Low    Class = "low"
+)
+
+// Classes are the priority classes, highest first
+var Classes = []Class{High, Normal, Low}
+
+// weights are the shares of the slots of the classes when all of them are waiting
+var weights = map[Class]int{High: 6, Normal: 3, Low: 1}
+
+// ParseClass parses a priority class, an empty class is Normal
+func ParseClass(s string) (Class, error) {
+	switch c := Class(s); c {
+	case "":
+		return Normal, nil
+	case High, Normal, Low:
+		return c, nil
+	default:
+		return "", fmt.Errorf("invalid priority class %s, expected %s, %s or %s", s, High, Normal, Low)
+	}
+}
+
+// ErrQueueFull is returned when the queue of the verifications waiting for a slot is full
+var ErrQueueFull = errors.New("verification queue is full")
+
+type waiter struct {
+	ready chan struct{}
+	// admit is called with the slot instead of waking up Wait, see OnAdmit
+	admit func(release func())
+}
+
+type classStats struct {
+	admitted uint64
+	queued   uint64
+	rejected uint64
+	canceled uint64
+	waitSum  time.Duration
+}
+
+// Limiter admits up to a number of concurrent verifications
+type Limiter struct {
+	mu          sync.Mutex
+	concurrency int
+	queueSize   int
+	free        int
+	queues      map[Class][]*waiter
+	current     map[Class]int
+	stats       map[Class]*classStats
+}
+
+// NewLimiter creates a Limiter of concurrency slots. Up to queueSize verifications wait for a slot, any number
+// when queueSize is 0.
+func NewLimiter(concurrency, queueSize int) *Limiter {
+	l := &Limiter{
+		concurrency: concurrency,
+		queueSize:   queueSize,
+		free:        concurrency,
+		queues:      make(map[Class][]*waiter, len(Classes)),
+		current:     make(map[Class]int, len(Classes)),
+		stats:       make(map[Class]*classStats, len(Classes)),
+	}
+	for _, c := range Classes {
+		l.stats[c] = &classStats{}
+	}
+	return l
+}
+
+// Acquire waits for a slot for a verification of the class, or until ctx is done. It fails with ErrQueueFull
+// when the verification would have to wait and the queue is full. The returned function releases the slot.
+func (l *Limiter) Acquire(ctx context.Context, class Class) (func(), error) {
+	r, err := l.Reserve(class)
+	if err != nil {
+		return nil, err
+	}
+	return r.Wait(ctx)
+}
+
+// Reservation is a verification that holds a slot, or waits for one in the queue
+type Reservation struct {
+	l     *Limiter
+	class Class
+	w     *waiter
+	start time.Time
+}
+
+// Reserve takes a free slot for a verification of the class, or queues it. It fails with ErrQueueFull when there
+// is no free slot and the queue is full.
+func (l *Limiter) Reserve(class Class) (*Reservation, error) {
+	if _, ok := weights[class]; !ok {
+		class = Normal
+	}
+	r := &Reservation{l: l, class: class, start: time.Now()}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.free > 0 && l.waiting() == 0 {
+		l.free--
+		l.stats[class].admitted++
+		return r, nil
+	}
+	if l.queueSize > 0 && l.waiting() >= l.queueSize {
+		l.stats[class].rejected++
+		return nil, ErrQueueFull
+	}
+	r.w = &waiter{ready: make(chan struct{})}
+	l.queues[class] = append(l.queues[class], r.w)
+	l.stats[class].queued++
+	return r, nil
+}
+
+// Admitted reports whether the reservation took a slot at once, without waiting in the queue
+func (r *Reservation) Admitted() bool {
+	return r.w == nil
+}
+
+// Wait waits for the slot of the reservation, or until ctx is done. The returned function releases the slot.
+func (r *Reservation) Wait(ctx context.Context) (func(), error) {
+	l := r.l
+	if r.w == nil {
+		return l.releaser(), nil
+	}
+	select {
+	case <-r.w.ready:
+		l.mu.Lock()
+		r.admitted()
+		l.mu.Unlock()
+		return l.releaser(), nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.stats[r.class].canceled++
+		removed := l.remove(r.class, r.w)
+		l.mu.Unlock()
+		if !removed {
+			// the slot was handed over while ctx was done
+			l.release()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// OnAdmit calls admit with the slot of the reservation once it is admitted, instead of waiting for it: at once when
+// the reservation holds a slot, or when a released slot is handed over to it. admit must not block, as it is called by
+// the release of the slot.
+func (r *Reservation) OnAdmit(admit func(release func())) {
+	l := r.l
+	if r.w == nil {
+		admit(l.releaser())
+		return
+	}
+	l.mu.Lock()
+	select {
+	case <-r.w.ready:
+		// the slot was handed over before admit was set
+		r.admitted()
+		l.mu.Unlock()
+		admit(l.releaser())
+	default:
+		r.w.admit = func(release func()) {
+			l.mu.Lock()
+			r.admitted()
+			l.mu.Unlock()
+			admit(release)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// admitted records the admission of a queued reservation, the lock of the limiter must be held
+func (r *Reservation) admitted() {
+	r.l.stats[r.class].admitted++
+	r.l.stats[r.class].waitSum += time.Since(r.start)
+}
+
+// releaser returns a function releasing the slot once
+func (l *Limiter) releaser() func() {
+	var once sync.Once
+	return func() { once.Do(l.release) }
+}
+
+// release hands the slot over to the next waiting verification, or frees it
+func (l *Limiter) release() {
+	l.mu.Lock()
+	next := l.next()
+	if next == nil {
+		l.free++
+		l.mu.Unlock()
+		return
+	}
+	close(next.ready)
+	l.mu.Unlock()
+	if next.admit != nil {
+		next.admit(l.releaser())
+	}
+}
+
+// next pops the next waiting verification with a smooth weighted round robin over the classes that have waiters
+func (l *Limiter) next() *waiter {
+	var best Class
+	total := 0
+	for _, c := range Classes {
+		if len(l.queues[c]) == 0 {
+			continue
+		}
+		l.current[c] += weights[c]
+		total += weights[c]
+		if best == "" || l.current[c] > l.current[best] {
+			best = c
+		}
+	}
+	if best == "" {
+		return nil
+	}
+	l.current[best] -= total
+	w := l.queues[best][0]
+	l.queues[best] = l.queues[best][1:]
+	return w
+}
+
+func (l *Limiter) remove(class Class, w *waiter) bool {
+	queue := l.queues[class]
+	for i := range queue {
+		if queue[i] == w {
+			l.queues[class] = append(queue[:i:i], queue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Limiter) waiting() int {
+	n := 0
+	for _, queue := range l.queues {
+		n += len(queue)
+	}
+	return n
+}
+
+// WritePrometheus writes the metrics of the classes in the Prometheus text format
+func (l *Limiter) WritePrometheus(w io.Writer) error {
+	l.mu.Lock()
+	waiting := make(map[Class]int, len(Classes))
+	stats := make(map[Class]classStats, len(Classes))
+	for _, c := range Classes {
+		waiting[c] = len(l.queues[c])
+		stats[c] = *l.stats[c]
+	}
+	busy := l.concurrency - l.free
+	l.mu.Unlock()
+
+	var err error
+	printf := func(format string, args ...any) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+	printf("# HELP verifier_verification_queue_waiting Verifications waiting for a slot by priority class.\n")
+	printf("# TYPE verifier_verification_queue_waiting gauge\n")
+	for _, c := range Classes {
+		printf("verifier_verification_queue_waiting{class=%q} %d\n", c, waiting[c])
+	}
+	printf("# HELP verifier_verification_slots_busy Verification slots in use.\n")
+	printf("# TYPE verifier_verification_slots_busy gauge\n")
+	printf("verifier_verification_slots_busy %d\n", busy)
+	printf("# HELP verifier_verification_slots Verification slots.\n")
+	printf("# TYPE verifier_verification_slots gauge\n")
+	printf("verifier_verification_slots %d\n", l.concurrency)
+	if l.queueSize > 0 {
+		printf("# HELP verifier_verification_queue_size Verifications that can wait for a slot.\n")
+		printf("# TYPE verifier_verification_queue_size gauge\n")
+		printf("verifier_verification_queue_size %d\n", l.queueSize)
+	}
+	printf("# HELP verifier_verification_admitted_total Verifications admitted by priority class.\n")
+	printf("# TYPE verifier_verification_admitted_total counter\n")
+	for _, c := range Classes {
+		printf("verifier_verification_admitted_total{class=%q} %d\n", c, stats[c].admitted)
+	}
+	printf("# HELP verifier_verification_queued_total Verifications that waited for a slot by priority class.\n")
+	printf("# TYPE verifier_verification_queued_total counter\n")
+	for _, c := range Classes {
+		printf("verifier_verification_queued_total{class=%q} %d\n", c, stats[c].queued)
+	}
+	printf("# HELP verifier_verification_rejected_total Verifications rejected because the queue was full by priority class.\n")
+	printf("# TYPE verifier_verification_rejected_total counter\n")
+	for _, c := range Classes {
+		printf("verifier_verification_rejected_total{class=%q} %d\n", c, stats[c].rejected)
+	}
+	printf("# HELP verifier_verification_canceled_total Verifications canceled while waiting for a slot by priority class.\n")
+	printf("# TYPE verifier_verification_canceled_total counter\n")
+	for _, c := range Classes {
+		printf("verifier_verification_canceled_total{class=%q} %d\n", c, stats[c].canceled)
+	}
+	printf("# HELP verifier_verification_queue_wait_seconds_total Time spent waiting for a slot by priority class.\n")
+	printf("# TYPE verifier_verification_queue_wait_seconds_total counter\n")
+	for _, c := range Classes {
+		printf("verifier_verification_queue_wait_seconds_total{class=%q} %g\n", c, stats[c].waitSum.Seconds())
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package lanes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClass(t *testing.T) {
+	for input, expected := range map[string]Class{"": Normal, "high": High, "normal": Normal, "low": Low} {
+		class, err := ParseClass(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, class)
+	}
+	_, err := ParseClass("urgent")
+	assert.Error(t, err)
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	l := NewLimiter(1, 0)
+	release, err := l.Acquire(ctx, Low)
+	require.NoError(t, err)
+
+	// waiters are queued before the slot is released, then admitted one at a time
+	admitted := make(chan Class, 20)
+	queue := func(class Class, n int) {
+		for i := 0; i < n; i++ {
+			go func() {
+				release, err := l.Acquire(ctx, class)
+				if !assert.NoError(t, err) {
+					return
+				}
+				admitted <- class
+				release()
+			}()
+		}
+		require.Eventually(t, func() bool {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			return len(l.queues[class]) == n
+		}, time.Second, time.Millisecond)
+	}
+	queue(High, 10)
+	queue(Low, 10)
+
+	// the canceled waiter does not take a slot
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = l.Acquire(canceled, Normal)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	release()
+	release()
+	order := make([]Class, 0, 20)
+	for i := 0; i < 20; i++ {
+		order = append(order, <-admitted)
+	}
+	// high is admitted six times for every low
+	assert.Equal(t, []Class{High, High, High, Low, High, High, High}, order[:7])
+	assert.Equal(t, Low, order[19])
+
+	var metrics bytes.Buffer
+	require.NoError(t, l.WritePrometheus(&metrics))
+	assert.Contains(t, metrics.String(), `verifier_verification_admitted_total{class="high"} 10`)
+	assert.Contains(t, metrics.String(), `verifier_verification_admitted_total{class="low"} 11`)
+	assert.Contains(t, metrics.String(), `verifier_verification_canceled_total{class="normal"} 1`)
+	assert.Contains(t, metrics.String(), `verifier_verification_queue_waiting{class="high"} 0`)
+}
+
+func TestLimiterQueueSize(t *testing.T) {
+	ctx := context.Background()
+	l := NewLimiter(1, 1)
+	admitted, err := l.Reserve(High)
+	require.NoError(t, err)
+	assert.True(t, admitted.Admitted())
+
+	queued, err := l.Reserve(Low)
+	require.NoError(t, err)
+	assert.False(t, queued.Admitted())
+	_, err = l.Reserve(High)
+	assert.ErrorIs(t, err, ErrQueueFull)
+	_, err = l.Acquire(ctx, Normal)
+	assert.ErrorIs(t, err, ErrQueueFull)
+
+	release, err := admitted.Wait(ctx)
+	require.NoError(t, err)
+	release()
+	release, err = queued.Wait(ctx)
+	require.NoError(t, err)
+
+	var metrics bytes.Buffer
+	require.NoError(t, l.WritePrometheus(&metrics))
+	assert.Contains(t, metrics.String(), "verifier_verification_slots_busy 1\n")
+	assert.Contains(t, metrics.String(), "verifier_verification_queue_size 1\n")
+	assert.Contains(t, metrics.String(), `verifier_verification_queued_total{class="low"} 1`)
+	assert.Contains(t, metrics.String(), `verifier_verification_rejected_total{class="high"} 1`)
+	assert.Contains(t, metrics.String(), `verifier_verification_rejected_total{class="normal"} 1`)
+	release()
+}
+
+func TestReservationOnAdmit(t *testing.T) {
+	ctx := context.Background()
+	l := NewLimiter(1, 0)
+	held, err := l.Reserve(Normal)
+	require.NoError(t, err)
+
+	admitted := make(chan func(), 2)
+	held.OnAdmit(func(release func()) { admitted <- release })
+	release := <-admitted
+
+	queued, err := l.Reserve(High)
+	require.NoError(t, err)
+	queued.OnAdmit(func(release func()) { admitted <- release })
+	assert.Empty(t, admitted, "the queued reservation waits for the slot")
+
+	// the released slot is handed over to the queued reservation without a waiting goroutine
+	release()
+	release = <-admitted
+	var metrics bytes.Buffer
+	require.NoError(t, l.WritePrometheus(&metrics))
+	assert.Contains(t, metrics.String(), "verifier_verification_slots_busy 1\n")
+	assert.Contains(t, metrics.String(), `verifier_verification_admitted_total{class="high"} 1`)
+
+	release()
+	release, err = l.Acquire(ctx, Low)
+	require.NoError(t, err)
+	release()
+}
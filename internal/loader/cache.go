@@ -0,0 +1,204 @@
+package loader
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/piprate/json-gold/ld"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/objectstore"
+)
+
+const (
+	// acceptHeader prefers JSON-LD documents, as the loader of go-schema-processor
+	acceptHeader = "application/ld+json, application/json;q=0.9, */*;q=0.1"
+	ipfsPrefix   = "ipfs://"
+	// fetchTimeout is the time a fetch of a document can take without guard
+	fetchTimeout = 30 * time.Second
+	maxDocSize   = 16 << 20
+)
+
+// Option configures a W3CDocumentLoader
+type Option func(*W3CDocumentLoader)
+
+// WithStore caches the documents fetched over http and from the ipfs gateway in store, so they survive restarts and
+// are shared across the replicas. Http documents are revalidated with their ETag once they are older than ttl,
+// and the cached document is used when the revalidation fails. Ipfs documents are immutable and never revalidated.
+func WithStore(store objectstore.Store, ttl time.Duration) Option {
+	return func(d *W3CDocumentLoader) {
+		d.cache = &documentCache{store: store, ttl: ttl, docs: make(map[string]*cachedDocument)}
+	}
+}
+
+// cachedDocument is a document as kept in the store
+type cachedDocument struct {
+	URL       string          `json:"url"`
+	ETag      string          `json:"etag,omitempty"`
+	FetchedAt time.Time       `json:"fetchedAt"`
+	Document  json.RawMessage `json:"document"`
+
+	parsed any
+}
+
+type documentCache struct {
+	store   objectstore.Store
+	ttl     time.Duration
+	client  *http.Client
+	timeout time.Duration
+
+	mu   sync.Mutex
+	docs map[string]*cachedDocument
+}
+
+// fetchFunc downloads a document, or revalidates the cached one when it is not nil
+type fetchFunc func(ctx context.Context, cached *cachedDocument) (*cachedDocument, error)
+
+// load returns the document of u, downloaded with fetch when it is not cached or has expired
+func (c *documentCache) load(u string, fetch fetchFunc, immutable bool) (*ld.RemoteDocument, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	c.mu.Lock()
+	doc, ok := c.docs[u]
+	c.mu.Unlock()
+	if !ok {
+		doc = c.read(ctx, u)
+	}
+	if doc != nil && (immutable || time.Since(doc.FetchedAt) < c.ttl) {
+		return c.remember(u, doc)
+	}
+
+	fetched, err := fetch(ctx, doc)
+	if err != nil {
+		if doc == nil {
+			return nil, ld.NewJsonLdError(ld.LoadingDocumentFailed, err)
+		}
+		log.WithFields(log.Fields{"url": u, "err": err}).Warn("failed to revalidate document, using the cached one")
+		return c.remember(u, doc)
+	}
+	c.write(ctx, u, fetched)
+	return c.remember(u, fetched)
+}
+
+// fetchHTTP downloads the document of fetchURL, or revalidates the cached one with its ETag
+func (c *documentCache) fetchHTTP(fetchURL string) fetchFunc {
+	return func(ctx context.Context, cached *cachedDocument) (*cachedDocument, error) {
+		return c.getHTTP(ctx, fetchURL, cached)
+	}
+}
+
+// fetchIPFS downloads the ipfs document of u
+func (c *documentCache) fetchIPFS(ipfs *IPFS, u string) fetchFunc {
+	return func(_ context.Context, _ *cachedDocument) (*cachedDocument, error) {
+		if ipfs == nil {
+			return nil, errors.New("ipfs is not configured")
+		}
+		r, err := ipfs.Cat(strings.TrimPrefix(u, ipfsPrefix))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		body, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		if !json.Valid(body) {
+			return nil, fmt.Errorf("document of %s is not json", u)
+		}
+		return &cachedDocument{URL: u, FetchedAt: time.Now().UTC(), Document: body}, nil
+	}
+}
+
+func (c *documentCache) getHTTP(ctx context.Context, fetchURL string, cached *cachedDocument) (*cachedDocument, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fetchURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", acceptHeader)
+	if cached != nil && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		if cached == nil {
+			return nil, errors.New("unexpected not modified response")
+		}
+		revalidated := *cached
+		revalidated.FetchedAt = time.Now().UTC()
+		return &revalidated, nil
+	case http.StatusOK:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxDocSize))
+		if err != nil {
+			return nil, err
+		}
+		if !json.Valid(body) {
+			return nil, fmt.Errorf("document of %s is not json", fetchURL)
+		}
+		return &cachedDocument{URL: fetchURL, ETag: resp.Header.Get("ETag"), FetchedAt: time.Now().UTC(), Document: body}, nil
+	default:
+		return nil, fmt.Errorf("unexpected status %s fetching %s", resp.Status, fetchURL)
+	}
+}
+
+// remember keeps the parsed document in memory
+func (c *documentCache) remember(u string, doc *cachedDocument) (*ld.RemoteDocument, error) {
+	if doc.parsed == nil {
+		parsed, err := ld.DocumentFromReader(bytes.NewReader(doc.Document))
+		if err != nil {
+			return nil, ld.NewJsonLdError(ld.LoadingDocumentFailed, err)
+		}
+		doc.parsed = parsed
+	}
+	c.mu.Lock()
+	c.docs[u] = doc
+	c.mu.Unlock()
+	return &ld.RemoteDocument{DocumentURL: u, Document: doc.parsed}, nil
+}
+
+func (c *documentCache) read(ctx context.Context, u string) *cachedDocument {
+	data, err := c.store.Get(ctx, objectName(u))
+	if err != nil {
+		if !errors.Is(err, objectstore.ErrNotFound) {
+			log.WithFields(log.Fields{"url": u, "err": err}).Warn("failed to read document from the cache")
+		}
+		return nil
+	}
+	var doc cachedDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil
+	}
+	return &doc
+}
+
+func (c *documentCache) write(ctx context.Context, u string, doc *cachedDocument) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return
+	}
+	if err := c.store.Put(ctx, objectName(u), data); err != nil {
+		log.WithFields(log.Fields{"url": u, "err": err}).Warn("failed to write document to the cache")
+	}
+}
+
+// objectName names the object of the document of u after its hash, as urls are not valid object names
+func objectName(u string) string {
+	sum := sha256.Sum256([]byte(u))
+	return "documents/" + hex.EncodeToString(sum[:]) + ".json"
+}
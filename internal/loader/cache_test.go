@@ -0,0 +1,69 @@
+package loader
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygonID/verifier-backend/internal/objectstore"
+)
+
+func TestLoaderCache(t *testing.T) {
+	var requests, revalidations atomic.Int32
+	var unavailable atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if unavailable.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidations.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"@context":{"name":"https://schema.org/name"}}`))
+	}))
+	defer server.Close()
+
+	store := objectstore.Dir{Path: t.TempDir()}
+	u := server.URL + "/kyc-v3.json-ld"
+	ipfsURL := "ipfs://QmXwNcbWDpHvUMvG7ALfR7KdYDpLMqjNYJ3QmrDAjLiCfe"
+
+	l := NewW3CDocumentLoader(NewIPFS("", []string{server.URL}), WithStore(store, time.Hour))
+	doc, err := l.LoadDocument(u)
+	require.NoError(t, err)
+	assert.Equal(t, u, doc.DocumentURL)
+	assert.Equal(t, map[string]any{"@context": map[string]any{"name": "https://schema.org/name"}}, doc.Document)
+	_, err = l.LoadDocument(ipfsURL)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// a restarted verifier reads the documents from the store
+	restarted := NewW3CDocumentLoader(NewIPFS("", []string{server.URL}), WithStore(store, time.Hour))
+	_, err = restarted.LoadDocument(u)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// expired http documents are revalidated, ipfs documents never expire
+	expired := NewW3CDocumentLoader(NewIPFS("", []string{server.URL}), WithStore(store, 0))
+	_, err = expired.LoadDocument(u)
+	require.NoError(t, err)
+	_, err = expired.LoadDocument(ipfsURL)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+	assert.Equal(t, int32(1), revalidations.Load())
+
+	// the cached document is used while the server is unavailable
+	unavailable.Store(true)
+	_, err = expired.LoadDocument(u)
+	require.NoError(t, err)
+	_, err = expired.LoadDocument(server.URL + "/missing.json-ld")
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrFetchNotAllowed is returned when the guard of the loader refuses to fetch a document
+var ErrFetchNotAllowed = errors.New("document fetch is not allowed")
+
+// blockedNetworks are the networks of the private, shared and reserved addresses that the standard library does not
+// classify, e.g. the carrier-grade NAT range of some cloud metadata endpoints
+var blockedNetworks = mustParseCIDRs("0.0.0.0/8", "100.64.0.0/10", "192.0.0.0/24", "198.18.0.0/15", "240.0.0.0/4", "64:ff9b::/96")
+
+// Guard restricts the outbound fetches of the documents, so a context url of a sign-in request cannot reach the
+// internal services of the verifier. Only the documents of Schemes are loaded, and the http fetches refuse the hosts
+// that resolve to private, loopback, link-local, e.g. 169.254.169.254, or reserved addresses unless AllowPrivate is
+// set. The address is checked when the connection is dialed, after the name resolution, so a DNS name cannot be
+// rebound to an internal address, and the fetches do not go through the proxies of the environment. A fetch follows
+// at most MaxRedirects redirects and takes at most Timeout.
+type Guard struct {
+	Schemes      []string
+	AllowPrivate bool
+	MaxRedirects int
+	Timeout      time.Duration
+}
+
+// WithGuard fetches the documents with the restrictions of g
+func WithGuard(g Guard) Option {
+	return func(d *W3CDocumentLoader) {
+		d.guard = &g
+		d.client = g.client()
+	}
+}
+
+// checkScheme refuses the documents whose url scheme is not allowed
+func (g *Guard) checkScheme(u string) error {
+	scheme := scheme(u)
+	for _, allowed := range g.Schemes {
+		if scheme == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: scheme of %s is not allowed", ErrFetchNotAllowed, u)
+}
+
+// client returns the http client of the fetches of the documents
+func (g *Guard) client() *http.Client {
+	dialer := &net.Dialer{Timeout: g.Timeout, KeepAlive: 30 * time.Second}
+	if !g.AllowPrivate {
+		dialer.Control = checkDialAddress
+	}
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   g.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > g.MaxRedirects {
+				return fmt.Errorf("%w: more than %d redirects fetching %s", ErrFetchNotAllowed, g.MaxRedirects, via[0].URL)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("%w: redirect to %s", ErrFetchNotAllowed, req.URL)
+			}
+			return g.checkScheme(req.URL.String())
+		},
+	}
+}
+
+// checkDialAddress refuses the connections to the internal addresses, it is called with the resolved address
+func checkDialAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("%w: invalid address %s", ErrFetchNotAllowed, address)
+	}
+	if isInternalIP(ip) {
+		return fmt.Errorf("%w: %s is an internal address", ErrFetchNotAllowed, ip)
+	}
+	return nil
+}
+
+func isInternalIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// timeout returns how long a fetch of a document can take, the longest timeout of the hosts included
+func (d *W3CDocumentLoader) timeout() time.Duration {
+	if d.guard != nil && d.guard.Timeout > 0 {
+		return maxHostTimeout(d.guard.Timeout, d.hosts)
+	}
+	return maxHostTimeout(fetchTimeout, d.hosts)
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,75 @@
+package loader
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuard(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/r3":
+			http.Redirect(w, r, "/r2", http.StatusFound)
+		case "/r2":
+			http.Redirect(w, r, "/r1", http.StatusFound)
+		case "/r1":
+			http.Redirect(w, r, "/kyc-v3.json-ld", http.StatusFound)
+		default:
+			_, _ = w.Write([]byte(`{"@context":{"name":"https://schema.org/name"}}`))
+		}
+	}))
+	defer server.Close()
+	guard := Guard{Schemes: []string{"http", "https"}, MaxRedirects: 2, Timeout: 5 * time.Second}
+
+	// the test server listens on a loopback address
+	_, err := NewW3CDocumentLoader(nil, WithGuard(guard)).LoadDocument(server.URL + "/kyc-v3.json-ld")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is an internal address")
+	assert.Equal(t, int32(0), requests.Load())
+
+	guard.AllowPrivate = true
+	l := NewW3CDocumentLoader(nil, WithGuard(guard))
+	doc, err := l.LoadDocument(server.URL + "/kyc-v3.json-ld")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"@context": map[string]any{"name": "https://schema.org/name"}}, doc.Document)
+	_, err = l.LoadDocument(server.URL + "/r2")
+	require.NoError(t, err)
+	_, err = l.LoadDocument(server.URL + "/r3")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "more than 2 redirects")
+
+	guard.Schemes = []string{"https"}
+	requests.Store(0)
+	_, err = NewW3CDocumentLoader(nil, WithGuard(guard)).LoadDocument(server.URL + "/other.json-ld")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not allowed")
+	assert.Equal(t, int32(0), requests.Load())
+}
+
+func TestIsInternalIP(t *testing.T) {
+	for ip, internal := range map[string]bool{
+		"127.0.0.1":       true,
+		"10.1.2.3":        true,
+		"172.16.0.1":      true,
+		"192.168.1.1":     true,
+		"169.254.169.254": true,
+		"100.100.100.200": true,
+		"0.0.0.0":         true,
+		"::1":             true,
+		"fd00:ec2::254":   true,
+		"fe80::1":         true,
+		"8.8.8.8":         false,
+		"2606:4700::1111": false,
+	} {
+		assert.Equal(t, internal, isInternalIP(net.ParseIP(ip)), ip)
+	}
+}
@@ -0,0 +1,107 @@
+package loader
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Host holds the settings of the requests to a schema host or an ipfs gateway, e.g. the token of a private gateway or
+// the basic auth of a private schema registry. Name is the host of the urls, with its port when it is not the default
+// one. The requests to the host take at most Timeout, when it is set, instead of the timeout of the fetches.
+type Host struct {
+	Name     string
+	Header   http.Header
+	Username string
+	Password string
+	Timeout  time.Duration
+}
+
+// WithHosts sets the headers, the basic auth and the timeouts of the requests to hosts, the ipfs gateways included.
+// They are only sent to their host, not to the hosts the requests are redirected to.
+func WithHosts(hosts []Host) Option {
+	return func(d *W3CDocumentLoader) {
+		d.hosts = make(map[string]Host, len(hosts))
+		for _, h := range hosts {
+			d.hosts[strings.ToLower(h.Name)] = h
+		}
+	}
+}
+
+// withHosts returns a client that sends the requests of client with the settings of their host. The timeout of client
+// becomes the timeout of each request to the other hosts.
+func withHosts(client *http.Client, hosts map[string]Host) *http.Client {
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	c := *client
+	c.Transport = &hostTransport{next: transport, hosts: hosts, timeout: client.Timeout}
+	c.Timeout = 0
+	return &c
+}
+
+// maxHostTimeout returns the longest of timeout and the timeouts of the hosts
+func maxHostTimeout(timeout time.Duration, hosts map[string]Host) time.Duration {
+	for _, h := range hosts {
+		if h.Timeout > timeout {
+			timeout = h.Timeout
+		}
+	}
+	return timeout
+}
+
+type hostTransport struct {
+	next    http.RoundTripper
+	hosts   map[string]Host
+	timeout time.Duration
+}
+
+func (t *hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host, ok := t.hosts[strings.ToLower(req.URL.Host)]
+	if !ok {
+		host, ok = t.hosts[strings.ToLower(req.URL.Hostname())]
+	}
+	timeout := t.timeout
+	if ok && host.Timeout > 0 {
+		timeout = host.Timeout
+	}
+	if !ok && timeout == 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	req = req.Clone(ctx)
+	if ok {
+		for name, values := range host.Header {
+			req.Header[http.CanonicalHeaderKey(name)] = values
+		}
+		if host.Username != "" || host.Password != "" {
+			req.SetBasicAuth(host.Username, host.Password)
+		}
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelBody cancels the context of the request once its response is read
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
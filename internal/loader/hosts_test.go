@@ -0,0 +1,79 @@
+package loader
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHosts(t *testing.T) {
+	private := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "verifier" || password != "secret" || r.Header.Get("X-Registry-Token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/slow.json-ld" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(`{"@context":{"name":"https://schema.org/name"}}`))
+	}))
+	defer private.Close()
+	var leaked []string
+	public := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = append(leaked, r.Header.Get("Authorization"), r.Header.Get("X-Registry-Token"))
+		http.Redirect(w, r, private.URL+"/kyc-v3.json-ld", http.StatusFound)
+	}))
+	defer public.Close()
+	privateURL, err := url.Parse(private.URL)
+	require.NoError(t, err)
+
+	guard := Guard{Schemes: []string{"http"}, AllowPrivate: true, MaxRedirects: 2, Timeout: 5 * time.Second}
+	_, err = NewW3CDocumentLoader(nil, WithGuard(guard)).LoadDocument(private.URL + "/kyc-v3.json-ld")
+	require.Error(t, err)
+
+	hosts := []Host{{
+		Name:     privateURL.Host,
+		Header:   http.Header{"X-Registry-Token": {"token"}},
+		Username: "verifier",
+		Password: "secret",
+		Timeout:  100 * time.Millisecond,
+	}}
+	l := NewW3CDocumentLoader(nil, WithGuard(guard), WithHosts(hosts))
+	doc, err := l.LoadDocument(private.URL + "/kyc-v3.json-ld")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"@context": map[string]any{"name": "https://schema.org/name"}}, doc.Document)
+
+	// the settings of the host are not sent to the other hosts
+	_, err = l.LoadDocument(public.URL + "/redirect.json-ld")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", ""}, leaked)
+
+	_, err = l.LoadDocument(private.URL + "/slow.json-ld")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "deadline exceeded")
+}
+
+func TestHostsIPFSGateway(t *testing.T) {
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Pinata-Gateway-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"@context":{"name":"https://schema.org/name"}}`))
+	}))
+	defer gateway.Close()
+	gatewayURL, err := url.Parse(gateway.URL)
+	require.NoError(t, err)
+
+	hosts := []Host{{Name: gatewayURL.Host, Header: http.Header{"X-Pinata-Gateway-Token": {"token"}}}}
+	l := NewW3CDocumentLoader(NewIPFS("", []string{gateway.URL}), WithHosts(hosts))
+	doc, err := l.LoadDocument("ipfs://QmdH1Vu79p2NcZLFbHxzJnLuUHJiMZnBeT7SNpLaqK7k9X")
+	require.NoError(t, err)
+	assert.NotNil(t, doc.Document)
+}
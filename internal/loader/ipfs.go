@@ -0,0 +1,132 @@
+package loader
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	shell "github.com/ipfs/go-ipfs-api"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ipfsTimeout = 10 * time.Second
+	// ipfsCooldown is how long a failing gateway is tried after the others
+	ipfsCooldown = 30 * time.Second
+)
+
+// IPFS fetches ipfs documents from a local node, when it is set, then from the gateways in order.
+// Gateways that fail are tried last for a while, so an outage of a gateway does not slow down every verification.
+type IPFS struct {
+	node     *shell.Shell
+	gateways []*gateway
+	client   *http.Client
+}
+
+type gateway struct {
+	url string
+
+	mu             sync.Mutex
+	unhealthyUntil time.Time
+}
+
+func (g *gateway) healthy(now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return !now.Before(g.unhealthyUntil)
+}
+
+func (g *gateway) report(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err == nil {
+		g.unhealthyUntil = time.Time{}
+		return
+	}
+	if g.unhealthyUntil.IsZero() {
+		log.WithFields(log.Fields{"gateway": g.url, "err": err}).Warn("ipfs gateway is failing")
+	}
+	g.unhealthyUntil = time.Now().Add(ipfsCooldown)
+}
+
+// NewIPFS creates an IPFS client of the node API at nodeURL, when it is not empty, and of the gateways
+func NewIPFS(nodeURL string, gateways []string) *IPFS {
+	i := &IPFS{client: &http.Client{Timeout: ipfsTimeout}}
+	if nodeURL != "" {
+		i.node = shell.NewShell(nodeURL)
+		i.node.SetTimeout(ipfsTimeout)
+	}
+	for _, url := range gateways {
+		if url = strings.TrimRight(url, "/"); url != "" {
+			i.gateways = append(i.gateways, &gateway{url: url})
+		}
+	}
+	return i
+}
+
+// Cat returns the content of the ipfs path, {cid}/{path}, from the first node or gateway that serves it
+func (i *IPFS) Cat(path string) (io.ReadCloser, error) {
+	path = strings.TrimLeft(path, "/")
+	var errs []error
+	if i.node != nil {
+		data, err := i.catNode(path)
+		if err == nil {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}
+		errs = append(errs, fmt.Errorf("ipfs node: %w", err))
+	}
+
+	now := time.Now()
+	var healthy, unhealthy []*gateway
+	for _, gw := range i.gateways {
+		if gw.healthy(now) {
+			healthy = append(healthy, gw)
+		} else {
+			unhealthy = append(unhealthy, gw)
+		}
+	}
+	for _, gw := range append(healthy, unhealthy...) {
+		data, err := i.catGateway(gw.url, path)
+		gw.report(err)
+		if err == nil {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}
+		errs = append(errs, fmt.Errorf("ipfs gateway %s: %w", gw.url, err))
+	}
+	if len(errs) == 0 {
+		return nil, errors.New("ipfs is not configured")
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (i *IPFS) catNode(path string) ([]byte, error) {
+	r, err := i.node.Cat(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(io.LimitReader(r, maxDocSize))
+}
+
+// catGateway reads the whole document, so the next gateway is tried when the transfer fails
+func (i *IPFS) catGateway(gatewayURL, path string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, gatewayURL+"/ipfs/"+path, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", acceptHeader)
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxDocSize))
+}
@@ -0,0 +1,50 @@
+package loader
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPFSFailover(t *testing.T) {
+	var down, up atomic.Int32
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		down.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	working := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		up.Add(1)
+		assert.Equal(t, "/ipfs/QmXwNcbWDpHvUMvG7ALfR7KdYDpLMqjNYJ3QmrDAjLiCfe/schema.json", r.URL.Path)
+		_, _ = w.Write([]byte(`{"$schema":"http://json-schema.org/draft-07/schema#"}`))
+	}))
+	defer working.Close()
+
+	ipfs := NewIPFS("", []string{failing.URL + "/", working.URL})
+	read := func() string {
+		r, err := ipfs.Cat("QmXwNcbWDpHvUMvG7ALfR7KdYDpLMqjNYJ3QmrDAjLiCfe/schema.json")
+		require.NoError(t, err)
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	assert.Equal(t, `{"$schema":"http://json-schema.org/draft-07/schema#"}`, read())
+	assert.Equal(t, int32(1), down.Load())
+
+	// the failing gateway is tried after the working one until its cooldown ends
+	read()
+	assert.Equal(t, int32(1), down.Load())
+	assert.Equal(t, int32(2), up.Load())
+
+	_, err := NewIPFS("", []string{failing.URL}).Cat("QmXwNcbWDpHvUMvG7ALfR7KdYDpLMqjNYJ3QmrDAjLiCfe")
+	assert.ErrorContains(t, err, "502 Bad Gateway")
+	_, err = NewIPFS("", nil).Cat("QmXwNcbWDpHvUMvG7ALfR7KdYDpLMqjNYJ3QmrDAjLiCfe")
+	assert.Error(t, err)
+}
@@ -1,27 +1,119 @@
 package loader
 
 import (
+	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/iden3/go-schema-processor/v2/loaders"
-	shell "github.com/ipfs/go-ipfs-api"
 	"github.com/piprate/json-gold/ld"
+
+	"github.com/0xPolygonID/verifier-backend/internal/policy"
 )
 
 // W3CDocumentLoader is a document loader that loads w3c context
 type W3CDocumentLoader struct {
-	l ld.DocumentLoader
+	l         ld.DocumentLoader
+	ipfs      *IPFS
+	client    *http.Client
+	guard     *Guard
+	hosts     map[string]Host
+	cache     *documentCache
+	memory    *memoryCache
+	allowlist *policy.SchemaAllowlist
+
+	mu     sync.RWMutex
+	pinned map[string]*ld.RemoteDocument
+}
+
+// NewW3CDocumentLoader creates a new document loader with a predefined http schema, that loads the ipfs documents with ipfs
+func NewW3CDocumentLoader(ipfs *IPFS, opts ...Option) *W3CDocumentLoader {
+	var ipfsCli loaders.IPFSClient
+	if ipfs != nil {
+		ipfsCli = ipfs
+	}
+	d := &W3CDocumentLoader{
+		ipfs:   ipfs,
+		client: http.DefaultClient,
+		pinned: make(map[string]*ld.RemoteDocument),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if len(d.hosts) > 0 {
+		d.client = withHosts(d.client, d.hosts)
+		if ipfs != nil {
+			ipfs.client = withHosts(ipfs.client, d.hosts)
+		}
+	}
+	d.l = loaders.NewDocumentLoader(ipfsCli, "", loaders.WithHTTPClient(d.client))
+	if d.cache != nil {
+		d.cache.client = d.client
+		d.cache.timeout = d.timeout()
+	}
+	return d
+}
+
+// WithSchemaAllowlist only loads the documents allowed by the schema allowlist, the other ones are not fetched
+func WithSchemaAllowlist(a *policy.SchemaAllowlist) Option {
+	return func(d *W3CDocumentLoader) {
+		d.allowlist = a
+	}
+}
+
+// LoadDocument loads a document, pinned documents are served from memory, as the documents of the memory cache
+func (d *W3CDocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	if err := d.checkAllowed(u); err != nil {
+		return nil, err
+	}
+	d.mu.RLock()
+	doc, ok := d.pinned[u]
+	d.mu.RUnlock()
+	if ok {
+		return doc, nil
+	}
+	if d.memory != nil {
+		return d.memory.load(u, d.load)
+	}
+	return d.load(u)
+}
+
+// Pin fetches the document of u and keeps it in memory, so the verifications that use it do not wait for its download
+// or revalidation. Pinning a pinned document fetches it again.
+func (d *W3CDocumentLoader) Pin(u string) error {
+	if err := d.checkAllowed(u); err != nil {
+		return err
+	}
+	doc, err := d.load(u)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pinned[u] = doc
+	return nil
 }
 
-// NewW3CDocumentLoader creates a new document loader with a predefined http schema
-func NewW3CDocumentLoader(_ *shell.Shell, ipfsGW string) ld.DocumentLoader {
-	return &W3CDocumentLoader{
-		l: loaders.NewDocumentLoader(nil, ipfsGW),
+// checkAllowed rejects the documents of the schemes the guard does not allow and the ones that are not in the schema
+// allowlist, the w3c context is served from memory and always allowed
+func (d *W3CDocumentLoader) checkAllowed(u string) error {
+	if u == W3CCredential2018ContextURL {
+		return nil
+	}
+	if d.guard != nil {
+		if err := d.guard.checkScheme(u); err != nil {
+			return ld.NewJsonLdError(ld.LoadingDocumentFailed, err)
+		}
 	}
+	if d.allowlist != nil {
+		if err := d.allowlist.Check(u); err != nil {
+			return ld.NewJsonLdError(ld.LoadingDocumentFailed, err)
+		}
+	}
+	return nil
 }
 
-// LoadDocument loads a document
-func (d *W3CDocumentLoader) LoadDocument(u string) (doc *ld.RemoteDocument, err error) {
+func (d *W3CDocumentLoader) load(u string) (*ld.RemoteDocument, error) {
 	if u == W3CCredential2018ContextURL {
 		w3cDoc, errIn := ld.DocumentFromReader(strings.NewReader(W3CCredential2018ContextDocument))
 		if errIn != nil {
@@ -33,6 +125,14 @@ func (d *W3CDocumentLoader) LoadDocument(u string) (doc *ld.RemoteDocument, err
 			ContextURL:  u,
 		}, nil
 	}
+	if d.cache != nil {
+		switch {
+		case strings.HasPrefix(u, "http://") || strings.HasPrefix(u, "https://"):
+			return d.cache.load(u, d.cache.fetchHTTP(u), false)
+		case strings.HasPrefix(u, ipfsPrefix):
+			return d.cache.load(u, d.cache.fetchIPFS(d.ipfs, u), true)
+		}
+	}
 	return d.l.LoadDocument(u)
 }
 
@@ -0,0 +1,163 @@
+package loader
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/piprate/json-gold/ld"
+)
+
+// WithMemoryCache keeps the last size loaded documents in memory, evicting the least recently used ones. ttls are the
+// times the documents of each url scheme, e.g. https or ipfs, are kept before they are loaded again, the documents of the
+// schemes without a ttl are kept until they are evicted. The failed loads are cached for negativeTTL, so a missing
+// context is not fetched again by every verification that uses it.
+func WithMemoryCache(size int, ttls map[string]time.Duration, negativeTTL time.Duration) Option {
+	return func(d *W3CDocumentLoader) {
+		if size <= 0 {
+			return
+		}
+		d.memory = &memoryCache{
+			size:        size,
+			ttls:        ttls,
+			negativeTTL: negativeTTL,
+			order:       list.New(),
+			entries:     make(map[string]*list.Element, size),
+			now:         time.Now,
+		}
+	}
+}
+
+// memoryEntry is a loaded document, or the error of a failed load
+type memoryEntry struct {
+	url       string
+	doc       *ld.RemoteDocument
+	err       error
+	expiresAt time.Time
+}
+
+// memoryStats are the counters of the cache since the loader was created
+type memoryStats struct {
+	hits         int
+	negativeHits int
+	misses       int
+	evictions    int
+}
+
+// memoryCache is a size bound LRU cache of the loaded documents
+type memoryCache struct {
+	size        int
+	ttls        map[string]time.Duration
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+	stats   memoryStats
+}
+
+// load returns the cached document of u, or loads it with load and caches the result
+func (c *memoryCache) load(u string, load func(string) (*ld.RemoteDocument, error)) (*ld.RemoteDocument, error) {
+	if entry, ok := c.get(u); ok {
+		return entry.doc, entry.err
+	}
+	doc, err := load(u)
+	c.put(u, doc, err)
+	return doc, err
+}
+
+func (c *memoryCache) get(u string) (memoryEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[u]
+	if ok {
+		entry := elem.Value.(*memoryEntry)
+		if entry.expiresAt.IsZero() || c.now().Before(entry.expiresAt) {
+			c.order.MoveToFront(elem)
+			if entry.err != nil {
+				c.stats.negativeHits++
+			} else {
+				c.stats.hits++
+			}
+			return *entry, true
+		}
+		c.order.Remove(elem)
+		delete(c.entries, u)
+	}
+	c.stats.misses++
+	return memoryEntry{}, false
+}
+
+func (c *memoryCache) put(u string, doc *ld.RemoteDocument, err error) {
+	entry := &memoryEntry{url: u, doc: doc, err: err}
+	if err != nil {
+		if c.negativeTTL <= 0 {
+			return
+		}
+		entry.expiresAt = c.now().Add(c.negativeTTL)
+	} else if ttl, ok := c.ttls[scheme(u)]; ok && ttl > 0 {
+		entry.expiresAt = c.now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[u]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[u] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryEntry).url)
+		c.stats.evictions++
+	}
+}
+
+// scheme returns the scheme of u, e.g. https or ipfs
+func scheme(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+	return parsed.Scheme
+}
+
+// WritePrometheus writes the metrics of the memory cache of the documents in the Prometheus text format. It writes
+// nothing when the loader has no memory cache.
+func (d *W3CDocumentLoader) WritePrometheus(w io.Writer) error {
+	if d.memory == nil {
+		return nil
+	}
+	c := d.memory
+	c.mu.Lock()
+	stats, entries := c.stats, c.order.Len()
+	c.mu.Unlock()
+
+	var err error
+	printf := func(format string, args ...any) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+	printf("# HELP verifier_document_cache_requests_total Loads of JSON-LD documents by cache result.\n")
+	printf("# TYPE verifier_document_cache_requests_total counter\n")
+	printf("verifier_document_cache_requests_total{result=\"hit\"} %d\n", stats.hits)
+	printf("verifier_document_cache_requests_total{result=\"negative_hit\"} %d\n", stats.negativeHits)
+	printf("verifier_document_cache_requests_total{result=\"miss\"} %d\n", stats.misses)
+	printf("# HELP verifier_document_cache_evictions_total JSON-LD documents evicted from the memory cache.\n")
+	printf("# TYPE verifier_document_cache_evictions_total counter\n")
+	printf("verifier_document_cache_evictions_total %d\n", stats.evictions)
+	printf("# HELP verifier_document_cache_entries JSON-LD documents and failed loads in the memory cache.\n")
+	printf("# TYPE verifier_document_cache_entries gauge\n")
+	printf("verifier_document_cache_entries %d\n", entries)
+	printf("# HELP verifier_document_cache_size Maximum number of entries of the memory cache.\n")
+	printf("# TYPE verifier_document_cache_size gauge\n")
+	printf("verifier_document_cache_size %d\n", c.size)
+	return err
+}
@@ -0,0 +1,75 @@
+package loader
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/piprate/json-gold/ld"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	now := time.Now()
+	loads := map[string]int{}
+	load := func(u string) (*ld.RemoteDocument, error) {
+		loads[u]++
+		if strings.HasSuffix(u, "missing.json-ld") {
+			return nil, errors.New("not found")
+		}
+		return &ld.RemoteDocument{DocumentURL: u}, nil
+	}
+
+	l := NewW3CDocumentLoader(nil, WithMemoryCache(2, map[string]time.Duration{"https": time.Hour}, time.Minute))
+	l.memory.now = func() time.Time { return now }
+	httpsURL := "https://example.com/kyc-v3.json-ld"
+	ipfsURL := "ipfs://QmXwNcbWDpHvUMvG7ALfR7KdYDpLMqjNYJ3QmrDAjLiCfe"
+	missingURL := "https://example.com/missing.json-ld"
+
+	for i := 0; i < 2; i++ {
+		doc, err := l.memory.load(httpsURL, load)
+		require.NoError(t, err)
+		assert.Equal(t, httpsURL, doc.DocumentURL)
+		_, err = l.memory.load(missingURL, load)
+		assert.Error(t, err)
+	}
+	assert.Equal(t, map[string]int{httpsURL: 1, missingURL: 1}, loads)
+
+	// the failed loads and the https documents expire, the ipfs documents are kept until they are evicted
+	now = now.Add(2 * time.Minute)
+	_, err := l.memory.load(missingURL, load)
+	assert.Error(t, err)
+	_, err = l.memory.load(ipfsURL, load)
+	require.NoError(t, err)
+	now = now.Add(2 * time.Hour)
+	_, err = l.memory.load(ipfsURL, load)
+	require.NoError(t, err)
+	_, err = l.memory.load(httpsURL, load)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{httpsURL: 2, missingURL: 2, ipfsURL: 1}, loads)
+
+	// the least recently used document is evicted
+	_, err = l.memory.load(ipfsURL, load)
+	require.NoError(t, err)
+	_, err = l.memory.load(missingURL, load)
+	assert.Error(t, err)
+	_, err = l.memory.load(ipfsURL, load)
+	require.NoError(t, err)
+	_, err = l.memory.load(httpsURL, load)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{httpsURL: 3, missingURL: 3, ipfsURL: 1}, loads)
+
+	var metrics strings.Builder
+	require.NoError(t, l.WritePrometheus(&metrics))
+	assert.Contains(t, metrics.String(), `verifier_document_cache_requests_total{result="hit"} 4`)
+	assert.Contains(t, metrics.String(), `verifier_document_cache_requests_total{result="negative_hit"} 1`)
+	assert.Contains(t, metrics.String(), `verifier_document_cache_requests_total{result="miss"} 7`)
+	assert.Contains(t, metrics.String(), "verifier_document_cache_evictions_total 4")
+	assert.Contains(t, metrics.String(), "verifier_document_cache_entries 2")
+
+	var disabled strings.Builder
+	require.NoError(t, NewW3CDocumentLoader(nil).WritePrometheus(&disabled))
+	assert.Empty(t, disabled.String())
+}
@@ -0,0 +1,46 @@
+// Package logging scopes the logs to the requests, so the callbacks of the wallets can be correlated
+// with the sign-in that created their session, across replicas.
+package logging
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	log "github.com/sirupsen/logrus"
+)
+
+// Fields shared by the logs of a request
+const (
+	FieldRequestID = "requestID"
+	FieldSessionID = "sessionID"
+)
+
+type ctxKey struct{}
+
+// WithEntry returns a copy of ctx with the logger of the request
+func WithEntry(ctx context.Context, entry *log.Entry) context.Context {
+	return context.WithValue(ctx, ctxKey{}, entry)
+}
+
+// FromContext returns the logger of the request, or an entry of logger when ctx is not scoped to a request
+func FromContext(ctx context.Context, logger *log.Logger) *log.Entry {
+	if entry, ok := ctx.Value(ctxKey{}).(*log.Entry); ok {
+		return entry
+	}
+	return log.NewEntry(logger)
+}
+
+// Middleware stores a logger with the id of the request, and the sessionID query parameter when present,
+// in the request context. It must run after the chi RequestID middleware.
+func Middleware(logger *log.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			entry := logger.WithField(FieldRequestID, middleware.GetReqID(r.Context()))
+			if sessionID := r.URL.Query().Get(FieldSessionID); sessionID != "" {
+				entry = entry.WithField(FieldSessionID, sessionID)
+			}
+			next.ServeHTTP(w, r.WithContext(WithEntry(r.Context(), entry)))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&buf)
+	logger.SetFormatter(&log.JSONFormatter{})
+
+	handler := middleware.RequestID(Middleware(logger)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context(), log.StandardLogger()).Info("callback")
+	})))
+
+	for _, tc := range []struct {
+		name      string
+		target    string
+		sessionID any
+	}{
+		{name: "with session", target: "/callback?sessionID=6dc645a6-2be3-4099-a645-20784ee53cd0", sessionID: "6dc645a6-2be3-4099-a645-20784ee53cd0"},
+		{name: "without session", target: "/sign-in"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf.Reset()
+			req := httptest.NewRequest(http.MethodPost, tc.target, nil)
+			req.Header.Set(middleware.RequestIDHeader, "req-1")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, "req-1", entry[FieldRequestID])
+			assert.Equal(t, tc.sessionID, entry[FieldSessionID])
+			assert.Equal(t, "callback", entry["msg"])
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+// ErrNotConfigured is returned when the SMTP server is not configured
+var ErrNotConfigured = errors.New("smtp server is not configured")
+
+// Sender sends plain text emails through an SMTP server
+type Sender struct {
+	cfg config.SMTP
+}
+
+// NewSender creates a new Sender
+func NewSender(cfg config.SMTP) *Sender {
+	return &Sender{cfg: cfg}
+}
+
+// Send sends an email with the given subject and body to the recipient
+func (s *Sender) Send(to, subject, body string) error {
+	if s.cfg.Addr == "" || s.cfg.From == "" {
+		return ErrNotConfigured
+	}
+
+	if strings.ContainsAny(to, "\r\n") || strings.ContainsAny(subject, "\r\n") {
+		return errors.New("invalid email header")
+	}
+
+	var auth smtp.Auth
+	if s.cfg.User != "" {
+		host, _, err := net.SplitHostPort(s.cfg.Addr)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", s.cfg.User, s.cfg.Password, host)
+	}
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n", s.cfg.From, to, subject, body)
+	return smtp.SendMail(s.cfg.Addr, auth, s.cfg.From, []string{to}, []byte(msg))
+}
@@ -0,0 +1,226 @@
+// Package memstore implements the in-memory store of the sessions of the verifier: a cache with expiration, bound to a
+// number of entries by evicting the least recently used ones, and its Prometheus metrics.
+package memstore
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+)
+
+// maxSizeDepth is how deep the size of the values is estimated, the deeper values are not counted
+const maxSizeDepth = 8
+
+// Store is an in-memory cache whose entries expire, and that keeps at most maxEntries entries, evicting the least
+// recently used ones. A burst of sessions cannot grow it until their expiration.
+type Store struct {
+	cache      *cache.Cache
+	maxEntries int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+	bytes   int64
+	stats   stats
+}
+
+// entry is a key of the store in the LRU order, with the estimated size of its value
+type entry struct {
+	key  string
+	size int64
+}
+
+// stats are the counters of the store since it was created
+type stats struct {
+	hits        int
+	misses      int
+	evictions   int
+	expirations int
+}
+
+// New creates a store whose entries expire after defaultExpiration, removed every cleanupInterval, that keeps at most
+// maxEntries entries, or any number of them when maxEntries is not positive
+func New(defaultExpiration, cleanupInterval time.Duration, maxEntries int) *Store {
+	s := &Store{
+		cache:      cache.New(defaultExpiration, cleanupInterval),
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+	s.cache.OnEvicted(s.expired)
+	return s
+}
+
+// Get returns the value of key, and whether it was found
+func (s *Store) Get(key string) (any, bool) {
+	value, ok := s.cache.Get(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !ok {
+		s.stats.misses++
+		return nil, false
+	}
+	s.stats.hits++
+	if elem, ok := s.entries[key]; ok {
+		s.order.MoveToFront(elem)
+	}
+	return value, true
+}
+
+// Set sets the value of key, replacing the current one. It expires after d, see go-cache for the special durations.
+func (s *Store) Set(key string, value any, d time.Duration) {
+	s.cache.Set(key, value, d)
+	s.track(key, value)
+}
+
+// Add sets the value of key only when the key is not set or has expired, it returns an error otherwise
+func (s *Store) Add(key string, value any, d time.Duration) error {
+	if err := s.cache.Add(key, value, d); err != nil {
+		return err
+	}
+	s.track(key, value)
+	return nil
+}
+
+// Delete removes key from the store
+func (s *Store) Delete(key string) {
+	s.mu.Lock()
+	s.forget(key)
+	s.mu.Unlock()
+	s.cache.Delete(key)
+}
+
+// Items returns the entries of the store that have not expired
+func (s *Store) Items() map[string]cache.Item {
+	return s.cache.Items()
+}
+
+// track moves key to the front of the LRU order, and evicts the least recently used entries over maxEntries
+func (s *Store) track(key string, value any) {
+	size := int64(len(key)) + sizeOf(reflect.ValueOf(value), 0)
+	var evicted []string
+	s.mu.Lock()
+	if elem, ok := s.entries[key]; ok {
+		e := elem.Value.(*entry)
+		s.bytes += size - e.size
+		e.size = size
+		s.order.MoveToFront(elem)
+	} else {
+		s.entries[key] = s.order.PushFront(&entry{key: key, size: size})
+		s.bytes += size
+	}
+	for s.maxEntries > 0 && s.order.Len() > s.maxEntries {
+		oldest := s.order.Back().Value.(*entry).key
+		s.forget(oldest)
+		s.stats.evictions++
+		evicted = append(evicted, oldest)
+	}
+	s.mu.Unlock()
+
+	// the callback of the evictions of go-cache takes the lock of the store
+	for _, key := range evicted {
+		s.cache.Delete(key)
+	}
+}
+
+// forget removes key from the LRU order, the lock must be held
+func (s *Store) forget(key string) bool {
+	elem, ok := s.entries[key]
+	if !ok {
+		return false
+	}
+	s.order.Remove(elem)
+	delete(s.entries, key)
+	s.bytes -= elem.Value.(*entry).size
+	return true
+}
+
+// expired is called by go-cache when it removes an entry, the entries deleted or evicted by the store are already
+// forgotten, so only the expired ones are counted
+func (s *Store) expired(key string, _ any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.forget(key) {
+		s.stats.expirations++
+	}
+}
+
+// WritePrometheus writes the metrics of the store in the Prometheus text format
+func (s *Store) WritePrometheus(w io.Writer) error {
+	s.mu.Lock()
+	stats, entries, bytes := s.stats, s.order.Len(), s.bytes
+	s.mu.Unlock()
+
+	var err error
+	printf := func(format string, args ...any) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+	printf("# HELP verifier_session_store_requests_total Reads of the in-memory session store by result.\n")
+	printf("# TYPE verifier_session_store_requests_total counter\n")
+	printf("verifier_session_store_requests_total{result=\"hit\"} %d\n", stats.hits)
+	printf("verifier_session_store_requests_total{result=\"miss\"} %d\n", stats.misses)
+	printf("# HELP verifier_session_store_evictions_total Entries removed from the in-memory session store by reason.\n")
+	printf("# TYPE verifier_session_store_evictions_total counter\n")
+	printf("verifier_session_store_evictions_total{reason=\"expired\"} %d\n", stats.expirations)
+	printf("verifier_session_store_evictions_total{reason=\"lru\"} %d\n", stats.evictions)
+	printf("# HELP verifier_session_store_entries Entries of the in-memory session store, the expired ones not yet removed included.\n")
+	printf("# TYPE verifier_session_store_entries gauge\n")
+	printf("verifier_session_store_entries %d\n", entries)
+	printf("# HELP verifier_session_store_max_entries Maximum number of entries of the in-memory session store, 0 when unbounded.\n")
+	printf("# TYPE verifier_session_store_max_entries gauge\n")
+	printf("verifier_session_store_max_entries %d\n", max(s.maxEntries, 0))
+	printf("# HELP verifier_session_store_bytes Estimated size of the keys and values of the in-memory session store.\n")
+	printf("# TYPE verifier_session_store_bytes gauge\n")
+	printf("verifier_session_store_bytes %d\n", bytes)
+	return err
+}
+
+// sizeOf estimates the memory used by v: the sizes of its values, and of the strings, slices, maps and pointers they
+// reference, the shared ones counted every time
+func sizeOf(v reflect.Value, depth int) int64 {
+	if !v.IsValid() {
+		return 0
+	}
+	size := int64(v.Type().Size())
+	if depth >= maxSizeDepth {
+		return size
+	}
+	switch v.Kind() {
+	case reflect.String:
+		size += int64(v.Len())
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			size += sizeOf(v.Elem(), depth+1)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return size + int64(v.Len())
+		}
+		for i := 0; i < v.Len(); i++ {
+			size += sizeOf(v.Index(i), depth+1)
+		}
+	case reflect.Array:
+		size = 0
+		for i := 0; i < v.Len(); i++ {
+			size += sizeOf(v.Index(i), depth+1)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			size += sizeOf(iter.Key(), depth+1) + sizeOf(iter.Value(), depth+1)
+		}
+	case reflect.Struct:
+		size = 0
+		for i := 0; i < v.NumField(); i++ {
+			size += sizeOf(v.Field(i), depth+1)
+		}
+	}
+	return size
+}
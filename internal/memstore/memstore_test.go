@@ -0,0 +1,79 @@
+package memstore
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	s := New(time.Hour, time.Hour, 2)
+	s.Set("a", "1", cache.DefaultExpiration)
+	s.Set("b", "2", cache.DefaultExpiration)
+	_, ok := s.Get("a")
+	require.True(t, ok)
+
+	// b is the least recently used entry
+	s.Set("c", "3", cache.DefaultExpiration)
+	_, ok = s.Get("b")
+	assert.False(t, ok)
+	for _, key := range []string{"a", "c"} {
+		_, ok := s.Get(key)
+		assert.True(t, ok, key)
+	}
+	assert.Len(t, s.Items(), 2)
+
+	require.Error(t, s.Add("a", "4", cache.DefaultExpiration))
+	s.Delete("a")
+	require.NoError(t, s.Add("a", "4", cache.DefaultExpiration))
+	value, ok := s.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, "4", value)
+
+	s.Set("d", "5", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	_, ok = s.Get("d")
+	assert.False(t, ok)
+	s.cache.DeleteExpired()
+
+	var metrics bytes.Buffer
+	require.NoError(t, s.WritePrometheus(&metrics))
+	assert.Contains(t, metrics.String(), "verifier_session_store_requests_total{result=\"hit\"} 4\n")
+	assert.Contains(t, metrics.String(), "verifier_session_store_requests_total{result=\"miss\"} 2\n")
+	assert.Contains(t, metrics.String(), "verifier_session_store_evictions_total{reason=\"expired\"} 1\n")
+	assert.Contains(t, metrics.String(), "verifier_session_store_evictions_total{reason=\"lru\"} 2\n")
+	assert.Contains(t, metrics.String(), "verifier_session_store_entries 1\n")
+	assert.Contains(t, metrics.String(), "verifier_session_store_max_entries 2\n")
+}
+
+func TestStoreUnbounded(t *testing.T) {
+	s := New(time.Hour, time.Hour, 0)
+	for _, key := range []string{"a", "b", "c"} {
+		s.Set(key, key, cache.DefaultExpiration)
+	}
+	assert.Len(t, s.Items(), 3)
+	s.Delete("b")
+	s.Set("a", "a", cache.DefaultExpiration)
+	assert.Equal(t, 2, s.order.Len())
+	assert.Equal(t, int64(2*(1+16+1)), s.bytes)
+}
+
+func TestSizeOf(t *testing.T) {
+	type session struct {
+		ID     string
+		Scopes []string
+		Meta   map[string]string
+		Next   *session
+	}
+	value := session{ID: "abcd", Scopes: []string{"ab"}, Meta: map[string]string{"k": "v"}}
+	value.Next = &session{ID: "ef"}
+	size := sizeOf(reflect.ValueOf(value), 0)
+	next := int64(16+2) + 24 + 8 + 8
+	assert.Equal(t, int64(16+4)+int64(24+16+2)+int64(8+16+1+16+1)+int64(8)+next, size)
+	assert.Equal(t, int64(24+3), sizeOf(reflect.ValueOf([]byte("abc")), 0))
+}
@@ -0,0 +1,87 @@
+// Package messages builds the iden3comm request messages of the sessions, and the QR codes that carry them.
+package messages
+
+import (
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	auth "github.com/iden3/go-iden3-auth/v2"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/iden3comm/v2/protocol"
+)
+
+// Request holds the fields shared by the request messages
+type Request struct {
+	ID     string
+	Reason string
+	From   string
+	To     string
+	// CallbackURL is where the wallet posts the response, only used by authorization requests
+	CallbackURL string
+	// Message is the message of the body of authorization requests, shown by the wallets
+	Message string
+	Scope   []protocol.ZeroKnowledgeProofRequest
+}
+
+// ProofRequest builds the zero knowledge proof request of a scope
+func ProofRequest(id uint32, circuitID string, query map[string]interface{}, params *map[string]interface{}) (protocol.ZeroKnowledgeProofRequest, error) {
+	request := protocol.ZeroKnowledgeProofRequest{
+		ID:        id,
+		CircuitID: circuitID,
+		Query:     query,
+	}
+	if params != nil {
+		p, err := Params(circuitID, *params)
+		if err != nil {
+			return protocol.ZeroKnowledgeProofRequest{}, err
+		}
+		request.Params = p
+	}
+	return request, nil
+}
+
+// AuthRequest builds an authorization request, used for off-chain verifications
+func AuthRequest(r Request) protocol.AuthorizationRequestMessage {
+	msg := auth.CreateAuthorizationRequest(r.Reason, r.From, r.CallbackURL)
+	msg.ID = r.ID
+	msg.ThreadID = r.ID
+	msg.To = r.To
+	msg.Body.Message = r.Message
+	msg.Body.Scope = append(msg.Body.Scope, r.Scope...)
+	return msg
+}
+
+// ContractInvokeRequest builds a contract invoke request, used for on-chain verifications.
+// The request is sent from the DID of the verifier contract.
+func ContractInvokeRequest(r Request, transactionData protocol.TransactionData) (protocol.ContractInvokeRequestMessage, error) {
+	verifierDID, err := OnChainVerifierDID(transactionData)
+	if err != nil {
+		return protocol.ContractInvokeRequestMessage{}, err
+	}
+
+	msg := auth.CreateContractInvokeRequest(r.Reason, r.From, transactionData, r.Scope...)
+	msg.ID = r.ID
+	msg.ThreadID = r.ID
+	msg.From = verifierDID.String()
+	msg.To = r.To
+	return msg, nil
+}
+
+// OnChainVerifierDID returns the DID of the verifier contract of the transaction data
+func OnChainVerifierDID(transactionData protocol.TransactionData) (*w3c.DID, error) {
+	address := ethcommon.HexToAddress(transactionData.ContractAddress)
+	var ethAddr [20]byte
+	copy(ethAddr[:], address.Bytes())
+
+	currentState := core.GenesisFromEthAddress(ethAddr)
+
+	blockchain, network, err := core.NetworkByChainID(core.ChainID(transactionData.ChainID))
+	if err != nil {
+		return nil, err
+	}
+	didType, err := core.BuildDIDType(core.DIDMethodIden3, blockchain, network)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.NewDID(didType, currentState)
+}
@@ -0,0 +1,212 @@
+package messages
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+const (
+	senderDID   = "did:iden3:polygon:amoy:x6x5sor7zpxixYDNyDZKnh3oJZRj7Vzn9jzoCNoxc"
+	userDID     = "did:polygonid:polygon:amoy:2qEATqfECVbCBzq9EhJpPSiv1xtJRpbMBKDaNM68Ci"
+	callbackURL = "http://localhost/callback?sessionID=6dc645a6-2be3-4099-a645-20784ee53cd0"
+	requestID   = "f780a169-8959-4380-9461-f7200e2ed3f4"
+)
+
+var (
+	kycQuery = map[string]interface{}{
+		"context":        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+		"allowedIssuers": []interface{}{"*"},
+		"type":           "KYCAgeCredential",
+		"credentialSubject": map[string]interface{}{
+			"birthday": map[string]interface{}{"$lt": 20000101},
+		},
+	}
+	nullifierParams = map[string]interface{}{"nullifierSessionID": "123443290439234342342423423423423"}
+	transactionData = protocol.TransactionData{
+		ContractAddress: "0x2b23e5cF70D133fFaA7D8ba61E1bAC4637253880",
+		MethodID:        "b68967e2",
+		ChainID:         80002,
+		Network:         "polygon-amoy",
+	}
+)
+
+// TestGolden builds the request message and the QR code of every circuit, with and without params and receiver,
+// and compares them with the golden files of testdata. Run with -update to regenerate them.
+func TestGolden(t *testing.T) {
+	type variant struct {
+		name   string
+		params *map[string]interface{}
+		to     string
+	}
+	variants := []variant{
+		{name: "plain"},
+		{name: "params", params: &nullifierParams},
+		{name: "to", to: userDID},
+		{name: "params-to", params: &nullifierParams, to: userDID},
+	}
+
+	offChain := []circuits.CircuitID{circuits.AtomicQuerySigV2CircuitID, circuits.AtomicQueryMTPV2CircuitID, circuits.AtomicQueryV3CircuitID}
+	for _, circuitID := range offChain {
+		for _, v := range variants {
+			t.Run(fmt.Sprintf("auth-%s-%s", circuitID, v.name), func(t *testing.T) {
+				scope, err := ProofRequest(1, string(circuitID), kycQuery, v.params)
+				require.NoError(t, err)
+				msg := AuthRequest(Request{ID: requestID, Reason: "test flow", From: senderDID, To: v.to, CallbackURL: callbackURL,
+					Scope: []protocol.ZeroKnowledgeProofRequest{scope}})
+				assertGolden(t, fmt.Sprintf("auth-%s-%s", circuitID, v.name), msg, AuthRequestQRCode(msg))
+			})
+		}
+	}
+
+	onChain := []circuits.CircuitID{circuits.AtomicQuerySigV2OnChainCircuitID, circuits.AtomicQueryMTPV2OnChainCircuitID, circuits.AtomicQueryV3OnChainCircuitID}
+	for _, circuitID := range onChain {
+		for _, v := range variants {
+			t.Run(fmt.Sprintf("invoke-%s-%s", circuitID, v.name), func(t *testing.T) {
+				scope, err := ProofRequest(1, string(circuitID), kycQuery, v.params)
+				require.NoError(t, err)
+				msg, err := ContractInvokeRequest(Request{ID: requestID, Reason: "test flow", From: senderDID, To: v.to,
+					Scope: []protocol.ZeroKnowledgeProofRequest{scope}}, transactionData)
+				require.NoError(t, err)
+				assertGolden(t, fmt.Sprintf("invoke-%s-%s", circuitID, v.name), msg, ContractInvokeQRCode(msg))
+			})
+		}
+	}
+}
+
+func TestWithValidity(t *testing.T) {
+	created := time.Date(2025, 6, 16, 10, 0, 0, 0, time.UTC)
+	qrCode := QRCode{Id: requestID}.WithValidity(created, created.Add(30*time.Minute))
+	b, err := json.Marshal(qrCode)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"created_time":1750068000,"expires_time":1750069800`)
+
+	b, err = json.Marshal(QRCode{Id: requestID})
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "expires_time")
+}
+
+func TestParams(t *testing.T) {
+	type testConfig struct {
+		name      string
+		circuitID circuits.CircuitID
+		params    map[string]interface{}
+		expected  map[string]interface{}
+		err       string
+	}
+	for _, tc := range []testConfig{
+		{
+			name:      "nullifier session",
+			circuitID: circuits.AtomicQueryV3CircuitID,
+			params:    map[string]interface{}{"nullifierSessionID": "00123"},
+			expected:  map[string]interface{}{"nullifierSessionId": "123"},
+		},
+		{
+			name:      "no params",
+			circuitID: circuits.AtomicQueryV3CircuitID,
+			params:    map[string]interface{}{},
+		},
+		{
+			name:      "linked query params",
+			circuitID: circuits.AtomicQueryV3OnChainCircuitID,
+			params: map[string]interface{}{
+				"verifierID": "did:iden3:polygon:amoy:x6x5sor7zpxixYDNyDZKnh3oJZRj7Vzn9jzoCNoxc",
+				"groupID":    float64(2),
+				"linkNonce":  "987654321",
+			},
+			expected: map[string]interface{}{
+				"verifierId": "did:iden3:polygon:amoy:x6x5sor7zpxixYDNyDZKnh3oJZRj7Vzn9jzoCNoxc",
+				"groupId":    2,
+				"linkNonce":  "987654321",
+			},
+		},
+		{
+			name:      "empty nullifier session",
+			circuitID: circuits.AtomicQueryV3CircuitID,
+			params:    map[string]interface{}{"nullifierSessionID": ""},
+			err:       "nullifierSessionID is empty",
+		},
+		{
+			name:      "invalid nullifier session",
+			circuitID: circuits.AtomicQueryV3CircuitID,
+			params:    map[string]interface{}{"nullifierSessionID": "0x12"},
+			err:       "nullifierSessionID is not a valid big integer",
+		},
+		{
+			name:      "nullifier session is not a string",
+			circuitID: circuits.AtomicQueryV3CircuitID,
+			params:    map[string]interface{}{"nullifierSessionID": 123},
+			err:       "nullifierSessionID is not a valid big integer",
+		},
+		{
+			name:      "invalid group",
+			circuitID: circuits.LinkedMultiQuery10CircuitID,
+			params:    map[string]interface{}{"groupID": 1.5},
+			err:       "groupID is not a positive integer",
+		},
+		{
+			name:      "invalid verifier",
+			circuitID: circuits.AtomicQueryV3CircuitID,
+			params:    map[string]interface{}{"verifierID": "verifier"},
+			err:       "verifierID is not a valid DID",
+		},
+		{
+			name:      "legacy nullifier session",
+			circuitID: circuits.AtomicQuerySigV2OnChainCircuitID,
+			params:    map[string]interface{}{"nullifierSessionID": "123"},
+			expected:  map[string]interface{}{"nullifierSessionId": "123"},
+		},
+		{
+			name:      "param of another circuit",
+			circuitID: circuits.AtomicQuerySigV2CircuitID,
+			params:    map[string]interface{}{"groupID": float64(1)},
+			err:       "param groupID is not supported by circuit credentialAtomicQuerySigV2",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			params, err := Params(string(tc.circuitID), tc.params)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, params)
+		})
+	}
+}
+
+func TestOnChainVerifierDID(t *testing.T) {
+	did, err := OnChainVerifierDID(transactionData)
+	require.NoError(t, err)
+	assert.Equal(t, "did:iden3:polygon:amoy:x6x5sor7zpxfm8cty3nUDV2iJ8Vmox4XKpTQnrGmW", did.String())
+
+	_, err = OnChainVerifierDID(protocol.TransactionData{ContractAddress: transactionData.ContractAddress, ChainID: 1234})
+	assert.Error(t, err)
+}
+
+// assertGolden compares the message and its QR code with the golden file of the test case
+func assertGolden(t *testing.T, name string, msg any, qrCode QRCode) {
+	t.Helper()
+	actual, err := json.MarshalIndent(map[string]any{"message": msg, "qrCode": qrCode}, "", "  ")
+	require.NoError(t, err)
+	actual = append(actual, '\n')
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		require.NoError(t, os.WriteFile(path, actual, 0o600))
+	}
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
@@ -0,0 +1,136 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+)
+
+const defaultBigIntBase = 10
+
+// scopeParam is a param of the scopes, named as in the sign-in requests, and sent to the wallets as name
+type scopeParam struct {
+	name     string
+	validate func(param string, value interface{}) (interface{}, error)
+}
+
+var (
+	nullifierSessionIDParam = scopeParam{name: "nullifierSessionId", validate: bigIntParam}
+	verifierIDParam         = scopeParam{name: "verifierId", validate: didParam}
+	groupIDParam            = scopeParam{name: "groupId", validate: positiveIntParam}
+	linkNonceParam          = scopeParam{name: "linkNonce", validate: bigIntParam}
+
+	// legacyParams are the params the V2 circuits accepted before the params were checked per circuit, kept so the
+	// sign-in requests that send them are not rejected
+	legacyParams = map[string]scopeParam{
+		"nullifierSessionID": nullifierSessionIDParam,
+	}
+
+	// circuitParams are the params the scopes of each circuit accept, by their name in the sign-in requests.
+	// A param of a new circuit only needs an entry here.
+	circuitParams = map[circuits.CircuitID]map[string]scopeParam{
+		circuits.AtomicQuerySigV2CircuitID:        legacyParams,
+		circuits.AtomicQueryMTPV2CircuitID:        legacyParams,
+		circuits.AtomicQuerySigV2OnChainCircuitID: legacyParams,
+		circuits.AtomicQueryMTPV2OnChainCircuitID: legacyParams,
+		circuits.AtomicQueryV3CircuitID: {
+			"nullifierSessionID": nullifierSessionIDParam,
+			"verifierID":         verifierIDParam,
+			"groupID":            groupIDParam,
+			"linkNonce":          linkNonceParam,
+		},
+		circuits.AtomicQueryV3OnChainCircuitID: {
+			"nullifierSessionID": nullifierSessionIDParam,
+			"verifierID":         verifierIDParam,
+			"groupID":            groupIDParam,
+			"linkNonce":          linkNonceParam,
+		},
+		circuits.LinkedMultiQuery10CircuitID: {
+			"groupID":   groupIDParam,
+			"linkNonce": linkNonceParam,
+		},
+	}
+)
+
+// Params validates the params of a scope of the circuit and converts them to the params of the proof request, e.g.
+// the nullifierSessionID is sent to the wallets as nullifierSessionId. The params the circuit does not accept are rejected.
+func Params(circuitID string, params map[string]interface{}) (map[string]interface{}, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	accepted := circuitParams[circuits.CircuitID(circuitID)]
+	converted := make(map[string]interface{}, len(params))
+	for _, name := range names {
+		param, ok := accepted[name]
+		if !ok {
+			return nil, fmt.Errorf("param %s is not supported by circuit %s", name, circuitID)
+		}
+		value, err := param.validate(name, params[name])
+		if err != nil {
+			return nil, err
+		}
+		converted[param.name] = value
+	}
+	return converted, nil
+}
+
+// bigIntParam accepts a decimal big integer, sent as its decimal string
+func bigIntParam(param string, value interface{}) (interface{}, error) {
+	if value == nil || value == "" {
+		return nil, fmt.Errorf("%s is empty", param)
+	}
+	str, _ := value.(string)
+	n := new(big.Int)
+	if _, ok := n.SetString(str, defaultBigIntBase); !ok {
+		return nil, fmt.Errorf("%s is not a valid big integer", param)
+	}
+	return n.String(), nil
+}
+
+// didParam accepts a DID
+func didParam(param string, value interface{}) (interface{}, error) {
+	str, _ := value.(string)
+	if str == "" {
+		return nil, fmt.Errorf("%s is empty", param)
+	}
+	did, err := w3c.ParseDID(str)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid DID", param)
+	}
+	return did.String(), nil
+}
+
+// positiveIntParam accepts a positive integer, as a number of the json requests or of the yaml files
+func positiveIntParam(param string, value interface{}) (interface{}, error) {
+	var n float64
+	switch v := value.(type) {
+	case float64:
+		n = v
+	case int:
+		n = float64(v)
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a positive integer", param)
+		}
+		n = f
+	default:
+		return nil, fmt.Errorf("%s is not a positive integer", param)
+	}
+	if n < 1 || n != math.Trunc(n) || n > math.MaxInt32 {
+		return nil, fmt.Errorf("%s is not a positive integer", param)
+	}
+	return int(n), nil
+}
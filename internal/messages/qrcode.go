@@ -0,0 +1,116 @@
+package messages
+
+import (
+	"time"
+
+	"github.com/iden3/iden3comm/v2/protocol"
+)
+
+// QRCode is the request message scanned by the wallets. It is the QRCode schema of the API.
+type QRCode struct {
+	Body Body    `json:"body"`
+	From string  `json:"from"`
+	Id   string  `json:"id"`
+	Thid string  `json:"thid"`
+	To   *string `json:"to,omitempty"`
+	Typ  string  `json:"typ"`
+	Type string  `json:"type"`
+	// CreatedTime and ExpiresTime are the iden3comm created_time and expires_time headers, in unix seconds
+	CreatedTime *int64 `json:"created_time,omitempty"`
+	ExpiresTime *int64 `json:"expires_time,omitempty"`
+}
+
+// WithValidity returns the QRCode with the created_time and expires_time headers of a request valid from created to expires
+func (q QRCode) WithValidity(created, expires time.Time) QRCode {
+	createdTime, expiresTime := created.Unix(), expires.Unix()
+	q.CreatedTime = &createdTime
+	q.ExpiresTime = &expiresTime
+	return q
+}
+
+// Body is the body of a QRCode
+type Body struct {
+	CallbackUrl *string `json:"callbackUrl,omitempty"`
+	Reason      string  `json:"reason"`
+	Scope       []Scope `json:"scope"`
+	// TransactionData is only set for on-chain verifications
+	TransactionData *TransactionData `json:"transaction_data,omitempty"`
+}
+
+// Scope is a proof request of a QRCode
+type Scope struct {
+	CircuitId string                  `json:"circuitId"`
+	Id        uint32                  `json:"id"`
+	Params    *map[string]interface{} `json:"params,omitempty"`
+	Query     map[string]interface{}  `json:"query"`
+}
+
+// TransactionData is the transaction of the verifier contract of an on-chain verification
+type TransactionData struct {
+	ChainId         int    `json:"chain_id"`
+	ContractAddress string `json:"contract_address"`
+	MethodId        string `json:"method_id"`
+	Network         string `json:"network"`
+}
+
+// AuthRequestQRCode returns the QRCode of an authorization request
+func AuthRequestQRCode(request protocol.AuthorizationRequestMessage) QRCode {
+	qrCode := QRCode{
+		From: request.From,
+		Id:   request.ID,
+		Thid: request.ThreadID,
+		Typ:  string(request.Typ),
+		Type: string(request.Type),
+		Body: Body{
+			CallbackUrl: &request.Body.CallbackURL,
+			Reason:      request.Body.Reason,
+			Scope:       qrScopes(request.Body.Scope),
+		},
+	}
+	if request.To != "" {
+		qrCode.To = &request.To
+	}
+	return qrCode
+}
+
+// ContractInvokeQRCode returns the QRCode of a contract invoke request
+func ContractInvokeQRCode(request protocol.ContractInvokeRequestMessage) QRCode {
+	qrCode := QRCode{
+		From: request.From,
+		Id:   request.ID,
+		Thid: request.ThreadID,
+		Typ:  string(request.Typ),
+		Type: string(request.Type),
+		Body: Body{
+			Reason: request.Body.Reason,
+			Scope:  qrScopes(request.Body.Scope),
+			TransactionData: &TransactionData{
+				ChainId:         request.Body.TransactionData.ChainID,
+				ContractAddress: request.Body.TransactionData.ContractAddress,
+				MethodId:        request.Body.TransactionData.MethodID,
+				Network:         request.Body.TransactionData.Network,
+			},
+		},
+	}
+	if request.To != "" {
+		qrCode.To = &request.To
+	}
+	return qrCode
+}
+
+func qrScopes(requests []protocol.ZeroKnowledgeProofRequest) []Scope {
+	scopes := make([]Scope, 0, len(requests))
+	for _, request := range requests {
+		scope := Scope{
+			CircuitId: request.CircuitID,
+			Id:        request.ID,
+			Query:     request.Query,
+		}
+		if request.Params != nil {
+			params := request.Params
+			scope.Params = &params
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes
+}
@@ -1,10 +1,40 @@
 package models
 
+import "github.com/0xPolygonID/verifier-backend/internal/timing"
+
 // VerificationResponse is the struct for verification response
 type VerificationResponse struct {
 	Jwz     string
 	UserDID string
 	Scopes  []VerificationResponseScope
+	Token   string
+	Timings timing.Breakdown
+	// EthAddress is the Ethereum address the identity of UserDID was created from, if any, derived from its genesis state
+	EthAddress string
+	// Provisional is set while a state the verification relies on does not have the required block confirmations
+	Provisional bool
+	// ScopeStatuses reconcile every requested scope with the response
+	ScopeStatuses []ScopeStatus
+	// Proofs describe how the credential of every verified scope was proven
+	Proofs []ScopeProof
+}
+
+// ScopeProof is how the credential of a verified scope was proven: with the signature of the issuer, which does not
+// need the credential to be published in the state of the issuer, or with a merkle tree proof of a published state
+type ScopeProof struct {
+	ID                 uint32
+	ProofType          string
+	IssuerDID          string
+	IssuerState        string
+	IssuerStateGenesis bool
+	Merklized          bool
+}
+
+// ScopeStatus is the result of the reconciliation of a requested scope with the response
+type ScopeStatus struct {
+	ID     uint32
+	Status string
+	Reason string
 }
 
 // VerificationResponseScope is the struct for verification response scope
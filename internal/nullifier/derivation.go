@@ -0,0 +1,99 @@
+package nullifier
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Names of the built-in derivations
+const (
+	// DerivationCampaign derives one nullifier session per campaign of a tenant, shared by all its credential types
+	DerivationCampaign = "campaign"
+	// DerivationCredentialType derives one nullifier session per credential type of a campaign of a tenant, so a user
+	// can prove each credential type once
+	DerivationCredentialType = "credential-type"
+)
+
+// SessionInput holds the inputs of the derivation of a nullifier session
+type SessionInput struct {
+	// Namespace isolates the nullifier sessions of the deployments that share their users, e.g. staging and production
+	Namespace      string
+	Tenant         string
+	Campaign       string
+	CredentialType string
+}
+
+// Derivation derives the nullifier sessions of the scopes of the sessions, so the callers do not manage them
+type Derivation interface {
+	// Derive returns the nullifier session of the input, it must fit in the field of the circuits
+	Derive(input SessionInput) *big.Int
+}
+
+// DerivationFunc is a function used as a Derivation
+type DerivationFunc func(input SessionInput) *big.Int
+
+// Derive calls f
+func (f DerivationFunc) Derive(input SessionInput) *big.Int {
+	return f(input)
+}
+
+var (
+	derivationsMu sync.RWMutex
+	derivations   = map[string]Derivation{
+		DerivationCampaign: DerivationFunc(func(input SessionInput) *big.Int {
+			return SessionID(namespaced(input.Namespace, "campaign"), input.Tenant, input.Campaign)
+		}),
+		DerivationCredentialType: DerivationFunc(func(input SessionInput) *big.Int {
+			return SessionID(namespaced(input.Namespace, "credential-type"), input.Tenant, input.Campaign, input.CredentialType)
+		}),
+	}
+)
+
+// RegisterDerivation makes a derivation available by name to the deployments built with it, usually from the init
+// function of its file. It panics if a derivation is registered twice with the same name.
+func RegisterDerivation(name string, derivation Derivation) {
+	derivationsMu.Lock()
+	defer derivationsMu.Unlock()
+	if derivation == nil {
+		panic("nullifier: RegisterDerivation derivation is nil")
+	}
+	if _, ok := derivations[name]; ok {
+		panic("nullifier: RegisterDerivation called twice for derivation " + name)
+	}
+	derivations[name] = derivation
+}
+
+// LookupDerivation returns the derivation registered with name
+func LookupDerivation(name string) (Derivation, error) {
+	derivationsMu.RLock()
+	defer derivationsMu.RUnlock()
+	derivation, ok := derivations[name]
+	if !ok {
+		names := make([]string, 0, len(derivations))
+		for name := range derivations {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown nullifier session derivation %s, the registered derivations are %v", name, names)
+	}
+	return derivation, nil
+}
+
+// SessionID hashes the fields into a nullifier session, 248 bits long to fit in the field of the circuits. The fields
+// are separated by a NUL byte, the first one names the derivation so different derivations do not share sessions.
+func SessionID(fields ...string) *big.Int {
+	h := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return new(big.Int).SetBytes(h[:31])
+}
+
+// namespaced prefixes the name of a derivation with the namespace, the sessions without namespace keep the name alone
+func namespaced(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "\x00" + name
+}
@@ -0,0 +1,119 @@
+package nullifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/iden3/go-merkletree-sql/v2"
+)
+
+// maxRecordSize is the size of the longest record of a FileStorage, a checkpoint with room to spare
+const maxRecordSize = 1 << 16
+
+// fileRecord is a line of a FileStorage: a node of the tree, a new root or a checkpoint
+type fileRecord struct {
+	Key        []byte           `json:"k,omitempty"`
+	Node       []byte           `json:"n,omitempty"`
+	Root       *merkletree.Hash `json:"root,omitempty"`
+	Checkpoint *Checkpoint      `json:"checkpoint,omitempty"`
+}
+
+// FileStorage is a Storage that persists the nullifiers tree and its checkpoints in an append-only file, one json record
+// per line. The file is replayed in memory when the storage is opened, so the registry survives restarts.
+type FileStorage struct {
+	*MemoryStorage
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenFileStorage opens the FileStorage at path, creating the file if it does not exist
+func OpenFileStorage(path string) (*FileStorage, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	mem := NewMemoryStorage()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxRecordSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record fileRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("invalid record at line %d of %s: %w", line, path, err)
+		}
+		if err := apply(ctx, mem, record); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("invalid record at line %d of %s: %w", line, path, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &FileStorage{MemoryStorage: mem, file: f}, nil
+}
+
+// Put stores a node of the tree
+func (s *FileStorage) Put(ctx context.Context, k []byte, v *merkletree.Node) error {
+	return s.write(ctx, fileRecord{Key: k, Node: v.Value()})
+}
+
+// SetRoot stores the root of the tree
+func (s *FileStorage) SetRoot(ctx context.Context, root *merkletree.Hash) error {
+	return s.write(ctx, fileRecord{Root: root})
+}
+
+// SaveCheckpoint appends a checkpoint
+func (s *FileStorage) SaveCheckpoint(ctx context.Context, checkpoint Checkpoint) error {
+	return s.write(ctx, fileRecord{Checkpoint: &checkpoint})
+}
+
+// Close closes the file of the storage
+func (s *FileStorage) Close() error {
+	return s.file.Close()
+}
+
+// write appends the record to the file before applying it in memory
+func (s *FileStorage) write(ctx context.Context, record fileRecord) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("failed to persist nullifiers tree: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to persist nullifiers tree: %w", err)
+	}
+	return apply(ctx, s.MemoryStorage, record)
+}
+
+func apply(ctx context.Context, mem *MemoryStorage, record fileRecord) error {
+	switch {
+	case record.Checkpoint != nil:
+		return mem.SaveCheckpoint(ctx, *record.Checkpoint)
+	case record.Root != nil:
+		return mem.SetRoot(ctx, record.Root)
+	case record.Key != nil:
+		node, err := merkletree.NewNodeFromBytes(record.Node)
+		if err != nil {
+			return err
+		}
+		return mem.Put(ctx, record.Key, node)
+	default:
+		return fmt.Errorf("empty record")
+	}
+}
@@ -0,0 +1,36 @@
+package nullifier
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iden3/go-merkletree-sql/v2/db/memory"
+)
+
+// MemoryStorage is a Storage that keeps the tree and the checkpoints in memory
+type MemoryStorage struct {
+	*memory.Storage
+
+	mu          sync.RWMutex
+	checkpoints []Checkpoint
+}
+
+// NewMemoryStorage creates a new MemoryStorage
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{Storage: memory.NewMemoryStorage()}
+}
+
+// SaveCheckpoint appends a checkpoint
+func (m *MemoryStorage) SaveCheckpoint(_ context.Context, checkpoint Checkpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checkpoints = append(m.checkpoints, checkpoint)
+	return nil
+}
+
+// GetCheckpoints returns a copy of the checkpoints ordered by ID
+func (m *MemoryStorage) GetCheckpoints(_ context.Context) ([]Checkpoint, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]Checkpoint{}, m.checkpoints...), nil
+}
@@ -0,0 +1,159 @@
+package nullifier
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/iden3/go-merkletree-sql/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+const mtLevels = 64
+
+// ErrCheckpointNotFound is returned when a checkpoint does not exist
+var ErrCheckpointNotFound = errors.New("checkpoint not found")
+
+// Checkpoint is a root of the nullifiers tree published at a given time.
+// Proofs are generated against checkpoints, so auditors can check them against the roots they have seen.
+type Checkpoint struct {
+	ID        int
+	Root      *merkletree.Hash
+	Size      int
+	CreatedAt time.Time
+}
+
+// Storage persists the nullifiers tree and its checkpoints
+type Storage interface {
+	merkletree.Storage
+	SaveCheckpoint(ctx context.Context, checkpoint Checkpoint) error
+	GetCheckpoints(ctx context.Context) ([]Checkpoint, error)
+}
+
+// Registry stores the nullifiers seen in the callbacks in a sparse merkle tree
+type Registry struct {
+	mu      sync.Mutex
+	storage Storage
+	tree    *merkletree.MerkleTree
+	size    int
+}
+
+// NewRegistry creates a new Registry, loading the tree from the storage if it exists
+func NewRegistry(ctx context.Context, storage Storage) (*Registry, error) {
+	tree, err := merkletree.NewMerkleTree(ctx, storage, mtLevels)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Registry{storage: storage, tree: tree}
+	checkpoints, err := storage.GetCheckpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(checkpoints) > 0 {
+		r.size = checkpoints[len(checkpoints)-1].Size
+	}
+	return r, nil
+}
+
+// Add adds a nullifier to the registry. It returns false if the nullifier was already in the registry.
+func (r *Registry) Add(ctx context.Context, nullifier *big.Int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.tree.Add(ctx, nullifier, big.NewInt(time.Now().Unix()))
+	if errors.Is(err, merkletree.ErrEntryIndexAlreadyExists) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	r.size++
+	return true, nil
+}
+
+// Checkpoint saves the current root of the tree as a new checkpoint, unless it did not change since the last checkpoint
+func (r *Registry) Checkpoint(ctx context.Context) (*Checkpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	checkpoints, err := r.storage.GetCheckpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	root := r.tree.Root()
+	if len(checkpoints) > 0 && checkpoints[len(checkpoints)-1].Root.Equals(root) {
+		return &checkpoints[len(checkpoints)-1], nil
+	}
+
+	checkpoint := Checkpoint{
+		ID:        len(checkpoints) + 1,
+		Root:      root,
+		Size:      r.size,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := r.storage.SaveCheckpoint(ctx, checkpoint); err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// Checkpoints returns all the checkpoints
+func (r *Registry) Checkpoints(ctx context.Context) ([]Checkpoint, error) {
+	return r.storage.GetCheckpoints(ctx)
+}
+
+// Prove generates a proof of inclusion or non-inclusion of the nullifier at a checkpoint.
+// The latest checkpoint is used when checkpointID is nil. The value of an included nullifier, the unix time it was
+// added at, is returned too, as it is needed to verify the proof.
+func (r *Registry) Prove(ctx context.Context, nullifier *big.Int, checkpointID *int) (*merkletree.Proof, *big.Int, *Checkpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	checkpoints, err := r.storage.GetCheckpoints(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(checkpoints) == 0 {
+		return nil, nil, nil, ErrCheckpointNotFound
+	}
+
+	checkpoint := checkpoints[len(checkpoints)-1]
+	if checkpointID != nil {
+		if *checkpointID < 1 || *checkpointID > len(checkpoints) {
+			return nil, nil, nil, ErrCheckpointNotFound
+		}
+		checkpoint = checkpoints[*checkpointID-1]
+	}
+
+	proof, value, err := r.tree.GenerateProof(ctx, nullifier, checkpoint.Root)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !proof.Existence {
+		value = nil
+	}
+	return proof, value, &checkpoint, nil
+}
+
+// Run creates a checkpoint every interval until the context is done
+func (r *Registry) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkpoint, err := r.Checkpoint(ctx)
+			if err != nil {
+				log.WithFields(log.Fields{"err": err}).Error("failed to create nullifiers checkpoint")
+				continue
+			}
+			log.WithFields(log.Fields{"id": checkpoint.ID, "root": checkpoint.Root.Hex(), "size": checkpoint.Size}).Debug("nullifiers checkpoint")
+		}
+	}
+}
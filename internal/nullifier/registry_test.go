@@ -0,0 +1,174 @@
+package nullifier
+
+import (
+	"context"
+	"crypto/sha256"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+	registry, err := NewRegistry(ctx, NewMemoryStorage())
+	require.NoError(t, err)
+
+	_, _, _, err = registry.Prove(ctx, big.NewInt(1), nil)
+	require.ErrorIs(t, err, ErrCheckpointNotFound)
+
+	added, err := registry.Add(ctx, big.NewInt(1))
+	require.NoError(t, err)
+	assert.True(t, added)
+	first, err := registry.Checkpoint(ctx)
+	require.NoError(t, err)
+
+	added, err = registry.Add(ctx, big.NewInt(2))
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = registry.Add(ctx, big.NewInt(1))
+	require.NoError(t, err)
+	assert.False(t, added)
+	second, err := registry.Checkpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.ID)
+	assert.Equal(t, 2, second.Size)
+
+	same, err := registry.Checkpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, same.ID)
+
+	proof, value, checkpoint, err := registry.Prove(ctx, big.NewInt(2), &first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, checkpoint.ID)
+	assert.False(t, proof.Existence)
+	assert.Nil(t, value)
+	assert.True(t, merkletree.VerifyProof(first.Root, proof, big.NewInt(2), big.NewInt(0)))
+
+	proof, value, checkpoint, err = registry.Prove(ctx, big.NewInt(2), nil)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, checkpoint.ID)
+	assert.True(t, proof.Existence)
+	require.NotNil(t, value)
+	assert.True(t, merkletree.VerifyProof(second.Root, proof, big.NewInt(2), value))
+
+	_, _, _, err = registry.Prove(ctx, big.NewInt(2), &[]int{3}[0])
+	assert.ErrorIs(t, err, ErrCheckpointNotFound)
+}
+
+func TestFileStorage(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "registry.jsonl")
+	storage, err := OpenFileStorage(path)
+	require.NoError(t, err)
+	registry, err := NewRegistry(ctx, storage)
+	require.NoError(t, err)
+	for _, n := range []int64{1, 2, 3} {
+		added, err := registry.Add(ctx, big.NewInt(n))
+		require.NoError(t, err)
+		assert.True(t, added)
+	}
+	checkpoint, err := registry.Checkpoint(ctx)
+	require.NoError(t, err)
+	require.NoError(t, storage.Close())
+
+	// the tree and the checkpoints survive restarts
+	storage, err = OpenFileStorage(path)
+	require.NoError(t, err)
+	defer storage.Close()
+	registry, err = NewRegistry(ctx, storage)
+	require.NoError(t, err)
+	checkpoints, err := registry.Checkpoints(ctx)
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 1)
+	assert.Equal(t, checkpoint.Root, checkpoints[0].Root)
+	assert.Equal(t, 3, checkpoints[0].Size)
+
+	added, err := registry.Add(ctx, big.NewInt(2))
+	require.NoError(t, err)
+	assert.False(t, added)
+	proof, value, _, err := registry.Prove(ctx, big.NewInt(3), nil)
+	require.NoError(t, err)
+	assert.True(t, proof.Existence)
+	assert.True(t, merkletree.VerifyProof(checkpoint.Root, proof, big.NewInt(3), value))
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "nullifiers.jsonl")
+	store, err := OpenFileStore(path)
+	require.NoError(t, err)
+
+	first := Key{SessionID: "1", Nullifier: "100"}
+	second := Key{SessionID: "2", Nullifier: "100"}
+	used, err := store.Claim(ctx, []Key{first})
+	require.NoError(t, err)
+	assert.Nil(t, used)
+
+	// the same nullifier can be used in another nullifier session
+	used, err = store.Claim(ctx, []Key{second})
+	require.NoError(t, err)
+	assert.Nil(t, used)
+
+	// no key is claimed when one of them was already used
+	third := Key{SessionID: "1", Nullifier: "200"}
+	used, err = store.Claim(ctx, []Key{third, first})
+	require.NoError(t, err)
+	assert.Equal(t, &first, used)
+	require.NoError(t, store.Close())
+
+	// used nullifiers survive restarts
+	store, err = OpenFileStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+	used, err = store.Claim(ctx, []Key{second})
+	require.NoError(t, err)
+	assert.Equal(t, &second, used)
+	used, err = store.Claim(ctx, []Key{third, third})
+	require.NoError(t, err)
+	assert.Equal(t, &third, used)
+	used, err = store.Claim(ctx, []Key{third})
+	require.NoError(t, err)
+	assert.Nil(t, used)
+}
+
+func TestDerivation(t *testing.T) {
+	campaign, err := LookupDerivation(DerivationCampaign)
+	require.NoError(t, err)
+	// the sessions of the campaigns without namespace are the ones derived before the derivations were pluggable
+	legacy := sha256.Sum256([]byte("campaign\x00acme\x00spring-airdrop"))
+	input := SessionInput{Tenant: "acme", Campaign: "spring-airdrop", CredentialType: "KYCAgeCredential"}
+	assert.Equal(t, new(big.Int).SetBytes(legacy[:31]), campaign.Derive(input))
+	assert.LessOrEqual(t, campaign.Derive(input).BitLen(), 248)
+	assert.Equal(t, campaign.Derive(input), campaign.Derive(SessionInput{Tenant: "acme", Campaign: "spring-airdrop", CredentialType: "KYCCountryOfResidenceCredential"}))
+	assert.NotEqual(t, campaign.Derive(input), campaign.Derive(SessionInput{Tenant: "globex", Campaign: "spring-airdrop"}))
+	namespaced := input
+	namespaced.Namespace = "staging"
+	assert.NotEqual(t, campaign.Derive(input), campaign.Derive(namespaced))
+
+	credentialType, err := LookupDerivation(DerivationCredentialType)
+	require.NoError(t, err)
+	other := input
+	other.CredentialType = "KYCCountryOfResidenceCredential"
+	assert.NotEqual(t, credentialType.Derive(input), credentialType.Derive(other))
+	assert.NotEqual(t, campaign.Derive(input), credentialType.Derive(input))
+
+	RegisterDerivation("test-tenant", DerivationFunc(func(input SessionInput) *big.Int { return SessionID("test-tenant", input.Tenant) }))
+	t.Cleanup(func() { unregisterDerivation("test-tenant") })
+	custom, err := LookupDerivation("test-tenant")
+	require.NoError(t, err)
+	assert.Equal(t, SessionID("test-tenant", "acme"), custom.Derive(input))
+	assert.Panics(t, func() { RegisterDerivation(DerivationCampaign, custom) })
+	_, err = LookupDerivation("unknown")
+	assert.EqualError(t, err, "unknown nullifier session derivation unknown, the registered derivations are [campaign credential-type test-tenant]")
+}
+
+// unregisterDerivation removes a derivation registered by a test, so the test can run several times in the same process
+func unregisterDerivation(name string) {
+	derivationsMu.Lock()
+	defer derivationsMu.Unlock()
+	delete(derivations, name)
+}
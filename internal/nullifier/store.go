@@ -0,0 +1,156 @@
+package nullifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Key identifies a nullifier within a nullifier session.
+// The same user generates different nullifiers in different nullifier sessions.
+type Key struct {
+	SessionID string `json:"nullifierSessionID"`
+	Nullifier string `json:"nullifier"`
+}
+
+// Store keeps the nullifiers that were already used, so a user can only prove once per nullifier session
+type Store interface {
+	// Claim marks all the keys as used, unless one of them was already used. It returns that key in that case.
+	Claim(ctx context.Context, keys []Key) (*Key, error)
+	// List returns the nullifiers used in the nullifier session
+	List(ctx context.Context, sessionID string) ([]string, error)
+}
+
+// MemoryStore is a Store that keeps the used nullifiers in memory
+type MemoryStore struct {
+	mu   sync.Mutex
+	used map[Key]struct{}
+}
+
+// NewMemoryStore creates a new MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{used: make(map[Key]struct{})}
+}
+
+// Claim marks all the keys as used, unless one of them was already used
+func (m *MemoryStore) Claim(_ context.Context, keys []Key) (*Key, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if used := m.firstUsed(keys); used != nil {
+		return used, nil
+	}
+	for _, key := range keys {
+		m.used[key] = struct{}{}
+	}
+	return nil, nil
+}
+
+// List returns the nullifiers used in the nullifier session, sorted
+func (m *MemoryStore) List(_ context.Context, sessionID string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nullifiers := make([]string, 0)
+	for key := range m.used {
+		if key.SessionID == sessionID {
+			nullifiers = append(nullifiers, key.Nullifier)
+		}
+	}
+	sort.Strings(nullifiers)
+	return nullifiers, nil
+}
+
+// firstUsed returns the first key that was already used, or that is repeated in keys
+func (m *MemoryStore) firstUsed(keys []Key) *Key {
+	claimed := make(map[Key]struct{}, len(keys))
+	for i := range keys {
+		if _, ok := m.used[keys[i]]; ok {
+			return &keys[i]
+		}
+		if _, ok := claimed[keys[i]]; ok {
+			return &keys[i]
+		}
+		claimed[keys[i]] = struct{}{}
+	}
+	return nil
+}
+
+// FileStore is a Store that persists the used nullifiers in an append-only file, one json key per line.
+// The file is loaded when the store is opened, so the nullifiers survive restarts.
+type FileStore struct {
+	mem  *MemoryStore
+	file *os.File
+}
+
+// OpenFileStore opens the FileStore at path, creating the file if it does not exist
+func OpenFileStore(path string) (*FileStore, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	mem := NewMemoryStore()
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var key Key
+		if err := json.Unmarshal(scanner.Bytes(), &key); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("invalid nullifier at line %d of %s: %w", line, path, err)
+		}
+		mem.used[key] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &FileStore{mem: mem, file: f}, nil
+}
+
+// Claim marks all the keys as used, unless one of them was already used.
+// The keys are written to the file before they are marked as used in memory.
+func (s *FileStore) Claim(_ context.Context, keys []Key) (*Key, error) {
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+
+	if used := s.mem.firstUsed(keys); used != nil {
+		return used, nil
+	}
+
+	var buf []byte
+	for _, key := range keys {
+		b, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(append(buf, b...), '\n')
+	}
+	if _, err := s.file.Write(buf); err != nil {
+		return nil, fmt.Errorf("failed to persist nullifiers: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return nil, fmt.Errorf("failed to persist nullifiers: %w", err)
+	}
+
+	for _, key := range keys {
+		s.mem.used[key] = struct{}{}
+	}
+	return nil, nil
+}
+
+// List returns the nullifiers used in the nullifier session, sorted
+func (s *FileStore) List(ctx context.Context, sessionID string) ([]string, error) {
+	return s.mem.List(ctx, sessionID)
+}
+
+// Close closes the file of the store
+func (s *FileStore) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,83 @@
+// Package objectstore reads and writes objects in a local directory or an s3 compatible bucket,
+// Google Cloud Storage included through its interoperability API.
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// gcsEndpoint is the s3 compatible endpoint of Google Cloud Storage
+const gcsEndpoint = "https://storage.googleapis.com"
+
+// ErrNotFound is returned when an object does not exist
+var ErrNotFound = errors.New("object not found")
+
+// Store is a store of objects
+type Store interface {
+	Get(ctx context.Context, name string) ([]byte, error)
+	Put(ctx context.Context, name string, data []byte) error
+	// Location describes where the objects are stored
+	Location() string
+}
+
+// New creates the store of location: a directory, an s3://bucket/prefix or a gs://bucket/prefix URL.
+// Buckets are accessed with the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables,
+// the HMAC keys of a service account for Google Cloud Storage. An endpoint can be set for s3 compatible stores.
+func New(location, s3Region, s3Endpoint string) (Store, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "":
+		return Dir{Path: location}, nil
+	case "s3", "gs":
+		if u.Host == "" {
+			return nil, fmt.Errorf("%s location %s has no bucket", u.Scheme, location)
+		}
+		if u.Scheme == "gs" {
+			s3Region, s3Endpoint = "auto", gcsEndpoint
+		}
+		return NewS3(u.Host, strings.Trim(u.Path, "/"), s3Region, s3Endpoint), nil
+	default:
+		return nil, fmt.Errorf("unsupported object store location %s, expected a directory, an s3 or a gs URL", location)
+	}
+}
+
+// Dir stores the objects as files of Path
+type Dir struct {
+	Path string
+}
+
+// Get reads the object
+func (d Dir) Get(_ context.Context, name string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(d.Path, filepath.FromSlash(name)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Put writes the object, replacing it atomically
+func (d Dir) Put(_ context.Context, name string, data []byte) error {
+	path := filepath.Join(d.Path, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Location returns the directory of the objects
+func (d Dir) Location() string {
+	return d.Path
+}
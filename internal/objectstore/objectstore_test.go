@@ -0,0 +1,103 @@
+package objectstore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygonID/verifier-backend/internal/awssig"
+)
+
+func TestNew(t *testing.T) {
+	type testConfig struct {
+		name     string
+		location string
+		expected string
+		err      bool
+	}
+	for _, tc := range []testConfig{
+		{name: "directory", location: "/var/cache/verifier", expected: "/var/cache/verifier"},
+		{name: "s3", location: "s3://bucket/documents/", expected: "s3://bucket/documents"},
+		{name: "gcs", location: "gs://bucket", expected: "gs://bucket"},
+		{name: "s3 without bucket", location: "s3:///documents", err: true},
+		{name: "unsupported scheme", location: "https://example.com", err: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			store, err := New(tc.location, "us-east-1", "")
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, store.Location())
+		})
+	}
+}
+
+func TestStores(t *testing.T) {
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			objects[r.URL.Path], _ = io.ReadAll(r.Body)
+		case http.MethodGet:
+			object, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(object)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "access")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	for name, store := range map[string]Store{
+		"dir": Dir{Path: t.TempDir()},
+		"s3":  NewS3("bucket", "cache", "eu-west-1", server.URL),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			_, err := store.Get(ctx, "documents/missing.json")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			require.NoError(t, store.Put(ctx, "documents/1.json", []byte(`{"id":1}`)))
+			require.NoError(t, store.Put(ctx, "documents/1.json", []byte(`{"id":2}`)))
+			data, err := store.Get(ctx, "documents/1.json")
+			require.NoError(t, err)
+			assert.Equal(t, []byte(`{"id":2}`), data)
+		})
+	}
+	assert.Contains(t, objects, "/bucket/cache/documents/1.json")
+}
+
+func TestS3Anonymous(t *testing.T) {
+	var authorization []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Values("Authorization")
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	store := NewS3("bucket", "cache", "eu-west-1", server.URL)
+	store.Credentials = awssig.Credentials{}
+	data, err := store.Get(context.Background(), "documents/1.json")
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"id":1}`), data)
+	assert.Empty(t, authorization)
+}
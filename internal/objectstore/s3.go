@@ -0,0 +1,134 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/0xPolygonID/verifier-backend/internal/awssig"
+)
+
+// maxObjectSize limits the size of the objects read from buckets
+const maxObjectSize = 16 << 20
+
+// S3 stores the objects under Prefix in an s3 bucket. Requests are signed with AWS signature version 4
+// unless the Credentials are anonymous. With an Endpoint, the bucket is addressed in the path.
+type S3 struct {
+	Bucket      string
+	Prefix      string
+	Region      string
+	Endpoint    string
+	Credentials awssig.Provider
+	Client      *http.Client
+}
+
+// NewS3 creates an S3 store with the credentials of the environment, see awssig.DefaultProvider
+func NewS3(bucket, prefix, region, endpoint string) *S3 {
+	return &S3{
+		Bucket:      bucket,
+		Prefix:      prefix,
+		Region:      region,
+		Endpoint:    strings.TrimSuffix(endpoint, "/"),
+		Credentials: awssig.DefaultProvider(),
+		Client:      http.DefaultClient,
+	}
+}
+
+// Get downloads the object
+func (s *S3) Get(ctx context.Context, name string) ([]byte, error) {
+	req, err := s.request(ctx, http.MethodGet, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(io.LimitReader(resp.Body, maxObjectSize))
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+}
+
+// Put uploads the object
+func (s *S3) Put(ctx context.Context, name string, data []byte) error {
+	req, err := s.request(ctx, http.MethodPut, name, data)
+	if err != nil {
+		return err
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// Location returns the s3 URL of the objects
+func (s *S3) Location() string {
+	if s.Endpoint == gcsEndpoint {
+		return "gs://" + path.Join(s.Bucket, s.Prefix)
+	}
+	return "s3://" + path.Join(s.Bucket, s.Prefix)
+}
+
+func (s *S3) request(ctx context.Context, method, name string, body []byte) (*http.Request, error) {
+	host := s.Bucket + ".s3." + s.Region + ".amazonaws.com"
+	scheme := "https"
+	objectPath := "/" + escapePath(path.Join(s.Prefix, name))
+	if s.Endpoint != "" {
+		endpoint, err := url.Parse(s.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		scheme, host = endpoint.Scheme, endpoint.Host
+		objectPath = "/" + escapePath(s.Bucket) + objectPath
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, scheme+"://"+host+objectPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	creds, err := s.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !creds.Anonymous() {
+		payload := sha256.Sum256(body)
+		req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payload[:]))
+		awssig.Sign(req, body, creds, "s3", s.Region, time.Now())
+	}
+	return req, nil
+}
+
+// escapePath escapes the object path as AWS expects it in canonical requests: every byte but the unreserved
+// characters and the slashes is percent-encoded
+func escapePath(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-._~/", c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
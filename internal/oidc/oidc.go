@@ -0,0 +1,355 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/signing"
+)
+
+const (
+	authorizationKeyPrefix = "oidc-authorization-"
+	codeKeyPrefix          = "oidc-code-"
+)
+
+// ErrPending is returned by Sessions when the user has not answered the verification request yet
+var ErrPending = errors.New("verification pending")
+
+//go:embed authorize.html
+var authorizePage string
+
+var authorizeTemplate = template.Must(template.New("authorize").Parse(authorizePage))
+
+// Identity is the user of a successful verification
+type Identity struct {
+	DID    string
+	Claims map[string]any
+}
+
+// Sessions creates the verification sessions of the sign-ins and returns their results
+type Sessions interface {
+	// CreateSession creates a verification session with a query template and returns its iden3comm URI
+	CreateSession(ctx context.Context, template string, chainID string) (uuid.UUID, string, error)
+	// ConsumeIdentity returns the identity of a verified session, only once
+	ConsumeIdentity(sessionID uuid.UUID) (*Identity, error)
+}
+
+type authorization struct {
+	ClientID    string
+	RedirectURI string
+	State       string
+	Nonce       string
+}
+
+type grant struct {
+	authorization
+	Identity *Identity
+	AuthTime time.Time
+}
+
+// Provider is an OpenID Connect provider implementing the authorization code flow,
+// where the user signs in by answering a verification request with the wallet
+type Provider struct {
+	cfg      config.OIDC
+	issuer   string
+	clients  map[string]config.OIDCClient
+	sessions Sessions
+	key      *signing.Key
+	cache    *cache.Cache
+	codesMu  sync.Mutex
+}
+
+// New creates a new Provider. ID tokens are signed with the default key of the key ring.
+func New(cfg config.Config, sessions Sessions, keys *signing.KeyRing) (*Provider, error) {
+	key, err := keys.Key("")
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make(map[string]config.OIDCClient, len(cfg.OIDC.Clients))
+	for _, client := range cfg.OIDC.Clients {
+		clients[client.ID] = client
+	}
+	return &Provider{
+		cfg:      cfg.OIDC,
+		issuer:   strings.TrimSuffix(cfg.Host, "/") + "/oidc",
+		clients:  clients,
+		sessions: sessions,
+		key:      key,
+		cache:    cache.New(cfg.OIDC.CodeTTL.AsDuration(), cfg.OIDC.CodeTTL.AsDuration()),
+	}, nil
+}
+
+// Register adds the provider endpoints to the mux
+func (p *Provider) Register(mux *chi.Mux) {
+	mux.Route("/oidc", func(r chi.Router) {
+		r.Get("/.well-known/openid-configuration", p.discovery)
+		r.Get("/authorize", p.authorize)
+		r.Get("/authorize/status", p.authorizeStatus)
+		r.Post("/token", p.token)
+		r.Get("/jwks", p.jwks)
+	})
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"jwks_uri":                              p.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"grant_types_supported":                 []string{"authorization_code"},
+		"subject_types_supported":               []string{"public"},
+		"scopes_supported":                      []string{"openid"},
+		"id_token_signing_alg_values_supported": []string{string(p.key.Algorithm())},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+		"claims_supported":                      []string{"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce", "claims"},
+	})
+}
+
+func (p *Provider) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, jose.JSONWebKeySet{Keys: []jose.JSONWebKey{p.key.Public()}})
+}
+
+// authorize validates the authorization request, creates a verification session and renders its QR code
+func (p *Provider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	client, ok := p.clients[query.Get("client_id")]
+	if !ok {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+	redirectURI := query.Get("redirect_uri")
+	if !contains(client.RedirectURIs, redirectURI) {
+		http.Error(w, "redirect_uri is not registered for the client", http.StatusBadRequest)
+		return
+	}
+
+	state := query.Get("state")
+	if query.Get("response_type") != "code" {
+		http.Redirect(w, r, errorRedirect(redirectURI, "unsupported_response_type", state), http.StatusFound)
+		return
+	}
+	if !contains(strings.Fields(query.Get("scope")), "openid") {
+		http.Redirect(w, r, errorRedirect(redirectURI, "invalid_scope", state), http.StatusFound)
+		return
+	}
+
+	sessionID, requestURI, err := p.sessions.CreateSession(r.Context(), client.Template, client.ChainID)
+	if err != nil {
+		log.WithFields(log.Fields{"client": client.ID, "err": err}).Error("failed to create oidc session")
+		http.Redirect(w, r, errorRedirect(redirectURI, "server_error", state), http.StatusFound)
+		return
+	}
+	p.cache.Set(authorizationKeyPrefix+sessionID.String(), authorization{
+		ClientID:    client.ID,
+		RedirectURI: redirectURI,
+		State:       state,
+		Nonce:       query.Get("nonce"),
+	}, cache.DefaultExpiration)
+
+	w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+	if err := authorizeTemplate.Execute(w, map[string]string{
+		"RequestURI": requestURI,
+		"StatusURL":  p.issuer + "/authorize/status?session=" + sessionID.String(),
+	}); err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("failed to render authorize page")
+	}
+}
+
+// authorizeStatus is polled by the authorize page. Once the session is verified, it returns the redirect with the authorization code.
+func (p *Provider) authorizeStatus(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := uuid.Parse(r.URL.Query().Get("session"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"status": "error"})
+		return
+	}
+	item, ok := p.cache.Get(authorizationKeyPrefix + sessionID.String())
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"status": "error"})
+		return
+	}
+	auth, _ := item.(authorization)
+
+	identity, err := p.sessions.ConsumeIdentity(sessionID)
+	if errors.Is(err, ErrPending) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "pending"})
+		return
+	}
+	p.cache.Delete(authorizationKeyPrefix + sessionID.String())
+	if err != nil {
+		log.WithFields(log.Fields{"sessionID": sessionID, "err": err}).Info("oidc verification failed")
+		writeJSON(w, http.StatusOK, map[string]string{"status": "done", "redirect": errorRedirect(auth.RedirectURI, "access_denied", auth.State)})
+		return
+	}
+
+	code, err := randomString()
+	if err != nil {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "done", "redirect": errorRedirect(auth.RedirectURI, "server_error", auth.State)})
+		return
+	}
+	p.cache.Set(codeKeyPrefix+code, grant{authorization: auth, Identity: identity, AuthTime: time.Now()}, cache.DefaultExpiration)
+
+	params := url.Values{"code": {code}}
+	if auth.State != "" {
+		params.Set("state", auth.State)
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "done", "redirect": withQuery(auth.RedirectURI, params)})
+}
+
+// token exchanges an authorization code for an ID token. Codes can only be used once.
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeTokenError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	clientID, secret, ok := r.BasicAuth()
+	if !ok {
+		clientID, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	client, ok := p.clients[clientID]
+	if !ok || subtle.ConstantTimeCompare([]byte(client.Secret), []byte(secret)) != 1 {
+		writeTokenError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+
+	g, ok := p.takeGrant(r.PostForm.Get("code"))
+	if !ok || g.ClientID != client.ID || g.RedirectURI != r.PostForm.Get("redirect_uri") {
+		writeTokenError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	idToken, err := p.idToken(g)
+	if err != nil {
+		log.WithFields(log.Fields{"client": client.ID, "err": err}).Error("failed to sign id token")
+		writeTokenError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+	accessToken, err := randomString()
+	if err != nil {
+		writeTokenError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   int(p.cfg.TokenTTL.AsDuration().Seconds()),
+		"id_token":     idToken,
+	})
+}
+
+// takeGrant returns the grant of an authorization code and deletes it
+func (p *Provider) takeGrant(code string) (grant, bool) {
+	p.codesMu.Lock()
+	defer p.codesMu.Unlock()
+
+	item, ok := p.cache.Get(codeKeyPrefix + code)
+	if !ok {
+		return grant{}, false
+	}
+	p.cache.Delete(codeKeyPrefix + code)
+	g, ok := item.(grant)
+	return g, ok
+}
+
+type idTokenClaims struct {
+	jwt.Claims
+	Nonce     string         `json:"nonce,omitempty"`
+	AuthTime  int64          `json:"auth_time"`
+	Disclosed map[string]any `json:"claims,omitempty"`
+}
+
+func (p *Provider) idToken(g grant) (string, error) {
+	signer, err := p.key.Signer((&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	return jwt.Signed(signer).Claims(idTokenClaims{
+		Claims: jwt.Claims{
+			Issuer:   p.issuer,
+			Subject:  g.Identity.DID,
+			Audience: jwt.Audience{g.ClientID},
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(now.Add(p.cfg.TokenTTL.AsDuration())),
+		},
+		Nonce:     g.Nonce,
+		AuthTime:  g.AuthTime.Unix(),
+		Disclosed: g.Identity.Claims,
+	}).CompactSerialize()
+}
+
+func errorRedirect(redirectURI, code, state string) string {
+	params := url.Values{"error": {code}}
+	if state != "" {
+		params.Set("state", state)
+	}
+	return withQuery(redirectURI, params)
+}
+
+func withQuery(rawURL string, params url.Values) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	for k, v := range params {
+		query[k] = v
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func writeTokenError(w http.ResponseWriter, status int, code string) {
+	writeJSON(w, status, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithFields(log.Fields{"err": err}).Error("failed to write response")
+	}
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
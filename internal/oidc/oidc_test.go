@@ -0,0 +1,124 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/signing"
+)
+
+type fakeSessions struct {
+	sessionID uuid.UUID
+	identity  *Identity
+}
+
+func (f *fakeSessions) CreateSession(_ context.Context, _ string, _ string) (uuid.UUID, string, error) {
+	return f.sessionID, "iden3comm://?request_uri=http://localhost/qr-store?id=1", nil
+}
+
+func (f *fakeSessions) ConsumeIdentity(_ uuid.UUID) (*Identity, error) {
+	if f.identity == nil {
+		return nil, ErrPending
+	}
+	return f.identity, nil
+}
+
+func TestProvider(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key, err := signing.NewKey(privateKey)
+	require.NoError(t, err)
+	keys, err := signing.NewKeyRing(config.Config{})
+	require.NoError(t, err)
+	keys.SetTenantKey("", key)
+
+	cfg := config.Config{
+		Host: "http://localhost",
+		OIDC: config.OIDC{
+			TokenTTL: config.CacheTTL(time.Hour),
+			CodeTTL:  config.CacheTTL(time.Minute),
+			Clients: []config.OIDCClient{
+				{ID: "app", Secret: "secret", RedirectURIs: []string{"https://app.example.com/cb"}, Template: "kyc", ChainID: "80002"},
+			},
+		},
+	}
+	sessions := &fakeSessions{sessionID: uuid.New()}
+	provider, err := New(cfg, sessions, keys)
+	require.NoError(t, err)
+	mux := chi.NewRouter()
+	provider.Register(mux)
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(httptest.NewRequest(http.MethodGet, "/oidc/authorize?client_id=app&redirect_uri=https://evil.example.com&response_type=code&scope=openid", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serve(httptest.NewRequest(http.MethodGet, "/oidc/authorize?client_id=app&redirect_uri=https://app.example.com/cb&response_type=code&scope=openid&state=xyz&nonce=n1", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	statusURL := "/oidc/authorize/status?session=" + sessions.sessionID.String()
+	rec = serve(httptest.NewRequest(http.MethodGet, statusURL, nil))
+	assert.JSONEq(t, `{"status":"pending"}`, rec.Body.String())
+
+	sessions.identity = &Identity{DID: "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK", Claims: map[string]any{"birthday": 19960424}}
+	rec = serve(httptest.NewRequest(http.MethodGet, statusURL, nil))
+	var status map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	require.Equal(t, "done", status["status"])
+	redirect, err := url.Parse(status["redirect"])
+	require.NoError(t, err)
+	assert.Equal(t, "xyz", redirect.Query().Get("state"))
+	code := redirect.Query().Get("code")
+	require.NotEmpty(t, code)
+
+	form := url.Values{"grant_type": {"authorization_code"}, "code": {code}, "redirect_uri": {"https://app.example.com/cb"}}
+	tokenRequest := func(secret string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/oidc/token", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth("app", secret)
+		return req
+	}
+
+	rec = serve(tokenRequest("wrong"))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = serve(tokenRequest("secret"))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var tokenResp struct {
+		IDToken string `json:"id_token"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tokenResp))
+
+	parsed, err := jwt.ParseSigned(tokenResp.IDToken)
+	require.NoError(t, err)
+	var claims idTokenClaims
+	require.NoError(t, parsed.Claims(&privateKey.PublicKey, &claims))
+	assert.Equal(t, sessions.identity.DID, claims.Subject)
+	assert.Equal(t, "n1", claims.Nonce)
+	assert.NoError(t, claims.Validate(jwt.Expected{Issuer: "http://localhost/oidc", Audience: jwt.Audience{"app"}, Time: time.Now()}))
+	assert.Equal(t, float64(19960424), claims.Disclosed["birthday"])
+
+	// codes can only be exchanged once
+	rec = serve(tokenRequest("secret"))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
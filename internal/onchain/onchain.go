@@ -0,0 +1,149 @@
+// Package onchain reads the proofs submitted to the verifier contracts by the on-chain sign-ins, so their sessions can
+// be completed once the wallet transaction is mined.
+package onchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/stateresolver"
+)
+
+// verifierABI is the part of the ABI of the Universal Verifier that reads the submitted proofs
+const verifierABI = `[
+	{"name":"getProofStatus","type":"function","stateMutability":"view",
+	 "inputs":[{"name":"sender","type":"address"},{"name":"requestId","type":"uint64"}],
+	 "outputs":[{"name":"","type":"tuple","components":[
+		{"name":"isVerified","type":"bool"},
+		{"name":"validatorVersion","type":"string"},
+		{"name":"blockNumber","type":"uint256"},
+		{"name":"blockTimestamp","type":"uint256"}]}]},
+	{"name":"getProofStorageField","type":"function","stateMutability":"view",
+	 "inputs":[{"name":"user","type":"address"},{"name":"requestId","type":"uint64"},{"name":"key","type":"string"}],
+	 "outputs":[{"name":"","type":"uint256"}]}
+]`
+
+// Fields of the public signals stored by the verifier contract with the proofs
+const (
+	FieldUserID    = "userID"
+	FieldNullifier = "nullifier"
+)
+
+// ErrUnknownChain is returned for the chains missing in the resolver settings
+var ErrUnknownChain = errors.New("chain not found in the resolver settings")
+
+// Proof is the proof of a request submitted by a sender to a verifier contract
+type Proof struct {
+	Verified  bool
+	UserID    *big.Int
+	Nullifier *big.Int
+	// Block and Time are the number and the timestamp of the block where the proof was submitted
+	Block uint64
+	Time  time.Time
+}
+
+// proofStatus is the ProofStatus struct of the verifier contract
+type proofStatus struct {
+	IsVerified       bool
+	ValidatorVersion string
+	BlockNumber      *big.Int
+	BlockTimestamp   *big.Int
+}
+
+// Reader reads the proofs submitted to the verifier contracts of the networks
+type Reader interface {
+	Proof(ctx context.Context, chainID int, contract, sender common.Address, requestID uint64) (Proof, error)
+}
+
+// ETHReader reads the verifier contracts through the clients of the RPC urls of the networks, with the retries and
+// the breakers of the state resolutions
+type ETHReader struct {
+	abi  ethabi.ABI
+	rpcs map[int]*stateresolver.Resolver
+}
+
+// NewETHReader creates an ETHReader of the networks of the resolver settings, whose clients are given by
+// blockchain:network. The networks without a client cannot be read.
+func NewETHReader(settings config.ResolverSettings, rpcs map[string]*stateresolver.Resolver) *ETHReader {
+	parsed, err := ethabi.JSON(strings.NewReader(verifierABI))
+	if err != nil {
+		panic(err)
+	}
+	reader := &ETHReader{abi: parsed, rpcs: make(map[int]*stateresolver.Resolver)}
+	for chainName, chainSettings := range settings {
+		for networkName, networkSettings := range chainSettings {
+			chainID, err := strconv.Atoi(networkSettings.ChainID)
+			if err != nil {
+				continue
+			}
+			if rpc, ok := rpcs[fmt.Sprintf("%s:%s", chainName, networkName)]; ok {
+				reader.rpcs[chainID] = rpc
+			}
+		}
+	}
+	return reader
+}
+
+// Proof reads the proof of requestID submitted by sender to the verifier contract. The user ID and the nullifier are
+// only read from the verified proofs, the nullifier is nil when the circuit of the request has none.
+func (r *ETHReader) Proof(ctx context.Context, chainID int, contract, sender common.Address, requestID uint64) (Proof, error) {
+	rpc, ok := r.rpcs[chainID]
+	if !ok {
+		return Proof{}, fmt.Errorf("%w: %d", ErrUnknownChain, chainID)
+	}
+
+	var out []interface{}
+	if err := r.call(ctx, rpc, contract, &out, "getProofStatus", sender, requestID); err != nil {
+		return Proof{}, fmt.Errorf("failed to read the proof status: %w", err)
+	}
+	status := *ethabi.ConvertType(out[0], new(proofStatus)).(*proofStatus)
+	proof := Proof{Verified: status.IsVerified}
+	if !proof.Verified {
+		return proof, nil
+	}
+	proof.Block = status.BlockNumber.Uint64()
+	proof.Time = time.Unix(status.BlockTimestamp.Int64(), 0).UTC()
+
+	var err error
+	if proof.UserID, err = r.field(ctx, rpc, contract, sender, requestID, FieldUserID); err != nil {
+		return Proof{}, err
+	}
+	nullifier, err := r.field(ctx, rpc, contract, sender, requestID, FieldNullifier)
+	if err != nil {
+		return Proof{}, err
+	}
+	if nullifier.Sign() != 0 {
+		proof.Nullifier = nullifier
+	}
+	return proof, nil
+}
+
+func (r *ETHReader) field(ctx context.Context, rpc *stateresolver.Resolver, contract, sender common.Address,
+	requestID uint64, key string,
+) (*big.Int, error) {
+	var out []interface{}
+	if err := r.call(ctx, rpc, contract, &out, "getProofStorageField", sender, requestID, key); err != nil {
+		return nil, fmt.Errorf("failed to read the %s of the proof: %w", key, err)
+	}
+	return *ethabi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+}
+
+// call calls method of the verifier contract through a client of the network
+func (r *ETHReader) call(ctx context.Context, rpc *stateresolver.Resolver, contract common.Address, out *[]interface{},
+	method string, params ...interface{},
+) error {
+	return rpc.Call(ctx, func(ctx context.Context, client stateresolver.Client) error {
+		verifier := bind.NewBoundContract(contract, r.abi, client, nil, nil)
+		return verifier.Call(&bind.CallOpts{Context: ctx}, out, method, params...)
+	})
+}
@@ -0,0 +1,150 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+// Mode defines what to do with wildcard allowed issuers in requests
+type Mode string
+
+const (
+	// ModeReject rejects requests with wildcard allowed issuers for credential types with a policy
+	ModeReject Mode = "reject"
+	// ModeRewrite replaces wildcard allowed issuers with the trusted issuers of the policy
+	ModeRewrite Mode = "rewrite"
+
+	wildcardIssuer = "*"
+)
+
+// ErrIssuerNotAllowed is returned when an issuer is not trusted for a credential type
+var ErrIssuerNotAllowed = errors.New("issuer is not allowed")
+
+// IssuerPolicy holds the trusted issuers per credential type.
+// Credential types without trusted issuers are not restricted.
+type IssuerPolicy struct {
+	mu      sync.RWMutex
+	mode    Mode
+	issuers map[string][]string
+}
+
+// NewIssuerPolicy creates a new IssuerPolicy from the configuration
+func NewIssuerPolicy(cfg config.IssuerPolicy) (*IssuerPolicy, error) {
+	mode := Mode(cfg.Mode)
+	switch mode {
+	case "":
+		mode = ModeReject
+	case ModeReject, ModeRewrite:
+	default:
+		return nil, fmt.Errorf("invalid issuer policy mode %q", cfg.Mode)
+	}
+
+	p := &IssuerPolicy{
+		mode:    mode,
+		issuers: make(map[string][]string, len(cfg.Issuers)),
+	}
+	for credentialType, issuers := range cfg.Issuers {
+		if err := p.Set(credentialType, issuers); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Mode returns the policy mode
+func (p *IssuerPolicy) Mode() Mode {
+	return p.mode
+}
+
+// AllowedIssuers applies the policy to the allowed issuers requested for a credential type
+// and returns the allowed issuers to use in the request.
+func (p *IssuerPolicy) AllowedIssuers(credentialType string, requested []string) ([]string, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	trusted, ok := p.issuers[credentialType]
+	if !ok {
+		return requested, nil
+	}
+
+	for _, issuer := range requested {
+		if issuer == wildcardIssuer {
+			if p.mode == ModeRewrite {
+				return append([]string{}, trusted...), nil
+			}
+			return nil, fmt.Errorf("%w: wildcard allowedIssuers are not allowed for type %s", ErrIssuerNotAllowed, credentialType)
+		}
+	}
+
+	for _, issuer := range requested {
+		if !contains(trusted, issuer) {
+			return nil, fmt.Errorf("%w: %s is not trusted for type %s", ErrIssuerNotAllowed, issuer, credentialType)
+		}
+	}
+	return requested, nil
+}
+
+// IsAllowed checks if the issuer is trusted for the credential type
+func (p *IssuerPolicy) IsAllowed(credentialType, issuer string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	trusted, ok := p.issuers[credentialType]
+	if !ok {
+		return true
+	}
+	return contains(trusted, issuer)
+}
+
+// Set replaces the trusted issuers for a credential type
+func (p *IssuerPolicy) Set(credentialType string, issuers []string) error {
+	if credentialType == "" {
+		return errors.New("credential type is empty")
+	}
+	if len(issuers) == 0 {
+		return fmt.Errorf("trusted issuers for type %s are empty", credentialType)
+	}
+	for _, issuer := range issuers {
+		if _, err := w3c.ParseDID(issuer); err != nil {
+			return fmt.Errorf("invalid issuer DID %s for type %s: %w", issuer, credentialType, err)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.issuers[credentialType] = append([]string{}, issuers...)
+	return nil
+}
+
+// Delete removes the policy of a credential type
+func (p *IssuerPolicy) Delete(credentialType string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.issuers, credentialType)
+}
+
+// List returns a copy of the trusted issuers per credential type
+func (p *IssuerPolicy) List() map[string][]string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	out := make(map[string][]string, len(p.issuers))
+	for credentialType, issuers := range p.issuers {
+		out[credentialType] = append([]string{}, issuers...)
+	}
+	return out
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+const (
+	trustedIssuer   = "did:polygonid:polygon:amoy:2qQ68JkRcf3xrHPQPWZei3YeVzHPP58wYNxx2mEouR"
+	untrustedIssuer = "did:polygonid:polygon:amoy:2qV9QXdhXXmN5sKjN1YueMjxgRbnJcEGK2kGpvk3cq"
+)
+
+func TestIssuerPolicy_AllowedIssuers(t *testing.T) {
+	type testConfig struct {
+		name           string
+		mode           string
+		credentialType string
+		requested      []string
+		expected       []string
+		expectedErr    bool
+	}
+
+	for _, tc := range []testConfig{
+		{
+			name:           "wildcard rejected",
+			mode:           "reject",
+			credentialType: "KYCAgeCredential",
+			requested:      []string{"*"},
+			expectedErr:    true,
+		},
+		{
+			name:           "wildcard rewritten",
+			mode:           "rewrite",
+			credentialType: "KYCAgeCredential",
+			requested:      []string{"*"},
+			expected:       []string{trustedIssuer},
+		},
+		{
+			name:           "trusted issuer",
+			mode:           "reject",
+			credentialType: "KYCAgeCredential",
+			requested:      []string{trustedIssuer},
+			expected:       []string{trustedIssuer},
+		},
+		{
+			name:           "untrusted issuer",
+			mode:           "rewrite",
+			credentialType: "KYCAgeCredential",
+			requested:      []string{untrustedIssuer},
+			expectedErr:    true,
+		},
+		{
+			name:           "type without policy",
+			mode:           "reject",
+			credentialType: "TestInteger01",
+			requested:      []string{"*"},
+			expected:       []string{"*"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := NewIssuerPolicy(config.IssuerPolicy{
+				Mode:    tc.mode,
+				Issuers: map[string][]string{"KYCAgeCredential": {trustedIssuer}},
+			})
+			require.NoError(t, err)
+
+			allowed, err := p.AllowedIssuers(tc.credentialType, tc.requested)
+			if tc.expectedErr {
+				assert.ErrorIs(t, err, ErrIssuerNotAllowed)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, allowed)
+		})
+	}
+}
+
+func TestNewIssuerPolicy_InvalidConfig(t *testing.T) {
+	_, err := NewIssuerPolicy(config.IssuerPolicy{Mode: "allow"})
+	assert.Error(t, err)
+
+	_, err = NewIssuerPolicy(config.IssuerPolicy{Issuers: map[string][]string{"KYCAgeCredential": {"not-a-did"}}})
+	assert.Error(t, err)
+}
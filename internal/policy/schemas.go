@@ -0,0 +1,125 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+const ipfsScheme = "ipfs://"
+
+// ErrSchemaNotAllowed is returned when a context or a schema is not in the schema allowlist
+var ErrSchemaNotAllowed = errors.New("schema is not allowed")
+
+var cidRegex = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+
+// SchemaAllowlist holds the JSON-LD contexts and schemas accepted in the queries and loaded by the verifier.
+// Every document is allowed while it is disabled.
+type SchemaAllowlist struct {
+	mu      sync.RWMutex
+	enabled bool
+	entries []string
+}
+
+// NewSchemaAllowlist creates a new SchemaAllowlist from the configuration
+func NewSchemaAllowlist(cfg config.SchemaAllowlist) (*SchemaAllowlist, error) {
+	a := &SchemaAllowlist{}
+	if err := a.Set(cfg.Enabled, cfg.Entries); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Enabled reports whether only the documents of the entries are allowed
+func (a *SchemaAllowlist) Enabled() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.enabled
+}
+
+// Entries returns a copy of the entries of the allowlist
+func (a *SchemaAllowlist) Entries() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return append([]string{}, a.entries...)
+}
+
+// Set replaces the entries of the allowlist and enables or disables it. An entry is an http(s) or ipfs url, a url
+// prefix ending with a slash, or an ipfs CID that allows the documents of its directory.
+func (a *SchemaAllowlist) Set(enabled bool, entries []string) error {
+	for _, entry := range entries {
+		if err := validateSchemaEntry(entry); err != nil {
+			return err
+		}
+	}
+	if enabled && len(entries) == 0 {
+		return errors.New("an enabled schema allowlist needs entries")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.enabled = enabled
+	a.entries = append([]string{}, entries...)
+	return nil
+}
+
+// IsAllowed checks if the document of u can be used, always true while the allowlist is disabled
+func (a *SchemaAllowlist) IsAllowed(u string) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if !a.enabled {
+		return true
+	}
+	for _, entry := range a.entries {
+		if schemaEntryMatches(entry, u) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns ErrSchemaNotAllowed when the document of u cannot be used
+func (a *SchemaAllowlist) Check(u string) error {
+	if !a.IsAllowed(u) {
+		return fmt.Errorf("%w: %s", ErrSchemaNotAllowed, u)
+	}
+	return nil
+}
+
+func schemaEntryMatches(entry, u string) bool {
+	if !strings.Contains(entry, "://") {
+		entry = ipfsScheme + entry + "/"
+		return u+"/" == entry || strings.HasPrefix(u, entry)
+	}
+	if strings.HasSuffix(entry, "/") {
+		return strings.HasPrefix(u, entry)
+	}
+	return u == entry
+}
+
+func validateSchemaEntry(entry string) error {
+	if !strings.Contains(entry, "://") {
+		if !cidRegex.MatchString(entry) {
+			return fmt.Errorf("schema allowlist entry %q is not a url or an ipfs CID", entry)
+		}
+		return nil
+	}
+	parsed, err := url.Parse(entry)
+	if err != nil {
+		return fmt.Errorf("schema allowlist entry %q is not a valid url: %w", entry, err)
+	}
+	switch parsed.Scheme {
+	case "http", "https", "ipfs":
+	default:
+		return fmt.Errorf("schema allowlist entry %q must be an http, https or ipfs url", entry)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("schema allowlist entry %q has no host", entry)
+	}
+	return nil
+}
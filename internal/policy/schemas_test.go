@@ -0,0 +1,61 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+func TestSchemaAllowlist_IsAllowed(t *testing.T) {
+	allowlist, err := NewSchemaAllowlist(config.SchemaAllowlist{
+		Enabled: true,
+		Entries: []string{
+			"https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/",
+			"https://schemas.example.com/kyc.json-ld",
+			"QmXwNcbWDpHvUMvG7ALfR7KdYDpLMqjNYJ3QmrDAjLiCfe",
+		},
+	})
+	require.NoError(t, err)
+
+	for u, allowed := range map[string]bool{
+		"https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld": true,
+		"https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/other/kyc-v3.json-ld":           false,
+		"https://schemas.example.com/kyc.json-ld":                                                        true,
+		"https://schemas.example.com/kyc.json-ld.evil":                                                   false,
+		"http://schemas.example.com/kyc.json-ld":                                                         false,
+		"ipfs://QmXwNcbWDpHvUMvG7ALfR7KdYDpLMqjNYJ3QmrDAjLiCfe":                                          true,
+		"ipfs://QmXwNcbWDpHvUMvG7ALfR7KdYDpLMqjNYJ3QmrDAjLiCfe/kyc.json-ld":                              true,
+		"ipfs://QmXwNcbWDpHvUMvG7ALfR7KdYDpLMqjNYJ3QmrDAjLiCfeX":                                         false,
+		"http://169.254.169.254/latest/meta-data":                                                        false,
+	} {
+		assert.Equal(t, allowed, allowlist.IsAllowed(u), u)
+	}
+	assert.ErrorIs(t, allowlist.Check("https://evil.example.com/kyc.json-ld"), ErrSchemaNotAllowed)
+
+	// a disabled allowlist allows every document
+	require.NoError(t, allowlist.Set(false, allowlist.Entries()))
+	assert.True(t, allowlist.IsAllowed("https://evil.example.com/kyc.json-ld"))
+	assert.NoError(t, allowlist.Check("https://evil.example.com/kyc.json-ld"))
+}
+
+func TestSchemaAllowlist_InvalidEntries(t *testing.T) {
+	for _, cfg := range []config.SchemaAllowlist{
+		{Enabled: true},
+		{Entries: []string{"ftp://schemas.example.com/kyc.json-ld"}},
+		{Entries: []string{"https:///kyc.json-ld"}},
+		{Entries: []string{"schemas.example.com/kyc.json-ld"}},
+		{Entries: []string{""}},
+	} {
+		_, err := NewSchemaAllowlist(cfg)
+		assert.Error(t, err, cfg)
+	}
+
+	allowlist, err := NewSchemaAllowlist(config.SchemaAllowlist{})
+	require.NoError(t, err)
+	assert.False(t, allowlist.Enabled())
+	assert.Error(t, allowlist.Set(true, []string{"not a url"}))
+	assert.False(t, allowlist.Enabled())
+}
@@ -0,0 +1,116 @@
+// Package resolver resolves DIDs of any method (did:ethr, did:key, did:web, ...) with a universal resolver.
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+	"github.com/patrickmn/go-cache"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+
+	// PushServiceType is the type of the DID document service of the wallets that receive push notifications
+	PushServiceType = "Iden3PushServiceV1"
+)
+
+var (
+	// ErrNotFound is returned when the resolver does not know the DID
+	ErrNotFound = errors.New("did not found")
+	// ErrServiceNotFound is returned when the DID document has no service of the requested type
+	ErrServiceNotFound = errors.New("did document service not found")
+)
+
+// resolution is the DID resolution result returned by the universal resolver
+type resolution struct {
+	Document *verifiable.DIDDocument `json:"didDocument"`
+	Metadata struct {
+		Error string `json:"error"`
+	} `json:"didResolutionMetadata"`
+}
+
+// Resolver resolves DIDs with the universal resolver at endpoint, e.g. https://dev.uniresolver.io.
+// Resolved documents are cached for ttl.
+type Resolver struct {
+	endpoint string
+	client   *http.Client
+	cache    *cache.Cache
+}
+
+// New creates a new Resolver
+func New(endpoint string, ttl time.Duration) *Resolver {
+	return &Resolver{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{Timeout: requestTimeout},
+		cache:    cache.New(ttl, ttl),
+	}
+}
+
+// Resolve returns the DID document of did
+func (r *Resolver) Resolve(ctx context.Context, did string) (*verifiable.DIDDocument, error) {
+	if _, err := w3c.ParseDID(did); err != nil {
+		return nil, fmt.Errorf("invalid did %s: %w", did, err)
+	}
+	if doc, ok := r.cache.Get(did); ok {
+		return doc.(*verifiable.DIDDocument), nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.endpoint+"/1.0/identifiers/"+url.PathEscape(did), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", `application/ld+json;profile="https://w3id.org/did-resolution"`)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, did)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("resolver replied with status %d for %s", resp.StatusCode, did)
+	}
+
+	var res resolution
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("invalid resolution of %s: %w", did, err)
+	}
+	if res.Metadata.Error != "" {
+		return nil, fmt.Errorf("failed to resolve %s: %s", did, res.Metadata.Error)
+	}
+	if res.Document == nil || res.Document.ID != did {
+		return nil, fmt.Errorf("resolver returned no document for %s", did)
+	}
+
+	r.cache.Set(did, res.Document, cache.DefaultExpiration)
+	return res.Document, nil
+}
+
+// Service returns the first service of the DID document with the given type, e.g. PushServiceType
+func Service(doc *verifiable.DIDDocument, serviceType string) (verifiable.Service, error) {
+	for _, s := range doc.Service {
+		b, err := json.Marshal(s)
+		if err != nil {
+			return verifiable.Service{}, err
+		}
+		var service verifiable.Service
+		if err := json.Unmarshal(b, &service); err != nil {
+			continue
+		}
+		if service.Type == serviceType {
+			return service, nil
+		}
+	}
+	return verifiable.Service{}, ErrServiceNotFound
+}
@@ -0,0 +1,67 @@
+package resolver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webDID = "did:web:verifier.example.com"
+
+func TestResolve(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch strings.TrimPrefix(r.URL.Path, "/1.0/identifiers/") {
+		case webDID:
+			_, _ = w.Write([]byte(`{
+				"didDocument": {
+					"@context": "https://www.w3.org/ns/did/v1",
+					"id": "did:web:verifier.example.com",
+					"service": [
+						{"id": "did:web:verifier.example.com#push", "type": "Iden3PushServiceV1", "serviceEndpoint": "https://push.example.com"}
+					]
+				},
+				"didResolutionMetadata": {"contentType": "application/did+ld+json"}
+			}`))
+		case "did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK":
+			_, _ = w.Write([]byte(`{"didDocument": null, "didResolutionMetadata": {"error": "invalidDid"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	r := New(srv.URL+"/", time.Minute)
+	ctx := context.Background()
+
+	doc, err := r.Resolve(ctx, webDID)
+	require.NoError(t, err)
+	assert.Equal(t, webDID, doc.ID)
+	service, err := Service(doc, PushServiceType)
+	require.NoError(t, err)
+	assert.Equal(t, "https://push.example.com", service.ServiceEndpoint)
+	_, err = Service(doc, "LinkedDomains")
+	assert.ErrorIs(t, err, ErrServiceNotFound)
+
+	// resolved documents are cached
+	_, err = r.Resolve(ctx, webDID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	_, err = r.Resolve(ctx, "did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK")
+	assert.EqualError(t, err, "failed to resolve did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK: invalidDid")
+
+	_, err = r.Resolve(ctx, "did:ethr:0x5:0xb9c5714089478a327f09197987f16f9e5d936e8a")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = r.Resolve(ctx, "not-a-did")
+	assert.Error(t, err)
+	assert.Equal(t, 3, requests)
+}
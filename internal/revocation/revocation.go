@@ -0,0 +1,186 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/stateresolver"
+)
+
+var (
+	// ErrUnsupportedStatusType is returned when the credential status type cannot be resolved by the checker
+	ErrUnsupportedStatusType = errors.New("unsupported credential status type")
+	// ErrHostNotAllowed is returned when the credential status is served by a host the checker must not call
+	ErrHostNotAllowed = errors.New("credential status host is not allowed")
+)
+
+// Result holds the outcome of a revocation check
+type Result struct {
+	Revoked bool
+	Status  verifiable.RevocationStatus
+}
+
+// Checker checks the revocation status of credentials using the configured resolvers
+type Checker struct {
+	registry *verifiable.CredentialStatusResolverRegistry
+	rhs      *rhsResolver
+	rhsURL   string
+	hosts    map[string]bool
+}
+
+// NewChecker creates a new revocation Checker.
+// rhsURL is the default reverse hash service used when only the issuer DID and the revocation nonce are known.
+// The credential statuses are only fetched from the host of rhsURL and from allowedHosts, as they are set by the callers.
+func NewChecker(settings config.ResolverSettings, rhsURL string, allowedHosts []string, opts ...Option) *Checker {
+	rhs := newRHSResolver(settings)
+	registry := &verifiable.CredentialStatusResolverRegistry{}
+	registry.Register(verifiable.SparseMerkleTreeProof, verifiable.IssuerResolver{})
+	registry.Register(verifiable.Iden3ReverseSparseMerkleTreeProof, rhs)
+	c := &Checker{
+		registry: registry,
+		rhs:      rhs,
+		rhsURL:   rhsURL,
+		hosts:    make(map[string]bool, len(allowedHosts)+1),
+	}
+	if u, err := url.Parse(rhsURL); err == nil && u.Host != "" {
+		c.hosts[strings.ToLower(u.Host)] = true
+	}
+	for _, host := range allowedHosts {
+		c.hosts[strings.ToLower(host)] = true
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Option configures a Checker
+type Option func(*Checker)
+
+// WithRPCs reads the latest issuer states through the RPC urls of the networks, by blockchain:network, sharing their
+// retries and breakers with the state resolutions, instead of dialing the network url of the resolver settings
+func WithRPCs(rpcs map[string]*stateresolver.Resolver) Option {
+	return func(c *Checker) {
+		c.rhs.dial = func(network string, attrs config.ResolverSettingsAttrs) (stateGetter, error) {
+			rpc, ok := rpcs[network]
+			if !ok {
+				return dialStateContract(network, attrs)
+			}
+			return rpcStateGetter{rpc: rpc, address: common.HexToAddress(attrs.ContractAddress)}, nil
+		}
+	}
+}
+
+// Check resolves the credential status issued by issuerDID and validates the returned non-revocation proof.
+func (c *Checker) Check(ctx context.Context, issuerDID *w3c.DID, status verifiable.CredentialStatus) (*Result, error) {
+	if _, err := c.registry.Get(status.Type); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedStatusType, status.Type)
+	}
+	if err := c.checkHost(status.ID); err != nil {
+		return nil, err
+	}
+	if status.StatusIssuer != nil {
+		if err := c.checkHost(status.StatusIssuer.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx = verifiable.WithIssuerDID(ctx, issuerDID)
+	revStatus, err := verifiable.ValidateCredentialStatus(ctx, status, verifiable.WithValidationStatusResolverRegistry(c.registry))
+	if errors.Is(err, verifiable.ErrCredentialIsRevoked) {
+		return &Result{Revoked: true, Status: revStatus}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Revoked: false, Status: revStatus}, nil
+}
+
+// StatusFromNonce builds a reverse hash service credential status for the given revocation nonce.
+func (c *Checker) StatusFromNonce(revocationNonce uint64) (verifiable.CredentialStatus, error) {
+	if c.rhsURL == "" {
+		return verifiable.CredentialStatus{}, errors.New("reverse hash service url is not configured")
+	}
+	return verifiable.CredentialStatus{
+		ID:              c.rhsURL,
+		Type:            verifiable.Iden3ReverseSparseMerkleTreeProof,
+		RevocationNonce: revocationNonce,
+	}, nil
+}
+
+// RevokedSince reports whether issuerDID revoked credentials since state, the issuer state of a non-revocation proof:
+// the revocation tree of state, read from the reverse hash service, is compared with the one of the latest issuer state.
+// The revocation nonces are private inputs of the proofs, so the revoked credentials cannot be told apart.
+func (c *Checker) RevokedSince(ctx context.Context, issuerDID *w3c.DID, state *merkletree.Hash) (bool, error) {
+	if c.rhsURL == "" {
+		return false, errors.New("reverse hash service url is not configured")
+	}
+	network, attrs, err := networkSettings(c.rhs.settings, issuerDID)
+	if err != nil {
+		return false, err
+	}
+	issuerID, err := core.IDFromDID(*issuerDID)
+	if err != nil {
+		return false, err
+	}
+	latest, err := c.rhs.latestState(ctx, network, attrs, issuerID)
+	if err != nil {
+		return false, err
+	}
+	if latest.Equals(state) {
+		return false, nil
+	}
+
+	rhsURL := strings.TrimSuffix(c.rhsURL, "/")
+	proven, err := c.rhs.revocationRoot(ctx, rhsURL, state)
+	if err != nil {
+		return false, err
+	}
+	current, err := c.rhs.revocationRoot(ctx, rhsURL, latest)
+	if err != nil {
+		return false, err
+	}
+	return !proven.Equals(current), nil
+}
+
+// checkHost checks that the url of a credential status is an http(s) url of an allowed host
+func (c *Checker) checkHost(statusURL string) error {
+	u, err := url.Parse(statusURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || !c.hosts[strings.ToLower(u.Host)] {
+		return fmt.Errorf("%w: %s", ErrHostNotAllowed, statusURL)
+	}
+	return nil
+}
+
+// networkSettings returns the network of did, as blockchain:network, and its resolver settings
+func networkSettings(settings config.ResolverSettings, did *w3c.DID) (string, config.ResolverSettingsAttrs, error) {
+	id, err := core.IDFromDID(*did)
+	if err != nil {
+		return "", config.ResolverSettingsAttrs{}, err
+	}
+	blockchain, err := core.BlockchainFromID(id)
+	if err != nil {
+		return "", config.ResolverSettingsAttrs{}, err
+	}
+	network, err := core.NetworkIDFromID(id)
+	if err != nil {
+		return "", config.ResolverSettingsAttrs{}, err
+	}
+
+	attrs, ok := settings[string(blockchain)][string(network)]
+	if !ok {
+		return "", config.ResolverSettingsAttrs{}, fmt.Errorf("resolver not found for %s:%s", blockchain, network)
+	}
+	return fmt.Sprintf("%s:%s", blockchain, network), attrs, nil
+}
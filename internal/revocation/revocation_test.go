@@ -0,0 +1,260 @@
+package revocation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/iden3/contracts-abi/state/go/abi"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+const (
+	issuer       = "did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK"
+	revokedNonce = 42
+)
+
+type fakeState struct {
+	state *big.Int
+	err   error
+	calls int
+}
+
+func (f *fakeState) GetStateInfoById(_ *bind.CallOpts, _ *big.Int) (abi.IStateStateInfo, error) {
+	f.calls++
+	if f.err != nil {
+		return abi.IStateStateInfo{}, f.err
+	}
+	return abi.IStateStateInfo{State: f.state}, nil
+}
+
+// rhsServer serves the nodes of an issuer state whose revocation tree only contains revokedNonce
+func rhsServer(t *testing.T) (*httptest.Server, *merkletree.Hash) {
+	t.Helper()
+	nonce, err := merkletree.NewHashFromBigInt(big.NewInt(revokedNonce))
+	require.NoError(t, err)
+	revRoot, err := merkletree.LeafKey(nonce, &merkletree.HashZero)
+	require.NoError(t, err)
+	claimsRoot, err := merkletree.NewHashFromBigInt(big.NewInt(7))
+	require.NoError(t, err)
+	one, err := merkletree.NewHashFromBigInt(big.NewInt(1))
+	require.NoError(t, err)
+	state, err := merkletree.HashElems(claimsRoot.BigInt(), revRoot.BigInt(), merkletree.HashZero.BigInt())
+	require.NoError(t, err)
+
+	nodes := map[string][]string{
+		state.Hex():   {claimsRoot.Hex(), revRoot.Hex(), merkletree.HashZero.Hex()},
+		revRoot.Hex(): {nonce.Hex(), merkletree.HashZero.Hex(), one.Hex()},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		children, ok := nodes[strings.TrimPrefix(r.URL.Path, "/node/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var resp rhsNodeResponse
+		resp.Node.Children = children
+		resp.Status = "OK"
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, state
+}
+
+func TestCheck(t *testing.T) {
+	srv, state := rhsServer(t)
+	did, err := w3c.ParseDID(issuer)
+	require.NoError(t, err)
+	settings := config.ResolverSettings{"polygon": {"amoy": {NetworkURL: "http://localhost:8545", ContractAddress: "0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124"}}}
+
+	type expected struct {
+		revoked bool
+		err     error
+	}
+	for _, tc := range []struct {
+		name     string
+		status   verifiable.CredentialStatus
+		stateErr error
+		expected expected
+	}{
+		{
+			name:     "host not allowed",
+			status:   verifiable.CredentialStatus{ID: "http://169.254.169.254/latest", Type: verifiable.Iden3ReverseSparseMerkleTreeProof, RevocationNonce: 1},
+			expected: expected{err: ErrHostNotAllowed},
+		},
+		{
+			name:     "status issuer host not allowed",
+			status:   verifiable.CredentialStatus{ID: srv.URL, Type: verifiable.Iden3ReverseSparseMerkleTreeProof, RevocationNonce: 1, StatusIssuer: &verifiable.CredentialStatus{ID: "file:///etc/passwd", Type: verifiable.SparseMerkleTreeProof}},
+			expected: expected{err: ErrHostNotAllowed},
+		},
+		{
+			name:     "unsupported type",
+			status:   verifiable.CredentialStatus{ID: srv.URL, Type: "Unknown", RevocationNonce: 1},
+			expected: expected{err: ErrUnsupportedStatusType},
+		},
+		{
+			name:     "rhs not revoked",
+			status:   verifiable.CredentialStatus{ID: srv.URL, Type: verifiable.Iden3ReverseSparseMerkleTreeProof, RevocationNonce: 1},
+			expected: expected{revoked: false},
+		},
+		{
+			name:     "rhs revoked",
+			status:   verifiable.CredentialStatus{ID: srv.URL + "/", Type: verifiable.Iden3ReverseSparseMerkleTreeProof, RevocationNonce: revokedNonce},
+			expected: expected{revoked: true},
+		},
+		{
+			name:     "identity not on chain without status issuer",
+			status:   verifiable.CredentialStatus{ID: srv.URL, Type: verifiable.Iden3ReverseSparseMerkleTreeProof, RevocationNonce: 1},
+			stateErr: errors.New(identityNotFoundException),
+			expected: expected{err: errors.New(identityNotFoundException)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			getter := &fakeState{state: state.BigInt(), err: tc.stateErr}
+			checker := NewChecker(settings, srv.URL, nil)
+			checker.rhs.dial = func(string, config.ResolverSettingsAttrs) (stateGetter, error) {
+				return getter, nil
+			}
+
+			result, err := checker.Check(context.Background(), did, tc.status)
+			if tc.expected.err != nil {
+				require.Error(t, err)
+				if errors.Is(tc.expected.err, ErrHostNotAllowed) || errors.Is(tc.expected.err, ErrUnsupportedStatusType) {
+					assert.ErrorIs(t, err, tc.expected.err)
+				} else {
+					assert.ErrorContains(t, err, tc.expected.err.Error())
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected.revoked, result.Revoked)
+			assert.Equal(t, state.Hex(), *result.Status.Issuer.State)
+		})
+	}
+}
+
+func TestCheckStatusIssuerFallback(t *testing.T) {
+	rhs, state := rhsServer(t)
+	nonce, err := merkletree.NewHashFromBigInt(big.NewInt(revokedNonce))
+	require.NoError(t, err)
+	revRoot, err := merkletree.LeafKey(nonce, &merkletree.HashZero)
+	require.NoError(t, err)
+	claimsRoot, err := merkletree.NewHashFromBigInt(big.NewInt(7))
+	require.NoError(t, err)
+
+	stateHex, claimsHex, revHex, rootsHex := state.Hex(), claimsRoot.Hex(), revRoot.Hex(), merkletree.HashZero.Hex()
+	proof, err := merkletree.NewProofFromData(false, []*merkletree.Hash{}, &merkletree.NodeAux{Key: nonce, Value: &merkletree.HashZero})
+	require.NoError(t, err)
+	issuerNode := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(verifiable.RevocationStatus{
+			Issuer: verifiable.TreeState{State: &stateHex, ClaimsTreeRoot: &claimsHex, RevocationTreeRoot: &revHex, RootOfRoots: &rootsHex},
+			MTP:    *proof,
+		})
+	}))
+	defer issuerNode.Close()
+	issuerURL, err := url.Parse(issuerNode.URL)
+	require.NoError(t, err)
+
+	did, err := w3c.ParseDID(issuer)
+	require.NoError(t, err)
+	settings := config.ResolverSettings{"polygon": {"amoy": {NetworkURL: "http://localhost:8545", ContractAddress: "0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124"}}}
+	getter := &fakeState{err: errors.New(identityNotFoundException)}
+	checker := NewChecker(settings, rhs.URL, []string{issuerURL.Host})
+	checker.rhs.dial = func(string, config.ResolverSettingsAttrs) (stateGetter, error) {
+		return getter, nil
+	}
+
+	status := verifiable.CredentialStatus{
+		ID:              rhs.URL,
+		Type:            verifiable.Iden3ReverseSparseMerkleTreeProof,
+		RevocationNonce: 1,
+		StatusIssuer:    &verifiable.CredentialStatus{ID: issuerNode.URL + "/v1/credentials/revocation/status/1", Type: verifiable.SparseMerkleTreeProof, RevocationNonce: 1},
+	}
+	result, err := checker.Check(context.Background(), did, status)
+	require.NoError(t, err)
+	assert.False(t, result.Revoked)
+	assert.Equal(t, 1, getter.calls)
+}
+
+func TestStateGetterIsReused(t *testing.T) {
+	dials := 0
+	r := newRHSResolver(nil)
+	r.dial = func(string, config.ResolverSettingsAttrs) (stateGetter, error) {
+		dials++
+		return &fakeState{}, nil
+	}
+	attrs := config.ResolverSettingsAttrs{NetworkURL: "http://localhost:8545", ContractAddress: "0x1"}
+	for i := 0; i < 3; i++ {
+		_, err := r.stateGetter("polygon:amoy", attrs)
+		require.NoError(t, err)
+	}
+	_, err := r.stateGetter("polygon:main", config.ResolverSettingsAttrs{NetworkURL: "http://localhost:8546", ContractAddress: "0x1"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, dials)
+}
+
+func TestRevokedSince(t *testing.T) {
+	hash := func(v int64) *merkletree.Hash {
+		h, err := merkletree.NewHashFromBigInt(big.NewInt(v))
+		require.NoError(t, err)
+		return h
+	}
+	// the proven state and the state issuing a credential share their revocation tree, the state revoking one does not
+	proven, issuing, revoking := hash(100), hash(101), hash(102)
+	nodes := map[string][]string{
+		proven.Hex():   {hash(7).Hex(), hash(8).Hex(), merkletree.HashZero.Hex()},
+		issuing.Hex():  {hash(9).Hex(), hash(8).Hex(), merkletree.HashZero.Hex()},
+		revoking.Hex(): {hash(9).Hex(), hash(10).Hex(), merkletree.HashZero.Hex()},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		children, ok := nodes[strings.TrimPrefix(r.URL.Path, "/node/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var resp rhsNodeResponse
+		resp.Node.Children = children
+		resp.Status = "OK"
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+	did, err := w3c.ParseDID(issuer)
+	require.NoError(t, err)
+	settings := config.ResolverSettings{"polygon": {"amoy": {NetworkURL: "http://localhost:8545", ContractAddress: "0x1a4cC30f2aA0377b0c3bc9848766D90cb4404124"}}}
+
+	for _, tc := range []struct {
+		name    string
+		latest  *merkletree.Hash
+		revoked bool
+	}{
+		{name: "latest state", latest: proven},
+		{name: "state replaced by an issuance", latest: issuing},
+		{name: "state replaced by a revocation", latest: revoking, revoked: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewChecker(settings, srv.URL, nil)
+			checker.rhs.dial = func(string, config.ResolverSettingsAttrs) (stateGetter, error) {
+				return &fakeState{state: tc.latest.BigInt()}, nil
+			}
+			revoked, err := checker.RevokedSince(context.Background(), did, proven)
+			require.NoError(t, err)
+			assert.Equal(t, tc.revoked, revoked)
+		})
+	}
+
+	_, err = NewChecker(settings, "", nil).RevokedSince(context.Background(), did, proven)
+	assert.Error(t, err)
+}
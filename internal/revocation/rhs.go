@@ -0,0 +1,278 @@
+package revocation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	common2 "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/iden3/contracts-abi/state/go/abi"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+	"github.com/0xPolygonID/verifier-backend/internal/stateresolver"
+)
+
+const (
+	identityNotFoundException = "execution reverted: Identity does not exist"
+	rhsMiddleNodeChildren     = 2
+	rhsLeafNodeChildren       = 3
+	rhsStateNodeChildren      = 3
+	rhsTimeout                = 10 * time.Second
+)
+
+type rhsNodeResponse struct {
+	Node struct {
+		Hash     string   `json:"hash"`
+		Children []string `json:"children"`
+	} `json:"node"`
+	Status string `json:"status"`
+}
+
+// stateGetter reads the latest state of an identity from a state contract
+type stateGetter interface {
+	GetStateInfoById(opts *bind.CallOpts, id *big.Int) (abi.IStateStateInfo, error)
+}
+
+// rhsResolver resolves Iden3ReverseSparseMerkleTreeProof credential statuses.
+// The issuer latest state is read from the state contract configured for the issuer network
+// and the non-revocation proof is built by walking the reverse hash service nodes.
+type rhsResolver struct {
+	settings config.ResolverSettings
+	client   *http.Client
+	dial     func(network string, attrs config.ResolverSettingsAttrs) (stateGetter, error)
+
+	mu     sync.Mutex
+	states map[string]stateGetter
+}
+
+func newRHSResolver(settings config.ResolverSettings) *rhsResolver {
+	return &rhsResolver{
+		settings: settings,
+		// redirects could lead the checks to hosts that are not allowed
+		client: &http.Client{
+			Timeout: rhsTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		dial:   dialStateContract,
+		states: make(map[string]stateGetter),
+	}
+}
+
+// Resolve implements verifiable.CredentialStatusResolver
+func (r *rhsResolver) Resolve(ctx context.Context, status verifiable.CredentialStatus) (verifiable.RevocationStatus, error) {
+	issuerDID := verifiable.GetIssuerDID(ctx)
+	if issuerDID == nil {
+		return verifiable.RevocationStatus{}, errors.New("issuer DID is not set")
+	}
+
+	network, attrs, err := networkSettings(r.settings, issuerDID)
+	if err != nil {
+		return verifiable.RevocationStatus{}, err
+	}
+
+	issuerID, err := core.IDFromDID(*issuerDID)
+	if err != nil {
+		return verifiable.RevocationStatus{}, err
+	}
+
+	state, err := r.latestState(ctx, network, attrs, issuerID)
+	if err != nil {
+		if strings.Contains(err.Error(), identityNotFoundException) && status.StatusIssuer != nil {
+			return verifiable.IssuerResolver{}.Resolve(ctx, *status.StatusIssuer)
+		}
+		return verifiable.RevocationStatus{}, err
+	}
+
+	return r.revocationStatus(ctx, strings.TrimSuffix(status.ID, "/"), state, status.RevocationNonce)
+}
+
+func (r *rhsResolver) revocationStatus(ctx context.Context, rhsURL string, state *merkletree.Hash, revocationNonce uint64) (verifiable.RevocationStatus, error) {
+	stateNode, err := r.getNode(ctx, rhsURL, state)
+	if err != nil {
+		return verifiable.RevocationStatus{}, err
+	}
+	if len(stateNode) != rhsStateNodeChildren {
+		return verifiable.RevocationStatus{}, errors.New("invalid state node")
+	}
+
+	revNonce, err := merkletree.NewHashFromBigInt(new(big.Int).SetUint64(revocationNonce))
+	if err != nil {
+		return verifiable.RevocationStatus{}, err
+	}
+
+	proof, err := r.generateProof(ctx, rhsURL, stateNode[1], revNonce)
+	if err != nil {
+		return verifiable.RevocationStatus{}, err
+	}
+
+	stateHex := state.Hex()
+	claimsRoot := stateNode[0].Hex()
+	revocationRoot := stateNode[1].Hex()
+	rootOfRoots := stateNode[2].Hex()
+	return verifiable.RevocationStatus{
+		Issuer: verifiable.TreeState{
+			State:              &stateHex,
+			ClaimsTreeRoot:     &claimsRoot,
+			RevocationTreeRoot: &revocationRoot,
+			RootOfRoots:        &rootOfRoots,
+		},
+		MTP: *proof,
+	}, nil
+}
+
+// revocationRoot returns the revocation tree root of an issuer state
+func (r *rhsResolver) revocationRoot(ctx context.Context, rhsURL string, state *merkletree.Hash) (*merkletree.Hash, error) {
+	stateNode, err := r.getNode(ctx, rhsURL, state)
+	if err != nil {
+		return nil, err
+	}
+	if len(stateNode) != rhsStateNodeChildren {
+		return nil, errors.New("invalid state node")
+	}
+	return stateNode[1], nil
+}
+
+func (r *rhsResolver) generateProof(ctx context.Context, rhsURL string, root, key *merkletree.Hash) (*merkletree.Proof, error) {
+	var (
+		siblings []*merkletree.Hash
+		nodeAux  *merkletree.NodeAux
+	)
+
+	next := root
+	for depth := uint(0); depth < uint(len(key)*8); depth++ {
+		if next.Equals(&merkletree.HashZero) {
+			return merkletree.NewProofFromData(false, siblings, nodeAux)
+		}
+
+		children, err := r.getNode(ctx, rhsURL, next)
+		if err != nil {
+			return nil, err
+		}
+
+		switch len(children) {
+		case rhsLeafNodeChildren:
+			if children[0].Equals(key) {
+				return merkletree.NewProofFromData(true, siblings, nodeAux)
+			}
+			nodeAux = &merkletree.NodeAux{Key: children[0], Value: children[1]}
+			return merkletree.NewProofFromData(false, siblings, nodeAux)
+		case rhsMiddleNodeChildren:
+			if merkletree.TestBit(key[:], depth) {
+				next = children[1]
+				siblings = append(siblings, children[0])
+			} else {
+				next = children[0]
+				siblings = append(siblings, children[1])
+			}
+		default:
+			return nil, fmt.Errorf("invalid node %s", next.Hex())
+		}
+	}
+
+	return nil, errors.New("tree depth is too high")
+}
+
+func (r *rhsResolver) getNode(ctx context.Context, rhsURL string, hash *merkletree.Hash) ([]*merkletree.Hash, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/node/%s", rhsURL, hash.Hex()), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code from reverse hash service: %d", resp.StatusCode)
+	}
+
+	var nodeResp rhsNodeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&nodeResp); err != nil {
+		return nil, err
+	}
+
+	children := make([]*merkletree.Hash, 0, len(nodeResp.Node.Children))
+	for _, child := range nodeResp.Node.Children {
+		h, err := merkletree.NewHashFromHex(child)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, h)
+	}
+	return children, nil
+}
+
+// latestState reads the latest state of id from the state contract of its network. The clients of the contracts
+// are created once per network and reused by the next checks.
+func (r *rhsResolver) latestState(ctx context.Context, network string, attrs config.ResolverSettingsAttrs, id core.ID) (*merkletree.Hash, error) {
+	getter, err := r.stateGetter(network, attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := getter.GetStateInfoById(&bind.CallOpts{Context: ctx}, id.BigInt())
+	if err != nil {
+		return nil, err
+	}
+
+	return merkletree.NewHashFromBigInt(info.State)
+}
+
+func (r *rhsResolver) stateGetter(network string, attrs config.ResolverSettingsAttrs) (stateGetter, error) {
+	key := attrs.NetworkURL + "|" + attrs.ContractAddress
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if getter, ok := r.states[key]; ok {
+		return getter, nil
+	}
+	getter, err := r.dial(network, attrs)
+	if err != nil {
+		return nil, err
+	}
+	r.states[key] = getter
+	return getter, nil
+}
+
+func dialStateContract(_ string, attrs config.ResolverSettingsAttrs) (stateGetter, error) {
+	client, err := ethclient.Dial(attrs.NetworkURL)
+	if err != nil {
+		return nil, err
+	}
+	return abi.NewStateCaller(common2.HexToAddress(attrs.ContractAddress), client)
+}
+
+// rpcStateGetter reads the state contract through the RPC urls of its network
+type rpcStateGetter struct {
+	rpc     *stateresolver.Resolver
+	address common2.Address
+}
+
+func (g rpcStateGetter) GetStateInfoById(opts *bind.CallOpts, id *big.Int) (abi.IStateStateInfo, error) {
+	var info abi.IStateStateInfo
+	err := g.rpc.Call(opts.Context, func(ctx context.Context, client stateresolver.Client) error {
+		caller, err := abi.NewStateCaller(g.address, client)
+		if err != nil {
+			return err
+		}
+		info, err = caller.GetStateInfoById(&bind.CallOpts{Context: ctx}, id)
+		return err
+	})
+	return info, err
+}
@@ -0,0 +1,134 @@
+package sessions
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// fileRecord is a line of a FileLedger
+type fileRecord struct {
+	SessionID string `json:"sessionID"`
+	Record
+}
+
+// FileLedger is a Ledger that persists the records in an append-only file, one json record per line, so the expired
+// sessions are still reported as expired after a restart. The file is replayed in memory when the ledger is opened,
+// and rewritten without the reaped records by Reap.
+type FileLedger struct {
+	*MemoryLedger
+
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// OpenFileLedger opens the FileLedger at path, creating the file if it does not exist
+func OpenFileLedger(path string) (*FileLedger, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	mem := NewMemoryLedger()
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record fileRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("invalid record at line %d of %s: %w", line, path, err)
+		}
+		mem.records[record.SessionID] = record.Record
+	}
+	if err := scanner.Err(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &FileLedger{MemoryLedger: mem, path: path, file: f}, nil
+}
+
+// Add appends the record of a session to the file before storing it in memory
+func (l *FileLedger) Add(ctx context.Context, sessionID string, record Record) error {
+	b, err := json.Marshal(fileRecord{SessionID: sessionID, Record: record})
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("failed to persist session record: %w", err)
+	}
+	return l.MemoryLedger.Add(ctx, sessionID, record)
+}
+
+// Reap removes the records of the sessions that expired before cutoff, and rewrites the file with the other records
+func (l *FileLedger) Reap(ctx context.Context, cutoff time.Time) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	reaped, err := l.MemoryLedger.Reap(ctx, cutoff)
+	if err != nil || reaped == 0 {
+		return reaped, err
+	}
+	if err := l.rewrite(); err != nil {
+		return reaped, fmt.Errorf("failed to compact session records: %w", err)
+	}
+	return reaped, nil
+}
+
+// Close closes the file of the ledger
+func (l *FileLedger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// rewrite replaces the file with the records in memory, through a temporary file so a crash leaves one of them whole
+func (l *FileLedger) rewrite() error {
+	tmp, err := os.OpenFile(l.path+".tmp", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(tmp)
+	l.MemoryLedger.mu.RLock()
+	for sessionID, record := range l.MemoryLedger.records {
+		b, err := json.Marshal(fileRecord{SessionID: sessionID, Record: record})
+		if err != nil {
+			l.MemoryLedger.mu.RUnlock()
+			_ = tmp.Close()
+			return err
+		}
+		_, _ = w.Write(append(b, '\n'))
+	}
+	l.MemoryLedger.mu.RUnlock()
+	if err := w.Flush(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(l.path+".tmp", l.path); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(l.path, os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	_ = l.file.Close()
+	l.file = f
+	return nil
+}
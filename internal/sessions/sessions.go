@@ -0,0 +1,90 @@
+// Package sessions keeps when the sessions were created and when they expire after they are removed from the cache of
+// the verifier, so the status of a session that expired can be told apart from the status of an unknown session
+package sessions
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Record is the lifetime of a session, with the metadata of the integrator echoed back with it
+type Record struct {
+	CreatedAt time.Time         `json:"createdAt"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// Ledger stores the records of the sessions
+type Ledger interface {
+	// Add stores the record of a session
+	Add(ctx context.Context, sessionID string, record Record) error
+	// Get returns the record of a session
+	Get(ctx context.Context, sessionID string) (Record, bool, error)
+	// Reap removes the records of the sessions that expired before cutoff, and returns how many were removed
+	Reap(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+// MemoryLedger is a Ledger that keeps the records in memory
+type MemoryLedger struct {
+	mu      sync.RWMutex
+	records map[string]Record
+}
+
+// NewMemoryLedger creates a new MemoryLedger
+func NewMemoryLedger() *MemoryLedger {
+	return &MemoryLedger{records: make(map[string]Record)}
+}
+
+// Add stores the record of a session
+func (m *MemoryLedger) Add(_ context.Context, sessionID string, record Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records[sessionID] = record
+	return nil
+}
+
+// Get returns the record of a session
+func (m *MemoryLedger) Get(_ context.Context, sessionID string) (Record, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	record, ok := m.records[sessionID]
+	return record, ok, nil
+}
+
+// Reap removes the records of the sessions that expired before cutoff
+func (m *MemoryLedger) Reap(_ context.Context, cutoff time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	reaped := 0
+	for sessionID, record := range m.records {
+		if record.ExpiresAt.Before(cutoff) {
+			delete(m.records, sessionID)
+			reaped++
+		}
+	}
+	return reaped, nil
+}
+
+// RunReaper removes every interval the records of the sessions that expired more than retention ago, until ctx is done
+func RunReaper(ctx context.Context, ledger Ledger, retention, interval time.Duration, logger *log.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		reaped, err := ledger.Reap(ctx, time.Now().Add(-retention))
+		if err != nil {
+			logger.WithField("err", err).Error("failed to reap the expired sessions")
+			continue
+		}
+		if reaped > 0 {
+			logger.WithField("sessions", reaped).Debug("reaped expired sessions")
+		}
+	}
+}
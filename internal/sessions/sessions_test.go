@@ -0,0 +1,77 @@
+package sessions
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLedger(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 16, 10, 0, 0, 0, time.UTC)
+	ledger := NewMemoryLedger()
+	require.NoError(t, ledger.Add(ctx, "old", Record{CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(-time.Hour)}))
+	require.NoError(t, ledger.Add(ctx, "new", Record{CreatedAt: now, ExpiresAt: now.Add(time.Hour)}))
+
+	record, ok, err := ledger.Get(ctx, "new")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(time.Hour), record.ExpiresAt)
+
+	reaped, err := ledger.Reap(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reaped)
+	_, ok, _ = ledger.Get(ctx, "old")
+	assert.False(t, ok)
+	_, ok, _ = ledger.Get(ctx, "new")
+	assert.True(t, ok)
+}
+
+func TestFileLedger(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 16, 10, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "sessions.jsonl")
+
+	ledger, err := OpenFileLedger(path)
+	require.NoError(t, err)
+	require.NoError(t, ledger.Add(ctx, "old", Record{CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(-time.Hour)}))
+	require.NoError(t, ledger.Add(ctx, "new", Record{CreatedAt: now, ExpiresAt: now.Add(time.Hour)}))
+	require.NoError(t, ledger.Close())
+
+	ledger, err = OpenFileLedger(path)
+	require.NoError(t, err)
+	record, ok, err := ledger.Get(ctx, "old")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(-time.Hour), record.ExpiresAt)
+
+	reaped, err := ledger.Reap(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reaped)
+	require.NoError(t, ledger.Add(ctx, "newer", Record{CreatedAt: now, ExpiresAt: now.Add(2 * time.Hour)}))
+	require.NoError(t, ledger.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 2)
+
+	ledger, err = OpenFileLedger(path)
+	require.NoError(t, err)
+	defer ledger.Close()
+	_, ok, _ = ledger.Get(ctx, "old")
+	assert.False(t, ok)
+	_, ok, _ = ledger.Get(ctx, "new")
+	assert.True(t, ok)
+	_, ok, _ = ledger.Get(ctx, "newer")
+	assert.True(t, ok)
+
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o600))
+	_, err = OpenFileLedger(path)
+	assert.ErrorContains(t, err, "line 1")
+}
@@ -0,0 +1,133 @@
+package shadow
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	auth "github.com/iden3/go-iden3-auth/v2"
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	"github.com/iden3/iden3comm/v2/protocol"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	verifyTimeout          = time.Minute
+	maxRecentDisagreements = 100
+)
+
+// Disagreement is a callback where the primary and the shadow verifier returned a different result
+type Disagreement struct {
+	SessionID  string
+	Timestamp  time.Time
+	PrimaryErr string
+	ShadowErr  string
+}
+
+// Report holds the result of the comparisons since the verifier was created.
+// Dropped counts the callbacks not compared because all the workers were busy.
+type Report struct {
+	Total               int
+	Agreements          int
+	Disagreements       int
+	Dropped             int
+	RecentDisagreements []Disagreement
+}
+
+// fullVerifier verifies the callbacks, it is implemented by *auth.Verifier
+type fullVerifier interface {
+	FullVerify(ctx context.Context, token string, request protocol.AuthorizationRequestMessage, opts ...pubsignals.VerifyOpt) (*protocol.AuthorizationResponseMessage, error)
+}
+
+// Verifier runs the callbacks through a second verifier configuration (e.g. new circuit keys)
+// and compares its result with the result of the primary verifier.
+type Verifier struct {
+	verifier fullVerifier
+	workers  chan struct{}
+
+	mu     sync.Mutex
+	report Report
+}
+
+// NewVerifier creates a new shadow Verifier that runs at most maxConcurrent comparisons at a time
+func NewVerifier(verifier *auth.Verifier, maxConcurrent int) *Verifier {
+	return newVerifier(verifier, maxConcurrent)
+}
+
+func newVerifier(verifier fullVerifier, maxConcurrent int) *Verifier {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	return &Verifier{verifier: verifier, workers: make(chan struct{}, maxConcurrent)}
+}
+
+// Submit compares the callback in the background when a worker is free, and drops it otherwise, so a burst of
+// callbacks never piles up goroutines and proofs in memory. It returns false when the callback is dropped.
+func (v *Verifier) Submit(sessionID, token string, request protocol.AuthorizationRequestMessage, primaryErr error, opts ...pubsignals.VerifyOpt) bool {
+	select {
+	case v.workers <- struct{}{}:
+	default:
+		v.mu.Lock()
+		v.report.Dropped++
+		v.mu.Unlock()
+		return false
+	}
+	go func() {
+		defer func() { <-v.workers }()
+		v.Compare(sessionID, token, request, primaryErr, opts...)
+	}()
+	return true
+}
+
+// Compare verifies the token with the shadow verifier and records if the result matches the primary result.
+// The callbacks use Submit, so the comparison never affects their response.
+func (v *Verifier) Compare(sessionID, token string, request protocol.AuthorizationRequestMessage, primaryErr error, opts ...pubsignals.VerifyOpt) {
+	ctx, cancel := context.WithTimeout(context.Background(), verifyTimeout)
+	defer cancel()
+
+	_, shadowErr := v.verifier.FullVerify(ctx, token, request, opts...)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.report.Total++
+	if (primaryErr == nil) == (shadowErr == nil) {
+		v.report.Agreements++
+		return
+	}
+
+	d := Disagreement{
+		SessionID:  sessionID,
+		Timestamp:  time.Now().UTC(),
+		PrimaryErr: errString(primaryErr),
+		ShadowErr:  errString(shadowErr),
+	}
+	log.WithFields(log.Fields{
+		"sessionID":  sessionID,
+		"primaryErr": d.PrimaryErr,
+		"shadowErr":  d.ShadowErr,
+	}).Warn("shadow verification disagreement")
+
+	v.report.Disagreements++
+	v.report.RecentDisagreements = append(v.report.RecentDisagreements, d)
+	if len(v.report.RecentDisagreements) > maxRecentDisagreements {
+		v.report.RecentDisagreements = v.report.RecentDisagreements[1:]
+	}
+}
+
+// Report returns a copy of the comparison report
+func (v *Verifier) Report() Report {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	r := v.report
+	r.RecentDisagreements = append([]Disagreement{}, v.report.RecentDisagreements...)
+	return r
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,61 @@
+package shadow
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeVerifier struct {
+	release chan struct{}
+	err     error
+}
+
+func (f *fakeVerifier) FullVerify(_ context.Context, _ string, _ protocol.AuthorizationRequestMessage, _ ...pubsignals.VerifyOpt) (*protocol.AuthorizationResponseMessage, error) {
+	<-f.release
+	return nil, f.err
+}
+
+func TestCompare(t *testing.T) {
+	fake := &fakeVerifier{release: make(chan struct{}), err: errors.New("proof is not valid")}
+	close(fake.release)
+	v := newVerifier(fake, 1)
+
+	v.Compare("8f1c4b4e-7c0c-4d4e-9d59-6e0b2d1f6a11", "token", protocol.AuthorizationRequestMessage{}, errors.New("proof is not valid"))
+	v.Compare("2d0b5c61-3f4e-4d8b-a1c2-7e9f0a1b2c3d", "token", protocol.AuthorizationRequestMessage{}, nil)
+
+	report := v.Report()
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, 1, report.Agreements)
+	assert.Equal(t, 1, report.Disagreements)
+	require.Len(t, report.RecentDisagreements, 1)
+	assert.Equal(t, "2d0b5c61-3f4e-4d8b-a1c2-7e9f0a1b2c3d", report.RecentDisagreements[0].SessionID)
+	assert.Empty(t, report.RecentDisagreements[0].PrimaryErr)
+	assert.Equal(t, "proof is not valid", report.RecentDisagreements[0].ShadowErr)
+}
+
+func TestSubmitDropsWhenSaturated(t *testing.T) {
+	fake := &fakeVerifier{release: make(chan struct{})}
+	v := newVerifier(fake, 2)
+
+	assert.True(t, v.Submit("s1", "token", protocol.AuthorizationRequestMessage{}, nil))
+	assert.True(t, v.Submit("s2", "token", protocol.AuthorizationRequestMessage{}, nil))
+	assert.False(t, v.Submit("s3", "token", protocol.AuthorizationRequestMessage{}, nil))
+	assert.Equal(t, 1, v.Report().Dropped)
+
+	close(fake.release)
+	require.Eventually(t, func() bool { return v.Report().Total == 2 }, time.Second, 10*time.Millisecond)
+
+	// the workers are free again once the comparisons are done
+	require.Eventually(t, func() bool {
+		return v.Submit("s4", "token", protocol.AuthorizationRequestMessage{}, nil)
+	}, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return v.Report().Total == 3 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, Report{Total: 3, Agreements: 3, Dropped: 1, RecentDisagreements: []Disagreement{}}, v.Report())
+}
@@ -0,0 +1,60 @@
+package shortener
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const requestTimeout = 5 * time.Second
+
+// Shortener shortens links with an HTTP service. The service receives a POST with the body {"url": "<link>"}
+// and replies with the body {"url": "<short link>"}.
+type Shortener struct {
+	endpoint string
+	client   *http.Client
+}
+
+// New creates a new Shortener for the service at endpoint
+func New(endpoint string) *Shortener {
+	return &Shortener{endpoint: endpoint, client: &http.Client{Timeout: requestTimeout}}
+}
+
+type shortenBody struct {
+	URL string `json:"url"`
+}
+
+// Shorten returns the short link of link
+func (s *Shortener) Shorten(ctx context.Context, link string) (string, error) {
+	body, err := json.Marshal(shortenBody{URL: link})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return "", fmt.Errorf("shortener replied with status %d", resp.StatusCode)
+	}
+
+	var short shortenBody
+	if err := json.NewDecoder(resp.Body).Decode(&short); err != nil {
+		return "", err
+	}
+	if short.URL == "" {
+		return "", errors.New("shortener replied without url")
+	}
+	return short.URL, nil
+}
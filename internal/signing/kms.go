@@ -0,0 +1,165 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/0xPolygonID/verifier-backend/internal/awssig"
+)
+
+const (
+	kmsTimeout         = 10 * time.Second
+	kmsMaxResponseSize = 1 << 20
+)
+
+// KMSClient calls the GetPublicKey and Sign actions of AWS KMS
+type KMSClient struct {
+	Region      string
+	Endpoint    string
+	Credentials awssig.Provider
+	Client      *http.Client
+}
+
+// NewKMSClient creates a KMSClient with the credentials of the environment, see awssig.DefaultProvider.
+// The endpoint of the region is used when endpoint is empty.
+func NewKMSClient(region, endpoint string) *KMSClient {
+	if endpoint == "" {
+		endpoint = "https://kms." + region + ".amazonaws.com"
+	}
+	return &KMSClient{
+		Region:      region,
+		Endpoint:    strings.TrimSuffix(endpoint, "/"),
+		Credentials: awssig.DefaultProvider(),
+		Client:      &http.Client{Timeout: kmsTimeout},
+	}
+}
+
+// NewKMSKey creates a Key whose private key is held in AWS KMS. Its public key is read once from KMS.
+func NewKMSKey(ctx context.Context, client *KMSClient, keyID string) (*Key, error) {
+	der, err := client.PublicKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	public, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key of kms key %s: %w", keyID, err)
+	}
+	return NewKey(&kmsSigner{client: client, keyID: keyID, public: public})
+}
+
+// kmsSigner is a crypto.Signer that signs the digests with a KMS key
+type kmsSigner struct {
+	client *KMSClient
+	keyID  string
+	public crypto.PublicKey
+}
+
+func (s *kmsSigner) Public() crypto.PublicKey {
+	return s.public
+}
+
+func (s *kmsSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	algorithm, err := kmsSigningAlgorithm(s.public, opts.HashFunc())
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Sign(context.Background(), s.keyID, digest, algorithm)
+}
+
+// PublicKey returns the DER encoded SubjectPublicKeyInfo of a KMS key
+func (c *KMSClient) PublicKey(ctx context.Context, keyID string) ([]byte, error) {
+	var resp struct {
+		PublicKey []byte `json:"PublicKey"`
+	}
+	if err := c.call(ctx, "GetPublicKey", map[string]string{"KeyId": keyID}, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get the public key of kms key %s: %w", keyID, err)
+	}
+	return resp.PublicKey, nil
+}
+
+// Sign signs a digest with a KMS key and returns the signature, ASN.1 DER encoded for the ECDSA algorithms
+func (c *KMSClient) Sign(ctx context.Context, keyID string, digest []byte, algorithm string) ([]byte, error) {
+	var resp struct {
+		Signature []byte `json:"Signature"`
+	}
+	err := c.call(ctx, "Sign", map[string]any{
+		"KeyId":            keyID,
+		"Message":          digest,
+		"MessageType":      "DIGEST",
+		"SigningAlgorithm": algorithm,
+	}, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with kms key %s: %w", keyID, err)
+	}
+	return resp.Signature, nil
+}
+
+func kmsSigningAlgorithm(public crypto.PublicKey, hash crypto.Hash) (string, error) {
+	switch key := public.(type) {
+	case *ecdsa.PublicKey:
+		switch {
+		case key.Curve == elliptic.P256() && hash == crypto.SHA256:
+			return "ECDSA_SHA_256", nil
+		case key.Curve == elliptic.P384() && hash == crypto.SHA384:
+			return "ECDSA_SHA_384", nil
+		case key.Curve == elliptic.P521() && hash == crypto.SHA512:
+			return "ECDSA_SHA_512", nil
+		}
+	case *rsa.PublicKey:
+		switch hash {
+		case crypto.SHA256:
+			return "RSASSA_PKCS1_V1_5_SHA_256", nil
+		case crypto.SHA384:
+			return "RSASSA_PKCS1_V1_5_SHA_384", nil
+		case crypto.SHA512:
+			return "RSASSA_PKCS1_V1_5_SHA_512", nil
+		}
+	}
+	return "", fmt.Errorf("unsupported kms key %T with hash %s", public, hash)
+}
+
+// call posts an action of the KMS JSON API
+func (c *KMSClient) call(ctx context.Context, action string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+	creds, err := c.Credentials.Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+	awssig.Sign(req, body, creds, "kms", c.Region, time.Now())
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, kmsMaxResponseSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, data)
+	}
+	return json.Unmarshal(data, out)
+}
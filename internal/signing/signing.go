@@ -0,0 +1,319 @@
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/cryptosigner"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+// ErrKeyNotFound is returned when there is no signing key for a tenant
+var ErrKeyNotFound = errors.New("signing key not found")
+
+// Key is a signing key identified by the thumbprint of its public key
+type Key struct {
+	opaque    jose.OpaqueSigner
+	public    jose.JSONWebKey
+	algorithm jose.SignatureAlgorithm
+}
+
+// NewKey creates a Key from a crypto.Signer.
+// The private key does not need to be in memory, so signers backed by a KMS or an HSM can be used.
+func NewKey(signer crypto.Signer) (*Key, error) {
+	algorithm, err := signatureAlgorithm(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	public := jose.JSONWebKey{Key: signer.Public(), Algorithm: string(algorithm), Use: "sig"}
+	thumbprint, err := public.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	public.KeyID = base64.RawURLEncoding.EncodeToString(thumbprint)
+
+	return &Key{opaque: cryptosigner.Opaque(signer), public: public, algorithm: algorithm}, nil
+}
+
+// LoadKeyFile creates a Key from a PEM encoded PKCS8, EC or PKCS1 private key file
+func LoadKeyFile(path string) (*Key, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	return ParseKey(content, path)
+}
+
+// ParseKey creates a Key from a PEM encoded PKCS8, EC or PKCS1 private key. The source names the key in the errors.
+func ParseKey(content []byte, source string) (*Key, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", source)
+	}
+
+	var (
+		privateKey any
+		err        error
+	)
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		privateKey, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key in %s: %w", source, err)
+	}
+
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key in %s", source)
+	}
+	return NewKey(signer)
+}
+
+// KeyID returns the id of the key, used in the kid header of the signatures and in the JWKS
+func (k *Key) KeyID() string {
+	return k.public.KeyID
+}
+
+// Algorithm returns the signature algorithm of the key
+func (k *Key) Algorithm() jose.SignatureAlgorithm {
+	return k.algorithm
+}
+
+// Public returns the public key as a JWK
+func (k *Key) Public() jose.JSONWebKey {
+	return k.public
+}
+
+// Signer returns a JWS signer that sets the kid header
+func (k *Key) Signer(opts *jose.SignerOptions) (jose.Signer, error) {
+	return jose.NewSigner(jose.SigningKey{Algorithm: k.algorithm, Key: publicKeyIDSigner{OpaqueSigner: k.opaque, public: &k.public}}, opts)
+}
+
+// KeyRing holds the signing keys of the verifier and of the tenants, and the retired keys of the verifier
+type KeyRing struct {
+	mu         sync.RWMutex
+	defaultKey *Key
+	retired    []*Key
+	tenants    map[string]*Key
+}
+
+// NewKeyRing creates a KeyRing from the configuration, with the keys of the PEM files, of the environment, of AWS KMS
+// and of Vault. Tenants without signing key use the default key of the verifier.
+func NewKeyRing(cfg config.Config) (*KeyRing, error) {
+	kmsKey := kmsKeys(cfg.SigningKMS)
+	key, previous, err := loadDefaultKey(cfg, kmsKey)
+	if err != nil {
+		return nil, err
+	}
+	ring := &KeyRing{defaultKey: key, retired: previous, tenants: make(map[string]*Key)}
+
+	for _, path := range cfg.SigningPreviousKeyPaths {
+		key, err := LoadKeyFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load previous signing key: %w", err)
+		}
+		ring.retired = append(ring.retired, key)
+	}
+
+	for _, tenant := range cfg.Tenants {
+		var (
+			key *Key
+			err error
+		)
+		switch {
+		case tenant.KMSKeyID != "":
+			key, err = kmsKey(tenant.KMSKeyID)
+		case tenant.SigningKeyPath != "":
+			key, err = LoadKeyFile(tenant.SigningKeyPath)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load signing key of tenant %s: %w", tenant.ID, err)
+		}
+		ring.tenants[tenant.ID] = key
+	}
+	return ring, nil
+}
+
+// kmsKeys returns a function that creates the Keys of KMS keys, with a client created on first use
+func kmsKeys(cfg config.SigningKMS) func(keyID string) (*Key, error) {
+	var client *KMSClient
+	return func(keyID string) (*Key, error) {
+		if client == nil {
+			client = NewKMSClient(cfg.Region, cfg.Endpoint)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), kmsTimeout)
+		defer cancel()
+		return NewKMSKey(ctx, client, keyID)
+	}
+}
+
+// loadDefaultKey loads the default key of the verifier from KMS, Vault, the environment or its PEM file, in this order.
+// The previous versions of a Vault key are returned with it. The key is nil when no source is configured.
+func loadDefaultKey(cfg config.Config, kmsKey func(keyID string) (*Key, error)) (*Key, []*Key, error) {
+	switch {
+	case cfg.SigningKMS.KeyID != "":
+		key, err := kmsKey(cfg.SigningKMS.KeyID)
+		return key, nil, err
+	case cfg.SigningVault.KeyName != "":
+		ctx, cancel := context.WithTimeout(context.Background(), vaultTimeout)
+		defer cancel()
+		vault := NewVaultClient(cfg.SigningVault.Address, cfg.SigningVault.Token, cfg.SigningVault.Mount)
+		return LoadVaultKeys(ctx, vault, cfg.SigningVault.KeyName)
+	case cfg.SigningKey != "":
+		key, err := ParseKey([]byte(cfg.SigningKey), "the signing key of the environment")
+		return key, nil, err
+	case cfg.SigningKeyPath != "":
+		key, err := LoadKeyFile(cfg.SigningKeyPath)
+		return key, nil, err
+	}
+	return nil, nil, nil
+}
+
+// SetTenantKey sets the signing key of a tenant, e.g. a key backed by an HSM
+func (r *KeyRing) SetTenantKey(tenantID string, key *Key) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tenants[tenantID] = key
+}
+
+// Key returns the signing key of a tenant, or the default key when the tenant is empty or has no own key.
+// Tokens signed with the default key for a tenant must carry the tenant, so they cannot be mistaken for the tokens of another one.
+func (r *KeyRing) Key(tenantID string) (*Key, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if key, ok := r.tenants[tenantID]; ok {
+		return key, nil
+	}
+	if r.defaultKey == nil {
+		return nil, ErrKeyNotFound
+	}
+	return r.defaultKey, nil
+}
+
+// Rotate makes key the default key of the verifier. The previous default key is retired: it no longer signs, but it is
+// still published with the default key until the tokens it signed expire and it is removed from the configuration.
+func (r *KeyRing) Rotate(key *Key) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rotate(key)
+}
+
+// Reload loads the default key of the verifier again from its source and rotates to it when it changed, e.g. when a
+// new version of the Vault key was created, the KMS alias points to another key or the PEM file was replaced.
+// It returns whether the default key was rotated.
+func (r *KeyRing) Reload(cfg config.Config) (bool, error) {
+	key, previous, err := loadDefaultKey(cfg, kmsKeys(cfg.SigningKMS))
+	if err != nil || key == nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.defaultKey != nil && r.defaultKey.KeyID() == key.KeyID() {
+		return false, nil
+	}
+	r.rotate(key)
+	for _, retired := range previous {
+		if !r.isRetired(retired) {
+			r.retired = append(r.retired, retired)
+		}
+	}
+	return true, nil
+}
+
+// Keys returns the default key of the verifier, nil when there is none, and its retired keys
+func (r *KeyRing) Keys() (*Key, []*Key) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.defaultKey, append([]*Key(nil), r.retired...)
+}
+
+func (r *KeyRing) rotate(key *Key) {
+	if r.defaultKey != nil && !r.isRetired(r.defaultKey) {
+		r.retired = append([]*Key{r.defaultKey}, r.retired...)
+	}
+	r.defaultKey = key
+}
+
+func (r *KeyRing) isRetired(key *Key) bool {
+	for _, retired := range r.retired {
+		if retired.KeyID() == key.KeyID() {
+			return true
+		}
+	}
+	return false
+}
+
+// JWKS returns the public key set used to verify the signatures made on behalf of a tenant, with the retired keys of
+// the verifier when the tenant uses the default key
+func (r *KeyRing) JWKS(tenantID string) (jose.JSONWebKeySet, error) {
+	key, err := r.Key(tenantID)
+	if err != nil {
+		return jose.JSONWebKeySet{}, err
+	}
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{key.Public()}}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if key == r.defaultKey {
+		for _, retired := range r.retired {
+			if retired.KeyID() != key.KeyID() {
+				jwks.Keys = append(jwks.Keys, retired.Public())
+			}
+		}
+	}
+	return jwks, nil
+}
+
+// publicKeyIDSigner overrides the public key of an opaque signer so the kid header is set
+type publicKeyIDSigner struct {
+	jose.OpaqueSigner
+	public *jose.JSONWebKey
+}
+
+func (s publicKeyIDSigner) Public() *jose.JSONWebKey {
+	return s.public
+}
+
+func signatureAlgorithm(public crypto.PublicKey) (jose.SignatureAlgorithm, error) {
+	switch key := public.(type) {
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return jose.ES256, nil
+		case elliptic.P384():
+			return jose.ES384, nil
+		case elliptic.P521():
+			return jose.ES512, nil
+		}
+		return "", fmt.Errorf("unsupported curve %s", key.Curve.Params().Name)
+	case *rsa.PublicKey:
+		return jose.RS256, nil
+	case ed25519.PublicKey:
+		return jose.EdDSA, nil
+	default:
+		return "", fmt.Errorf("unsupported key type %T", public)
+	}
+}
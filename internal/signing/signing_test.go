@@ -0,0 +1,322 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+
+	"github.com/0xPolygonID/verifier-backend/internal/config"
+)
+
+func writeKeyFile(t *testing.T, dir, name string) *ecdsa.PrivateKey {
+	t.Helper()
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600))
+	return privateKey
+}
+
+// fakeKMS answers the GetPublicKey and Sign actions of AWS KMS with an in-memory key
+func fakeKMS(t *testing.T, keyID string) (*httptest.Server, *ecdsa.PrivateKey) {
+	t.Helper()
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var in struct {
+			KeyID            string `json:"KeyId"`
+			Message          []byte
+			MessageType      string
+			SigningAlgorithm string
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		if in.KeyID != keyID {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string][]byte{"PublicKey": der})
+		case "TrentService.Sign":
+			assert.Equal(t, "DIGEST", in.MessageType)
+			assert.Equal(t, "ECDSA_SHA_256", in.SigningAlgorithm)
+			signature, err := ecdsa.SignASN1(rand.Reader, privateKey, in.Message)
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string][]byte{"Signature": signature})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, privateKey
+}
+
+// fakeVault answers the keys and sign endpoints of the transit engine of Vault with in-memory key versions
+func fakeVault(t *testing.T, name string, versions int) (*httptest.Server, []*ecdsa.PrivateKey) {
+	t.Helper()
+	privateKeys := make([]*ecdsa.PrivateKey, versions)
+	for i := range privateKeys {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		privateKeys[i] = privateKey
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/transit/keys/"+name:
+			keys := make(map[string]map[string]string)
+			for i, privateKey := range privateKeys {
+				der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+				require.NoError(t, err)
+				keys[strconv.Itoa(i+1)] = map[string]string{"public_key": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"type": "ecdsa-p256", "latest_version": versions, "keys": keys}})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/transit/sign/"+name+"/sha2-256":
+			var in struct {
+				Input      []byte `json:"input"`
+				KeyVersion int    `json:"key_version"`
+				Prehashed  bool   `json:"prehashed"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			assert.True(t, in.Prehashed)
+			signature, err := ecdsa.SignASN1(rand.Reader, privateKeys[in.KeyVersion-1], in.Input)
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{
+				"signature": fmt.Sprintf("vault:v%d:%s", in.KeyVersion, base64.StdEncoding.EncodeToString(signature)),
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, privateKeys
+}
+
+func TestNewKeyRing(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	dir := t.TempDir()
+	defaultKey := writeKeyFile(t, dir, "default.pem")
+	acmeKey := writeKeyFile(t, dir, "acme.pem")
+	kms, kmsKey := fakeKMS(t, "alias/globex")
+
+	ring, err := NewKeyRing(config.Config{
+		SigningKeyPath: filepath.Join(dir, "default.pem"),
+		SigningKMS:     config.SigningKMS{Region: "eu-west-1", Endpoint: kms.URL},
+		Tenants: []config.Tenant{
+			{ID: "acme", SigningKeyPath: filepath.Join(dir, "acme.pem")},
+			{ID: "globex", KMSKeyID: "alias/globex"},
+			{ID: "initech"},
+		},
+	})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		tenant   string
+		expected crypto.PublicKey
+	}{
+		{tenant: "", expected: &defaultKey.PublicKey},
+		{tenant: "acme", expected: &acmeKey.PublicKey},
+		{tenant: "globex", expected: &kmsKey.PublicKey},
+		{tenant: "initech", expected: &defaultKey.PublicKey},
+	} {
+		t.Run(tc.tenant, func(t *testing.T) {
+			key, err := ring.Key(tc.tenant)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, key.Public().Key)
+
+			signer, err := key.Signer(nil)
+			require.NoError(t, err)
+			token, err := jwt.Signed(signer).Claims(jwt.Claims{Subject: "did:example:user"}).CompactSerialize()
+			require.NoError(t, err)
+			parsed, err := jwt.ParseSigned(token)
+			require.NoError(t, err)
+			var claims jwt.Claims
+			require.NoError(t, parsed.Claims(tc.expected, &claims))
+			assert.Equal(t, "did:example:user", claims.Subject)
+		})
+	}
+
+	jwks, err := ring.JWKS("globex")
+	require.NoError(t, err)
+	assert.Equal(t, []jose.JSONWebKey{mustKey(t, ring, "globex").Public()}, jwks.Keys)
+}
+
+func TestNewKeyRingErrors(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "other")
+	kms, _ := fakeKMS(t, "alias/globex")
+
+	_, err := NewKeyRing(config.Config{SigningKMS: config.SigningKMS{KeyID: "alias/globex", Region: "eu-west-1", Endpoint: kms.URL}})
+	assert.ErrorContains(t, err, "403")
+
+	_, err = NewKeyRing(config.Config{Tenants: []config.Tenant{{ID: "acme", SigningKeyPath: filepath.Join(t.TempDir(), "missing.pem")}}})
+	assert.ErrorContains(t, err, "tenant acme")
+
+	ring, err := NewKeyRing(config.Config{})
+	require.NoError(t, err)
+	_, err = ring.Key("acme")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestSetTenantKey(t *testing.T) {
+	ring, err := NewKeyRing(config.Config{})
+	require.NoError(t, err)
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key, err := NewKey(privateKey)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			ring.SetTenantKey("acme", key)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = ring.Key("acme")
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, key, mustKey(t, ring, "acme"))
+}
+
+func mustKey(t *testing.T, ring *KeyRing, tenant string) *Key {
+	t.Helper()
+	key, err := ring.Key(tenant)
+	require.NoError(t, err)
+	return key
+}
+
+func TestNewKeyRingVault(t *testing.T) {
+	vault, privateKeys := fakeVault(t, "verifier", 2)
+
+	ring, err := NewKeyRing(config.Config{SigningVault: config.SigningVault{Address: vault.URL, Token: "token", Mount: "transit", KeyName: "verifier"}})
+	require.NoError(t, err)
+	key := mustKey(t, ring, "")
+	assert.Equal(t, &privateKeys[1].PublicKey, key.Public().Key)
+
+	signer, err := key.Signer(nil)
+	require.NoError(t, err)
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{Subject: "did:example:user"}).CompactSerialize()
+	require.NoError(t, err)
+	parsed, err := jwt.ParseSigned(token)
+	require.NoError(t, err)
+	var claims jwt.Claims
+	require.NoError(t, parsed.Claims(&privateKeys[1].PublicKey, &claims))
+	assert.Equal(t, "did:example:user", claims.Subject)
+
+	// the previous version is still published
+	jwks, err := ring.JWKS("")
+	require.NoError(t, err)
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, &privateKeys[0].PublicKey, jwks.Keys[1].Key)
+
+	_, err = NewKeyRing(config.Config{SigningVault: config.SigningVault{Address: vault.URL, Token: "other", Mount: "transit", KeyName: "verifier"}})
+	assert.ErrorContains(t, err, "403")
+}
+
+func TestRotate(t *testing.T) {
+	dir := t.TempDir()
+	previousKey := writeKeyFile(t, dir, "previous.pem")
+	currentKey := writeKeyFile(t, dir, "current.pem")
+	der, err := x509.MarshalECPrivateKey(currentKey)
+	require.NoError(t, err)
+
+	// the current key from the environment, the previous key still published
+	ring, err := NewKeyRing(config.Config{
+		SigningKey:              string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})),
+		SigningPreviousKeyPaths: []string{filepath.Join(dir, "previous.pem")},
+		Tenants:                 []config.Tenant{{ID: "acme", SigningKeyPath: filepath.Join(dir, "previous.pem")}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &currentKey.PublicKey, mustKey(t, ring, "").Public().Key)
+	jwks, err := ring.JWKS("")
+	require.NoError(t, err)
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, &previousKey.PublicKey, jwks.Keys[1].Key)
+	jwks, err = ring.JWKS("acme")
+	require.NoError(t, err)
+	assert.Len(t, jwks.Keys, 1)
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	next, err := NewKey(privateKey)
+	require.NoError(t, err)
+	ring.Rotate(next)
+	assert.Equal(t, next, mustKey(t, ring, ""))
+	jwks, err = ring.JWKS("")
+	require.NoError(t, err)
+	require.Len(t, jwks.Keys, 3)
+	assert.Equal(t, &currentKey.PublicKey, jwks.Keys[1].Key)
+
+	_, err = NewKeyRing(config.Config{SigningKey: "not a key"})
+	assert.ErrorContains(t, err, "environment")
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	currentKey := writeKeyFile(t, dir, "current.pem")
+	cfg := config.Config{SigningKeyPath: filepath.Join(dir, "current.pem")}
+	ring, err := NewKeyRing(cfg)
+	require.NoError(t, err)
+
+	// the key file did not change
+	rotated, err := ring.Reload(cfg)
+	require.NoError(t, err)
+	assert.False(t, rotated)
+
+	// the key file is replaced, the previous key is retired
+	nextKey := writeKeyFile(t, dir, "current.pem")
+	rotated, err = ring.Reload(cfg)
+	require.NoError(t, err)
+	assert.True(t, rotated)
+	assert.Equal(t, &nextKey.PublicKey, mustKey(t, ring, "").Public().Key)
+	current, retired := ring.Keys()
+	assert.Equal(t, &nextKey.PublicKey, current.Public().Key)
+	require.Len(t, retired, 1)
+	assert.Equal(t, &currentKey.PublicKey, retired[0].Public().Key)
+
+	// a key that cannot be loaded keeps the default key
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "current.pem"), []byte("not a key"), 0o600))
+	_, err = ring.Reload(cfg)
+	assert.Error(t, err)
+	assert.Equal(t, &nextKey.PublicKey, mustKey(t, ring, "").Public().Key)
+
+	// without a configured key there is nothing to reload
+	ring, err = NewKeyRing(config.Config{})
+	require.NoError(t, err)
+	rotated, err = ring.Reload(config.Config{})
+	require.NoError(t, err)
+	assert.False(t, rotated)
+}
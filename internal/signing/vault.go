@@ -0,0 +1,231 @@
+package signing
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	vaultTimeout         = 10 * time.Second
+	vaultMaxResponseSize = 1 << 20
+	vaultSignaturePrefix = "vault:v"
+)
+
+// VaultClient calls the keys and sign endpoints of the transit secrets engine of HashiCorp Vault
+type VaultClient struct {
+	Address string
+	Token   string
+	Mount   string
+	Client  *http.Client
+}
+
+// NewVaultClient creates a VaultClient for the transit engine mounted at mount
+func NewVaultClient(address, token, mount string) *VaultClient {
+	return &VaultClient{
+		Address: strings.TrimSuffix(address, "/"),
+		Token:   token,
+		Mount:   strings.Trim(mount, "/"),
+		Client:  &http.Client{Timeout: vaultTimeout},
+	}
+}
+
+// LoadVaultKeys creates the Keys of the versions of a transit key. The latest version is the current key, the previous
+// versions still held by Vault are returned newest first, so the tokens they signed before a rotation can be verified.
+// Every Key signs with its own version.
+func LoadVaultKeys(ctx context.Context, client *VaultClient, name string) (*Key, []*Key, error) {
+	var resp struct {
+		Data struct {
+			Type          string `json:"type"`
+			LatestVersion int    `json:"latest_version"`
+			Keys          map[string]struct {
+				PublicKey string `json:"public_key"`
+			} `json:"keys"`
+		} `json:"data"`
+	}
+	if err := client.call(ctx, http.MethodGet, "keys/"+url.PathEscape(name), nil, &resp); err != nil {
+		return nil, nil, fmt.Errorf("failed to get the public keys of vault key %s: %w", name, err)
+	}
+
+	versions := make([]int, 0, len(resp.Data.Keys))
+	for v := range resp.Data.Keys {
+		version, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid version %s of vault key %s", v, name)
+		}
+		versions = append(versions, version)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+	var (
+		current  *Key
+		previous []*Key
+	)
+	for _, version := range versions {
+		public, err := vaultPublicKey(resp.Data.Type, resp.Data.Keys[strconv.Itoa(version)].PublicKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid public key of version %d of vault key %s: %w", version, name, err)
+		}
+		key, err := NewKey(&vaultSigner{client: client, name: name, version: version, public: public})
+		if err != nil {
+			return nil, nil, err
+		}
+		if version == resp.Data.LatestVersion {
+			current = key
+		} else {
+			previous = append(previous, key)
+		}
+	}
+	if current == nil {
+		return nil, nil, fmt.Errorf("latest version %d of vault key %s not found", resp.Data.LatestVersion, name)
+	}
+	return current, previous, nil
+}
+
+// ReadSecret returns the fields of the latest version of a secret of the KV version 2 engine mounted at mount
+func (c *VaultClient) ReadSecret(ctx context.Context, mount, path string) (map[string]string, error) {
+	var resp struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	kv := *c
+	kv.Mount = strings.Trim(mount, "/")
+	if err := kv.call(ctx, http.MethodGet, "data/"+strings.Trim(path, "/"), nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to read vault secret %s: %w", path, err)
+	}
+	return resp.Data.Data, nil
+}
+
+// vaultPublicKey parses the public key of a version of a transit key, PEM encoded but for the ed25519 keys
+func vaultPublicKey(keyType, public string) (crypto.PublicKey, error) {
+	if keyType == "ed25519" {
+		raw, err := base64.StdEncoding.DecodeString(public)
+		if err != nil {
+			return nil, err
+		}
+		if len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid ed25519 public key size %d", len(raw))
+		}
+		return ed25519.PublicKey(raw), nil
+	}
+	block, _ := pem.Decode([]byte(public))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in the public key of type %s", keyType)
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// vaultSigner is a crypto.Signer that signs with a version of a transit key
+type vaultSigner struct {
+	client  *VaultClient
+	name    string
+	version int
+	public  crypto.PublicKey
+}
+
+func (s *vaultSigner) Public() crypto.PublicKey {
+	return s.public
+}
+
+func (s *vaultSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	in := map[string]any{
+		"input":       base64.StdEncoding.EncodeToString(digest),
+		"key_version": s.version,
+	}
+	path := "sign/" + url.PathEscape(s.name)
+	switch s.public.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		algorithm, err := vaultHashAlgorithm(opts.HashFunc())
+		if err != nil {
+			return nil, err
+		}
+		path += "/" + algorithm
+		in["prehashed"] = true
+		in["marshaling_algorithm"] = "asn1"
+		if _, ok := s.public.(*rsa.PublicKey); ok {
+			in["signature_algorithm"] = "pkcs1v15"
+		}
+	case ed25519.PublicKey:
+	default:
+		return nil, fmt.Errorf("unsupported vault key %T", s.public)
+	}
+
+	var resp struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}
+	if err := s.client.call(context.Background(), http.MethodPost, path, in, &resp); err != nil {
+		return nil, fmt.Errorf("failed to sign with vault key %s: %w", s.name, err)
+	}
+	// the signatures are prefixed with vault:v<version>:
+	signature := resp.Data.Signature
+	if !strings.HasPrefix(signature, vaultSignaturePrefix) || strings.Count(signature, ":") != 2 {
+		return nil, fmt.Errorf("invalid signature of vault key %s", s.name)
+	}
+	return base64.StdEncoding.DecodeString(signature[strings.LastIndex(signature, ":")+1:])
+}
+
+func vaultHashAlgorithm(hash crypto.Hash) (string, error) {
+	switch hash {
+	case crypto.SHA256:
+		return "sha2-256", nil
+	case crypto.SHA384:
+		return "sha2-384", nil
+	case crypto.SHA512:
+		return "sha2-512", nil
+	}
+	return "", fmt.Errorf("unsupported vault hash %s", hash)
+}
+
+// call sends a request to an endpoint of the transit engine
+func (c *VaultClient) call(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.Address+"/v1/"+c.Mount+"/"+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", c.Token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, vaultMaxResponseSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, data)
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,259 @@
+// Package sli computes rolling service level indicators of the verifications: the success rate,
+// the p95 verification latency and the ratio of failed RPC calls.
+package sli
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	bucketWidth = time.Minute
+	// numBuckets covers the longest window
+	numBuckets        = 60
+	numLatencyBuckets = 10
+)
+
+// Window is a period over which the indicators are computed
+type Window struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Windows are the periods of the indicators, short enough for alerting and long enough for burn rates
+var Windows = []Window{{Name: "5m", Duration: 5 * time.Minute}, {Name: "1h", Duration: time.Hour}}
+
+// LatencyBuckets are the upper bounds, in seconds, of the verification latency histogram
+var LatencyBuckets = [numLatencyBuckets]float64{0.25, 0.5, 1, 2, 3, 5, 10, 20, 30, 60}
+
+// counts are the events observed during a bucket of time, or since the tracker started
+type counts struct {
+	verifications int
+	failures      int
+	rpcCalls      int
+	rpcErrors     int
+	latencySum    float64
+	// latencies is the histogram of the verification latencies, its last element is the +Inf bucket
+	latencies [numLatencyBuckets + 1]int
+}
+
+func (c *counts) add(o counts) {
+	c.verifications += o.verifications
+	c.failures += o.failures
+	c.rpcCalls += o.rpcCalls
+	c.rpcErrors += o.rpcErrors
+	c.latencySum += o.latencySum
+	for i := range c.latencies {
+		c.latencies[i] += o.latencies[i]
+	}
+}
+
+type bucket struct {
+	minute int64
+	counts
+}
+
+// Indicators are the SLIs of a window
+type Indicators struct {
+	Window        Window
+	Verifications int
+	Failures      int
+	RPCCalls      int
+	RPCErrors     int
+	// P95Latency is estimated from the latency histogram, like histogram_quantile does
+	P95Latency time.Duration
+}
+
+// SuccessRate returns the ratio of successful verifications, false when there were no verifications
+func (i Indicators) SuccessRate() (float64, bool) {
+	if i.Verifications == 0 {
+		return 0, false
+	}
+	return float64(i.Verifications-i.Failures) / float64(i.Verifications), true
+}
+
+// RPCErrorRatio returns the ratio of failed RPC calls, false when there were no calls
+func (i Indicators) RPCErrorRatio() (float64, bool) {
+	if i.RPCCalls == 0 {
+		return 0, false
+	}
+	return float64(i.RPCErrors) / float64(i.RPCCalls), true
+}
+
+// Tracker keeps per minute counts of the last hour, and the totals since it was created
+type Tracker struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	buckets [numBuckets]bucket
+	totals  counts
+}
+
+// NewTracker creates a new Tracker
+func NewTracker() *Tracker {
+	return &Tracker{now: time.Now}
+}
+
+// ObserveVerification records the outcome and the latency of a verification, and the RPC calls it made
+func (t *Tracker) ObserveVerification(success bool, latency time.Duration, rpcCalls, rpcErrors int) {
+	c := counts{verifications: 1, rpcCalls: rpcCalls, rpcErrors: rpcErrors, latencySum: latency.Seconds()}
+	if !success {
+		c.failures = 1
+	}
+	c.latencies[latencyBucket(latency)] = 1
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	b := t.bucket()
+	b.add(c)
+	t.totals.add(c)
+}
+
+// Indicators returns the indicators of the window
+func (t *Tracker) Indicators(w Window) Indicators {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var c counts
+	current := t.minute()
+	first := current - int64(w.Duration/bucketWidth) + 1
+	for i := range t.buckets {
+		if t.buckets[i].minute >= first && t.buckets[i].minute <= current {
+			c.add(t.buckets[i].counts)
+		}
+	}
+	return Indicators{
+		Window:        w,
+		Verifications: c.verifications,
+		Failures:      c.failures,
+		RPCCalls:      c.rpcCalls,
+		RPCErrors:     c.rpcErrors,
+		P95Latency:    quantile(0.95, c.latencies),
+	}
+}
+
+// WritePrometheus writes the totals and the indicators of every window in the Prometheus text format.
+// The totals are counters and histograms for recording rules, the indicators are gauges for the dashboards.
+func (t *Tracker) WritePrometheus(w io.Writer) error {
+	t.mu.Lock()
+	totals := t.totals
+	t.mu.Unlock()
+
+	p := &printer{w: w}
+	p.printf("# HELP verifier_verifications_total Verifications of callbacks by result.\n")
+	p.printf("# TYPE verifier_verifications_total counter\n")
+	p.printf("verifier_verifications_total{result=\"success\"} %d\n", totals.verifications-totals.failures)
+	p.printf("verifier_verifications_total{result=\"failure\"} %d\n", totals.failures)
+
+	p.printf("# HELP verifier_verification_duration_seconds Latency of the verifications of callbacks.\n")
+	p.printf("# TYPE verifier_verification_duration_seconds histogram\n")
+	cumulative := 0
+	for i, le := range LatencyBuckets {
+		cumulative += totals.latencies[i]
+		p.printf("verifier_verification_duration_seconds_bucket{le=\"%g\"} %d\n", le, cumulative)
+	}
+	p.printf("verifier_verification_duration_seconds_bucket{le=\"+Inf\"} %d\n", totals.verifications)
+	p.printf("verifier_verification_duration_seconds_sum %g\n", totals.latencySum)
+	p.printf("verifier_verification_duration_seconds_count %d\n", totals.verifications)
+
+	p.printf("# HELP verifier_rpc_calls_total RPC calls made to resolve states by result.\n")
+	p.printf("# TYPE verifier_rpc_calls_total counter\n")
+	p.printf("verifier_rpc_calls_total{result=\"success\"} %d\n", totals.rpcCalls-totals.rpcErrors)
+	p.printf("verifier_rpc_calls_total{result=\"error\"} %d\n", totals.rpcErrors)
+
+	indicators := make([]Indicators, 0, len(Windows))
+	for _, window := range Windows {
+		indicators = append(indicators, t.Indicators(window))
+	}
+	p.printf("# HELP verifier_sli_success_ratio Ratio of successful verifications over the window.\n")
+	p.printf("# TYPE verifier_sli_success_ratio gauge\n")
+	for _, i := range indicators {
+		if rate, ok := i.SuccessRate(); ok {
+			p.printf("verifier_sli_success_ratio{window=%q} %g\n", i.Window.Name, rate)
+		}
+	}
+	p.printf("# HELP verifier_sli_verification_latency_p95_seconds 95th percentile of the verification latency over the window.\n")
+	p.printf("# TYPE verifier_sli_verification_latency_p95_seconds gauge\n")
+	for _, i := range indicators {
+		if i.Verifications > 0 {
+			p.printf("verifier_sli_verification_latency_p95_seconds{window=%q} %g\n", i.Window.Name, i.P95Latency.Seconds())
+		}
+	}
+	p.printf("# HELP verifier_sli_rpc_error_ratio Ratio of failed RPC calls over the window.\n")
+	p.printf("# TYPE verifier_sli_rpc_error_ratio gauge\n")
+	for _, i := range indicators {
+		if ratio, ok := i.RPCErrorRatio(); ok {
+			p.printf("verifier_sli_rpc_error_ratio{window=%q} %g\n", i.Window.Name, ratio)
+		}
+	}
+	return p.err
+}
+
+// bucket returns the bucket of the current minute, resetting it if it holds an older minute
+func (t *Tracker) bucket() *bucket {
+	minute := t.minute()
+	b := &t.buckets[minute%numBuckets]
+	if b.minute != minute {
+		*b = bucket{minute: minute}
+	}
+	return b
+}
+
+func (t *Tracker) minute() int64 {
+	return t.now().Unix() / int64(bucketWidth/time.Second)
+}
+
+func latencyBucket(latency time.Duration) int {
+	for i, le := range LatencyBuckets {
+		if latency.Seconds() <= le {
+			return i
+		}
+	}
+	return len(LatencyBuckets)
+}
+
+// quantile estimates the q quantile of the histogram interpolating linearly within its buckets.
+// Observations in the +Inf bucket are reported as the highest finite bound.
+func quantile(q float64, histogram [numLatencyBuckets + 1]int) time.Duration {
+	total := 0
+	for _, n := range histogram {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+
+	rank := q * float64(total)
+	cumulative := 0
+	for i, n := range histogram {
+		if float64(cumulative+n) < rank || n == 0 {
+			cumulative += n
+			continue
+		}
+		if i == len(LatencyBuckets) {
+			break
+		}
+		lower := 0.0
+		if i > 0 {
+			lower = LatencyBuckets[i-1]
+		}
+		seconds := lower + (LatencyBuckets[i]-lower)*(rank-float64(cumulative))/float64(n)
+		return time.Duration(math.Round(seconds * float64(time.Second)))
+	}
+	return time.Duration(LatencyBuckets[len(LatencyBuckets)-1] * float64(time.Second))
+}
+
+// printer keeps the first write error, so the exposition can be written without checking every line
+type printer struct {
+	w   io.Writer
+	err error
+}
+
+func (p *printer) printf(format string, args ...any) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.w, format, args...)
+}
@@ -0,0 +1,56 @@
+package sli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	tracker := NewTracker()
+	tracker.now = func() time.Time { return now }
+
+	// an hour ago, only in the 1h window
+	now = now.Add(-50 * time.Minute)
+	tracker.ObserveVerification(false, 40*time.Second, 2, 2)
+	now = now.Add(50 * time.Minute)
+
+	for i := 0; i < 18; i++ {
+		tracker.ObserveVerification(true, 800*time.Millisecond, 2, 0)
+	}
+	tracker.ObserveVerification(false, 4*time.Second, 2, 1)
+	tracker.ObserveVerification(true, 1500*time.Millisecond, 0, 0)
+
+	short := tracker.Indicators(Windows[0])
+	assert.Equal(t, 20, short.Verifications)
+	rate, ok := short.SuccessRate()
+	require.True(t, ok)
+	assert.InDelta(t, 0.95, rate, 1e-9)
+	ratio, ok := short.RPCErrorRatio()
+	require.True(t, ok)
+	assert.InDelta(t, 1.0/38, ratio, 1e-9)
+	// the 19th observation is at the upper bound of the (1s, 2s] bucket
+	assert.Equal(t, 2*time.Second, short.P95Latency)
+
+	long := tracker.Indicators(Windows[1])
+	assert.Equal(t, 21, long.Verifications)
+	assert.Equal(t, 2, long.Failures)
+	assert.Equal(t, 3, long.RPCErrors)
+
+	// buckets older than the window are ignored
+	now = now.Add(2 * time.Hour)
+	_, ok = tracker.Indicators(Windows[1]).SuccessRate()
+	assert.False(t, ok)
+
+	var out strings.Builder
+	require.NoError(t, tracker.WritePrometheus(&out))
+	assert.Contains(t, out.String(), `verifier_verifications_total{result="failure"} 2`)
+	assert.Contains(t, out.String(), `verifier_verification_duration_seconds_bucket{le="1"} 18`)
+	assert.Contains(t, out.String(), `verifier_verification_duration_seconds_bucket{le="+Inf"} 21`)
+	assert.Contains(t, out.String(), `verifier_rpc_calls_total{result="error"} 3`)
+	assert.NotContains(t, out.String(), `verifier_sli_success_ratio{`)
+}
@@ -0,0 +1,134 @@
+package stateresolver
+
+import (
+	"sort"
+	"time"
+
+	"github.com/iden3/contracts-abi/state/go/abi"
+	log "github.com/sirupsen/logrus"
+)
+
+// Orders the RPC urls of a network are tried in
+const (
+	// SelectionFailover tries the urls in the configured order
+	SelectionFailover = "failover"
+	// SelectionLatency tries the urls with the lowest average latency first, and the urls not used yet before them
+	SelectionLatency = "latency"
+)
+
+// latencyWeight is the weight of the last call in the moving average of the latency of a url
+const latencyWeight = 0.2
+
+// endpoint is an RPC url of the network with its circuit breaker
+type endpoint struct {
+	url      string
+	client   Client
+	contract Contract
+	// failures is the number of consecutive failures of the url, it is skipped until openUntil once they reach the
+	// threshold of the breaker
+	failures  int
+	openUntil time.Time
+	latency   time.Duration
+}
+
+// selection returns the endpoints in the order they are tried at now: the endpoints whose breaker is closed, or
+// half-open once its cooldown is over, in the order of the selection, then the open ones, the soonest to close
+// first, so the calls are still attempted when every url is failing
+func (r *Resolver) selection(now time.Time) []*endpoint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	available := make([]*endpoint, 0, len(r.endpoints))
+	var open []*endpoint
+	for _, e := range r.endpoints {
+		if now.Before(e.openUntil) {
+			open = append(open, e)
+			continue
+		}
+		available = append(available, e)
+	}
+	if r.opts.Selection == SelectionLatency {
+		sort.SliceStable(available, func(i, j int) bool {
+			return available[i].latency < available[j].latency
+		})
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		return open[i].openUntil.Before(open[j].openUntil)
+	})
+	return append(available, open...)
+}
+
+// contract returns the caller of the state contract of the endpoint, created on its first use and reused by the
+// following calls
+func (r *Resolver) contract(e *endpoint) (Contract, error) {
+	if r.opts.Dial != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if e.contract == nil {
+			contract, err := r.opts.Dial(e.url, r.address)
+			if err != nil {
+				return nil, err
+			}
+			e.contract = contract
+		}
+		return e.contract, nil
+	}
+	client, err := r.client(e)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e.contract == nil {
+		contract, err := abi.NewStateCaller(r.address, client)
+		if err != nil {
+			return nil, err
+		}
+		e.contract = contract
+	}
+	return e.contract, nil
+}
+
+// client returns the RPC client of the endpoint, dialed on its first use and reused by the following calls
+func (r *Resolver) client(e *endpoint) (Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e.client != nil {
+		return e.client, nil
+	}
+	client, err := r.opts.DialClient(e.url)
+	if err != nil {
+		return nil, err
+	}
+	e.client = client
+	return client, nil
+}
+
+// succeeded closes the breaker of the endpoint and records the latency of its call
+func (r *Resolver) succeeded(e *endpoint, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.opts.BreakerThreshold > 0 && e.failures >= r.opts.BreakerThreshold {
+		log.WithField("host", host(e.url)).Info("RPC url available again")
+	}
+	e.failures = 0
+	e.openUntil = time.Time{}
+	if e.latency == 0 {
+		e.latency = latency
+		return
+	}
+	e.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(e.latency))
+}
+
+// failed counts a failure of the endpoint at now, and opens its breaker once the failures reach the threshold. A
+// half-open endpoint that fails again is opened again.
+func (r *Resolver) failed(e *endpoint, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e.failures++
+	if r.opts.BreakerThreshold <= 0 || e.failures < r.opts.BreakerThreshold {
+		return
+	}
+	e.openUntil = now.Add(r.opts.BreakerCooldown)
+	log.WithFields(log.Fields{"host": host(e.url), "failures": e.failures, "until": e.openUntil}).
+		Warn("RPC url unavailable, skipping it")
+}
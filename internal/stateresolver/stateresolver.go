@@ -0,0 +1,323 @@
+// Package stateresolver resolves the identity states and global roots of a state contract with long-lived RPC
+// clients. The resolved states are cached, so the same issuer states are not resolved again on every verification,
+// and the failed calls are retried on the other RPC urls of the network, skipping the urls that keep failing.
+package stateresolver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/iden3/contracts-abi/state/go/abi"
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	"github.com/iden3/go-iden3-auth/v2/state"
+	"github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
+)
+
+// JSON-RPC errors of the providers that are worth retrying
+const (
+	rpcInternalError = -32603
+	rpcLimitExceeded = -32005
+)
+
+// Contract is the state contract of a network, read through a bound caller
+type Contract interface {
+	state.StateGetter
+	state.GISTGetter
+	GetStateInfoById(opts *bind.CallOpts, id *big.Int) (abi.IStateStateInfo, error)
+}
+
+// Client is the RPC client of an url of a network
+type Client interface {
+	bind.ContractCaller
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// Dialer creates the caller of the state contract at address through the RPC url
+type Dialer func(rpcURL string, address common.Address) (Contract, error)
+
+// DialETH dials the RPC url with an ethclient, whose HTTP connections are kept alive and reused by the calls
+func DialETH(rpcURL string) (Client, error) {
+	return ethclient.Dial(rpcURL)
+}
+
+// dialError is the failure to dial an RPC url, the call is retried on the next url
+type dialError struct {
+	err error
+}
+
+func (e dialError) Error() string {
+	return e.err.Error()
+}
+
+func (e dialError) Unwrap() error {
+	return e.err
+}
+
+// Options configure the cache and the retries of a Resolver
+type Options struct {
+	// CacheTTL is how long the latest states are cached, 0 disables the cache
+	CacheTTL time.Duration
+	// ReplacedCacheTTL is how long the replaced states are cached, they never change
+	ReplacedCacheTTL time.Duration
+	// Retries is the number of times a failed call is retried, on the next RPC url in turn
+	Retries int
+	// Selection is the order the RPC urls are tried in, SelectionFailover by default
+	Selection string
+	// BreakerThreshold is the number of consecutive failures of an RPC url after which it is skipped for
+	// BreakerCooldown, 0 never skips the urls
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// Backoff is the delay before the first retry, doubled on each retry with a random jitter
+	Backoff time.Duration
+	// Timeout bounds each call, 0 for no timeout
+	Timeout time.Duration
+	// DialClient creates the clients of the RPC urls, DialETH by default
+	DialClient func(rpcURL string) (Client, error)
+	// Dial creates the callers of the state contract, bound to the clients of the RPC urls by default
+	Dial Dialer
+}
+
+// Resolver resolves the states of a state contract through the RPC urls of its network
+type Resolver struct {
+	address common.Address
+	opts    Options
+	cache   *cache.Cache
+	group   singleflight.Group
+
+	mu        sync.Mutex
+	endpoints []*endpoint
+}
+
+// New creates a Resolver of the state contract through urls
+func New(urls []string, contract string, opts Options) *Resolver {
+	if opts.DialClient == nil {
+		opts.DialClient = DialETH
+	}
+	r := &Resolver{
+		address:   common.HexToAddress(contract),
+		opts:      opts,
+		cache:     cache.New(opts.CacheTTL, max(opts.CacheTTL, opts.ReplacedCacheTTL)),
+		endpoints: make([]*endpoint, 0, len(urls)),
+	}
+	for _, u := range urls {
+		r.endpoints = append(r.endpoints, &endpoint{url: u})
+	}
+	return r
+}
+
+// Resolve resolves the state of the identity id
+func (r *Resolver) Resolve(ctx context.Context, id, st *big.Int) (*state.ResolvedState, error) {
+	return r.resolve(ctx, "state:"+id.String()+":"+st.String(), func(ctx context.Context, c Contract) (*state.ResolvedState, error) {
+		return state.Resolve(ctx, c, id, st)
+	})
+}
+
+// ResolveGlobalRoot resolves the global root st
+func (r *Resolver) ResolveGlobalRoot(ctx context.Context, st *big.Int) (*state.ResolvedState, error) {
+	return r.resolve(ctx, "root:"+st.String(), func(ctx context.Context, c Contract) (*state.ResolvedState, error) {
+		return state.ResolveGlobalRoot(ctx, c, st)
+	})
+}
+
+// LatestState returns the latest state of the identity id published on the state contract, it fails for the identities
+// that never published a state. The latest states are not cached.
+func (r *Resolver) LatestState(ctx context.Context, id *big.Int) (*big.Int, error) {
+	var latest *big.Int
+	err := r.do(ctx, func(ctx context.Context, e *endpoint) error {
+		contract, err := r.contract(e)
+		if err != nil {
+			return dialError{err}
+		}
+		info, err := contract.GetStateInfoById(&bind.CallOpts{Context: ctx}, id)
+		if err != nil {
+			return err
+		}
+		latest = info.State
+		return nil
+	})
+	return latest, err
+}
+
+// Uncached returns a resolver of the same RPC urls that does not read nor fill the cache, e.g. for the syncs of
+// the states kept elsewhere, which must read the latest states of the contract
+func (r *Resolver) Uncached() pubsignals.StateResolver {
+	return uncached{r: r}
+}
+
+type uncached struct {
+	r *Resolver
+}
+
+func (u uncached) Resolve(ctx context.Context, id, st *big.Int) (*state.ResolvedState, error) {
+	return u.r.call(ctx, func(ctx context.Context, c Contract) (*state.ResolvedState, error) {
+		return state.Resolve(ctx, c, id, st)
+	})
+}
+
+func (u uncached) ResolveGlobalRoot(ctx context.Context, st *big.Int) (*state.ResolvedState, error) {
+	return u.r.call(ctx, func(ctx context.Context, c Contract) (*state.ResolvedState, error) {
+		return state.ResolveGlobalRoot(ctx, c, st)
+	})
+}
+
+// resolve returns the cached state of key, or resolves it once for the concurrent lookups of key
+func (r *Resolver) resolve(ctx context.Context, key string,
+	call func(context.Context, Contract) (*state.ResolvedState, error),
+) (*state.ResolvedState, error) {
+	if cached, ok := r.cache.Get(key); ok {
+		resolved := cached.(state.ResolvedState)
+		return &resolved, nil
+	}
+	lookup := r.group.DoChan(key, func() (any, error) {
+		// the lookup is shared by the concurrent callers, so it does not stop with the context of the first one,
+		// and each of its attempts is bounded by the timeout instead
+		resolved, err := r.call(context.WithoutCancel(ctx), call)
+		if err != nil {
+			return nil, err
+		}
+		ttl := r.opts.CacheTTL
+		if !resolved.Latest {
+			ttl = r.opts.ReplacedCacheTTL
+		}
+		if ttl > 0 {
+			r.cache.Set(key, *resolved, ttl)
+		}
+		return *resolved, nil
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-lookup:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		resolved := result.Val.(state.ResolvedState)
+		return &resolved, nil
+	}
+}
+
+// call resolves a state with the caller of the state contract of an RPC url
+func (r *Resolver) call(ctx context.Context, call func(context.Context, Contract) (*state.ResolvedState, error)) (*state.ResolvedState, error) {
+	var resolved *state.ResolvedState
+	err := r.do(ctx, func(ctx context.Context, e *endpoint) error {
+		contract, err := r.contract(e)
+		if err != nil {
+			return dialError{err}
+		}
+		resolved, err = call(ctx, contract)
+		return err
+	})
+	return resolved, err
+}
+
+// Call runs call with the client of an RPC url of the network, with the selection, the retries and the breakers of
+// the state resolutions, so the other reads of the network share its urls
+func (r *Resolver) Call(ctx context.Context, call func(context.Context, Client) error) error {
+	return r.do(ctx, func(ctx context.Context, e *endpoint) error {
+		client, err := r.client(e)
+		if err != nil {
+			return dialError{err}
+		}
+		return call(ctx, client)
+	})
+}
+
+// do runs the call on the RPC urls in the order of the selection until it succeeds, fails with an error that is
+// not worth retrying or runs out of retries
+func (r *Resolver) do(ctx context.Context, call func(context.Context, *endpoint) error) error {
+	endpoints := r.selection(time.Now())
+	if len(endpoints) == 0 {
+		return errors.New("no RPC url")
+	}
+	var err error
+	for attempt := 0; attempt <= r.opts.Retries; attempt++ {
+		if attempt > 0 {
+			if err := r.wait(ctx, attempt); err != nil {
+				return err
+			}
+		}
+		e := endpoints[attempt%len(endpoints)]
+		start := time.Now()
+		err = r.attempt(ctx, e, call)
+		var dialErr dialError
+		if errors.As(err, &dialErr) {
+			r.failed(e, time.Now())
+			continue
+		}
+		if err == nil || !retryable(err) {
+			// the answers of the contract, even the errors, show that the url is available
+			r.succeeded(e, time.Since(start))
+			return err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		r.failed(e, time.Now())
+		log.WithFields(log.Fields{"host": host(e.url), "attempt": attempt + 1, "err": err}).Warn("failed to call an RPC url")
+	}
+	return err
+}
+
+func (r *Resolver) attempt(ctx context.Context, e *endpoint, call func(context.Context, *endpoint) error) error {
+	if r.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.opts.Timeout)
+		defer cancel()
+	}
+	return call(ctx, e)
+}
+
+// wait sleeps before the retry attempt: the backoff doubled on each retry, plus a random jitter of up to the same
+func (r *Resolver) wait(ctx context.Context, attempt int) error {
+	if r.opts.Backoff <= 0 {
+		return ctx.Err()
+	}
+	delay := r.opts.Backoff << (attempt - 1)
+	delay += time.Duration(rand.Int63n(int64(delay)))
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryable reports whether err is a failure of the RPC provider rather than an answer of the state contract
+func retryable(err error) bool {
+	var netErr net.Error
+	var httpErr rpc.HTTPError
+	var rpcErr rpc.Error
+	switch {
+	case errors.As(err, &httpErr):
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= http.StatusInternalServerError
+	case errors.As(err, &rpcErr):
+		return rpcErr.ErrorCode() == rpcInternalError || rpcErr.ErrorCode() == rpcLimitExceeded
+	}
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// host returns the host of the RPC url, without the path and the query where the providers put their api keys
+func host(rpcURL string) string {
+	u, err := url.Parse(rpcURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
@@ -0,0 +1,299 @@
+package stateresolver
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iden3/contracts-abi/state/go/abi"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeContract answers the states with replacedAt as their replacement time, 0 for the latest states,
+// after failing its first failures calls with err. The states are answered once block is closed, when it is set.
+type fakeContract struct {
+	mu         sync.Mutex
+	calls      int
+	failures   int
+	err        error
+	replacedAt int64
+	block      chan struct{}
+}
+
+func (c *fakeContract) GetStateInfoByIdAndState(_ *bind.CallOpts, id, st *big.Int) (abi.IStateStateInfo, error) {
+	if c.block != nil {
+		<-c.block
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.calls <= c.failures {
+		return abi.IStateStateInfo{}, c.err
+	}
+	return abi.IStateStateInfo{Id: id, State: st, ReplacedAtTimestamp: big.NewInt(c.replacedAt)}, nil
+}
+
+func (c *fakeContract) GetGISTRootInfo(_ *bind.CallOpts, root *big.Int) (abi.IStateGistRootInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	return abi.IStateGistRootInfo{Root: root, ReplacedByRoot: big.NewInt(0), ReplacedAtTimestamp: big.NewInt(0)}, nil
+}
+
+func (c *fakeContract) GetStateInfoById(_ *bind.CallOpts, id *big.Int) (abi.IStateStateInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.calls <= c.failures {
+		return abi.IStateStateInfo{}, c.err
+	}
+	return abi.IStateStateInfo{Id: id, State: big.NewInt(42), ReplacedAtTimestamp: big.NewInt(0)}, nil
+}
+
+func (c *fakeContract) Calls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func dialer(contracts map[string]*fakeContract) Dialer {
+	return func(rpcURL string, _ common.Address) (Contract, error) {
+		return contracts[rpcURL], nil
+	}
+}
+
+func identity(t *testing.T) *big.Int {
+	t.Helper()
+	did, err := w3c.ParseDID("did:iden3:polygon:amoy:x6x5sor7zpxixYDNyDZKnh3oJZRj7Vzn9jzoCNoxc")
+	require.NoError(t, err)
+	id, err := core.IDFromDID(*did)
+	require.NoError(t, err)
+	return id.BigInt()
+}
+
+func TestResolverCache(t *testing.T) {
+	ctx := context.Background()
+	id := identity(t)
+	latest := &fakeContract{}
+	r := New([]string{"https://rpc"}, "0x1", Options{CacheTTL: time.Minute, ReplacedCacheTTL: time.Hour,
+		Dial: dialer(map[string]*fakeContract{"https://rpc": latest})})
+
+	for i := 0; i < 3; i++ {
+		resolved, err := r.Resolve(ctx, id, big.NewInt(10))
+		require.NoError(t, err)
+		assert.True(t, resolved.Latest)
+		_, err = r.ResolveGlobalRoot(ctx, big.NewInt(20))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, latest.Calls(), "the state and the root are resolved once")
+
+	// the uncached resolver always calls the contract
+	_, err := r.Uncached().Resolve(ctx, id, big.NewInt(10))
+	require.NoError(t, err)
+	_, err = r.Uncached().ResolveGlobalRoot(ctx, big.NewInt(20))
+	require.NoError(t, err)
+	assert.Equal(t, 4, latest.Calls())
+
+	// without a cache ttl the latest states are not cached, the replaced ones are
+	latest = &fakeContract{}
+	replaced := &fakeContract{replacedAt: 1700000000}
+	r = New([]string{"https://latest"}, "0x1", Options{ReplacedCacheTTL: time.Hour,
+		Dial: dialer(map[string]*fakeContract{"https://latest": latest})})
+	for i := 0; i < 2; i++ {
+		_, err := r.Resolve(ctx, id, big.NewInt(10))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, latest.Calls())
+
+	r = New([]string{"https://replaced"}, "0x1", Options{ReplacedCacheTTL: time.Hour,
+		Dial: dialer(map[string]*fakeContract{"https://replaced": replaced})})
+	for i := 0; i < 2; i++ {
+		resolved, err := r.Resolve(ctx, id, big.NewInt(10))
+		require.NoError(t, err)
+		assert.False(t, resolved.Latest)
+		assert.Equal(t, int64(1700000000), resolved.TransitionTimestamp)
+	}
+	assert.Equal(t, 1, replaced.Calls())
+}
+
+func TestResolverSharedLookup(t *testing.T) {
+	id := identity(t)
+	contract := &fakeContract{block: make(chan struct{})}
+	r := New([]string{"https://rpc"}, "0x1", Options{CacheTTL: time.Minute, Timeout: time.Second,
+		Dial: dialer(map[string]*fakeContract{"https://rpc": contract})})
+
+	// the first caller gives up while the lookup it started is shared with a second caller
+	canceled, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := r.Resolve(canceled, id, big.NewInt(10))
+		first <- err
+	}()
+	second := make(chan error, 1)
+	go func() {
+		_, err := r.Resolve(context.Background(), id, big.NewInt(10))
+		second <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+
+	close(contract.block)
+	require.NoError(t, <-second, "the lookup is not canceled by the first caller")
+	assert.Equal(t, 1, contract.Calls())
+}
+
+func TestResolverRetries(t *testing.T) {
+	ctx := context.Background()
+	id := identity(t)
+	unavailable := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	primary := &fakeContract{failures: 10, err: unavailable}
+	fallback := &fakeContract{}
+	r := New([]string{"https://primary", "https://fallback"}, "0x1", Options{Retries: 2, Backoff: time.Millisecond,
+		Dial: dialer(map[string]*fakeContract{"https://primary": primary, "https://fallback": fallback})})
+	resolved, err := r.Resolve(ctx, id, big.NewInt(10))
+	require.NoError(t, err)
+	assert.True(t, resolved.Latest)
+	assert.Equal(t, 1, primary.Calls())
+	assert.Equal(t, 1, fallback.Calls())
+
+	// the calls fail once the retries are exhausted
+	primary, fallback = &fakeContract{failures: 10, err: unavailable}, &fakeContract{failures: 10, err: unavailable}
+	r = New([]string{"https://primary", "https://fallback"}, "0x1", Options{Retries: 2, Backoff: time.Millisecond,
+		Dial: dialer(map[string]*fakeContract{"https://primary": primary, "https://fallback": fallback})})
+	_, err = r.Resolve(ctx, id, big.NewInt(10))
+	assert.ErrorIs(t, err, unavailable)
+	assert.Equal(t, 2, primary.Calls())
+	assert.Equal(t, 1, fallback.Calls())
+
+	// the answers of the contract are not retried
+	reverted := &fakeContract{failures: 10, err: errors.New("execution reverted: State does not exist")}
+	r = New([]string{"https://primary", "https://fallback"}, "0x1", Options{Retries: 2, Backoff: time.Millisecond,
+		Dial: dialer(map[string]*fakeContract{"https://primary": reverted})})
+	_, err = r.Resolve(ctx, id, big.NewInt(10))
+	assert.EqualError(t, err, "state is not genesis and not registered in the smart contract")
+	assert.Equal(t, 1, reverted.Calls())
+}
+
+func TestResolverBreaker(t *testing.T) {
+	ctx := context.Background()
+	id := identity(t)
+	unavailable := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	primary := &fakeContract{failures: 2, err: unavailable}
+	fallback := &fakeContract{}
+	r := New([]string{"https://primary", "https://fallback"}, "0x1", Options{Retries: 1, BreakerThreshold: 2,
+		BreakerCooldown: time.Hour, Dial: dialer(map[string]*fakeContract{"https://primary": primary, "https://fallback": fallback})})
+	for i := 0; i < 4; i++ {
+		_, err := r.Resolve(ctx, id, big.NewInt(int64(i)))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, primary.Calls(), "the primary url is skipped once its breaker is open")
+	assert.Equal(t, 4, fallback.Calls())
+
+	// the open urls are still tried when every url is failing
+	r.mu.Lock()
+	r.endpoints[1].failures, r.endpoints[1].openUntil = 2, time.Now().Add(2*time.Hour)
+	r.mu.Unlock()
+	_, err := r.Resolve(ctx, id, big.NewInt(10))
+	require.NoError(t, err)
+	assert.Equal(t, 3, primary.Calls(), "the url closing first is tried first")
+
+	// the breaker is half-open after its cooldown and closes on a success
+	r.mu.Lock()
+	r.endpoints[0].failures, r.endpoints[0].openUntil = 2, time.Now().Add(time.Hour)
+	r.endpoints[1].openUntil = time.Now().Add(-time.Second)
+	r.mu.Unlock()
+	_, err = r.Resolve(ctx, id, big.NewInt(11))
+	require.NoError(t, err)
+	assert.Equal(t, 5, fallback.Calls())
+	assert.Equal(t, []string{"https://fallback", "https://primary"}, urls(r.selection(time.Now())))
+	assert.Zero(t, r.endpoints[1].failures)
+}
+
+func TestResolverLatencySelection(t *testing.T) {
+	r := New([]string{"https://slow", "https://fast", "https://new"}, "0x1", Options{Selection: SelectionLatency})
+	r.succeeded(r.endpoints[0], 300*time.Millisecond)
+	r.succeeded(r.endpoints[1], 100*time.Millisecond)
+	assert.Equal(t, []string{"https://new", "https://fast", "https://slow"}, urls(r.selection(time.Now())))
+
+	// the latencies are averaged over the calls
+	r.succeeded(r.endpoints[1], time.Second)
+	assert.Equal(t, 280*time.Millisecond, r.endpoints[1].latency)
+	assert.Equal(t, []string{"https://new", "https://fast", "https://slow"}, urls(r.selection(time.Now())))
+	r.succeeded(r.endpoints[1], time.Second)
+	assert.Equal(t, []string{"https://new", "https://slow", "https://fast"}, urls(r.selection(time.Now())))
+
+	// the failover selection keeps the configured order
+	r.opts.Selection = SelectionFailover
+	assert.Equal(t, []string{"https://slow", "https://fast", "https://new"}, urls(r.selection(time.Now())))
+}
+
+func urls(endpoints []*endpoint) []string {
+	res := make([]string, 0, len(endpoints))
+	for _, e := range endpoints {
+		res = append(res, e.url)
+	}
+	return res
+}
+
+// fakeClient answers the block number head, or fails with err
+type fakeClient struct {
+	bind.ContractCaller
+	head  uint64
+	err   error
+	calls int
+}
+
+func (c *fakeClient) BlockNumber(context.Context) (uint64, error) {
+	c.calls++
+	return c.head, c.err
+}
+
+func TestResolverCall(t *testing.T) {
+	ctx := context.Background()
+	unavailable := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	primary, fallback := &fakeClient{err: unavailable}, &fakeClient{head: 100}
+	clients := map[string]*fakeClient{"https://primary": primary, "https://fallback": fallback}
+	r := New([]string{"https://primary", "https://fallback"}, "0x1", Options{Retries: 1, BreakerThreshold: 1, BreakerCooldown: time.Hour,
+		DialClient: func(rpcURL string) (Client, error) { return clients[rpcURL], nil }})
+
+	// the other reads of the network are retried on its urls and open their breakers
+	var head uint64
+	require.NoError(t, r.Call(ctx, func(ctx context.Context, client Client) (err error) {
+		head, err = client.BlockNumber(ctx)
+		return err
+	}))
+	assert.Equal(t, uint64(100), head)
+	assert.Equal(t, []string{"https://fallback", "https://primary"}, urls(r.selection(time.Now())))
+
+	// the answers of the contracts are not retried
+	reverted := errors.New("execution reverted")
+	err := r.Call(ctx, func(context.Context, Client) error { return reverted })
+	assert.ErrorIs(t, err, reverted)
+	assert.Equal(t, 1, primary.calls)
+}
+
+func TestResolverLatestState(t *testing.T) {
+	ctx := context.Background()
+	missing := errors.New("execution reverted: Identity does not exist")
+	contracts := map[string]*fakeContract{"https://rpc": {failures: 1, err: missing}}
+	r := New([]string{"https://rpc"}, "0x1", Options{CacheTTL: time.Minute, Retries: 2, Dial: dialer(contracts)})
+
+	_, err := r.LatestState(ctx, identity(t))
+	assert.ErrorIs(t, err, missing)
+	latest, err := r.LatestState(ctx, identity(t))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), latest)
+	assert.Equal(t, 2, contracts["https://rpc"].Calls())
+}
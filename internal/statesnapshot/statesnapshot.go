@@ -0,0 +1,206 @@
+// Package statesnapshot answers the identity states and global roots of a network from a local snapshot, synced
+// periodically from the state contract, so the verifications do not wait for an RPC call on their hot path.
+package statesnapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	"github.com/iden3/go-iden3-auth/v2/state"
+	log "github.com/sirupsen/logrus"
+)
+
+// Options configure the freshness and the persistence of a Snapshot
+type Options struct {
+	// MaxAge is how long a latest state is answered from the snapshot after its last sync, it is resolved again on
+	// the hot path once older. It should not exceed the delay accepted after a state transition.
+	MaxAge time.Duration
+	// Retention is how long the states that are not used anymore are kept, 0 keeps them
+	Retention time.Duration
+	// Path is the file the snapshot is persisted to after each sync and loaded from, it is kept in memory when empty
+	Path string
+}
+
+// entry is a resolved state of the snapshot. ID is nil for the global roots.
+type entry struct {
+	ID       *big.Int            `json:"id,omitempty"`
+	State    *big.Int            `json:"state"`
+	Resolved state.ResolvedState `json:"resolved"`
+	SyncedAt time.Time           `json:"syncedAt"`
+	UsedAt   time.Time           `json:"usedAt"`
+}
+
+// Snapshot resolves the states of a network from its snapshot, and through source for the states it does not have
+// yet. The replaced states never change, so only the latest ones are synced again.
+type Snapshot struct {
+	network string
+	source  pubsignals.StateResolver
+	opts    Options
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+// Open creates the Snapshot of the network, loaded from the file of opts when it exists
+func Open(network string, source pubsignals.StateResolver, opts Options) (*Snapshot, error) {
+	s := &Snapshot{network: network, source: source, opts: opts, entries: make(map[string]*entry)}
+	if opts.Path == "" {
+		return s, nil
+	}
+	b, err := os.ReadFile(filepath.Clean(opts.Path))
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []*entry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		s.entries[key(e.ID, e.State)] = e
+	}
+	return s, nil
+}
+
+// Resolve resolves the state of the identity id
+func (s *Snapshot) Resolve(ctx context.Context, id, st *big.Int) (*state.ResolvedState, error) {
+	return s.resolve(ctx, id, st)
+}
+
+// ResolveGlobalRoot resolves the global root st
+func (s *Snapshot) ResolveGlobalRoot(ctx context.Context, st *big.Int) (*state.ResolvedState, error) {
+	return s.resolve(ctx, nil, st)
+}
+
+func (s *Snapshot) resolve(ctx context.Context, id, st *big.Int) (*state.ResolvedState, error) {
+	now := time.Now()
+	k := key(id, st)
+	s.mu.Lock()
+	if e, ok := s.entries[k]; ok && s.fresh(e, now) {
+		e.UsedAt = now
+		resolved := e.Resolved
+		s.mu.Unlock()
+		return &resolved, nil
+	}
+	s.mu.Unlock()
+
+	resolved, err := s.fetch(ctx, id, st)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.entries[k] = &entry{ID: id, State: st, Resolved: *resolved, SyncedAt: now, UsedAt: now}
+	s.mu.Unlock()
+	return resolved, nil
+}
+
+// fresh reports whether the entry can be answered at now: the replaced states never change, the latest ones are
+// answered until MaxAge after their last sync
+func (s *Snapshot) fresh(e *entry, now time.Time) bool {
+	return !e.Resolved.Latest || now.Sub(e.SyncedAt) <= s.opts.MaxAge
+}
+
+func (s *Snapshot) fetch(ctx context.Context, id, st *big.Int) (*state.ResolvedState, error) {
+	if id == nil {
+		return s.source.ResolveGlobalRoot(ctx, st)
+	}
+	return s.source.Resolve(ctx, id, st)
+}
+
+// Run syncs the snapshot now, to refresh the entries it was loaded with, then every interval until ctx is done
+func (s *Snapshot) Run(ctx context.Context, interval time.Duration) {
+	s.sync(ctx, time.Now())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sync(ctx, time.Now())
+		}
+	}
+}
+
+// sync drops the entries unused for the retention, resolves the latest states again and persists the snapshot.
+// The states that fail to resolve keep their last sync, they are resolved on the hot path once too old.
+func (s *Snapshot) sync(ctx context.Context, now time.Time) {
+	var latest []entry
+	s.mu.Lock()
+	for k, e := range s.entries {
+		if s.opts.Retention > 0 && now.Sub(e.UsedAt) > s.opts.Retention {
+			delete(s.entries, k)
+			continue
+		}
+		if e.Resolved.Latest {
+			latest = append(latest, *e)
+		}
+	}
+	s.mu.Unlock()
+
+	var failed int
+	for _, e := range latest {
+		resolved, err := s.fetch(ctx, e.ID, e.State)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			failed++
+			continue
+		}
+		s.mu.Lock()
+		if current, ok := s.entries[key(e.ID, e.State)]; ok {
+			current.Resolved, current.SyncedAt = *resolved, now
+		}
+		s.mu.Unlock()
+	}
+	fields := log.Fields{"network": s.network, "synced": len(latest) - failed, "failed": failed}
+	if failed > 0 {
+		log.WithFields(fields).Warn("failed to sync states of the snapshot")
+	} else {
+		log.WithFields(fields).Debug("state snapshot synced")
+	}
+
+	if err := s.save(); err != nil {
+		log.WithFields(log.Fields{"network": s.network, "path": s.opts.Path, "err": err}).Error("failed to save state snapshot")
+	}
+}
+
+// save writes the snapshot to its file, through a temporary file so a crash does not leave it truncated
+func (s *Snapshot) save() error {
+	if s.opts.Path == "" {
+		return nil
+	}
+	s.mu.Lock()
+	entries := make([]*entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		copied := *e
+		entries = append(entries, &copied)
+	}
+	s.mu.Unlock()
+	b, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	tmp := s.opts.Path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.opts.Path)
+}
+
+func key(id, st *big.Int) string {
+	if id == nil {
+		return "root:" + st.String()
+	}
+	return "state:" + id.String() + ":" + st.String()
+}
@@ -0,0 +1,124 @@
+package statesnapshot
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iden3/go-iden3-auth/v2/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// source resolves every state as latest until replacedAt is set, and fails with err when set
+type source struct {
+	mu         sync.Mutex
+	calls      int
+	replacedAt int64
+	err        error
+}
+
+func (s *source) Resolve(_ context.Context, _, st *big.Int) (*state.ResolvedState, error) {
+	return s.resolved(st)
+}
+
+func (s *source) ResolveGlobalRoot(_ context.Context, st *big.Int) (*state.ResolvedState, error) {
+	return s.resolved(st)
+}
+
+func (s *source) resolved(st *big.Int) (*state.ResolvedState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &state.ResolvedState{State: st.String(), Latest: s.replacedAt == 0, TransitionTimestamp: s.replacedAt}, nil
+}
+
+func (s *source) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	src := &source{}
+	s, err := Open("polygon:amoy", src, Options{MaxAge: time.Minute, Retention: time.Hour})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		resolved, err := s.Resolve(ctx, big.NewInt(1), big.NewInt(10))
+		require.NoError(t, err)
+		assert.True(t, resolved.Latest)
+		_, err = s.ResolveGlobalRoot(ctx, big.NewInt(20))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, src.Calls(), "the states are answered from the snapshot")
+
+	// the sync picks up the replacement of the latest states
+	src.replacedAt = 1700000000
+	s.sync(ctx, time.Now())
+	assert.Equal(t, 4, src.Calls())
+	resolved, err := s.Resolve(ctx, big.NewInt(1), big.NewInt(10))
+	require.NoError(t, err)
+	assert.False(t, resolved.Latest)
+	assert.Equal(t, int64(1700000000), resolved.TransitionTimestamp)
+
+	// the replaced states are not synced anymore
+	s.sync(ctx, time.Now())
+	assert.Equal(t, 4, src.Calls())
+
+	// the states unused for the retention are dropped
+	s.sync(ctx, time.Now().Add(2*time.Hour))
+	_, err = s.Resolve(ctx, big.NewInt(1), big.NewInt(10))
+	require.NoError(t, err)
+	assert.Equal(t, 5, src.Calls())
+}
+
+func TestSnapshotMaxAge(t *testing.T) {
+	ctx := context.Background()
+	src := &source{}
+	s, err := Open("polygon:amoy", src, Options{MaxAge: time.Minute})
+	require.NoError(t, err)
+	_, err = s.Resolve(ctx, big.NewInt(1), big.NewInt(10))
+	require.NoError(t, err)
+
+	// a failed sync keeps the last state until it is too old, then it is resolved on the hot path
+	src.err = errors.New("unavailable")
+	s.sync(ctx, time.Now())
+	_, err = s.Resolve(ctx, big.NewInt(1), big.NewInt(10))
+	require.NoError(t, err)
+	assert.Equal(t, 2, src.Calls())
+
+	s.mu.Lock()
+	s.entries[key(big.NewInt(1), big.NewInt(10))].SyncedAt = time.Now().Add(-2 * time.Minute)
+	s.mu.Unlock()
+	_, err = s.Resolve(ctx, big.NewInt(1), big.NewInt(10))
+	assert.EqualError(t, err, "unavailable")
+	assert.Equal(t, 3, src.Calls())
+}
+
+func TestSnapshotFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "polygon_amoy.json")
+	src := &source{replacedAt: 1700000000}
+	s, err := Open("polygon:amoy", src, Options{MaxAge: time.Minute, Path: path})
+	require.NoError(t, err)
+	_, err = s.Resolve(ctx, big.NewInt(1), big.NewInt(10))
+	require.NoError(t, err)
+	s.sync(ctx, time.Now())
+
+	src = &source{}
+	s, err = Open("polygon:amoy", src, Options{MaxAge: time.Minute, Path: path})
+	require.NoError(t, err)
+	resolved, err := s.Resolve(ctx, big.NewInt(1), big.NewInt(10))
+	require.NoError(t, err)
+	assert.False(t, resolved.Latest)
+	assert.Zero(t, src.Calls(), "the states are loaded from the file")
+}
@@ -0,0 +1,205 @@
+// Package stats aggregates the verifications per day: their outcomes, latencies, credential types and the error codes
+// of the failures. The days are flushed to an object store, so the aggregates survive restarts. Flushes read and rewrite
+// the days, so the replicas must not share a location.
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/0xPolygonID/verifier-backend/internal/objectstore"
+)
+
+// DateLayout is the layout of the dates of the days
+const DateLayout = "2006-01-02"
+
+// MaxDays is the longest range of days that can be read at once
+const MaxDays = 366
+
+// Day is the aggregate of the verifications of a UTC day
+type Day struct {
+	Date           string  `json:"date"`
+	Verifications  int     `json:"verifications"`
+	Failures       int     `json:"failures"`
+	LatencySeconds float64 `json:"latencySeconds"`
+	// CredentialTypes counts the verifications that requested every credential type
+	CredentialTypes map[string]int `json:"credentialTypes,omitempty"`
+	// ErrorCodes counts the failures by error code
+	ErrorCodes map[string]int `json:"errorCodes,omitempty"`
+}
+
+// Add adds the counts of o to d
+func (d *Day) Add(o Day) {
+	d.Verifications += o.Verifications
+	d.Failures += o.Failures
+	d.LatencySeconds += o.LatencySeconds
+	for typ, n := range o.CredentialTypes {
+		if d.CredentialTypes == nil {
+			d.CredentialTypes = make(map[string]int)
+		}
+		d.CredentialTypes[typ] += n
+	}
+	for code, n := range o.ErrorCodes {
+		if d.ErrorCodes == nil {
+			d.ErrorCodes = make(map[string]int)
+		}
+		d.ErrorCodes[code] += n
+	}
+}
+
+// Tracker counts the verifications of the days in memory until they are flushed to its store.
+// Without a store, the days are only kept in memory.
+type Tracker struct {
+	store objectstore.Store
+	now   func() time.Time
+
+	mu      sync.Mutex
+	pending map[string]*Day
+}
+
+// NewTracker creates a Tracker that flushes to store, which may be nil
+func NewTracker(store objectstore.Store) *Tracker {
+	return &Tracker{store: store, now: time.Now, pending: make(map[string]*Day)}
+}
+
+// ObserveVerification records the outcome and the latency of a verification of the credential types,
+// with the error code of its failure
+func (t *Tracker) ObserveVerification(success bool, latency time.Duration, credentialTypes []string, errorCode string) {
+	o := Day{Verifications: 1, LatencySeconds: latency.Seconds(), CredentialTypes: make(map[string]int, len(credentialTypes))}
+	for _, typ := range credentialTypes {
+		o.CredentialTypes[typ]++
+	}
+	if !success {
+		o.Failures = 1
+		o.ErrorCodes = map[string]int{errorCode: 1}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	date := t.now().UTC().Format(DateLayout)
+	day, ok := t.pending[date]
+	if !ok {
+		day = &Day{Date: date}
+		t.pending[date] = day
+	}
+	day.Add(o)
+}
+
+// Days returns the days from from to to, both included, with the flushed and the pending counts
+func (t *Tracker) Days(ctx context.Context, from, to time.Time) ([]Day, error) {
+	from, to = from.UTC().Truncate(24*time.Hour), to.UTC().Truncate(24*time.Hour)
+	if to.Before(from) {
+		return nil, errors.New("the range ends before it starts")
+	}
+	if n := int(to.Sub(from)/(24*time.Hour)) + 1; n > MaxDays {
+		return nil, fmt.Errorf("the range has %d days, at most %d can be read", n, MaxDays)
+	}
+
+	var days []Day
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		day, err := t.load(ctx, date.Format(DateLayout))
+		if err != nil {
+			return nil, err
+		}
+		t.mu.Lock()
+		if pending, ok := t.pending[day.Date]; ok {
+			day.Add(*pending)
+		}
+		t.mu.Unlock()
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// Flush adds the pending counts to the days of the store. The counts of the days that cannot be written are
+// kept for the next flush.
+func (t *Tracker) Flush(ctx context.Context) error {
+	if t.store == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = make(map[string]*Day)
+	t.mu.Unlock()
+
+	var errs []error
+	for date, counts := range pending {
+		if err := t.flushDay(ctx, date, *counts); err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush %s: %w", date, err))
+			t.mu.Lock()
+			if day, ok := t.pending[date]; ok {
+				day.Add(*counts)
+			} else {
+				t.pending[date] = counts
+			}
+			t.mu.Unlock()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run flushes the pending counts every interval, and once more when the context is done
+func (t *Tracker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// the context of the flush outlives ctx, so the last counts are not lost on shutdown
+			flushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := t.Flush(flushCtx); err != nil {
+				log.WithFields(log.Fields{"err": err}).Error("failed to flush verification stats")
+			}
+			cancel()
+			return
+		case <-ticker.C:
+			if err := t.Flush(ctx); err != nil {
+				log.WithFields(log.Fields{"err": err}).Error("failed to flush verification stats")
+			}
+		}
+	}
+}
+
+func (t *Tracker) flushDay(ctx context.Context, date string, counts Day) error {
+	day, err := t.load(ctx, date)
+	if err != nil {
+		return err
+	}
+	day.Add(counts)
+	data, err := json.Marshal(day)
+	if err != nil {
+		return err
+	}
+	return t.store.Put(ctx, objectName(date), data)
+}
+
+// load returns the flushed day of date, an empty day when it was never flushed
+func (t *Tracker) load(ctx context.Context, date string) (Day, error) {
+	day := Day{Date: date}
+	if t.store == nil {
+		return day, nil
+	}
+	data, err := t.store.Get(ctx, objectName(date))
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return day, nil
+	}
+	if err != nil {
+		return day, err
+	}
+	if err := json.Unmarshal(data, &day); err != nil {
+		return day, fmt.Errorf("invalid stats of %s: %w", date, err)
+	}
+	return day, nil
+}
+
+func objectName(date string) string {
+	return "stats/" + date + ".json"
+}
@@ -0,0 +1,56 @@
+package stats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygonID/verifier-backend/internal/objectstore"
+)
+
+func TestTracker(t *testing.T) {
+	ctx := context.Background()
+	store := objectstore.Dir{Path: t.TempDir()}
+	now := time.Date(2025, 6, 15, 23, 0, 0, 0, time.UTC)
+	tracker := NewTracker(store)
+	tracker.now = func() time.Time { return now }
+
+	tracker.ObserveVerification(true, 2*time.Second, []string{"KYCAgeCredential"}, "")
+	tracker.ObserveVerification(false, time.Second, []string{"KYCAgeCredential", "KYCCountryOfResidenceCredential"}, "EXPIRED_STATE")
+	require.NoError(t, tracker.Flush(ctx))
+
+	// the counts of a restarted tracker are added to the flushed ones
+	tracker = NewTracker(store)
+	tracker.now = func() time.Time { return now }
+	tracker.ObserveVerification(false, 3*time.Second, []string{"KYCAgeCredential"}, "EXPIRED_STATE")
+	now = now.Add(2 * time.Hour)
+	tracker.ObserveVerification(true, time.Second, nil, "")
+
+	days, err := tracker.Days(ctx, now.AddDate(0, 0, -2), now)
+	require.NoError(t, err)
+	assert.Equal(t, []Day{
+		{Date: "2025-06-14"},
+		{
+			Date:            "2025-06-15",
+			Verifications:   3,
+			Failures:        2,
+			LatencySeconds:  6,
+			CredentialTypes: map[string]int{"KYCAgeCredential": 3, "KYCCountryOfResidenceCredential": 1},
+			ErrorCodes:      map[string]int{"EXPIRED_STATE": 2},
+		},
+		{Date: "2025-06-16", Verifications: 1, LatencySeconds: 1},
+	}, days)
+
+	require.NoError(t, tracker.Flush(ctx))
+	flushed, err := NewTracker(store).Days(ctx, now.AddDate(0, 0, -1), now)
+	require.NoError(t, err)
+	assert.Equal(t, days[1:], flushed)
+
+	_, err = tracker.Days(ctx, now, now.AddDate(0, 0, -1))
+	assert.Error(t, err)
+	_, err = tracker.Days(ctx, now.AddDate(0, 0, -MaxDays), now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,148 @@
+// Package testmode verifies a canned token without zero-knowledge proofs, so integration tests and staging frontends
+// can run the full sign-in flow without generating proofs. It must never be enabled in production.
+package testmode
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/go-iden3-core/v2/w3c"
+	"github.com/iden3/iden3comm/v2/packers"
+	"github.com/iden3/iden3comm/v2/protocol"
+)
+
+// valueArraySize is the size of the values of the query in the pub signals of the credential circuits
+const valueArraySize = 64
+
+// ErrInvalidToken is returned for the tokens that are not the canned token
+var ErrInvalidToken = errors.New("test mode only accepts the canned token")
+
+// Verifier accepts the canned token for any authorization request, as the answer of userDID with credentials of issuerDID
+type Verifier struct {
+	token    string
+	userDID  string
+	userID   core.ID
+	issuerID core.ID
+}
+
+// NewVerifier creates a Verifier of token
+func NewVerifier(token, userDID, issuerDID string) (*Verifier, error) {
+	if token == "" {
+		return nil, errors.New("test mode requires a canned token")
+	}
+	userID, err := parseID(userDID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid test mode user did: %w", err)
+	}
+	issuerID, err := parseID(issuerDID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid test mode issuer did: %w", err)
+	}
+	return &Verifier{token: token, userDID: userDID, userID: userID, issuerID: issuerID}, nil
+}
+
+// FullVerify answers every scope of the request with pub signals of the user and the issuer, or of the first allowed
+// issuer of the scope, when token is the canned token. Only the off-chain credential circuits are supported.
+func (v *Verifier) FullVerify(_ context.Context, token string, request protocol.AuthorizationRequestMessage,
+	_ ...pubsignals.VerifyOpt,
+) (*protocol.AuthorizationResponseMessage, error) {
+	if subtle.ConstantTimeCompare([]byte(token), []byte(v.token)) != 1 {
+		return nil, ErrInvalidToken
+	}
+
+	response := &protocol.AuthorizationResponseMessage{
+		ID:       uuid.NewString(),
+		Typ:      packers.MediaTypeZKPMessage,
+		Type:     protocol.AuthorizationResponseMessageType,
+		ThreadID: request.ThreadID,
+		From:     v.userDID,
+		To:       request.From,
+		Body: protocol.AuthorizationMessageResponseBody{
+			Message: request.Body.Message,
+			Scope:   make([]protocol.ZeroKnowledgeProofResponse, 0, len(request.Body.Scope)),
+		},
+	}
+	for _, scope := range request.Body.Scope {
+		issuerID, err := v.issuer(scope)
+		if err != nil {
+			return nil, err
+		}
+		signals, err := v.pubSignals(scope, issuerID)
+		if err != nil {
+			return nil, err
+		}
+		answer := protocol.ZeroKnowledgeProofResponse{ID: scope.ID, CircuitID: scope.CircuitID}
+		answer.PubSignals = signals
+		response.Body.Scope = append(response.Body.Scope, answer)
+	}
+	return response, nil
+}
+
+// issuer returns the first allowed issuer of the scope, so the issuer checks of the verifier pass
+func (v *Verifier) issuer(scope protocol.ZeroKnowledgeProofRequest) (core.ID, error) {
+	allowed, _ := scope.Query["allowedIssuers"].([]interface{})
+	for _, issuer := range allowed {
+		if did, ok := issuer.(string); ok && did != "*" {
+			return parseID(did)
+		}
+	}
+	return v.issuerID, nil
+}
+
+// pubSignals returns the pub signals of a proof of the scope, in the order of the outputs of its circuit
+func (v *Verifier) pubSignals(scope protocol.ZeroKnowledgeProofRequest, issuerID core.ID) ([]string, error) {
+	var (
+		userID    = v.userID.BigInt().String()
+		issuer    = issuerID.BigInt().String()
+		requestID = strconv.FormatUint(uint64(scope.ID), 10)
+		timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+	)
+
+	var signals []string
+	switch circuits.CircuitID(scope.CircuitID) {
+	case circuits.AtomicQuerySigV2CircuitID:
+		// merklized, userID, issuerAuthState, requestID, issuerID, isRevocationChecked, issuerClaimNonRevState,
+		// timestamp, claimSchema, claimPathNotExists, claimPathKey, slotIndex, operator
+		signals = []string{"0", userID, "0", requestID, issuer, "1", "0", timestamp, "0", "0", "0", "0", "0"}
+		signals = append(signals, values()...)
+	case circuits.AtomicQueryMTPV2CircuitID:
+		// merklized, userID, requestID, issuerID, issuerClaimIdenState, isRevocationChecked, issuerClaimNonRevState,
+		// timestamp, claimSchema, claimPathNotExists, claimPathKey, slotIndex, operator
+		signals = []string{"0", userID, requestID, issuer, "0", "1", "0", timestamp, "0", "0", "0", "0", "0"}
+		signals = append(signals, values()...)
+	case circuits.AtomicQueryV3CircuitID:
+		// merklized, userID, issuerState, linkID, nullifier, operatorOutput, proofType, requestID, issuerID,
+		// isRevocationChecked, issuerClaimNonRevState, timestamp, claimSchema, claimPathKey, slotIndex, operator
+		signals = []string{"0", userID, "0", "0", "0", "0", "1", requestID, issuer, "1", "0", timestamp, "0", "0", "0", "0"}
+		signals = append(signals, values()...)
+		// valueArraySize, verifierID, nullifierSessionID
+		signals = append(signals, "1", "0", "0")
+	default:
+		return nil, fmt.Errorf("circuit %s is not supported in test mode", scope.CircuitID)
+	}
+	return signals, nil
+}
+
+func values() []string {
+	values := make([]string, valueArraySize)
+	for i := range values {
+		values[i] = "0"
+	}
+	return values
+}
+
+func parseID(did string) (core.ID, error) {
+	parsed, err := w3c.ParseDID(did)
+	if err != nil {
+		return core.ID{}, err
+	}
+	return core.IDFromDID(*parsed)
+}
@@ -0,0 +1,59 @@
+package testmode
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/iden3/go-circuits/v2"
+	core "github.com/iden3/go-iden3-core/v2"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userDID    = "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"
+	issuerDID  = "did:iden3:polygon:amoy:x6x5sor7zpxjsoheG3a53ZRBgNkJuv3WU8Sb9roVK"
+	allowedDID = "did:iden3:polygon:amoy:x6x5sor7zpxixYDNyDZKnh3oJZRj7Vzn9jzoCNoxc"
+)
+
+func TestFullVerify(t *testing.T) {
+	v, err := NewVerifier("canned-token", userDID, issuerDID)
+	require.NoError(t, err)
+	issuerID, err := parseID(issuerDID)
+	require.NoError(t, err)
+	allowedID, err := parseID(allowedDID)
+	require.NoError(t, err)
+
+	request := protocol.AuthorizationRequestMessage{ThreadID: "thread", From: "did:iden3:polygon:amoy:verifier"}
+	request.Body.Scope = []protocol.ZeroKnowledgeProofRequest{
+		{ID: 1, CircuitID: string(circuits.AtomicQuerySigV2CircuitID), Query: map[string]interface{}{"allowedIssuers": []interface{}{"*"}}},
+		{ID: 2, CircuitID: string(circuits.AtomicQueryMTPV2CircuitID), Query: map[string]interface{}{"allowedIssuers": []interface{}{allowedDID}}},
+		{ID: 3, CircuitID: string(circuits.AtomicQueryV3CircuitID), Query: map[string]interface{}{}},
+	}
+
+	_, err = v.FullVerify(context.Background(), "another-token", request)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	response, err := v.FullVerify(context.Background(), "canned-token", request)
+	require.NoError(t, err)
+	assert.Equal(t, userDID, response.From)
+	assert.Equal(t, request.From, response.To)
+	assert.Equal(t, "thread", response.ThreadID)
+	require.Len(t, response.Body.Scope, 3)
+
+	for i, expected := range []core.ID{issuerID, allowedID, issuerID} {
+		scope := response.Body.Scope[i]
+		signals, err := json.Marshal(scope.PubSignals)
+		require.NoError(t, err)
+		output, err := circuits.UnmarshalCircuitOutput(circuits.CircuitID(scope.CircuitID), signals)
+		require.NoError(t, err, scope.CircuitID)
+		assert.Equal(t, &expected, output["issuerID"], scope.CircuitID)
+		assert.Equal(t, v.userID, *output["userID"].(*core.ID), scope.CircuitID)
+	}
+
+	request.Body.Scope = []protocol.ZeroKnowledgeProofRequest{{ID: 1, CircuitID: string(circuits.AtomicQuerySigV2OnChainCircuitID)}}
+	_, err = v.FullVerify(context.Background(), "canned-token", request)
+	assert.Error(t, err)
+}
@@ -0,0 +1,284 @@
+package timing
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	"github.com/iden3/go-iden3-auth/v2/state"
+)
+
+// Stage is a step of the verification of a callback
+type Stage string
+
+// Verification stages
+const (
+	StageParse             Stage = "parse"
+	StageStateResolution   Stage = "state_resolution"
+	StageRevocationCheck   Stage = "revocation_check"
+	StageProofVerification Stage = "proof_verification"
+	StagePostProcessing    Stage = "post_processing"
+)
+
+// Stages are the verification stages in the order they run
+var Stages = []Stage{StageParse, StageStateResolution, StageRevocationCheck, StageProofVerification, StagePostProcessing}
+
+// Spans of the parse stage
+const (
+	SpanUnpack          = "unpack"
+	SpanPubSignalsParse = "pub_signals_parse"
+)
+
+// Breakdown is the time spent in each stage of a verification
+type Breakdown map[Stage]time.Duration
+
+// Span is a timed step of a verification, of one scope of the request when ScopeID is set. Start is the time since the
+// recorder was created. The spans of a stage contain the spans of its steps, e.g. the parse stage contains the unpack.
+type Span struct {
+	Name     string
+	ScopeID  *uint32
+	Start    time.Duration
+	Duration time.Duration
+}
+
+// expectedResolution is the stage and the scope of a state resolution of an issuer
+type expectedResolution struct {
+	stage   Stage
+	scopeID uint32
+}
+
+type ctxKey struct{}
+
+// Recorder collects the breakdown of a verification. A nil Recorder discards everything.
+type Recorder struct {
+	mu        sync.Mutex
+	start     time.Time
+	breakdown Breakdown
+	spans     []Span
+	// expected holds the stage and the scope of the next state resolutions of each issuer,
+	// as the issuer state and the non-revocation state are resolved with the same call
+	expected map[string][]expectedResolution
+	// rpcCalls and rpcErrors count the state resolutions and the failed ones
+	rpcCalls  int
+	rpcErrors int
+}
+
+// NewRecorder creates a new Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{start: time.Now(), breakdown: make(Breakdown), expected: make(map[string][]expectedResolution)}
+}
+
+// WithRecorder returns a copy of ctx with the recorder
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, ctxKey{}, r)
+}
+
+// FromContext returns the recorder of the context, or nil
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(ctxKey{}).(*Recorder)
+	return r
+}
+
+// Add adds d to the time spent in the stage
+func (r *Recorder) Add(stage Stage, d time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.breakdown[stage] += d
+}
+
+// Start starts timing a stage, recorded as a span of the stage. The returned function stops it.
+func (r *Recorder) Start(stage Stage) func() {
+	start := time.Now()
+	return func() {
+		d := time.Since(start)
+		r.Add(stage, d)
+		r.AddSpan(string(stage), nil, start, d)
+	}
+}
+
+// StartSpan starts timing a step of a stage, of the scope when scopeID is not nil. The returned function stops it.
+func (r *Recorder) StartSpan(name string, scopeID *uint32) func() {
+	start := time.Now()
+	return func() {
+		r.AddSpan(name, scopeID, start, time.Since(start))
+	}
+}
+
+// AddSpan records a span that started at start and lasted d
+func (r *Recorder) AddSpan(name string, scopeID *uint32, start time.Time, d time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, Span{Name: name, ScopeID: scopeID, Start: start.Sub(r.start), Duration: d})
+}
+
+// ExpectIssuerResolution registers that the state of an issuer is going to be resolved for the proof of a scope,
+// followed by its non-revocation state when revocationChecked is set.
+func (r *Recorder) ExpectIssuerResolution(scopeID uint32, issuerID *big.Int, revocationChecked bool) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := issuerID.String()
+	r.expected[key] = append(r.expected[key], expectedResolution{stage: StageStateResolution, scopeID: scopeID})
+	if revocationChecked {
+		r.expected[key] = append(r.expected[key], expectedResolution{stage: StageRevocationCheck, scopeID: scopeID})
+	}
+}
+
+// Breakdown returns a copy of the recorded breakdown
+func (r *Recorder) Breakdown() Breakdown {
+	if r == nil {
+		return Breakdown{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := make(Breakdown, len(r.breakdown))
+	for stage, d := range r.breakdown {
+		b[stage] = d
+	}
+	return b
+}
+
+// Spans returns a copy of the recorded spans, in the order they ended
+func (r *Recorder) Spans() []Span {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Span(nil), r.spans...)
+}
+
+// RPCCalls returns the number of state resolutions and how many of them failed
+func (r *Recorder) RPCCalls() (int, int) {
+	if r == nil {
+		return 0, 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rpcCalls, r.rpcErrors
+}
+
+func (r *Recorder) addRPCCall(err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rpcCalls++
+	if err != nil {
+		r.rpcErrors++
+	}
+}
+
+func (r *Recorder) addIssuerResolution(issuerID *big.Int, start time.Time, d time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stage, scopeID := StageStateResolution, (*uint32)(nil)
+	key := issuerID.String()
+	if expected := r.expected[key]; len(expected) > 0 {
+		id := expected[0].scopeID
+		stage, scopeID = expected[0].stage, &id
+		r.expected[key] = expected[1:]
+	}
+	r.breakdown[stage] += d
+	r.spans = append(r.spans, Span{Name: string(stage), ScopeID: scopeID, Start: start.Sub(r.start), Duration: d})
+}
+
+// StateResolver records the time spent resolving states in the recorder of the context
+type StateResolver struct {
+	pubsignals.StateResolver
+}
+
+// Resolve resolves the state of an identity
+func (s StateResolver) Resolve(ctx context.Context, id *big.Int, st *big.Int) (*state.ResolvedState, error) {
+	start := time.Now()
+	resolved, err := s.StateResolver.Resolve(ctx, id, st)
+	FromContext(ctx).addIssuerResolution(id, start, time.Since(start))
+	FromContext(ctx).addRPCCall(err)
+	return resolved, err
+}
+
+// ResolveGlobalRoot resolves a global identities tree root
+func (s StateResolver) ResolveGlobalRoot(ctx context.Context, st *big.Int) (*state.ResolvedState, error) {
+	stop := FromContext(ctx).Start(StageStateResolution)
+	resolved, err := s.StateResolver.ResolveGlobalRoot(ctx, st)
+	stop()
+	FromContext(ctx).addRPCCall(err)
+	return resolved, err
+}
+
+// WrapResolvers wraps the resolvers so the time spent resolving states is recorded
+func WrapResolvers(resolvers map[string]pubsignals.StateResolver) map[string]pubsignals.StateResolver {
+	wrapped := make(map[string]pubsignals.StateResolver, len(resolvers))
+	for prefix, resolver := range resolvers {
+		wrapped[prefix] = StateResolver{StateResolver: resolver}
+	}
+	return wrapped
+}
+
+// StageStats are the aggregated timings of a stage
+type StageStats struct {
+	Count int
+	Total time.Duration
+	Max   time.Duration
+}
+
+// Average returns the average time spent in the stage
+func (s StageStats) Average() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// Stats aggregates the breakdowns of the verifications since the server started
+type Stats struct {
+	mu            sync.Mutex
+	verifications int
+	stages        map[Stage]StageStats
+}
+
+// NewStats creates a new Stats
+func NewStats() *Stats {
+	return &Stats{stages: make(map[Stage]StageStats)}
+}
+
+// Observe adds a breakdown to the aggregates
+func (s *Stats) Observe(b Breakdown) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verifications++
+	for stage, d := range b {
+		stats := s.stages[stage]
+		stats.Count++
+		stats.Total += d
+		if d > stats.Max {
+			stats.Max = d
+		}
+		s.stages[stage] = stats
+	}
+}
+
+// Snapshot returns the number of observed verifications and the aggregates of each stage
+func (s *Stats) Snapshot() (int, map[Stage]StageStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stages := make(map[Stage]StageStats, len(s.stages))
+	for stage, stats := range s.stages {
+		stages[stage] = stats
+	}
+	return s.verifications, stages
+}
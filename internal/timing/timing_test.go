@@ -0,0 +1,75 @@
+package timing
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/iden3/go-iden3-auth/v2/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sleepResolver struct {
+	delay time.Duration
+}
+
+func (r sleepResolver) Resolve(_ context.Context, _ *big.Int, _ *big.Int) (*state.ResolvedState, error) {
+	time.Sleep(r.delay)
+	return &state.ResolvedState{Latest: true}, nil
+}
+
+func (r sleepResolver) ResolveGlobalRoot(_ context.Context, _ *big.Int) (*state.ResolvedState, error) {
+	time.Sleep(r.delay)
+	return &state.ResolvedState{Latest: true}, nil
+}
+
+func TestStateResolver(t *testing.T) {
+	resolver := StateResolver{StateResolver: sleepResolver{delay: 10 * time.Millisecond}}
+	issuer := big.NewInt(1)
+
+	recorder := NewRecorder()
+	recorder.ExpectIssuerResolution(2, issuer, true)
+	ctx := WithRecorder(context.Background(), recorder)
+
+	_, err := resolver.ResolveGlobalRoot(ctx, big.NewInt(10))
+	assert.NoError(t, err)
+	_, err = resolver.Resolve(ctx, issuer, big.NewInt(20))
+	assert.NoError(t, err)
+	_, err = resolver.Resolve(ctx, issuer, big.NewInt(30))
+	assert.NoError(t, err)
+
+	breakdown := recorder.Breakdown()
+	assert.GreaterOrEqual(t, breakdown[StageStateResolution], 20*time.Millisecond)
+	assert.GreaterOrEqual(t, breakdown[StageRevocationCheck], 10*time.Millisecond)
+	spans := recorder.Spans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, string(StageStateResolution), spans[0].Name)
+	assert.Nil(t, spans[0].ScopeID)
+	assert.Equal(t, string(StageStateResolution), spans[1].Name)
+	assert.Equal(t, uint32(2), *spans[1].ScopeID)
+	assert.Equal(t, string(StageRevocationCheck), spans[2].Name)
+	assert.Equal(t, uint32(2), *spans[2].ScopeID)
+	assert.GreaterOrEqual(t, spans[2].Start, spans[1].Start+spans[1].Duration)
+
+	calls, errors := recorder.RPCCalls()
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 0, errors)
+
+	// without recorder the resolutions are not recorded
+	_, err = resolver.Resolve(context.Background(), issuer, big.NewInt(20))
+	assert.NoError(t, err)
+}
+
+func TestStats(t *testing.T) {
+	stats := NewStats()
+	stats.Observe(Breakdown{StageParse: time.Millisecond, StageProofVerification: 3 * time.Millisecond})
+	stats.Observe(Breakdown{StageParse: 3 * time.Millisecond})
+
+	verifications, stages := stats.Snapshot()
+	assert.Equal(t, 2, verifications)
+	assert.Equal(t, StageStats{Count: 2, Total: 4 * time.Millisecond, Max: 3 * time.Millisecond}, stages[StageParse])
+	assert.Equal(t, 2*time.Millisecond, stages[StageParse].Average())
+	assert.Equal(t, 1, stages[StageProofVerification].Count)
+}
@@ -0,0 +1,136 @@
+package users
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// maxRecordSize is the size of the longest line of a FileRegistry, a user with many credential types and nullifiers
+const maxRecordSize = 1 << 20
+
+// FileRegistry is a Registry that persists the records in an append-only file, the whole record of the user on every
+// upsert, one json record per line. The file is replayed in memory when the registry is opened, the last record of a
+// user winning, and rewritten with a single record per user when it has more lines than users.
+type FileRegistry struct {
+	*MemoryRegistry
+
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// OpenFileRegistry opens the FileRegistry at path, creating the file if it does not exist
+func OpenFileRegistry(path string) (*FileRegistry, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	mem := NewMemoryRegistry()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxRecordSize)
+	lines := 0
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("invalid record at line %d of %s: %w", line, path, err)
+		}
+		mem.records[recordKey{tenant: record.Tenant, did: record.DID}] = record
+		lines++
+	}
+	if err := scanner.Err(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	r := &FileRegistry{MemoryRegistry: mem, path: path, file: f}
+	if lines > len(mem.records) {
+		if err := r.rewrite(); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to compact user records: %w", err)
+		}
+	}
+	return r, nil
+}
+
+// Upsert adds the verification to the record of the user, and appends the record to the file
+func (r *FileRegistry) Upsert(_ context.Context, tenant string, verification Verification) (Record, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the record is merged under the lock of the file, so the lines of the concurrent upserts of a user are in order
+	key := recordKey{tenant: tenant, did: verification.DID}
+	r.MemoryRegistry.mu.RLock()
+	record := merge(r.MemoryRegistry.records[key], tenant, verification)
+	r.MemoryRegistry.mu.RUnlock()
+
+	b, err := json.Marshal(record)
+	if err != nil {
+		return Record{}, err
+	}
+	if _, err := r.file.Write(append(b, '\n')); err != nil {
+		return Record{}, fmt.Errorf("failed to persist user record: %w", err)
+	}
+
+	r.MemoryRegistry.mu.Lock()
+	r.MemoryRegistry.records[key] = record
+	r.MemoryRegistry.mu.Unlock()
+	return record, nil
+}
+
+// Close closes the file of the registry
+func (r *FileRegistry) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// rewrite replaces the file with the records in memory, through a temporary file so a crash leaves one of them whole
+func (r *FileRegistry) rewrite() error {
+	tmp, err := os.OpenFile(r.path+".tmp", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(tmp)
+	r.MemoryRegistry.mu.RLock()
+	for _, record := range r.MemoryRegistry.records {
+		b, err := json.Marshal(record)
+		if err != nil {
+			r.MemoryRegistry.mu.RUnlock()
+			_ = tmp.Close()
+			return err
+		}
+		_, _ = w.Write(append(b, '\n'))
+	}
+	r.MemoryRegistry.mu.RUnlock()
+	if err := w.Flush(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(r.path+".tmp", r.path); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(r.path, os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	_ = r.file.Close()
+	r.file = f
+	return nil
+}
@@ -0,0 +1,153 @@
+// Package users keeps the registry of the users that verified, keyed by their DID, so the relying parties can tell
+// whether a DID verified before and for which credentials without keeping their own store
+package users
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonID/verifier-backend/internal/nullifier"
+)
+
+// Verification is a successful verification of a user
+type Verification struct {
+	DID             string
+	VerifiedAt      time.Time
+	CredentialTypes []string
+	Nullifiers      []nullifier.Key
+}
+
+// Record is what the registry knows about a user of a tenant, the verifier when Tenant is empty
+type Record struct {
+	Tenant          string          `json:"tenant,omitempty"`
+	DID             string          `json:"did"`
+	FirstVerifiedAt time.Time       `json:"firstVerifiedAt"`
+	LastVerifiedAt  time.Time       `json:"lastVerifiedAt"`
+	Verifications   int             `json:"verifications"`
+	CredentialTypes []string        `json:"credentialTypes"`
+	Nullifiers      []nullifier.Key `json:"nullifiers"`
+}
+
+// Registry stores the records of the users
+type Registry interface {
+	// Upsert adds the verification to the record of the user of the tenant, creating it on the first verification
+	Upsert(ctx context.Context, tenant string, verification Verification) (Record, error)
+	// Get returns the record of the user of the tenant
+	Get(ctx context.Context, tenant, did string) (Record, bool, error)
+	// List returns the records of the users of the tenant that verified the credential type, every user when it is
+	// empty, the most recently verified first
+	List(ctx context.Context, tenant, credentialType string) ([]Record, error)
+}
+
+// recordKey identifies the record of a user of a tenant
+type recordKey struct {
+	tenant string
+	did    string
+}
+
+// MemoryRegistry is a Registry that keeps the records in memory
+type MemoryRegistry struct {
+	mu      sync.RWMutex
+	records map[recordKey]Record
+}
+
+// NewMemoryRegistry creates a new MemoryRegistry
+func NewMemoryRegistry() *MemoryRegistry {
+	return &MemoryRegistry{records: make(map[recordKey]Record)}
+}
+
+// Upsert adds the verification to the record of the user of the tenant
+func (m *MemoryRegistry) Upsert(_ context.Context, tenant string, verification Verification) (Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := recordKey{tenant: tenant, did: verification.DID}
+	record := merge(m.records[key], tenant, verification)
+	m.records[key] = record
+	return record, nil
+}
+
+// Get returns the record of the user of the tenant
+func (m *MemoryRegistry) Get(_ context.Context, tenant, did string) (Record, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	record, ok := m.records[recordKey{tenant: tenant, did: did}]
+	return record, ok, nil
+}
+
+// List returns the records of the users of the tenant that verified the credential type, the most recently verified first
+func (m *MemoryRegistry) List(_ context.Context, tenant, credentialType string) ([]Record, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	records := make([]Record, 0)
+	for key, record := range m.records {
+		if key.tenant != tenant || (credentialType != "" && !contains(record.CredentialTypes, credentialType)) {
+			continue
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].LastVerifiedAt.Equal(records[j].LastVerifiedAt) {
+			return records[i].LastVerifiedAt.After(records[j].LastVerifiedAt)
+		}
+		return records[i].DID < records[j].DID
+	})
+	return records, nil
+}
+
+// merge returns the record with the verification, a new record when the record is empty
+func merge(record Record, tenant string, verification Verification) Record {
+	if record.DID == "" {
+		record = Record{Tenant: tenant, DID: verification.DID, FirstVerifiedAt: verification.VerifiedAt}
+	}
+	if verification.VerifiedAt.Before(record.FirstVerifiedAt) {
+		record.FirstVerifiedAt = verification.VerifiedAt
+	}
+	if verification.VerifiedAt.After(record.LastVerifiedAt) {
+		record.LastVerifiedAt = verification.VerifiedAt
+	}
+	record.Verifications++
+
+	types := append([]string{}, record.CredentialTypes...)
+	for _, credentialType := range verification.CredentialTypes {
+		if credentialType != "" && !contains(types, credentialType) {
+			types = append(types, credentialType)
+		}
+	}
+	sort.Strings(types)
+	record.CredentialTypes = types
+
+	nullifiers := append([]nullifier.Key{}, record.Nullifiers...)
+	for _, key := range verification.Nullifiers {
+		if !containsKey(nullifiers, key) {
+			nullifiers = append(nullifiers, key)
+		}
+	}
+	sort.Slice(nullifiers, func(i, j int) bool {
+		if nullifiers[i].SessionID != nullifiers[j].SessionID {
+			return nullifiers[i].SessionID < nullifiers[j].SessionID
+		}
+		return nullifiers[i].Nullifier < nullifiers[j].Nullifier
+	})
+	record.Nullifiers = nullifiers
+	return record
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsKey(keys []nullifier.Key, key nullifier.Key) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
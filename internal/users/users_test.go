@@ -0,0 +1,103 @@
+package users
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygonID/verifier-backend/internal/nullifier"
+)
+
+const userDID = "did:iden3:polygon:amoy:x7Z95VkUuyo6mqraJw2VGwCfqTzdqhM1RVjRHzcpK"
+
+func TestMemoryRegistry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 16, 10, 0, 0, 0, time.UTC)
+	registry := NewMemoryRegistry()
+
+	_, err := registry.Upsert(ctx, "", Verification{
+		DID:             userDID,
+		VerifiedAt:      now,
+		CredentialTypes: []string{"KYCAgeCredential"},
+		Nullifiers:      []nullifier.Key{{SessionID: "1", Nullifier: "42"}},
+	})
+	require.NoError(t, err)
+	record, err := registry.Upsert(ctx, "", Verification{
+		DID:             userDID,
+		VerifiedAt:      now.Add(time.Hour),
+		CredentialTypes: []string{"KYCCountryOfResidenceCredential", "KYCAgeCredential"},
+		Nullifiers:      []nullifier.Key{{SessionID: "1", Nullifier: "42"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Record{
+		DID:             userDID,
+		FirstVerifiedAt: now,
+		LastVerifiedAt:  now.Add(time.Hour),
+		Verifications:   2,
+		CredentialTypes: []string{"KYCAgeCredential", "KYCCountryOfResidenceCredential"},
+		Nullifiers:      []nullifier.Key{{SessionID: "1", Nullifier: "42"}},
+	}, record)
+
+	// the users of the tenants are apart
+	_, err = registry.Upsert(ctx, "acme", Verification{DID: userDID, VerifiedAt: now, CredentialTypes: []string{"KYCAgeCredential"}})
+	require.NoError(t, err)
+	_, err = registry.Upsert(ctx, "", Verification{DID: "did:example:other", VerifiedAt: now.Add(2 * time.Hour)})
+	require.NoError(t, err)
+
+	got, ok, err := registry.Get(ctx, "acme", userDID)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, 1, got.Verifications)
+	_, ok, err = registry.Get(ctx, "acme", "did:example:other")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	records, err := registry.List(ctx, "", "")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "did:example:other", records[0].DID)
+	records, err = registry.List(ctx, "", "KYCCountryOfResidenceCredential")
+	require.NoError(t, err)
+	assert.Equal(t, []Record{record}, records)
+}
+
+func TestFileRegistry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 16, 10, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "users.jsonl")
+
+	registry, err := OpenFileRegistry(path)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = registry.Upsert(ctx, "acme", Verification{DID: userDID, VerifiedAt: now.Add(time.Duration(i) * time.Hour)})
+		require.NoError(t, err)
+	}
+	require.NoError(t, registry.Close())
+
+	registry, err = OpenFileRegistry(path)
+	require.NoError(t, err)
+	record, ok, err := registry.Get(ctx, "acme", userDID)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, 3, record.Verifications)
+	assert.Equal(t, now, record.FirstVerifiedAt)
+	assert.Equal(t, now.Add(2*time.Hour), record.LastVerifiedAt)
+	_, err = registry.Upsert(ctx, "", Verification{DID: userDID, VerifiedAt: now})
+	require.NoError(t, err)
+	require.NoError(t, registry.Close())
+
+	// the file was compacted when it was opened
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 2)
+
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o600))
+	_, err = OpenFileRegistry(path)
+	assert.ErrorContains(t, err, "invalid record at line 1")
+}
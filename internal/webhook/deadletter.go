@@ -0,0 +1,240 @@
+package webhook
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// maxDeadLetterSize is the size of the longest line of a FileDeadLetters, a delivery with long metadata
+const maxDeadLetterSize = 1 << 20
+
+// DeadLetters stores the deliveries that failed, until they are replayed
+type DeadLetters interface {
+	// Add stores the delivery
+	Add(ctx context.Context, delivery Delivery) error
+	// Get returns the delivery id
+	Get(ctx context.Context, id string) (Delivery, bool, error)
+	// List returns the deliveries, the oldest first
+	List(ctx context.Context) ([]Delivery, error)
+	// Remove removes the delivery id, and returns whether it was stored
+	Remove(ctx context.Context, id string) (bool, error)
+}
+
+// MemoryDeadLetters is a DeadLetters that keeps the last deliveries in memory
+type MemoryDeadLetters struct {
+	mu         sync.RWMutex
+	size       int
+	order      []string
+	deliveries map[string]Delivery
+}
+
+// NewMemoryDeadLetters creates a MemoryDeadLetters that keeps the last size deliveries, every delivery when size is
+// not positive
+func NewMemoryDeadLetters(size int) *MemoryDeadLetters {
+	return &MemoryDeadLetters{size: size, deliveries: make(map[string]Delivery)}
+}
+
+// Add stores the delivery, dropping the oldest one when the dead letters are full
+func (m *MemoryDeadLetters) Add(_ context.Context, delivery Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.add(delivery)
+	return nil
+}
+
+// Get returns the delivery id
+func (m *MemoryDeadLetters) Get(_ context.Context, id string) (Delivery, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	delivery, ok := m.deliveries[id]
+	return delivery, ok, nil
+}
+
+// List returns the deliveries, the oldest first
+func (m *MemoryDeadLetters) List(_ context.Context) ([]Delivery, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	deliveries := make([]Delivery, 0, len(m.order))
+	for _, id := range m.order {
+		deliveries = append(deliveries, m.deliveries[id])
+	}
+	return deliveries, nil
+}
+
+// Remove removes the delivery id
+func (m *MemoryDeadLetters) Remove(_ context.Context, id string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.remove(id), nil
+}
+
+func (m *MemoryDeadLetters) add(delivery Delivery) {
+	if _, ok := m.deliveries[delivery.ID]; ok {
+		m.remove(delivery.ID)
+	}
+	if m.size > 0 && len(m.order) >= m.size {
+		delete(m.deliveries, m.order[0])
+		m.order = m.order[1:]
+	}
+	m.order = append(m.order, delivery.ID)
+	m.deliveries[delivery.ID] = delivery
+}
+
+func (m *MemoryDeadLetters) remove(id string) bool {
+	if _, ok := m.deliveries[id]; !ok {
+		return false
+	}
+	delete(m.deliveries, id)
+	for i, candidate := range m.order {
+		if candidate == id {
+			m.order = append(m.order[:i:i], m.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// deadLetterLine is a line of a FileDeadLetters, an added delivery or the id of a removed one
+type deadLetterLine struct {
+	Delivery *Delivery `json:"delivery,omitempty"`
+	Removed  string    `json:"removed,omitempty"`
+}
+
+// FileDeadLetters is a DeadLetters that persists the deliveries in an append-only file, one json line per added or
+// removed delivery, so the failed deliveries survive the restarts of the verifier. The file is replayed in memory when
+// the dead letters are opened, and rewritten with the stored deliveries when it has more lines than deliveries.
+type FileDeadLetters struct {
+	*MemoryDeadLetters
+
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// OpenFileDeadLetters opens the FileDeadLetters at path, creating the file if it does not exist, that keeps the last
+// size deliveries
+func OpenFileDeadLetters(path string, size int) (*FileDeadLetters, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	mem := NewMemoryDeadLetters(size)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxDeadLetterSize)
+	lines := 0
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry deadLetterLine
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("invalid dead letter at line %d of %s: %w", line, path, err)
+		}
+		if entry.Delivery != nil {
+			mem.add(*entry.Delivery)
+		} else {
+			mem.remove(entry.Removed)
+		}
+		lines++
+	}
+	if err := scanner.Err(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	d := &FileDeadLetters{MemoryDeadLetters: mem, path: path, file: f}
+	if lines > len(mem.order) {
+		if err := d.rewrite(); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to compact dead letters: %w", err)
+		}
+	}
+	return d, nil
+}
+
+// Add appends the delivery to the file and stores it
+func (d *FileDeadLetters) Add(ctx context.Context, delivery Delivery) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.append(deadLetterLine{Delivery: &delivery}); err != nil {
+		return err
+	}
+	return d.MemoryDeadLetters.Add(ctx, delivery)
+}
+
+// Remove appends the removal of the delivery id to the file and removes it
+func (d *FileDeadLetters) Remove(ctx context.Context, id string) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok, _ := d.MemoryDeadLetters.Get(ctx, id); !ok {
+		return false, nil
+	}
+	if err := d.append(deadLetterLine{Removed: id}); err != nil {
+		return false, err
+	}
+	return d.MemoryDeadLetters.Remove(ctx, id)
+}
+
+// Close closes the file of the dead letters
+func (d *FileDeadLetters) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.file.Close()
+}
+
+func (d *FileDeadLetters) append(entry deadLetterLine) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := d.file.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("failed to persist dead letter: %w", err)
+	}
+	return nil
+}
+
+// rewrite replaces the file with the deliveries in memory, through a temporary file so a crash leaves one of them whole
+func (d *FileDeadLetters) rewrite() error {
+	tmp, err := os.OpenFile(d.path+".tmp", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(tmp)
+	deliveries, _ := d.MemoryDeadLetters.List(context.Background())
+	for i := range deliveries {
+		b, err := json.Marshal(deadLetterLine{Delivery: &deliveries[i]})
+		if err != nil {
+			_ = tmp.Close()
+			return err
+		}
+		_, _ = w.Write(append(b, '\n'))
+	}
+	if err := w.Flush(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(d.path+".tmp", d.path); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(d.path, os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	_ = d.file.Close()
+	d.file = f
+	return nil
+}
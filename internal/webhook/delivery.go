@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DeliveryPending is the status of the deliveries being attempted or waiting for their next attempt
+	DeliveryPending = "pending"
+	// DeliveryDelivered is the status of the deliveries accepted by the webhook
+	DeliveryDelivered = "delivered"
+	// DeliveryDead is the status of the deliveries that failed, kept in the dead letters until they are replayed
+	DeliveryDead = "dead"
+)
+
+var (
+	// ErrDeliveryNotFound is returned when a replayed delivery is not in the dead letters
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrWebhookNotFound is returned when the webhook of a replayed delivery is not configured anymore
+	ErrWebhookNotFound = errors.New("webhook of the delivery not configured")
+)
+
+// Delivery is the delivery of an event to a webhook, the session webhook when Webhook is empty and the webhook of the
+// flow Webhook otherwise
+type Delivery struct {
+	ID        string    `json:"id"`
+	Webhook   string    `json:"webhook,omitempty"`
+	Event     Event     `json:"event"`
+	Status    string    `json:"status"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"lastError,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Retry configures the attempts of the deliveries. The wait after the first failed attempt is Backoff, and it doubles
+// after every other one up to MaxBackoff.
+type Retry struct {
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// attempts returns the number of attempts of a delivery, at least one
+func (r Retry) attempts() int {
+	return max(r.Attempts, 1)
+}
+
+// backoff returns the wait after the failed attempt n, counted from 1
+func (r Retry) backoff(n int) time.Duration {
+	d := r.Backoff
+	for i := 1; i < n && (r.MaxBackoff <= 0 || d < r.MaxBackoff); i++ {
+		d *= 2
+	}
+	if r.MaxBackoff > 0 && d > r.MaxBackoff {
+		return r.MaxBackoff
+	}
+	return d
+}
+
+// Dispatcher delivers the events to the webhooks in the background, so an unavailable webhook never delays the
+// sessions. The failed attempts are retried with an exponential backoff, and the deliveries that fail every attempt,
+// or that the webhook rejects, are moved to the dead letters, from where they can be replayed. onUpdate is called with
+// the delivery whenever its status changes, or after a failed attempt.
+type Dispatcher struct {
+	retry    Retry
+	dead     DeadLetters
+	onUpdate func(Delivery)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDispatcher creates a Dispatcher that retries the deliveries with retry and keeps the failed ones in dead
+func NewDispatcher(retry Retry, dead DeadLetters, onUpdate func(Delivery)) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	if onUpdate == nil {
+		onUpdate = func(Delivery) {}
+	}
+	return &Dispatcher{retry: retry, dead: dead, onUpdate: onUpdate, ctx: ctx, cancel: cancel}
+}
+
+// Deliver sends the event to the webhook name with sender in the background, and returns the pending delivery
+func (d *Dispatcher) Deliver(sender *Sender, name string, event Event) Delivery {
+	now := time.Now().UTC()
+	delivery := Delivery{
+		ID:        uuid.NewString(),
+		Webhook:   name,
+		Event:     event,
+		Status:    DeliveryPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	d.start(sender, delivery)
+	return delivery
+}
+
+// DeadLetters returns the deliveries in the dead letters, the oldest first
+func (d *Dispatcher) DeadLetters(ctx context.Context) ([]Delivery, error) {
+	return d.dead.List(ctx)
+}
+
+// Replay removes the delivery id from the dead letters and sends its event again, with the sender returned by senders
+// for its webhook and the attempts of a new delivery
+func (d *Dispatcher) Replay(ctx context.Context, id string, senders func(name string) *Sender) (Delivery, error) {
+	delivery, ok, err := d.dead.Get(ctx, id)
+	if err != nil {
+		return Delivery{}, err
+	}
+	if !ok {
+		return Delivery{}, ErrDeliveryNotFound
+	}
+	sender := senders(delivery.Webhook)
+	if sender == nil {
+		return Delivery{}, ErrWebhookNotFound
+	}
+	// a delivery replayed twice at once is only sent again once
+	if ok, err := d.dead.Remove(ctx, id); err != nil {
+		return Delivery{}, err
+	} else if !ok {
+		return Delivery{}, ErrDeliveryNotFound
+	}
+
+	delivery.Status, delivery.UpdatedAt = DeliveryPending, time.Now().UTC()
+	d.start(sender, delivery)
+	return delivery, nil
+}
+
+// Close stops the retries, the deliveries waiting for their next attempt are moved to the dead letters, and waits for
+// the attempts in progress
+func (d *Dispatcher) Close() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) start(sender *Sender, delivery Delivery) {
+	d.onUpdate(delivery)
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.run(sender, delivery)
+	}()
+}
+
+// run attempts the delivery until it succeeds, the webhook rejects it, its attempts are exhausted or the dispatcher is
+// closed
+func (d *Dispatcher) run(sender *Sender, delivery Delivery) {
+	logger := log.WithFields(log.Fields{"delivery": delivery.ID, "sessionID": delivery.Event.SessionID, "event": delivery.Event.Type})
+	for attempt := 1; ; attempt++ {
+		// the attempts in progress are not cancelled by Close, they are bound by the timeout of the sender
+		err := sender.Send(context.Background(), delivery.Event)
+		delivery.Attempts++
+		delivery.UpdatedAt = time.Now().UTC()
+		if err == nil {
+			delivery.Status, delivery.LastError = DeliveryDelivered, ""
+			d.onUpdate(delivery)
+			return
+		}
+		delivery.LastError = err.Error()
+
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && !statusErr.Retryable() || attempt >= d.retry.attempts() {
+			break
+		}
+		d.onUpdate(delivery)
+		logger.WithFields(log.Fields{"attempt": attempt, "err": err}).Warn("failed to send webhook, retrying")
+		if !d.wait(d.retry.backoff(attempt)) {
+			break
+		}
+	}
+
+	delivery.Status = DeliveryDead
+	logger.WithFields(log.Fields{"attempts": delivery.Attempts, "err": delivery.LastError}).Error("failed to send webhook")
+	if err := d.dead.Add(context.Background(), delivery); err != nil {
+		logger.WithFields(log.Fields{"err": err}).Error("failed to store the dead letter of the webhook")
+	}
+	d.onUpdate(delivery)
+}
+
+// wait waits for duration, and returns false when the dispatcher is closed first
+func (d *Dispatcher) wait(duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-d.ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// updates records the updates of the deliveries
+type updates struct {
+	mu     sync.Mutex
+	values []Delivery
+}
+
+func (u *updates) add(delivery Delivery) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.values = append(u.values, delivery)
+}
+
+func (u *updates) last() Delivery {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.values[len(u.values)-1]
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+	var (
+		failures atomic.Int32
+		status   atomic.Int32
+		received atomic.Int32
+	)
+	status.Store(http.StatusServiceUnavailable)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if failures.Add(-1) >= 0 {
+			w.WriteHeader(int(status.Load()))
+			return
+		}
+		received.Add(1)
+	}))
+	defer srv.Close()
+	sender := NewSender(srv.URL, "", time.Second)
+	event := Event{Type: EventSessionExpired, SessionID: "8f1c4b4e-7c0c-4d4e-9d59-6e0b2d1f6a11", Status: "expired"}
+
+	// the failed attempts are retried
+	var got updates
+	dead := NewMemoryDeadLetters(0)
+	d := NewDispatcher(Retry{Attempts: 3, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}, dead, got.add)
+	failures.Store(2)
+	delivery := d.Deliver(sender, "", event)
+	assert.Equal(t, DeliveryPending, delivery.Status)
+	require.Eventually(t, func() bool { return got.last().Status == DeliveryDelivered }, time.Second, time.Millisecond)
+	d.Close()
+	assert.Equal(t, int32(1), received.Load())
+	assert.Equal(t, 3, got.last().Attempts)
+	assert.Empty(t, got.last().LastError)
+
+	// the deliveries that fail every attempt are moved to the dead letters, and replayed from them
+	d = NewDispatcher(Retry{Attempts: 2, Backoff: time.Millisecond}, dead, got.add)
+	failures.Store(2)
+	delivery = d.Deliver(sender, "kyc", event)
+	require.Eventually(t, func() bool { return got.last().Status == DeliveryDead }, time.Second, time.Millisecond)
+	letters, err := d.DeadLetters(ctx)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	assert.Equal(t, delivery.ID, letters[0].ID)
+	assert.Equal(t, "kyc", letters[0].Webhook)
+	assert.Equal(t, 2, letters[0].Attempts)
+	assert.Equal(t, "unexpected status code from webhook: 503", letters[0].LastError)
+
+	_, err = d.Replay(ctx, delivery.ID, func(string) *Sender { return nil })
+	assert.ErrorIs(t, err, ErrWebhookNotFound)
+	replayed, err := d.Replay(ctx, delivery.ID, func(name string) *Sender {
+		assert.Equal(t, "kyc", name)
+		return sender
+	})
+	require.NoError(t, err)
+	assert.Equal(t, DeliveryPending, replayed.Status)
+	_, err = d.Replay(ctx, delivery.ID, func(string) *Sender { return sender })
+	assert.ErrorIs(t, err, ErrDeliveryNotFound)
+	require.Eventually(t, func() bool { return got.last().Status == DeliveryDelivered }, time.Second, time.Millisecond)
+	d.Close()
+	assert.Equal(t, int32(2), received.Load())
+	assert.Equal(t, 3, got.last().Attempts)
+	letters, err = d.DeadLetters(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, letters)
+
+	// the rejected deliveries are not retried
+	d = NewDispatcher(Retry{Attempts: 5, Backoff: time.Millisecond}, dead, got.add)
+	status.Store(http.StatusBadRequest)
+	failures.Store(5)
+	d.Deliver(sender, "", event)
+	require.Eventually(t, func() bool { return got.last().Status == DeliveryDead }, time.Second, time.Millisecond)
+	d.Close()
+	assert.Equal(t, 1, got.last().Attempts)
+
+	// the deliveries waiting for a retry are moved to the dead letters when the dispatcher is closed
+	d = NewDispatcher(Retry{Attempts: 5, Backoff: time.Hour}, dead, got.add)
+	status.Store(http.StatusTooManyRequests)
+	failures.Store(5)
+	d.Deliver(sender, "", event)
+	require.Eventually(t, func() bool { return got.last().Attempts == 1 }, time.Second, time.Millisecond)
+	d.Close()
+	assert.Equal(t, DeliveryDead, got.last().Status)
+	letters, err = d.DeadLetters(ctx)
+	require.NoError(t, err)
+	assert.Len(t, letters, 2)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	retry := Retry{Attempts: 10, Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	for n, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 60: 5 * time.Second} {
+		assert.Equal(t, want, retry.backoff(n), n)
+	}
+	assert.Equal(t, 1, Retry{}.attempts())
+}
+
+func TestFileDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "dead-letters.jsonl")
+
+	letters, err := OpenFileDeadLetters(path, 0)
+	require.NoError(t, err)
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, letters.Add(ctx, Delivery{ID: id, Status: DeliveryDead, Event: Event{Type: EventSessionExpired}}))
+	}
+	removed, err := letters.Remove(ctx, "b")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = letters.Remove(ctx, "b")
+	require.NoError(t, err)
+	assert.False(t, removed)
+	require.NoError(t, letters.Close())
+
+	// the removed deliveries are compacted away when the dead letters are opened again, keeping the last size ones
+	letters, err = OpenFileDeadLetters(path, 1)
+	require.NoError(t, err)
+	deliveries, err := letters.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "c", deliveries[0].ID)
+	require.NoError(t, letters.Close())
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, bytes.Count(b, []byte("\n")))
+
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o600))
+	_, err = OpenFileDeadLetters(path, 0)
+	assert.ErrorContains(t, err, "invalid dead letter at line 1")
+}
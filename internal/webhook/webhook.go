@@ -0,0 +1,114 @@
+// Package webhook delivers the events of the sessions to the endpoint configured by the integrator
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader is the header with the HMAC-SHA256 of the body of the events, signed with the webhook secret
+	SignatureHeader = "X-Verifier-Signature"
+	signaturePrefix = "sha256="
+
+	// EventSessionExpired is sent when a session expires without a callback and its QR code was not fetched
+	EventSessionExpired = "session.expired"
+	// EventSessionAbandoned is sent when a session expires without a callback after its QR code was fetched by a wallet
+	EventSessionAbandoned = "session.abandoned"
+	// EventVerificationStale is sent when a successful verification relies on a state that was replaced since
+	EventVerificationStale = "verification.stale"
+	// EventVerificationRevoked is sent when the credential of a successful verification may have been revoked since
+	EventVerificationRevoked = "verification.revoked"
+)
+
+// Event is the body of the requests sent to the webhook
+type Event struct {
+	Type      string    `json:"type"`
+	SessionID string    `json:"sessionID"`
+	Status    string    `json:"status"`
+	Time      time.Time `json:"time"`
+	// Metadata is the metadata of the sign-in of the session
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Sender posts the events to the url of the webhook
+type Sender struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+// NewSender creates a Sender of the events to url. The events are signed with secret when it is not empty.
+func NewSender(url, secret string, timeout time.Duration) *Sender {
+	return &Sender{
+		url:    url,
+		secret: []byte(secret),
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Send posts the event to the webhook, any response other than 2xx is an error
+func (s *Sender) Send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(s.secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return &StatusError{StatusCode: resp.StatusCode}
+	}
+	return nil
+}
+
+// StatusError is the error of the events the webhook answered with another status than 2xx
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code from webhook: %d", e.StatusCode)
+}
+
+// Retryable reports whether the event may be accepted later: the other client errors are rejections of the event
+func (e *StatusError) Retryable() bool {
+	return e.StatusCode >= http.StatusInternalServerError ||
+		e.StatusCode == http.StatusRequestTimeout || e.StatusCode == http.StatusTooManyRequests
+}
+
+// Sign returns the value of the SignatureHeader of body
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the value of the SignatureHeader of body, so integrators can reject events not sent by the verifier
+func Verify(secret, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	var (
+		body      []byte
+		signature string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	event := Event{Type: EventSessionExpired, SessionID: "8f1c4b4e-7c0c-4d4e-9d59-6e0b2d1f6a11", Status: "expired", Time: time.Date(2025, 6, 16, 10, 0, 0, 0, time.UTC)}
+	require.NoError(t, NewSender(srv.URL, "secret", time.Second).Send(context.Background(), event))
+
+	var got Event
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, event, got)
+	assert.True(t, Verify([]byte("secret"), body, signature))
+	assert.False(t, Verify([]byte("other"), body, signature))
+	assert.False(t, Verify([]byte("secret"), append(body, ' '), signature))
+
+	require.NoError(t, NewSender(srv.URL, "", time.Second).Send(context.Background(), event))
+	assert.Empty(t, signature)
+
+	assert.ErrorContains(t, NewSender(srv.URL+"/failing", "secret", time.Second).Send(context.Background(), event), "500")
+}
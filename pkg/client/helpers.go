@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+)
+
+// Statuses of a session
+const (
+	StatusPending  = "pending"
+	StatusSuccess  = "success"
+	StatusError    = "error"
+	StatusConsumed = "consumed"
+)
+
+// DefaultPollInterval is the interval between two status requests of WaitForStatus
+const DefaultPollInterval = 2 * time.Second
+
+// DefaultBackoff is a Backoff for PollStatus that suits sessions answered by users, from a few seconds to a few minutes
+var DefaultBackoff = Backoff{Initial: 500 * time.Millisecond, Max: 10 * time.Second, Multiplier: 1.5}
+
+// ErrTokenKeyNotFound is returned when the key that signed a token is not in the key set
+var ErrTokenKeyNotFound = errors.New("token signing key not found")
+
+// APIError is a response of the verifier other than 200
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("verifier responded %d: %s", e.StatusCode, e.Message)
+}
+
+// Temporary reports whether the request can be retried later: 429 and 5xx responses
+func (e *APIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+func newAPIError(statusCode int, body []byte) *APIError {
+	var msg GenericErrorMessage
+	if err := json.Unmarshal(body, &msg); err != nil || msg.Message == "" {
+		msg.Message = strings.TrimSpace(string(body))
+	}
+	return &APIError{StatusCode: statusCode, Message: msg.Message}
+}
+
+// SignIn creates a session. Responses other than 200 are returned as an *APIError.
+func SignIn(ctx context.Context, c ClientWithResponsesInterface, params *SignInParams, body SignInJSONRequestBody, reqEditors ...RequestEditorFn) (*SingInResponse, error) {
+	resp, err := c.SignInWithResponse(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		return nil, newAPIError(resp.StatusCode(), resp.Body)
+	}
+	return resp.JSON200, nil
+}
+
+// Backoff is the schedule of the status requests of PollStatus: the first wait is Initial, and every wait is Multiplier
+// times the previous one, up to Max
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+func (b Backoff) next(wait time.Duration) time.Duration {
+	if wait <= 0 {
+		wait = b.Initial
+	} else if b.Multiplier > 1 {
+		wait = time.Duration(float64(wait) * b.Multiplier)
+	}
+	if b.Max > 0 && wait > b.Max {
+		wait = b.Max
+	}
+	if wait <= 0 {
+		wait = DefaultPollInterval
+	}
+	return wait
+}
+
+// PollStatus polls the status of the session until it is not pending, or ctx is done, waiting longer between requests
+// as set by backoff. Network errors, 429 and 5xx responses are retried, the other responses are returned as an *APIError.
+// Set a deadline on ctx, as the sessions of the users that never scan the QR code stay pending until they expire.
+func PollStatus(ctx context.Context, c ClientWithResponsesInterface, sessionID uuid.UUID, backoff Backoff, reqEditors ...RequestEditorFn) (*StatusResponse, error) {
+	var wait time.Duration
+	for {
+		resp, err := c.StatusWithResponse(ctx, &StatusParams{SessionID: sessionID}, reqEditors...)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+		case resp.StatusCode() != http.StatusOK || resp.JSON200 == nil:
+			if apiErr := newAPIError(resp.StatusCode(), resp.Body); !apiErr.Temporary() {
+				return nil, apiErr
+			}
+		case resp.JSON200.Status != StatusPending:
+			return resp.JSON200, nil
+		}
+
+		wait = backoff.next(wait)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// WaitForStatus polls the status of the session every interval until it is not pending, or ctx is done, see PollStatus
+func WaitForStatus(ctx context.Context, c ClientWithResponsesInterface, sessionID uuid.UUID, interval time.Duration, reqEditors ...RequestEditorFn) (*StatusResponse, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	return PollStatus(ctx, c, sessionID, Backoff{Initial: interval, Max: interval}, reqEditors...)
+}
+
+// TokenClaims are the claims of the token issued by the verifier after a successful verification
+type TokenClaims struct {
+	jwt.Claims
+	Scopes     []TokenScope `json:"scopes"`
+	Nullifiers []string     `json:"nullifiers,omitempty"`
+}
+
+// TokenScope summarizes a scope of the verification request
+type TokenScope struct {
+	ID        uint32 `json:"id"`
+	CircuitID string `json:"circuitId"`
+	Type      string `json:"type,omitempty"`
+}
+
+// VerifyToken verifies the signature of a token with the key set of the verifier, from /.well-known/jwks.json
+// or /tenants/{tenantID}/.well-known/jwks.json, and validates its issuer, audience and lifetime.
+func VerifyToken(token string, keys JWKS, expected jwt.Expected) (*TokenClaims, error) {
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed.Headers) == 0 {
+		return nil, ErrTokenKeyNotFound
+	}
+	matching := keys.Key(parsed.Headers[0].KeyID)
+	if len(matching) == 0 {
+		return nil, ErrTokenKeyNotFound
+	}
+
+	var claims TokenClaims
+	if err := parsed.Claims(matching[0].Key, &claims); err != nil {
+		return nil, err
+	}
+	if expected.Time.IsZero() {
+		expected.Time = time.Now()
+	}
+	if err := claims.ValidateWithLeeway(expected, jwt.DefaultLeeway); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+// SignInLinkURL returns a sign-in link of the verifier at server for the query template, signed with apiKey so it can be
+// opened from a plain hyperlink. The link expires at expires, or never when expires is zero.
+func SignInLinkURL(server, apiKey, templateID, chainID string, expires time.Time) (string, error) {
+	u, err := url.Parse(strings.TrimSuffix(server, "/") + "/sign-in/link")
+	if err != nil {
+		return "", err
+	}
+	var expiresAt int64
+	if !expires.IsZero() {
+		expiresAt = expires.Unix()
+	}
+
+	mac := hmac.New(sha256.New, []byte(apiKey))
+	_, _ = fmt.Fprintf(mac, "%s\n%s\n%d", templateID, chainID, expiresAt)
+	query := url.Values{}
+	query.Set("templateId", templateID)
+	query.Set("chainId", chainID)
+	if expiresAt != 0 {
+		query.Set("expires", strconv.FormatInt(expiresAt, 10))
+	}
+	query.Set("signature", hex.EncodeToString(mac.Sum(nil)))
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+)
+
+func TestWaitForStatus(t *testing.T) {
+	sessionID := uuid.New()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, sessionID.String(), r.URL.Query().Get("sessionID"))
+		status := StatusPending
+		if calls.Add(1) == 3 {
+			status = StatusSuccess
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(StatusResponse{Status: status})
+	}))
+	defer server.Close()
+
+	c, err := NewClientWithResponses(server.URL)
+	require.NoError(t, err)
+
+	status, err := WaitForStatus(context.Background(), c, sessionID, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, StatusSuccess, status.Status)
+	assert.Equal(t, int32(3), calls.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls.Store(-10)
+	_, err = WaitForStatus(ctx, c, sessionID, time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestPollStatus(t *testing.T) {
+	sessionID := uuid.New()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(GenericErrorMessage{Message: "read-only replica"})
+		case 2:
+			_ = json.NewEncoder(w).Encode(StatusResponse{Status: StatusPending})
+		default:
+			_ = json.NewEncoder(w).Encode(StatusResponse{Status: StatusSuccess})
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClientWithResponses(server.URL)
+	require.NoError(t, err)
+
+	status, err := PollStatus(context.Background(), c, sessionID, Backoff{Initial: time.Millisecond, Max: 2 * time.Millisecond, Multiplier: 2})
+	require.NoError(t, err)
+	assert.Equal(t, StatusSuccess, status.Status)
+	assert.Equal(t, int32(3), calls.Load())
+
+	notFound := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(GenericErrorMessage{Message: "session not found"})
+	}))
+	defer notFound.Close()
+	c, err = NewClientWithResponses(notFound.URL)
+	require.NoError(t, err)
+	_, err = PollStatus(context.Background(), c, sessionID, DefaultBackoff)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "session not found", apiErr.Message)
+}
+
+func TestBackoff(t *testing.T) {
+	b := Backoff{Initial: time.Second, Max: 3 * time.Second, Multiplier: 2}
+	assert.Equal(t, time.Second, b.next(0))
+	assert.Equal(t, 2*time.Second, b.next(time.Second))
+	assert.Equal(t, 3*time.Second, b.next(2*time.Second))
+	assert.Equal(t, time.Second, Backoff{Initial: time.Second}.next(time.Second))
+	assert.Equal(t, DefaultPollInterval, Backoff{}.next(0))
+}
+
+func TestSignIn(t *testing.T) {
+	sessionID := uuid.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("X-API-Key") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(GenericErrorMessage{Message: "invalid api key"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(SingInResponse{QrCode: "iden3comm://?request_uri=https://verifier.example.com/qr-store?id=1", SessionID: sessionID})
+	}))
+	defer server.Close()
+
+	c, err := NewClientWithResponses(server.URL)
+	require.NoError(t, err)
+	body := SignInJSONRequestBody{Scope: []ScopeRequest{{Id: 1, CircuitId: "credentialAtomicQuerySigV2"}}}
+	key := "key"
+
+	resp, err := SignIn(context.Background(), c, &SignInParams{XAPIKey: &key}, body)
+	require.NoError(t, err)
+	assert.Equal(t, sessionID, resp.SessionID)
+
+	_, err = SignIn(context.Background(), c, &SignInParams{}, body)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.Equal(t, "invalid api key", apiErr.Message)
+	assert.False(t, apiErr.Temporary())
+}
+
+func TestVerifyToken(t *testing.T) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key := jose.JSONWebKey{Key: private, KeyID: "key-1", Algorithm: string(jose.ES256), Use: "sig"}
+	keys := JWKS{Keys: []jose.JSONWebKey{key.Public()}}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, (&jose.SignerOptions{}).WithType("JWT"))
+	require.NoError(t, err)
+	now := time.Now()
+	token, err := jwt.Signed(signer).Claims(TokenClaims{
+		Claims: jwt.Claims{
+			ID:       uuid.NewString(),
+			Issuer:   "https://verifier.example.com",
+			Subject:  "did:iden3:polygon:amoy:x6x5sor7zpxixYDNyDZKnh3oJZRj7Vzn9jzoCNoxc",
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		Scopes: []TokenScope{{ID: 1, CircuitID: "credentialAtomicQuerySigV2", Type: "KYCAgeCredential"}},
+	}).CompactSerialize()
+	require.NoError(t, err)
+
+	claims, err := VerifyToken(token, keys, jwt.Expected{Issuer: "https://verifier.example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "did:iden3:polygon:amoy:x6x5sor7zpxixYDNyDZKnh3oJZRj7Vzn9jzoCNoxc", claims.Subject)
+	assert.Equal(t, []TokenScope{{ID: 1, CircuitID: "credentialAtomicQuerySigV2", Type: "KYCAgeCredential"}}, claims.Scopes)
+
+	_, err = VerifyToken(token, keys, jwt.Expected{Issuer: "https://other.example.com"})
+	assert.ErrorIs(t, err, jwt.ErrInvalidIssuer)
+
+	_, err = VerifyToken(token, JWKS{}, jwt.Expected{})
+	assert.ErrorIs(t, err, ErrTokenKeyNotFound)
+
+	_, err = VerifyToken(token, keys, jwt.Expected{Time: now.Add(2 * time.Hour)})
+	assert.ErrorIs(t, err, jwt.ErrExpired)
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/0xPolygonID/verifier-backend/internal/webhook"
+)
+
+// WebhookSignatureHeader is the header with the signature of the events sent to the session webhook
+const WebhookSignatureHeader = webhook.SignatureHeader
+
+// maxWebhookBodySize is the size of the largest event accepted by ParseWebhookEvent
+const maxWebhookBodySize = 1 << 16
+
+// ErrInvalidWebhookSignature is returned when an event was not signed with the webhook secret
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+// WebhookEvent is an event sent to the session webhook, session.expired or session.abandoned
+type WebhookEvent = webhook.Event
+
+// VerifyWebhookSignature checks the value of the WebhookSignatureHeader of body with the webhook secret
+func VerifyWebhookSignature(secret, body []byte, signature string) bool {
+	return webhook.Verify(secret, body, signature)
+}
+
+// ParseWebhookEvent reads the event of a request to the session webhook, after checking its signature with the webhook secret
+func ParseWebhookEvent(r *http.Request, secret []byte) (*WebhookEvent, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+	if err != nil {
+		return nil, err
+	}
+	if !VerifyWebhookSignature(secret, body, r.Header.Get(WebhookSignatureHeader)) {
+		return nil, ErrInvalidWebhookSignature
+	}
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygonID/verifier-backend/internal/webhook"
+)
+
+func TestParseWebhookEvent(t *testing.T) {
+	event := WebhookEvent{Type: webhook.EventSessionExpired, SessionID: "8f1c4b4e-7c0c-4d4e-9d59-6e0b2d1f6a11", Status: "expired", Time: time.Date(2025, 6, 16, 10, 0, 0, 0, time.UTC)}
+	body, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	r.Header.Set(WebhookSignatureHeader, webhook.Sign([]byte("secret"), body))
+	got, err := ParseWebhookEvent(r, []byte("secret"))
+	require.NoError(t, err)
+	assert.Equal(t, event, *got)
+
+	r = httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	r.Header.Set(WebhookSignatureHeader, webhook.Sign([]byte("other"), body))
+	_, err = ParseWebhookEvent(r, []byte("secret"))
+	assert.ErrorIs(t, err, ErrInvalidWebhookSignature)
+
+	assert.True(t, VerifyWebhookSignature([]byte("secret"), body, webhook.Sign([]byte("secret"), body)))
+	assert.False(t, VerifyWebhookSignature([]byte("secret"), body, ""))
+}
@@ -0,0 +1,286 @@
+package verifier
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/go-schema-processor/v2/verifiable"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+)
+
+// v2Operators are the operators of the credentialAtomicQuerySigV2 and credentialAtomicQueryMTPV2 circuits,
+// the credentialAtomicQueryV3 circuits support all the operators
+var v2Operators = map[string]bool{
+	"$eq":  true,
+	"$lt":  true,
+	"$gt":  true,
+	"$in":  true,
+	"$nin": true,
+	"$ne":  true,
+}
+
+// queryOperators are the operators that can be used in the credentialSubject of the queries
+var queryOperators = map[string]bool{
+	"$eq":         true,
+	"$lt":         true,
+	"$gt":         true,
+	"$in":         true,
+	"$nin":        true,
+	"$ne":         true,
+	"$lte":        true,
+	"$gte":        true,
+	"$between":    true,
+	"$nonbetween": true,
+	"$exists":     true,
+}
+
+// orderOperators compare the value of the field, so they expect numbers, or dates that the wallets convert to numbers
+var orderOperators = map[string]bool{
+	"$lt":         true,
+	"$gt":         true,
+	"$lte":        true,
+	"$gte":        true,
+	"$between":    true,
+	"$nonbetween": true,
+}
+
+// maxQueryValues is the size of the value array of the query circuits, the most values $in and $nin can take
+const maxQueryValues = 64
+
+// Scope is a proof requested by a session, its query is checked against its circuit
+type Scope struct {
+	ID        uint32
+	CircuitID string
+	Query     map[string]interface{}
+	// Params are the params of the circuit, the nullifierSessionID of the V3 circuits
+	Params *map[string]interface{}
+}
+
+// IsV3Operator returns whether the operator can only be used with the credentialAtomicQueryV3 circuits
+func IsV3Operator(operator string) bool {
+	return queryOperators[operator] && !v2Operators[operator]
+}
+
+// ValidateScopes checks the scopes of an off-chain request, or of an on-chain one when onChain is set, so the
+// requests the wallets cannot prove are rejected before their QR codes are created
+func ValidateScopes(onChain bool, scopes []Scope) error {
+	reqIds := make(map[uint32]bool, 0)
+	for _, scope := range scopes {
+		if reqIds[scope.ID] {
+			return i18n.New(i18n.CodeScopeIDNotUnique, scope.ID)
+		}
+		reqIds[scope.ID] = true
+
+		if scope.ID <= 0 {
+			return i18n.New(i18n.CodeFieldEmpty, "scope id")
+		}
+
+		if scope.CircuitID == "" {
+			return i18n.New(i18n.CodeFieldEmpty, "circuitId")
+		}
+
+		circuitID := circuits.CircuitID(scope.CircuitID)
+		if !onChain {
+			if circuitID != circuits.AtomicQuerySigV2CircuitID && circuitID != circuits.AtomicQueryMTPV2CircuitID && circuitID != circuits.AtomicQueryV3CircuitID {
+				return i18n.New(i18n.CodeCircuitIDNotSupported, scope.CircuitID, circuits.AtomicQuerySigV2CircuitID, circuits.AtomicQueryMTPV2CircuitID, circuits.AtomicQueryV3CircuitID)
+			}
+		}
+
+		if onChain {
+			if circuitID != circuits.AtomicQuerySigV2OnChainCircuitID && circuitID != circuits.AtomicQueryMTPV2OnChainCircuitID && circuitID != circuits.AtomicQueryV3OnChainCircuitID {
+				return i18n.New(i18n.CodeCircuitIDNotSupported, scope.CircuitID, circuits.AtomicQuerySigV2OnChainCircuitID, circuits.AtomicQueryMTPV2OnChainCircuitID, circuits.AtomicQueryV3OnChainCircuitID)
+			}
+		}
+
+		if scope.Query == nil {
+			return i18n.New(i18n.CodeFieldEmpty, "query")
+		}
+
+		if scope.Query["context"] == nil || scope.Query["context"] == "" {
+			return i18n.New(i18n.CodeQueryFieldEmpty, "context")
+		}
+
+		if scope.Query["type"] == nil || scope.Query["type"] == "" {
+			return i18n.New(i18n.CodeQueryFieldEmpty, "type")
+		}
+
+		if scope.Query["allowedIssuers"] == nil {
+			return i18n.New(i18n.CodeQueryFieldEmpty, "allowedIssuers")
+		}
+
+		if err := ValidateQuery(scope.ID, circuitID, scope.Query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateQuery checks the credentialSubject and the proofType of the query of a scope against its circuit,
+// so the queries the wallets cannot prove are rejected on sign-in
+func ValidateQuery(scopeID uint32, circuitID circuits.CircuitID, query map[string]interface{}) error {
+	if err := validateProofType(scopeID, circuitID, query["proofType"]); err != nil {
+		return err
+	}
+
+	subject, ok := query["credentialSubject"].(map[string]interface{})
+	if !ok || len(subject) == 0 {
+		return nil
+	}
+	if len(subject) > 1 {
+		return i18n.New(i18n.CodeQueryMultipleFields, scopeID, len(subject))
+	}
+	for field, value := range subject {
+		expression, ok := value.(map[string]interface{})
+		if !ok {
+			return i18n.New(i18n.CodeQueryFieldInvalid, field, scopeID)
+		}
+		// an empty expression is a selective disclosure, supported by all the circuits
+		if len(expression) == 0 {
+			return nil
+		}
+		if len(expression) > 1 {
+			return i18n.New(i18n.CodeQueryMultipleOperators, field, scopeID, len(expression))
+		}
+		for operator, value := range expression {
+			if err := validateOperator(scopeID, circuitID, field, operator, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateOperator(scopeID uint32, circuitID circuits.CircuitID, field, operator string, value interface{}) error {
+	if !queryOperators[operator] {
+		return i18n.New(i18n.CodeQueryOperatorUnknown, operator, field, scopeID)
+	}
+	if !isV3Circuit(circuitID) && !v2Operators[operator] {
+		return i18n.New(i18n.CodeQueryOperatorNotSupported, operator, field, scopeID, circuitID)
+	}
+
+	values, isArray := value.([]interface{})
+	switch operator {
+	case "$in", "$nin":
+		if !isArray || len(values) == 0 {
+			return i18n.New(i18n.CodeQueryValueArray, operator, field, scopeID)
+		}
+		if len(values) > maxQueryValues {
+			return i18n.New(i18n.CodeQueryValueArrayTooLong, operator, field, scopeID, maxQueryValues, len(values))
+		}
+		for _, v := range values {
+			if !isScalar(v) {
+				return i18n.New(i18n.CodeQueryValueArrayItems, operator, field, scopeID)
+			}
+		}
+	case "$between", "$nonbetween":
+		if !isArray || len(values) != 2 {
+			return i18n.New(i18n.CodeQueryValueRange, operator, field, scopeID)
+		}
+		for _, v := range values {
+			if !isOrdered(v) {
+				return i18n.New(i18n.CodeQueryValueNumber, operator, field, scopeID, queryValue(v))
+			}
+		}
+		low, lowOk := toFloat(values[0])
+		high, highOk := toFloat(values[1])
+		if lowOk && highOk && low > high {
+			return i18n.New(i18n.CodeQueryValueRangeOrder, operator, field, scopeID, queryValue(values[0]), queryValue(values[1]))
+		}
+	case "$exists":
+		if _, ok := value.(bool); !ok {
+			return i18n.New(i18n.CodeQueryValueBoolean, operator, field, scopeID)
+		}
+	default:
+		if !isScalar(value) {
+			return i18n.New(i18n.CodeQueryValueScalar, operator, field, scopeID)
+		}
+		if orderOperators[operator] && !isOrdered(value) {
+			return i18n.New(i18n.CodeQueryValueNumber, operator, field, scopeID, queryValue(value))
+		}
+	}
+	return nil
+}
+
+// isScalar reports whether a value of a query is a single value, not an array, an object or null
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case nil, []interface{}, map[string]interface{}:
+		return false
+	default:
+		return true
+	}
+}
+
+// isOrdered reports whether a value of a query can be compared: a number, or a string holding a number or a date
+func isOrdered(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v != ""
+	case bool:
+		return false
+	default:
+		_, ok := toFloat(value)
+		return ok
+	}
+}
+
+// queryValue formats a value of a query as it was written, json numbers are decoded as floats
+func queryValue(value interface{}) interface{} {
+	if v, ok := value.(float64); ok {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return value
+}
+
+// toFloat returns the value of a query as a number, when it is one
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// validateProofType checks that the proofType of a query, when it is set, can be proved with the circuit of the scope
+func validateProofType(scopeID uint32, circuitID circuits.CircuitID, value interface{}) error {
+	if value == nil || value == "" {
+		return nil
+	}
+	proofType, _ := value.(string)
+	allowed := proofTypes(circuitID)
+	for _, t := range allowed {
+		if proofType == t {
+			return nil
+		}
+	}
+	sort.Strings(allowed)
+	return i18n.New(i18n.CodeQueryProofTypeInvalid, value, scopeID, circuitID, strings.Join(allowed, ", "))
+}
+
+func proofTypes(circuitID circuits.CircuitID) []string {
+	switch circuitID {
+	case circuits.AtomicQuerySigV2CircuitID, circuits.AtomicQuerySigV2OnChainCircuitID:
+		return []string{string(verifiable.BJJSignatureProofType)}
+	case circuits.AtomicQueryMTPV2CircuitID, circuits.AtomicQueryMTPV2OnChainCircuitID:
+		return []string{string(verifiable.Iden3SparseMerkleTreeProofType)}
+	default:
+		return []string{string(verifiable.BJJSignatureProofType), string(verifiable.Iden3SparseMerkleTreeProofType)}
+	}
+}
+
+func isV3Circuit(circuitID circuits.CircuitID) bool {
+	return circuitID == circuits.AtomicQueryV3CircuitID || circuitID == circuits.AtomicQueryV3OnChainCircuitID
+}
@@ -0,0 +1,56 @@
+package verifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/patrickmn/go-cache"
+)
+
+// Record is a session kept in a Store: its request, and its result once its callback was handled
+type Record struct {
+	Request   protocol.AuthorizationRequestMessage `json:"request"`
+	Result    *Result                              `json:"result,omitempty"`
+	ExpiresAt time.Time                            `json:"expiresAt"`
+}
+
+// Store keeps the sessions of a Verifier until they expire. A store shared by several instances of a service lets any
+// of them handle the callbacks of the sessions created by the others.
+type Store interface {
+	// Get returns the session id
+	Get(ctx context.Context, id string) (Record, bool, error)
+	// Set stores the session id until it expires
+	Set(ctx context.Context, id string, record Record) error
+}
+
+// MemoryStore is a Store that keeps the sessions in memory
+type MemoryStore struct {
+	cache *cache.Cache
+}
+
+// NewMemoryStore creates a MemoryStore, removing the expired sessions every minute
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{cache: cache.New(cache.NoExpiration, time.Minute)}
+}
+
+// Get returns the session id
+func (m *MemoryStore) Get(_ context.Context, id string) (Record, bool, error) {
+	item, ok := m.cache.Get(id)
+	if !ok {
+		return Record{}, false, nil
+	}
+	return item.(Record), true, nil
+}
+
+// Set stores the session id until it expires
+func (m *MemoryStore) Set(_ context.Context, id string, record Record) error {
+	ttl := time.Until(record.ExpiresAt)
+	if ttl <= 0 {
+		// go-cache keeps the items without a positive duration forever
+		m.cache.Delete(id)
+		return nil
+	}
+	m.cache.Set(id, record, ttl)
+	return nil
+}
@@ -0,0 +1,282 @@
+// Package verifier embeds the verification of the sessions in Go services, without running the HTTP server of the
+// verifier: it builds the authorization requests of the sessions, verifies the tokens that the wallets post to their
+// callbacks and reports their status. The service serves the QR codes and the callbacks with its own routes.
+//
+//	v := verifier.New(verifier.Config{
+//		SenderDIDs:  map[string]string{"80002": senderDID},
+//		CallbackURL: "https://example.com/verification/callback",
+//	}, authVerifier)
+//	session, err := v.CreateRequest(ctx, verifier.Request{ChainID: "80002", Scopes: scopes})
+//	// in the callback handler, with the sessionID query param and the body of the request
+//	result, err := v.HandleCallback(ctx, sessionID, token)
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	"github.com/iden3/iden3comm/v2/protocol"
+
+	"github.com/0xPolygonID/verifier-backend/internal/i18n"
+	"github.com/0xPolygonID/verifier-backend/internal/messages"
+)
+
+// Statuses of a session
+const (
+	StatusPending = "pending"
+	StatusSuccess = "success"
+	StatusError   = "error"
+)
+
+const (
+	// DefaultSessionTTL is how long the sessions are kept when Config.SessionTTL is not set
+	DefaultSessionTTL = time.Hour
+	// DefaultReason is the reason of the requests when neither the request nor the Config set one
+	DefaultReason = "for testing purposes"
+	// StateTransitionDelay is how long the states of the identities are accepted after they were replaced
+	StateTransitionDelay = 5 * time.Minute
+)
+
+var (
+	// ErrSessionNotFound is returned for the sessions that were not created or that expired
+	ErrSessionNotFound = errors.New("session not found")
+	// ErrSessionAnswered is returned for the callbacks of the sessions that already have a result
+	ErrSessionAnswered = errors.New("session already answered")
+)
+
+// ProofVerifier verifies the tokens posted to the callbacks against the requests of their sessions, the *auth.Verifier
+// of go-iden3-auth implements it
+type ProofVerifier interface {
+	FullVerify(ctx context.Context, token string, request protocol.AuthorizationRequestMessage,
+		opts ...pubsignals.VerifyOpt) (*protocol.AuthorizationResponseMessage, error)
+}
+
+// Config configures a Verifier
+type Config struct {
+	// SenderDIDs are the DIDs the requests are sent from, by chain id
+	SenderDIDs map[string]string
+	// CallbackURL is where the wallets post their tokens, the id of the session is added in the sessionID query param
+	CallbackURL string
+	// DefaultReason is the reason of the requests without one
+	DefaultReason string
+	// SessionTTL is how long the sessions wait for their callback and keep their result, DefaultSessionTTL when zero
+	SessionTTL time.Duration
+}
+
+// Verifier creates the requests of the sessions and verifies their callbacks
+type Verifier struct {
+	cfg    Config
+	proofs ProofVerifier
+	store  Store
+}
+
+// Option configures optional Verifier dependencies
+type Option func(*Verifier)
+
+// WithStore keeps the sessions in store, instead of in memory
+func WithStore(store Store) Option {
+	return func(v *Verifier) {
+		v.store = store
+	}
+}
+
+// New creates a Verifier that verifies the proofs of the callbacks with proofs
+func New(cfg Config, proofs ProofVerifier, opts ...Option) *Verifier {
+	if cfg.SessionTTL <= 0 {
+		cfg.SessionTTL = DefaultSessionTTL
+	}
+	v := &Verifier{cfg: cfg, proofs: proofs, store: NewMemoryStore()}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Request is the request of a session
+type Request struct {
+	ChainID string
+	// Reason is shown by the wallets, Config.DefaultReason when empty
+	Reason string
+	// Message is the message of the body of the request, shown by the wallets
+	Message string
+	// To is the DID of the only user allowed to answer, anyone when empty
+	To     string
+	Scopes []Scope
+}
+
+// Session is a session waiting for its callback
+type Session struct {
+	ID uuid.UUID
+	// Request is the authorization request of the session, the service shows it to the wallets in a QR code
+	Request   protocol.AuthorizationRequestMessage
+	ExpiresAt time.Time
+}
+
+// Result is the result of the verification of a session
+type Result struct {
+	Status  string        `json:"status"`
+	UserDID string        `json:"userDID,omitempty"`
+	Scopes  []ScopeResult `json:"scopes,omitempty"`
+	// Error is why the verification failed
+	Error string `json:"error,omitempty"`
+}
+
+// ScopeResult holds the nullifier of a scope proved with a credentialAtomicQueryV3 circuit
+type ScopeResult struct {
+	ID                 uint32 `json:"id"`
+	NullifierSessionID string `json:"nullifierSessionID"`
+	Nullifier          string `json:"nullifier"`
+}
+
+// CreateRequest validates the request and creates its session
+func (v *Verifier) CreateRequest(ctx context.Context, req Request) (Session, error) {
+	if req.ChainID == "" {
+		return Session{}, i18n.New(i18n.CodeFieldEmpty, "chainId")
+	}
+	if err := ValidateScopes(false, req.Scopes); err != nil {
+		return Session{}, err
+	}
+	senderDID, ok := v.cfg.SenderDIDs[req.ChainID]
+	if !ok {
+		return Session{}, i18n.New(i18n.CodeSenderNotFound, req.ChainID)
+	}
+	scopes := make([]protocol.ZeroKnowledgeProofRequest, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		request, err := messages.ProofRequest(scope.ID, scope.CircuitID, scope.Query, scope.Params)
+		if err != nil {
+			return Session{}, err
+		}
+		scopes = append(scopes, request)
+	}
+
+	sessionID := uuid.New()
+	callbackURL, err := url.Parse(v.cfg.CallbackURL)
+	if err != nil {
+		return Session{}, err
+	}
+	query := callbackURL.Query()
+	query.Set("sessionID", sessionID.String())
+	callbackURL.RawQuery = query.Encode()
+
+	session := Session{
+		ID: sessionID,
+		Request: messages.AuthRequest(messages.Request{
+			ID:          uuid.NewString(),
+			Reason:      v.reason(req.Reason),
+			Message:     req.Message,
+			From:        senderDID,
+			To:          req.To,
+			CallbackURL: callbackURL.String(),
+			Scope:       scopes,
+		}),
+		ExpiresAt: time.Now().Add(v.cfg.SessionTTL),
+	}
+	if err := v.store.Set(ctx, sessionID.String(), Record{Request: session.Request, ExpiresAt: session.ExpiresAt}); err != nil {
+		return Session{}, err
+	}
+	return session, nil
+}
+
+// HandleCallback verifies the token posted to the callback of the session and stores its result. The error of a
+// failed verification is returned with its result.
+func (v *Verifier) HandleCallback(ctx context.Context, sessionID uuid.UUID, token string) (Result, error) {
+	record, ok, err := v.store.Get(ctx, sessionID.String())
+	if err != nil {
+		return Result{}, err
+	}
+	if !ok {
+		return Result{}, ErrSessionNotFound
+	}
+	if record.Result != nil {
+		return *record.Result, ErrSessionAnswered
+	}
+
+	result, verifyErr := v.verify(ctx, token, record.Request)
+	record.Result = &result
+	if err := v.store.Set(ctx, sessionID.String(), record); err != nil {
+		return Result{}, err
+	}
+	return result, verifyErr
+}
+
+// Status returns the result of the session, pending until its callback was handled
+func (v *Verifier) Status(ctx context.Context, sessionID uuid.UUID) (Result, error) {
+	record, ok, err := v.store.Get(ctx, sessionID.String())
+	if err != nil {
+		return Result{}, err
+	}
+	if !ok {
+		return Result{}, ErrSessionNotFound
+	}
+	if record.Result == nil {
+		return Result{Status: StatusPending}, nil
+	}
+	return *record.Result, nil
+}
+
+func (v *Verifier) verify(ctx context.Context, token string, request protocol.AuthorizationRequestMessage) (Result, error) {
+	resp, err := v.proofs.FullVerify(ctx, token, request, pubsignals.WithAcceptedStateTransitionDelay(StateTransitionDelay))
+	if err == nil {
+		var scopes []ScopeResult
+		if scopes, err = ScopeResults(resp.Body.Scope); err == nil {
+			return Result{Status: StatusSuccess, UserDID: resp.From, Scopes: scopes}, nil
+		}
+	}
+	err = i18n.Wrap(err, i18n.CodeVerificationFailed, err.Error())
+	return Result{Status: StatusError, Error: err.Error()}, err
+}
+
+// reason returns the reason of a request, the configured default reason when the request does not set one
+func (v *Verifier) reason(reason string) string {
+	if reason != "" {
+		return reason
+	}
+	if v.cfg.DefaultReason != "" {
+		return v.cfg.DefaultReason
+	}
+	return DefaultReason
+}
+
+// ScopeResults returns the nullifiers of the scopes of a response proved with the credentialAtomicQueryV3 circuit, none
+// when the scopes were proved with other circuits
+func ScopeResults(scopes []protocol.ZeroKnowledgeProofResponse) ([]ScopeResult, error) {
+	// the responses without scopes answer the requests of /sign-in/auth, the verification rejects them when scopes were requested
+	if len(scopes) == 0 {
+		return []ScopeResult{}, nil
+	}
+
+	if scopes[0].CircuitID != string(circuits.AtomicQueryV3CircuitID) {
+		return []ScopeResult{}, nil
+	}
+
+	resp := make([]ScopeResult, 0, len(scopes))
+	for _, scope := range scopes {
+		ps := circuits.AtomicQueryV3PubSignals{}
+		if scope.CircuitID != string(circuits.AtomicQueryV3CircuitID) {
+			return []ScopeResult{}, nil
+		}
+
+		signals, err := json.Marshal(scope.PubSignals)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := ps.PubSignalsUnmarshal(signals); err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, ScopeResult{
+			ID:                 scope.ID,
+			NullifierSessionID: ps.NullifierSessionID.String(),
+			Nullifier:          ps.Nullifier.String(),
+		})
+	}
+
+	return resp, nil
+}
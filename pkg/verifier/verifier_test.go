@@ -0,0 +1,233 @@
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-circuits/v2"
+	"github.com/iden3/go-iden3-auth/v2/pubsignals"
+	"github.com/iden3/iden3comm/v2/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	senderDID = "did:iden3:polygon:amoy:x6x5sor7zpxixYDNyDZKnh3oJZRj7Vzn9jzoCNoxc"
+	userDID   = "did:polygonid:polygon:amoy:2qEATqfECVbCBzq9EhJpPSiv1xtJRpbMBKDaNM68Ci"
+)
+
+// proofVerifier accepts the token "valid" from userDID, and rejects the other ones
+type proofVerifier struct {
+	requests []protocol.AuthorizationRequestMessage
+}
+
+func (p *proofVerifier) FullVerify(_ context.Context, token string, request protocol.AuthorizationRequestMessage,
+	_ ...pubsignals.VerifyOpt,
+) (*protocol.AuthorizationResponseMessage, error) {
+	p.requests = append(p.requests, request)
+	if token != "valid" {
+		return nil, errors.New("invalid proof")
+	}
+	return &protocol.AuthorizationResponseMessage{From: userDID}, nil
+}
+
+func TestVerifier(t *testing.T) {
+	ctx := context.Background()
+	proofs := &proofVerifier{}
+	v := New(Config{
+		SenderDIDs:  map[string]string{"80002": senderDID},
+		CallbackURL: "https://example.com/verification/callback?tenant=acme",
+	}, proofs)
+	scopes := []Scope{{
+		ID:        1,
+		CircuitID: string(circuits.AtomicQuerySigV2CircuitID),
+		Query: jsonToMap(t, `{
+			"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+			"allowedIssuers": ["*"],
+			"type": "KYCAgeCredential",
+			"credentialSubject": {"birthday": {"$lt": 20000101}}
+		}`),
+	}}
+
+	// the invalid requests are rejected
+	_, err := v.CreateRequest(ctx, Request{Scopes: scopes})
+	assert.EqualError(t, err, "field chainId is empty")
+	_, err = v.CreateRequest(ctx, Request{ChainID: "137", Scopes: scopes})
+	assert.EqualError(t, err, "sender not found for chainID 137")
+	_, err = v.CreateRequest(ctx, Request{ChainID: "80002", Scopes: []Scope{{ID: 1, CircuitID: string(circuits.AtomicQuerySigV2OnChainCircuitID)}}})
+	assert.Error(t, err)
+
+	session, err := v.CreateRequest(ctx, Request{ChainID: "80002", Scopes: scopes})
+	require.NoError(t, err)
+	assert.Equal(t, senderDID, session.Request.From)
+	assert.Equal(t, DefaultReason, session.Request.Body.Reason)
+	require.Len(t, session.Request.Body.Scope, 1)
+	callbackURL, err := url.Parse(session.Request.Body.CallbackURL)
+	require.NoError(t, err)
+	assert.Equal(t, "/verification/callback", callbackURL.Path)
+	assert.Equal(t, url.Values{"tenant": {"acme"}, "sessionID": {session.ID.String()}}, callbackURL.Query())
+	assert.WithinDuration(t, time.Now().Add(DefaultSessionTTL), session.ExpiresAt, time.Second)
+
+	result, err := v.Status(ctx, session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Status: StatusPending}, result)
+
+	// the callback is verified against the request of the session
+	result, err = v.HandleCallback(ctx, session.ID, "valid")
+	require.NoError(t, err)
+	assert.Equal(t, Result{Status: StatusSuccess, UserDID: userDID, Scopes: []ScopeResult{}}, result)
+	assert.Equal(t, []protocol.AuthorizationRequestMessage{session.Request}, proofs.requests)
+	status, err := v.Status(ctx, session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, result, status)
+	_, err = v.HandleCallback(ctx, session.ID, "valid")
+	assert.ErrorIs(t, err, ErrSessionAnswered)
+
+	// the failed verifications are reported by the status
+	session, err = v.CreateRequest(ctx, Request{ChainID: "80002", Reason: "age check", Scopes: scopes})
+	require.NoError(t, err)
+	assert.Equal(t, "age check", session.Request.Body.Reason)
+	result, err = v.HandleCallback(ctx, session.ID, "forged")
+	assert.EqualError(t, err, "failed to verify the proof: invalid proof")
+	assert.Equal(t, Result{Status: StatusError, Error: "failed to verify the proof: invalid proof"}, result)
+	status, err = v.Status(ctx, session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusError, status.Status)
+
+	_, err = v.HandleCallback(ctx, uuid.New(), "valid")
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+	_, err = v.Status(ctx, uuid.New())
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	require.NoError(t, store.Set(ctx, "live", Record{ExpiresAt: time.Now().Add(time.Minute)}))
+	require.NoError(t, store.Set(ctx, "expired", Record{ExpiresAt: time.Now().Add(-time.Second)}))
+	_, ok, err := store.Get(ctx, "live")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	_, ok, err = store.Get(ctx, "expired")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestValidateQuery(t *testing.T) {
+	sig := circuits.AtomicQuerySigV2CircuitID
+	v3 := circuits.AtomicQueryV3CircuitID
+	tests := []struct {
+		name      string
+		circuitID circuits.CircuitID
+		query     string
+		err       string
+	}{
+		{name: "no subject", circuitID: sig, query: `{}`},
+		{name: "selective disclosure", circuitID: sig, query: `{"credentialSubject": {"birthday": {}}}`},
+		{name: "eq", circuitID: sig, query: `{"credentialSubject": {"birthday": {"$eq": 20000101}}}`},
+		{name: "in", circuitID: sig, query: `{"credentialSubject": {"country": {"$in": ["AR", "ES"]}}}`},
+		{name: "between v3", circuitID: v3, query: `{"credentialSubject": {"birthday": {"$between": [19900101, 20000101]}}}`},
+		{name: "exists v3", circuitID: v3, query: `{"credentialSubject": {"birthday": {"$exists": true}}}`},
+		{name: "proof type", circuitID: v3, query: `{"proofType": "Iden3SparseMerkleTreeProof"}`},
+		{
+			name: "unknown operator", circuitID: v3, query: `{"credentialSubject": {"birthday": {"$like": 2000}}}`,
+			err: "unknown operator $like of field birthday in scope 1",
+		},
+		{
+			name: "v3 operator", circuitID: sig, query: `{"credentialSubject": {"birthday": {"$lte": 20000101}}}`,
+			err: "the operator $lte of field birthday in scope 1 is not supported by the circuit credentialAtomicQuerySigV2, use credentialAtomicQueryV3",
+		},
+		{
+			name: "in scalar", circuitID: sig, query: `{"credentialSubject": {"country": {"$in": "AR"}}}`,
+			err: "the operator $in of field country in scope 1 expects an array of values",
+		},
+		{
+			name: "between three values", circuitID: v3, query: `{"credentialSubject": {"birthday": {"$between": [1, 2, 3]}}}`,
+			err: "the operator $between of field birthday in scope 1 expects an array of two values",
+		},
+		{
+			name: "between reversed", circuitID: v3, query: `{"credentialSubject": {"birthday": {"$between": [20000101, 19900101]}}}`,
+			err: "the operator $between of field birthday in scope 1 expects the lower bound 20000101 before the upper bound 19900101",
+		},
+		{
+			name: "nonbetween boolean", circuitID: v3, query: `{"credentialSubject": {"birthday": {"$nonbetween": [true, 20000101]}}}`,
+			err: "the operator $nonbetween of field birthday in scope 1 expects a number or a date, got true",
+		},
+		{
+			name: "lt boolean", circuitID: sig, query: `{"credentialSubject": {"birthday": {"$lt": false}}}`,
+			err: "the operator $lt of field birthday in scope 1 expects a number or a date, got false",
+		},
+		{name: "gte date", circuitID: v3, query: `{"credentialSubject": {"birthday": {"$gte": "2000-01-01T00:00:00Z"}}}`},
+		{
+			name: "in objects", circuitID: sig, query: `{"credentialSubject": {"country": {"$in": [{"code": "AR"}]}}}`,
+			err: "the operator $in of field country in scope 1 expects an array of single values",
+		},
+		{
+			name: "in too many values", circuitID: sig,
+			query: `{"credentialSubject": {"country": {"$in": [` + strings.Repeat(`"AR", `, 64) + `"ES"]}}}`,
+			err:   "the operator $in of field country in scope 1 accepts at most 64 values, got 65",
+		},
+		{
+			name: "nin empty", circuitID: sig, query: `{"credentialSubject": {"country": {"$nin": []}}}`,
+			err: "the operator $nin of field country in scope 1 expects an array of values",
+		},
+		{
+			name: "exists v2", circuitID: sig, query: `{"credentialSubject": {"birthday": {"$exists": true}}}`,
+			err: "the operator $exists of field birthday in scope 1 is not supported by the circuit credentialAtomicQuerySigV2, use credentialAtomicQueryV3",
+		},
+		{
+			name: "between mtp", circuitID: circuits.AtomicQueryMTPV2CircuitID, query: `{"credentialSubject": {"birthday": {"$between": [1, 2]}}}`,
+			err: "the operator $between of field birthday in scope 1 is not supported by the circuit credentialAtomicQueryMTPV2, use credentialAtomicQueryV3",
+		},
+		{
+			name: "exists string", circuitID: v3, query: `{"credentialSubject": {"birthday": {"$exists": "yes"}}}`,
+			err: "the operator $exists of field birthday in scope 1 expects true or false",
+		},
+		{
+			name: "eq array", circuitID: sig, query: `{"credentialSubject": {"birthday": {"$eq": [1]}}}`,
+			err: "the operator $eq of field birthday in scope 1 expects a single value",
+		},
+		{
+			name: "several fields", circuitID: sig, query: `{"credentialSubject": {"birthday": {"$eq": 1}, "country": {"$eq": "AR"}}}`,
+			err: "the credentialSubject of scope 1 queries 2 fields, only one field can be queried per scope",
+		},
+		{
+			name: "several operators", circuitID: sig, query: `{"credentialSubject": {"birthday": {"$gt": 1, "$lt": 2}}}`,
+			err: "the field birthday in scope 1 has 2 operators, only one operator can be used per field",
+		},
+		{
+			name: "field value", circuitID: sig, query: `{"credentialSubject": {"birthday": 20000101}}`,
+			err: "the field birthday in scope 1 must be an object with an operator, or empty for selective disclosure",
+		},
+		{
+			name: "proof type of circuit", circuitID: sig, query: `{"proofType": "Iden3SparseMerkleTreeProof"}`,
+			err: "the proofType Iden3SparseMerkleTreeProof of scope 1 is not supported by the circuit credentialAtomicQuerySigV2, expected BJJSignature2021",
+		},
+		{
+			name: "unknown proof type", circuitID: v3, query: `{"proofType": "Ed25519Signature2020"}`,
+			err: "the proofType Ed25519Signature2020 of scope 1 is not supported by the circuit credentialAtomicQueryV3-beta.1, expected BJJSignature2021, Iden3SparseMerkleTreeProof",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateQuery(1, tc.circuitID, jsonToMap(t, tc.query))
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func jsonToMap(t *testing.T, jsonStr string) map[string]interface{} {
+	result := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal([]byte(jsonStr), &result))
+	return result
+}